@@ -0,0 +1,224 @@
+package backtest
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// mockLogger implements logger.LoggerInterface for testing.
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Info(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (m *mockLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+var _ logger.LoggerInterface = (*mockLogger)(nil)
+
+// writeRecording writes a recording directory with the given file contents.
+func writeRecording(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestDataset_LatestAt(t *testing.T) {
+	dir := writeRecording(t, map[string]string{
+		BlocksFile: `{"number":101,"gas_price":"1"}
+{"number":100,"gas_price":"1"}
+`,
+		DepthFile: `{"block":100,"symbol":"ETHUSDC","bids":[["1","1"]],"asks":[["2","1"]]}
+{"block":102,"symbol":"ETHUSDC","bids":[["3","1"]],"asks":[["4","1"]]}
+`,
+	})
+
+	ds, err := LoadDataset(dir)
+	if err != nil {
+		t.Fatalf("LoadDataset failed: %v", err)
+	}
+	if ds.Blocks[0].Number != 100 {
+		t.Errorf("expected blocks sorted by number, got first %d", ds.Blocks[0].Number)
+	}
+
+	tests := []struct {
+		block     uint64
+		wantOK    bool
+		wantBlock uint64
+	}{
+		{block: 99, wantOK: false},
+		{block: 100, wantOK: true, wantBlock: 100},
+		{block: 101, wantOK: true, wantBlock: 100},
+		{block: 105, wantOK: true, wantBlock: 102},
+	}
+	for _, tt := range tests {
+		rec, ok := ds.Depth("ETHUSDC", tt.block)
+		if ok != tt.wantOK || (ok && rec.Block != tt.wantBlock) {
+			t.Errorf("Depth(%d) = block %d, ok %v; want block %d, ok %v", tt.block, rec.Block, ok, tt.wantBlock, tt.wantOK)
+		}
+	}
+}
+
+func TestLoadDataset_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{name: "missing_blocks", files: map[string]string{}},
+		{name: "empty_blocks", files: map[string]string{BlocksFile: ""}},
+		{name: "malformed_line", files: map[string]string{BlocksFile: "{not json}\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadDataset(writeRecording(t, tt.files)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	// Block 100: CEX ask 3000 vs DEX 3100 → profitable.
+	// Block 101: CEX ask moves to 3099 and the quote carries over → 1 USD spread, unprofitable.
+	dir := writeRecording(t, map[string]string{
+		BlocksFile: `{"number":100,"timestamp":1700000000,"gas_price":"1000000000"}
+{"number":101,"timestamp":1700000012,"gas_price":"1000000000"}
+`,
+		DepthFile: `{"block":100,"symbol":"ETHUSDC","bids":[["2999","10"]],"asks":[["3000","10"]]}
+{"block":101,"symbol":"ETHUSDC","bids":[["3098","10"]],"asks":[["3099","10"]]}
+`,
+		QuotesFile: `{"block":100,"token_in":"` + asset.AddrWETHEthereum.Hex() + `","token_out":"` + asset.USDC.Address().Hex() + `","amount_in":"1000000000000000000","amount_out":"3100000000","gas_estimate":150000,"fee_tier":500}
+`,
+	})
+
+	calculator := app.NewProfitCalculator(decimal.NewFromInt(10), decimal.NewFromInt(5))
+	summary, err := Run(context.Background(), Config{
+		Dir: dir,
+		Detector: app.DetectorConfig{
+			Pairs:      []pricingDomain.Pair{pricingDomain.NewPair(asset.ETH, asset.USDC)},
+			TradeSizes: []decimal.Decimal{decimal.NewFromInt(1)},
+		},
+		Calculator: calculator,
+	}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if summary.Blocks != 2 || summary.FirstBlock != 100 || summary.LastBlock != 101 {
+		t.Errorf("expected blocks 100..101 (2), got %d..%d (%d)", summary.FirstBlock, summary.LastBlock, summary.Blocks)
+	}
+	if summary.Opportunities != 1 {
+		t.Fatalf("expected 1 opportunity, got %d", summary.Opportunities)
+	}
+
+	// Gross 100 - fees 3000 * 0.004 = 12 - gas (150k quote estimate + 35k WETH unwrap)
+	// * 1 gwei * 3000 = 0.555, kept at full precision
+	want := decimal.RequireFromString("87.445")
+	if !summary.TotalProfitUSD.Equal(want) {
+		t.Errorf("expected total profit %s, got %s", want, summary.TotalProfitUSD)
+	}
+	if pair := summary.Pairs["ETH-USDC"]; pair == nil || !pair.BestProfitUSD.Equal(want) {
+		t.Errorf("expected ETH-USDC best profit %s, got %+v", want, pair)
+	}
+}
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Replay a fixture as the "live" source, record it, and replay the recording
+	src, err := LoadDataset(writeRecording(t, map[string]string{
+		BlocksFile: `{"number":100,"timestamp":1700000000,"gas_price":"1000000000"}
+{"number":101,"timestamp":1700000012,"gas_price":"1000000000"}
+`,
+		DepthFile: `{"block":100,"symbol":"ETHUSDC","bids":[["2999","10"]],"asks":[["3000","10"]]}
+{"block":101,"symbol":"ETHUSDC","bids":[["3098","10"]],"asks":[["3099","10"]]}
+`,
+		QuotesFile: `{"block":100,"token_in":"` + asset.AddrWETHEthereum.Hex() + `","token_out":"` + asset.USDC.Address().Hex() + `","amount_in":"1000000000000000000","amount_out":"3100000000","gas_estimate":150000,"fee_tier":500}
+`,
+	}))
+	if err != nil {
+		t.Fatalf("LoadDataset failed: %v", err)
+	}
+
+	out := t.TempDir()
+	recorder, err := NewRecorder(RecorderConfig{Dir: out, MaxFileSize: 1}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+
+	clock := &Clock{}
+	chain := NewChain(src, clock)
+	sub := recorder.WrapSubscriber(chain)
+	oracle := recorder.WrapGasOracle(chain)
+	cex := recorder.WrapCEX(NewCEX(src, clock))
+	dex := recorder.WrapDEX(NewDEX(src, clock, asset.DefaultRegistry(), asset.ChainIDEthereum))
+
+	reporter := recorder.WrapReporter(newSummaryReporter(&Clock{}, nil))
+
+	blocks, err := sub.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	// Drive the ports the way the detector does: UpdateBlock, gas price, then prices
+	pair := pricingDomain.NewPair(asset.ETH, asset.USDC)
+	amountIn, _ := new(big.Int).SetString("1000000000000000000", 10)
+	for range 2 {
+		block := <-blocks
+		clock.Set(block.Number)
+		reporter.UpdateBlock(block.Number)
+		if _, err := oracle.GetGasPrice(ctx); err != nil {
+			t.Fatalf("GetGasPrice(%d) failed: %v", block.Number, err)
+		}
+		for _, side := range []pricingDomain.Side{pricingDomain.SideBuy, pricingDomain.SideSell} {
+			if _, err := cex.GetEffectivePrice(ctx, pair, decimal.NewFromInt(1), side); err != nil {
+				t.Fatalf("GetEffectivePrice(%d) failed: %v", block.Number, err)
+			}
+		}
+		if _, err := dex.GetQuote(ctx, asset.AddrWETHEthereum, asset.USDC.Address(), amountIn); err != nil {
+			t.Fatalf("GetQuote(%d) failed: %v", block.Number, err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// A 1-byte limit puts every record in its own file
+	if files, _ := filepath.Glob(filepath.Join(out, "depth-*.jsonl")); len(files) != 2 {
+		t.Errorf("expected 2 rotated depth files, got %d", len(files))
+	}
+
+	got, err := LoadDataset(out)
+	if err != nil {
+		t.Fatalf("LoadDataset(recording) failed: %v", err)
+	}
+	if len(got.Blocks) != 2 || got.Blocks[1].Number != 101 || got.Blocks[1].GasPrice != "1000000000" {
+		t.Errorf("unexpected recorded blocks: %+v", got.Blocks)
+	}
+	if rec, ok := got.Depth("ETHUSDC", 101); !ok || rec.Block != 101 || rec.Asks[0][0] != "3099" {
+		t.Errorf("unexpected recorded depth at 101: %+v (ok %v)", rec, ok)
+	}
+	if rec, ok := got.Quote(asset.AddrWETHEthereum, asset.USDC.Address(), amountIn, 101); !ok || rec.AmountOut != "3100000000" {
+		t.Errorf("unexpected recorded quote at 101: %+v (ok %v)", rec, ok)
+	}
+}
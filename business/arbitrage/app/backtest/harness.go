@@ -0,0 +1,258 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	blockchainApp "github.com/fd1az/arbitrage-bot/business/blockchain/app"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// Config configures a backtest run.
+type Config struct {
+	Dir        string                // Directory with the recorded JSONL files
+	Detector   app.DetectorConfig    // Pairs, trade sizes and per-pair calculators
+	Calculator *app.ProfitCalculator // Default profit calculator
+	Registry   *asset.Registry       // Resolves quote tokens (defaults to asset.DefaultRegistry)
+	ChainID    uint64                // Chain the recorded quotes were taken on (0 = mainnet)
+	Next       app.Reporter          // Optional reporter that also receives every update
+}
+
+// PairSummary aggregates the opportunities found for one route.
+type PairSummary struct {
+	Opportunities int
+	ProfitUSD     decimal.Decimal // Sum of the best opportunity per block
+	BestProfitUSD decimal.Decimal
+}
+
+// Summary is the outcome of a backtest run.
+type Summary struct {
+	Blocks        int
+	FirstBlock    uint64
+	LastBlock     uint64
+	Opportunities int // Profitable opportunities reported (every trade size counts)
+
+	// TotalProfitUSD sums the best opportunity per pair per block, since only
+	// one trade size could actually be executed on a given block.
+	TotalProfitUSD decimal.Decimal
+	Pairs          map[string]*PairSummary
+	Duration       time.Duration
+}
+
+// Write prints a human-readable report of the summary.
+func (s *Summary) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Blocks replayed:\t%d (%d → %d)\n", s.Blocks, s.FirstBlock, s.LastBlock)
+	fmt.Fprintf(tw, "Opportunities:\t%d\n", s.Opportunities)
+	fmt.Fprintf(tw, "Theoretical profit:\t$%s\n", s.TotalProfitUSD.StringFixed(2))
+	fmt.Fprintf(tw, "Duration:\t%s\n", s.Duration.Round(time.Millisecond))
+
+	if len(s.Pairs) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "PAIR\tOPPORTUNITIES\tPROFIT USD\tBEST USD")
+		routes := make([]string, 0, len(s.Pairs))
+		for route := range s.Pairs {
+			routes = append(routes, route)
+		}
+		sort.Strings(routes)
+		for _, route := range routes {
+			p := s.Pairs[route]
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", route, p.Opportunities,
+				p.ProfitUSD.StringFixed(2), p.BestProfitUSD.StringFixed(2))
+		}
+	}
+
+	return tw.Flush()
+}
+
+// Run replays the recorded data in cfg.Dir through an unmodified Detector
+// and returns a summary of the opportunities it reported.
+func Run(ctx context.Context, cfg Config, log logger.LoggerInterface) (*Summary, error) {
+	data, err := LoadDataset(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ChainID == 0 {
+		cfg.ChainID = asset.ChainIDEthereum
+	}
+	if cfg.Registry == nil {
+		cfg.Registry = asset.DefaultRegistry()
+	}
+
+	clock := &Clock{}
+	chain := NewChain(data, clock)
+	blockchain := blockchainApp.NewBlockchainService(chain, chain)
+	pricing := pricingApp.NewPricingService(NewCEX(data, clock), NewDEX(data, clock, cfg.Registry, cfg.ChainID))
+
+	reporter := newSummaryReporter(clock, cfg.Next)
+	detector := app.NewDetector(blockchain, pricing, cfg.Calculator, reporter, cfg.Detector, log)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	if err := detector.Start(runCtx); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-chain.Done():
+	case <-ctx.Done():
+	}
+	cancel()
+
+	if err := detector.Stop(); err != nil {
+		log.Warn(ctx, "failed to stop backtest reporter", "error", err)
+	}
+
+	summary := reporter.summary()
+	summary.Duration = time.Since(start)
+	return summary, ctx.Err()
+}
+
+// summaryReporter implements Reporter by tallying opportunities.
+// It also advances the replay clock: the detector calls UpdateBlock
+// before fetching any prices for a block, on the same goroutine.
+type summaryReporter struct {
+	clock *Clock
+	next  app.Reporter
+
+	mu     sync.Mutex
+	result Summary
+	best   map[string]decimal.Decimal // route -> best net profit on the current block
+}
+
+// Ensure summaryReporter implements Reporter.
+var _ app.Reporter = (*summaryReporter)(nil)
+
+func newSummaryReporter(clock *Clock, next app.Reporter) *summaryReporter {
+	return &summaryReporter{
+		clock: clock,
+		next:  next,
+		result: Summary{
+			TotalProfitUSD: decimal.Zero,
+			Pairs:          make(map[string]*PairSummary),
+		},
+		best: make(map[string]decimal.Decimal),
+	}
+}
+
+func (r *summaryReporter) Start(ctx context.Context) error {
+	if r.next != nil {
+		return r.next.Start(ctx)
+	}
+	return nil
+}
+
+func (r *summaryReporter) Report(opp *domain.Opportunity) {
+	if r.next != nil {
+		r.next.Report(opp)
+	}
+	if opp.Profit == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	route := opp.Route()
+	pair, ok := r.result.Pairs[route]
+	if !ok {
+		pair = &PairSummary{ProfitUSD: decimal.Zero, BestProfitUSD: decimal.Zero}
+		r.result.Pairs[route] = pair
+	}
+	pair.Opportunities++
+	r.result.Opportunities++
+
+	profit := opp.Profit.NetProfitRaw
+	if profit.GreaterThan(pair.BestProfitUSD) {
+		pair.BestProfitUSD = profit
+	}
+
+	// Count only the improvement over this block's best for the route
+	prev, seen := r.best[route]
+	if seen && !profit.GreaterThan(prev) {
+		return
+	}
+	delta := profit
+	if seen {
+		delta = profit.Sub(prev)
+	}
+	r.best[route] = profit
+	pair.ProfitUSD = pair.ProfitUSD.Add(delta)
+	r.result.TotalProfitUSD = r.result.TotalProfitUSD.Add(delta)
+}
+
+func (r *summaryReporter) UpdatePrices(prices *pricingDomain.PriceSnapshot) {
+	if r.next != nil {
+		r.next.UpdatePrices(prices)
+	}
+}
+
+func (r *summaryReporter) UpdateConnectionStatus(name string, connected bool, latency time.Duration) {
+	if r.next != nil {
+		r.next.UpdateConnectionStatus(name, connected, latency)
+	}
+}
+
+func (r *summaryReporter) UpdateBlock(blockNumber uint64) {
+	r.clock.Set(blockNumber)
+
+	r.mu.Lock()
+	if r.result.Blocks == 0 {
+		r.result.FirstBlock = blockNumber
+	}
+	r.result.Blocks++
+	r.result.LastBlock = blockNumber
+	clear(r.best)
+	r.mu.Unlock()
+
+	if r.next != nil {
+		r.next.UpdateBlock(blockNumber)
+	}
+}
+
+func (r *summaryReporter) UpdateGasPrice(gweiPrice float64) {
+	if r.next != nil {
+		r.next.UpdateGasPrice(gweiPrice)
+	}
+}
+
+func (r *summaryReporter) UpdateCostBreakdown(breakdown *app.CostBreakdown) {
+	if r.next != nil {
+		r.next.UpdateCostBreakdown(breakdown)
+	}
+}
+
+func (r *summaryReporter) Stop() error {
+	if r.next != nil {
+		return r.next.Stop()
+	}
+	return nil
+}
+
+// summary returns a copy of the tallied results.
+func (r *summaryReporter) summary() *Summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.result
+	s.Pairs = make(map[string]*PairSummary, len(r.result.Pairs))
+	for route, p := range r.result.Pairs {
+		cp := *p
+		s.Pairs[route] = &cp
+	}
+	return &s
+}
@@ -0,0 +1,449 @@
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	blockchainApp "github.com/fd1az/arbitrage-bot/business/blockchain/app"
+	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// RecorderConfig holds recording settings.
+type RecorderConfig struct {
+	Dir            string        // Output directory (created if missing)
+	MaxFileSize    int64         // Rotate a file once it reaches this many bytes (0 = no limit)
+	RotateInterval time.Duration // Rotate files this often (0 = never)
+}
+
+// Recorder writes live blocks, CEX depth snapshots and DEX quotes to rotated
+// JSONL files that LoadDataset reads back. It wraps the live ports so detection
+// continues unchanged while the data flowing through them is recorded.
+type Recorder struct {
+	blocks *rotatingWriter
+	depth  *rotatingWriter
+	quotes *rotatingWriter
+	logger logger.LoggerInterface
+
+	// Block the recorded prices belong to (the block being analyzed)
+	block atomic.Uint64
+
+	mu      sync.Mutex
+	pending map[uint64]*BlockRecord // Received blocks, written once their gas price is known
+	depthAt map[string]uint64       // symbol -> block of the last recorded snapshot
+}
+
+// NewRecorder creates the output directory and a recorder writing into it.
+func NewRecorder(cfg RecorderConfig, log logger.LoggerInterface) (*Recorder, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, apperror.New(apperror.CodeStorageOpenFailed,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("failed to create recording directory %s", cfg.Dir)))
+	}
+
+	return &Recorder{
+		blocks:  newRotatingWriter(cfg, BlocksFile),
+		depth:   newRotatingWriter(cfg, DepthFile),
+		quotes:  newRotatingWriter(cfg, QuotesFile),
+		logger:  log,
+		pending: make(map[uint64]*BlockRecord),
+		depthAt: make(map[string]uint64),
+	}, nil
+}
+
+// WrapSubscriber records every block delivered by sub.
+func (r *Recorder) WrapSubscriber(sub blockchainApp.BlockSubscriber) blockchainApp.BlockSubscriber {
+	return &recordingSubscriber{BlockSubscriber: sub, recorder: r}
+}
+
+// WrapGasOracle records the gas price fetched for each block alongside it.
+func (r *Recorder) WrapGasOracle(oracle blockchainApp.GasOracle) blockchainApp.GasOracle {
+	return &recordingGasOracle{GasOracle: oracle, recorder: r}
+}
+
+// WrapCEX records the orderbook behind each effective price, once per symbol per block.
+func (r *Recorder) WrapCEX(cex pricingApp.CEXProvider) pricingApp.CEXProvider {
+	return &recordingCEX{CEXProvider: cex, recorder: r}
+}
+
+// WrapReporter tracks the block being analyzed: the detector calls UpdateBlock
+// before fetching the gas price and prices for a block, on the same goroutine.
+func (r *Recorder) WrapReporter(next app.Reporter) app.Reporter {
+	return &recordingReporter{Reporter: next, recorder: r}
+}
+
+// WrapDEX records every successful quote.
+func (r *Recorder) WrapDEX(dex pricingApp.DEXProvider) pricingApp.DEXProvider {
+	return &recordingDEX{DEXProvider: dex, recorder: r}
+}
+
+// Close writes any block still waiting for a gas price and closes the files.
+func (r *Recorder) Close() error {
+	r.flushBefore(math.MaxUint64)
+
+	var firstErr error
+	for _, w := range []*rotatingWriter{r.blocks, r.depth, r.quotes} {
+		if err := w.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// onBlock holds block until its gas price is fetched.
+func (r *Recorder) onBlock(block *blockchainDomain.Block) {
+	rec := &BlockRecord{
+		Number:     block.Number,
+		Hash:       block.Hash,
+		ParentHash: block.ParentHash,
+		Timestamp:  block.Timestamp.Unix(),
+	}
+	if block.BaseFee != nil {
+		rec.BaseFee = block.BaseFee.String()
+	}
+
+	r.mu.Lock()
+	r.pending[rec.Number] = rec
+	r.mu.Unlock()
+}
+
+// onAnalyze attributes subsequent gas and price fetches to block. Older blocks
+// still waiting for a gas price (skipped or failed) are written without one.
+func (r *Recorder) onAnalyze(block uint64) {
+	r.block.Store(block)
+	r.flushBefore(block)
+}
+
+// onGasPrice completes the current block with its gas price and writes it.
+func (r *Recorder) onGasPrice(gasPrice *blockchainDomain.GasPrice) {
+	block := r.block.Load()
+
+	r.mu.Lock()
+	rec, ok := r.pending[block]
+	delete(r.pending, block)
+	r.mu.Unlock()
+
+	if !ok {
+		return // Already written for this block
+	}
+	rec.GasPrice = gasPrice.Wei().String()
+	r.write(r.blocks, rec)
+}
+
+// flushBefore writes pending blocks numbered below block, in order.
+func (r *Recorder) flushBefore(block uint64) {
+	r.mu.Lock()
+	var flush []*BlockRecord
+	for number, rec := range r.pending {
+		if number < block {
+			flush = append(flush, rec)
+			delete(r.pending, number)
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(flush, func(i, j int) bool { return flush[i].Number < flush[j].Number })
+	for _, rec := range flush {
+		r.write(r.blocks, rec)
+	}
+}
+
+// needsDepth reports whether no snapshot of pair was recorded for the current block yet.
+func (r *Recorder) needsDepth(pair pricingDomain.Pair) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last, ok := r.depthAt[depthSymbol(pair)]
+	return !ok || last != r.block.Load()
+}
+
+// depthSymbol is the Binance-style symbol depth records are keyed by (as read by CEX).
+func depthSymbol(pair pricingDomain.Pair) string {
+	return pair.Base.Symbol() + pair.Quote.Symbol()
+}
+
+// recordDepth writes a snapshot unless one was already recorded for the current block.
+func (r *Recorder) recordDepth(ob *pricingDomain.Orderbook) {
+	block := r.block.Load()
+	symbol := depthSymbol(ob.Pair)
+
+	r.mu.Lock()
+	if last, ok := r.depthAt[symbol]; ok && last == block {
+		r.mu.Unlock()
+		return
+	}
+	r.depthAt[symbol] = block
+	r.mu.Unlock()
+
+	r.write(r.depth, DepthRecord{
+		Block:  block,
+		Symbol: symbol,
+		Bids:   toRawLevels(ob.Bids),
+		Asks:   toRawLevels(ob.Asks),
+	})
+}
+
+// recordQuote writes a DEX quote for the current block.
+func (r *Recorder) recordQuote(tokenIn, tokenOut common.Address, amountIn *big.Int, q *pricingDomain.Quote) {
+	rec := QuoteRecord{
+		Block:       r.block.Load(),
+		TokenIn:     tokenIn,
+		TokenOut:    tokenOut,
+		AmountIn:    amountIn.String(),
+		AmountOut:   q.AmountOut.Raw().String(),
+		GasEstimate: q.GasEstimate,
+		FeeTier:     q.FeeTier,
+		Protocol:    q.Protocol,
+	}
+	if q.SqrtPriceX96Before != nil && q.SqrtPriceX96After != nil {
+		rec.SqrtPriceX96Before = q.SqrtPriceX96Before.String()
+		rec.SqrtPriceX96After = q.SqrtPriceX96After.String()
+	}
+	r.write(r.quotes, rec)
+}
+
+// write encodes v as one JSON line; failures are logged so detection is never interrupted.
+func (r *Recorder) write(w *rotatingWriter, v any) {
+	line, err := json.Marshal(v)
+	if err != nil {
+		r.logger.Warn(context.Background(), "failed to encode recording", "file", w.name, "error", err)
+		return
+	}
+	if err := w.WriteLine(line); err != nil {
+		r.logger.Warn(context.Background(), "failed to write recording", "file", w.name, "error", err)
+	}
+}
+
+// toRawLevels converts orderbook levels to [price, quantity] string pairs.
+func toRawLevels(levels []pricingDomain.OrderbookLevel) [][]string {
+	raw := make([][]string, 0, len(levels))
+	for _, l := range levels {
+		raw = append(raw, []string{l.Price.String(), l.Amount.ToDecimal().String()})
+	}
+	return raw
+}
+
+// recordingSubscriber forwards blocks from the wrapped subscriber, recording each.
+type recordingSubscriber struct {
+	blockchainApp.BlockSubscriber
+	recorder *Recorder
+}
+
+func (s *recordingSubscriber) Subscribe(ctx context.Context) (<-chan *blockchainDomain.Block, error) {
+	upstream, err := s.BlockSubscriber.Subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *blockchainDomain.Block)
+	go func() {
+		defer close(out)
+		for block := range upstream {
+			if block != nil {
+				s.recorder.onBlock(block)
+			}
+			select {
+			case out <- block:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// Tip forwards the wrapped subscriber's unconfirmed head channel, if it has one.
+func (s *recordingSubscriber) Tip() <-chan *blockchainDomain.Block {
+	if tip, ok := s.BlockSubscriber.(blockchainApp.TipSubscriber); ok {
+		return tip.Tip()
+	}
+	return nil
+}
+
+// Reorgs forwards the wrapped subscriber's reorg channel, if it has one.
+func (s *recordingSubscriber) Reorgs() <-chan *blockchainDomain.ReorgEvent {
+	if notifier, ok := s.BlockSubscriber.(blockchainApp.ReorgNotifier); ok {
+		return notifier.Reorgs()
+	}
+	return nil
+}
+
+// recordingReporter moves the recorder to each block the detector analyzes.
+type recordingReporter struct {
+	app.Reporter
+	recorder *Recorder
+}
+
+func (r *recordingReporter) UpdateBlock(blockNumber uint64) {
+	r.recorder.onAnalyze(blockNumber)
+	r.Reporter.UpdateBlock(blockNumber)
+}
+
+// recordingGasOracle attaches fetched gas prices to the current block record.
+type recordingGasOracle struct {
+	blockchainApp.GasOracle
+	recorder *Recorder
+}
+
+func (o *recordingGasOracle) GetGasPrice(ctx context.Context) (*blockchainDomain.GasPrice, error) {
+	price, err := o.GasOracle.GetGasPrice(ctx)
+	if err == nil {
+		o.recorder.onGasPrice(price)
+	}
+	return price, err
+}
+
+func (o *recordingGasOracle) GetGasPriceEIP1559(ctx context.Context, baseFee *big.Int) (*blockchainDomain.GasPrice, error) {
+	price, err := o.GasOracle.GetGasPriceEIP1559(ctx, baseFee)
+	if err == nil {
+		o.recorder.onGasPrice(price)
+	}
+	return price, err
+}
+
+// recordingCEX records the book behind effective price requests.
+type recordingCEX struct {
+	pricingApp.CEXProvider
+	recorder *Recorder
+}
+
+func (c *recordingCEX) GetOrderbook(ctx context.Context, pair pricingDomain.Pair) (*pricingDomain.Orderbook, error) {
+	ob, err := c.CEXProvider.GetOrderbook(ctx, pair)
+	if err == nil {
+		c.recorder.recordDepth(ob)
+	}
+	return ob, err
+}
+
+func (c *recordingCEX) GetEffectivePrice(ctx context.Context, pair pricingDomain.Pair, size decimal.Decimal, side pricingDomain.Side) (*pricingDomain.Price, error) {
+	price, err := c.CEXProvider.GetEffectivePrice(ctx, pair, size, side)
+	if err != nil {
+		return nil, err
+	}
+	// The provider reads its book internally; fetch it once per block for the recording
+	if c.recorder.needsDepth(pair) {
+		if _, err := c.GetOrderbook(ctx, pair); err != nil {
+			c.recorder.logger.Debug(ctx, "failed to record orderbook", "pair", pair.String(), "error", err)
+		}
+	}
+	return price, nil
+}
+
+// recordingDEX records successful quotes.
+type recordingDEX struct {
+	pricingApp.DEXProvider
+	recorder *Recorder
+}
+
+func (d *recordingDEX) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*pricingDomain.Quote, error) {
+	quote, err := d.DEXProvider.GetQuote(ctx, tokenIn, tokenOut, amountIn)
+	if err == nil {
+		d.recorder.recordQuote(tokenIn, tokenOut, amountIn, quote)
+	}
+	return quote, err
+}
+
+// rotatingWriter appends lines to name-<timestamp>-<seq>.jsonl, starting a
+// new file when the size limit or rotation interval is reached.
+type rotatingWriter struct {
+	dir      string
+	name     string
+	maxSize  int64
+	interval time.Duration
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+	seq    int
+}
+
+func newRotatingWriter(cfg RecorderConfig, name string) *rotatingWriter {
+	return &rotatingWriter{
+		dir:      cfg.Dir,
+		name:     name,
+		maxSize:  cfg.MaxFileSize,
+		interval: cfg.RotateInterval,
+	}
+}
+
+// WriteLine appends line and a newline, rotating first when due.
+func (w *rotatingWriter) WriteLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil || w.dueForRotation(int64(len(line))+1) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.file.Write(append(line, '\n'))
+	w.size += int64(n)
+	return err
+}
+
+// dueForRotation reports whether writing n more bytes should start a new file.
+// A file always takes at least one line, so oversized records still get written.
+func (w *rotatingWriter) dueForRotation(n int64) bool {
+	if w.maxSize > 0 && w.size > 0 && w.size+n > w.maxSize {
+		return true
+	}
+	return w.interval > 0 && time.Since(w.opened) >= w.interval
+}
+
+// rotate closes the current file and opens the next one.
+func (w *rotatingWriter) rotate() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+
+	w.seq++
+	now := time.Now().UTC()
+	path := filepath.Join(w.dir, fmt.Sprintf("%s-%s-%04d%s",
+		strings.TrimSuffix(w.name, fileExt), now.Format("20060102T150405"), w.seq, fileExt))
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return apperror.New(apperror.CodeStorageWriteFailed,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("failed to open recording file %s", path)))
+	}
+
+	w.file = f
+	w.size = 0
+	w.opened = now
+	return nil
+}
+
+// Close closes the current file.
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
@@ -0,0 +1,236 @@
+// Package backtest replays recorded market data through the arbitrage detector.
+//
+// A backtest directory holds three JSONL files, one record per line:
+//
+//	blocks.jsonl  {"number":19000000,"hash":"0x..","timestamp":1700000000,"base_fee":"30000000000","gas_price":"32000000000"}
+//	depth.jsonl   {"block":19000000,"symbol":"ETHUSDC","bids":[["3400.10","1.5"]],"asks":[["3400.20","2.0"]]}
+//	quotes.jsonl  {"block":19000000,"token_in":"0x..","token_out":"0x..","amount_in":"1000000000000000000","amount_out":"3401000000","gas_estimate":150000,"fee_tier":500}
+//
+// Each kind may also be split into rotated files named like
+// blocks-20250101T120000-0001.jsonl (as written by Recorder); they are read in
+// name order. Depth snapshots and quotes apply from their block until a newer
+// record for the same symbol (or token pair and amount) replaces them, so a
+// recording only needs to store changes.
+package backtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// Recorded data file names inside a backtest directory.
+const (
+	BlocksFile = "blocks.jsonl"
+	DepthFile  = "depth.jsonl"
+	QuotesFile = "quotes.jsonl"
+
+	fileExt = ".jsonl"
+)
+
+// maxLineSize bounds a single JSONL record (deep orderbook snapshots are large).
+const maxLineSize = 4 << 20
+
+// BlockRecord is a recorded block header with the gas price observed at that block.
+type BlockRecord struct {
+	Number     uint64      `json:"number"`
+	Hash       common.Hash `json:"hash"`
+	ParentHash common.Hash `json:"parent_hash"`
+	Timestamp  int64       `json:"timestamp"`          // Unix seconds
+	BaseFee    string      `json:"base_fee,omitempty"` // Wei, empty before London
+	GasPrice   string      `json:"gas_price"`          // Wei
+}
+
+// DepthRecord is a recorded Binance depth snapshot (price/quantity string pairs).
+type DepthRecord struct {
+	Block  uint64     `json:"block"`
+	Symbol string     `json:"symbol"` // Binance symbol, e.g. ETHUSDC
+	Bids   [][]string `json:"bids"`
+	Asks   [][]string `json:"asks"`
+}
+
+// QuoteRecord is a recorded Uniswap quote. Amounts are raw token units.
+type QuoteRecord struct {
+	Block              uint64         `json:"block"`
+	TokenIn            common.Address `json:"token_in"`
+	TokenOut           common.Address `json:"token_out"`
+	AmountIn           string         `json:"amount_in"`
+	AmountOut          string         `json:"amount_out"`
+	GasEstimate        uint64         `json:"gas_estimate"`
+	FeeTier            int            `json:"fee_tier"`
+	Protocol           string         `json:"protocol,omitempty"`
+	SqrtPriceX96Before string         `json:"sqrt_price_x96_before,omitempty"`
+	SqrtPriceX96After  string         `json:"sqrt_price_x96_after,omitempty"`
+}
+
+// Dataset is a loaded backtest directory, indexed for lookups by block.
+type Dataset struct {
+	Blocks []BlockRecord
+
+	// Records per key, sorted by block
+	depth  map[string][]DepthRecord
+	quotes map[string][]QuoteRecord
+}
+
+// LoadDataset reads the recorded files in dir. At least one block must be
+// recorded; depth and quote files may be absent (every lookup then misses).
+func LoadDataset(dir string) (*Dataset, error) {
+	ds := &Dataset{
+		depth:  make(map[string][]DepthRecord),
+		quotes: make(map[string][]QuoteRecord),
+	}
+
+	if err := readFiles(dir, BlocksFile, func(b BlockRecord) {
+		ds.Blocks = append(ds.Blocks, b)
+	}); err != nil {
+		return nil, err
+	}
+	if len(ds.Blocks) == 0 {
+		return nil, apperror.New(apperror.CodeInvalidInput,
+			apperror.WithContext(fmt.Sprintf("no blocks recorded in %s", dir)))
+	}
+	sort.SliceStable(ds.Blocks, func(i, j int) bool { return ds.Blocks[i].Number < ds.Blocks[j].Number })
+
+	if err := readFiles(dir, DepthFile, func(d DepthRecord) {
+		ds.depth[d.Symbol] = append(ds.depth[d.Symbol], d)
+	}); err != nil {
+		return nil, err
+	}
+	for _, records := range ds.depth {
+		sort.SliceStable(records, func(i, j int) bool { return records[i].Block < records[j].Block })
+	}
+
+	if err := readFiles(dir, QuotesFile, func(q QuoteRecord) {
+		key := quoteKey(q.TokenIn, q.TokenOut, q.AmountIn)
+		ds.quotes[key] = append(ds.quotes[key], q)
+	}); err != nil {
+		return nil, err
+	}
+	for _, records := range ds.quotes {
+		sort.SliceStable(records, func(i, j int) bool { return records[i].Block < records[j].Block })
+	}
+
+	return ds, nil
+}
+
+// Depth returns the latest depth snapshot for symbol recorded at or before block.
+func (ds *Dataset) Depth(symbol string, block uint64) (DepthRecord, bool) {
+	return latestAt(ds.depth[symbol], block, func(d DepthRecord) uint64 { return d.Block })
+}
+
+// Quote returns the latest quote for the swap recorded at or before block.
+func (ds *Dataset) Quote(tokenIn, tokenOut common.Address, amountIn *big.Int, block uint64) (QuoteRecord, bool) {
+	return latestAt(ds.quotes[quoteKey(tokenIn, tokenOut, amountIn.String())], block, func(q QuoteRecord) uint64 { return q.Block })
+}
+
+// latestAt returns the last record whose block is <= block.
+func latestAt[T any](records []T, block uint64, blockOf func(T) uint64) (T, bool) {
+	i := sort.Search(len(records), func(i int) bool { return blockOf(records[i]) > block })
+	if i == 0 {
+		var zero T
+		return zero, false
+	}
+	return records[i-1], true
+}
+
+// quoteKey identifies a recorded swap by direction and exact input amount.
+func quoteKey(tokenIn, tokenOut common.Address, amountIn string) string {
+	return tokenIn.Hex() + ":" + tokenOut.Hex() + ":" + amountIn
+}
+
+// readFiles reads name and its rotated parts (name-*.jsonl) in name order.
+func readFiles[T any](dir, name string, fn func(T)) error {
+	base := strings.TrimSuffix(name, fileExt)
+	rotated, err := filepath.Glob(filepath.Join(dir, base+"-*"+fileExt))
+	if err != nil {
+		return fmt.Errorf("failed to list %s files: %w", base, err)
+	}
+	sort.Strings(rotated)
+
+	paths := rotated
+	if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+		paths = append([]string{filepath.Join(dir, name)}, rotated...)
+	}
+
+	for _, path := range paths {
+		if err := readJSONL(path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readJSONL decodes each line of path into a T and passes it to fn.
+func readJSONL[T any](path string, fn func(T)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return apperror.New(apperror.CodeInvalidInput,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("failed to open %s", path)))
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record T
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return apperror.New(apperror.CodeInvalidFormat,
+				apperror.WithCause(err),
+				apperror.WithContext(fmt.Sprintf("%s:%d", path, line)))
+		}
+		fn(record)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseBig parses a base-10 integer string; empty yields nil.
+func parseBig(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return v, nil
+}
+
+// parseLevels parses [price, quantity] string pairs.
+func parseLevels(raw [][]string) ([][2]decimal.Decimal, error) {
+	levels := make([][2]decimal.Decimal, 0, len(raw))
+	for _, entry := range raw {
+		if len(entry) < 2 {
+			return nil, fmt.Errorf("invalid level %v", entry)
+		}
+		price, err := decimal.NewFromString(entry[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid price %q: %w", entry[0], err)
+		}
+		qty, err := decimal.NewFromString(entry[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q: %w", entry[1], err)
+		}
+		levels = append(levels, [2]decimal.Decimal{price, qty})
+	}
+	return levels, nil
+}
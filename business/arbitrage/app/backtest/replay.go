@@ -0,0 +1,289 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+
+	blockchainApp "github.com/fd1az/arbitrage-bot/business/blockchain/app"
+	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// Ensure the replay adapters implement the live ports.
+var (
+	_ blockchainApp.BlockSubscriber = (*Chain)(nil)
+	_ blockchainApp.GasOracle       = (*Chain)(nil)
+	_ pricingApp.CEXProvider        = (*CEX)(nil)
+	_ pricingApp.DEXProvider        = (*DEX)(nil)
+)
+
+// Clock holds the block the detector is currently processing.
+// Price lookups return the data recorded at or before it.
+type Clock struct {
+	block atomic.Uint64
+}
+
+// Set moves the clock to block.
+func (c *Clock) Set(block uint64) {
+	c.block.Store(block)
+}
+
+// Block returns the current block.
+func (c *Clock) Block() uint64 {
+	return c.block.Load()
+}
+
+// Chain replays recorded blocks and gas prices.
+type Chain struct {
+	data  *Dataset
+	clock *Clock
+	done  chan struct{}
+}
+
+// NewChain creates a block replayer over data.
+func NewChain(data *Dataset, clock *Clock) *Chain {
+	return &Chain{
+		data:  data,
+		clock: clock,
+		done:  make(chan struct{}),
+	}
+}
+
+// Subscribe emits every recorded block in order. The channel is unbuffered,
+// so each send waits for the consumer to finish the previous block.
+func (c *Chain) Subscribe(ctx context.Context) (<-chan *blockchainDomain.Block, error) {
+	blocks := make([]*blockchainDomain.Block, 0, len(c.data.Blocks))
+	for _, rec := range c.data.Blocks {
+		block, err := rec.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	ch := make(chan *blockchainDomain.Block)
+	go func() {
+		defer close(c.done)
+		for _, block := range blocks {
+			select {
+			case ch <- block:
+			case <-ctx.Done():
+				return
+			}
+		}
+		// The detector ignores nil blocks; this send only completes once the
+		// last real block has been fully processed.
+		select {
+		case ch <- nil:
+		case <-ctx.Done():
+		}
+	}()
+
+	return ch, nil
+}
+
+// Done is closed once every recorded block has been processed.
+func (c *Chain) Done() <-chan struct{} {
+	return c.done
+}
+
+// LatestBlock returns the last recorded block.
+func (c *Chain) LatestBlock(ctx context.Context) (*blockchainDomain.Block, error) {
+	return c.data.Blocks[len(c.data.Blocks)-1].toDomain()
+}
+
+// State always reports connected.
+func (c *Chain) State() blockchainDomain.ConnectionState {
+	return blockchainDomain.StateConnected
+}
+
+// GetGasPrice returns the gas price recorded for the current block.
+func (c *Chain) GetGasPrice(ctx context.Context) (*blockchainDomain.GasPrice, error) {
+	block := c.clock.Block()
+	rec, ok := latestAt(c.data.Blocks, block, func(b BlockRecord) uint64 { return b.Number })
+	if !ok || rec.Number != block {
+		return nil, apperror.New(apperror.CodeBlockNotFound,
+			apperror.WithContext(fmt.Sprintf("block %d not recorded", block)))
+	}
+	wei, err := parseBig(rec.GasPrice)
+	if err != nil || wei == nil {
+		return nil, apperror.New(apperror.CodeGasEstimationFailed,
+			apperror.WithContext(fmt.Sprintf("no gas price recorded for block %d", block)))
+	}
+	return blockchainDomain.NewGasPrice(wei), nil
+}
+
+// GetGasPriceEIP1559 returns the recorded gas price (already the effective fee).
+func (c *Chain) GetGasPriceEIP1559(ctx context.Context, baseFee *big.Int) (*blockchainDomain.GasPrice, error) {
+	return c.GetGasPrice(ctx)
+}
+
+// EstimateGas is not available from recorded data.
+func (c *Chain) EstimateGas(ctx context.Context, data []byte, to string) (uint64, error) {
+	return 0, apperror.New(apperror.CodeGasEstimationFailed,
+		apperror.WithContext("gas estimation is not available in backtest mode"))
+}
+
+// toDomain converts a recorded block to a domain block.
+func (b BlockRecord) toDomain() (*blockchainDomain.Block, error) {
+	baseFee, err := parseBig(b.BaseFee)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInvalidFormat,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("block %d base_fee", b.Number)))
+	}
+	return &blockchainDomain.Block{
+		Number:     b.Number,
+		Hash:       b.Hash,
+		ParentHash: b.ParentHash,
+		Timestamp:  time.Unix(b.Timestamp, 0),
+		BaseFee:    baseFee,
+	}, nil
+}
+
+// CEX serves recorded Binance depth snapshots.
+type CEX struct {
+	data  *Dataset
+	clock *Clock
+}
+
+// NewCEX creates a CEX provider over recorded depth snapshots.
+func NewCEX(data *Dataset, clock *Clock) *CEX {
+	return &CEX{data: data, clock: clock}
+}
+
+// GetOrderbook returns the snapshot in effect at the current block.
+func (c *CEX) GetOrderbook(ctx context.Context, pair pricingDomain.Pair) (*pricingDomain.Orderbook, error) {
+	symbol := depthSymbol(pair)
+	rec, ok := c.data.Depth(symbol, c.clock.Block())
+	if !ok {
+		return nil, apperror.New(apperror.CodeOrderbookFetchFailed,
+			apperror.WithContext(fmt.Sprintf("no depth recorded for %s at block %d", symbol, c.clock.Block())))
+	}
+
+	bids, err := toLevels(pair.Base, rec.Bids)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook, apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("%s bids at block %d", symbol, rec.Block)))
+	}
+	asks, err := toLevels(pair.Base, rec.Asks)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook, apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("%s asks at block %d", symbol, rec.Block)))
+	}
+
+	return &pricingDomain.Orderbook{Pair: pair, Bids: bids, Asks: asks}, nil
+}
+
+// GetEffectivePrice walks the recorded book and returns the volume-weighted price for size.
+func (c *CEX) GetEffectivePrice(ctx context.Context, pair pricingDomain.Pair, size decimal.Decimal, side pricingDomain.Side) (*pricingDomain.Price, error) {
+	ob, err := c.GetOrderbook(ctx, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := ob.Bids // Sell into bids
+	if side == pricingDomain.SideBuy {
+		levels = ob.Asks // Buy from asks
+	}
+
+	remaining := size
+	totalCost := decimal.Zero
+	totalFilled := decimal.Zero
+	for _, level := range levels {
+		if remaining.IsZero() {
+			break
+		}
+		fillQty := decimal.Min(remaining, level.Amount.ToDecimal())
+		totalCost = totalCost.Add(fillQty.Mul(level.Price))
+		totalFilled = totalFilled.Add(fillQty)
+		remaining = remaining.Sub(fillQty)
+	}
+
+	if totalFilled.IsZero() {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithContext("no liquidity"))
+	}
+
+	sizeAmount, _ := asset.ParseDecimal(pair.Base, totalFilled)
+	rate := asset.NewPriceNow(pair.Base, pair.Quote, totalCost.Div(totalFilled))
+	price := pricingDomain.NewPrice(rate, sizeAmount, side, pricingDomain.VenueBinance)
+	return &price, nil
+}
+
+// toLevels converts recorded [price, quantity] pairs to orderbook levels.
+func toLevels(base *asset.Asset, raw [][]string) ([]pricingDomain.OrderbookLevel, error) {
+	parsed, err := parseLevels(raw)
+	if err != nil {
+		return nil, err
+	}
+	levels := make([]pricingDomain.OrderbookLevel, 0, len(parsed))
+	for _, l := range parsed {
+		amount, err := asset.ParseDecimal(base, l[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %s: %w", l[1], err)
+		}
+		levels = append(levels, pricingDomain.OrderbookLevel{Price: l[0], Amount: amount})
+	}
+	return levels, nil
+}
+
+// DEX serves recorded Uniswap quotes.
+type DEX struct {
+	data     *Dataset
+	clock    *Clock
+	registry *asset.Registry
+	chainID  uint64
+}
+
+// NewDEX creates a DEX provider over quotes recorded on chainID.
+func NewDEX(data *Dataset, clock *Clock, registry *asset.Registry, chainID uint64) *DEX {
+	return &DEX{data: data, clock: clock, registry: registry, chainID: chainID}
+}
+
+// GetQuote returns the quote recorded for the exact swap at or before the current block.
+func (d *DEX) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*pricingDomain.Quote, error) {
+	rec, ok := d.data.Quote(tokenIn, tokenOut, amountIn, d.clock.Block())
+	if !ok {
+		return nil, apperror.New(apperror.CodeUniswapQuoteFailed,
+			apperror.WithContext(fmt.Sprintf("no quote recorded for %s %s->%s at block %d",
+				amountIn, tokenIn.Hex(), tokenOut.Hex(), d.clock.Block())))
+	}
+
+	amountOut, err := parseBig(rec.AmountOut)
+	if err != nil || amountOut == nil {
+		return nil, apperror.New(apperror.CodeInvalidQuote,
+			apperror.WithContext(fmt.Sprintf("invalid amount_out %q at block %d", rec.AmountOut, rec.Block)))
+	}
+
+	assetIn := d.resolveAsset(tokenIn)
+	assetOut := d.resolveAsset(tokenOut)
+	quote := pricingDomain.NewQuote(assetIn, assetOut,
+		asset.NewAmount(assetIn, amountIn), asset.NewAmount(assetOut, amountOut),
+		rec.GasEstimate, rec.FeeTier)
+	quote.Protocol = rec.Protocol
+	if quote.Protocol == "" {
+		quote.Protocol = pricingDomain.ProtocolUniswapV3
+	}
+	quote.SqrtPriceX96Before, _ = parseBig(rec.SqrtPriceX96Before)
+	quote.SqrtPriceX96After, _ = parseBig(rec.SqrtPriceX96After)
+
+	return &quote, nil
+}
+
+// resolveAsset looks the token up in the registry, falling back to a generic 18-decimal ERC20.
+func (d *DEX) resolveAsset(addr common.Address) *asset.Asset {
+	if a, ok := d.registry.GetToken(d.chainID, addr); ok {
+		return a
+	}
+	return asset.NewAsset(asset.NewTokenAssetID(d.chainID, addr), addr.Hex()[:8], 18)
+}
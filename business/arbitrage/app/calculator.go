@@ -2,6 +2,8 @@
 package app
 
 import (
+	"sync"
+
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
 	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
 	"github.com/fd1az/arbitrage-bot/internal/asset"
@@ -14,6 +16,8 @@ var (
 	UniswapFeeBps = decimal.NewFromFloat(0.003)
 	// Binance spot trading fee (~0.1% = 10 bps)
 	BinanceFeeBps = decimal.NewFromFloat(0.001)
+	// Binance spot maker fee for resting limit orders (~0.08% = 8 bps)
+	BinanceMakerFeeBps = decimal.NewFromFloat(0.0008)
 	// Total round-trip fees
 	TotalFeeRate = UniswapFeeBps.Add(BinanceFeeBps)
 )
@@ -22,6 +26,27 @@ var (
 type ProfitCalculator struct {
 	minProfitBps decimal.Decimal
 	minProfitUSD decimal.Decimal
+	thresholdsMu sync.RWMutex   // Thresholds can be hot-reloaded
+	slippage     *SlippageModel // Optional; nil charges no slippage
+	cexFees      *cexFees       // Shared with WithThresholds copies
+	fixedCosts   FixedCosts
+}
+
+// FixedCosts are per-trade overheads charged on top of swap gas and trading
+// fees. Zero values charge nothing.
+type FixedCosts struct {
+	ApprovalGas         uint64          // Gas for the token approval transaction
+	CEXWithdrawalFeeUSD decimal.Decimal // Network fee to withdraw from the CEX
+	CEXDepositFeeUSD    decimal.Decimal // Fee to deposit back onto the CEX
+}
+
+// cexFees holds the account's actual CEX fee rates; until set, the defaults
+// apply.
+type cexFees struct {
+	mu    sync.RWMutex
+	set   bool
+	taker decimal.Decimal
+	maker decimal.Decimal
 }
 
 // NewProfitCalculator creates a new ProfitCalculator with thresholds.
@@ -29,40 +54,161 @@ func NewProfitCalculator(minProfitBps, minProfitUSD decimal.Decimal) *ProfitCalc
 	return &ProfitCalculator{
 		minProfitBps: minProfitBps,
 		minProfitUSD: minProfitUSD,
+		cexFees:      &cexFees{},
 	}
 }
 
+// WithThresholds returns a copy of the calculator with different profit
+// thresholds, sharing its slippage model (used for per-pair overrides).
+func (c *ProfitCalculator) WithThresholds(minProfitBps, minProfitUSD decimal.Decimal) *ProfitCalculator {
+	clone := NewProfitCalculator(minProfitBps, minProfitUSD)
+	clone.slippage = c.slippage
+	clone.cexFees = c.cexFees
+	clone.fixedCosts = c.fixedCosts
+	return clone
+}
+
+// SetFixedCosts sets the overheads Calculate adds to every trade's costs.
+func (c *ProfitCalculator) SetFixedCosts(costs FixedCosts) {
+	c.fixedCosts = costs
+}
+
+// overheads prices the fixed costs; approval gas is charged at the same
+// gas and ETH price as the swap.
+func (c *ProfitCalculator) overheads(gasCost *domain.GasCost) domain.Overheads {
+	o := domain.Overheads{
+		WithdrawalFeeUSD: c.fixedCosts.CEXWithdrawalFeeUSD,
+		DepositFeeUSD:    c.fixedCosts.CEXDepositFeeUSD,
+	}
+	if c.fixedCosts.ApprovalGas > 0 && gasCost != nil && gasCost.GasLimit > 0 {
+		o.ApprovalGasUSD = gasCost.ExactUSD.
+			Mul(decimal.NewFromInt(int64(c.fixedCosts.ApprovalGas))).
+			Div(decimal.NewFromInt(int64(gasCost.GasLimit)))
+	}
+	return o
+}
+
+// SetThresholds replaces the profit thresholds (e.g. on config reload).
+func (c *ProfitCalculator) SetThresholds(minProfitBps, minProfitUSD decimal.Decimal) {
+	c.thresholdsMu.Lock()
+	defer c.thresholdsMu.Unlock()
+	c.minProfitBps = minProfitBps
+	c.minProfitUSD = minProfitUSD
+}
+
+// thresholds returns the current min profit bps and USD.
+func (c *ProfitCalculator) thresholds() (minProfitBps, minProfitUSD decimal.Decimal) {
+	c.thresholdsMu.RLock()
+	defer c.thresholdsMu.RUnlock()
+	return c.minProfitBps, c.minProfitUSD
+}
+
+// SetCEXFees replaces the default Binance taker and maker fee rates with the
+// account's actual rates (fractions, e.g. 0.001 = 0.1%). Zero is a real rate,
+// e.g. for a zero-fee promotion.
+func (c *ProfitCalculator) SetCEXFees(taker, maker decimal.Decimal) {
+	c.cexFees.mu.Lock()
+	defer c.cexFees.mu.Unlock()
+	c.cexFees.set = true
+	c.cexFees.taker = taker
+	c.cexFees.maker = maker
+}
+
+// CEXFees returns the CEX taker and maker fee rates in use.
+func (c *ProfitCalculator) CEXFees() (taker, maker decimal.Decimal) {
+	c.cexFees.mu.RLock()
+	defer c.cexFees.mu.RUnlock()
+	if !c.cexFees.set {
+		return BinanceFeeBps, BinanceMakerFeeBps
+	}
+	return c.cexFees.taker, c.cexFees.maker
+}
+
+// SetSlippageModel enables price-impact slippage in CalculateWithSlippage.
+func (c *ProfitCalculator) SetSlippageModel(m *SlippageModel) {
+	c.slippage = m
+}
+
+// SlippageModel returns the configured slippage model (nil if disabled).
+func (c *ProfitCalculator) SlippageModel() *SlippageModel {
+	return c.slippage
+}
+
 // Calculate computes the profit for a potential arbitrage opportunity.
-// Includes all costs: gas + exchange fees (Uniswap 0.3% + Binance 0.1%)
+// Includes all costs: gas + exchange fees (Uniswap 0.3% + Binance 0.1% taker,
+// 0.08% maker) + fixed overheads. A maker execution measures the spread against
+// exec.BestPrice instead of the VWAP in spread.CEXPrice.
 func (c *ProfitCalculator) Calculate(
 	spread pricingDomain.Spread,
 	tradeSize decimal.Decimal,
 	tradeValueUSD decimal.Decimal,
 	gasCost *domain.GasCost,
+	exec domain.Execution,
+) *domain.ProfitResult {
+	return c.calculate(spread, tradeSize, tradeValueUSD, gasCost, decimal.Zero, exec)
+}
+
+// CalculateWithSlippage is Calculate with the slippage model's price-impact
+// cost deducted from gross profit. Without a model it equals Calculate.
+func (c *ProfitCalculator) CalculateWithSlippage(
+	spread pricingDomain.Spread,
+	tradeSize decimal.Decimal,
+	tradeValueUSD decimal.Decimal,
+	gasCost *domain.GasCost,
+	priceImpactBps decimal.Decimal,
+	exec domain.Execution,
 ) *domain.ProfitResult {
-	// Gross profit = |price difference| × quantity
+	slippageCost := c.slippage.Cost(tradeValueUSD, priceImpactBps)
+	result := c.calculate(spread, tradeSize, tradeValueUSD, gasCost, slippageCost, exec)
+	result.PriceImpactBps = priceImpactBps
+	result.SlippageCost = slippageCost
+	return result
+}
+
+func (c *ProfitCalculator) calculate(
+	spread pricingDomain.Spread,
+	tradeSize decimal.Decimal,
+	tradeValueUSD decimal.Decimal,
+	gasCost *domain.GasCost,
+	slippageCost decimal.Decimal,
+	exec domain.Execution,
+) *domain.ProfitResult {
+	// A resting maker order fills at the top of book, not through it
+	takerFee, makerFee := c.CEXFees()
+	feeRate := UniswapFeeBps.Add(takerFee)
+	mode := domain.ExecutionTaker
+	if exec.IsMaker() {
+		spread = pricingDomain.CalculateSpread(exec.BestPrice, spread.DEXPrice)
+		feeRate = UniswapFeeBps.Add(makerFee)
+		mode = domain.ExecutionMaker
+	}
+
+	// Gross profit = |price difference| × quantity - slippage
 	// spread.Absolute is DEX-CEX, can be negative when DEX is cheaper
-	grossProfit := spread.Absolute.Abs().Mul(tradeSize)
+	grossProfit := spread.Absolute.Abs().Mul(tradeSize).Sub(slippageCost)
 
-	// Exchange fees = trade value × fee rate (0.4% total)
-	exchangeFees := tradeValueUSD.Mul(TotalFeeRate)
+	// Exchange fees = trade value × fee rate (0.4% total as taker)
+	exchangeFees := tradeValueUSD.Mul(feeRate)
 
 	// Gas cost in USD
-	gasCostUSD := gasCost.TotalUSD.ToDecimal()
+	gasCostUSD := gasCost.ExactUSD
 
-	// Total costs = gas + exchange fees
-	totalCosts := gasCostUSD.Add(exchangeFees)
+	// Total costs = gas + exchange fees + approval and CEX transfer overheads
+	overheads := c.overheads(gasCost)
+	totalCosts := gasCostUSD.Add(exchangeFees).Add(overheads.Total())
 
 	// Use the domain helper that handles decimal -> Amount conversion
-	result := domain.NewProfitResultWithFees(grossProfit, gasCostUSD, exchangeFees, asset.USD)
+	result := domain.NewProfitResultWithOverheads(grossProfit, gasCostUSD, exchangeFees, overheads, asset.USD)
+	result.ExecutionMode = mode
 
 	// Check if meets minimum thresholds
-	meetsThresholds := spread.BasisPoints.Abs().GreaterThanOrEqual(c.minProfitBps) &&
-		result.NetProfit.ToDecimal().GreaterThanOrEqual(c.minProfitUSD)
+	minProfitBps, minProfitUSD := c.thresholds()
+	meetsThresholds := spread.BasisPoints.Abs().GreaterThanOrEqual(minProfitBps) &&
+		result.NetProfitRaw.Abs().GreaterThanOrEqual(minProfitUSD)
 
 	// In production (positive thresholds), also require gross > costs
 	// In testing (negative thresholds), allow all opportunities through
-	if c.minProfitBps.IsNegative() || c.minProfitUSD.IsNegative() {
+	if minProfitBps.IsNegative() || minProfitUSD.IsNegative() {
 		// Testing mode: only check thresholds
 		result.IsProfitable = meetsThresholds
 	} else {
@@ -72,3 +218,53 @@ func (c *ProfitCalculator) Calculate(
 
 	return result
 }
+
+// LegFeeRate returns the trading fee rate for a leg executed on the given venue.
+func LegFeeRate(venue string) decimal.Decimal {
+	if venue == pricingDomain.VenueUniswap {
+		return UniswapFeeBps
+	}
+	return BinanceFeeBps
+}
+
+// CalculateCycle computes the profit for a multi-leg (triangular) cycle.
+// Exchange fees are charged per leg at the leg venue's rate and gasCost is
+// charged once for every DEX leg. Values are in USD.
+func (c *ProfitCalculator) CalculateCycle(
+	legs []domain.Leg,
+	startValueUSD decimal.Decimal,
+	endValueUSD decimal.Decimal,
+	gasCost *domain.GasCost,
+) *domain.ProfitResult {
+	// Gross profit keeps its sign: a cycle only pays in one direction
+	grossProfit := endValueUSD.Sub(startValueUSD)
+
+	exchangeFees := decimal.Zero
+	gasCostUSD := decimal.Zero
+	for _, leg := range legs {
+		exchangeFees = exchangeFees.Add(startValueUSD.Mul(LegFeeRate(leg.Venue)))
+		if leg.Venue == pricingDomain.VenueUniswap {
+			gasCostUSD = gasCostUSD.Add(gasCost.ExactUSD)
+		}
+	}
+
+	result := domain.NewProfitResultWithFees(grossProfit, gasCostUSD, exchangeFees, asset.USD)
+
+	returnBps := decimal.Zero
+	if !startValueUSD.IsZero() {
+		returnBps = grossProfit.Div(startValueUSD).Mul(decimal.NewFromInt(10000))
+	}
+
+	minProfitBps, minProfitUSD := c.thresholds()
+	meetsThresholds := returnBps.GreaterThanOrEqual(minProfitBps) &&
+		result.NetProfitRaw.GreaterThanOrEqual(minProfitUSD)
+
+	if minProfitBps.IsNegative() || minProfitUSD.IsNegative() {
+		// Testing mode: only check thresholds
+		result.IsProfitable = meetsThresholds
+	} else {
+		result.IsProfitable = meetsThresholds && result.NetProfitRaw.IsPositive()
+	}
+
+	return result
+}
@@ -227,7 +227,7 @@ func TestProfitCalculator_Calculate(t *testing.T) {
 			gasCost := makeGasCost(tt.gasLimit, tt.gasPriceGwei, tt.ethPriceUSD)
 
 			// Calculate
-			result := calc.Calculate(spread, tradeSize, tradeValueUSD, gasCost)
+			result := calc.Calculate(spread, tradeSize, tradeValueUSD, gasCost, domain.Execution{})
 
 			// Check profitability
 			if result.IsProfitable != tt.wantProfitable {
@@ -312,11 +312,11 @@ func TestProfitCalculator_GrossProfit_UsesAbsoluteSpread(t *testing.T) {
 
 	// Test with negative spread (DEX cheaper)
 	spreadNeg := makeSpread("3400", "3350") // DEX $50 cheaper, spread = -50
-	result1 := calc.Calculate(spreadNeg, decimal.NewFromInt(10), decimal.NewFromInt(34000), gasCost)
+	result1 := calc.Calculate(spreadNeg, decimal.NewFromInt(10), decimal.NewFromInt(34000), gasCost, domain.Execution{})
 
 	// Test with positive spread (DEX more expensive)
 	spreadPos := makeSpread("3350", "3400") // DEX $50 more expensive, spread = +50
-	result2 := calc.Calculate(spreadPos, decimal.NewFromInt(10), decimal.NewFromInt(34000), gasCost)
+	result2 := calc.Calculate(spreadPos, decimal.NewFromInt(10), decimal.NewFromInt(34000), gasCost, domain.Execution{})
 
 	// Both should have same gross profit (|50| * 10 = 500)
 	if !result1.GrossProfit.ToDecimal().Equal(result2.GrossProfit.ToDecimal()) {
@@ -325,6 +325,163 @@ func TestProfitCalculator_GrossProfit_UsesAbsoluteSpread(t *testing.T) {
 	}
 }
 
+func TestProfitCalculator_ExecutionModes(t *testing.T) {
+	tests := []struct {
+		name      string
+		exec      domain.Execution
+		wantGross string
+		wantFees  string
+		wantMode  domain.ExecutionMode
+	}{
+		{
+			name:      "zero_value_is_taker",
+			exec:      domain.Execution{},
+			wantGross: "500",   // (3450 - 3400 VWAP) * 10
+			wantFees:  "136",   // 34000 * (0.3% + 0.1%)
+			wantMode:  domain.ExecutionTaker,
+		},
+		{
+			name:      "taker_fills_at_vwap",
+			exec:      domain.Execution{Mode: domain.ExecutionTaker, BestPrice: decimal.NewFromInt(3398)},
+			wantGross: "500",
+			wantFees:  "136",
+			wantMode:  domain.ExecutionTaker,
+		},
+		{
+			name:      "maker_fills_at_best_bid",
+			exec:      domain.Execution{Mode: domain.ExecutionMaker, BestPrice: decimal.NewFromInt(3398)},
+			wantGross: "520",   // (3450 - 3398) * 10
+			wantFees:  "129.2", // 34000 * (0.3% + 0.08%)
+			wantMode:  domain.ExecutionMaker,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := NewProfitCalculator(decimal.Zero, decimal.Zero)
+			result := calc.Calculate(makeSpread("3400", "3450"), decimal.NewFromInt(10), decimal.NewFromInt(34000), makeGasCost(0, 0, "3400"), tt.exec)
+
+			if want := decimal.RequireFromString(tt.wantGross); !result.GrossProfit.ToDecimal().Equal(want) {
+				t.Errorf("GrossProfit = %s, want %s", result.GrossProfit.ToDecimal(), want)
+			}
+			if want := decimal.RequireFromString(tt.wantFees); !result.ExchangeFees.ToDecimal().Equal(want) {
+				t.Errorf("ExchangeFees = %s, want %s", result.ExchangeFees.ToDecimal(), want)
+			}
+			if result.ExecutionMode != tt.wantMode {
+				t.Errorf("ExecutionMode = %s, want %s", result.ExecutionMode, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestProfitCalculator_SetThresholds(t *testing.T) {
+	calc := NewProfitCalculator(decimal.NewFromInt(10), decimal.NewFromInt(5))
+	gasCost := makeGasCost(0, 0, "3400")
+	spread := makeSpread("3400", "3350")
+
+	// Gross 500 on 34000 notional clears the initial thresholds
+	if result := calc.Calculate(spread, decimal.NewFromInt(10), decimal.NewFromInt(34000), gasCost, domain.Execution{}); !result.IsProfitable {
+		t.Fatal("expected profitable before raising thresholds")
+	}
+
+	calc.SetThresholds(decimal.NewFromInt(10), decimal.NewFromInt(10_000))
+	if result := calc.Calculate(spread, decimal.NewFromInt(10), decimal.NewFromInt(34000), gasCost, domain.Execution{}); result.IsProfitable {
+		t.Error("expected unprofitable after raising min profit USD")
+	}
+
+	derived := calc.WithThresholds(decimal.NewFromInt(1), decimal.NewFromInt(1))
+	if derived.SlippageModel() != calc.SlippageModel() {
+		t.Error("WithThresholds should keep the slippage model")
+	}
+	if result := derived.Calculate(spread, decimal.NewFromInt(10), decimal.NewFromInt(34000), gasCost, domain.Execution{}); !result.IsProfitable {
+		t.Error("derived calculator should use its own thresholds")
+	}
+}
+
+func TestProfitCalculator_SetCEXFees(t *testing.T) {
+	calc := NewProfitCalculator(decimal.NewFromInt(-1), decimal.NewFromInt(-1))
+	derived := calc.WithThresholds(decimal.NewFromInt(-1), decimal.NewFromInt(-1))
+	gasCost := makeGasCost(0, 0, "3400")
+	spread := makeSpread("3400", "3350")
+	notional := decimal.NewFromInt(10_000)
+
+	// Defaults: 0.3% Uniswap + 0.1% Binance taker
+	if got := calc.Calculate(spread, decimal.NewFromInt(3), notional, gasCost, domain.Execution{}).ExchangeFees.ToDecimal(); !got.Equal(decimal.NewFromInt(40)) {
+		t.Errorf("default ExchangeFees = %s, want 40", got)
+	}
+
+	// Account fees (0.075% taker) apply to derived per-pair calculators too
+	calc.SetCEXFees(decimal.RequireFromString("0.00075"), decimal.RequireFromString("0.0006"))
+	if got := derived.Calculate(spread, decimal.NewFromInt(3), notional, gasCost, domain.Execution{}).ExchangeFees.ToDecimal(); !got.Equal(decimal.RequireFromString("37.5")) {
+		t.Errorf("account ExchangeFees = %s, want 37.5", got)
+	}
+	if taker, maker := derived.CEXFees(); !taker.Equal(decimal.RequireFromString("0.00075")) || !maker.Equal(decimal.RequireFromString("0.0006")) {
+		t.Errorf("CEXFees() = %s/%s, want 0.00075/0.0006", taker, maker)
+	}
+
+	// A zero-fee account pays only the Uniswap fee
+	calc.SetCEXFees(decimal.Zero, decimal.Zero)
+	if got := derived.Calculate(spread, decimal.NewFromInt(3), notional, gasCost, domain.Execution{}).ExchangeFees.ToDecimal(); !got.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("zero-fee ExchangeFees = %s, want 30", got)
+	}
+	if taker, maker := derived.CEXFees(); !taker.IsZero() || !maker.IsZero() {
+		t.Errorf("CEXFees() = %s/%s, want 0/0", taker, maker)
+	}
+}
+
+func TestProfitCalculator_SetFixedCosts(t *testing.T) {
+	calc := NewProfitCalculator(decimal.NewFromInt(-1), decimal.NewFromInt(-1))
+	gasCost := makeGasCost(200_000, 25, "3400") // 17 USD
+	spread := makeSpread("3400", "3350")
+	notional := decimal.NewFromInt(10_000)
+
+	// No overheads by default: 17 gas + 40 fees
+	if got := calc.Calculate(spread, decimal.NewFromInt(3), notional, gasCost, domain.Execution{}).TotalCosts.ToDecimal(); !got.Equal(decimal.NewFromInt(57)) {
+		t.Errorf("default TotalCosts = %s, want 57", got)
+	}
+
+	calc.SetFixedCosts(FixedCosts{
+		ApprovalGas:         50_000,
+		CEXWithdrawalFeeUSD: decimal.RequireFromString("1.5"),
+		CEXDepositFeeUSD:    decimal.RequireFromString("0.5"),
+	})
+	derived := calc.WithThresholds(decimal.NewFromInt(-1), decimal.NewFromInt(-1))
+	result := derived.Calculate(spread, decimal.NewFromInt(3), notional, gasCost, domain.Execution{})
+
+	// Approval gas is priced like the swap: 50k / 200k × 17 USD
+	if !result.Overheads.ApprovalGasUSD.Equal(decimal.RequireFromString("4.25")) {
+		t.Errorf("ApprovalGasUSD = %s, want 4.25", result.Overheads.ApprovalGasUSD)
+	}
+	if got := result.TotalCosts.ToDecimal(); !got.Equal(decimal.RequireFromString("63.25")) {
+		t.Errorf("TotalCosts = %s, want 63.25", got)
+	}
+	if !result.NetProfitRaw.Equal(decimal.RequireFromString("86.75")) {
+		t.Errorf("NetProfitRaw = %s, want 86.75", result.NetProfitRaw)
+	}
+}
+
+func TestProfitCalculator_KeepsFullPrecision(t *testing.T) {
+	calc := NewProfitCalculator(decimal.NewFromInt(-1), decimal.NewFromInt(-1))
+	gasCost := makeGasCost(185_000, 1, "3000") // 0.555 USD, 0.56 for display
+	spread := makeSpread("3400", "3350.005")
+	notional := decimal.RequireFromString("10000.01")
+
+	result := calc.Calculate(spread, decimal.NewFromInt(3), notional, gasCost, domain.Execution{})
+
+	// Gas is charged at 0.555, not the rounded-up 0.56, and fees keep their
+	// sub-cent digits: 0.555 + 10000.01 × 0.004
+	if want := decimal.RequireFromString("40.55504"); !result.Exact.TotalCosts.Equal(want) {
+		t.Errorf("Exact.TotalCosts = %s, want %s", result.Exact.TotalCosts, want)
+	}
+	// 49.995 × 3 - 40.55504
+	if want := decimal.RequireFromString("109.42996"); !result.NetProfitRaw.Equal(want) {
+		t.Errorf("NetProfitRaw = %s, want %s", result.NetProfitRaw, want)
+	}
+	if got := result.NetProfit.ToDecimal(); !got.Equal(decimal.RequireFromString("109.43")) {
+		t.Errorf("NetProfit = %s, want 109.43", got)
+	}
+}
+
 // Benchmark for performance-critical calculation
 func BenchmarkProfitCalculator_Calculate(b *testing.B) {
 	calc := NewProfitCalculator(decimal.NewFromInt(10), decimal.NewFromInt(50))
@@ -335,6 +492,6 @@ func BenchmarkProfitCalculator_Calculate(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		calc.Calculate(spread, tradeSize, tradeValueUSD, gasCost)
+		calc.Calculate(spread, tradeSize, tradeValueUSD, gasCost, domain.Execution{})
 	}
 }
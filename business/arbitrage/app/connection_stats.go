@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"time"
+)
+
+// connectionStatsInterval is how often each source's connection stability
+// is reported.
+const connectionStatsInterval = 5 * time.Second
+
+// watchConnectionStats reports connection stability on an interval until
+// ctx ends or the detector shuts down. It runs on its own ticker rather than
+// per block so a node outage still shows its reconnect backoff.
+func (d *Detector) watchConnectionStats(ctx context.Context) {
+	sr, ok := d.reporter.(ConnectionStatsReporter)
+	if !ok {
+		return
+	}
+
+	ticker := time.NewTicker(connectionStatsInterval)
+	defer ticker.Stop()
+
+	for {
+		d.reportConnectionStats(sr)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reportConnectionStats reports the stats of every source that tracks them.
+func (d *Detector) reportConnectionStats(sr ConnectionStatsReporter) {
+	if stats, ok := d.blockchain.ConnectionStats(); ok {
+		sr.UpdateConnectionStats("Ethereum", stats)
+	}
+	for venue, stats := range d.pricing.CEXConnectionStats() {
+		sr.UpdateConnectionStats(venue, stats)
+	}
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+)
+
+// dedupeKey identifies one market condition: every profitable size on the
+// same block, pair and direction is the same spread traded in different sizes.
+type dedupeKey struct {
+	block     uint64
+	pair      string
+	direction domain.Direction
+}
+
+// dedupeOpportunities collapses opportunities sharing a dedupeKey into the
+// one with the highest net profit, recording the range of sizes it stands
+// for. Order follows the first opportunity seen for each key.
+func dedupeOpportunities(opps []*domain.Opportunity) []*domain.Opportunity {
+	best := make(map[dedupeKey]*domain.Opportunity, len(opps))
+	ranges := make(map[dedupeKey]*domain.SizeRange, len(opps))
+	var keys []dedupeKey
+
+	for _, opp := range opps {
+		key := dedupeKey{block: opp.BlockNumber, pair: opp.Route(), direction: opp.Direction}
+		r, ok := ranges[key]
+		if !ok {
+			keys = append(keys, key)
+			best[key] = opp
+			ranges[key] = &domain.SizeRange{Min: opp.TradeSize, Max: opp.TradeSize, Count: 1}
+			continue
+		}
+
+		r.Count++
+		if opp.TradeSize.LessThan(r.Min) {
+			r.Min = opp.TradeSize
+		}
+		if opp.TradeSize.GreaterThan(r.Max) {
+			r.Max = opp.TradeSize
+		}
+		// Ranked by net, not gross: larger sizes gross more but pay more impact
+		if opp.Profit.NetProfitRaw.GreaterThan(best[key].Profit.NetProfitRaw) {
+			best[key] = opp
+		}
+	}
+
+	result := make([]*domain.Opportunity, 0, len(keys))
+	for _, key := range keys {
+		opp := best[key]
+		if r := ranges[key]; r.Count > 1 {
+			opp.SizeRange = r
+		}
+		result = append(result, opp)
+	}
+	return result
+}
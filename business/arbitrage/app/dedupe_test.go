@@ -0,0 +1,59 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+func TestDedupeOpportunities(t *testing.T) {
+	pair := pricingDomain.Pair{Base: asset.ETH, Quote: asset.USDC}
+	opp := func(block uint64, dir domain.Direction, size, net float64) *domain.Opportunity {
+		return &domain.Opportunity{
+			BlockNumber: block,
+			Pair:        pair,
+			Direction:   dir,
+			TradeSize:   decimal.NewFromFloat(size),
+			Profit: &domain.ProfitResult{
+				NetProfitRaw: decimal.NewFromFloat(net),
+				IsProfitable: true,
+			},
+		}
+	}
+
+	opps := []*domain.Opportunity{
+		opp(100, domain.DirectionCEXToDEX, 1, 12),
+		opp(100, domain.DirectionCEXToDEX, 10, 15), // Largest size, so largest gross
+		opp(100, domain.DirectionCEXToDEX, 5, 18),
+		opp(100, domain.DirectionDEXToCEX, 1, 4),
+		opp(101, domain.DirectionCEXToDEX, 1, 12),
+	}
+
+	got := dedupeOpportunities(opps)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 opportunities, got %d", len(got))
+	}
+
+	// Best by net profit, not gross
+	if !got[0].TradeSize.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("expected size 5 (highest net), got %s", got[0].TradeSize)
+	}
+	r := got[0].SizeRange
+	if r == nil || r.Count != 3 || !r.Min.Equal(decimal.NewFromInt(1)) || !r.Max.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("expected range 1-10 over 3 sizes, got %+v", r)
+	}
+
+	// Distinct directions and blocks stay separate, without a range
+	for _, o := range got[1:] {
+		if o.SizeRange != nil {
+			t.Errorf("expected no range for a single size, got %+v", o.SizeRange)
+		}
+	}
+	if got[1].Direction != domain.DirectionDEXToCEX || got[2].BlockNumber != 101 {
+		t.Errorf("unexpected order: %s@%d, %s@%d", got[1].Direction, got[1].BlockNumber, got[2].Direction, got[2].BlockNumber)
+	}
+}
@@ -3,14 +3,20 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	blockchainApp "github.com/fd1az/arbitrage-bot/business/blockchain/app"
 	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
 	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
 	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
 	"github.com/shopspring/decimal"
 	"go.opentelemetry.io/otel"
@@ -24,10 +30,66 @@ const (
 	meterName  = "github.com/fd1az/arbitrage-bot/business/arbitrage/app"
 )
 
+// swapGasLimit is the estimated gas for a single DEX swap, used when the
+// quote carries no estimate and no default is configured.
+const swapGasLimit = 200_000
+
+// wrapGasLimit is the gas for a WETH deposit or withdrawal. CEXs move native
+// ETH while pools hold WETH, so a route whose pair includes ETH wraps or
+// unwraps once on the DEX side.
+const wrapGasLimit = 35_000
+
+// orphanRetention is how many blocks below a reorg orphaned hashes are remembered.
+const orphanRetention = 64
+
 // DetectorConfig holds configuration for the arbitrage detector.
 type DetectorConfig struct {
 	Pairs      []pricingDomain.Pair
 	TradeSizes []decimal.Decimal
+
+	// TradeSizesUSD replaces TradeSizes with USD notionals, converted to a
+	// base-asset size per pair at the CEX ask on every block.
+	TradeSizesUSD []decimal.Decimal
+
+	// PairCalculators overrides the profit calculator per pair (keyed by
+	// Pair.String()); pairs without an entry use the default calculator.
+	PairCalculators map[string]*ProfitCalculator
+
+	// ExecutionMode is the assumed CEX fill (empty = taker).
+	ExecutionMode domain.ExecutionMode
+
+	// MaxCapitalUSD is the capital available per trade (zero = unlimited).
+	MaxCapitalUSD decimal.Decimal
+
+	// SpreadBasis is the CEX price spreads are measured against (empty = ask).
+	SpreadBasis pricingDomain.SpreadBasis
+
+	// MaxSnapshotAge is the oldest CEX or DEX price analyzed (zero = no limit).
+	MaxSnapshotAge time.Duration
+
+	// DefaultSwapGasLimit is the swap gas used when a quote has no gas
+	// estimate (zero = swapGasLimit).
+	DefaultSwapGasLimit uint64
+
+	// ETHPricePair is priced on the CEX every block to convert gas costs to
+	// USD (nil = take the price from a scanned ETH pair).
+	ETHPricePair *pricingDomain.Pair
+
+	// SimulateExecution attaches a simulated two-leg fill to profitable
+	// opportunities before they are reported.
+	SimulateExecution bool
+
+	// ScanInterval re-evaluates pairs between blocks with fresh CEX prices
+	// against the last block's DEX quotes (zero = scan on blocks only).
+	ScanInterval time.Duration
+
+	// Dedupe reports the profitable sizes of one block, pair and direction
+	// as a single opportunity: the best by net profit, with the size range.
+	Dedupe bool
+
+	// Safety holds the conditions that halt detection (the zero value
+	// checks none).
+	Safety SafetyConfig
 }
 
 // detectorMetrics holds OTEL metric instruments for the detector.
@@ -36,7 +98,15 @@ type detectorMetrics struct {
 	opportunitiesProfitable metric.Int64Counter
 	spreadBPS              metric.Float64Histogram
 	netProfitUSD           metric.Float64Histogram
+	gasCostUSD             metric.Float64Histogram
+	exchangeFeesUSD        metric.Float64Histogram
 	analysisLatency        metric.Float64Histogram
+	blockToReportLatency   metric.Float64Histogram
+	opportunitiesInvalidated metric.Int64Counter
+	profitMissedUSD        metric.Float64Counter
+	profitAvailableUSD     metric.Float64Counter
+	skippedStale           metric.Int64Counter
+	noLiquidity            metric.Int64Counter
 }
 
 // Detector orchestrates arbitrage detection.
@@ -46,6 +116,7 @@ type Detector struct {
 	calculator *ProfitCalculator
 	reporter   Reporter
 	config     DetectorConfig
+	configMu   sync.RWMutex // Guards config against hot reloads
 	logger     logger.LoggerInterface
 
 	// OTEL instrumentation
@@ -54,6 +125,42 @@ type Detector struct {
 
 	// ETH price in USD for gas cost conversion (updated on each block)
 	ethPriceUSD decimal.Decimal
+
+	// Whether the last ETHPricePair lookup failed; scanned ETH pairs stand
+	// in until it recovers
+	ethFeedFailing bool
+
+	// Optional triangular cycle scanner
+	triangular *TriangularDetector
+
+	// Optional readiness gate, flipped on the first successful analysis
+	readiness ReadinessGate
+	ready     atomic.Bool
+
+	// Paused detectors still consume blocks but skip all pricing work
+	paused atomic.Bool
+
+	// Kill switch halting detection on abnormal conditions, checked per block
+	safety       *SafetyMonitor
+	onSafetyHalt func(event *domain.SafetyEvent)
+
+	// Blocks orphaned by reorgs (hash -> number); their opportunities are dropped
+	orphaned   map[common.Hash]uint64
+	orphanedMu sync.RWMutex
+
+	// When each pair last logged having no liquidity, so dead pairs log
+	// once per noLiquidityLogInterval rather than every block
+	noLiquidityLogged map[string]time.Time
+	noLiquidityMu     sync.Mutex
+
+	// Last block's scan input, re-priced by interval scans. Only touched
+	// from the run loop, which serializes block and interval scans.
+	lastScan *blockScan
+
+	// Shutdown closes stopCh to end the run loop, which closes done on exit
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
 }
 
 // NewDetector creates a new arbitrage Detector.
@@ -74,6 +181,9 @@ func NewDetector(
 		logger:      log,
 		tracer:      otel.Tracer(tracerName),
 		ethPriceUSD: decimal.NewFromInt(3000), // Default, will be updated
+		orphaned:    make(map[common.Hash]uint64),
+		safety:      NewSafetyMonitor(config.Safety),
+		stopCh:      make(chan struct{}),
 	}
 
 	// Initialize metrics (errors are logged but don't fail startup)
@@ -84,6 +194,62 @@ func NewDetector(
 	return d
 }
 
+// SetTriangularDetector enables triangular cycle scanning on every block.
+func (d *Detector) SetTriangularDetector(t *TriangularDetector) {
+	d.triangular = t
+}
+
+// UpdateConfig replaces the detector configuration (pairs, trade sizes and
+// per-pair calculators) while running; it takes effect from the next block.
+func (d *Detector) UpdateConfig(cfg DetectorConfig) {
+	d.configMu.Lock()
+	d.config = cfg
+	d.configMu.Unlock()
+	d.safety.SetConfig(cfg.Safety)
+
+	d.logger.Info(context.Background(), "detector config updated",
+		"pairs", len(cfg.Pairs),
+		"trade_sizes", len(cfg.TradeSizes),
+		"trade_sizes_usd", len(cfg.TradeSizesUSD),
+	)
+}
+
+// getConfig returns the current detector configuration.
+func (d *Detector) getConfig() DetectorConfig {
+	d.configMu.RLock()
+	defer d.configMu.RUnlock()
+	return d.config
+}
+
+// SetPaused pauses or resumes detection. While paused, blocks are still
+// consumed (so the subscription doesn't back up) but no gas, orderbook or
+// quoter calls are made.
+func (d *Detector) SetPaused(paused bool) {
+	if d.paused.Swap(paused) != paused {
+		d.logger.Info(context.Background(), "detection paused", "paused", paused)
+	}
+}
+
+// Paused reports whether detection is paused.
+func (d *Detector) Paused() bool {
+	return d.paused.Load()
+}
+
+// SetReadinessGate sets the gate marked ready after the first successful analysis,
+// i.e. once a block has arrived and both CEX and DEX prices are available.
+func (d *Detector) SetReadinessGate(g ReadinessGate) {
+	d.readiness = g
+}
+
+// markReady flips the readiness gate once.
+func (d *Detector) markReady(ctx context.Context) {
+	if d.readiness == nil || !d.ready.CompareAndSwap(false, true) {
+		return
+	}
+	d.readiness.SetReady(true)
+	d.logger.Info(ctx, "first analysis completed, marking service ready")
+}
+
 // initMetrics initializes OTEL metric instruments.
 func (d *Detector) initMetrics() error {
 	meter := otel.Meter(meterName)
@@ -129,6 +295,29 @@ func (d *Detector) initMetrics() error {
 		return err
 	}
 
+	// Gas cost tracks gas price, not trade size: a 200k gas swap spans
+	// well under a dollar at 1 gwei to hundreds during spikes
+	d.metrics.gasCostUSD, err = meter.Float64Histogram(
+		"arbitrage_gas_cost_usd",
+		metric.WithDescription("Estimated gas cost per analyzed opportunity in USD"),
+		metric.WithUnit("{USD}"),
+		metric.WithExplicitBucketBoundaries(0.5, 1, 2, 5, 10, 20, 50, 100, 200, 500),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Fees scale with trade value, so buckets grow geometrically
+	d.metrics.exchangeFeesUSD, err = meter.Float64Histogram(
+		"arbitrage_exchange_fees_usd",
+		metric.WithDescription("CEX and DEX fees per analyzed opportunity in USD"),
+		metric.WithUnit("{USD}"),
+		metric.WithExplicitBucketBoundaries(0.1, 0.5, 1, 5, 10, 50, 100, 500, 1000, 5000),
+	)
+	if err != nil {
+		return err
+	}
+
 	d.metrics.analysisLatency, err = meter.Float64Histogram(
 		"arbitrage_analysis_latency_ms",
 		metric.WithDescription("Time to analyze an opportunity in milliseconds"),
@@ -139,14 +328,71 @@ func (d *Detector) initMetrics() error {
 		return err
 	}
 
+	d.metrics.blockToReportLatency, err = meter.Float64Histogram(
+		"arbitrage_block_to_report_latency_ms",
+		metric.WithDescription("Wall time from block arrival until a pair finishes analysis in milliseconds"),
+		metric.WithUnit("ms"),
+		metric.WithExplicitBucketBoundaries(10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 12000),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.metrics.opportunitiesInvalidated, err = meter.Int64Counter(
+		"arbitrage_opportunities_invalidated_total",
+		metric.WithDescription("Opportunities dropped because their block was orphaned by a reorg"),
+		metric.WithUnit("{opportunity}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.metrics.skippedStale, err = meter.Int64Counter(
+		"arbitrage_skipped_stale_total",
+		metric.WithDescription("Opportunities skipped because a CEX or DEX price exceeded the max snapshot age"),
+		metric.WithUnit("{opportunity}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.metrics.noLiquidity, err = meter.Int64Counter(
+		"arbitrage_no_liquidity_total",
+		metric.WithDescription("Pair analyses skipped because neither the CEX nor the DEX could price the pair"),
+		metric.WithUnit("{analysis}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.metrics.profitMissedUSD, err = meter.Float64Counter(
+		"arbitrage_profit_missed_usd",
+		metric.WithDescription("Positive net profit of opportunities rejected by the profit thresholds"),
+		metric.WithUnit("{USD}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.metrics.profitAvailableUSD, err = meter.Float64Counter(
+		"arbitrage_profit_available_usd",
+		metric.WithDescription("Net profit of opportunities that passed the profit thresholds"),
+		metric.WithUnit("{USD}"),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // Start begins the arbitrage detection loop.
 func (d *Detector) Start(ctx context.Context) error {
+	cfg := d.getConfig()
 	d.logger.Info(ctx, "starting arbitrage detector",
-		"pairs", len(d.config.Pairs),
-		"trade_sizes", len(d.config.TradeSizes),
+		"pairs", len(cfg.Pairs),
+		"trade_sizes", len(cfg.TradeSizes),
+		"trade_sizes_usd", len(cfg.TradeSizesUSD),
 	)
 
 	// Start reporter
@@ -156,7 +402,7 @@ func (d *Detector) Start(ctx context.Context) error {
 
 	// Report initial connecting status
 	d.reporter.UpdateConnectionStatus("Ethereum", false, 0)
-	d.reporter.UpdateConnectionStatus("Binance", false, 0)
+	d.reporter.UpdateConnectionStatus(d.pricing.CEXName(), false, 0)
 
 	// Subscribe to new blocks
 	blocks, err := d.blockchain.SubscribeBlocks(ctx)
@@ -166,37 +412,139 @@ func (d *Detector) Start(ctx context.Context) error {
 	}
 
 	// Report Ethereum connected
-	d.reporter.UpdateConnectionStatus("Ethereum", true, 0)
+	d.reporter.UpdateConnectionStatus("Ethereum", true, d.blockchain.Latency())
+
+	// Watch for reorgs separately so in-flight analysis can be invalidated
+	if reorgs := d.blockchain.SubscribeReorgs(); reorgs != nil {
+		go d.watchReorgs(ctx, reorgs)
+	}
+
+	// Report connection uptime and reconnects to reporters that show them
+	go d.watchConnectionStats(ctx)
 
 	// Main detection loop
+	d.done = make(chan struct{})
 	go d.run(ctx, blocks)
 
 	return nil
 }
 
 func (d *Detector) run(ctx context.Context, blocks <-chan *blockchainDomain.Block) {
+	defer close(d.done)
+
+	var ticker scanTicker
+	defer ticker.stop()
+
+	// A scan in flight when ctx is cancelled runs to completion; Shutdown
+	// bounds how long it is waited for
+	scanCtx := context.WithoutCancel(ctx)
+
 	for {
+		ticker.sync(d.getConfig().ScanInterval)
+
 		select {
 		case <-ctx.Done():
 			d.logger.Info(ctx, "detector stopping", "reason", ctx.Err())
 			return
+		case <-d.stopCh:
+			d.logger.Info(ctx, "detector stopping", "reason", "shutdown")
+			return
 		case block := <-blocks:
 			if block != nil {
-				d.onNewBlock(ctx, block)
+				d.onNewBlock(scanCtx, block)
+				ticker.reset()
 			}
+		case <-ticker.C():
+			d.onScanTick(scanCtx)
 		}
 	}
 }
 
+// watchReorgs marks the blocks orphaned by each reorg.
+func (d *Detector) watchReorgs(ctx context.Context, reorgs <-chan *blockchainDomain.ReorgEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-reorgs:
+			if !ok {
+				return
+			}
+			d.onReorg(ctx, event)
+		}
+	}
+}
+
+// onReorg records the orphaned block hashes, forgetting ones far below the reorg.
+func (d *Detector) onReorg(ctx context.Context, event *blockchainDomain.ReorgEvent) {
+	d.orphanedMu.Lock()
+	for i, hash := range event.Orphaned {
+		d.orphaned[hash] = event.FromBlock + uint64(i)
+	}
+	for hash, number := range d.orphaned {
+		if number+orphanRetention < event.FromBlock {
+			delete(d.orphaned, hash)
+		}
+	}
+	d.orphanedMu.Unlock()
+
+	d.logger.Warn(ctx, "invalidating opportunities from orphaned blocks",
+		"from_block", event.FromBlock,
+		"to_block", event.ToBlock,
+		"depth", event.Depth())
+}
+
+// isOrphaned reports whether block was orphaned by a reorg.
+func (d *Detector) isOrphaned(block *blockchainDomain.Block) bool {
+	d.orphanedMu.RLock()
+	defer d.orphanedMu.RUnlock()
+	_, ok := d.orphaned[block.Hash]
+	return ok
+}
+
+// invalidate drops an opportunity whose block was orphaned before it was reported.
+func (d *Detector) invalidate(ctx context.Context, opp *domain.Opportunity) {
+	if d.metrics != nil {
+		d.metrics.opportunitiesInvalidated.Add(ctx, 1, metric.WithAttributes(attribute.String("pair", opp.Pair.String())))
+	}
+	d.logger.Info(ctx, "dropping opportunity from orphaned block", "id", opp.ID, "block", opp.BlockNumber)
+}
+
 func (d *Detector) onNewBlock(ctx context.Context, block *blockchainDomain.Block) {
+	// Parent span for the block, so a trace shows every pair and size
+	// analyzed for it
+	ctx, span := d.tracer.Start(ctx, "processBlock",
+		trace.WithAttributes(
+			attribute.Int64("block_number", int64(block.Number)),
+			attribute.String("block_hash", block.Hash.Hex()),
+		),
+	)
+	defer span.End()
+
 	d.logger.Debug(ctx, "processing block", "number", block.Number, "hash", block.Hash.Hex())
 
 	// Update block in reporter
 	d.reporter.UpdateBlock(block.Number)
 
+	if d.paused.Load() {
+		span.SetAttributes(attribute.String("skip_reason", skipReasonPaused))
+		d.logger.Debug(ctx, "detection paused, skipping block", "number", block.Number)
+		return
+	}
+
+	// Let providers drop state from earlier blocks (e.g. cached quotes)
+	d.pricing.OnNewBlock(block.Number)
+
+	// Refresh the node's measured RPC latency
+	d.reporter.UpdateConnectionStatus("Ethereum", true, d.blockchain.Latency())
+
+	// Surface DEX degradation (open circuit breaker) instead of silently skipping pairs
+	d.reporter.UpdateConnectionStatus("Uniswap", d.pricing.DEXAvailable(), 0)
+
 	// Get current gas price
-	gasPrice, err := d.blockchain.GetGasPrice(ctx)
+	gasPrice, err := d.getGasPrice(ctx, block)
 	if err != nil {
+		span.RecordError(err)
 		d.logger.Error(ctx, "failed to get gas price", "error", err)
 		return
 	}
@@ -204,26 +552,240 @@ func (d *Detector) onNewBlock(ctx context.Context, block *blockchainDomain.Block
 	// Update gas price in reporter (convert wei to gwei)
 	gweiPrice := float64(gasPrice.Wei().Int64()) / 1e9
 	d.reporter.UpdateGasPrice(gweiPrice)
+	if gr, ok := d.reporter.(GasPercentileReporter); ok {
+		if rank, ok := d.blockchain.GasPercentileRank(gweiPrice); ok {
+			gr.UpdateGasPercentile(gweiPrice, rank)
+		}
+	}
+
+	// Refresh the gas-cost ETH price first: the safety checks watch it too
+	cfg := d.getConfig()
+	d.refreshETHPrice(ctx, cfg.ETHPricePair)
 
-	// Process each configured pair
-	for _, pair := range d.config.Pairs {
-		d.processPair(ctx, block, pair, gasPrice)
+	// Stop reporting on abnormal conditions rather than act on garbage data
+	if d.checkSafety(ctx, block, gweiPrice) {
+		span.SetAttributes(attribute.String("skip_reason", skipReasonSafetyHalt))
+		d.logger.Debug(ctx, "detection halted by safety check, skipping block", "number", block.Number)
+		return
 	}
+
+	// Price every pair and trade size up front so DEX quotes share one round trip
+	sizes := d.planTradeSizes(ctx, cfg)
+	snapshots := d.prefetchSnapshots(ctx, cfg.Pairs, sizes)
+	d.lastScan = &blockScan{block: block, gasPrice: gasPrice, sizes: sizes, snapshots: snapshots}
+
+	span.SetAttributes(attribute.Int("pairs", len(cfg.Pairs)))
+
+	if !d.scanPairs(ctx, block, gasPrice, sizes, snapshots) {
+		span.SetAttributes(attribute.Bool("orphaned", true))
+		return
+	}
+
+	// Scan triangular cycles (uses the ETH price refreshed above)
+	if d.triangular != nil && !d.isOrphaned(block) {
+		d.triangular.ProcessBlock(ctx, block, gasPrice, d.ethPriceUSD)
+	}
+}
+
+// scanPairs analyzes each configured pair at its planned sizes, stopping if
+// the block gets orphaned. It reports whether every pair was analyzed.
+func (d *Detector) scanPairs(ctx context.Context, block *blockchainDomain.Block, gasPrice *blockchainDomain.GasPrice, sizes map[string][]plannedSize, snapshots map[string]snapshotResult) bool {
+	for _, pair := range d.getConfig().Pairs {
+		if d.isOrphaned(block) {
+			d.logger.Info(ctx, "block orphaned, skipping remaining pairs", "number", block.Number)
+			return false
+		}
+		d.processPair(ctx, block, pair, sizes[pair.String()], gasPrice, snapshots)
+	}
+	return true
+}
+
+// calculatorFor returns the pair's profit calculator, falling back to the default.
+func (d *Detector) calculatorFor(pair pricingDomain.Pair) *ProfitCalculator {
+	if calc, ok := d.getConfig().PairCalculators[pair.String()]; ok {
+		return calc
+	}
+	return d.calculator
 }
 
-func (d *Detector) processPair(ctx context.Context, block *blockchainDomain.Block, pair pricingDomain.Pair, gasPrice *blockchainDomain.GasPrice) {
+// executionFor returns how the CEX leg is executed. Maker orders rest at the
+// best bid when buying on the CEX and at the best ask when selling; without a
+// usable orderbook the taker fill is assumed.
+func (d *Detector) executionFor(ctx context.Context, pair pricingDomain.Pair, spread pricingDomain.Spread) domain.Execution {
+	if d.getConfig().ExecutionMode != domain.ExecutionMaker {
+		return domain.Execution{Mode: domain.ExecutionTaker}
+	}
+
+	ob, err := d.pricing.GetCEXOrderbook(ctx, pair)
+	if err != nil || len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+		d.logger.Debug(ctx, "no orderbook for maker pricing, assuming taker fill", "pair", pair.String(), "error", err)
+		return domain.Execution{Mode: domain.ExecutionTaker}
+	}
+
+	best := ob.Asks[0].Price
+	if spread.Direction == pricingDomain.SpreadCEXToDEX {
+		best = ob.Bids[0].Price
+	}
+	return domain.Execution{Mode: domain.ExecutionMaker, BestPrice: best}
+}
+
+// spreadPrice returns the CEX price spreads are measured against. The mid and
+// micro bases need the orderbook; without a usable one the ask is kept.
+func (d *Detector) spreadPrice(ctx context.Context, pair pricingDomain.Pair, ask decimal.Decimal) decimal.Decimal {
+	basis := d.getConfig().SpreadBasis
+	if basis == "" || basis == pricingDomain.SpreadBasisAsk {
+		return ask
+	}
+
+	ob, err := d.pricing.GetCEXOrderbook(ctx, pair)
+	if err != nil {
+		d.logger.Debug(ctx, "no orderbook for spread basis, using ask", "pair", pair.String(), "basis", string(basis), "error", err)
+		return ask
+	}
+
+	price := ob.MidPrice()
+	if basis == pricingDomain.SpreadBasisMicro {
+		price = ob.MicroPrice()
+	}
+	if price.IsZero() {
+		return ask
+	}
+	return price
+}
+
+// refreshETHPrice updates the gas-cost ETH price from the CEX ask for one
+// unit of pair. When the lookup fails the last price is kept and scanned ETH
+// pairs update it instead; only the first failure is logged as a warning.
+func (d *Detector) refreshETHPrice(ctx context.Context, pair *pricingDomain.Pair) {
+	if pair == nil {
+		return
+	}
+	price, err := d.pricing.GetCEXPrice(ctx, *pair, decimal.NewFromInt(1), pricingDomain.SideBuy)
+	if err != nil {
+		if !d.ethFeedFailing {
+			d.logger.Warn(ctx, "ETH price feed unavailable, falling back to scanned ETH pairs",
+				"pair", pair.String(), "price", d.ethPriceUSD.String(), "error", err)
+		} else {
+			d.logger.Debug(ctx, "ETH price feed still unavailable", "pair", pair.String(), "error", err)
+		}
+		d.ethFeedFailing = true
+		return
+	}
+	if d.ethFeedFailing {
+		d.logger.Info(ctx, "ETH price feed recovered", "pair", pair.String())
+	}
+	d.ethFeedFailing = false
+	d.ethPriceUSD = price.Rate.Rate()
+}
+
+// pricesETHInUSD reports whether a pair's price is the ETH price in USD,
+// e.g. ETH-USDC but not ETH-BTC.
+func pricesETHInUSD(pair pricingDomain.Pair) bool {
+	return pair.Base.Symbol() == "ETH" && IsUSDLike(pair.Quote)
+}
+
+// ethFeedActive reports whether the ETH price comes from a working
+// ETHPricePair feed rather than from scanned pairs.
+func (d *Detector) ethFeedActive() bool {
+	return d.getConfig().ETHPricePair != nil && !d.ethFeedFailing
+}
+
+// getGasPrice returns the EIP-1559 max fee when the block carries a base fee,
+// falling back to the legacy gas price otherwise.
+func (d *Detector) getGasPrice(ctx context.Context, block *blockchainDomain.Block) (*blockchainDomain.GasPrice, error) {
+	if block.BaseFee != nil {
+		gasPrice, err := d.blockchain.GetGasPriceEIP1559(ctx, block.BaseFee)
+		if err == nil {
+			return gasPrice, nil
+		}
+		d.logger.Warn(ctx, "failed to get EIP-1559 gas price, using legacy", "error", err)
+	}
+	return d.blockchain.GetGasPrice(ctx)
+}
+
+// snapshotResult is a prefetched price snapshot or the error fetching it.
+type snapshotResult struct {
+	snapshot *pricingDomain.PriceSnapshot
+	err      error
+}
+
+// snapshotKey identifies a prefetched snapshot by pair and trade size.
+func snapshotKey(pair pricingDomain.Pair, tradeSize decimal.Decimal) string {
+	return pair.String() + "@" + tradeSize.String()
+}
+
+// prefetchSnapshots prices every pair at each of its planned sizes in one batch.
+func (d *Detector) prefetchSnapshots(ctx context.Context, pairs []pricingDomain.Pair, sizes map[string][]plannedSize) map[string]snapshotResult {
+	var requests []pricingApp.SnapshotRequest
+	for _, pair := range pairs {
+		for _, size := range sizes[pair.String()] {
+			requests = append(requests, pricingApp.SnapshotRequest{Pair: pair, TradeSize: size.base})
+		}
+	}
+
+	snapshots, errs := d.pricing.GetPriceSnapshots(ctx, requests)
+	results := make(map[string]snapshotResult, len(requests))
+	for i, req := range requests {
+		results[snapshotKey(req.Pair, req.TradeSize)] = snapshotResult{snapshot: snapshots[i], err: errs[i]}
+	}
+	return results
+}
+
+// snapshotFor returns the prefetched snapshot for a pair and size, fetching it
+// individually when it wasn't part of the batch (e.g. an interpolated size).
+func (d *Detector) snapshotFor(ctx context.Context, prefetched map[string]snapshotResult, pair pricingDomain.Pair, tradeSize decimal.Decimal) (*pricingDomain.PriceSnapshot, error) {
+	if r, ok := prefetched[snapshotKey(pair, tradeSize)]; ok {
+		return r.snapshot, r.err
+	}
+	return d.pricing.GetPriceSnapshot(ctx, pair, tradeSize)
+}
+
+func (d *Detector) processPair(ctx context.Context, block *blockchainDomain.Block, pair pricingDomain.Pair, sizes []plannedSize, gasPrice *blockchainDomain.GasPrice, snapshots map[string]snapshotResult) {
+	ctx, span := d.tracer.Start(ctx, "processPair",
+		trace.WithAttributes(
+			attribute.String("pair", pair.String()),
+			attribute.Int("sizes", len(sizes)),
+			attribute.Int64("block_number", int64(block.Number)),
+		),
+	)
+	defer span.End()
+
 	// Track best opportunity across all trade sizes
 	var bestBreakdown *CostBreakdown
 	var bestGrossProfit decimal.Decimal
 
-	// Process each trade size
-	for _, tradeSize := range d.config.TradeSizes {
-		opp, breakdown := d.analyzeOpportunity(ctx, block, pair, tradeSize, gasPrice)
+	// Process each trade size, plus an interpolated size when capital
+	// rules out every configured one
+	cfg := d.getConfig()
+	configured := baseSizes(sizes)
+	var optimalSize decimal.Decimal
+	var profitable []*domain.Opportunity
+	var sizeSpreads []SizeSpread
+	for i := 0; i < len(sizes); i++ {
+		opp, breakdown := d.analyzeOpportunity(ctx, block, pair, sizes[i], gasPrice, snapshots)
+		if opp != nil {
+			// Size the trade against available capital and the slippage cap,
+			// at the CEX price in USD (analyzeOpportunity only reports pairs
+			// whose quote has one)
+			quoteUSD, _ := d.quotePriceUSD(pair.Quote)
+			opp.OptimalSize = optimalTradeSize(configured, opp.CEXPrice.Mul(quoteUSD), cfg.MaxCapitalUSD, d.calculatorFor(pair).SlippageModel())
+		}
 		if opp != nil && opp.IsProfitable() {
-			d.reporter.Report(opp)
+			if d.isOrphaned(block) {
+				d.invalidate(ctx, opp)
+			} else {
+				profitable = append(profitable, opp)
+			}
+		}
+		if opp != nil && optimalSize.IsZero() {
+			optimalSize = opp.OptimalSize
+			if cfg.MaxCapitalUSD.IsPositive() && optimalSize.IsPositive() && !containsSize(configured, optimalSize) {
+				sizes = append(slices.Clip(sizes), plannedSize{base: optimalSize})
+			}
 		}
 		// Track best breakdown by gross profit (always take first valid, then compare)
 		if breakdown != nil {
+			sizeSpreads = append(sizeSpreads, SizeSpread{TradeSize: sizes[i].base, NetSpreadBps: breakdown.NetSpreadBps})
 			if bestBreakdown == nil || breakdown.GrossProfit.GreaterThan(bestGrossProfit) {
 				bestBreakdown = breakdown
 				bestGrossProfit = breakdown.GrossProfit
@@ -231,9 +793,30 @@ func (d *Detector) processPair(ctx context.Context, block *blockchainDomain.Bloc
 		}
 	}
 
+	// One market condition across several sizes is reported once when deduping
+	if cfg.Dedupe {
+		profitable = dedupeOpportunities(profitable)
+	}
+	span.SetAttributes(attribute.Int("opportunities", len(profitable)))
+	for _, opp := range profitable {
+		if cfg.SimulateExecution {
+			d.attachSimulation(ctx, opp)
+		}
+		d.reporter.Report(opp)
+	}
+
 	// Send best cost breakdown to UI (not each one individually)
 	if bestBreakdown != nil {
+		bestBreakdown.SizeSpreads = sizeSpreads
 		d.reporter.UpdateCostBreakdown(bestBreakdown)
+		d.markReady(ctx)
+	}
+
+	// Record end-to-end latency (includes orderbook and quoter round trips)
+	if d.metrics != nil && !block.ReceivedAt.IsZero() {
+		latencyMs := float64(time.Since(block.ReceivedAt).Microseconds()) / 1000.0
+		d.metrics.blockToReportLatency.Record(ctx, latencyMs,
+			metric.WithAttributes(attribute.String("pair", pair.String())))
 	}
 }
 
@@ -241,16 +824,19 @@ func (d *Detector) analyzeOpportunity(
 	ctx context.Context,
 	block *blockchainDomain.Block,
 	pair pricingDomain.Pair,
-	tradeSize decimal.Decimal,
+	size plannedSize,
 	gasPrice *blockchainDomain.GasPrice,
+	snapshots map[string]snapshotResult,
 ) (*domain.Opportunity, *CostBreakdown) {
 	start := time.Now()
+	tradeSize := size.base
 
 	// Start tracing span for opportunity analysis
 	ctx, span := d.tracer.Start(ctx, "analyzeOpportunity",
 		trace.WithAttributes(
 			attribute.String("pair", pair.String()),
 			attribute.String("trade_size", tradeSize.String()),
+			attribute.String("trade_notional_usd", size.notionalUSD.String()),
 			attribute.Int64("block_number", int64(block.Number)),
 		),
 	)
@@ -259,25 +845,52 @@ func (d *Detector) analyzeOpportunity(
 	// Metric attributes
 	metricAttrs := metric.WithAttributes(
 		attribute.String("pair", pair.String()),
-		attribute.String("trade_size", tradeSize.String()),
+		attribute.String("trade_size", size.label()),
 	)
 
 	// Get price snapshot from both CEX and DEX
-	snapshot, err := d.pricing.GetPriceSnapshot(ctx, pair, tradeSize)
+	snapshot, err := d.snapshotFor(ctx, snapshots, pair, tradeSize)
+	var fillErr *pricingDomain.PartialFillError
+	if errors.As(err, &fillErr) {
+		// Sizes the book can't fill are skipped (cex.reject_partial_fills)
+		span.SetAttributes(attribute.String("skip_reason", skipReasonThinBook))
+		d.logger.Debug(ctx, "skipping size the CEX book can't fill",
+			"pair", pair.String(),
+			"size", tradeSize.String(),
+			"venue", fillErr.Venue,
+			"filled", fillErr.Filled.String(),
+		)
+		return nil, nil
+	}
+	if errors.Is(err, asset.ErrTooManyDecimals) {
+		// A size the base asset can't represent (e.g. 9 decimals of WBTC)
+		span.SetAttributes(attribute.String("skip_reason", skipReasonSizePrecision))
+		d.logger.Debug(ctx, "skipping size finer than the base asset's decimals",
+			"pair", pair.String(),
+			"size", tradeSize.String(),
+			"decimals", pair.Base.Decimals(),
+		)
+		return nil, nil
+	}
+	var noLiquidityErr *pricingDomain.NoLiquidityError
+	if errors.As(err, &noLiquidityErr) {
+		d.skipNoLiquidity(ctx, span, pair, err)
+		return nil, nil
+	}
 	if err != nil {
 		d.logger.Debug(ctx, "failed to get price snapshot",
 			"pair", pair.String(),
 			"size", tradeSize.String(),
 			"error", err,
 		)
-		// Report Binance disconnected if we can't get prices
-		d.reporter.UpdateConnectionStatus("Binance", false, 0)
+		// Report the CEX disconnected if we can't get prices
+		d.reporter.UpdateConnectionStatus(d.pricing.CEXName(), false, 0)
 		span.SetAttributes(attribute.String("error", err.Error()))
 		return nil, nil
 	}
 
-	// Report Binance connected since we got prices
-	d.reporter.UpdateConnectionStatus("Binance", true, 0)
+	// Report the CEX connected since we got prices
+	d.reporter.UpdateConnectionStatus(d.pricing.CEXName(), true, d.pricing.CEXLatency())
 
 	// Update price display
 	d.reporter.UpdatePrices(snapshot)
@@ -285,50 +898,100 @@ func (d *Detector) analyzeOpportunity(
 	// Extract prices
 	if snapshot.CEXAsk == nil || snapshot.DEXQuote == nil {
 		span.SetAttributes(attribute.Bool("incomplete_snapshot", true))
+		if snapshot.CEXAsk == nil && snapshot.DEXQuote == nil {
+			d.skipNoLiquidity(ctx, span, pair, nil)
+		}
+		return nil, nil
+	}
+
+	// Skip prices from a data gap rather than trade on them
+	if source, age, stale := staleSource(snapshot, d.getConfig().MaxSnapshotAge); stale {
+		if d.metrics != nil {
+			d.metrics.skippedStale.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("pair", pair.String()),
+				attribute.String("source", source),
+			))
+		}
+		span.SetAttributes(attribute.String("skip_reason", skipReasonStaleData))
+		d.logger.Debug(ctx, "skipping stale snapshot",
+			"pair", pair.String(),
+			"size", tradeSize.String(),
+			"source", source,
+			"age", age,
+		)
 		return nil, nil
 	}
 
 	cexPrice := snapshot.CEXAsk.Rate.Rate() // CEX ask for buying
-	dexPrice := snapshot.DEXQuote.Price.Rate()
 
-	// Update ETH price (using CEX price if pair includes ETH)
-	if pair.Base.Symbol() == "ETH" {
+	// Without a working dedicated feed, take the ETH price from an ETH pair
+	// quoted in USD
+	if !d.ethFeedActive() && pricesETHInUSD(pair) {
 		d.ethPriceUSD = cexPrice
 	}
 
-	// Calculate spread
-	spread := pricingDomain.CalculateSpread(cexPrice, dexPrice)
-
-	// Calculate gas cost (estimate ~200k gas for a swap)
-	const swapGasLimit = 200_000
-	gasCost := domain.NewGasCost(swapGasLimit, gasPrice.Wei(), d.ethPriceUSD)
-
-	// Calculate trade value in USD (for fee calculation)
-	tradeValueUSD := cexPrice.Mul(tradeSize)
+	// Costs are in USD, so the quote needs a USD price to value the trade
+	quoteUSD, ok := d.quotePriceUSD(pair.Quote)
+	if !ok {
+		span.SetAttributes(attribute.String("skip_reason", skipReasonNoUSDPrice))
+		d.logger.Debug(ctx, "skipping pair without a USD price for its quote",
+			"pair", pair.String(),
+			"quote", pair.Quote.Symbol(),
+		)
+		return nil, nil
+	}
 
-	// Calculate profit (includes gas + exchange fees)
-	// Always calculate this for cost breakdown display
-	profit := d.calculator.Calculate(spread, tradeSize, tradeValueUSD, gasCost)
+	// Calculate spread against the configured CEX basis, pricing the DEX leg
+	// with the quote for the direction it points to
+	dexQuote, dexPrice, spread := dexLeg(snapshot, d.spreadPrice(ctx, pair, cexPrice))
+
+	// Calculate gas cost from the quoter's estimate for the route, plus the
+	// wrap or unwrap when the CEX leg moves native ETH
+	gasLimit := gasLimitFor(dexQuote, d.getConfig().DefaultSwapGasLimit) + wrapGasFor(pair)
+	gasCost := domain.NewGasCost(gasLimit, gasPrice.Wei(), d.ethPriceUSD)
+
+	// Calculate trade value in USD (for fee calculation). Sizes and prices are
+	// in whole base units, so the base's decimals only matter for raw amounts;
+	// the price is in quote units, so convert it with the quote's USD price
+	tradeValueUSD := cexPrice.Mul(tradeSize).Mul(quoteUSD)
+
+	// Calculate profit (includes gas + exchange fees, and DEX slippage when
+	// the quote carries pool prices). Always calculated for cost breakdown display
+	priceImpactBps, _ := dexQuote.PriceImpactBps()
+	exec := d.executionFor(ctx, pair, spread)
+	usdSpread, usdExec := spreadInUSD(spread, exec, quoteUSD)
+	profit := d.calculatorFor(pair).CalculateWithSlippage(usdSpread, tradeSize, tradeValueUSD, gasCost, priceImpactBps, usdExec)
+
+	// A maker order rests at the top of book: report the price it fills at
+	if exec.IsMaker() {
+		cexPrice = exec.BestPrice
+		spread = pricingDomain.CalculateSpread(cexPrice, dexPrice)
+	}
 
 	// Build cost breakdown first (always show analysis even if not profitable)
 	breakdown := &CostBreakdown{
-		TradeSize:     tradeSize.String() + " ETH",
+		TradeSize:     tradeSizeLabel(size, pair.Base),
 		TradeValueUSD: tradeValueUSD,
-		GrossProfit:   profit.GrossProfit.ToDecimal(),
-		GasCostUSD:    profit.GasCost.ToDecimal(),
-		ExchangeFees:  profit.ExchangeFees.ToDecimal(),
-		TotalCosts:    profit.TotalCosts.ToDecimal(),
+		NotionalUSD:   size.notionalUSD,
+		GrossProfit:   profit.Exact.GrossProfit,
+		GasCostUSD:    profit.Exact.GasCost,
+		ExchangeFees:  profit.Exact.ExchangeFees,
+		Overheads:     profit.Overheads,
+		TotalCosts:    profit.Exact.TotalCosts,
 		NetProfit:     profit.NetProfitRaw, // Use raw value to preserve sign
 		IsProfitable:  profit.IsProfitable,
+		NetSpreadBps:  netSpreadBps(spread, profit.Exact.TotalCosts, tradeValueUSD),
 	}
 
 	// Record spread and profit metrics
 	spreadFloat, _ := spread.BasisPoints.Float64()
-	netProfitFloat, _ := profit.NetProfit.ToDecimal().Float64()
+	netProfitFloat, _ := profit.NetProfitRaw.Abs().Float64()
 
 	if d.metrics != nil {
 		d.metrics.spreadBPS.Record(ctx, spreadFloat, metricAttrs)
 		d.metrics.netProfitUSD.Record(ctx, netProfitFloat, metricAttrs)
+		d.metrics.gasCostUSD.Record(ctx, profit.Exact.GasCost.InexactFloat64(), metricAttrs)
+		d.metrics.exchangeFeesUSD.Record(ctx, profit.Exact.ExchangeFees.InexactFloat64(), metricAttrs)
 		d.metrics.opportunitiesAnalyzed.Add(ctx, 1, metricAttrs)
 	}
 
@@ -337,6 +1000,7 @@ func (d *Detector) analyzeOpportunity(
 		attribute.Float64("cex_price", cexPrice.InexactFloat64()),
 		attribute.Float64("dex_price", dexPrice.InexactFloat64()),
 		attribute.Float64("spread_bps", spreadFloat),
+		attribute.Float64("price_impact_bps", priceImpactBps.InexactFloat64()),
 		attribute.Float64("net_profit_usd", netProfitFloat),
 		attribute.Bool("profitable", profit.IsProfitable),
 	)
@@ -353,8 +1017,9 @@ func (d *Detector) analyzeOpportunity(
 		return nil, breakdown
 	}
 
-	// Calculate required capital (trade size * CEX price)
-	requiredCapital := tradeSize.Mul(cexPrice)
+	// Calculate required capital in USD (trade size * CEX price), to compare
+	// against MaxCapitalUSD
+	requiredCapital := tradeSize.Mul(cexPrice).Mul(quoteUSD)
 
 	// Build opportunity
 	opp := &domain.Opportunity{
@@ -364,18 +1029,32 @@ func (d *Detector) analyzeOpportunity(
 		Pair:            pair,
 		Direction:       direction,
 		TradeSize:       tradeSize,
+		TradeSizeUSD:    size.notionalUSD,
+		CEXVenue:        snapshot.CEXVenue,
 		CEXPrice:        cexPrice,
 		DEXPrice:        dexPrice,
 		Spread:          spread,
 		GasCost:         gasCost,
 		Profit:          profit,
-		DEXQuote:        snapshot.DEXQuote,
+		DEXQuote:        dexQuote,
 		RequiredCapital: requiredCapital,
 	}
 
+	// Capital limit the trade is sized against (see processPair)
+	opp.AvailableCapital = d.getConfig().MaxCapitalUSD
+
 	// Add execution steps and risk factors
 	opp.ExecutionSteps = d.buildExecutionSteps(opp)
 	opp.RiskFactors = d.buildRiskFactors(spread)
+	if risk, ok := d.calculatorFor(pair).SlippageModel().RiskFactor(tradeSize, priceImpactBps); ok {
+		opp.RiskFactors = append(opp.RiskFactors, risk)
+	}
+	if risk, ok := cexFillRisk(snapshot, direction, tradeSize); ok {
+		opp.RiskFactors = append(opp.RiskFactors, risk)
+	}
+	if risk, ok := stableBasisRisk(snapshot, d.pricing.StableEquivalents()); ok {
+		opp.RiskFactors = append(opp.RiskFactors, risk)
+	}
 
 	// Record profitable opportunity metric
 	if opp.IsProfitable() && d.metrics != nil {
@@ -383,6 +1062,9 @@ func (d *Detector) analyzeOpportunity(
 		span.SetAttributes(attribute.Bool("opportunity_detected", true))
 	}
 
+	// Track profit taken vs left on the table by the thresholds
+	d.recordProfit(ctx, pair, profit)
+
 	// Record analysis latency
 	latencyMs := float64(time.Since(start).Microseconds()) / 1000.0
 	if d.metrics != nil {
@@ -399,6 +1081,163 @@ func (d *Detector) analyzeOpportunity(
 	return opp, breakdown
 }
 
+// dexLeg picks the DEX quote for the trade the spread points to. Selling base
+// on the DEX (CEX→DEX) uses DEXQuote. Buying base on the DEX (DEX→CEX) uses
+// DEXBuyQuote, priced as quote spent per base received; when that price is
+// above the CEX basis, the pool's own fee and impact swallow the gap and
+// neither direction pays (SpreadNone). Without a buy quote the sell quote
+// stands in for both directions.
+func dexLeg(snapshot *pricingDomain.PriceSnapshot, cexBasis decimal.Decimal) (*pricingDomain.Quote, decimal.Decimal, pricingDomain.Spread) {
+	quote := snapshot.DEXQuote
+	price := quote.Price.Rate()
+	spread := pricingDomain.CalculateSpread(cexBasis, price)
+	if spread.Direction != pricingDomain.SpreadDEXToCEX || snapshot.DEXBuyQuote == nil {
+		return quote, price, spread
+	}
+
+	buyPrice := snapshot.DEXBuyQuote.InverseRate()
+	if buyPrice.IsZero() {
+		return quote, price, spread
+	}
+	spread = pricingDomain.CalculateSpread(cexBasis, buyPrice)
+	if spread.Direction != pricingDomain.SpreadDEXToCEX {
+		spread = pricingDomain.Spread{CEXPrice: cexBasis, DEXPrice: buyPrice, Direction: pricingDomain.SpreadNone}
+	}
+	return snapshot.DEXBuyQuote, buyPrice, spread
+}
+
+// netSpreadBps returns the spread magnitude left after costs, with the costs
+// expressed in bps of the trade value. Spreads in either direction are
+// traded, so the gross side is the absolute spread.
+func netSpreadBps(spread pricingDomain.Spread, totalCostsUSD, tradeValueUSD decimal.Decimal) decimal.Decimal {
+	gross := spread.BasisPoints.Abs()
+	if !tradeValueUSD.IsPositive() {
+		return gross
+	}
+	return gross.Sub(totalCostsUSD.Div(tradeValueUSD).Mul(decimal.NewFromInt(10000)))
+}
+
+// gasLimitFor returns the quote's gas estimate, which reflects the route's
+// hops and token transfer hooks, falling back to the configured default.
+func gasLimitFor(quote *pricingDomain.Quote, defaultLimit uint64) uint64 {
+	if quote != nil && quote.GasEstimate > 0 {
+		return quote.GasEstimate
+	}
+	if defaultLimit > 0 {
+		return defaultLimit
+	}
+	return swapGasLimit
+}
+
+// wrapGasFor returns the wrap/unwrap gas for a pair that crosses the
+// ETH/WETH boundary between the CEX and DEX legs, or zero.
+func wrapGasFor(pair pricingDomain.Pair) uint64 {
+	if pair.Base.IsNative() || pair.Quote.IsNative() {
+		return wrapGasLimit
+	}
+	return 0
+}
+
+// skipReasonStaleData marks opportunities skipped for exceeding MaxSnapshotAge.
+const skipReasonStaleData = "stale_data"
+
+// skipReasonThinBook marks sizes skipped because the CEX book can't fill them.
+const skipReasonThinBook = "thin_book"
+
+// skipReasonNoLiquidity marks pairs no venue could price.
+const skipReasonNoLiquidity = "no_liquidity"
+
+// skipReasonSizePrecision marks sizes finer than the base asset's decimals.
+const skipReasonSizePrecision = "size_precision"
+
+// skipReasonNoUSDPrice marks pairs whose quote asset has no USD price.
+const skipReasonNoUSDPrice = "no_usd_price"
+
+// noLiquidityLogInterval is how often a pair without liquidity is logged.
+const noLiquidityLogInterval = time.Minute
+
+// skipNoLiquidity counts a pair no venue could price and logs it at debug,
+// at most once per noLiquidityLogInterval per pair so a dead pair doesn't
+// log every block.
+func (d *Detector) skipNoLiquidity(ctx context.Context, span trace.Span, pair pricingDomain.Pair, err error) {
+	span.SetAttributes(attribute.String("skip_reason", skipReasonNoLiquidity))
+	if d.metrics != nil {
+		d.metrics.noLiquidity.Add(ctx, 1, metric.WithAttributes(attribute.String("pair", pair.String())))
+	}
+
+	now := time.Now()
+	d.noLiquidityMu.Lock()
+	if d.noLiquidityLogged == nil {
+		d.noLiquidityLogged = make(map[string]time.Time)
+	}
+	last, logged := d.noLiquidityLogged[pair.String()]
+	due := !logged || now.Sub(last) >= noLiquidityLogInterval
+	if due {
+		d.noLiquidityLogged[pair.String()] = now
+	}
+	d.noLiquidityMu.Unlock()
+
+	if due {
+		d.logger.Debug(ctx, "no liquidity on any venue, skipping pair",
+			"pair", pair.String(),
+			"error", err,
+		)
+	}
+}
+
+// skipReasonPaused marks blocks skipped while detection is paused.
+const skipReasonPaused = "paused"
+
+// skipReasonSafetyHalt marks blocks skipped while a safety check halts detection.
+const skipReasonSafetyHalt = "safety_halt"
+
+// staleSource reports which side of the snapshot ("cex" or "dex") is older
+// than maxAge, and its age. A zero maxAge disables the check.
+func staleSource(snapshot *pricingDomain.PriceSnapshot, maxAge time.Duration) (string, time.Duration, bool) {
+	if maxAge <= 0 {
+		return "", 0, false
+	}
+	if age := snapshot.CEXAsk.Rate.Age(); age > maxAge {
+		return "cex", age, true
+	}
+	if age := snapshot.DEXQuote.Price.Age(); age > maxAge {
+		return "dex", age, true
+	}
+	return "", 0, false
+}
+
+// recordProfit adds net profit to the available counter when the thresholds
+// pass, or to the missed counter when it was positive but below them.
+func (d *Detector) recordProfit(ctx context.Context, pair pricingDomain.Pair, profit *domain.ProfitResult) {
+	if d.metrics == nil || !profit.NetProfitRaw.IsPositive() {
+		return
+	}
+	attrs := metric.WithAttributes(attribute.String("pair", pair.String()))
+	netProfit := profit.NetProfitRaw.InexactFloat64()
+	if profit.IsProfitable {
+		d.metrics.profitAvailableUSD.Add(ctx, netProfit, attrs)
+	} else {
+		d.metrics.profitMissedUSD.Add(ctx, netProfit, attrs)
+	}
+}
+
+// Shutdown stops taking new blocks, waits for the analysis in flight to
+// finish or ctx to expire, then stops the reporter chain, which flushes
+// batched storage and waits for pending alert deliveries.
+func (d *Detector) Shutdown(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stopCh) })
+
+	if d.done != nil {
+		select {
+		case <-d.done:
+		case <-ctx.Done():
+			d.logger.Warn(ctx, "shutdown deadline reached with analysis in flight", "error", ctx.Err())
+		}
+	}
+
+	return d.Stop()
+}
+
 // Stop gracefully shuts down the detector.
 func (d *Detector) Stop() error {
 	d.logger.Info(context.Background(), "stopping arbitrage detector")
@@ -409,29 +1248,40 @@ func (d *Detector) Stop() error {
 func (d *Detector) buildExecutionSteps(opp *domain.Opportunity) []domain.ExecutionStep {
 	steps := make([]domain.ExecutionStep, 0, 5)
 
-	// Get fee tier percentage for display
+	// Get fee tier percentage and protocol for display
 	feeTierPct := "0.30%"
+	dexName := pricingDomain.VenueDisplayName(pricingDomain.ProtocolUniswapV3)
 	if opp.DEXQuote != nil {
 		feeTierPct = opp.DEXQuote.FeeTierPercent()
+		if opp.DEXQuote.Protocol != "" {
+			dexName = pricingDomain.VenueDisplayName(opp.DEXQuote.Protocol)
+		}
 	}
 
 	// Calculate expected output
 	expectedOutput := opp.TradeSize.Mul(opp.DEXPrice)
 
+	// CEX venue name for display
+	cexVenue := pricingDomain.VenueDisplayName(opp.CEXVenue)
+
+	// Pools swap WETH where the CEX moves native ETH
+	dexBase := asset.DEXAsset(opp.Pair.Base).Symbol()
+	dexQuote := asset.DEXAsset(opp.Pair.Quote).Symbol()
+
 	if opp.Direction == domain.DirectionCEXToDEX {
 		// Buy on CEX, sell on DEX
 		steps = append(steps,
 			domain.ExecutionStep{
 				Number:      1,
-				Description: fmt.Sprintf("Buy %s %s on Binance at $%s", opp.TradeSize.StringFixed(4), opp.Pair.Base.Symbol(), opp.CEXPrice.StringFixed(2)),
+				Description: fmt.Sprintf("Buy %s %s on %s at $%s", opp.TradeSize.StringFixed(4), opp.Pair.Base.Symbol(), cexVenue, opp.CEXPrice.StringFixed(2)),
 			},
 			domain.ExecutionStep{
 				Number:      2,
-				Description: fmt.Sprintf("Transfer %s to trading wallet", opp.Pair.Base.Symbol()),
+				Description: fmt.Sprintf("Transfer %s to trading wallet%s", opp.Pair.Base.Symbol(), wrapNote(opp.Pair.Base, "wrap to")),
 			},
 			domain.ExecutionStep{
 				Number:      3,
-				Description: fmt.Sprintf("Execute Uniswap V3 swap: %s → %s via %s pool", opp.Pair.Base.Symbol(), opp.Pair.Quote.Symbol(), feeTierPct),
+				Description: fmt.Sprintf("Execute %s swap: %s → %s via %s pool", dexName, dexBase, dexQuote, feeTierPct),
 			},
 			domain.ExecutionStep{
 				Number:      4,
@@ -439,7 +1289,7 @@ func (d *Detector) buildExecutionSteps(opp *domain.Opportunity) []domain.Executi
 			},
 			domain.ExecutionStep{
 				Number:      5,
-				Description: fmt.Sprintf("Transfer %s back to Binance for next cycle", opp.Pair.Quote.Symbol()),
+				Description: fmt.Sprintf("Transfer %s back to %s for next cycle", opp.Pair.Quote.Symbol(), cexVenue),
 			},
 		)
 	} else {
@@ -447,7 +1297,7 @@ func (d *Detector) buildExecutionSteps(opp *domain.Opportunity) []domain.Executi
 		steps = append(steps,
 			domain.ExecutionStep{
 				Number:      1,
-				Description: fmt.Sprintf("Execute Uniswap V3 swap: %s → %s via %s pool", opp.Pair.Quote.Symbol(), opp.Pair.Base.Symbol(), feeTierPct),
+				Description: fmt.Sprintf("Execute %s swap: %s → %s via %s pool", dexName, dexQuote, dexBase, feeTierPct),
 			},
 			domain.ExecutionStep{
 				Number:      2,
@@ -455,11 +1305,11 @@ func (d *Detector) buildExecutionSteps(opp *domain.Opportunity) []domain.Executi
 			},
 			domain.ExecutionStep{
 				Number:      3,
-				Description: fmt.Sprintf("Transfer %s to Binance", opp.Pair.Base.Symbol()),
+				Description: fmt.Sprintf("Transfer %s to %s%s", opp.Pair.Base.Symbol(), cexVenue, wrapNote(opp.Pair.Base, "unwrapped from")),
 			},
 			domain.ExecutionStep{
 				Number:      4,
-				Description: fmt.Sprintf("Sell %s %s on Binance at $%s", opp.TradeSize.StringFixed(4), opp.Pair.Base.Symbol(), opp.CEXPrice.StringFixed(2)),
+				Description: fmt.Sprintf("Sell %s %s on %s at $%s", opp.TradeSize.StringFixed(4), opp.Pair.Base.Symbol(), cexVenue, opp.CEXPrice.StringFixed(2)),
 			},
 			domain.ExecutionStep{
 				Number:      5,
@@ -471,6 +1321,55 @@ func (d *Detector) buildExecutionSteps(opp *domain.Opportunity) []domain.Executi
 	return steps
 }
 
+// wrapNote describes the wrap or unwrap a native asset needs between the CEX
+// and DEX legs, or returns "" for tokens.
+func wrapNote(a *asset.Asset, verb string) string {
+	if !a.IsNative() {
+		return ""
+	}
+	return fmt.Sprintf(" (%s %s)", verb, asset.DEXAsset(a).Symbol())
+}
+
+// cexFillRisk returns an "Insufficient CEX Liquidity" risk when the CEX leg
+// was priced on a partial fill: the book held less than tradeSize on the
+// side the trade takes (asks when buying on the CEX, bids when selling).
+// Prices that don't carry their filled size are assumed fully filled.
+func cexFillRisk(snapshot *pricingDomain.PriceSnapshot, direction domain.Direction, tradeSize decimal.Decimal) (domain.RiskFactor, bool) {
+	price := snapshot.CEXAsk
+	if direction == domain.DirectionDEXToCEX {
+		price = snapshot.CEXBid
+	}
+	if price == nil {
+		return domain.RiskFactor{}, false
+	}
+	filled := price.Size.ToDecimal()
+	if filled.IsZero() || filled.GreaterThanOrEqual(tradeSize) {
+		return domain.RiskFactor{}, false
+	}
+	return domain.RiskFactor{
+		Name:        "Insufficient CEX Liquidity",
+		Description: fmt.Sprintf("%s book fills %s of %s %s", pricingDomain.VenueDisplayName(price.Source), filled.String(), tradeSize.String(), snapshot.Pair.Base.Symbol()),
+		Severity:    "high",
+	}, true
+}
+
+// stableBasisRisk returns a "Stablecoin Basis Risk" when the CEX leg was
+// priced from a book quoted in a different stable than the pair: the spread
+// assumes both hold their configured pegs.
+func stableBasisRisk(snapshot *pricingDomain.PriceSnapshot, stables *pricingApp.StableEquivalents) (domain.RiskFactor, bool) {
+	if snapshot.CEXQuote == nil {
+		return domain.RiskFactor{}, false
+	}
+	cexPeg, _ := stables.Peg(snapshot.CEXQuote.Symbol())
+	pairPeg, _ := stables.Peg(snapshot.Pair.Quote.Symbol())
+	return domain.RiskFactor{
+		Name: "Stablecoin Basis Risk",
+		Description: fmt.Sprintf("CEX priced in %s (peg %s) against %s (peg %s)",
+			snapshot.CEXQuote.Symbol(), cexPeg.String(), snapshot.Pair.Quote.Symbol(), pairPeg.String()),
+		Severity: "medium",
+	}, true
+}
+
 // buildRiskFactors creates the risk factors for an opportunity based on spread.
 func (d *Detector) buildRiskFactors(spread pricingDomain.Spread) []domain.RiskFactor {
 	risks := make([]domain.RiskFactor, 0, 3)
@@ -0,0 +1,422 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// mockLogger implements logger.LoggerInterface for testing.
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Info(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (m *mockLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+var _ logger.LoggerInterface = (*mockLogger)(nil)
+
+// recordingReporter counts the reporter calls made by the detector.
+type recordingReporter struct {
+	blocks      []uint64
+	connections int
+	reports     int
+	stopped     bool
+}
+
+func (r *recordingReporter) Start(ctx context.Context) error                  { return nil }
+func (r *recordingReporter) Report(opp *domain.Opportunity)                   { r.reports++ }
+func (r *recordingReporter) UpdatePrices(prices *pricingDomain.PriceSnapshot) {}
+func (r *recordingReporter) UpdateConnectionStatus(name string, connected bool, latency time.Duration) {
+	r.connections++
+}
+func (r *recordingReporter) UpdateBlock(blockNumber uint64)               { r.blocks = append(r.blocks, blockNumber) }
+func (r *recordingReporter) UpdateGasPrice(gweiPrice float64)             {}
+func (r *recordingReporter) UpdateCostBreakdown(breakdown *CostBreakdown) {}
+func (r *recordingReporter) Stop() error                                  { r.stopped = true; return nil }
+
+// TestDetector_SetPaused tests that a paused detector consumes blocks without
+// touching the blockchain or pricing services (both nil here).
+func TestDetector_SetPaused(t *testing.T) {
+	reporter := &recordingReporter{}
+	d := NewDetector(nil, nil, nil, reporter, DetectorConfig{}, &mockLogger{})
+
+	d.SetPaused(true)
+	if !d.Paused() {
+		t.Fatal("expected detector to be paused")
+	}
+
+	d.onNewBlock(context.Background(), &blockchainDomain.Block{Number: 100})
+	d.onNewBlock(context.Background(), &blockchainDomain.Block{Number: 101})
+
+	if len(reporter.blocks) != 2 || reporter.blocks[1] != 101 {
+		t.Errorf("expected both blocks to be reported, got %v", reporter.blocks)
+	}
+	if reporter.connections != 0 || reporter.reports != 0 {
+		t.Errorf("paused detector did pricing work: %d connection updates, %d reports",
+			reporter.connections, reporter.reports)
+	}
+
+	d.SetPaused(false)
+	if d.Paused() {
+		t.Error("expected detector to be resumed")
+	}
+}
+
+// TestDetector_ProcessBlockSpan tests that each block gets a processBlock
+// span, marked with why it was skipped.
+func TestDetector_ProcessBlockSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	d := NewDetector(nil, nil, nil, &recordingReporter{}, DetectorConfig{}, &mockLogger{})
+	d.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+
+	d.SetPaused(true)
+	d.onNewBlock(context.Background(), &blockchainDomain.Block{Number: 100})
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "processBlock" {
+		t.Fatalf("expected one processBlock span, got %d", len(spans))
+	}
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	if v, ok := attrs.Value("block_number"); !ok || v.AsInt64() != 100 {
+		t.Errorf("block_number = %v, want 100", v.AsInt64())
+	}
+	if v, ok := attrs.Value("skip_reason"); !ok || v.AsString() != skipReasonPaused {
+		t.Errorf("skip_reason = %q, want %q", v.AsString(), skipReasonPaused)
+	}
+}
+
+// TestDetector_Shutdown tests that Shutdown ends the run loop before
+// stopping the reporter, even while the app context is still live.
+func TestDetector_Shutdown(t *testing.T) {
+	reporter := &recordingReporter{}
+	d := NewDetector(nil, nil, nil, reporter, DetectorConfig{}, &mockLogger{})
+
+	d.done = make(chan struct{})
+	go d.run(context.Background(), make(chan *blockchainDomain.Block))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := d.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	select {
+	case <-d.done:
+	default:
+		t.Error("expected the run loop to have exited")
+	}
+	if !reporter.stopped {
+		t.Error("expected the reporter to be stopped")
+	}
+}
+
+// TestStaleSource tests that snapshots with a price older than the max age are flagged.
+func TestStaleSource(t *testing.T) {
+	snapshotAt := func(cexAge, dexAge time.Duration) *pricingDomain.PriceSnapshot {
+		rate := asset.NewPrice(asset.ETH, asset.USDC, decimal.NewFromInt(3000), time.Now().Add(-cexAge))
+		ask := pricingDomain.NewPrice(rate, asset.NewAmount(asset.ETH, big.NewInt(1e18)), pricingDomain.SideBuy, pricingDomain.VenueBinance)
+		quote := pricingDomain.NewQuote(asset.ETH, asset.USDC,
+			asset.NewAmount(asset.ETH, big.NewInt(1e18)), asset.NewAmount(asset.USDC, big.NewInt(3_000_000_000)), 0, 500)
+		quote.Price = asset.NewPrice(asset.ETH, asset.USDC, decimal.NewFromInt(3000), time.Now().Add(-dexAge))
+		return &pricingDomain.PriceSnapshot{CEXAsk: &ask, DEXQuote: &quote}
+	}
+
+	tests := []struct {
+		name       string
+		snapshot   *pricingDomain.PriceSnapshot
+		maxAge     time.Duration
+		wantSource string
+		wantStale  bool
+	}{
+		{name: "fresh", snapshot: snapshotAt(0, 0), maxAge: 2 * time.Second},
+		{name: "stale_cex", snapshot: snapshotAt(3*time.Second, 0), maxAge: 2 * time.Second, wantSource: "cex", wantStale: true},
+		{name: "stale_dex", snapshot: snapshotAt(0, 3*time.Second), maxAge: 2 * time.Second, wantSource: "dex", wantStale: true},
+		{name: "disabled", snapshot: snapshotAt(time.Minute, time.Minute), maxAge: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, _, stale := staleSource(tt.snapshot, tt.maxAge)
+			if stale != tt.wantStale || source != tt.wantSource {
+				t.Errorf("staleSource() = (%q, %v), want (%q, %v)", source, stale, tt.wantSource, tt.wantStale)
+			}
+		})
+	}
+}
+
+// TestDexLeg tests that buying base on the DEX is priced with the quote→base
+// quote rather than the sell quote.
+func TestDexLeg(t *testing.T) {
+	oneETH := asset.NewAmount(asset.ETH, big.NewInt(1e18))
+	// Sells 1 ETH for 2991 USDC
+	sell := pricingDomain.NewQuote(asset.ETH, asset.USDC, oneETH, asset.NewAmount(asset.USDC, big.NewInt(2_991_000_000)), 0, 3000)
+	// Spends 3009 USDC for 1 ETH
+	buy := pricingDomain.NewQuote(asset.USDC, asset.ETH, asset.NewAmount(asset.USDC, big.NewInt(3_009_000_000)), oneETH, 0, 3000)
+
+	tests := []struct {
+		name          string
+		cex           string
+		buyQuote      *pricingDomain.Quote
+		wantBuy       bool
+		wantPrice     string
+		wantDirection pricingDomain.SpreadDirection
+	}{
+		{name: "dex_sell", cex: "2980", buyQuote: &buy, wantPrice: "2991", wantDirection: pricingDomain.SpreadCEXToDEX},
+		{name: "dex_buy", cex: "3020", buyQuote: &buy, wantBuy: true, wantPrice: "3009", wantDirection: pricingDomain.SpreadDEXToCEX},
+		// Below the DEX's sell price but above neither side of its spread
+		{name: "inside_dex_spread", cex: "3000", buyQuote: &buy, wantBuy: true, wantPrice: "3009", wantDirection: pricingDomain.SpreadNone},
+		{name: "no_buy_quote", cex: "3000", wantPrice: "2991", wantDirection: pricingDomain.SpreadDEXToCEX},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := &pricingDomain.PriceSnapshot{DEXQuote: &sell, DEXBuyQuote: tt.buyQuote}
+			quote, price, spread := dexLeg(snapshot, decimal.RequireFromString(tt.cex))
+
+			if wantQuote := snapshot.DEXQuote; tt.wantBuy {
+				if quote != tt.buyQuote {
+					t.Errorf("expected the buy quote, got %s→%s", quote.TokenIn.Symbol(), quote.TokenOut.Symbol())
+				}
+			} else if quote != wantQuote {
+				t.Errorf("expected the sell quote, got %s→%s", quote.TokenIn.Symbol(), quote.TokenOut.Symbol())
+			}
+			if !price.Equal(decimal.RequireFromString(tt.wantPrice)) {
+				t.Errorf("dex price = %s, want %s", price, tt.wantPrice)
+			}
+			if spread.Direction != tt.wantDirection {
+				t.Errorf("direction = %s, want %s", spread.Direction, tt.wantDirection)
+			}
+		})
+	}
+}
+
+// TestCexFillRisk tests that a CEX leg priced on a partial fill is flagged
+// on the side the trade takes.
+func TestCexFillRisk(t *testing.T) {
+	pair := pricingDomain.NewPair(asset.ETH, asset.USDC)
+	price := func(filled string) *pricingDomain.Price {
+		size, _ := asset.ParseDecimal(asset.ETH, decimal.RequireFromString(filled))
+		rate := asset.NewPriceNow(asset.ETH, asset.USDC, decimal.NewFromInt(3000))
+		p := pricingDomain.NewPrice(rate, size, pricingDomain.SideBuy, pricingDomain.VenueBinance)
+		return &p
+	}
+	snapshot := &pricingDomain.PriceSnapshot{Pair: pair, CEXAsk: price("0.4"), CEXBid: price("1")}
+	size := decimal.NewFromInt(1)
+
+	risk, ok := cexFillRisk(snapshot, domain.DirectionCEXToDEX, size)
+	if !ok || risk.Name != "Insufficient CEX Liquidity" {
+		t.Fatalf("expected a thin ask book flagged, got %+v", risk)
+	}
+	if _, ok := cexFillRisk(snapshot, domain.DirectionDEXToCEX, size); ok {
+		t.Error("expected a fully filled bid not flagged")
+	}
+	if _, ok := cexFillRisk(&pricingDomain.PriceSnapshot{Pair: pair, CEXAsk: &pricingDomain.Price{}}, domain.DirectionCEXToDEX, size); ok {
+		t.Error("expected a price without a filled size not flagged")
+	}
+}
+
+// TestStableBasisRisk tests that a CEX leg priced in another stable is
+// flagged with both pegs.
+func TestStableBasisRisk(t *testing.T) {
+	stables := pricingApp.NewStableEquivalents(map[string]decimal.Decimal{
+		"USDC": decimal.NewFromInt(1),
+		"USDT": decimal.RequireFromString("0.999"),
+	}, "", asset.DefaultRegistry())
+	pair := pricingDomain.NewPair(asset.ETH, asset.USDC)
+
+	risk, ok := stableBasisRisk(&pricingDomain.PriceSnapshot{Pair: pair, CEXQuote: asset.USDT}, stables)
+	if !ok || risk.Name != "Stablecoin Basis Risk" {
+		t.Fatalf("expected a USDT-priced CEX leg flagged, got %+v", risk)
+	}
+	if want := "CEX priced in USDT (peg 0.999) against USDC (peg 1)"; risk.Description != want {
+		t.Errorf("expected description %q, got %q", want, risk.Description)
+	}
+	if _, ok := stableBasisRisk(&pricingDomain.PriceSnapshot{Pair: pair}, stables); ok {
+		t.Error("expected a CEX leg in the pair's own quote not flagged")
+	}
+}
+
+// debugCounter is a mockLogger counting debug messages.
+type debugCounter struct {
+	mockLogger
+	debugs int
+}
+
+func (l *debugCounter) Debug(ctx context.Context, msg string, args ...any) { l.debugs++ }
+
+// TestDetector_SkipNoLiquidity tests that a dead pair logs once per interval
+// rather than on every block.
+func TestDetector_SkipNoLiquidity(t *testing.T) {
+	log := &debugCounter{}
+	d := NewDetector(nil, nil, nil, &recordingReporter{}, DetectorConfig{}, log)
+	span := trace.SpanFromContext(context.Background())
+	eth := pricingDomain.NewPair(asset.ETH, asset.USDC)
+	btc := pricingDomain.NewPair(asset.WBTC, asset.USDC)
+
+	for range 3 {
+		d.skipNoLiquidity(context.Background(), span, eth, nil)
+	}
+	d.skipNoLiquidity(context.Background(), span, btc, nil)
+	if log.debugs != 2 {
+		t.Fatalf("expected one log per pair, got %d", log.debugs)
+	}
+
+	d.noLiquidityLogged[eth.String()] = time.Now().Add(-noLiquidityLogInterval)
+	d.skipNoLiquidity(context.Background(), span, eth, nil)
+	if log.debugs != 3 {
+		t.Errorf("expected the pair logged again after the interval, got %d logs", log.debugs)
+	}
+}
+
+// warnCounter counts warnings.
+type warnCounter struct {
+	mockLogger
+	warns int
+}
+
+func (l *warnCounter) Warn(ctx context.Context, msg string, args ...any) { l.warns++ }
+
+// TestDetector_RefreshETHPrice tests that the ETH price comes from the
+// configured feed, and that scanned pairs stand in while it is down.
+func TestDetector_RefreshETHPrice(t *testing.T) {
+	cex := &fakeCEX{asks: map[string]decimal.Decimal{ethUSDC.String(): decimal.NewFromInt(2500)}}
+	log := &warnCounter{}
+	d := NewDetector(nil, pricingApp.NewPricingService(cex, &fakeDEX{}), nil, &recordingReporter{}, DetectorConfig{ETHPricePair: &ethUSDC}, log)
+
+	d.refreshETHPrice(context.Background(), &ethUSDC)
+	if !d.ethPriceUSD.Equal(decimal.NewFromInt(2500)) {
+		t.Fatalf("expected the feed's ask, got %s", d.ethPriceUSD)
+	}
+	if !d.ethFeedActive() || !d.safetyETHPrice(context.Background()).Equal(decimal.NewFromInt(2500)) {
+		t.Error("expected the safety checks to watch the feed's price")
+	}
+
+	delete(cex.asks, ethUSDC.String())
+	for range 3 {
+		d.refreshETHPrice(context.Background(), &ethUSDC)
+	}
+	if !d.ethPriceUSD.Equal(decimal.NewFromInt(2500)) {
+		t.Errorf("expected the last price kept when the feed fails, got %s", d.ethPriceUSD)
+	}
+	if d.ethFeedActive() {
+		t.Error("expected scanned ETH pairs to stand in while the feed is down")
+	}
+	if log.warns != 1 {
+		t.Errorf("expected only the first failure warned about, got %d warnings", log.warns)
+	}
+
+	cex.asks[ethUSDC.String()] = decimal.NewFromInt(2600)
+	d.refreshETHPrice(context.Background(), &ethUSDC)
+	if !d.ethFeedActive() || !d.ethPriceUSD.Equal(decimal.NewFromInt(2600)) {
+		t.Errorf("expected the feed to take over again once it recovers, got %s", d.ethPriceUSD)
+	}
+}
+
+func TestPricesETHInUSD(t *testing.T) {
+	tests := []struct {
+		pair pricingDomain.Pair
+		want bool
+	}{
+		{pair: ethUSDC, want: true},
+		{pair: pricingDomain.NewPair(asset.ETH, asset.USDT), want: true},
+		{pair: pricingDomain.NewPair(asset.ETH, asset.WBTC), want: false},
+		{pair: wbtcUSDC, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pair.String(), func(t *testing.T) {
+			if got := pricesETHInUSD(tt.pair); got != tt.want {
+				t.Errorf("pricesETHInUSD(%s) = %v, want %v", tt.pair, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGasLimitFor(t *testing.T) {
+	withEstimate := &pricingDomain.Quote{GasEstimate: 350_000}
+
+	tests := []struct {
+		name         string
+		quote        *pricingDomain.Quote
+		defaultLimit uint64
+		want         uint64
+	}{
+		{name: "quote_estimate", quote: withEstimate, defaultLimit: 180_000, want: 350_000},
+		{name: "configured_default", quote: &pricingDomain.Quote{}, defaultLimit: 180_000, want: 180_000},
+		{name: "no_quote", defaultLimit: 180_000, want: 180_000},
+		{name: "fallback", quote: &pricingDomain.Quote{}, want: swapGasLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gasLimitFor(tt.quote, tt.defaultLimit); got != tt.want {
+				t.Errorf("gasLimitFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetSpreadBps(t *testing.T) {
+	tests := []struct {
+		name       string
+		cex, dex   string
+		costs      string
+		tradeValue string
+		want       string
+	}{
+		// 50 bps gross, $30 on $10k is 30 bps
+		{name: "actionable", cex: "1000", dex: "1005", costs: "30", tradeValue: "10000", want: "20"},
+		{name: "dex_below_cex", cex: "1000", dex: "995", costs: "30", tradeValue: "10000", want: "20"},
+		{name: "costs_exceed_spread", cex: "1000", dex: "1002", costs: "30", tradeValue: "10000", want: "-10"},
+		{name: "no_trade_value", cex: "1000", dex: "1005", costs: "30", tradeValue: "0", want: "50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spread := pricingDomain.CalculateSpread(decimal.RequireFromString(tt.cex), decimal.RequireFromString(tt.dex))
+			got := netSpreadBps(spread, decimal.RequireFromString(tt.costs), decimal.RequireFromString(tt.tradeValue))
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("netSpreadBps() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapGasFor(t *testing.T) {
+	tests := []struct {
+		name string
+		pair pricingDomain.Pair
+		want uint64
+	}{
+		{name: "native_base", pair: pricingDomain.NewPair(asset.ETH, asset.USDC), want: wrapGasLimit},
+		{name: "wrapped_base", pair: pricingDomain.NewPair(asset.WETH, asset.USDC), want: 0},
+		{name: "no_eth", pair: pricingDomain.NewPair(asset.WBTC, asset.USDC), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapGasFor(tt.pair); got != tt.want {
+				t.Errorf("wrapGasFor(%s) = %d, want %d", tt.pair, got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
 	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 	"github.com/shopspring/decimal"
 )
 
@@ -15,12 +16,28 @@ import (
 type CostBreakdown struct {
 	TradeSize     string
 	TradeValueUSD decimal.Decimal
+	NotionalUSD   decimal.Decimal // Configured USD size TradeSize was converted from (zero = sized in base units)
 	GrossProfit   decimal.Decimal
 	GasCostUSD    decimal.Decimal
 	ExchangeFees  decimal.Decimal
+	Overheads     domain.Overheads // Approval gas and CEX transfer fees
 	TotalCosts    decimal.Decimal
 	NetProfit     decimal.Decimal
 	IsProfitable  bool
+
+	// NetSpreadBps is the spread left after costs: the gross spread
+	// magnitude minus total costs in bps of the trade value
+	NetSpreadBps decimal.Decimal
+
+	// SizeSpreads holds the net spread of every size analyzed for the pair,
+	// so displays can show it next to each size's raw spread
+	SizeSpreads []SizeSpread
+}
+
+// SizeSpread is the net spread of one analyzed trade size.
+type SizeSpread struct {
+	TradeSize    decimal.Decimal // Base-asset amount
+	NetSpreadBps decimal.Decimal
 }
 
 // Reporter defines the interface for reporting arbitrage opportunities.
@@ -50,3 +67,44 @@ type Reporter interface {
 	// Stop gracefully shuts down the reporter.
 	Stop() error
 }
+
+// SummaryReporter is implemented by reporters that display or deliver
+// periodic profit summaries. Decorators forward summaries to the reporter
+// they wrap when it implements this.
+type SummaryReporter interface {
+	// ReportSummary handles the summary of one aggregation interval.
+	ReportSummary(summary *domain.ProfitSummary)
+}
+
+// SafetyReporter is implemented by reporters that display or deliver safety
+// halts and resumes. Decorators forward events to the reporter they wrap
+// when it implements this.
+type SafetyReporter interface {
+	// ReportSafety handles detection halting or resuming.
+	ReportSafety(event *domain.SafetyEvent)
+}
+
+// GasPercentileReporter is implemented by reporters that show how the gas
+// price ranks among recent ones. Decorators forward ranks to the reporter
+// they wrap when it implements this.
+type GasPercentileReporter interface {
+	// UpdateGasPercentile handles the gas price and its percentile rank
+	// (0-100) among recently fetched prices.
+	UpdateGasPercentile(gweiPrice, percentileRank float64)
+}
+
+// ConnectionStatsReporter is implemented by reporters that show how stable
+// each data source's connection has been this session. Decorators forward
+// stats to the reporter they wrap when it implements this.
+type ConnectionStatsReporter interface {
+	// UpdateConnectionStats handles a source's uptime, reconnect count and
+	// pending reconnect backoff.
+	UpdateConnectionStats(name string, stats health.ConnectionStats)
+}
+
+// ReadinessGate is flipped once the detector is producing results
+// (e.g. the health server's /ready endpoint).
+type ReadinessGate interface {
+	// SetReady marks the service as ready (or not) to serve traffic.
+	SetReady(ready bool)
+}
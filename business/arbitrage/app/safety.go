@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/shopspring/decimal"
+)
+
+// SafetyConfig holds the conditions that halt detection. Each check is off
+// at its zero value.
+type SafetyConfig struct {
+	// MaxGasGwei halts detection while gas is above this price
+	MaxGasGwei float64
+
+	// MaxPriceMovePct halts detection when the ETH price moves more than
+	// this percentage from one block to the next
+	MaxPriceMovePct decimal.Decimal
+
+	// HaltOnDisconnect halts detection while neither the CEX feed nor the
+	// DEX can be priced
+	HaltOnDisconnect bool
+
+	// ResumeAfter is how many consecutive clear blocks detection waits for
+	// before resuming (0 = the first clear block)
+	ResumeAfter int
+}
+
+// Enabled reports whether any safety check is on.
+func (c SafetyConfig) Enabled() bool {
+	return c.MaxGasGwei > 0 || c.MaxPriceMovePct.IsPositive() || c.HaltOnDisconnect
+}
+
+// SafetyCheck is the state of one block that safety conditions are checked on.
+type SafetyCheck struct {
+	BlockNumber  uint64
+	GasGwei      float64
+	ETHPriceUSD  decimal.Decimal // Zero when unknown (the move check is skipped)
+	CEXConnected bool
+	DEXAvailable bool
+}
+
+// SafetyMonitor is a kill switch evaluated on every block. Once a condition
+// trips, detection stays halted until conditions have been clear for
+// ResumeAfter blocks.
+type SafetyMonitor struct {
+	mu         sync.Mutex
+	config     SafetyConfig
+	halted     bool
+	clear      int             // Consecutive clear blocks while halted
+	lastETHUSD decimal.Decimal // ETH price of the previous block with one
+}
+
+// NewSafetyMonitor creates a SafetyMonitor checking cfg's conditions.
+func NewSafetyMonitor(cfg SafetyConfig) *SafetyMonitor {
+	return &SafetyMonitor{config: cfg}
+}
+
+// SetConfig replaces the conditions checked from the next block on.
+func (m *SafetyMonitor) SetConfig(cfg SafetyConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config = cfg
+}
+
+// Enabled reports whether any safety check is on.
+func (m *SafetyMonitor) Enabled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.config.Enabled()
+}
+
+// Halted reports whether detection is halted.
+func (m *SafetyMonitor) Halted() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.halted
+}
+
+// Evaluate checks one block. It returns an event when the block halts or
+// resumes detection, and nil when the state is unchanged.
+func (m *SafetyMonitor) Evaluate(check SafetyCheck) *domain.SafetyEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reasons := m.trippedConditions(check)
+	if len(reasons) > 0 {
+		m.clear = 0
+		if m.halted {
+			return nil
+		}
+		m.halted = true
+		return &domain.SafetyEvent{BlockNumber: check.BlockNumber, Timestamp: time.Now(), Halted: true, Reasons: reasons}
+	}
+
+	if !m.halted {
+		return nil
+	}
+	m.clear++
+	if m.clear < max(m.config.ResumeAfter, 1) {
+		return nil
+	}
+	m.halted = false
+	m.clear = 0
+	return &domain.SafetyEvent{BlockNumber: check.BlockNumber, Timestamp: time.Now()}
+}
+
+// trippedConditions describes each configured condition check breaks, and
+// remembers its ETH price for the next block. Callers hold m.mu.
+func (m *SafetyMonitor) trippedConditions(check SafetyCheck) []string {
+	var reasons []string
+
+	if m.config.MaxGasGwei > 0 && check.GasGwei > m.config.MaxGasGwei {
+		reasons = append(reasons, fmt.Sprintf("gas %.1f gwei above the %.1f gwei ceiling", check.GasGwei, m.config.MaxGasGwei))
+	}
+
+	if check.ETHPriceUSD.IsPositive() {
+		if m.config.MaxPriceMovePct.IsPositive() && m.lastETHUSD.IsPositive() {
+			move := check.ETHPriceUSD.Sub(m.lastETHUSD).Abs().Div(m.lastETHUSD).Mul(decimal.NewFromInt(100))
+			if move.GreaterThan(m.config.MaxPriceMovePct) {
+				reasons = append(reasons, fmt.Sprintf("ETH moved %s%% in one block (%s -> %s)",
+					move.StringFixed(2), m.lastETHUSD.StringFixed(2), check.ETHPriceUSD.StringFixed(2)))
+			}
+		}
+		m.lastETHUSD = check.ETHPriceUSD
+	}
+
+	if m.config.HaltOnDisconnect && !check.CEXConnected && !check.DEXAvailable {
+		reasons = append(reasons, "CEX and DEX both unavailable")
+	}
+
+	return reasons
+}
+
+// OnSafetyHalt sets a callback run whenever a safety condition halts
+// detection, e.g. to shut the bot down.
+func (d *Detector) OnSafetyHalt(fn func(event *domain.SafetyEvent)) {
+	d.onSafetyHalt = fn
+}
+
+// SafetyHalted reports whether a safety condition has halted detection.
+func (d *Detector) SafetyHalted() bool {
+	return d.safety.Halted()
+}
+
+// checkSafety evaluates the block's safety conditions, reporting halts and
+// resumes. It returns whether detection is halted for the block.
+func (d *Detector) checkSafety(ctx context.Context, block *blockchainDomain.Block, gasGwei float64) bool {
+	if !d.safety.Enabled() && !d.safety.Halted() {
+		return false
+	}
+
+	check := SafetyCheck{
+		BlockNumber:  block.Number,
+		GasGwei:      gasGwei,
+		ETHPriceUSD:  d.safetyETHPrice(ctx),
+		CEXConnected: d.pricing.CEXConnected(),
+		DEXAvailable: d.pricing.DEXAvailable(),
+	}
+
+	event := d.safety.Evaluate(check)
+	if event == nil {
+		return d.safety.Halted()
+	}
+
+	if event.Halted {
+		d.logger.Error(ctx, "safety check tripped, halting detection",
+			"block", event.BlockNumber,
+			"reasons", event.Reasons)
+	} else {
+		d.logger.Info(ctx, "safety conditions cleared, resuming detection", "block", event.BlockNumber)
+	}
+	if sr, ok := d.reporter.(SafetyReporter); ok {
+		sr.ReportSafety(event)
+	}
+	if event.Halted && d.onSafetyHalt != nil {
+		d.onSafetyHalt(event)
+	}
+	return event.Halted
+}
+
+// safetyETHPrice returns the ETH price from the ETHPricePair feed, or
+// while it is down the CEX ask of the first configured pair pricing ETH in
+// USD; zero when neither does.
+func (d *Detector) safetyETHPrice(ctx context.Context) decimal.Decimal {
+	if d.ethFeedActive() {
+		return d.ethPriceUSD
+	}
+	for _, pair := range d.getConfig().Pairs {
+		if !asset.SameUnderlying(pair.Base, asset.ETH) || !IsUSDLike(pair.Quote) {
+			continue
+		}
+		if price, err := d.basePriceUSD(ctx, pair); err == nil {
+			return price
+		}
+	}
+	return decimal.Zero
+}
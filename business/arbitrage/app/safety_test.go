@@ -0,0 +1,114 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// clearCheck is a block on which no safety condition holds.
+func clearCheck(block uint64) SafetyCheck {
+	return SafetyCheck{
+		BlockNumber:  block,
+		GasGwei:      20,
+		ETHPriceUSD:  decimal.NewFromInt(3000),
+		CEXConnected: true,
+		DEXAvailable: true,
+	}
+}
+
+func TestSafetyMonitor_GasCeilingAndResume(t *testing.T) {
+	m := NewSafetyMonitor(SafetyConfig{MaxGasGwei: 100, ResumeAfter: 2})
+
+	if event := m.Evaluate(clearCheck(1)); event != nil || m.Halted() {
+		t.Fatalf("expected a clear block to leave detection running, got %+v", event)
+	}
+
+	spike := clearCheck(2)
+	spike.GasGwei = 250
+	event := m.Evaluate(spike)
+	if event == nil || !event.Halted || !m.Halted() {
+		t.Fatalf("expected the gas spike to halt detection, got %+v", event)
+	}
+	if len(event.Reasons) != 1 || !strings.Contains(event.Reasons[0], "250.0 gwei") {
+		t.Errorf("expected the gas reason, got %v", event.Reasons)
+	}
+
+	spike.BlockNumber = 3
+	if event := m.Evaluate(spike); event != nil {
+		t.Errorf("expected no new event while still halted, got %+v", event)
+	}
+
+	if event := m.Evaluate(clearCheck(4)); event != nil || !m.Halted() {
+		t.Fatalf("expected detection to wait for a second clear block, got %+v", event)
+	}
+	event = m.Evaluate(clearCheck(5))
+	if event == nil || event.Halted || m.Halted() {
+		t.Fatalf("expected detection to resume, got %+v", event)
+	}
+	if event.BlockNumber != 5 {
+		t.Errorf("expected the resume at block 5, got %d", event.BlockNumber)
+	}
+}
+
+func TestSafetyMonitor_PriceMove(t *testing.T) {
+	m := NewSafetyMonitor(SafetyConfig{MaxPriceMovePct: decimal.NewFromInt(5)})
+
+	m.Evaluate(clearCheck(1))
+
+	moved := clearCheck(2)
+	moved.ETHPriceUSD = decimal.NewFromInt(3120) // +4%
+	if event := m.Evaluate(moved); event != nil {
+		t.Fatalf("expected a 4%% move allowed, got %+v", event)
+	}
+
+	// A block without a price neither trips nor resets the reference
+	unknown := clearCheck(3)
+	unknown.ETHPriceUSD = decimal.Zero
+	if event := m.Evaluate(unknown); event != nil {
+		t.Fatalf("expected an unknown price skipped, got %+v", event)
+	}
+
+	crash := clearCheck(4)
+	crash.ETHPriceUSD = decimal.NewFromInt(2808) // -10% from 3120
+	event := m.Evaluate(crash)
+	if event == nil || !event.Halted || !strings.Contains(event.Reasons[0], "10.00%") {
+		t.Fatalf("expected a 10%% move to halt detection, got %+v", event)
+	}
+
+	// Prices holding steady from the new level clear the condition
+	if event := m.Evaluate(SafetyCheck{BlockNumber: 5, ETHPriceUSD: decimal.NewFromInt(2810)}); event == nil || event.Halted {
+		t.Errorf("expected detection to resume, got %+v", event)
+	}
+}
+
+func TestSafetyMonitor_Disconnect(t *testing.T) {
+	m := NewSafetyMonitor(SafetyConfig{HaltOnDisconnect: true})
+
+	cexDown := clearCheck(1)
+	cexDown.CEXConnected = false
+	if event := m.Evaluate(cexDown); event != nil {
+		t.Fatalf("expected one source down not to halt detection, got %+v", event)
+	}
+
+	bothDown := cexDown
+	bothDown.DEXAvailable = false
+	if event := m.Evaluate(bothDown); event == nil || !event.Halted {
+		t.Fatalf("expected both sources down to halt detection, got %+v", event)
+	}
+}
+
+func TestSafetyMonitor_Disabled(t *testing.T) {
+	m := NewSafetyMonitor(SafetyConfig{})
+	if m.Enabled() {
+		t.Fatal("expected the zero config to check nothing")
+	}
+
+	check := clearCheck(1)
+	check.GasGwei = 10_000
+	check.CEXConnected, check.DEXAvailable = false, false
+	if event := m.Evaluate(check); event != nil {
+		t.Errorf("expected no halt with every check off, got %+v", event)
+	}
+}
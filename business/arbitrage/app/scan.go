@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+)
+
+// blockScan is the input of one block's pair analysis.
+type blockScan struct {
+	block     *blockchainDomain.Block
+	gasPrice  *blockchainDomain.GasPrice
+	sizes     map[string][]plannedSize // Trade sizes per pair, kept so USD sizes convert once per block
+	snapshots map[string]snapshotResult
+}
+
+// onScanTick re-evaluates every pair between blocks: fresh CEX prices
+// against the DEX quotes and gas price fetched for the last block. Blocks
+// still refresh quotes; this catches spreads opened by CEX moves alone
+// without another quoter round trip.
+func (d *Detector) onScanTick(ctx context.Context) {
+	last := d.lastScan
+	if last == nil || d.paused.Load() || d.safety.Halted() || d.isOrphaned(last.block) {
+		return
+	}
+
+	cfg := d.getConfig()
+	snapshots := make(map[string]snapshotResult, len(last.snapshots))
+	for _, pair := range cfg.Pairs {
+		for _, size := range last.sizes[pair.String()] {
+			key := snapshotKey(pair, size.base)
+			prev, ok := last.snapshots[key]
+			if !ok {
+				continue // Added by a reload; priced on the next block
+			}
+			if prev.err != nil {
+				snapshots[key] = prev
+				continue
+			}
+
+			// The quotes reflect the head block's state, which no newer
+			// block has replaced. They keep the time they were fetched, so
+			// MaxSnapshotAge still skips them once the block feed stalls
+			snapshot, err := d.pricing.GetPriceSnapshotWithQuote(ctx, pair, size.base,
+				prev.snapshot.DEXQuote, prev.snapshot.DEXBuyQuote)
+			snapshots[key] = snapshotResult{snapshot: snapshot, err: err}
+		}
+	}
+
+	// No block arrival to measure report latency from
+	block := *last.block
+	block.ReceivedAt = time.Time{}
+
+	d.logger.Debug(ctx, "interval scan", "block", block.Number)
+	d.scanPairs(ctx, &block, last.gasPrice, last.sizes, snapshots)
+}
+
+// scanTicker drives interval scans. Its channel is nil, and so never
+// fires, while interval scans are disabled.
+type scanTicker struct {
+	ticker   *time.Ticker
+	interval time.Duration
+}
+
+// C returns the tick channel.
+func (t *scanTicker) C() <-chan time.Time {
+	if t.ticker == nil {
+		return nil
+	}
+	return t.ticker.C
+}
+
+// sync follows interval changes from config reloads.
+func (t *scanTicker) sync(interval time.Duration) {
+	if interval == t.interval {
+		return
+	}
+	t.stop()
+	t.interval = interval
+	if interval > 0 {
+		t.ticker = time.NewTicker(interval)
+	}
+}
+
+// reset restarts the interval after a block scan, which just priced everything.
+func (t *scanTicker) reset() {
+	if t.ticker != nil {
+		t.ticker.Reset(t.interval)
+	}
+}
+
+func (t *scanTicker) stop() {
+	if t.ticker != nil {
+		t.ticker.Stop()
+		t.ticker = nil
+	}
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// TestScanTicker tests that the ticker only fires while an interval is set
+// and follows interval changes.
+func TestScanTicker(t *testing.T) {
+	var ticker scanTicker
+	defer ticker.stop()
+
+	if ticker.C() != nil {
+		t.Fatal("expected no tick channel while disabled")
+	}
+
+	ticker.sync(5 * time.Millisecond)
+	select {
+	case <-ticker.C():
+	case <-time.After(time.Second):
+		t.Fatal("expected a tick after enabling")
+	}
+
+	ticker.sync(0)
+	if ticker.C() != nil {
+		t.Error("expected no tick channel after disabling")
+	}
+}
+
+// TestDetector_OnScanTickWithoutBlock tests that interval scans wait for a
+// first block to re-price (pricing service is nil here).
+func TestDetector_OnScanTickWithoutBlock(t *testing.T) {
+	reporter := &recordingReporter{}
+	d := NewDetector(nil, nil, nil, reporter, DetectorConfig{ScanInterval: time.Second}, &mockLogger{})
+
+	d.onScanTick(context.Background())
+
+	// Paused detectors keep the last block but skip interval scans too
+	d.lastScan = &blockScan{block: &blockchainDomain.Block{Number: 100}}
+	d.SetPaused(true)
+	d.onScanTick(context.Background())
+
+	if reporter.connections != 0 || reporter.reports != 0 {
+		t.Errorf("interval scan did pricing work: %d connection updates, %d reports",
+			reporter.connections, reporter.reports)
+	}
+}
+
+// breakdownReporter counts the cost breakdowns sent to the UI.
+type breakdownReporter struct {
+	recordingReporter
+	breakdowns int
+}
+
+func (r *breakdownReporter) UpdateCostBreakdown(breakdown *CostBreakdown) { r.breakdowns++ }
+
+// TestDetector_OnScanTickStaleQuote tests that interval scans keep the DEX
+// quote's fetch time, so a stalled block feed trips MaxSnapshotAge.
+func TestDetector_OnScanTickStaleQuote(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		quoteAge     time.Duration
+		wantAnalyzed bool
+	}{
+		{name: "fresh", quoteAge: 0, wantAnalyzed: true},
+		{name: "stalled_feed", quoteAge: time.Minute, wantAnalyzed: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cex := &fakeCEX{
+				bids: map[string]decimal.Decimal{ethUSDC.String(): decimal.NewFromInt(2999)},
+				asks: map[string]decimal.Decimal{ethUSDC.String(): decimal.NewFromInt(3000)},
+			}
+			reporter := &breakdownReporter{}
+			cfg := DetectorConfig{Pairs: []pricingDomain.Pair{ethUSDC}, ScanInterval: time.Second, MaxSnapshotAge: 2 * time.Second}
+			d := NewDetector(nil, pricingApp.NewPricingService(cex, &fakeDEX{}), NewProfitCalculator(decimal.Zero, decimal.Zero), reporter, cfg, &mockLogger{})
+
+			oneETH := asset.NewAmount(asset.ETH, big.NewInt(1e18))
+			quote := pricingDomain.NewQuote(asset.ETH, asset.USDC, oneETH, asset.NewAmount(asset.USDC, big.NewInt(3_010_000_000)), 0, 500)
+			quote.Price = asset.NewPrice(asset.ETH, asset.USDC, decimal.NewFromInt(3010), time.Now().Add(-tt.quoteAge))
+
+			size := plannedSize{base: decimal.NewFromInt(1)}
+			d.lastScan = &blockScan{
+				block:    &blockchainDomain.Block{Number: 100},
+				gasPrice: blockchainDomain.NewGasPrice(big.NewInt(1_000_000_000)),
+				sizes:    map[string][]plannedSize{ethUSDC.String(): {size}},
+				snapshots: map[string]snapshotResult{
+					snapshotKey(ethUSDC, size.base): {snapshot: &pricingDomain.PriceSnapshot{DEXQuote: &quote}},
+				},
+			}
+
+			d.onScanTick(context.Background())
+			if analyzed := reporter.breakdowns > 0; analyzed != tt.wantAnalyzed {
+				t.Errorf("analyzed = %v, want %v", analyzed, tt.wantAnalyzed)
+			}
+		})
+	}
+}
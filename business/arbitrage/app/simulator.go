@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SimulateExecution re-prices an opportunity as if both legs were executed.
+// The CEX leg walks the current book on the side it trades: asks when buying
+// on the CEX, bids when selling. The DEX leg uses the quote's effective price;
+// the quote sells the base, so buying it on the DEX is approximated by
+// mirroring the pool's price impact to the other side of the curve.
+func (d *Detector) SimulateExecution(ctx context.Context, opp *domain.Opportunity) (*domain.SimulationResult, error) {
+	ctx, span := d.tracer.Start(ctx, "simulateExecution",
+		trace.WithAttributes(
+			attribute.String("pair", opp.Pair.String()),
+			attribute.String("direction", string(opp.Direction)),
+			attribute.String("trade_size", opp.TradeSize.String()),
+		),
+	)
+	defer span.End()
+
+	if opp.IsTriangular() || opp.Profit == nil {
+		return nil, apperror.New(apperror.CodeInvalidInput,
+			apperror.WithContext("simulation needs a direct CEX/DEX opportunity"))
+	}
+
+	ob, err := d.pricing.GetCEXOrderbook(ctx, opp.Pair)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	buyOnCEX := opp.Direction == domain.DirectionCEXToDEX
+	levels := ob.Bids
+	if buyOnCEX {
+		levels = ob.Asks
+	}
+	cexPrice, filled := walkBook(levels, opp.TradeSize)
+	if filled.IsZero() {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithContext(fmt.Sprintf("no %s liquidity for %s", bookSide(buyOnCEX), opp.Pair)))
+	}
+
+	impactBps := decimal.Zero
+	if opp.DEXQuote != nil {
+		impactBps, _ = opp.DEXQuote.PriceImpactBps()
+	}
+	dexPrice := opp.DEXPrice
+	if !buyOnCEX {
+		dexPrice = dexPrice.Mul(decimal.NewFromInt(1).Add(impactBps.Div(decimal.NewFromInt(10000))))
+	}
+
+	sim := &domain.SimulationResult{
+		CEXFillPrice:   cexPrice,
+		CEXFilled:      filled,
+		CEXSlippageBps: slippageBps(levels[0].Price, cexPrice),
+		DEXFillPrice:   dexPrice,
+		DEXSlippageBps: impactBps,
+	}
+
+	// Sell high, buy low: the venue sold on is the one receiving the higher price
+	priceDiff := dexPrice.Sub(cexPrice)
+	if !buyOnCEX {
+		priceDiff = cexPrice.Sub(dexPrice)
+	}
+	sim.RealizedGrossProfit = priceDiff.Mul(filled)
+	sim.RealizedNetProfit = sim.RealizedGrossProfit.Sub(opp.Profit.Exact.TotalCosts)
+	if !cexPrice.IsZero() {
+		sim.RealizedSpreadBps = priceDiff.Div(cexPrice).Mul(decimal.NewFromInt(10000))
+	}
+
+	span.SetAttributes(
+		attribute.Float64("cex_fill_price", cexPrice.InexactFloat64()),
+		attribute.Float64("dex_fill_price", dexPrice.InexactFloat64()),
+		attribute.Float64("realized_net_profit_usd", sim.RealizedNetProfit.InexactFloat64()),
+		attribute.Bool("capturable", sim.Capturable(opp.TradeSize)),
+	)
+
+	return sim, nil
+}
+
+// walkBook fills size through the levels best-first and returns the VWAP and
+// the amount filled, which is less than size when the book runs out.
+func walkBook(levels []pricingDomain.OrderbookLevel, size decimal.Decimal) (vwap, filled decimal.Decimal) {
+	cost := decimal.Zero
+	remaining := size
+	for _, level := range levels {
+		if !remaining.IsPositive() {
+			break
+		}
+		qty := decimal.Min(remaining, level.Amount.ToDecimal())
+		cost = cost.Add(qty.Mul(level.Price))
+		filled = filled.Add(qty)
+		remaining = remaining.Sub(qty)
+	}
+	if filled.IsZero() {
+		return decimal.Zero, decimal.Zero
+	}
+	return cost.Div(filled), filled
+}
+
+// slippageBps is the distance of the fill price from the top of book.
+func slippageBps(top, fill decimal.Decimal) decimal.Decimal {
+	if top.IsZero() {
+		return decimal.Zero
+	}
+	return fill.Sub(top).Abs().Div(top).Mul(decimal.NewFromInt(10000))
+}
+
+func bookSide(buy bool) string {
+	if buy {
+		return "ask"
+	}
+	return "bid"
+}
+
+// attachSimulation runs SimulateExecution and attaches the result; a failed
+// simulation leaves the opportunity as analyzed.
+func (d *Detector) attachSimulation(ctx context.Context, opp *domain.Opportunity) {
+	sim, err := d.SimulateExecution(ctx, opp)
+	if err != nil {
+		d.logger.Debug(ctx, "execution simulation failed", "id", opp.ID, "error", err)
+		return
+	}
+	opp.Simulation = sim
+
+	d.logger.Debug(ctx, "simulated execution",
+		"id", opp.ID,
+		"cex_fill_price", sim.CEXFillPrice.StringFixed(2),
+		"dex_fill_price", sim.DEXFillPrice.StringFixed(2),
+		"realized_net_profit", sim.RealizedNetProfit.StringFixed(2),
+		"capturable", sim.Capturable(opp.TradeSize),
+	)
+}
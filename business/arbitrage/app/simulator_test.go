@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+func TestWalkBook(t *testing.T) {
+	level := func(price, amount string) pricingDomain.OrderbookLevel {
+		amt, err := asset.ParseString(asset.ETH, amount)
+		if err != nil {
+			t.Fatalf("ParseString(%s): %v", amount, err)
+		}
+		return pricingDomain.OrderbookLevel{Price: decimal.RequireFromString(price), Amount: amt}
+	}
+	asks := []pricingDomain.OrderbookLevel{
+		level("3000", "1"),
+		level("3010", "2"),
+	}
+
+	tests := []struct {
+		name       string
+		size       string
+		wantVWAP   string
+		wantFilled string
+		wantSlip   string // Bps vs top of book
+	}{
+		{name: "top_level", size: "0.5", wantVWAP: "3000", wantFilled: "0.5", wantSlip: "0"},
+		{name: "two_levels", size: "2", wantVWAP: "3005", wantFilled: "2", wantSlip: "16.67"},
+		{name: "exhausts_book", size: "5", wantVWAP: "3006.6666666666666667", wantFilled: "3", wantSlip: "22.22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vwap, filled := walkBook(asks, decimal.RequireFromString(tt.size))
+			if !vwap.Equal(decimal.RequireFromString(tt.wantVWAP)) {
+				t.Errorf("vwap = %s, want %s", vwap, tt.wantVWAP)
+			}
+			if !filled.Equal(decimal.RequireFromString(tt.wantFilled)) {
+				t.Errorf("filled = %s, want %s", filled, tt.wantFilled)
+			}
+			if slip := slippageBps(asks[0].Price, vwap); !slip.Round(2).Equal(decimal.RequireFromString(tt.wantSlip)) {
+				t.Errorf("slippage = %s bps, want %s", slip, tt.wantSlip)
+			}
+		})
+	}
+
+	if vwap, filled := walkBook(nil, decimal.NewFromInt(1)); !vwap.IsZero() || !filled.IsZero() {
+		t.Errorf("empty book: vwap = %s, filled = %s, want zero", vwap, filled)
+	}
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// sizePrecision is the number of decimals kept on an interpolated trade size.
+const sizePrecision = 4
+
+// optimalTradeSize returns the largest trade size that fits both the capital
+// limit at price and the slippage model's size cap.
+//
+// The largest configured size within those limits is preferred; when none
+// fits, the size is interpolated down to the limit itself (truncated to
+// sizePrecision decimals). A zero maxCapitalUSD means unlimited capital.
+func optimalTradeSize(sizes []decimal.Decimal, price, maxCapitalUSD decimal.Decimal, slippage *SlippageModel) decimal.Decimal {
+	limit := decimal.Zero
+	if maxCapitalUSD.IsPositive() && price.IsPositive() {
+		limit = maxCapitalUSD.Div(price)
+	}
+	if slippage != nil && slippage.MaxTradeSize.IsPositive() {
+		if limit.IsZero() || slippage.MaxTradeSize.LessThan(limit) {
+			limit = slippage.MaxTradeSize
+		}
+	}
+
+	best := decimal.Zero
+	for _, size := range sizes {
+		if limit.IsPositive() && size.GreaterThan(limit) {
+			continue
+		}
+		if size.GreaterThan(best) {
+			best = size
+		}
+	}
+	if best.IsPositive() || limit.IsZero() {
+		return best
+	}
+	return limit.Truncate(sizePrecision)
+}
+
+// containsSize reports whether sizes includes size.
+func containsSize(sizes []decimal.Decimal, size decimal.Decimal) bool {
+	for _, s := range sizes {
+		if s.Equal(size) {
+			return true
+		}
+	}
+	return false
+}
+
+// usdSizePrecision is the number of decimals kept on a base size converted
+// from a USD notional (fewer when the base asset has fewer).
+const usdSizePrecision = 8
+
+// plannedSize is one trade size analyzed for a pair: the base-asset amount
+// and, when sizes are configured in USD, the notional it was converted from.
+type plannedSize struct {
+	base        decimal.Decimal
+	notionalUSD decimal.Decimal // Zero for sizes configured in base units
+}
+
+// label names the size in metrics and logs. USD sizes are labeled by their
+// notional, which stays fixed while the converted base amount moves with price.
+func (s plannedSize) label() string {
+	if s.notionalUSD.IsPositive() {
+		return s.notionalUSD.String() + " USD"
+	}
+	return s.base.String()
+}
+
+// tradeSizeLabel formats a size of base for the cost breakdown, leading
+// with the USD notional when the size was configured in USD.
+func tradeSizeLabel(s plannedSize, base *asset.Asset) string {
+	if s.notionalUSD.IsPositive() {
+		return "$" + s.notionalUSD.String() + " (" + s.base.String() + " " + base.Symbol() + ")"
+	}
+	return s.base.String() + " " + base.Symbol()
+}
+
+// baseSizes returns the base-asset amounts of sizes.
+func baseSizes(sizes []plannedSize) []decimal.Decimal {
+	result := make([]decimal.Decimal, len(sizes))
+	for i, s := range sizes {
+		result[i] = s.base
+	}
+	return result
+}
+
+// planTradeSizes returns the trade sizes to analyze for each pair this
+// block, keyed by Pair.String(). Base-unit sizes apply to every pair as
+// configured; USD notionals are converted at the pair's current CEX ask.
+// A pair whose price is unavailable gets no sizes and is skipped this block.
+func (d *Detector) planTradeSizes(ctx context.Context, cfg DetectorConfig) map[string][]plannedSize {
+	plan := make(map[string][]plannedSize, len(cfg.Pairs))
+	if len(cfg.TradeSizesUSD) == 0 {
+		sizes := make([]plannedSize, len(cfg.TradeSizes))
+		for i, size := range cfg.TradeSizes {
+			sizes[i] = plannedSize{base: size}
+		}
+		for _, pair := range cfg.Pairs {
+			plan[pair.String()] = sizes
+		}
+		return plan
+	}
+
+	for _, pair := range cfg.Pairs {
+		priceUSD, err := d.basePriceUSD(ctx, pair)
+		if err != nil {
+			d.logger.Debug(ctx, "no USD price to size trades, skipping pair", "pair", pair.String(), "error", err)
+			continue
+		}
+		plan[pair.String()] = usdTradeSizes(cfg.TradeSizesUSD, priceUSD, pair.Base)
+	}
+	return plan
+}
+
+// basePriceUSD returns the USD value of one unit of the pair's base asset at
+// the CEX ask. Quotes in USD stablecoins count at $1 and quotes in ETH at
+// the detector's ETH price.
+func (d *Detector) basePriceUSD(ctx context.Context, pair pricingDomain.Pair) (decimal.Decimal, error) {
+	quoteUSD, ok := d.quotePriceUSD(pair.Quote)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("no USD price for %s", pair.Quote.Symbol())
+	}
+
+	ob, err := d.pricing.GetCEXOrderbook(ctx, pair)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if len(ob.Asks) == 0 || !ob.Asks[0].Price.IsPositive() {
+		return decimal.Zero, fmt.Errorf("no CEX ask for %s", pair)
+	}
+	return ob.Asks[0].Price.Mul(quoteUSD), nil
+}
+
+// quotePriceUSD returns the USD value of one unit of a quote asset: $1 for
+// USD stablecoins and the detector's ETH price for ETH. Other quotes have no
+// USD price.
+func (d *Detector) quotePriceUSD(quote *asset.Asset) (decimal.Decimal, bool) {
+	switch {
+	case IsUSDLike(quote):
+		return decimal.NewFromInt(1), true
+	case asset.SameUnderlying(quote, asset.ETH) && d.ethPriceUSD.IsPositive():
+		return d.ethPriceUSD, true
+	}
+	return decimal.Zero, false
+}
+
+// spreadInUSD restates a spread, and a maker's resting price, in USD per base
+// unit, so the calculator weighs gross profit against costs in the same unit.
+// Basis points are unchanged.
+func spreadInUSD(spread pricingDomain.Spread, exec domain.Execution, quoteUSD decimal.Decimal) (pricingDomain.Spread, domain.Execution) {
+	usd := pricingDomain.CalculateSpread(spread.CEXPrice.Mul(quoteUSD), spread.DEXPrice.Mul(quoteUSD))
+	if exec.IsMaker() {
+		exec.BestPrice = exec.BestPrice.Mul(quoteUSD)
+	}
+	return usd, exec
+}
+
+// usdTradeSizes converts USD notionals to base-asset sizes at priceUSD per
+// unit of base, rounded to usdSizePrecision decimals. Notionals too small to
+// round to a positive size are dropped.
+func usdTradeSizes(notionals []decimal.Decimal, priceUSD decimal.Decimal, base *asset.Asset) []plannedSize {
+	places := int32(min(usdSizePrecision, int(base.Decimals())))
+	sizes := make([]plannedSize, 0, len(notionals))
+	for _, notional := range notionals {
+		size := notional.Div(priceUSD).Round(places)
+		if !size.IsPositive() {
+			continue
+		}
+		sizes = append(sizes, plannedSize{base: size, notionalUSD: notional})
+	}
+	return sizes
+}
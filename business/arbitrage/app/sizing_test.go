@@ -0,0 +1,202 @@
+package app
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+func TestOptimalTradeSize(t *testing.T) {
+	sizes := []decimal.Decimal{
+		decimal.RequireFromString("0.5"),
+		decimal.NewFromInt(1),
+		decimal.NewFromInt(10),
+	}
+
+	tests := []struct {
+		name       string
+		price      string
+		maxCapital string
+		model      *SlippageModel
+		want       string
+	}{
+		{
+			name:       "unlimited_picks_largest",
+			price:      "3000",
+			maxCapital: "0",
+			want:       "10",
+		},
+		{
+			name:       "capital_picks_largest_fitting",
+			price:      "3000",
+			maxCapital: "5000", // 1.666 ETH
+			want:       "1",
+		},
+		{
+			name:       "exact_fit",
+			price:      "3000",
+			maxCapital: "30000",
+			want:       "10",
+		},
+		{
+			name:       "no_size_fits_interpolates",
+			price:      "3000",
+			maxCapital: "1000", // 0.33333 ETH
+			want:       "0.3333",
+		},
+		{
+			name:       "slippage_cap_tighter_than_capital",
+			price:      "3000",
+			maxCapital: "100000",
+			model:      NewSlippageModel(decimal.NewFromInt(1), decimal.NewFromInt(5)),
+			want:       "1",
+		},
+		{
+			name:       "slippage_cap_below_all_sizes",
+			price:      "3000",
+			maxCapital: "0",
+			model:      NewSlippageModel(decimal.NewFromInt(1), decimal.RequireFromString("0.25")),
+			want:       "0.25",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := optimalTradeSize(sizes,
+				decimal.RequireFromString(tt.price),
+				decimal.RequireFromString(tt.maxCapital),
+				tt.model,
+			)
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("optimalTradeSize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUSDTradeSizes(t *testing.T) {
+	notionals := []decimal.Decimal{
+		decimal.NewFromInt(1000),
+		decimal.NewFromInt(5000),
+		decimal.RequireFromString("0.0000001"), // Rounds to a zero size
+	}
+
+	tests := []struct {
+		name  string
+		base  *asset.Asset
+		price string
+		want  []string
+	}{
+		{name: "eth", base: asset.ETH, price: "4000", want: []string{"0.25", "1.25"}},
+		{name: "eth_rounded", base: asset.ETH, price: "3000", want: []string{"0.33333333", "1.66666667"}},
+		{name: "wbtc", base: asset.WBTC, price: "60000", want: []string{"0.01666667", "0.08333333"}},
+		{name: "usdc_capped_to_decimals", base: asset.USDC, price: "0.9999", want: []string{"1000.10001", "5000.50005"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sizes := usdTradeSizes(notionals, decimal.RequireFromString(tt.price), tt.base)
+			if len(sizes) != len(tt.want) {
+				t.Fatalf("usdTradeSizes() returned %d sizes, want %d", len(sizes), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !sizes[i].base.Equal(decimal.RequireFromString(want)) {
+					t.Errorf("size %d = %s, want %s", i, sizes[i].base, want)
+				}
+				if !sizes[i].notionalUSD.Equal(notionals[i]) {
+					t.Errorf("size %d notional = %s, want %s", i, sizes[i].notionalUSD, notionals[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTradeSizeLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		size plannedSize
+		base *asset.Asset
+		want string
+	}{
+		{name: "eth", size: plannedSize{base: decimal.NewFromInt(1)}, base: asset.ETH, want: "1 ETH"},
+		{name: "wbtc", size: plannedSize{base: decimal.RequireFromString("0.01")}, base: asset.WBTC, want: "0.01 WBTC"},
+		{
+			name: "wbtc_usd",
+			size: plannedSize{base: decimal.RequireFromString("0.01666667"), notionalUSD: decimal.NewFromInt(1000)},
+			base: asset.WBTC,
+			want: "$1000 (0.01666667 WBTC)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tradeSizeLabel(tt.size, tt.base); got != tt.want {
+				t.Errorf("tradeSizeLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_QuotePriceUSD(t *testing.T) {
+	d := &Detector{ethPriceUSD: decimal.NewFromInt(2500)}
+
+	tests := []struct {
+		name   string
+		quote  *asset.Asset
+		want   string
+		wantOK bool
+	}{
+		{name: "usdc", quote: asset.USDC, want: "1", wantOK: true},
+		{name: "eth", quote: asset.ETH, want: "2500", wantOK: true},
+		{name: "weth", quote: asset.WETH, want: "2500", wantOK: true},
+		{name: "wbtc", quote: asset.WBTC, want: "0", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := d.quotePriceUSD(tt.quote)
+			if ok != tt.wantOK || !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("quotePriceUSD() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	// Without an ETH price yet, ETH quotes can't be valued
+	if _, ok := (&Detector{}).quotePriceUSD(asset.ETH); ok {
+		t.Error("expected no USD price for ETH before the ETH price is known")
+	}
+}
+
+func TestSpreadInUSD(t *testing.T) {
+	// WBTC-ETH: the DEX pays 0.1 ETH more per WBTC, with ETH at $2500
+	spread := pricingDomain.CalculateSpread(decimal.NewFromInt(20), decimal.RequireFromString("20.1"))
+	quoteUSD := decimal.NewFromInt(2500)
+
+	usd, exec := spreadInUSD(spread, domain.Execution{Mode: domain.ExecutionTaker}, quoteUSD)
+	if !usd.Absolute.Equal(decimal.NewFromInt(250)) {
+		t.Errorf("Absolute = %s, want 250", usd.Absolute)
+	}
+	if !usd.BasisPoints.Equal(spread.BasisPoints) || usd.Direction != spread.Direction {
+		t.Errorf("expected bps and direction unchanged, got %s %s", usd.BasisPoints, usd.Direction)
+	}
+	if !exec.BestPrice.IsZero() {
+		t.Errorf("expected a taker execution untouched, got %s", exec.BestPrice)
+	}
+
+	_, maker := spreadInUSD(spread, domain.Execution{Mode: domain.ExecutionMaker, BestPrice: decimal.RequireFromString("19.99")}, quoteUSD)
+	if !maker.BestPrice.Equal(decimal.NewFromInt(49975)) {
+		t.Errorf("maker BestPrice = %s, want 49975", maker.BestPrice)
+	}
+
+	// Gross profit is then in USD, comparable with the USD costs
+	calc := NewProfitCalculator(decimal.Zero, decimal.Zero)
+	result := calc.Calculate(usd, decimal.NewFromInt(1), decimal.NewFromInt(50000), domain.NewGasCost(0, big.NewInt(0), quoteUSD), exec)
+	if got := result.GrossProfit.ToDecimal(); !got.Equal(decimal.NewFromInt(250)) {
+		t.Errorf("GrossProfit = %s, want 250 USD", got)
+	}
+}
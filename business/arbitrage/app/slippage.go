@@ -0,0 +1,47 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	"github.com/shopspring/decimal"
+)
+
+// SlippageModel turns the DEX price impact of a trade into a USD haircut on gross profit.
+//
+// The quoter output is exact only against the pool state at the quoted block.
+// Competing swaps landing first push the pool the same way ours does, so the
+// realized price is expected to be worse by roughly our own impact again.
+// ImpactFactor scales that estimate (1 = charge the full impact once).
+type SlippageModel struct {
+	ImpactFactor decimal.Decimal // Multiplier applied to the measured impact
+	MaxTradeSize decimal.Decimal // Trades above this size get a "High Slippage" risk (0 = disabled)
+}
+
+// NewSlippageModel creates a SlippageModel.
+func NewSlippageModel(impactFactor, maxTradeSize decimal.Decimal) *SlippageModel {
+	return &SlippageModel{
+		ImpactFactor: impactFactor,
+		MaxTradeSize: maxTradeSize,
+	}
+}
+
+// Cost returns the USD slippage for a trade of tradeValueUSD with the given price impact.
+func (m *SlippageModel) Cost(tradeValueUSD, priceImpactBps decimal.Decimal) decimal.Decimal {
+	if m == nil || !priceImpactBps.IsPositive() {
+		return decimal.Zero
+	}
+	return tradeValueUSD.Mul(priceImpactBps).Div(decimal.NewFromInt(10000)).Mul(m.ImpactFactor)
+}
+
+// RiskFactor returns a "High Slippage" risk when tradeSize exceeds MaxTradeSize.
+func (m *SlippageModel) RiskFactor(tradeSize, priceImpactBps decimal.Decimal) (domain.RiskFactor, bool) {
+	if m == nil || !m.MaxTradeSize.IsPositive() || tradeSize.LessThanOrEqual(m.MaxTradeSize) {
+		return domain.RiskFactor{}, false
+	}
+	return domain.RiskFactor{
+		Name:        "High Slippage",
+		Description: fmt.Sprintf("Size above %s cap, DEX price impact %s bps", m.MaxTradeSize.String(), priceImpactBps.StringFixed(1)),
+		Severity:    "high",
+	}, true
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+)
+
+func TestProfitCalculator_CalculateWithSlippage(t *testing.T) {
+	tests := []struct {
+		name           string
+		model          *SlippageModel
+		impactBps      string
+		wantGross      string
+		wantSlippage   string
+		wantProfitable bool
+	}{
+		{
+			// Same inputs as profitable_large_spread: gross 500, fees 136, gas 17
+			name:           "no_model_matches_calculate",
+			model:          nil,
+			impactBps:      "50",
+			wantGross:      "500",
+			wantSlippage:   "0",
+			wantProfitable: true,
+		},
+		{
+			name:           "impact_reduces_gross",
+			model:          NewSlippageModel(decimal.NewFromInt(1), decimal.Zero),
+			impactBps:      "25", // 34000 * 0.0025 = 85
+			wantGross:      "415",
+			wantSlippage:   "85",
+			wantProfitable: true,
+		},
+		{
+			name:           "large_impact_kills_opportunity",
+			model:          NewSlippageModel(decimal.NewFromInt(1), decimal.Zero),
+			impactBps:      "120", // 34000 * 0.012 = 408 -> gross 92 < costs 153
+			wantGross:      "92",
+			wantSlippage:   "408",
+			wantProfitable: false,
+		},
+		{
+			name:           "half_factor",
+			model:          NewSlippageModel(decimal.RequireFromString("0.5"), decimal.Zero),
+			impactBps:      "25",
+			wantGross:      "457.5",
+			wantSlippage:   "42.5",
+			wantProfitable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := NewProfitCalculator(decimal.NewFromInt(10), decimal.NewFromInt(50))
+			calc.SetSlippageModel(tt.model)
+
+			result := calc.CalculateWithSlippage(
+				makeSpread("3400", "3350"),
+				decimal.NewFromInt(10),
+				decimal.NewFromInt(34000),
+				makeGasCost(200_000, 25, "3400"),
+				decimal.RequireFromString(tt.impactBps),
+				domain.Execution{},
+			)
+
+			if want := decimal.RequireFromString(tt.wantGross); !result.GrossProfit.ToDecimal().Equal(want) {
+				t.Errorf("expected gross %s, got %s", want, result.GrossProfit.ToDecimal())
+			}
+			if want := decimal.RequireFromString(tt.wantSlippage); !result.SlippageCost.Equal(want) {
+				t.Errorf("expected slippage %s, got %s", want, result.SlippageCost)
+			}
+			if result.IsProfitable != tt.wantProfitable {
+				t.Errorf("expected profitable=%v, got %v", tt.wantProfitable, result.IsProfitable)
+			}
+		})
+	}
+}
+
+func TestSlippageModel_RiskFactor(t *testing.T) {
+	model := NewSlippageModel(decimal.NewFromInt(1), decimal.NewFromInt(50))
+
+	if _, ok := model.RiskFactor(decimal.NewFromInt(10), decimal.NewFromInt(5)); ok {
+		t.Error("expected no risk below the size cap")
+	}
+	if _, ok := model.RiskFactor(decimal.NewFromInt(50), decimal.NewFromInt(5)); ok {
+		t.Error("expected no risk at the size cap")
+	}
+
+	risk, ok := model.RiskFactor(decimal.NewFromInt(100), decimal.NewFromInt(80))
+	if !ok {
+		t.Fatal("expected High Slippage risk above the size cap")
+	}
+	if risk.Name != "High Slippage" || risk.Severity != "high" {
+		t.Errorf("unexpected risk factor: %+v", risk)
+	}
+
+	var disabled *SlippageModel
+	if _, ok := disabled.RiskFactor(decimal.NewFromInt(100), decimal.Zero); ok {
+		t.Error("expected nil model to report no risk")
+	}
+}
@@ -0,0 +1,355 @@
+// Package app contains application services and port definitions for the arbitrage context.
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TriangularConfig holds configuration for triangular arbitrage detection.
+type TriangularConfig struct {
+	Cycles     [][]pricingDomain.Pair // Each cycle starts and ends on the first pair's base asset
+	TradeSizes []decimal.Decimal      // Start amounts in the cycle's start asset
+
+	// DefaultSwapGasLimit is the gas charged per DEX leg (zero = swapGasLimit)
+	DefaultSwapGasLimit uint64
+}
+
+// TriangularDetector scans multi-leg cycles (e.g., ETH→USDC→WBTC→ETH) for arbitrage.
+// Each leg is priced at the best available venue for the trade size.
+type TriangularDetector struct {
+	pricing    *pricingApp.PricingService
+	calculator *ProfitCalculator
+	reporter   Reporter
+	config     TriangularConfig
+	logger     logger.LoggerInterface
+
+	// OTEL instrumentation
+	tracer trace.Tracer
+}
+
+// NewTriangularDetector creates a new TriangularDetector.
+func NewTriangularDetector(
+	pricing *pricingApp.PricingService,
+	calculator *ProfitCalculator,
+	reporter Reporter,
+	config TriangularConfig,
+	log logger.LoggerInterface,
+) *TriangularDetector {
+	return &TriangularDetector{
+		pricing:    pricing,
+		calculator: calculator,
+		reporter:   reporter,
+		config:     config,
+		logger:     log,
+		tracer:     otel.Tracer(tracerName),
+	}
+}
+
+// ValidateCycle checks that a cycle chains from the first pair's base asset
+// through every pair and back to it.
+func ValidateCycle(cycle []pricingDomain.Pair) error {
+	if len(cycle) < 2 {
+		return apperror.New(apperror.CodeInvalidInput,
+			apperror.WithContext("cycle needs at least two pairs"))
+	}
+
+	_, err := cycleSides(cycle)
+	return err
+}
+
+// cycleSides returns the trade side for each leg of the cycle.
+// Holding the base asset means selling, holding the quote asset means buying.
+func cycleSides(cycle []pricingDomain.Pair) ([]pricingDomain.Side, error) {
+	start := cycle[0].Base.Symbol()
+	current := start
+
+	sides := make([]pricingDomain.Side, len(cycle))
+	for i, pair := range cycle {
+		switch current {
+		case pair.Base.Symbol():
+			sides[i] = pricingDomain.SideSell
+			current = pair.Quote.Symbol()
+		case pair.Quote.Symbol():
+			sides[i] = pricingDomain.SideBuy
+			current = pair.Base.Symbol()
+		default:
+			return nil, apperror.New(apperror.CodeInvalidInput,
+				apperror.WithContext(fmt.Sprintf("cycle broken at %s: holding %s", pair, current)))
+		}
+	}
+
+	if current != start {
+		return nil, apperror.New(apperror.CodeInvalidInput,
+			apperror.WithContext(fmt.Sprintf("cycle ends on %s, expected %s", current, start)))
+	}
+
+	return sides, nil
+}
+
+// ProcessBlock analyzes every configured cycle and trade size for a new block.
+func (t *TriangularDetector) ProcessBlock(ctx context.Context, block *blockchainDomain.Block, gasPrice *blockchainDomain.GasPrice, ethPriceUSD decimal.Decimal) {
+	for _, cycle := range t.config.Cycles {
+		for _, tradeSize := range t.config.TradeSizes {
+			opp, err := t.AnalyzeCycle(ctx, block, cycle, tradeSize, gasPrice, ethPriceUSD)
+			if err != nil {
+				t.logger.Debug(ctx, "failed to analyze cycle",
+					"start", cycle[0].Base.Symbol(),
+					"size", tradeSize.String(),
+					"error", err,
+				)
+				continue
+			}
+			if opp.IsProfitable() {
+				t.reporter.Report(opp)
+			}
+		}
+	}
+}
+
+// AnalyzeCycle chains effective prices across the cycle's legs and returns
+// the resulting opportunity (profitable or not).
+func (t *TriangularDetector) AnalyzeCycle(
+	ctx context.Context,
+	block *blockchainDomain.Block,
+	cycle []pricingDomain.Pair,
+	tradeSize decimal.Decimal,
+	gasPrice *blockchainDomain.GasPrice,
+	ethPriceUSD decimal.Decimal,
+) (*domain.Opportunity, error) {
+	ctx, span := t.tracer.Start(ctx, "analyzeCycle",
+		trace.WithAttributes(
+			attribute.String("start", cycle[0].Base.Symbol()),
+			attribute.Int("legs", len(cycle)),
+			attribute.String("trade_size", tradeSize.String()),
+			attribute.Int64("block_number", int64(block.Number)),
+		),
+	)
+	defer span.End()
+
+	sides, err := cycleSides(cycle)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Chain each leg's output into the next leg's input
+	legs := make([]domain.Leg, 0, len(cycle))
+	amount := tradeSize
+	for i, pair := range cycle {
+		leg, err := t.priceLeg(ctx, pair, sides[i], amount)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("leg %d (%s): %w", i+1, pair, err)
+		}
+		legs = append(legs, leg)
+		amount = leg.AmountOut
+	}
+
+	// Value start and end amounts in USD
+	unitUSD, ok := usdPerUnit(cycle[0].Base, legs, ethPriceUSD)
+	if !ok {
+		return nil, apperror.New(apperror.CodePriceCalculationFailed,
+			apperror.WithContext(fmt.Sprintf("no USD price for %s", cycle[0].Base.Symbol())))
+	}
+	startValueUSD := tradeSize.Mul(unitUSD)
+	endValueUSD := amount.Mul(unitUSD)
+
+	// Gas is charged once per DEX leg; legs are priced by effective price
+	// and carry no quoter estimate
+	legGas := gasLimitFor(nil, t.config.DefaultSwapGasLimit)
+	gasCost := domain.NewGasCost(legGas, gasPrice.Wei(), ethPriceUSD)
+	profit := t.calculator.CalculateCycle(legs, startValueUSD, endValueUSD, gasCost)
+
+	dexLegs := 0
+	for _, leg := range legs {
+		if leg.Venue == pricingDomain.VenueUniswap {
+			dexLegs++
+		}
+	}
+	var totalGas *domain.GasCost
+	if dexLegs > 0 {
+		totalGas = domain.NewGasCost(legGas*uint64(dexLegs), gasPrice.Wei(), ethPriceUSD)
+	}
+
+	returnBps := decimal.Zero
+	if !tradeSize.IsZero() {
+		returnBps = amount.Sub(tradeSize).Div(tradeSize).Mul(decimal.NewFromInt(10000))
+	}
+
+	opp := &domain.Opportunity{
+		BlockNumber: block.Number,
+		Timestamp:   time.Now(),
+		Pair:        cycle[0],
+		Direction:   domain.DirectionTriangular,
+		TradeSize:   tradeSize,
+		Spread: pricingDomain.Spread{
+			Absolute:    endValueUSD.Sub(startValueUSD),
+			BasisPoints: returnBps,
+			Direction:   pricingDomain.SpreadNone,
+		},
+		GasCost:         totalGas,
+		Profit:          profit,
+		RequiredCapital: startValueUSD,
+		Legs:            legs,
+	}
+	opp.ID = fmt.Sprintf("%d-%s-%s", block.Number, opp.Route(), tradeSize.String())
+	opp.ExecutionSteps = buildLegSteps(legs)
+	opp.RiskFactors = buildCycleRiskFactors(dexLegs)
+
+	span.SetAttributes(
+		attribute.String("route", opp.Route()),
+		attribute.Float64("return_bps", returnBps.InexactFloat64()),
+		attribute.Float64("net_profit_usd", profit.NetProfitRaw.InexactFloat64()),
+		attribute.Bool("profitable", profit.IsProfitable),
+	)
+
+	return opp, nil
+}
+
+// priceLeg computes the effective price and output amount for a single leg.
+// Sell legs take the better of the CEX bid and the DEX quote; buy legs are
+// sized from the CEX best ask and priced at the CEX effective ask.
+func (t *TriangularDetector) priceLeg(ctx context.Context, pair pricingDomain.Pair, side pricingDomain.Side, amountIn decimal.Decimal) (domain.Leg, error) {
+	leg := domain.Leg{Pair: pair, Side: side, AmountIn: amountIn}
+
+	if side == pricingDomain.SideSell {
+		snapshot, err := t.pricing.GetPriceSnapshot(ctx, pair, amountIn)
+		if err == nil && snapshot.CEXBid != nil {
+			leg.Venue = snapshot.CEXBid.Source
+			leg.Price = snapshot.CEXBid.Rate.Rate()
+			if snapshot.DEXQuote != nil && snapshot.DEXQuote.Price.Rate().GreaterThan(leg.Price) {
+				leg.Venue = pricingDomain.VenueUniswap
+				leg.Price = snapshot.DEXQuote.Price.Rate()
+			}
+		} else {
+			// DEX unavailable for this pair: price on CEX only
+			price, err := t.pricing.GetCEXPrice(ctx, pair, amountIn, side)
+			if err != nil {
+				return leg, err
+			}
+			leg.Venue = price.Source
+			leg.Price = price.Rate.Rate()
+		}
+		leg.AmountOut = amountIn.Mul(leg.Price)
+		return leg, nil
+	}
+
+	// Buying base with quote: estimate the base size from the best ask
+	ob, err := t.pricing.GetCEXOrderbook(ctx, pair)
+	if err != nil {
+		return leg, err
+	}
+	bestAsk := ob.BestAsk()
+	if bestAsk == nil || !bestAsk.Price.IsPositive() {
+		return leg, apperror.New(apperror.CodeInsufficientLiquidity,
+			apperror.WithContext(fmt.Sprintf("no asks for %s", pair)))
+	}
+
+	price, err := t.pricing.GetCEXPrice(ctx, pair, amountIn.Div(bestAsk.Price), side)
+	if err != nil {
+		return leg, err
+	}
+	leg.Venue = price.Source
+	leg.Price = price.Rate.Rate()
+	if !leg.Price.IsPositive() {
+		return leg, apperror.New(apperror.CodePriceCalculationFailed,
+			apperror.WithContext(fmt.Sprintf("invalid ask price for %s", pair)))
+	}
+	leg.AmountOut = amountIn.Div(leg.Price)
+
+	return leg, nil
+}
+
+// usdPerUnit returns the USD value of one unit of the start asset.
+// Stablecoins are valued at $1, ETH at the detector's ETH price, and other
+// assets via a leg that quotes them against a USD stablecoin.
+func usdPerUnit(start *asset.Asset, legs []domain.Leg, ethPriceUSD decimal.Decimal) (decimal.Decimal, bool) {
+	if IsUSDLike(start) {
+		return decimal.NewFromInt(1), true
+	}
+	if asset.SameUnderlying(start, asset.ETH) {
+		return ethPriceUSD, true
+	}
+	for _, leg := range legs {
+		if leg.Pair.Base.Symbol() == start.Symbol() && IsUSDLike(leg.Pair.Quote) {
+			return leg.Price, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// IsUSDLike returns true for USD and USD stablecoins.
+func IsUSDLike(a *asset.Asset) bool {
+	switch a.Symbol() {
+	case "USD", "USDC", "USDT", "DAI":
+		return true
+	}
+	return false
+}
+
+// buildLegSteps creates one execution step per leg.
+func buildLegSteps(legs []domain.Leg) []domain.ExecutionStep {
+	steps := make([]domain.ExecutionStep, 0, len(legs))
+	for i, leg := range legs {
+		venue := pricingDomain.VenueDisplayName(leg.Venue)
+
+		var description string
+		if leg.Side == pricingDomain.SideSell {
+			description = fmt.Sprintf("Sell %s %s for ~%s %s on %s at %s",
+				leg.AmountIn.StringFixed(4), leg.AssetIn(),
+				leg.AmountOut.StringFixed(4), leg.AssetOut(),
+				venue, leg.Price.Round(6).String())
+		} else {
+			description = fmt.Sprintf("Buy ~%s %s with %s %s on %s at %s",
+				leg.AmountOut.StringFixed(4), leg.AssetOut(),
+				leg.AmountIn.StringFixed(4), leg.AssetIn(),
+				venue, leg.Price.Round(6).String())
+		}
+
+		steps = append(steps, domain.ExecutionStep{
+			Number:      i + 1,
+			Description: description,
+		})
+	}
+	return steps
+}
+
+// buildCycleRiskFactors creates the risk factors for a multi-leg cycle.
+func buildCycleRiskFactors(dexLegs int) []domain.RiskFactor {
+	risks := []domain.RiskFactor{
+		{
+			Name:        "Slippage Risk",
+			Description: "Price movement compounds across legs",
+			Severity:    "medium",
+		},
+		{
+			Name:        "Timing Risk",
+			Description: "Legs execute sequentially",
+			Severity:    "high",
+		},
+	}
+
+	if dexLegs > 0 {
+		risks = append(risks, domain.RiskFactor{
+			Name:        "MEV Risk",
+			Description: "Sandwich attacks from MEV bots",
+			Severity:    "medium",
+		})
+	}
+
+	return risks
+}
@@ -0,0 +1,167 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	blockchainDomain "github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/shopspring/decimal"
+)
+
+// fakeCEX returns flat books with fixed bid/ask per pair.
+type fakeCEX struct {
+	bids map[string]decimal.Decimal
+	asks map[string]decimal.Decimal
+}
+
+func (f *fakeCEX) GetOrderbook(ctx context.Context, pair pricingDomain.Pair) (*pricingDomain.Orderbook, error) {
+	ask, ok := f.asks[pair.String()]
+	if !ok {
+		return nil, errors.New("unknown pair")
+	}
+	return &pricingDomain.Orderbook{
+		Pair: pair,
+		Bids: []pricingDomain.OrderbookLevel{{Price: f.bids[pair.String()]}},
+		Asks: []pricingDomain.OrderbookLevel{{Price: ask}},
+	}, nil
+}
+
+func (f *fakeCEX) GetEffectivePrice(ctx context.Context, pair pricingDomain.Pair, size decimal.Decimal, side pricingDomain.Side) (*pricingDomain.Price, error) {
+	prices := f.bids
+	if side == pricingDomain.SideBuy {
+		prices = f.asks
+	}
+	rate, ok := prices[pair.String()]
+	if !ok {
+		return nil, errors.New("unknown pair")
+	}
+	price := pricingDomain.NewPrice(asset.NewPriceNow(pair.Base, pair.Quote, rate), asset.Amount{}, side, pricingDomain.VenueBinance)
+	return &price, nil
+}
+
+// fakeDEX always fails, forcing legs onto the CEX.
+type fakeDEX struct{}
+
+func (f *fakeDEX) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*pricingDomain.Quote, error) {
+	return nil, errors.New("no pool")
+}
+
+var (
+	ethUSDC  = pricingDomain.Pair{Base: asset.ETH, Quote: asset.USDC}
+	wbtcUSDC = pricingDomain.Pair{Base: asset.WBTC, Quote: asset.USDC}
+	wbtcETH  = pricingDomain.Pair{Base: asset.WBTC, Quote: asset.ETH}
+)
+
+func TestValidateCycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		cycle   []pricingDomain.Pair
+		wantErr bool
+	}{
+		{name: "valid_three_legs", cycle: []pricingDomain.Pair{ethUSDC, wbtcUSDC, wbtcETH}},
+		{name: "valid_round_trip", cycle: []pricingDomain.Pair{ethUSDC, ethUSDC}},
+		{name: "too_short", cycle: []pricingDomain.Pair{ethUSDC}, wantErr: true},
+		{name: "broken_chain", cycle: []pricingDomain.Pair{ethUSDC, wbtcETH, wbtcUSDC}, wantErr: true},
+		{name: "does_not_close", cycle: []pricingDomain.Pair{ethUSDC, wbtcUSDC}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCycle(tt.cycle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCycle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfitCalculator_CalculateCycle(t *testing.T) {
+	calc := NewProfitCalculator(decimal.NewFromInt(10), decimal.NewFromInt(5))
+	gasCost := makeGasCost(200_000, 25, "3400") // ~17 USD per swap
+
+	legs := []domain.Leg{
+		{Venue: pricingDomain.VenueBinance},
+		{Venue: pricingDomain.VenueUniswap},
+	}
+
+	result := calc.CalculateCycle(legs, decimal.NewFromInt(10000), decimal.NewFromInt(10100), gasCost)
+
+	// Fees: 10000 * (0.001 + 0.003) = 40, gas: one DEX leg = 17
+	if !result.ExchangeFees.ToDecimal().Equal(decimal.NewFromInt(40)) {
+		t.Errorf("expected fees 40, got %s", result.ExchangeFees.ToDecimal())
+	}
+	if !result.GasCost.ToDecimal().Equal(decimal.NewFromInt(17)) {
+		t.Errorf("expected gas 17, got %s", result.GasCost.ToDecimal())
+	}
+	if !result.NetProfitRaw.Equal(decimal.NewFromInt(43)) {
+		t.Errorf("expected net 43, got %s", result.NetProfitRaw)
+	}
+	if !result.IsProfitable {
+		t.Error("expected cycle to be profitable")
+	}
+
+	// A losing cycle must never be profitable, even though gross is reported as absolute
+	loss := calc.CalculateCycle(legs, decimal.NewFromInt(10000), decimal.NewFromInt(9900), gasCost)
+	if loss.IsProfitable {
+		t.Error("expected losing cycle to be unprofitable")
+	}
+}
+
+func TestTriangularDetector_AnalyzeCycle(t *testing.T) {
+	cex := &fakeCEX{
+		bids: map[string]decimal.Decimal{
+			ethUSDC.String(): decimal.NewFromInt(3000),
+			wbtcETH.String(): decimal.RequireFromString("20.5"),
+		},
+		asks: map[string]decimal.Decimal{
+			wbtcUSDC.String(): decimal.NewFromInt(60000),
+		},
+	}
+	pricing := pricingApp.NewPricingService(cex, &fakeDEX{})
+	calc := NewProfitCalculator(decimal.NewFromInt(10), decimal.NewFromInt(5))
+	detector := NewTriangularDetector(pricing, calc, nil, TriangularConfig{}, nil)
+
+	block := &blockchainDomain.Block{Number: 100}
+	gasPrice := blockchainDomain.NewGasPrice(big.NewInt(25_000_000_000))
+	cycle := []pricingDomain.Pair{ethUSDC, wbtcUSDC, wbtcETH}
+
+	opp, err := detector.AnalyzeCycle(context.Background(), block, cycle, decimal.NewFromInt(1), gasPrice, decimal.NewFromInt(3000))
+	if err != nil {
+		t.Fatalf("AnalyzeCycle failed: %v", err)
+	}
+
+	// 1 ETH -> 3000 USDC -> 0.05 WBTC -> 1.025 ETH
+	if len(opp.Legs) != 3 {
+		t.Fatalf("expected 3 legs, got %d", len(opp.Legs))
+	}
+	if got := opp.Legs[2].AmountOut; !got.Equal(decimal.RequireFromString("1.025")) {
+		t.Errorf("expected 1.025 ETH out, got %s", got)
+	}
+	if got := opp.Route(); got != "ETH→USDC→WBTC→ETH" {
+		t.Errorf("expected route ETH→USDC→WBTC→ETH, got %s", got)
+	}
+	if !opp.Spread.BasisPoints.Equal(decimal.NewFromInt(250)) {
+		t.Errorf("expected 250 bps return, got %s", opp.Spread.BasisPoints)
+	}
+
+	// Gross 75 - fees 3 * 3000 * 0.001 = 66, all legs on CEX so no gas
+	if !opp.Profit.NetProfitRaw.Equal(decimal.NewFromInt(66)) {
+		t.Errorf("expected net profit 66, got %s", opp.Profit.NetProfitRaw)
+	}
+	if opp.GasCost != nil {
+		t.Error("expected no gas cost for CEX-only cycle")
+	}
+	if !opp.IsProfitable() {
+		t.Error("expected profitable opportunity")
+	}
+	if len(opp.ExecutionSteps) != 3 {
+		t.Errorf("expected 3 execution steps, got %d", len(opp.ExecutionSteps))
+	}
+}
@@ -0,0 +1,31 @@
+package arbitrage
+
+import (
+	"context"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app/backtest"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/infra"
+	"github.com/fd1az/arbitrage-bot/internal/config"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/fd1az/arbitrage-bot/internal/monolith"
+)
+
+// RunBacktest replays the recordings in dir through the detector configured
+// from cfg (pairs, trade sizes, thresholds and slippage) instead of live feeds.
+// Opportunities are also printed by the console reporter as they are found.
+func RunBacktest(ctx context.Context, cfg *config.Config, dir string, log logger.LoggerInterface) (*backtest.Summary, error) {
+	registry, err := monolith.NewAssetRegistry(cfg)
+	if err != nil {
+		return nil, err
+	}
+	calculator := newProfitCalculator(cfg)
+
+	return backtest.Run(ctx, backtest.Config{
+		Dir:        dir,
+		Detector:   newDetectorConfig(cfg, calculator, registry, log),
+		Calculator: calculator,
+		Registry:   registry,
+		ChainID:    cfg.Ethereum.ChainID,
+		Next:       infra.NewConsoleReporter(infra.NewMarketState(infra.DefaultRecentOpportunities)),
+	}, log)
+}
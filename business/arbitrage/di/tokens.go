@@ -3,18 +3,24 @@ package di
 
 import (
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app/backtest"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/infra"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/infra/alerting"
 	"github.com/fd1az/arbitrage-bot/internal/di"
 )
 
 // Public service tokens - exposed to other modules
 var (
-	Detector = di.NewToken[*app.Detector]("arbitrage.Detector")
+	Detector          = di.NewToken[*app.Detector]("arbitrage.Detector")
+	MarketState       = di.NewToken[*infra.MarketState]("arbitrage.MarketState")           // Reporter state served by the REST API
+	OpportunityStream = di.NewToken[*alerting.GRPCReporter]("arbitrage.OpportunityStream") // nil when grpc is disabled
 )
 
 // Private dependency tokens - internal to arbitrage module
 var (
 	ProfitCalculator = di.NewToken[*app.ProfitCalculator]("arbitrage:profitCalculator")
 	Reporter         = di.NewToken[app.Reporter]("arbitrage:reporter")
+	Recorder         = di.NewToken[*backtest.Recorder]("arbitrage:recorder") // nil when recording is off
 )
 
 // Helper functions for type-safe access
@@ -22,6 +28,14 @@ func GetDetector(c di.ServiceRegistry) *app.Detector {
 	return di.GetToken(c, Detector)
 }
 
+func GetMarketState(c di.ServiceRegistry) *infra.MarketState {
+	return di.GetToken(c, MarketState)
+}
+
+func GetOpportunityStream(c di.ServiceRegistry) *alerting.GRPCReporter {
+	return di.GetToken(c, OpportunityStream)
+}
+
 func GetProfitCalculator(c di.ServiceRegistry) *app.ProfitCalculator {
 	return di.GetToken(c, ProfitCalculator)
 }
@@ -29,3 +43,7 @@ func GetProfitCalculator(c di.ServiceRegistry) *app.ProfitCalculator {
 func GetReporter(c di.ServiceRegistry) app.Reporter {
 	return di.GetToken(c, Reporter)
 }
+
+func GetRecorder(c di.ServiceRegistry) *backtest.Recorder {
+	return di.GetToken(c, Recorder)
+}
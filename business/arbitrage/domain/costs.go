@@ -13,7 +13,11 @@ type GasCost struct {
 	GasLimit uint64       // Gas units needed
 	GasPrice asset.Amount // Price per gas unit in ETH (wei)
 	TotalETH asset.Amount // Total cost in ETH
-	TotalUSD asset.Amount // Total cost in USD (converted)
+	TotalUSD asset.Amount // Total cost in USD, rounded up to the cent for display
+
+	// ExactUSD is the USD cost at full precision; cost calculations use it
+	// so cents are only rounded at the display boundary
+	ExactUSD decimal.Decimal
 }
 
 // NewGasCost creates a GasCost from gas parameters and ETH price.
@@ -26,16 +30,17 @@ func NewGasCost(gasLimit uint64, gasPriceWei *big.Int, ethPriceUSD decimal.Decim
 	totalETH := asset.NewAmount(asset.ETH, totalWei)
 
 	// Convert ETH to USD
-	// USD = ETH amount * ETH price
-	ethDecimal := totalETH.ToDecimal()
-	usdDecimal := ethDecimal.Mul(ethPriceUSD)
-	totalUSD, _ := asset.ParseDecimal(asset.USD, usdDecimal)
+	// USD = ETH amount * ETH price, rounded up to whole cents so sub-cent
+	// precision neither fails the parse nor understates the cost
+	exactUSD := totalETH.ToDecimal().Mul(ethPriceUSD)
+	totalUSD, _ := asset.ParseDecimal(asset.USD, exactUSD.RoundCeil(int32(asset.USD.Decimals())))
 
 	return &GasCost{
 		GasLimit: gasLimit,
 		GasPrice: gasPrice,
 		TotalETH: totalETH,
 		TotalUSD: totalUSD,
+		ExactUSD: exactUSD,
 	}
 }
 
@@ -44,17 +49,79 @@ func (g *GasCost) TotalWei() *big.Int {
 	return g.TotalETH.Raw()
 }
 
+// ExecutionMode is how the CEX leg of a trade is assumed to fill.
+type ExecutionMode string
+
+const (
+	// ExecutionTaker crosses the book: fills at the VWAP for the trade size
+	// and pays the taker fee.
+	ExecutionTaker ExecutionMode = "taker"
+	// ExecutionMaker rests a limit order at the best bid (buying) or best ask
+	// (selling) and pays the maker fee.
+	ExecutionMaker ExecutionMode = "maker"
+)
+
+// Execution describes how the CEX leg is executed. The zero value is a taker fill.
+type Execution struct {
+	Mode      ExecutionMode
+	BestPrice decimal.Decimal // Top-of-book price a maker order rests at (maker only)
+}
+
+// IsMaker reports whether the CEX leg is a resting limit order.
+func (e Execution) IsMaker() bool {
+	return e.Mode == ExecutionMaker
+}
+
 // ProfitResult contains the calculated profit for an opportunity.
+//
+// The asset.Amount fields are rounded to the quote asset's decimals and are
+// meant for display. Further arithmetic should use NetProfitRaw and Exact,
+// which keep full precision.
 type ProfitResult struct {
 	GrossProfit   asset.Amount    // Profit before any costs
 	GasCost       asset.Amount    // Gas cost in quote currency
 	ExchangeFees  asset.Amount    // Exchange trading fees (Uniswap + Binance)
-	TotalCosts    asset.Amount    // Gas + Exchange fees
+	TotalCosts    asset.Amount    // Gas + Exchange fees + Overheads
 	NetProfit     asset.Amount    // Profit after all costs (absolute value)
 	NetProfitRaw  decimal.Decimal // Net profit with sign (can be negative)
 	NetProfitPct  decimal.Decimal // Net profit as percentage of gross
 	IsProfitable  bool
 	TradeValueUSD asset.Amount // Total trade value for reference
+
+	// Slippage from DEX price impact, already deducted from GrossProfit
+	PriceImpactBps decimal.Decimal
+	SlippageCost   decimal.Decimal
+
+	// ExecutionMode is the CEX fill assumed for gross profit and fees
+	ExecutionMode ExecutionMode
+
+	// Overheads are the fixed round-trip costs included in TotalCosts
+	Overheads Overheads
+
+	// Exact holds the unrounded amounts behind the Amount fields
+	Exact ExactProfit
+}
+
+// ExactProfit is a profit breakdown at full decimal precision, with signs
+// preserved.
+type ExactProfit struct {
+	GrossProfit  decimal.Decimal
+	GasCost      decimal.Decimal
+	ExchangeFees decimal.Decimal
+	TotalCosts   decimal.Decimal
+}
+
+// Overheads are fixed per-trade costs outside the swap itself: the token
+// approval transaction and moving funds off and onto the CEX. All in USD.
+type Overheads struct {
+	ApprovalGasUSD   decimal.Decimal
+	WithdrawalFeeUSD decimal.Decimal
+	DepositFeeUSD    decimal.Decimal
+}
+
+// Total returns the sum of all overheads.
+func (o Overheads) Total() decimal.Decimal {
+	return o.ApprovalGasUSD.Add(o.WithdrawalFeeUSD).Add(o.DepositFeeUSD)
 }
 
 // NewProfitResult calculates profit from gross profit and gas cost.
@@ -80,6 +147,11 @@ func NewProfitResult(grossProfit, gasCost asset.Amount) (*ProfitResult, error) {
 		NetProfitRaw: netProfit.ToDecimal(),
 		NetProfitPct: pct,
 		IsProfitable: netProfit.IsPositive(),
+		Exact: ExactProfit{
+			GrossProfit: grossProfit.ToDecimal(),
+			GasCost:     gasCost.ToDecimal(),
+			TotalCosts:  gasCost.ToDecimal(),
+		},
 	}, nil
 }
 
@@ -112,13 +184,24 @@ func NewProfitResultFromDecimals(grossProfit, gasCost decimal.Decimal, quoteAsse
 		NetProfitRaw: netProfit, // Preserve sign
 		NetProfitPct: pct,
 		IsProfitable: isProfitable,
+		Exact: ExactProfit{
+			GrossProfit: grossProfit,
+			GasCost:     gasCost,
+			TotalCosts:  gasCost,
+		},
 	}
 }
 
 // NewProfitResultWithFees creates a ProfitResult including exchange fees.
 // This is the complete calculation for arbitrage profitability.
 func NewProfitResultWithFees(grossProfit, gasCost, exchangeFees decimal.Decimal, quoteAsset *asset.Asset) *ProfitResult {
-	totalCosts := gasCost.Add(exchangeFees)
+	return NewProfitResultWithOverheads(grossProfit, gasCost, exchangeFees, Overheads{}, quoteAsset)
+}
+
+// NewProfitResultWithOverheads is NewProfitResultWithFees with fixed
+// round-trip overheads added to the total costs.
+func NewProfitResultWithOverheads(grossProfit, gasCost, exchangeFees decimal.Decimal, overheads Overheads, quoteAsset *asset.Asset) *ProfitResult {
+	totalCosts := gasCost.Add(exchangeFees).Add(overheads.Total())
 	netProfit := grossProfit.Sub(totalCosts)
 
 	pct := decimal.Zero
@@ -126,7 +209,8 @@ func NewProfitResultWithFees(grossProfit, gasCost, exchangeFees decimal.Decimal,
 		pct = netProfit.Div(grossProfit).Mul(decimal.NewFromInt(100))
 	}
 
-	// Round to asset's decimal places (USD has 2 decimals)
+	// Round to asset's decimal places (USD has 2 decimals) only for the
+	// display Amounts; NetProfitRaw and Exact keep full precision
 	decimals := int32(quoteAsset.Decimals())
 	grossRounded := grossProfit.Abs().Round(decimals)
 	gasRounded := gasCost.Abs().Round(decimals)
@@ -148,8 +232,15 @@ func NewProfitResultWithFees(grossProfit, gasCost, exchangeFees decimal.Decimal,
 		ExchangeFees: fees,
 		TotalCosts:   costs,
 		NetProfit:    net,
-		NetProfitRaw: netProfit.Round(decimals), // Preserve sign for display
+		NetProfitRaw: netProfit, // Preserve sign and precision
 		NetProfitPct: pct,
 		IsProfitable: isProfitable,
+		Overheads:    overheads,
+		Exact: ExactProfit{
+			GrossProfit:  grossProfit,
+			GasCost:      gasCost,
+			ExchangeFees: exchangeFees,
+			TotalCosts:   totalCosts,
+		},
 	}
 }
@@ -65,6 +65,14 @@ func TestNewGasCost(t *testing.T) {
 			wantTotalETH: "0.009",             // 300000 * 30 gwei = 0.009 ETH
 			wantTotalUSD: "31.5",              // 0.009 * 3500 = 31.5 USD
 		},
+		{
+			name:         "sub_cent_rounds_up",
+			gasLimit:     185_000,
+			gasPriceWei:  "1000000000",        // 1 gwei
+			ethPriceUSD:  "3000",
+			wantTotalETH: "0.000185",
+			wantTotalUSD: "0.56",              // 0.555 USD, rounded up to the cent
+		},
 		{
 			name:         "zero_gas_limit",
 			gasLimit:     0,
@@ -254,8 +262,8 @@ func TestNewProfitResultWithFees(t *testing.T) {
 				t.Errorf("ExchangeFees = %s, want %s", gotFees, fees.Abs().Round(2))
 			}
 
-			// Check NetProfitRaw preserves the sign
-			expectedRaw := gross.Sub(gas).Sub(fees).Round(2)
+			// Check NetProfitRaw preserves the sign and full precision
+			expectedRaw := gross.Sub(gas).Sub(fees)
 			if !result.NetProfitRaw.Equal(expectedRaw) {
 				t.Errorf("NetProfitRaw = %s, want %s", result.NetProfitRaw, expectedRaw)
 			}
@@ -346,6 +354,53 @@ func TestNewProfitResultFromDecimals_Negative(t *testing.T) {
 	}
 }
 
+func TestNewGasCost_ExactUSD(t *testing.T) {
+	gasPrice, _ := new(big.Int).SetString("1000000000", 10) // 1 gwei
+	gasCost := NewGasCost(185_000, gasPrice, decimal.NewFromInt(3000))
+
+	// 0.000185 ETH * 3000 = 0.555 USD: only the display Amount rounds
+	if want := decimal.RequireFromString("0.555"); !gasCost.ExactUSD.Equal(want) {
+		t.Errorf("ExactUSD = %s, want %s", gasCost.ExactUSD, want)
+	}
+	if want := decimal.RequireFromString("0.56"); !gasCost.TotalUSD.ToDecimal().Equal(want) {
+		t.Errorf("TotalUSD = %s, want %s", gasCost.TotalUSD.ToDecimal(), want)
+	}
+}
+
+func TestNewProfitResultWithOverheads_PreservesPrecision(t *testing.T) {
+	// Sub-cent components on a large notional: rounding each one to cents
+	// before summing would be off by up to half a cent per term
+	gross := decimal.RequireFromString("12345.674999")
+	gas := decimal.RequireFromString("0.555")
+	fees := decimal.RequireFromString("4938.271605")
+	overheads := Overheads{
+		ApprovalGasUSD:   decimal.RequireFromString("0.135"),
+		WithdrawalFeeUSD: decimal.RequireFromString("1.005"),
+	}
+
+	result := NewProfitResultWithOverheads(gross, gas, fees, overheads, asset.USD)
+
+	wantCosts := decimal.RequireFromString("4939.966605")
+	wantNet := decimal.RequireFromString("7405.708394")
+	if !result.Exact.TotalCosts.Equal(wantCosts) {
+		t.Errorf("Exact.TotalCosts = %s, want %s", result.Exact.TotalCosts, wantCosts)
+	}
+	if !result.NetProfitRaw.Equal(wantNet) {
+		t.Errorf("NetProfitRaw = %s, want %s", result.NetProfitRaw, wantNet)
+	}
+	if !result.Exact.GrossProfit.Equal(gross) || !result.Exact.GasCost.Equal(gas) || !result.Exact.ExchangeFees.Equal(fees) {
+		t.Errorf("Exact = %+v, want the unrounded inputs", result.Exact)
+	}
+
+	// The display Amounts are rounded once, from the exact values
+	if got, want := result.TotalCosts.ToDecimal(), wantCosts.Round(2); !got.Equal(want) {
+		t.Errorf("TotalCosts = %s, want %s", got, want)
+	}
+	if got, want := result.NetProfit.ToDecimal(), wantNet.Round(2); !got.Equal(want) {
+		t.Errorf("NetProfit = %s, want %s", got, want)
+	}
+}
+
 // Benchmark for performance
 func BenchmarkNewProfitResultWithFees(b *testing.B) {
 	gross := decimal.RequireFromString("100.50")
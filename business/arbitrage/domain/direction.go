@@ -10,6 +10,9 @@ const (
 
 	// DirectionDEXToCEX means buy on DEX, sell on CEX.
 	DirectionDEXToCEX Direction = "DEX_TO_CEX"
+
+	// DirectionTriangular means a multi-leg cycle returning to the start asset.
+	DirectionTriangular Direction = "TRIANGULAR"
 )
 
 // String returns a human-readable description of the direction.
@@ -19,6 +22,8 @@ func (d Direction) String() string {
 		return "CEX → DEX (Buy on Binance, Sell on Uniswap)"
 	case DirectionDEXToCEX:
 		return "DEX → CEX (Buy on Uniswap, Sell on Binance)"
+	case DirectionTriangular:
+		return "Triangular (multi-leg cycle)"
 	default:
 		return "Unknown"
 	}
@@ -31,6 +36,8 @@ func (d Direction) ShortString() string {
 		return "CEX→DEX"
 	case DirectionDEXToCEX:
 		return "DEX→CEX"
+	case DirectionTriangular:
+		return "TRI"
 	default:
 		return "???"
 	}
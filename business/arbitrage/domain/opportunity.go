@@ -2,6 +2,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
@@ -21,23 +22,89 @@ type RiskFactor struct {
 	Severity    string // "low", "medium", "high"
 }
 
+// Leg represents a single trade within a multi-leg (triangular) opportunity.
+type Leg struct {
+	Pair      pricingDomain.Pair
+	Side      pricingDomain.Side // Sell = base -> quote, Buy = quote -> base
+	Venue     string             // Venue executing the leg (e.g., "binance", "uniswap")
+	Price     decimal.Decimal    // Effective price in quote per base
+	AmountIn  decimal.Decimal    // Amount of the input asset
+	AmountOut decimal.Decimal    // Amount of the output asset
+}
+
+// AssetIn returns the symbol of the asset spent in this leg.
+func (l Leg) AssetIn() string {
+	if l.Side == pricingDomain.SideSell {
+		return l.Pair.Base.Symbol()
+	}
+	return l.Pair.Quote.Symbol()
+}
+
+// AssetOut returns the symbol of the asset received in this leg.
+func (l Leg) AssetOut() string {
+	if l.Side == pricingDomain.SideSell {
+		return l.Pair.Quote.Symbol()
+	}
+	return l.Pair.Base.Symbol()
+}
+
 // Opportunity represents a detected arbitrage opportunity.
 type Opportunity struct {
-	ID              string
-	BlockNumber     uint64
-	Timestamp       time.Time
-	Pair            pricingDomain.Pair
-	Direction       Direction
-	TradeSize       decimal.Decimal
-	CEXPrice        decimal.Decimal
-	DEXPrice        decimal.Decimal
-	Spread          pricingDomain.Spread
-	GasCost         *GasCost
-	Profit          *ProfitResult
-	DEXQuote        *pricingDomain.Quote
-	ExecutionSteps  []ExecutionStep
-	RiskFactors     []RiskFactor
-	RequiredCapital decimal.Decimal
+	ID               string
+	BlockNumber      uint64
+	Timestamp        time.Time
+	Pair             pricingDomain.Pair
+	Direction        Direction
+	TradeSize        decimal.Decimal
+	TradeSizeUSD     decimal.Decimal // USD notional TradeSize was converted from (zero = sized in base units)
+	CEXVenue         string          // CEX venue that supplied CEXPrice (e.g., "binance")
+	CEXPrice         decimal.Decimal
+	DEXPrice         decimal.Decimal
+	Spread           pricingDomain.Spread
+	GasCost          *GasCost
+	Profit           *ProfitResult
+	DEXQuote         *pricingDomain.Quote
+	ExecutionSteps   []ExecutionStep
+	RiskFactors      []RiskFactor
+	RequiredCapital  decimal.Decimal
+	AvailableCapital decimal.Decimal   // Configured capital limit (zero = unlimited)
+	OptimalSize      decimal.Decimal   // Largest size within capital and slippage limits
+	Legs             []Leg             // Legs of a triangular cycle (empty for direct opportunities)
+	Simulation       *SimulationResult // Simulated two-leg execution (nil unless simulation is enabled)
+	SizeRange        *SizeRange        // Profitable sizes collapsed into this one (nil unless deduplicated)
+}
+
+// SizeRange spans the profitable trade sizes of one market condition when
+// several are reported as a single opportunity.
+type SizeRange struct {
+	Min   decimal.Decimal
+	Max   decimal.Decimal
+	Count int // Number of profitable sizes collapsed
+}
+
+// IsTriangular returns true if this is a multi-leg cycle opportunity.
+func (o *Opportunity) IsTriangular() bool {
+	return len(o.Legs) > 0
+}
+
+// Route returns a display label for the opportunity.
+// Direct opportunities return the pair (e.g., "ETH-USDC"), triangular
+// opportunities return the asset path (e.g., "ETH→USDC→WBTC→ETH").
+func (o *Opportunity) Route() string {
+	if !o.IsTriangular() {
+		return o.Pair.String()
+	}
+	path := make([]string, 0, len(o.Legs)+1)
+	path = append(path, o.Legs[0].AssetIn())
+	for _, leg := range o.Legs {
+		path = append(path, leg.AssetOut())
+	}
+	return strings.Join(path, "→")
+}
+
+// ExceedsCapital returns true if the opportunity needs more capital than is available.
+func (o *Opportunity) ExceedsCapital() bool {
+	return o.AvailableCapital.IsPositive() && o.RequiredCapital.GreaterThan(o.AvailableCapital)
 }
 
 // IsProfitable returns true if this opportunity has positive net profit.
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SafetyEvent is a change in the detector's safety state: detection halted
+// because a safety condition tripped, or resumed once conditions cleared.
+type SafetyEvent struct {
+	BlockNumber uint64
+	Timestamp   time.Time
+	Halted      bool
+	Reasons     []string // Conditions that tripped (empty on resume)
+}
+
+// String describes the event for logs and alerts.
+func (e *SafetyEvent) String() string {
+	if !e.Halted {
+		return fmt.Sprintf("detection resumed at block %d", e.BlockNumber)
+	}
+	return fmt.Sprintf("detection halted at block %d: %s", e.BlockNumber, strings.Join(e.Reasons, "; "))
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+// SimulationResult is an opportunity re-priced as if both legs were executed:
+// the CEX leg walks the book on the side it actually trades, and the DEX leg
+// carries the pool's concentrated-liquidity price impact.
+type SimulationResult struct {
+	// CEX leg: VWAP through the book on the traded side
+	CEXFillPrice   decimal.Decimal
+	CEXFilled      decimal.Decimal // Base amount the book could fill (< TradeSize when thin)
+	CEXSlippageBps decimal.Decimal // VWAP vs top of book
+
+	// DEX leg: effective swap price including price impact
+	DEXFillPrice   decimal.Decimal
+	DEXSlippageBps decimal.Decimal // Pool price impact (zero when unknown)
+
+	RealizedGrossProfit decimal.Decimal // Price difference × filled size
+	RealizedNetProfit   decimal.Decimal // After the opportunity's fees, gas and overheads
+	RealizedSpreadBps   decimal.Decimal
+}
+
+// FullyFilled reports whether the CEX book could absorb the whole trade size.
+func (s *SimulationResult) FullyFilled(tradeSize decimal.Decimal) bool {
+	return s.CEXFilled.GreaterThanOrEqual(tradeSize)
+}
+
+// Capturable reports whether the simulated trade fills completely and still
+// nets a profit after both legs' slippage.
+func (s *SimulationResult) Capturable(tradeSize decimal.Decimal) bool {
+	return s.FullyFilled(tradeSize) && s.RealizedNetProfit.IsPositive()
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ProfitSummary aggregates the profitable opportunities reported over one
+// interval. Trade sizes of the same block, pair and direction compete for the
+// same liquidity, so each counts once towards the profit, at its best size.
+type ProfitSummary struct {
+	Start time.Time
+	End   time.Time
+
+	Opportunities    int             // Profitable opportunities reported in the interval
+	ProfitableBlocks int             // Distinct blocks with at least one of them
+	NetProfitUSD     decimal.Decimal // Theoretical net profit of the interval
+	TotalProfitUSD   decimal.Decimal // Theoretical net profit since startup
+	Best             *Opportunity    // Most profitable opportunity of the interval (nil if none)
+}
+
+// Window returns the length of the summarized interval.
+func (s *ProfitSummary) Window() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// BestProfitUSD returns the net profit of the best opportunity, or zero.
+func (s *ProfitSummary) BestProfitUSD() decimal.Decimal {
+	if s.Best == nil || s.Best.Profit == nil {
+		return decimal.Zero
+	}
+	return s.Best.Profit.NetProfitRaw
+}
@@ -0,0 +1,250 @@
+package infra
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/health"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// Ensure AggregatingReporter implements Reporter.
+var _ app.Reporter = (*AggregatingReporter)(nil)
+
+// summaryKey identifies opportunities competing for the same liquidity.
+type summaryKey struct {
+	block     uint64
+	route     string
+	direction domain.Direction
+}
+
+// AggregatingReporter wraps a reporter and accumulates the profitable
+// opportunities passing through it. Every interval it logs a ProfitSummary
+// and hands it to the wrapped reporter if that implements SummaryReporter.
+type AggregatingReporter struct {
+	next     app.Reporter
+	interval time.Duration
+	logger   logger.LoggerInterface
+
+	mu     sync.Mutex
+	start  time.Time
+	count  int
+	best   *domain.Opportunity
+	profit map[summaryKey]decimal.Decimal // Best net profit per block, pair and direction
+	blocks map[uint64]struct{}
+	total  decimal.Decimal
+
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	startOnce sync.Once
+}
+
+// NewAggregatingReporter creates a reporter summarizing every interval
+// around next (which may be nil).
+func NewAggregatingReporter(interval time.Duration, next app.Reporter, log logger.LoggerInterface) *AggregatingReporter {
+	return &AggregatingReporter{
+		next:     next,
+		interval: interval,
+		logger:   log,
+		start:    time.Now(),
+		profit:   make(map[summaryKey]decimal.Decimal),
+		blocks:   make(map[uint64]struct{}),
+	}
+}
+
+// Start starts the wrapped reporter and the summary loop.
+func (r *AggregatingReporter) Start(ctx context.Context) error {
+	if r.next != nil {
+		if err := r.next.Start(ctx); err != nil {
+			return err
+		}
+	}
+
+	r.startOnce.Do(func() {
+		loopCtx, cancel := context.WithCancel(context.Background())
+		r.cancel = cancel
+
+		r.mu.Lock()
+		r.start = time.Now()
+		r.mu.Unlock()
+
+		r.wg.Add(1)
+		go r.summaryLoop(loopCtx)
+	})
+	return nil
+}
+
+// Report forwards the opportunity and adds it to the current interval.
+func (r *AggregatingReporter) Report(opp *domain.Opportunity) {
+	if r.next != nil {
+		r.next.Report(opp)
+	}
+	if !opp.IsProfitable() {
+		return
+	}
+
+	netProfit := opp.Profit.NetProfitRaw
+	key := summaryKey{block: opp.BlockNumber, route: opp.Route(), direction: opp.Direction}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.count++
+	r.blocks[opp.BlockNumber] = struct{}{}
+	if prev, ok := r.profit[key]; !ok || netProfit.GreaterThan(prev) {
+		r.profit[key] = netProfit
+	}
+	if r.best == nil || netProfit.GreaterThan(r.best.Profit.NetProfitRaw) {
+		r.best = opp
+	}
+}
+
+// UpdatePrices forwards to the wrapped reporter.
+func (r *AggregatingReporter) UpdatePrices(prices *pricingDomain.PriceSnapshot) {
+	if r.next != nil {
+		r.next.UpdatePrices(prices)
+	}
+}
+
+// UpdateConnectionStatus forwards to the wrapped reporter.
+func (r *AggregatingReporter) UpdateConnectionStatus(name string, connected bool, latency time.Duration) {
+	if r.next != nil {
+		r.next.UpdateConnectionStatus(name, connected, latency)
+	}
+}
+
+// UpdateBlock forwards to the wrapped reporter.
+func (r *AggregatingReporter) UpdateBlock(blockNumber uint64) {
+	if r.next != nil {
+		r.next.UpdateBlock(blockNumber)
+	}
+}
+
+// UpdateGasPrice forwards to the wrapped reporter.
+func (r *AggregatingReporter) UpdateGasPrice(gweiPrice float64) {
+	if r.next != nil {
+		r.next.UpdateGasPrice(gweiPrice)
+	}
+}
+
+// UpdateCostBreakdown forwards to the wrapped reporter.
+func (r *AggregatingReporter) UpdateCostBreakdown(breakdown *app.CostBreakdown) {
+	if r.next != nil {
+		r.next.UpdateCostBreakdown(breakdown)
+	}
+}
+
+// UpdateGasPercentile forwards to the wrapped reporter if it shows gas ranks.
+func (r *AggregatingReporter) UpdateGasPercentile(gweiPrice, percentileRank float64) {
+	if next, ok := r.next.(app.GasPercentileReporter); ok {
+		next.UpdateGasPercentile(gweiPrice, percentileRank)
+	}
+}
+
+// UpdateConnectionStats forwards to the wrapped reporter if it shows
+// connection stats.
+func (r *AggregatingReporter) UpdateConnectionStats(name string, stats health.ConnectionStats) {
+	if next, ok := r.next.(app.ConnectionStatsReporter); ok {
+		next.UpdateConnectionStats(name, stats)
+	}
+}
+
+// ReportSafety forwards to the wrapped reporter if it handles safety events.
+func (r *AggregatingReporter) ReportSafety(event *domain.SafetyEvent) {
+	if next, ok := r.next.(app.SafetyReporter); ok {
+		next.ReportSafety(event)
+	}
+}
+
+// Stop stops the summary loop, emits the partial interval if it saw any
+// opportunities, and stops the wrapped reporter.
+func (r *AggregatingReporter) Stop() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+
+	if summary := r.flush(time.Now()); summary.Opportunities > 0 {
+		r.emit(summary)
+	}
+
+	if r.next != nil {
+		return r.next.Stop()
+	}
+	return nil
+}
+
+// summaryLoop emits a summary every interval until ctx is cancelled.
+func (r *AggregatingReporter) summaryLoop(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.emit(r.flush(now))
+		}
+	}
+}
+
+// flush closes the current interval at now and starts the next one.
+func (r *AggregatingReporter) flush(now time.Time) *domain.ProfitSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	netProfit := decimal.Zero
+	for _, profit := range r.profit {
+		netProfit = netProfit.Add(profit)
+	}
+	r.total = r.total.Add(netProfit)
+
+	summary := &domain.ProfitSummary{
+		Start:            r.start,
+		End:              now,
+		Opportunities:    r.count,
+		ProfitableBlocks: len(r.blocks),
+		NetProfitUSD:     netProfit,
+		TotalProfitUSD:   r.total,
+		Best:             r.best,
+	}
+
+	r.start = now
+	r.count = 0
+	r.best = nil
+	clear(r.profit)
+	clear(r.blocks)
+
+	return summary
+}
+
+// emit logs the summary and hands it to the wrapped reporter.
+func (r *AggregatingReporter) emit(summary *domain.ProfitSummary) {
+	args := []any{
+		"window", summary.Window().Round(time.Second),
+		"opportunities", summary.Opportunities,
+		"profitable_blocks", summary.ProfitableBlocks,
+		"net_profit_usd", summary.NetProfitUSD.StringFixed(2),
+		"total_profit_usd", summary.TotalProfitUSD.StringFixed(2),
+	}
+	if summary.Best != nil {
+		args = append(args,
+			"best_pair", summary.Best.Route(),
+			"best_profit_usd", summary.BestProfitUSD().StringFixed(2),
+			"best_block", summary.Best.BlockNumber)
+	}
+	r.logger.Info(context.Background(), "profit summary", args...)
+
+	if next, ok := r.next.(app.SummaryReporter); ok {
+		next.ReportSummary(summary)
+	}
+}
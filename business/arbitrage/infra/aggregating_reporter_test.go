@@ -0,0 +1,121 @@
+package infra
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// nopLogger discards log output.
+type nopLogger struct{}
+
+func (nopLogger) Debug(ctx context.Context, msg string, args ...any)              {}
+func (nopLogger) Info(ctx context.Context, msg string, args ...any)               {}
+func (nopLogger) Warn(ctx context.Context, msg string, args ...any)               {}
+func (nopLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (nopLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (nopLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (nopLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (nopLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+// summaryRecorder is a reporter recording the summaries it receives.
+type summaryRecorder struct {
+	*ConsoleReporter
+	reported  int
+	summaries []*domain.ProfitSummary
+}
+
+func (r *summaryRecorder) Report(opp *domain.Opportunity) { r.reported++ }
+
+func (r *summaryRecorder) ReportSummary(summary *domain.ProfitSummary) {
+	r.summaries = append(r.summaries, summary)
+}
+
+func summaryOpp(pair pricingDomain.Pair, block uint64, netProfit string, profitable bool) *domain.Opportunity {
+	return &domain.Opportunity{
+		BlockNumber: block,
+		Pair:        pair,
+		Direction:   domain.DirectionCEXToDEX,
+		Profit: &domain.ProfitResult{
+			NetProfitRaw: decimal.RequireFromString(netProfit),
+			IsProfitable: profitable,
+		},
+	}
+}
+
+func TestAggregatingReporter_Summary(t *testing.T) {
+	eth := pricingDomain.NewPair(asset.ETH, asset.USDC)
+	btc := pricingDomain.NewPair(asset.WBTC, asset.USDC)
+
+	next := &summaryRecorder{ConsoleReporter: NewConsoleReporter(NewMarketState(0))}
+	r := NewAggregatingReporter(time.Hour, next, nopLogger{})
+
+	r.Report(summaryOpp(eth, 100, "10", true))
+	r.Report(summaryOpp(eth, 100, "25", true)) // Larger size, same block/pair/direction
+	r.Report(summaryOpp(btc, 100, "5", true))
+	r.Report(summaryOpp(eth, 101, "8", true))
+	r.Report(summaryOpp(eth, 102, "-3", false))
+
+	if next.reported != 5 {
+		t.Errorf("expected every opportunity forwarded, got %d", next.reported)
+	}
+
+	start := r.start
+	summary := r.flush(start.Add(time.Minute))
+	if summary.Window() != time.Minute {
+		t.Errorf("expected a 1m window, got %s", summary.Window())
+	}
+	if summary.Opportunities != 4 || summary.ProfitableBlocks != 2 {
+		t.Errorf("expected 4 opportunities in 2 blocks, got %d in %d", summary.Opportunities, summary.ProfitableBlocks)
+	}
+	// Best size per block/pair/direction: 25 + 5 + 8
+	if !summary.NetProfitUSD.Equal(decimal.NewFromInt(38)) {
+		t.Errorf("expected net profit 38, got %s", summary.NetProfitUSD)
+	}
+	if !summary.BestProfitUSD().Equal(decimal.NewFromInt(25)) {
+		t.Errorf("expected best profit 25, got %s", summary.BestProfitUSD())
+	}
+
+	r.Report(summaryOpp(btc, 103, "2", true))
+	summary = r.flush(start.Add(2 * time.Minute))
+	if summary.Opportunities != 1 || !summary.NetProfitUSD.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("expected the interval to restart, got %d opportunities for %s", summary.Opportunities, summary.NetProfitUSD)
+	}
+	if !summary.TotalProfitUSD.Equal(decimal.NewFromInt(40)) {
+		t.Errorf("expected cumulative profit 40, got %s", summary.TotalProfitUSD)
+	}
+
+	empty := r.flush(start.Add(3 * time.Minute))
+	if empty.Opportunities != 0 || empty.Best != nil || !empty.BestProfitUSD().IsZero() {
+		t.Errorf("expected an empty interval, got %+v", empty)
+	}
+
+	r.emit(summary)
+	if len(next.summaries) != 1 || next.summaries[0] != summary {
+		t.Errorf("expected the summary handed to the wrapped reporter, got %v", next.summaries)
+	}
+}
+
+func TestAggregatingReporter_StopEmitsPartialInterval(t *testing.T) {
+	next := &summaryRecorder{ConsoleReporter: NewConsoleReporter(NewMarketState(0))}
+	next.out = io.Discard
+	r := NewAggregatingReporter(time.Hour, next, nopLogger{})
+	if err := r.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	r.Report(summaryOpp(pricingDomain.NewPair(asset.ETH, asset.USDC), 100, "10", true))
+	if err := r.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if len(next.summaries) != 1 || next.summaries[0].Opportunities != 1 {
+		t.Errorf("expected the partial interval emitted on stop, got %v", next.summaries)
+	}
+}
@@ -0,0 +1,37 @@
+// Package alerting notifies external channels about profitable opportunities.
+package alerting
+
+import (
+	"sync"
+	"time"
+)
+
+// debouncer suppresses repeated alerts for the same key within a window.
+type debouncer struct {
+	window time.Duration
+	last   map[string]time.Time
+	mu     sync.Mutex
+	now    func() time.Time
+}
+
+// newDebouncer creates a debouncer; a zero window never suppresses.
+func newDebouncer(window time.Duration) *debouncer {
+	return &debouncer{
+		window: window,
+		last:   make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether an alert for key may fire now and records it if so.
+func (d *debouncer) Allow(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if last, ok := d.last[key]; ok && now.Sub(last) < d.window {
+		return false
+	}
+	d.last[key] = now
+	return true
+}
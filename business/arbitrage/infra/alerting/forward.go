@@ -0,0 +1,104 @@
+package alerting
+
+import (
+	"context"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/health"
+)
+
+// forwarder passes every Reporter call through to a wrapped reporter.
+// Alert reporters embed it and override Report (and Stop when they own goroutines).
+type forwarder struct {
+	next app.Reporter // May be nil when alerting is the only output
+}
+
+// Start starts the wrapped reporter.
+func (f forwarder) Start(ctx context.Context) error {
+	if f.next != nil {
+		return f.next.Start(ctx)
+	}
+	return nil
+}
+
+// Report forwards to the wrapped reporter.
+func (f forwarder) Report(opp *domain.Opportunity) {
+	if f.next != nil {
+		f.next.Report(opp)
+	}
+}
+
+// UpdatePrices forwards to the wrapped reporter.
+func (f forwarder) UpdatePrices(prices *pricingDomain.PriceSnapshot) {
+	if f.next != nil {
+		f.next.UpdatePrices(prices)
+	}
+}
+
+// UpdateConnectionStatus forwards to the wrapped reporter.
+func (f forwarder) UpdateConnectionStatus(name string, connected bool, latency time.Duration) {
+	if f.next != nil {
+		f.next.UpdateConnectionStatus(name, connected, latency)
+	}
+}
+
+// UpdateBlock forwards to the wrapped reporter.
+func (f forwarder) UpdateBlock(blockNumber uint64) {
+	if f.next != nil {
+		f.next.UpdateBlock(blockNumber)
+	}
+}
+
+// UpdateGasPrice forwards to the wrapped reporter.
+func (f forwarder) UpdateGasPrice(gweiPrice float64) {
+	if f.next != nil {
+		f.next.UpdateGasPrice(gweiPrice)
+	}
+}
+
+// UpdateCostBreakdown forwards to the wrapped reporter.
+func (f forwarder) UpdateCostBreakdown(breakdown *app.CostBreakdown) {
+	if f.next != nil {
+		f.next.UpdateCostBreakdown(breakdown)
+	}
+}
+
+// ReportSummary forwards to the wrapped reporter if it handles summaries.
+func (f forwarder) ReportSummary(summary *domain.ProfitSummary) {
+	if next, ok := f.next.(app.SummaryReporter); ok {
+		next.ReportSummary(summary)
+	}
+}
+
+// UpdateGasPercentile forwards to the wrapped reporter if it shows gas ranks.
+func (f forwarder) UpdateGasPercentile(gweiPrice, percentileRank float64) {
+	if next, ok := f.next.(app.GasPercentileReporter); ok {
+		next.UpdateGasPercentile(gweiPrice, percentileRank)
+	}
+}
+
+// UpdateConnectionStats forwards to the wrapped reporter if it shows
+// connection stats.
+func (f forwarder) UpdateConnectionStats(name string, stats health.ConnectionStats) {
+	if next, ok := f.next.(app.ConnectionStatsReporter); ok {
+		next.UpdateConnectionStats(name, stats)
+	}
+}
+
+// ReportSafety forwards to the wrapped reporter if it handles safety events.
+func (f forwarder) ReportSafety(event *domain.SafetyEvent) {
+	if next, ok := f.next.(app.SafetyReporter); ok {
+		next.ReportSafety(event)
+	}
+}
+
+// Stop stops the wrapped reporter.
+func (f forwarder) Stop() error {
+	if f.next != nil {
+		return f.next.Stop()
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package alerting
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	"github.com/fd1az/arbitrage-bot/internal/grpcserver"
+	"github.com/fd1az/arbitrage-bot/internal/grpcserver/arbitragev1"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+const defaultStreamBuffer = 64
+
+// Ensure GRPCReporter implements Reporter and feeds the gRPC server.
+var (
+	_ app.Reporter           = (*GRPCReporter)(nil)
+	_ grpcserver.Broadcaster = (*GRPCReporter)(nil)
+)
+
+// GRPCConfig holds gRPC streaming settings.
+type GRPCConfig struct {
+	BufferSize int // Opportunities queued per client before it is dropped
+}
+
+// GRPCReporter implements Reporter by fanning opportunities out to connected
+// gRPC streams. Each client gets a buffered channel; a client whose buffer is
+// full is dropped instead of blocking the detection loop.
+type GRPCReporter struct {
+	forwarder
+
+	cfg    GRPCConfig
+	logger logger.LoggerInterface
+
+	mu          sync.Mutex
+	subscribers map[int]chan *arbitragev1.Opportunity
+	nextID      int
+	stopped     chan struct{} // Closed by Stop
+}
+
+// NewGRPCReporter creates a gRPC streaming reporter wrapping next (which may be nil).
+func NewGRPCReporter(cfg GRPCConfig, next app.Reporter, log logger.LoggerInterface) *GRPCReporter {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultStreamBuffer
+	}
+	return &GRPCReporter{
+		forwarder:   forwarder{next: next},
+		cfg:         cfg,
+		logger:      log,
+		subscribers: make(map[int]chan *arbitragev1.Opportunity),
+		stopped:     make(chan struct{}),
+	}
+}
+
+// Subscribe registers a client stream. After Stop the returned channel is
+// already closed.
+func (r *GRPCReporter) Subscribe() (<-chan *arbitragev1.Opportunity, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ch := make(chan *arbitragev1.Opportunity, r.cfg.BufferSize)
+	if r.isStopped() {
+		close(ch)
+		return ch, func() {}
+	}
+
+	id := r.nextID
+	r.nextID++
+	r.subscribers[id] = ch
+
+	return ch, func() { r.unsubscribe(id) }
+}
+
+// unsubscribe removes and closes a subscriber if it is still registered.
+// Callers must not hold mu.
+func (r *GRPCReporter) unsubscribe(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ch, ok := r.subscribers[id]; ok {
+		delete(r.subscribers, id)
+		close(ch)
+	}
+}
+
+// Stopped is closed once Stop is called, before the streams are closed.
+func (r *GRPCReporter) Stopped() <-chan struct{} {
+	return r.stopped
+}
+
+// isStopped reports whether Stop was called. Callers must hold mu.
+func (r *GRPCReporter) isStopped() bool {
+	select {
+	case <-r.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// Subscribers returns the number of connected streams.
+func (r *GRPCReporter) Subscribers() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.subscribers)
+}
+
+// Report forwards the opportunity and pushes it to every connected stream.
+func (r *GRPCReporter) Report(opp *domain.Opportunity) {
+	r.forwarder.Report(opp)
+
+	msg := NewOpportunityMessage(opp)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, ch := range r.subscribers {
+		select {
+		case ch <- msg:
+		default:
+			// Slow consumer: drop it rather than block the detector
+			delete(r.subscribers, id)
+			close(ch)
+			r.logger.Warn(context.Background(), "dropping slow grpc stream", "subscriber", id, "buffer", r.cfg.BufferSize)
+		}
+	}
+}
+
+// Stop closes every stream and stops the wrapped reporter.
+func (r *GRPCReporter) Stop() error {
+	r.mu.Lock()
+	if !r.isStopped() {
+		close(r.stopped)
+	}
+	for id, ch := range r.subscribers {
+		delete(r.subscribers, id)
+		close(ch)
+	}
+	r.mu.Unlock()
+
+	return r.forwarder.Stop()
+}
+
+// NewOpportunityMessage converts an opportunity to its gRPC message.
+func NewOpportunityMessage(opp *domain.Opportunity) *arbitragev1.Opportunity {
+	msg := &arbitragev1.Opportunity{
+		Id:          opp.ID,
+		Pair:        opp.Route(),
+		Direction:   string(opp.Direction),
+		TradeSize:   opp.TradeSize.String(),
+		CexVenue:    opp.CEXVenue,
+		CexPrice:    opp.CEXPrice.String(),
+		DexPrice:    opp.DEXPrice.String(),
+		SpreadBps:   opp.Spread.BasisPoints.StringFixed(2),
+		Profitable:  opp.IsProfitable(),
+		BlockNumber: opp.BlockNumber,
+		Timestamp:   timestamppb.New(opp.Timestamp),
+	}
+	if opp.Profit != nil {
+		msg.GrossProfitUsd = opp.Profit.GrossProfit.ToDecimal().StringFixed(2)
+		msg.NetProfitUsd = opp.Profit.NetProfitRaw.StringFixed(2)
+	}
+	for _, leg := range opp.Legs {
+		msg.Legs = append(msg.Legs, &arbitragev1.Leg{
+			Pair:      leg.Pair.String(),
+			Side:      string(leg.Side),
+			Venue:     leg.Venue,
+			Price:     leg.Price.String(),
+			AmountIn:  leg.AmountIn.String(),
+			AmountOut: leg.AmountOut.String(),
+		})
+	}
+	return msg
+}
@@ -0,0 +1,58 @@
+package alerting
+
+import (
+	"testing"
+)
+
+// TestGRPCReporter_FanOut tests delivery to every stream and dropping slow consumers.
+func TestGRPCReporter_FanOut(t *testing.T) {
+	r := NewGRPCReporter(GRPCConfig{BufferSize: 1}, nil, &mockLogger{})
+
+	fast, cancelFast := r.Subscribe()
+	defer cancelFast()
+	slow, cancelSlow := r.Subscribe()
+	defer cancelSlow()
+
+	r.Report(newOpportunity(ethUSDC, 100, "25", true))
+	if msg := <-fast; msg.GetBlockNumber() != 100 || msg.GetPair() != "ETH-USDC" || msg.GetNetProfitUsd() != "25.00" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+
+	// slow still holds block 100, so block 101 overflows its buffer
+	r.Report(newOpportunity(ethUSDC, 101, "30", true))
+	if msg := <-fast; msg.GetBlockNumber() != 101 {
+		t.Errorf("expected block 101 on fast stream, got %d", msg.GetBlockNumber())
+	}
+	if r.Subscribers() != 1 {
+		t.Errorf("expected slow stream to be dropped, %d subscribers left", r.Subscribers())
+	}
+	if msg := <-slow; msg.GetBlockNumber() != 100 {
+		t.Errorf("expected buffered block 100 on slow stream, got %d", msg.GetBlockNumber())
+	}
+	if _, ok := <-slow; ok {
+		t.Error("expected slow stream to be closed")
+	}
+	select {
+	case <-r.Stopped():
+		t.Error("expected a dropped stream not to report a stop")
+	default:
+	}
+
+	if err := r.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if _, ok := <-fast; ok {
+		t.Error("expected Stop to close remaining streams")
+	}
+	select {
+	case <-r.Stopped():
+	default:
+		t.Error("expected Stopped to be closed after Stop")
+	}
+
+	late, cancelLate := r.Subscribe()
+	defer cancelLate()
+	if _, ok := <-late; ok {
+		t.Error("expected a stream subscribed after Stop to be closed")
+	}
+}
@@ -0,0 +1,359 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/httpclient"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/fd1az/arbitrage-bot/internal/ratelimit"
+)
+
+const (
+	// TelegramAPIURL is the Telegram Bot API base URL.
+	TelegramAPIURL = "https://api.telegram.org"
+
+	// Telegram allows ~20 messages per minute to the same group chat.
+	defaultTelegramMessagesPerMinute = 20
+	defaultTelegramQueueSize         = 32
+	defaultTelegramDrainTimeout      = 10 * time.Second
+)
+
+// Ensure TelegramReporter implements Reporter.
+var _ app.Reporter = (*TelegramReporter)(nil)
+
+// TelegramConfig holds Telegram alert settings.
+type TelegramConfig struct {
+	BotToken          string
+	ChatID            string
+	APIURL            string          // Defaults to TelegramAPIURL
+	MinProfitUSD      decimal.Decimal // Only alert above this net profit
+	Debounce          time.Duration   // Minimum time between alerts for the same pair (0 = every block)
+	MessagesPerMinute int             // Send rate limit (flood control)
+	QueueSize         int             // Pending messages; alerts are dropped when full
+	DrainTimeout      time.Duration   // How long Stop keeps sending queued messages
+	Timeout           time.Duration   // Request timeout
+	SendSummaries     bool            // Also send periodic profit summaries with opportunities
+}
+
+// sendMessageRequest is the Bot API sendMessage body.
+type sendMessageRequest struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+// sendMessageResponse is the Bot API response envelope.
+type sendMessageResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// TelegramReporter implements Reporter by sending profitable opportunities to a Telegram chat.
+// Messages are queued and sent by a single rate-limited worker, so Report never blocks
+// the detection loop; when the queue is full the alert is dropped and logged.
+type TelegramReporter struct {
+	forwarder
+
+	cfg       TelegramConfig
+	client    httpclient.Client
+	limiter   *ratelimit.Limiter
+	debouncer *debouncer
+	logger    logger.LoggerInterface
+	tracer    trace.Tracer
+
+	queue     chan string
+	draining  chan struct{} // Closed by Stop: send what is queued, then exit
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// NewTelegramReporter creates a Telegram reporter wrapping next (which may be nil).
+func NewTelegramReporter(cfg TelegramConfig, next app.Reporter, log logger.LoggerInterface) (*TelegramReporter, error) {
+	if cfg.BotToken == "" || cfg.ChatID == "" {
+		return nil, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext("telegram bot token and chat id are required"))
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = TelegramAPIURL
+	}
+	if cfg.MessagesPerMinute <= 0 {
+		cfg.MessagesPerMinute = defaultTelegramMessagesPerMinute
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultTelegramQueueSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.DrainTimeout <= 0 {
+		cfg.DrainTimeout = defaultTelegramDrainTimeout
+	}
+
+	tracer := otel.Tracer(tracerName)
+
+	client, err := httpclient.NewInstrumentedClient(
+		httpclient.WithProviderName("telegram"),
+		httpclient.WithBaseURL(cfg.APIURL),
+		httpclient.WithRequestTimeout(cfg.Timeout),
+		httpclient.WithTraceOptions(tracer),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	return &TelegramReporter{
+		forwarder: forwarder{next: next},
+		cfg:       cfg,
+		client:    client,
+		limiter:   ratelimit.NewWithBurst(float64(cfg.MessagesPerMinute)/60.0, 1),
+		debouncer: newDebouncer(cfg.Debounce),
+		logger:    log,
+		tracer:    tracer,
+		queue:     make(chan string, cfg.QueueSize),
+		draining:  make(chan struct{}),
+	}, nil
+}
+
+// Start starts the wrapped reporter and the send worker.
+func (r *TelegramReporter) Start(ctx context.Context) error {
+	if err := r.forwarder.Start(ctx); err != nil {
+		return err
+	}
+	r.startWorker()
+	return nil
+}
+
+// startWorker launches the rate-limited send loop once.
+func (r *TelegramReporter) startWorker() {
+	r.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		r.cancel = cancel
+
+		r.wg.Add(1)
+		go r.sendLoop(ctx)
+	})
+}
+
+// Report forwards the opportunity and queues a message if it clears the threshold.
+func (r *TelegramReporter) Report(opp *domain.Opportunity) {
+	r.forwarder.Report(opp)
+
+	if !opp.IsProfitable() || opp.Profit.NetProfitRaw.LessThanOrEqual(r.cfg.MinProfitUSD) {
+		return
+	}
+	if !r.debouncer.Allow(opp.Route()) {
+		return
+	}
+
+	select {
+	case r.queue <- FormatTelegramMessage(opp):
+	default:
+		r.logger.Warn(context.Background(), "telegram queue full, dropping alert",
+			"pair", opp.Route(), "block", opp.BlockNumber)
+	}
+}
+
+// ReportSummary forwards the summary and, when enabled, queues it as a
+// message. Intervals without profitable opportunities are not sent.
+func (r *TelegramReporter) ReportSummary(summary *domain.ProfitSummary) {
+	r.forwarder.ReportSummary(summary)
+
+	if !r.cfg.SendSummaries || summary.Opportunities == 0 {
+		return
+	}
+
+	select {
+	case r.queue <- FormatTelegramSummary(summary):
+	default:
+		r.logger.Warn(context.Background(), "telegram queue full, dropping summary")
+	}
+}
+
+// ReportSafety forwards the event and queues it as a message: halts and
+// resumes are always sent.
+func (r *TelegramReporter) ReportSafety(event *domain.SafetyEvent) {
+	r.forwarder.ReportSafety(event)
+
+	select {
+	case r.queue <- FormatTelegramSafety(event):
+	default:
+		r.logger.Warn(context.Background(), "telegram queue full, dropping safety alert")
+	}
+}
+
+// sendLoop drains the queue, waiting on the rate limiter before each send.
+// Once Stop starts draining it sends what is left and exits.
+func (r *TelegramReporter) sendLoop(ctx context.Context) {
+	defer r.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case text := <-r.queue:
+			if !r.send(ctx, text) {
+				return
+			}
+		case <-r.draining:
+			for {
+				select {
+				case text := <-r.queue:
+					if !r.send(ctx, text) {
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// send delivers one queued message at the rate limit, reporting false once
+// ctx is cancelled.
+func (r *TelegramReporter) send(ctx context.Context, text string) bool {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return false
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+	if err := r.SendMessage(sendCtx, text); err != nil {
+		r.logger.Warn(sendCtx, "telegram alert failed", "error", err)
+	}
+	return true
+}
+
+// SendMessage posts a single HTML-formatted message to the configured chat.
+func (r *TelegramReporter) SendMessage(ctx context.Context, text string) error {
+	ctx, span := r.tracer.Start(ctx, "alerting.telegram.send",
+		trace.WithAttributes(attribute.String("chat_id", r.cfg.ChatID)),
+	)
+	defer span.End()
+
+	var result sendMessageResponse
+	resp, err := r.client.NewRequestWithOptions(
+		httpclient.WithLabels(httpclient.NewLabel("endpoint", "sendMessage")),
+	).
+		SetBody(sendMessageRequest{
+			ChatID:                r.cfg.ChatID,
+			Text:                  text,
+			ParseMode:             "HTML",
+			DisableWebPagePreview: true,
+		}).
+		SetResult(&result).
+		Post(ctx, "/bot"+r.cfg.BotToken+"/sendMessage")
+	if err != nil {
+		span.RecordError(err)
+		return apperror.New(apperror.CodeAlertDeliveryFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("telegram sendMessage request failed"))
+	}
+
+	if resp.IsError() || !result.OK {
+		return apperror.New(apperror.CodeAlertDeliveryFailed,
+			apperror.WithContext(fmt.Sprintf("telegram HTTP %d: %s", resp.StatusCode, result.Description)))
+	}
+
+	return nil
+}
+
+// Stop sends the queued messages, waiting up to DrainTimeout, then stops the
+// send worker and the wrapped reporter. Messages still queued at the
+// deadline are dropped and logged.
+func (r *TelegramReporter) Stop() error {
+	if r.cancel != nil {
+		r.stopOnce.Do(func() {
+			close(r.draining)
+			deadline := time.AfterFunc(r.cfg.DrainTimeout, r.cancel)
+			r.wg.Wait()
+			deadline.Stop()
+			r.cancel()
+
+			if dropped := len(r.queue); dropped > 0 {
+				r.logger.Warn(context.Background(), "telegram drain timed out, dropping queued messages",
+					"dropped", dropped, "timeout", r.cfg.DrainTimeout)
+			}
+		})
+	}
+	return r.forwarder.Stop()
+}
+
+// FormatTelegramMessage renders an opportunity as Telegram HTML,
+// including the execution plan and risk factors.
+func FormatTelegramMessage(opp *domain.Opportunity) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<b>💰 Arbitrage: %s</b>\n", html.EscapeString(opp.Route()))
+	fmt.Fprintf(&b, "Direction: %s\n", html.EscapeString(opp.Direction.String()))
+	fmt.Fprintf(&b, "Spread: %s bps\n", opp.Spread.BasisPoints.StringFixed(2))
+	if opp.Profit != nil {
+		fmt.Fprintf(&b, "Net profit: <b>$%s</b>\n", opp.Profit.NetProfitRaw.StringFixed(2))
+	}
+	fmt.Fprintf(&b, "Block: %d\n", opp.BlockNumber)
+
+	if len(opp.ExecutionSteps) > 0 {
+		b.WriteString("\n<b>Execution</b>\n")
+		for _, step := range opp.ExecutionSteps {
+			fmt.Fprintf(&b, "%d. %s\n", step.Number, html.EscapeString(step.Description))
+		}
+	}
+
+	if len(opp.RiskFactors) > 0 {
+		b.WriteString("\n<b>Risks</b>\n")
+		for _, risk := range opp.RiskFactors {
+			fmt.Fprintf(&b, "• [%s] %s: %s\n",
+				html.EscapeString(risk.Severity),
+				html.EscapeString(risk.Name),
+				html.EscapeString(risk.Description))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// FormatTelegramSummary renders a profit summary as Telegram HTML.
+func FormatTelegramSummary(summary *domain.ProfitSummary) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<b>📊 Profit summary (%s)</b>\n", summary.Window().Round(time.Second))
+	fmt.Fprintf(&b, "Profitable: %d in %d blocks\n", summary.Opportunities, summary.ProfitableBlocks)
+	fmt.Fprintf(&b, "Net profit: <b>$%s</b>\n", summary.NetProfitUSD.StringFixed(2))
+	fmt.Fprintf(&b, "Since start: $%s", summary.TotalProfitUSD.StringFixed(2))
+	if best := summary.Best; best != nil {
+		fmt.Fprintf(&b, "\nBest: %s $%s (block %d)",
+			html.EscapeString(best.Route()), summary.BestProfitUSD().StringFixed(2), best.BlockNumber)
+	}
+
+	return b.String()
+}
+
+// FormatTelegramSafety formats a safety halt or resume as an HTML message.
+func FormatTelegramSafety(event *domain.SafetyEvent) string {
+	if !event.Halted {
+		return fmt.Sprintf("<b>✅ Detection resumed</b>\nBlock: %d", event.BlockNumber)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<b>🛑 Detection halted</b>\nBlock: %d", event.BlockNumber)
+	for _, reason := range event.Reasons {
+		fmt.Fprintf(&b, "\n• %s", html.EscapeString(reason))
+	}
+	return b.String()
+}
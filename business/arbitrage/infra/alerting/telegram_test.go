@@ -0,0 +1,264 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	"github.com/shopspring/decimal"
+)
+
+// TestTelegramReporter_SendsFormattedMessage tests delivery through the Bot API.
+func TestTelegramReporter_SendsFormattedMessage(t *testing.T) {
+	received := make(chan sendMessageRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/botTOKEN/sendMessage" {
+			t.Errorf("expected path /botTOKEN/sendMessage, got %s", r.URL.Path)
+		}
+		var req sendMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok": true}`))
+		received <- req
+	}))
+	defer server.Close()
+
+	cfg := TelegramConfig{
+		BotToken:     "TOKEN",
+		ChatID:       "-100123",
+		APIURL:       server.URL,
+		MinProfitUSD: decimal.NewFromInt(50),
+	}
+	reporter, err := NewTelegramReporter(cfg, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+	if err := reporter.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start reporter: %v", err)
+	}
+	defer reporter.Stop()
+
+	reporter.Report(newOpportunity(ethUSDC, 100, "10", true)) // below threshold, skipped
+	reporter.Report(newOpportunity(ethUSDC, 101, "75", true))
+
+	select {
+	case req := <-received:
+		if req.ChatID != "-100123" || req.ParseMode != "HTML" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		if !strings.Contains(req.Text, "Block: 101") {
+			t.Errorf("expected message for block 101, got %q", req.Text)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for telegram message")
+	}
+}
+
+// TestTelegramReporter_ReportNeverBlocks tests that a full queue drops alerts.
+func TestTelegramReporter_ReportNeverBlocks(t *testing.T) {
+	cfg := TelegramConfig{
+		BotToken:     "TOKEN",
+		ChatID:       "1",
+		APIURL:       "http://127.0.0.1:0",
+		MinProfitUSD: decimal.Zero,
+		QueueSize:    1,
+	}
+	reporter, err := NewTelegramReporter(cfg, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+
+	// Worker not started: the second alert must be dropped rather than block
+	done := make(chan struct{})
+	go func() {
+		reporter.Report(newOpportunity(ethUSDC, 1, "10", true))
+		reporter.Report(newOpportunity(wbtcUSDC, 1, "10", true))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Report blocked on a full queue")
+	}
+	if len(reporter.queue) != 1 {
+		t.Errorf("expected 1 queued message, got %d", len(reporter.queue))
+	}
+}
+
+// TestTelegramReporter_StopDrainsQueue tests that Stop sends the queued
+// alerts before returning.
+func TestTelegramReporter_StopDrainsQueue(t *testing.T) {
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	cfg := TelegramConfig{BotToken: "TOKEN", ChatID: "1", APIURL: server.URL, MessagesPerMinute: 6000}
+	reporter, err := NewTelegramReporter(cfg, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+
+	// Queue before the worker starts so nothing is sent ahead of Stop
+	for block := range uint64(3) {
+		reporter.Report(newOpportunity(ethUSDC, block, "10", true))
+		reporter.Report(newOpportunity(wbtcUSDC, block, "10", true))
+	}
+	if err := reporter.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start reporter: %v", err)
+	}
+	if err := reporter.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	if got := sent.Load(); got != 6 {
+		t.Errorf("expected all 6 queued alerts sent on Stop, got %d", got)
+	}
+	if len(reporter.queue) != 0 {
+		t.Errorf("expected an empty queue after Stop, got %d", len(reporter.queue))
+	}
+}
+
+// TestTelegramReporter_StopDrainTimeout tests that Stop gives up on the
+// queue at DrainTimeout rather than wait out the rate limit.
+func TestTelegramReporter_StopDrainTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	cfg := TelegramConfig{BotToken: "TOKEN", ChatID: "1", APIURL: server.URL, MessagesPerMinute: 1, DrainTimeout: 50 * time.Millisecond}
+	reporter, err := NewTelegramReporter(cfg, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+	for block := range uint64(3) {
+		reporter.Report(newOpportunity(ethUSDC, block, "10", true))
+	}
+	if err := reporter.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start reporter: %v", err)
+	}
+
+	start := time.Now()
+	reporter.Stop()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Stop bounded by the drain timeout, took %s", elapsed)
+	}
+	if len(reporter.queue) == 0 {
+		t.Error("expected messages past the deadline left unsent")
+	}
+}
+
+// TestFormatTelegramMessage tests that steps and risks are rendered and escaped.
+func TestFormatTelegramMessage(t *testing.T) {
+	opp := newOpportunity(ethUSDC, 100, "75.5", true)
+	opp.ExecutionSteps = []domain.ExecutionStep{
+		{Number: 1, Description: "Buy 1 ETH on Uniswap V3"},
+		{Number: 2, Description: "Sell 1 ETH on Binance"},
+	}
+	opp.RiskFactors = []domain.RiskFactor{
+		{Name: "Slippage", Description: "size > 1% of <pool> depth", Severity: "medium"},
+	}
+
+	msg := FormatTelegramMessage(opp)
+
+	for _, want := range []string{
+		"<b>💰 Arbitrage: ETH-USDC</b>",
+		"Net profit: <b>$75.50</b>",
+		"1. Buy 1 ETH on Uniswap V3",
+		"2. Sell 1 ETH on Binance",
+		"• [medium] Slippage: size &gt; 1% of &lt;pool&gt; depth",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected message to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+// TestTelegramReporter_ReportSummary tests that summaries are queued only
+// when enabled and the interval had opportunities.
+func TestTelegramReporter_ReportSummary(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	summary := &domain.ProfitSummary{
+		Start:            start,
+		End:              start.Add(time.Minute),
+		Opportunities:    3,
+		ProfitableBlocks: 2,
+		NetProfitUSD:     decimal.RequireFromString("120.5"),
+		TotalProfitUSD:   decimal.RequireFromString("480"),
+		Best:             newOpportunity(ethUSDC, 101, "75", true),
+	}
+
+	for _, tt := range []struct {
+		name    string
+		enabled bool
+		summary *domain.ProfitSummary
+		want    int
+	}{
+		{name: "disabled", enabled: false, summary: summary, want: 0},
+		{name: "enabled", enabled: true, summary: summary, want: 1},
+		{name: "empty interval", enabled: true, summary: &domain.ProfitSummary{Start: start, End: start.Add(time.Minute)}, want: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := TelegramConfig{BotToken: "TOKEN", ChatID: "1", SendSummaries: tt.enabled}
+			reporter, err := NewTelegramReporter(cfg, nil, &mockLogger{})
+			if err != nil {
+				t.Fatalf("failed to create reporter: %v", err)
+			}
+			reporter.ReportSummary(tt.summary)
+			if len(reporter.queue) != tt.want {
+				t.Errorf("expected %d queued messages, got %d", tt.want, len(reporter.queue))
+			}
+		})
+	}
+
+	msg := FormatTelegramSummary(summary)
+	for _, want := range []string{
+		"<b>📊 Profit summary (1m0s)</b>",
+		"Profitable: 3 in 2 blocks",
+		"Net profit: <b>$120.50</b>",
+		"Since start: $480.00",
+		"Best: ETH-USDC $75.00 (block 101)",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected summary to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestTelegramReporter_ReportSafety(t *testing.T) {
+	reporter, err := NewTelegramReporter(TelegramConfig{BotToken: "TOKEN", ChatID: "1"}, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+
+	halt := &domain.SafetyEvent{BlockNumber: 100, Halted: true, Reasons: []string{"gas 250.0 gwei above the 100.0 gwei ceiling", "CEX & DEX both unavailable"}}
+	reporter.ReportSafety(halt)
+	reporter.ReportSafety(&domain.SafetyEvent{BlockNumber: 110})
+	if len(reporter.queue) != 2 {
+		t.Fatalf("expected halt and resume queued, got %d messages", len(reporter.queue))
+	}
+
+	msg := <-reporter.queue
+	for _, want := range []string{"<b>🛑 Detection halted</b>", "Block: 100", "• gas 250.0 gwei", "CEX &amp; DEX"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected halt message to contain %q, got:\n%s", want, msg)
+		}
+	}
+	if msg := <-reporter.queue; !strings.Contains(msg, "Detection resumed") {
+		t.Errorf("expected a resume message, got:\n%s", msg)
+	}
+}
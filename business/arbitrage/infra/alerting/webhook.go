@@ -0,0 +1,170 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/httpclient"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+const (
+	tracerName = "alerting"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// Ensure WebhookReporter implements Reporter.
+var _ app.Reporter = (*WebhookReporter)(nil)
+
+// WebhookConfig holds webhook alert settings.
+type WebhookConfig struct {
+	URL          string
+	MinProfitUSD decimal.Decimal // Only alert above this net profit
+	Debounce     time.Duration   // Minimum time between alerts for the same pair (0 = every block)
+	Timeout      time.Duration   // Request timeout
+}
+
+// WebhookPayload is the JSON body posted for each alert.
+type WebhookPayload struct {
+	Pair         string    `json:"pair"`
+	Direction    string    `json:"direction"`
+	SpreadBps    string    `json:"spread_bps"`
+	NetProfitUSD string    `json:"net_profit_usd"`
+	BlockNumber  uint64    `json:"block"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// NewWebhookPayload builds the alert payload for an opportunity.
+func NewWebhookPayload(opp *domain.Opportunity) WebhookPayload {
+	return WebhookPayload{
+		Pair:         opp.Route(),
+		Direction:    string(opp.Direction),
+		SpreadBps:    opp.Spread.BasisPoints.StringFixed(2),
+		NetProfitUSD: opp.Profit.NetProfitRaw.StringFixed(2),
+		BlockNumber:  opp.BlockNumber,
+		Timestamp:    opp.Timestamp,
+	}
+}
+
+// WebhookReporter implements Reporter by POSTing profitable opportunities to a URL.
+// It decorates another reporter: every call is forwarded, and Report additionally
+// sends an alert in the background so the detection loop is never blocked.
+type WebhookReporter struct {
+	forwarder
+
+	cfg       WebhookConfig
+	client    httpclient.Client
+	debouncer *debouncer
+	logger    logger.LoggerInterface
+	tracer    trace.Tracer
+
+	wg sync.WaitGroup
+}
+
+// NewWebhookReporter creates a webhook reporter wrapping next (which may be nil).
+func NewWebhookReporter(cfg WebhookConfig, next app.Reporter, log logger.LoggerInterface) (*WebhookReporter, error) {
+	if cfg.URL == "" {
+		return nil, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext("webhook url is required"))
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	tracer := otel.Tracer(tracerName)
+
+	client, err := httpclient.NewInstrumentedClient(
+		httpclient.WithProviderName("webhook"),
+		httpclient.WithRequestTimeout(cfg.Timeout),
+		httpclient.WithTraceOptions(tracer, httpclient.TraceRequest, httpclient.TraceResponse),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	return &WebhookReporter{
+		forwarder: forwarder{next: next},
+		cfg:       cfg,
+		client:    client,
+		debouncer: newDebouncer(cfg.Debounce),
+		logger:    log,
+		tracer:    tracer,
+	}, nil
+}
+
+// Report forwards the opportunity and alerts if it clears the threshold.
+func (r *WebhookReporter) Report(opp *domain.Opportunity) {
+	r.forwarder.Report(opp)
+
+	if !r.shouldAlert(opp) {
+		return
+	}
+
+	payload := NewWebhookPayload(opp)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+		defer cancel()
+
+		if err := r.Send(ctx, payload); err != nil {
+			r.logger.Warn(ctx, "webhook alert failed", "pair", payload.Pair, "error", err)
+		}
+	}()
+}
+
+// shouldAlert applies the profit threshold and per-pair debounce.
+func (r *WebhookReporter) shouldAlert(opp *domain.Opportunity) bool {
+	if !opp.IsProfitable() || opp.Profit.NetProfitRaw.LessThanOrEqual(r.cfg.MinProfitUSD) {
+		return false
+	}
+	return r.debouncer.Allow(opp.Route())
+}
+
+// Send POSTs a single alert payload to the webhook URL.
+func (r *WebhookReporter) Send(ctx context.Context, payload WebhookPayload) error {
+	ctx, span := r.tracer.Start(ctx, "alerting.webhook.send",
+		trace.WithAttributes(
+			attribute.String("pair", payload.Pair),
+			attribute.Int64("block", int64(payload.BlockNumber)),
+		),
+	)
+	defer span.End()
+
+	resp, err := r.client.NewRequestWithOptions(
+		httpclient.WithLabels(httpclient.NewLabel("endpoint", "webhook")),
+	).
+		SetBody(payload).
+		Post(ctx, r.cfg.URL)
+	if err != nil {
+		span.RecordError(err)
+		return apperror.New(apperror.CodeAlertDeliveryFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("webhook request failed"))
+	}
+
+	if resp.IsError() {
+		return apperror.New(apperror.CodeAlertDeliveryFailed,
+			apperror.WithContext(fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.String())))
+	}
+
+	return nil
+}
+
+// Stop waits for in-flight alerts and stops the wrapped reporter.
+func (r *WebhookReporter) Stop() error {
+	r.wg.Wait()
+	return r.forwarder.Stop()
+}
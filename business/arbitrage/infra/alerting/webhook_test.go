@@ -0,0 +1,136 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/shopspring/decimal"
+)
+
+// mockLogger implements logger.LoggerInterface for testing.
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Info(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (m *mockLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+var _ logger.LoggerInterface = (*mockLogger)(nil)
+
+var (
+	ethUSDC  = pricingDomain.Pair{Base: asset.ETH, Quote: asset.USDC}
+	wbtcUSDC = pricingDomain.Pair{Base: asset.WBTC, Quote: asset.USDC}
+)
+
+func newOpportunity(pair pricingDomain.Pair, block uint64, netProfit string, profitable bool) *domain.Opportunity {
+	return &domain.Opportunity{
+		BlockNumber: block,
+		Timestamp:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Pair:        pair,
+		Direction:   domain.DirectionDEXToCEX,
+		Spread:      pricingDomain.Spread{BasisPoints: decimal.NewFromInt(35)},
+		Profit: &domain.ProfitResult{
+			NetProfitRaw: decimal.RequireFromString(netProfit),
+			IsProfitable: profitable,
+		},
+	}
+}
+
+// TestWebhookReporter_Report tests threshold filtering, per-pair debounce and the payload.
+func TestWebhookReporter_Report(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []WebhookPayload
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		var payload WebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		mu.Lock()
+		received = append(received, payload)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	cfg := WebhookConfig{
+		URL:          server.URL,
+		MinProfitUSD: decimal.NewFromInt(50),
+		Debounce:     time.Hour,
+	}
+	reporter, err := NewWebhookReporter(cfg, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+
+	reporter.Report(newOpportunity(ethUSDC, 100, "75.5", true)) // alert
+	reporter.Report(newOpportunity(ethUSDC, 101, "80", true))   // debounced
+	reporter.Report(newOpportunity(wbtcUSDC, 101, "20", true))  // below threshold
+	reporter.Report(newOpportunity(wbtcUSDC, 102, "60", false)) // not profitable
+	reporter.Report(newOpportunity(wbtcUSDC, 103, "120", true)) // alert, different pair
+
+	if err := reporter.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 alerts, got %d: %+v", len(received), received)
+	}
+
+	byPair := make(map[string]WebhookPayload)
+	for _, p := range received {
+		byPair[p.Pair] = p
+	}
+
+	eth, ok := byPair["ETH-USDC"]
+	if !ok {
+		t.Fatal("expected ETH-USDC alert")
+	}
+	if eth.BlockNumber != 100 || eth.NetProfitUSD != "75.50" || eth.SpreadBps != "35.00" {
+		t.Errorf("unexpected ETH-USDC payload: %+v", eth)
+	}
+	if wbtc := byPair["WBTC-USDC"]; wbtc.BlockNumber != 103 {
+		t.Errorf("expected WBTC-USDC alert from block 103, got %+v", wbtc)
+	}
+}
+
+// TestDebouncer_Allow tests the per-key quiet window.
+func TestDebouncer_Allow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := newDebouncer(time.Minute)
+	d.now = func() time.Time { return now }
+
+	if !d.Allow("ETH-USDC") {
+		t.Error("expected first alert to be allowed")
+	}
+	if d.Allow("ETH-USDC") {
+		t.Error("expected repeat alert inside window to be suppressed")
+	}
+	if !d.Allow("WBTC-USDC") {
+		t.Error("expected other key to be allowed")
+	}
+
+	now = now.Add(time.Minute)
+	if !d.Allow("ETH-USDC") {
+		t.Error("expected alert after window to be allowed")
+	}
+}
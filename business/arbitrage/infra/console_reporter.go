@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
@@ -15,16 +16,23 @@ import (
 
 // ConsoleReporter implements Reporter for CLI output.
 type ConsoleReporter struct {
-	out io.Writer
+	out   io.Writer
+	state *MarketState
 }
 
-// NewConsoleReporter creates a new ConsoleReporter.
-func NewConsoleReporter() *ConsoleReporter {
+// NewConsoleReporter creates a new ConsoleReporter that also keeps state up to date.
+func NewConsoleReporter(state *MarketState) *ConsoleReporter {
 	return &ConsoleReporter{
-		out: os.Stdout,
+		out:   os.Stdout,
+		state: state,
 	}
 }
 
+// Snapshot returns a thread-safe copy of the reported state.
+func (r *ConsoleReporter) Snapshot() StateSnapshot {
+	return r.state.Snapshot()
+}
+
 // Start initializes the console reporter.
 func (r *ConsoleReporter) Start(ctx context.Context) error {
 	fmt.Fprintln(r.out, "Arbitrage Bot Started")
@@ -34,29 +42,53 @@ func (r *ConsoleReporter) Start(ctx context.Context) error {
 
 // Report outputs an arbitrage opportunity to the console.
 func (r *ConsoleReporter) Report(opp *domain.Opportunity) {
+	r.state.AddOpportunity(opp)
+
 	fmt.Fprintln(r.out, "")
 	fmt.Fprintln(r.out, "================================================================================")
 	fmt.Fprintln(r.out, "ARBITRAGE OPPORTUNITY DETECTED")
 	fmt.Fprintln(r.out, "================================================================================")
 	fmt.Fprintf(r.out, "Block:          #%d\n", opp.BlockNumber)
 	fmt.Fprintf(r.out, "Timestamp:      %s\n", opp.Timestamp.Format(time.RFC3339))
-	fmt.Fprintf(r.out, "Pair:           %s\n", opp.Pair.String())
+	fmt.Fprintf(r.out, "Pair:           %s\n", opp.Route())
 	fmt.Fprintf(r.out, "Direction:      %s\n", opp.Direction.String())
 	fmt.Fprintln(r.out, "--------------------------------------------------------------------------------")
-	fmt.Fprintln(r.out, "PRICES")
-	fmt.Fprintf(r.out, "  CEX (Binance):  $%s\n", opp.CEXPrice.StringFixed(2))
-	fmt.Fprintf(r.out, "  DEX (Uniswap):  $%s\n", opp.DEXPrice.StringFixed(2))
-	fmt.Fprintf(r.out, "  Spread:         %s bps\n", opp.Spread.BasisPoints.StringFixed(2))
-	if opp.DEXQuote != nil {
-		fmt.Fprintf(r.out, "  Pool Fee Tier:  %s\n", opp.DEXQuote.FeeTierPercent())
+	if opp.IsTriangular() {
+		fmt.Fprintln(r.out, "LEGS")
+		for i, leg := range opp.Legs {
+			fmt.Fprintf(r.out, "  %d. %-4s %-10s @ %s (%s)\n", i+1, strings.ToUpper(string(leg.Side)), leg.Pair.String(),
+				leg.Price.Round(6).String(), pricingDomain.VenueDisplayName(leg.Venue))
+		}
+		fmt.Fprintf(r.out, "  Cycle Return:   %s bps\n", opp.Spread.BasisPoints.StringFixed(2))
+	} else {
+		fmt.Fprintln(r.out, "PRICES")
+		fmt.Fprintf(r.out, "  CEX (%s):  $%s\n", pricingDomain.VenueDisplayName(opp.CEXVenue), opp.CEXPrice.StringFixed(2))
+		fmt.Fprintf(r.out, "  DEX (Uniswap):  $%s\n", opp.DEXPrice.StringFixed(2))
+		fmt.Fprintf(r.out, "  Spread:         %s bps\n", opp.Spread.BasisPoints.StringFixed(2))
+		if opp.DEXQuote != nil {
+			fmt.Fprintf(r.out, "  Pool Fee Tier:  %s\n", opp.DEXQuote.FeeTierPercent())
+		}
 	}
 	fmt.Fprintln(r.out, "--------------------------------------------------------------------------------")
 	fmt.Fprintln(r.out, "TRADE DETAILS")
-	fmt.Fprintf(r.out, "  Size:           %s ETH\n", opp.TradeSize.StringFixed(4))
+	fmt.Fprintf(r.out, "  Size:           %s %s\n", opp.TradeSize.StringFixed(4), opp.Pair.Base.Symbol())
+	if opp.TradeSizeUSD.IsPositive() {
+		fmt.Fprintf(r.out, "  Notional:       $%s\n", opp.TradeSizeUSD.StringFixed(2))
+	}
 	if opp.GasCost != nil {
 		fmt.Fprintf(r.out, "  Gas Cost:       %s ETH ($%s)\n", opp.GasCost.TotalETH.ToDecimal().StringFixed(6), opp.GasCost.TotalUSD.ToDecimal().StringFixed(2))
 	}
 	fmt.Fprintf(r.out, "  Required Capital: $%s\n", opp.RequiredCapital.StringFixed(2))
+	if opp.AvailableCapital.IsPositive() {
+		flag := ""
+		if opp.ExceedsCapital() {
+			flag = " (EXCEEDS LIMIT)"
+		}
+		fmt.Fprintf(r.out, "  Available:      $%s%s\n", opp.AvailableCapital.StringFixed(2), flag)
+	}
+	if opp.OptimalSize.IsPositive() {
+		fmt.Fprintf(r.out, "  Optimal Size:   %s %s\n", opp.OptimalSize.String(), opp.Pair.Base.Symbol())
+	}
 	fmt.Fprintln(r.out, "--------------------------------------------------------------------------------")
 	fmt.Fprintln(r.out, "PROFIT")
 	if opp.Profit != nil {
@@ -79,10 +111,13 @@ func (r *ConsoleReporter) Report(opp *domain.Opportunity) {
 // UpdatePrices outputs current prices (no-op for console in detection mode).
 func (r *ConsoleReporter) UpdatePrices(prices *pricingDomain.PriceSnapshot) {
 	// Console reporter only outputs opportunities, not continuous price updates
+	r.state.SetPrices(prices)
 }
 
 // UpdateConnectionStatus outputs connection status changes.
 func (r *ConsoleReporter) UpdateConnectionStatus(name string, connected bool, latency time.Duration) {
+	r.state.SetConnection(name, connected, latency)
+
 	status := "disconnected"
 	if connected {
 		status = fmt.Sprintf("connected (%s)", latency)
@@ -93,11 +128,13 @@ func (r *ConsoleReporter) UpdateConnectionStatus(name string, connected bool, la
 // UpdateBlock outputs block number (no-op for console - too noisy).
 func (r *ConsoleReporter) UpdateBlock(blockNumber uint64) {
 	// Console reporter doesn't output every block
+	r.state.SetBlock(blockNumber)
 }
 
 // UpdateGasPrice outputs gas price (no-op for console - too noisy).
 func (r *ConsoleReporter) UpdateGasPrice(gweiPrice float64) {
 	// Console reporter doesn't output continuous gas updates
+	r.state.SetGasPrice(gweiPrice)
 }
 
 // UpdateCostBreakdown outputs cost breakdown (no-op for console - too noisy).
@@ -105,6 +142,11 @@ func (r *ConsoleReporter) UpdateCostBreakdown(breakdown *app.CostBreakdown) {
 	// Console reporter doesn't output continuous cost analysis
 }
 
+// ReportSafety outputs detection halting or resuming.
+func (r *ConsoleReporter) ReportSafety(event *domain.SafetyEvent) {
+	fmt.Fprintf(r.out, "[%s] SAFETY: %s\n", event.Timestamp.Format("15:04:05"), event)
+}
+
 // Stop gracefully shuts down the console reporter.
 func (r *ConsoleReporter) Stop() error {
 	fmt.Fprintln(r.out, "")
@@ -0,0 +1,179 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/health"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// Ensure MultiReporter implements Reporter and forwards every optional
+// reporter interface.
+var (
+	_ app.Reporter                = (*MultiReporter)(nil)
+	_ app.SummaryReporter         = (*MultiReporter)(nil)
+	_ app.SafetyReporter          = (*MultiReporter)(nil)
+	_ app.GasPercentileReporter   = (*MultiReporter)(nil)
+	_ app.ConnectionStatsReporter = (*MultiReporter)(nil)
+)
+
+// MultiReporter fans every Reporter call out to a list of reporters, so the
+// display, storage and alert outputs run side by side. Each child is
+// isolated: a panic is recovered and logged, and a child that fails to start
+// is left out instead of stopping the others.
+type MultiReporter struct {
+	logger logger.LoggerInterface
+
+	mu       sync.RWMutex
+	children []app.Reporter // Started children (all of them before Start)
+}
+
+// NewMultiReporter creates a reporter fanning out to children, in order.
+// Nil children are skipped.
+func NewMultiReporter(log logger.LoggerInterface, children ...app.Reporter) *MultiReporter {
+	m := &MultiReporter{logger: log}
+	for _, child := range children {
+		if child != nil {
+			m.children = append(m.children, child)
+		}
+	}
+	return m
+}
+
+// Start starts every child. Children that fail are logged and dropped; Start
+// fails only when none of them started.
+func (m *MultiReporter) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	started := make([]app.Reporter, 0, len(m.children))
+	var errs []error
+	for _, child := range m.children {
+		var err error
+		m.safely(child, "Start", func() { err = child.Start(ctx) })
+		if err != nil {
+			m.logger.Error(ctx, "reporter failed to start, continuing without it",
+				"reporter", reporterName(child), "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", reporterName(child), err))
+			continue
+		}
+		started = append(started, child)
+	}
+	m.children = started
+
+	if len(started) == 0 && len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// Report forwards to every child.
+func (m *MultiReporter) Report(opp *domain.Opportunity) {
+	m.each("Report", func(r app.Reporter) { r.Report(opp) })
+}
+
+// UpdatePrices forwards to every child.
+func (m *MultiReporter) UpdatePrices(prices *pricingDomain.PriceSnapshot) {
+	m.each("UpdatePrices", func(r app.Reporter) { r.UpdatePrices(prices) })
+}
+
+// UpdateConnectionStatus forwards to every child.
+func (m *MultiReporter) UpdateConnectionStatus(name string, connected bool, latency time.Duration) {
+	m.each("UpdateConnectionStatus", func(r app.Reporter) { r.UpdateConnectionStatus(name, connected, latency) })
+}
+
+// UpdateBlock forwards to every child.
+func (m *MultiReporter) UpdateBlock(blockNumber uint64) {
+	m.each("UpdateBlock", func(r app.Reporter) { r.UpdateBlock(blockNumber) })
+}
+
+// UpdateGasPrice forwards to every child.
+func (m *MultiReporter) UpdateGasPrice(gweiPrice float64) {
+	m.each("UpdateGasPrice", func(r app.Reporter) { r.UpdateGasPrice(gweiPrice) })
+}
+
+// UpdateCostBreakdown forwards to every child.
+func (m *MultiReporter) UpdateCostBreakdown(breakdown *app.CostBreakdown) {
+	m.each("UpdateCostBreakdown", func(r app.Reporter) { r.UpdateCostBreakdown(breakdown) })
+}
+
+// ReportSummary forwards to the children that handle summaries.
+func (m *MultiReporter) ReportSummary(summary *domain.ProfitSummary) {
+	m.each("ReportSummary", func(r app.Reporter) {
+		if sr, ok := r.(app.SummaryReporter); ok {
+			sr.ReportSummary(summary)
+		}
+	})
+}
+
+// ReportSafety forwards to the children that handle safety events.
+func (m *MultiReporter) ReportSafety(event *domain.SafetyEvent) {
+	m.each("ReportSafety", func(r app.Reporter) {
+		if sr, ok := r.(app.SafetyReporter); ok {
+			sr.ReportSafety(event)
+		}
+	})
+}
+
+// UpdateGasPercentile forwards to the children that show gas ranks.
+func (m *MultiReporter) UpdateGasPercentile(gweiPrice, percentileRank float64) {
+	m.each("UpdateGasPercentile", func(r app.Reporter) {
+		if gr, ok := r.(app.GasPercentileReporter); ok {
+			gr.UpdateGasPercentile(gweiPrice, percentileRank)
+		}
+	})
+}
+
+// UpdateConnectionStats forwards to the children that show connection stats.
+func (m *MultiReporter) UpdateConnectionStats(name string, stats health.ConnectionStats) {
+	m.each("UpdateConnectionStats", func(r app.Reporter) {
+		if cr, ok := r.(app.ConnectionStatsReporter); ok {
+			cr.UpdateConnectionStats(name, stats)
+		}
+	})
+}
+
+// Stop stops every started child, returning their errors joined.
+func (m *MultiReporter) Stop() error {
+	var errs []error
+	m.each("Stop", func(r app.Reporter) {
+		if err := r.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", reporterName(r), err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// each calls fn for every child in order, recovering each child's panic.
+func (m *MultiReporter) each(method string, fn func(app.Reporter)) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, child := range m.children {
+		m.safely(child, method, func() { fn(child) })
+	}
+}
+
+// safely runs call, logging instead of propagating a panic so the other
+// children still get the call.
+func (m *MultiReporter) safely(child app.Reporter, method string, call func()) {
+	defer func() {
+		if p := recover(); p != nil {
+			m.logger.Error(context.Background(), "reporter panicked",
+				"reporter", reporterName(child), "method", method, "panic", p)
+		}
+	}()
+	call()
+}
+
+// reporterName identifies a child in logs and errors by its type.
+func reporterName(r app.Reporter) string {
+	return fmt.Sprintf("%T", r)
+}
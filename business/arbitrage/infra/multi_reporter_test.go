@@ -0,0 +1,107 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	"github.com/fd1az/arbitrage-bot/internal/health"
+)
+
+// fanoutChild records the calls it receives; it can fail to start or panic
+// on Report.
+type fanoutChild struct {
+	*ConsoleReporter
+	startErr  error
+	panics    bool
+	reported  int
+	summaries int
+	stats     int
+	stopped   bool
+	stopErr   error
+}
+
+func newFanoutChild() *fanoutChild {
+	return &fanoutChild{ConsoleReporter: NewConsoleReporter(NewMarketState(0))}
+}
+
+func (c *fanoutChild) Start(ctx context.Context) error {
+	return c.startErr
+}
+
+func (c *fanoutChild) Report(opp *domain.Opportunity) {
+	if c.panics {
+		panic("report failed")
+	}
+	c.reported++
+}
+
+func (c *fanoutChild) ReportSummary(summary *domain.ProfitSummary) { c.summaries++ }
+
+func (c *fanoutChild) UpdateConnectionStats(name string, stats health.ConnectionStats) { c.stats++ }
+
+func (c *fanoutChild) Stop() error {
+	c.stopped = true
+	return c.stopErr
+}
+
+func TestMultiReporter_FansOut(t *testing.T) {
+	first, second := newFanoutChild(), newFanoutChild()
+	m := NewMultiReporter(nopLogger{}, first, nil, second)
+
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	m.Report(&domain.Opportunity{})
+	m.ReportSummary(&domain.ProfitSummary{})
+	m.UpdateConnectionStats("Binance", health.ConnectionStats{})
+	if err := m.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	for i, child := range []*fanoutChild{first, second} {
+		if child.reported != 1 || child.summaries != 1 || child.stats != 1 || !child.stopped {
+			t.Errorf("child %d: expected every call once, got %+v", i, child)
+		}
+	}
+}
+
+// TestMultiReporter_IsolatesChildren tests that a panicking or failing child
+// doesn't keep the others from receiving calls.
+func TestMultiReporter_IsolatesChildren(t *testing.T) {
+	panicking, failing, healthy := newFanoutChild(), newFanoutChild(), newFanoutChild()
+	panicking.panics = true
+	failing.startErr = errors.New("disk full")
+	panicking.stopErr = errors.New("flush failed")
+	m := NewMultiReporter(nopLogger{}, panicking, failing, healthy)
+
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("expected Start to succeed with healthy children, got %v", err)
+	}
+	m.Report(&domain.Opportunity{})
+
+	if healthy.reported != 1 {
+		t.Errorf("expected the healthy child to get the report past the panic, got %d", healthy.reported)
+	}
+	if failing.reported != 0 {
+		t.Errorf("expected the child that failed to start left out, got %d reports", failing.reported)
+	}
+
+	err := m.Stop()
+	if err == nil || !healthy.stopped {
+		t.Errorf("expected every started child stopped and the flush error returned, got %v", err)
+	}
+	if failing.stopped {
+		t.Error("expected the child that failed to start not stopped")
+	}
+}
+
+func TestMultiReporter_StartFailsWhenNoChildStarts(t *testing.T) {
+	child := newFanoutChild()
+	child.startErr = errors.New("no terminal")
+
+	if err := NewMultiReporter(nopLogger{}, child).Start(context.Background()); err == nil {
+		t.Fatal("expected Start to fail when no child started")
+	}
+}
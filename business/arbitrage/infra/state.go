@@ -0,0 +1,242 @@
+package infra
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apiserver"
+)
+
+// DefaultRecentOpportunities is how many opportunities MarketState keeps.
+const DefaultRecentOpportunities = apiserver.MaxOpportunityLimit
+
+// Ensure MarketState can back the REST API.
+var _ apiserver.StateSource = (*MarketState)(nil)
+
+// ConnectionState is the last reported status of a data source.
+type ConnectionState struct {
+	Connected bool
+	Latency   time.Duration
+	UpdatedAt time.Time
+}
+
+// StateSnapshot is a point-in-time copy of the reporter state.
+type StateSnapshot struct {
+	Prices        map[string]*pricingDomain.PriceSnapshot // Latest snapshot per pair
+	Opportunities []*domain.Opportunity                   // Newest first
+	Connections   map[string]ConnectionState
+	BlockNumber   uint64
+	GasPriceGwei  float64
+}
+
+// MarketState is the in-memory view the reporters maintain: latest prices per
+// pair, recent opportunities and connection states. It is safe for concurrent use.
+type MarketState struct {
+	mu            sync.RWMutex
+	prices        map[string]*pricingDomain.PriceSnapshot
+	opportunities []*domain.Opportunity // Ring buffer, oldest at next once full
+	next          int
+	capacity      int
+	connections   map[string]ConnectionState
+	blockNumber   uint64
+	gasPriceGwei  float64
+}
+
+// NewMarketState creates a MarketState keeping up to capacity recent opportunities.
+func NewMarketState(capacity int) *MarketState {
+	if capacity <= 0 {
+		capacity = DefaultRecentOpportunities
+	}
+	return &MarketState{
+		prices:        make(map[string]*pricingDomain.PriceSnapshot),
+		opportunities: make([]*domain.Opportunity, 0, capacity),
+		capacity:      capacity,
+		connections:   make(map[string]ConnectionState),
+	}
+}
+
+// AddOpportunity records opp, evicting the oldest once full.
+func (s *MarketState) AddOpportunity(opp *domain.Opportunity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.opportunities) < s.capacity {
+		s.opportunities = append(s.opportunities, opp)
+		return
+	}
+	s.opportunities[s.next] = opp
+	s.next = (s.next + 1) % s.capacity
+}
+
+// SetPrices stores the latest snapshot for its pair.
+func (s *MarketState) SetPrices(prices *pricingDomain.PriceSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prices[prices.Pair.String()] = prices
+}
+
+// SetConnection records the status of a data source.
+func (s *MarketState) SetConnection(name string, connected bool, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connections[name] = ConnectionState{Connected: connected, Latency: latency, UpdatedAt: time.Now()}
+}
+
+// SetBlock records the latest block number.
+func (s *MarketState) SetBlock(blockNumber uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blockNumber = blockNumber
+}
+
+// SetGasPrice records the latest gas price in gwei.
+func (s *MarketState) SetGasPrice(gweiPrice float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gasPriceGwei = gweiPrice
+}
+
+// Snapshot returns a copy of the current state.
+func (s *MarketState) Snapshot() StateSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := StateSnapshot{
+		Prices:        make(map[string]*pricingDomain.PriceSnapshot, len(s.prices)),
+		Opportunities: s.recentLocked(len(s.opportunities)),
+		Connections:   make(map[string]ConnectionState, len(s.connections)),
+		BlockNumber:   s.blockNumber,
+		GasPriceGwei:  s.gasPriceGwei,
+	}
+	for pair, prices := range s.prices {
+		snap.Prices[pair] = prices
+	}
+	for name, conn := range s.connections {
+		snap.Connections[name] = conn
+	}
+	return snap
+}
+
+// recentLocked returns up to limit opportunities, newest first. Callers hold mu.
+func (s *MarketState) recentLocked(limit int) []*domain.Opportunity {
+	n := min(limit, len(s.opportunities))
+	recent := make([]*domain.Opportunity, 0, n)
+	newest := len(s.opportunities) - 1
+	if len(s.opportunities) == s.capacity {
+		newest = (s.next - 1 + s.capacity) % s.capacity
+	}
+	for i := range n {
+		recent = append(recent, s.opportunities[(newest-i+len(s.opportunities))%len(s.opportunities)])
+	}
+	return recent
+}
+
+// Prices implements apiserver.StateSource, sorted by pair.
+func (s *MarketState) Prices() []apiserver.Price {
+	snap := s.Snapshot()
+	prices := make([]apiserver.Price, 0, len(snap.Prices))
+	for _, p := range snap.Prices {
+		prices = append(prices, toAPIPrice(p))
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Pair < prices[j].Pair })
+	return prices
+}
+
+// Opportunities implements apiserver.StateSource.
+func (s *MarketState) Opportunities(limit int) []apiserver.Opportunity {
+	s.mu.RLock()
+	recent := s.recentLocked(limit)
+	s.mu.RUnlock()
+
+	opps := make([]apiserver.Opportunity, 0, len(recent))
+	for _, opp := range recent {
+		opps = append(opps, toAPIOpportunity(opp))
+	}
+	return opps
+}
+
+// Status implements apiserver.StateSource, with connections sorted by name.
+func (s *MarketState) Status() apiserver.Status {
+	snap := s.Snapshot()
+	status := apiserver.Status{
+		BlockNumber:  snap.BlockNumber,
+		GasPriceGwei: snap.GasPriceGwei,
+		Connections:  make([]apiserver.Connection, 0, len(snap.Connections)),
+		Timestamp:    time.Now().UTC(),
+	}
+	for name, conn := range snap.Connections {
+		status.Connections = append(status.Connections, apiserver.Connection{
+			Name:      name,
+			Connected: conn.Connected,
+			LatencyMs: float64(conn.Latency.Microseconds()) / 1000,
+			UpdatedAt: conn.UpdatedAt.UTC(),
+		})
+	}
+	sort.Slice(status.Connections, func(i, j int) bool { return status.Connections[i].Name < status.Connections[j].Name })
+	return status
+}
+
+// toAPIPrice converts a price snapshot to its API representation.
+func toAPIPrice(p *pricingDomain.PriceSnapshot) apiserver.Price {
+	price := apiserver.Price{
+		Pair:         p.Pair.String(),
+		CEXVenue:     p.CEXVenue,
+		GasPriceGwei: p.GasPrice.ToDecimal().Shift(9).InexactFloat64(),
+		BlockNumber:  p.BlockNumber,
+		Timestamp:    p.Timestamp.UTC(),
+	}
+	if p.CEXBid != nil {
+		price.CEXBid = p.CEXBid.Rate.Rate().String()
+	}
+	if p.CEXAsk != nil {
+		price.CEXAsk = p.CEXAsk.Rate.Rate().String()
+	}
+	if p.DEXQuote != nil {
+		price.DEXPrice = p.DEXQuote.Price.Rate().String()
+		price.DEXFeeTier = p.DEXQuote.FeeTier
+		price.DEXProtocol = p.DEXQuote.Protocol
+	}
+	return price
+}
+
+// toAPIOpportunity converts an opportunity to its API representation.
+func toAPIOpportunity(opp *domain.Opportunity) apiserver.Opportunity {
+	gross, gas, fees, netProfit := decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero
+	if opp.Profit != nil {
+		gross = opp.Profit.Exact.GrossProfit.Abs()
+		gas = opp.Profit.Exact.GasCost
+		fees = opp.Profit.Exact.ExchangeFees
+		netProfit = opp.Profit.NetProfitRaw
+	}
+	return apiserver.Opportunity{
+		ID:             opp.ID,
+		Pair:           opp.Route(),
+		Direction:      string(opp.Direction),
+		TradeSize:      opp.TradeSize.String(),
+		TradeSizeUSD:   tradeSizeUSD(opp),
+		CEXVenue:       opp.CEXVenue,
+		CEXPrice:       opp.CEXPrice.StringFixed(2),
+		DEXPrice:       opp.DEXPrice.StringFixed(2),
+		SpreadBps:      opp.Spread.BasisPoints.StringFixed(2),
+		GrossProfitUSD: gross.StringFixed(2),
+		GasCostUSD:     gas.StringFixed(2),
+		FeesUSD:        fees.StringFixed(2),
+		NetProfitUSD:   netProfit.StringFixed(2),
+		Profitable:     opp.IsProfitable(),
+		BlockNumber:    opp.BlockNumber,
+		Timestamp:      opp.Timestamp.UTC(),
+	}
+}
+
+// tradeSizeUSD returns the opportunity's configured USD notional, or empty
+// when it was sized in base units.
+func tradeSizeUSD(opp *domain.Opportunity) string {
+	if !opp.TradeSizeUSD.IsPositive() {
+		return ""
+	}
+	return opp.TradeSizeUSD.String()
+}
@@ -0,0 +1,58 @@
+package infra
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+func TestMarketState_Opportunities(t *testing.T) {
+	pair := pricingDomain.NewPair(asset.ETH, asset.USDC)
+	state := NewMarketState(3)
+	for i := range 5 {
+		state.AddOpportunity(&domain.Opportunity{ID: fmt.Sprint(i), BlockNumber: uint64(i), Pair: pair})
+	}
+
+	tests := []struct {
+		limit   int
+		wantIDs []string
+	}{
+		{limit: 1, wantIDs: []string{"4"}},
+		{limit: 3, wantIDs: []string{"4", "3", "2"}},
+		{limit: 10, wantIDs: []string{"4", "3", "2"}}, // Oldest evicted beyond capacity
+	}
+	for _, tt := range tests {
+		got := state.Opportunities(tt.limit)
+		if len(got) != len(tt.wantIDs) {
+			t.Fatalf("Opportunities(%d): expected %d, got %d", tt.limit, len(tt.wantIDs), len(got))
+		}
+		for i, id := range tt.wantIDs {
+			if got[i].ID != id {
+				t.Errorf("Opportunities(%d)[%d]: expected %s, got %s", tt.limit, i, id, got[i].ID)
+			}
+		}
+	}
+}
+
+func TestMarketState_Status(t *testing.T) {
+	state := NewMarketState(0)
+	state.SetBlock(100)
+	state.SetGasPrice(12.5)
+	state.SetConnection("Uniswap", true, 40*time.Millisecond)
+	state.SetConnection("Binance", false, 0)
+
+	status := state.Status()
+	if status.BlockNumber != 100 || status.GasPriceGwei != 12.5 {
+		t.Errorf("expected block 100 at 12.5 gwei, got %d at %v", status.BlockNumber, status.GasPriceGwei)
+	}
+	if len(status.Connections) != 2 || status.Connections[0].Name != "Binance" {
+		t.Fatalf("expected connections sorted by name, got %+v", status.Connections)
+	}
+	if uni := status.Connections[1]; !uni.Connected || uni.LatencyMs != 40 {
+		t.Errorf("expected Uniswap connected at 40ms, got %+v", uni)
+	}
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// CSVColumns is the header written by WriteCSV. The order is part of the
+// export format; append new columns at the end.
+var CSVColumns = []string{
+	"block", "timestamp", "pair", "direction", "spread_bps",
+	"gross", "gas", "fees", "net", "profitable",
+}
+
+// WriteCSV writes records as CSV with a CSVColumns header. Timestamps are
+// RFC 3339 in UTC with fractional seconds when non-zero (time.RFC3339Nano),
+// and USD amounts are fixed to two decimals.
+func WriteCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVColumns); err != nil {
+		return err
+	}
+	for _, rec := range records {
+		row := []string{
+			strconv.FormatUint(rec.BlockNumber, 10),
+			rec.Timestamp.UTC().Format(time.RFC3339Nano),
+			rec.Pair,
+			rec.Direction,
+			rec.SpreadBps.StringFixed(2),
+			rec.GrossProfitUSD.StringFixed(2),
+			rec.GasCostUSD.StringFixed(2),
+			rec.FeesUSD.StringFixed(2),
+			rec.NetProfitUSD.StringFixed(2),
+			strconv.FormatBool(rec.Profitable),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
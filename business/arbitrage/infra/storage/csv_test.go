@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TestWriteCSV tests the header and row layout of the CSV export.
+func TestWriteCSV(t *testing.T) {
+	records := []Record{
+		{
+			BlockNumber:    100,
+			Pair:           "ETH-USDC",
+			Direction:      "CEX_TO_DEX",
+			SpreadBps:      decimal.RequireFromString("42.5"),
+			GrossProfitUSD: decimal.RequireFromString("120"),
+			GasCostUSD:     decimal.RequireFromString("8.123"),
+			FeesUSD:        decimal.RequireFromString("30"),
+			NetProfitUSD:   decimal.RequireFromString("81.877"),
+			Profitable:     true,
+			Timestamp:      time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			BlockNumber:  101,
+			Pair:         "ETH-USDC",
+			Direction:    "DEX_TO_CEX",
+			NetProfitUSD: decimal.RequireFromString("-3.5"),
+			Timestamp:    time.Date(2025, 1, 1, 12, 0, 12, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, records); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	want := "block,timestamp,pair,direction,spread_bps,gross,gas,fees,net,profitable\n" +
+		"100,2025-01-01T12:00:00Z,ETH-USDC,CEX_TO_DEX,42.50,120.00,8.12,30.00,81.88,true\n" +
+		"101,2025-01-01T12:00:12Z,ETH-USDC,DEX_TO_CEX,0.00,0.00,0.00,0.00,-3.50,false\n"
+	if buf.String() != want {
+		t.Errorf("unexpected csv:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+// TestWriteCSV_FractionalSeconds tests that sub-second timestamps keep their
+// fraction.
+func TestWriteCSV_FractionalSeconds(t *testing.T) {
+	var buf bytes.Buffer
+	records := []Record{{BlockNumber: 100, Timestamp: time.Date(2025, 1, 1, 12, 0, 0, 500_000_000, time.UTC)}}
+	if err := WriteCSV(&buf, records); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "100,2025-01-01T12:00:00.5Z,") {
+		t.Errorf("expected an RFC 3339 timestamp with fractional seconds, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,429 @@
+// Package storage persists detected arbitrage opportunities for later analysis.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	_ "modernc.org/sqlite" // Pure Go SQLite driver
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/health"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// Ensure SQLiteReporter implements Reporter.
+var _ app.Reporter = (*SQLiteReporter)(nil)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS opportunities (
+	id             INTEGER PRIMARY KEY AUTOINCREMENT,
+	block_number   INTEGER NOT NULL,
+	pair           TEXT    NOT NULL,
+	direction      TEXT    NOT NULL,
+	spread_bps     TEXT    NOT NULL,
+	net_profit_usd TEXT    NOT NULL,
+	detected_at    INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_opportunities_detected_at ON opportunities(detected_at);`
+
+// migrations add columns introduced after the initial schema; rows written
+// before them read back as zero.
+var migrations = []struct {
+	column string
+	ddl    string
+}{
+	{"gross_profit_usd", `ALTER TABLE opportunities ADD COLUMN gross_profit_usd TEXT NOT NULL DEFAULT '0'`},
+	{"gas_cost_usd", `ALTER TABLE opportunities ADD COLUMN gas_cost_usd TEXT NOT NULL DEFAULT '0'`},
+	{"fees_usd", `ALTER TABLE opportunities ADD COLUMN fees_usd TEXT NOT NULL DEFAULT '0'`},
+	{"profitable", `ALTER TABLE opportunities ADD COLUMN profitable INTEGER NOT NULL DEFAULT 0`},
+}
+
+// Config holds SQLite reporter settings.
+type Config struct {
+	Path          string        // Database file path
+	BatchSize     int           // Pending records that trigger a flush
+	FlushInterval time.Duration // Maximum time a record waits before being written
+}
+
+// DefaultConfig returns sensible defaults for the given database path.
+func DefaultConfig(path string) Config {
+	return Config{
+		Path:          path,
+		BatchSize:     50,
+		FlushInterval: 5 * time.Second,
+	}
+}
+
+// Record is a persisted opportunity row.
+type Record struct {
+	BlockNumber    uint64
+	Pair           string
+	Direction      string
+	SpreadBps      decimal.Decimal
+	GrossProfitUSD decimal.Decimal
+	GasCostUSD     decimal.Decimal
+	FeesUSD        decimal.Decimal
+	NetProfitUSD   decimal.Decimal
+	Profitable     bool
+	Timestamp      time.Time
+}
+
+// NewRecord flattens an opportunity into a Record.
+func NewRecord(opp *domain.Opportunity) Record {
+	rec := Record{
+		BlockNumber: opp.BlockNumber,
+		Pair:        opp.Route(),
+		Direction:   string(opp.Direction),
+		SpreadBps:   opp.Spread.BasisPoints,
+		Profitable:  opp.IsProfitable(),
+		Timestamp:   opp.Timestamp,
+	}
+	if opp.Profit != nil {
+		rec.GrossProfitUSD = opp.Profit.Exact.GrossProfit.Abs()
+		rec.GasCostUSD = opp.Profit.Exact.GasCost
+		rec.FeesUSD = opp.Profit.Exact.ExchangeFees
+		rec.NetProfitUSD = opp.Profit.NetProfitRaw
+	}
+	return rec
+}
+
+// SQLiteReporter implements Reporter by writing opportunities to SQLite.
+// It decorates another reporter (e.g. the TUI) so display keeps working:
+// every call is forwarded to the wrapped reporter, and Report additionally
+// queues the opportunity for a batched insert.
+type SQLiteReporter struct {
+	db     *sql.DB
+	next   app.Reporter
+	cfg    Config
+	logger logger.LoggerInterface
+
+	pending []Record
+	mu      sync.Mutex
+
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewSQLiteReporter opens (or creates) the database and wraps next.
+// next may be nil when persistence is the only output.
+func NewSQLiteReporter(cfg Config, next app.Reporter, log logger.LoggerInterface) (*SQLiteReporter, error) {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultConfig(cfg.Path).BatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultConfig(cfg.Path).FlushInterval
+	}
+
+	db, err := sql.Open("sqlite", cfg.Path)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeStorageOpenFailed,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("failed to open sqlite database %s", cfg.Path)))
+	}
+	// SQLite allows a single writer; serialize access through one connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, apperror.New(apperror.CodeStorageOpenFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to create opportunities schema"))
+	}
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, apperror.New(apperror.CodeStorageOpenFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to migrate opportunities schema"))
+	}
+
+	return &SQLiteReporter{
+		db:      db,
+		next:    next,
+		cfg:     cfg,
+		logger:  log,
+		flushCh: make(chan struct{}, 1),
+		stopCh:  make(chan struct{}),
+	}, nil
+}
+
+// Start starts the wrapped reporter and the background flush loop.
+func (r *SQLiteReporter) Start(ctx context.Context) error {
+	if r.next != nil {
+		if err := r.next.Start(ctx); err != nil {
+			return err
+		}
+	}
+
+	r.wg.Add(1)
+	go r.flushLoop()
+
+	return nil
+}
+
+// Report forwards the opportunity and queues it for persistence.
+func (r *SQLiteReporter) Report(opp *domain.Opportunity) {
+	if r.next != nil {
+		r.next.Report(opp)
+	}
+
+	r.mu.Lock()
+	r.pending = append(r.pending, NewRecord(opp))
+	full := len(r.pending) >= r.cfg.BatchSize
+	r.mu.Unlock()
+
+	if full {
+		select {
+		case r.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// UpdatePrices forwards to the wrapped reporter.
+func (r *SQLiteReporter) UpdatePrices(prices *pricingDomain.PriceSnapshot) {
+	if r.next != nil {
+		r.next.UpdatePrices(prices)
+	}
+}
+
+// UpdateConnectionStatus forwards to the wrapped reporter.
+func (r *SQLiteReporter) UpdateConnectionStatus(name string, connected bool, latency time.Duration) {
+	if r.next != nil {
+		r.next.UpdateConnectionStatus(name, connected, latency)
+	}
+}
+
+// UpdateBlock forwards to the wrapped reporter.
+func (r *SQLiteReporter) UpdateBlock(blockNumber uint64) {
+	if r.next != nil {
+		r.next.UpdateBlock(blockNumber)
+	}
+}
+
+// UpdateGasPrice forwards to the wrapped reporter.
+func (r *SQLiteReporter) UpdateGasPrice(gweiPrice float64) {
+	if r.next != nil {
+		r.next.UpdateGasPrice(gweiPrice)
+	}
+}
+
+// UpdateCostBreakdown forwards to the wrapped reporter.
+func (r *SQLiteReporter) UpdateCostBreakdown(breakdown *app.CostBreakdown) {
+	if r.next != nil {
+		r.next.UpdateCostBreakdown(breakdown)
+	}
+}
+
+// ReportSummary forwards to the wrapped reporter if it handles summaries.
+func (r *SQLiteReporter) ReportSummary(summary *domain.ProfitSummary) {
+	if next, ok := r.next.(app.SummaryReporter); ok {
+		next.ReportSummary(summary)
+	}
+}
+
+// UpdateGasPercentile forwards to the wrapped reporter if it shows gas ranks.
+func (r *SQLiteReporter) UpdateGasPercentile(gweiPrice, percentileRank float64) {
+	if next, ok := r.next.(app.GasPercentileReporter); ok {
+		next.UpdateGasPercentile(gweiPrice, percentileRank)
+	}
+}
+
+// UpdateConnectionStats forwards to the wrapped reporter if it shows
+// connection stats.
+func (r *SQLiteReporter) UpdateConnectionStats(name string, stats health.ConnectionStats) {
+	if next, ok := r.next.(app.ConnectionStatsReporter); ok {
+		next.UpdateConnectionStats(name, stats)
+	}
+}
+
+// ReportSafety forwards to the wrapped reporter if it handles safety events.
+func (r *SQLiteReporter) ReportSafety(event *domain.SafetyEvent) {
+	if next, ok := r.next.(app.SafetyReporter); ok {
+		next.ReportSafety(event)
+	}
+}
+
+// Query returns persisted opportunities detected within [from, to], oldest first.
+// Pending records are flushed first so the result includes everything reported so far.
+func (r *SQLiteReporter) Query(from, to time.Time) ([]Record, error) {
+	if err := r.Flush(); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT block_number, pair, direction, spread_bps, gross_profit_usd, gas_cost_usd,
+		       fees_usd, net_profit_usd, profitable, detected_at
+		FROM opportunities
+		WHERE detected_at BETWEEN ? AND ?
+		ORDER BY detected_at, id`,
+		from.UnixNano(), to.UnixNano())
+	if err != nil {
+		return nil, apperror.New(apperror.CodeStorageQueryFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to query opportunities"))
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var (
+			rec                                 Record
+			spread, gross, gas, fees, netProfit string
+			detectedAt                          int64
+		)
+		if err := rows.Scan(&rec.BlockNumber, &rec.Pair, &rec.Direction, &spread, &gross, &gas,
+			&fees, &netProfit, &rec.Profitable, &detectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan opportunity: %w", err)
+		}
+		for _, col := range []struct {
+			name  string
+			raw   string
+			field *decimal.Decimal
+		}{
+			{"spread_bps", spread, &rec.SpreadBps},
+			{"gross_profit_usd", gross, &rec.GrossProfitUSD},
+			{"gas_cost_usd", gas, &rec.GasCostUSD},
+			{"fees_usd", fees, &rec.FeesUSD},
+			{"net_profit_usd", netProfit, &rec.NetProfitUSD},
+		} {
+			if *col.field, err = decimal.NewFromString(col.raw); err != nil {
+				return nil, fmt.Errorf("invalid %s %q: %w", col.name, col.raw, err)
+			}
+		}
+		rec.Timestamp = time.Unix(0, detectedAt)
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+// Flush writes all pending records in a single transaction.
+func (r *SQLiteReporter) Flush() error {
+	r.mu.Lock()
+	batch := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := r.insert(batch); err != nil {
+		return apperror.New(apperror.CodeStorageWriteFailed,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("failed to persist %d opportunities", len(batch))))
+	}
+	return nil
+}
+
+// insert writes a batch of records inside one transaction.
+func (r *SQLiteReporter) insert(batch []Record) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO opportunities (block_number, pair, direction, spread_bps, gross_profit_usd,
+			gas_cost_usd, fees_usd, net_profit_usd, profitable, detected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, rec := range batch {
+		if _, err := stmt.Exec(rec.BlockNumber, rec.Pair, rec.Direction, rec.SpreadBps.String(),
+			rec.GrossProfitUSD.String(), rec.GasCostUSD.String(), rec.FeesUSD.String(),
+			rec.NetProfitUSD.String(), rec.Profitable, rec.Timestamp.UnixNano()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// migrate adds any columns missing from a database created by an older version.
+func migrate(db *sql.DB) error {
+	rows, err := db.Query(`PRAGMA table_info(opportunities)`)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, colType    string
+			defaultValue     sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if existing[m.column] {
+			continue
+		}
+		if _, err := db.Exec(m.ddl); err != nil {
+			return fmt.Errorf("failed to add column %s: %w", m.column, err)
+		}
+	}
+	return nil
+}
+
+// flushLoop writes pending records when the batch fills or the interval elapses.
+func (r *SQLiteReporter) flushLoop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+		case <-r.flushCh:
+		}
+
+		if err := r.Flush(); err != nil {
+			r.logger.Error(context.Background(), "failed to flush opportunities", "error", err)
+		}
+	}
+}
+
+// Stop flushes remaining records, closes the database and stops the wrapped reporter.
+func (r *SQLiteReporter) Stop() error {
+	close(r.stopCh)
+	r.wg.Wait()
+
+	flushErr := r.Flush()
+	closeErr := r.db.Close()
+
+	if r.next != nil {
+		if err := r.next.Stop(); err != nil {
+			return err
+		}
+	}
+	if flushErr != nil {
+		return flushErr
+	}
+	return closeErr
+}
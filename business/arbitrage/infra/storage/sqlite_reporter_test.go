@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/shopspring/decimal"
+)
+
+// mockLogger implements logger.LoggerInterface for testing.
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Info(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (m *mockLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+var _ logger.LoggerInterface = (*mockLogger)(nil)
+
+func newOpportunity(block uint64, ts time.Time, netProfit string) *domain.Opportunity {
+	return &domain.Opportunity{
+		BlockNumber: block,
+		Timestamp:   ts,
+		Pair:        pricingDomain.Pair{Base: asset.ETH, Quote: asset.USDC},
+		Direction:   domain.DirectionCEXToDEX,
+		Spread:      pricingDomain.Spread{BasisPoints: decimal.RequireFromString("42.5")},
+		Profit:      &domain.ProfitResult{NetProfitRaw: decimal.RequireFromString(netProfit)},
+	}
+}
+
+// TestSQLiteReporter_ReportAndQuery tests that reported opportunities round-trip through SQLite.
+func TestSQLiteReporter_ReportAndQuery(t *testing.T) {
+	cfg := DefaultConfig(filepath.Join(t.TempDir(), "opportunities.db"))
+	cfg.FlushInterval = time.Hour // Only explicit flushes
+
+	reporter, err := NewSQLiteReporter(cfg, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+	if err := reporter.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start reporter: %v", err)
+	}
+	defer reporter.Stop()
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	reporter.Report(newOpportunity(100, base, "12.34"))
+	reporter.Report(newOpportunity(101, base.Add(time.Minute), "-3.5"))
+	reporter.Report(newOpportunity(102, base.Add(time.Hour), "7"))
+
+	tests := []struct {
+		name       string
+		from, to   time.Time
+		wantBlocks []uint64
+	}{
+		{name: "all", from: base, to: base.Add(2 * time.Hour), wantBlocks: []uint64{100, 101, 102}},
+		{name: "first_minute", from: base, to: base.Add(time.Minute), wantBlocks: []uint64{100, 101}},
+		{name: "empty_range", from: base.Add(-time.Hour), to: base.Add(-time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := reporter.Query(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			if len(records) != len(tt.wantBlocks) {
+				t.Fatalf("expected %d records, got %d", len(tt.wantBlocks), len(records))
+			}
+			for i, rec := range records {
+				if rec.BlockNumber != tt.wantBlocks[i] {
+					t.Errorf("record %d: expected block %d, got %d", i, tt.wantBlocks[i], rec.BlockNumber)
+				}
+			}
+		})
+	}
+
+	records, err := reporter.Query(base, base)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("expected 1 record at base time, got %d (err %v)", len(records), err)
+	}
+	rec := records[0]
+	if rec.Pair != "ETH-USDC" || rec.Direction != string(domain.DirectionCEXToDEX) {
+		t.Errorf("unexpected pair/direction: %s %s", rec.Pair, rec.Direction)
+	}
+	if !rec.SpreadBps.Equal(decimal.RequireFromString("42.5")) {
+		t.Errorf("expected spread 42.5, got %s", rec.SpreadBps)
+	}
+	if !rec.NetProfitUSD.Equal(decimal.RequireFromString("12.34")) {
+		t.Errorf("expected net profit 12.34, got %s", rec.NetProfitUSD)
+	}
+	if !rec.Timestamp.Equal(base) {
+		t.Errorf("expected timestamp %s, got %s", base, rec.Timestamp)
+	}
+}
+
+// TestSQLiteReporter_StopFlushes tests that pending records are written on shutdown.
+func TestSQLiteReporter_StopFlushes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "opportunities.db")
+	cfg := DefaultConfig(path)
+	cfg.FlushInterval = time.Hour
+
+	reporter, err := NewSQLiteReporter(cfg, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create reporter: %v", err)
+	}
+	if err := reporter.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start reporter: %v", err)
+	}
+
+	now := time.Now()
+	reporter.Report(newOpportunity(200, now, "1"))
+	if err := reporter.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	reopened, err := NewSQLiteReporter(cfg, nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to reopen database: %v", err)
+	}
+	defer reopened.db.Close()
+
+	records, err := reopened.Query(now.Add(-time.Second), now.Add(time.Second))
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 1 || records[0].BlockNumber != 200 {
+		t.Errorf("expected block 200 to be persisted, got %+v", records)
+	}
+}
+
+// TestSQLiteReporter_MigratesOldSchema tests that a database created before the
+// cost columns existed is upgraded and its rows read back with zero costs.
+func TestSQLiteReporter_MigratesOldSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "opportunities.db")
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("failed to create old schema: %v", err)
+	}
+	now := time.Now()
+	if _, err := db.Exec(`
+		INSERT INTO opportunities (block_number, pair, direction, spread_bps, net_profit_usd, detected_at)
+		VALUES (300, 'ETH-USDC', 'CEX_TO_DEX', '10', '4.2', ?)`, now.UnixNano()); err != nil {
+		t.Fatalf("failed to insert old row: %v", err)
+	}
+	db.Close()
+
+	reporter, err := NewSQLiteReporter(DefaultConfig(path), nil, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to open old database: %v", err)
+	}
+	defer reporter.db.Close()
+
+	reporter.Report(newOpportunity(301, now, "1"))
+	records, err := reporter.Query(now.Add(-time.Second), now.Add(time.Second))
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if !records[0].NetProfitUSD.Equal(decimal.RequireFromString("4.2")) || !records[0].GrossProfitUSD.IsZero() || records[0].Profitable {
+		t.Errorf("unexpected migrated record: %+v", records[0])
+	}
+}
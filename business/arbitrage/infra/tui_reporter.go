@@ -3,22 +3,30 @@ package infra
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
 	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 	"github.com/fd1az/arbitrage-bot/pkg/ui"
 )
 
 // TUIReporter implements Reporter for Bubble Tea TUI.
 type TUIReporter struct {
 	started bool
+	state   *MarketState
 }
 
-// NewTUIReporter creates a new TUIReporter.
-func NewTUIReporter() *TUIReporter {
-	return &TUIReporter{}
+// NewTUIReporter creates a new TUIReporter that also keeps state up to date.
+func NewTUIReporter(state *MarketState) *TUIReporter {
+	return &TUIReporter{state: state}
+}
+
+// Snapshot returns a thread-safe copy of the reported state.
+func (r *TUIReporter) Snapshot() StateSnapshot {
+	return r.state.Snapshot()
 }
 
 // Start initializes the TUI reporter.
@@ -45,6 +53,7 @@ func (r *TUIReporter) UpdateStartup(step, status, message string) {
 
 // Report sends an arbitrage opportunity to the TUI.
 func (r *TUIReporter) Report(opp *domain.Opportunity) {
+	r.state.AddOpportunity(opp)
 	if !r.started {
 		return
 	}
@@ -53,6 +62,7 @@ func (r *TUIReporter) Report(opp *domain.Opportunity) {
 
 // UpdatePrices sends price updates to the TUI.
 func (r *TUIReporter) UpdatePrices(prices *pricingDomain.PriceSnapshot) {
+	r.state.SetPrices(prices)
 	if !r.started {
 		return
 	}
@@ -61,6 +71,7 @@ func (r *TUIReporter) UpdatePrices(prices *pricingDomain.PriceSnapshot) {
 
 // UpdateConnectionStatus sends connection status to the TUI.
 func (r *TUIReporter) UpdateConnectionStatus(name string, connected bool, latency time.Duration) {
+	r.state.SetConnection(name, connected, latency)
 	if !r.started {
 		return
 	}
@@ -71,8 +82,24 @@ func (r *TUIReporter) UpdateConnectionStatus(name string, connected bool, latenc
 	})
 }
 
+// UpdateConnectionStats sends a source's uptime, reconnects and backoff to
+// the TUI.
+func (r *TUIReporter) UpdateConnectionStats(name string, stats health.ConnectionStats) {
+	if !r.started {
+		return
+	}
+	ui.Send(ui.ConnectionStatsMsg{
+		Name:           name,
+		ConnectedSince: stats.ConnectedSince,
+		UptimePct:      stats.UptimePct,
+		Reconnects:     stats.Reconnects,
+		Backoff:        stats.Backoff,
+	})
+}
+
 // UpdateBlock sends block number to the TUI.
 func (r *TUIReporter) UpdateBlock(blockNumber uint64) {
+	r.state.SetBlock(blockNumber)
 	if !r.started {
 		return
 	}
@@ -84,6 +111,7 @@ func (r *TUIReporter) UpdateBlock(blockNumber uint64) {
 
 // UpdateGasPrice sends gas price to the TUI.
 func (r *TUIReporter) UpdateGasPrice(gweiPrice float64) {
+	r.state.SetGasPrice(gweiPrice)
 	if !r.started {
 		return
 	}
@@ -92,12 +120,28 @@ func (r *TUIReporter) UpdateGasPrice(gweiPrice float64) {
 	})
 }
 
+// UpdateGasPercentile sends the gas price with its rank among recent prices.
+func (r *TUIReporter) UpdateGasPercentile(gweiPrice, percentileRank float64) {
+	if !r.started {
+		return
+	}
+	ui.Send(ui.GasPriceMsg{
+		GweiPrice:      gweiPrice,
+		PercentileRank: percentileRank,
+		Ranked:         true,
+	})
+}
+
 // UpdateCostBreakdown sends cost breakdown data to the TUI.
 // UI should display this directly without any calculations.
 func (r *TUIReporter) UpdateCostBreakdown(breakdown *app.CostBreakdown) {
 	if !r.started {
 		return
 	}
+	sizeSpreads := make([]ui.SizeSpread, len(breakdown.SizeSpreads))
+	for i, s := range breakdown.SizeSpreads {
+		sizeSpreads[i] = ui.SizeSpread{TradeSize: s.TradeSize, NetSpreadBps: s.NetSpreadBps}
+	}
 	ui.Send(ui.CostBreakdownMsg{
 		TradeSize:     breakdown.TradeSize,
 		TradeValueUSD: breakdown.TradeValueUSD.InexactFloat64(),
@@ -107,9 +151,36 @@ func (r *TUIReporter) UpdateCostBreakdown(breakdown *app.CostBreakdown) {
 		TotalCosts:    breakdown.TotalCosts.InexactFloat64(),
 		NetProfit:     breakdown.NetProfit.InexactFloat64(),
 		IsProfitable:  breakdown.IsProfitable,
+		NetSpreadBps:  breakdown.NetSpreadBps.InexactFloat64(),
+
+		ApprovalGasUSD:   breakdown.Overheads.ApprovalGasUSD.InexactFloat64(),
+		WithdrawalFeeUSD: breakdown.Overheads.WithdrawalFeeUSD.InexactFloat64(),
+		DepositFeeUSD:    breakdown.Overheads.DepositFeeUSD.InexactFloat64(),
+
+		SizeSpreads: sizeSpreads,
 	})
 }
 
+// ReportSummary sends the latest profit summary to the TUI.
+func (r *TUIReporter) ReportSummary(summary *domain.ProfitSummary) {
+	if !r.started {
+		return
+	}
+	ui.Send(ui.SummaryMsg{Summary: summary})
+}
+
+// ReportSafety shows a safety halt as an error and a resume in the log.
+func (r *TUIReporter) ReportSafety(event *domain.SafetyEvent) {
+	if !r.started {
+		return
+	}
+	if event.Halted {
+		ui.Send(ui.ErrorMsg{Error: errors.New(event.String())})
+		return
+	}
+	ui.Send(ui.LogMsg{Level: "info", Message: event.String()})
+}
+
 // Stop gracefully shuts down the TUI reporter.
 func (r *TUIReporter) Stop() error {
 	r.started = false
@@ -3,14 +3,22 @@ package arbitrage
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/app"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/app/backtest"
 	arbitrageDI "github.com/fd1az/arbitrage-bot/business/arbitrage/di"
+	arbitrageDomain "github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/infra"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/infra/alerting"
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/infra/storage"
+	blockchainApp "github.com/fd1az/arbitrage-bot/business/blockchain/app"
 	blockchainDI "github.com/fd1az/arbitrage-bot/business/blockchain/di"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
 	pricingDI "github.com/fd1az/arbitrage-bot/business/pricing/di"
 	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
 	"github.com/fd1az/arbitrage-bot/internal/asset"
 	"github.com/fd1az/arbitrage-bot/internal/config"
 	"github.com/fd1az/arbitrage-bot/internal/di"
@@ -23,22 +31,46 @@ type Module struct{}
 
 // RegisterServices registers all arbitrage services with the DI container.
 func (m *Module) RegisterServices(c di.Container) error {
+	// Register MarketState - public service (kept current by the display reporter)
+	di.RegisterToken(c, arbitrageDI.MarketState, func(sr di.ServiceRegistry) *infra.MarketState {
+		return infra.NewMarketState(infra.DefaultRecentOpportunities)
+	})
+
+	// Register OpportunityStream - public service (the outermost reporter when grpc is enabled)
+	di.RegisterToken(c, arbitrageDI.OpportunityStream, func(sr di.ServiceRegistry) *alerting.GRPCReporter {
+		stream, _ := arbitrageDI.GetReporter(sr).(*alerting.GRPCReporter)
+		return stream
+	})
+
 	// Register Reporter - private dependency
 	di.RegisterToken(c, arbitrageDI.Reporter, func(sr di.ServiceRegistry) app.Reporter {
 		cfg := sr.Get("config").(*config.Config)
-		if cfg.Arbitrage.TUIMode {
-			return infra.NewTUIReporter()
-		}
-		return infra.NewConsoleReporter()
+		log := sr.Get("logger").(logger.LoggerInterface)
+		return newReporter(cfg, arbitrageDI.GetMarketState(sr), log)
 	})
 
 	// Register ProfitCalculator - private dependency
 	di.RegisterToken(c, arbitrageDI.ProfitCalculator, func(sr di.ServiceRegistry) *app.ProfitCalculator {
 		cfg := sr.Get("config").(*config.Config)
-		return app.NewProfitCalculator(
-			cfg.Arbitrage.MinProfitBpsDecimal(),
-			cfg.Arbitrage.MinProfitUSDDecimal(),
-		)
+		return newProfitCalculator(cfg)
+	})
+
+	// Register Recorder - private dependency (nil unless --record or recording.dir is set)
+	di.RegisterToken(c, arbitrageDI.Recorder, func(sr di.ServiceRegistry) *backtest.Recorder {
+		cfg := sr.Get("config").(*config.Config)
+		log := sr.Get("logger").(logger.LoggerInterface)
+		if cfg.Recording.Dir == "" {
+			return nil
+		}
+		recorder, err := backtest.NewRecorder(backtest.RecorderConfig{
+			Dir:            cfg.Recording.Dir,
+			MaxFileSize:    int64(cfg.Recording.MaxFileSizeMB) << 20,
+			RotateInterval: cfg.Recording.RotateInterval,
+		}, log)
+		if err != nil {
+			panic("failed to create recorder: " + err.Error())
+		}
+		return recorder
 	})
 
 	// Register Detector - public service
@@ -52,13 +84,39 @@ func (m *Module) RegisterServices(c di.Container) error {
 		calculator := arbitrageDI.GetProfitCalculator(sr)
 		reporter := arbitrageDI.GetReporter(sr)
 
+		// Record what the detector sees by wrapping the live ports it consumes
+		if recorder := arbitrageDI.GetRecorder(sr); recorder != nil {
+			blockchain = blockchainApp.NewBlockchainService(
+				recorder.WrapSubscriber(blockchainDI.GetBlockSubscriber(sr)),
+				recorder.WrapGasOracle(blockchainDI.GetGasOracle(sr)),
+			)
+			stables, venues := pricing.StableEquivalents(), pricing.CEXVenues()
+			pricing = pricingApp.NewPricingService(
+				recorder.WrapCEX(pricingDI.GetCEXProvider(sr)),
+				recorder.WrapDEX(pricingDI.GetDEXProvider(sr)),
+			)
+			pricing.SetStableEquivalents(stables)
+			pricing.SetCEXVenues(venues)
+			reporter = recorder.WrapReporter(reporter)
+		}
+
 		// Build detector config from app config
-		detectorCfg := app.DetectorConfig{
-			Pairs:      buildPairs(cfg.Arbitrage.Pairs, registry, log),
-			TradeSizes: cfg.Arbitrage.TradeSizesDecimal(),
+		detectorCfg := newDetectorConfig(cfg, calculator, registry, log)
+
+		detector := app.NewDetector(blockchain, pricing, calculator, reporter, detectorCfg, log)
+
+		// Enable triangular scanning when cycles are configured
+		if cycles := buildCycles(cfg.Arbitrage.TriangularCycles, registry, cfg.Ethereum.ChainID, log); len(cycles) > 0 {
+			triangularCfg := app.TriangularConfig{
+				Cycles:     cycles,
+				TradeSizes: cfg.Arbitrage.TradeSizesDecimal(),
+
+				DefaultSwapGasLimit: cfg.Arbitrage.DefaultSwapGasLimit,
+			}
+			detector.SetTriangularDetector(app.NewTriangularDetector(pricing, calculator, reporter, triangularCfg, log))
 		}
 
-		return app.NewDetector(blockchain, pricing, calculator, reporter, detectorCfg, log)
+		return detector
 	})
 
 	return nil
@@ -66,47 +124,331 @@ func (m *Module) RegisterServices(c di.Container) error {
 
 // Startup initializes the arbitrage module.
 func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) error {
+	// Fail fast on pairs that can't be priced rather than silently skip them
+	cfg := mono.Config()
+	registry := mono.Services().Get("assetRegistry").(*asset.Registry)
+	if _, err := resolvePairs(cfg.Arbitrage.PairSymbols(), registry, cfg.Ethereum.ChainID); err != nil {
+		return err
+	}
+	if err := checkETHPricePair(cfg, registry, pricingDI.GetCEXProvider(mono.Services())); err != nil {
+		return err
+	}
+
+	// /ready stays at 503 until the detector has analyzed a pair end to end
+	if server := mono.Health(); server != nil {
+		arbitrageDI.GetDetector(mono.Services()).SetReadinessGate(server)
+	}
+
+	// Close recording files on shutdown so the last block is flushed
+	if recorder := arbitrageDI.GetRecorder(mono.Services()); recorder != nil {
+		mono.Logger().Info(ctx, "recording market data", "dir", mono.Config().Recording.Dir)
+		go func() {
+			<-ctx.Done()
+			if err := recorder.Close(); err != nil {
+				mono.Logger().Error(context.Background(), "failed to close recording", "error", err)
+			}
+		}()
+	}
+
+	// Price CEX legs with the account's actual fees when API credentials are set
+	if stream := pricingDI.GetUserDataStream(mono.Services()); stream != nil {
+		go loadCEXFees(ctx, stream, arbitrageDI.GetProfitCalculator(mono.Services()), mono.Config().Binance.Symbols, mono.Logger())
+	}
+
 	mono.Logger().Info(ctx, "arbitrage module started")
 	return nil
 }
 
-// buildPairs converts config strings to domain pairs using the injected registry.
-func buildPairs(pairs []string, registry *asset.Registry, log logger.LoggerInterface) []pricingDomain.Pair {
-	result := make([]pricingDomain.Pair, 0, len(pairs))
-	ctx := context.Background()
+// loadCEXFees fetches the account's commission rates and applies them to the
+// calculator. Rates are per account tier, so the first symbol is representative.
+func loadCEXFees(ctx context.Context, source pricingApp.FeeRateSource, calculator *app.ProfitCalculator, symbols []string, log logger.LoggerInterface) {
+	if len(symbols) == 0 {
+		return
+	}
+	rates, err := source.FeeRates(ctx, symbols[0])
+	if err != nil {
+		log.Warn(ctx, "failed to load binance fee rates, using defaults", "error", err)
+		return
+	}
+	calculator.SetCEXFees(rates.Taker, rates.Maker)
+	log.Info(ctx, "using binance account fee rates",
+		"taker", rates.Taker.String(),
+		"maker", rates.Maker.String(),
+	)
+}
+
+// newProfitCalculator builds the default calculator with the global thresholds and slippage model.
+func newProfitCalculator(cfg *config.Config) *app.ProfitCalculator {
+	calculator := app.NewProfitCalculator(
+		cfg.Arbitrage.MinProfitBpsDecimal(),
+		cfg.Arbitrage.MinProfitUSDDecimal(),
+	)
+	calculator.SetSlippageModel(app.NewSlippageModel(
+		cfg.Arbitrage.SlippageImpactFactorDecimal(),
+		cfg.Arbitrage.SlippageMaxTradeSizeDecimal(),
+	))
+	calculator.SetFixedCosts(app.FixedCosts{
+		ApprovalGas:         cfg.Arbitrage.ApprovalGas,
+		CEXWithdrawalFeeUSD: cfg.Arbitrage.CEXWithdrawalFeeUSDDecimal(),
+		CEXDepositFeeUSD:    cfg.Arbitrage.CEXDepositFeeUSDDecimal(),
+	})
+	return calculator
+}
+
+// newReporter builds the display reporter and the enabled secondary outputs
+// (SQLite persistence, webhook and Telegram alerts) side by side behind a
+// MultiReporter, then wraps them with the summary and gRPC stream decorators.
+func newReporter(cfg *config.Config, state *infra.MarketState, log logger.LoggerInterface) app.Reporter {
+	var display app.Reporter = infra.NewConsoleReporter(state)
+	if cfg.Arbitrage.TUIMode {
+		display = infra.NewTUIReporter(state)
+	}
+	outputs := []app.Reporter{display}
+
+	if cfg.Storage.Enabled {
+		storageCfg := storage.Config{
+			Path:          cfg.Storage.Path,
+			BatchSize:     cfg.Storage.BatchSize,
+			FlushInterval: cfg.Storage.FlushInterval,
+		}
+		sqliteReporter, err := storage.NewSQLiteReporter(storageCfg, nil, log)
+		if err != nil {
+			panic("failed to create sqlite reporter: " + err.Error())
+		}
+		outputs = append(outputs, sqliteReporter)
+	}
+
+	if cfg.Alerts.WebhookURL != "" {
+		webhookCfg := alerting.WebhookConfig{
+			URL:          cfg.Alerts.WebhookURL,
+			MinProfitUSD: cfg.Alerts.MinProfitUSDDecimal(),
+			Debounce:     cfg.Alerts.Debounce,
+		}
+		webhookReporter, err := alerting.NewWebhookReporter(webhookCfg, nil, log)
+		if err != nil {
+			panic("failed to create webhook reporter: " + err.Error())
+		}
+		outputs = append(outputs, webhookReporter)
+	}
+
+	if cfg.Alerts.TelegramEnabled() {
+		telegramCfg := alerting.TelegramConfig{
+			BotToken:          cfg.Alerts.TelegramBotToken,
+			ChatID:            cfg.Alerts.TelegramChatID,
+			MinProfitUSD:      cfg.Alerts.MinProfitUSDDecimal(),
+			Debounce:          cfg.Alerts.Debounce,
+			MessagesPerMinute: cfg.Alerts.TelegramMessagesPerMinute,
+			SendSummaries:     cfg.Alerts.SendSummaries,
+		}
+		telegramReporter, err := alerting.NewTelegramReporter(telegramCfg, nil, log)
+		if err != nil {
+			panic("failed to create telegram reporter: " + err.Error())
+		}
+		outputs = append(outputs, telegramReporter)
+	}
+
+	// One failing output must not silence the others
+	reporter := display
+	if len(outputs) > 1 {
+		reporter = infra.NewMultiReporter(log, outputs...)
+	}
+
+	if cfg.Arbitrage.SummaryInterval > 0 {
+		reporter = infra.NewAggregatingReporter(cfg.Arbitrage.SummaryInterval, reporter, log)
+	}
+
+	// Outermost, so OpportunityStream can hand it to the gRPC server
+	if cfg.GRPC.Enabled {
+		reporter = alerting.NewGRPCReporter(alerting.GRPCConfig{BufferSize: cfg.GRPC.BufferSize}, reporter, log)
+	}
+
+	return reporter
+}
 
+// buildPairs converts config strings to domain pairs using the injected
+// registry, preferring the assets of the selected chain. Pairs that don't
+// resolve are logged and skipped; startup has already rejected them (see
+// resolvePairs), so this only drops pairs introduced by a reload.
+func buildPairs(pairs []string, registry *asset.Registry, chainID uint64, log logger.LoggerInterface) []pricingDomain.Pair {
+	result := make([]pricingDomain.Pair, 0, len(pairs))
 	for _, p := range pairs {
-		parts := strings.SplitN(p, "-", 2)
-		if len(parts) != 2 {
-			log.Warn(ctx, "invalid pair format, skipping", "pair", p)
+		pair, err := resolvePair(p, registry, chainID)
+		if err != nil {
+			log.Warn(context.Background(), "skipping unresolvable pair", "pair", p, "error", err)
 			continue
 		}
+		result = append(result, pair)
+	}
+	return result
+}
 
-		baseSymbol := strings.TrimSpace(parts[0])
-		quoteSymbol := strings.TrimSpace(parts[1])
-
-		// Get assets from registry (prefer Ethereum mainnet)
-		base, ok := registry.GetBySymbolAndChain(baseSymbol, asset.ChainIDEthereum)
-		if !ok {
-			assets := registry.GetBySymbol(baseSymbol)
-			if len(assets) == 0 {
-				log.Warn(ctx, "unknown base asset, skipping pair", "asset", baseSymbol, "pair", p)
-				continue
-			}
-			base = assets[0]
+// resolvePairs resolves every configured pair, failing on the first that
+// names an asset the registry doesn't know; such a pair would otherwise be
+// dropped and never priced.
+func resolvePairs(pairs []string, registry *asset.Registry, chainID uint64) ([]pricingDomain.Pair, error) {
+	result := make([]pricingDomain.Pair, 0, len(pairs))
+	for _, p := range pairs {
+		pair, err := resolvePair(p, registry, chainID)
+		if err != nil {
+			return nil, err
 		}
+		result = append(result, pair)
+	}
+	return result, nil
+}
 
-		quote, ok := registry.GetBySymbolAndChain(quoteSymbol, asset.ChainIDEthereum)
-		if !ok {
-			assets := registry.GetBySymbol(quoteSymbol)
-			if len(assets) == 0 {
-				log.Warn(ctx, "unknown quote asset, skipping pair", "asset", quoteSymbol, "pair", p)
-				continue
-			}
-			quote = assets[0]
+// buildETHPricePair resolves the gas-cost ETH price pair, returning nil (take
+// the price from a scanned ETH pair) when it is unset or can't be resolved.
+func buildETHPricePair(symbol string, registry *asset.Registry, chainID uint64, log logger.LoggerInterface) *pricingDomain.Pair {
+	pair, err := resolveETHPricePair(symbol, registry, chainID)
+	if err != nil {
+		log.Warn(context.Background(), "ignoring ETH price pair", "pair", symbol, "error", err)
+		return nil
+	}
+	return pair
+}
+
+// checkETHPricePair fails when arbitrage.eth_price_symbol can't be resolved
+// or no CEX venue subscribes its book; the feed would otherwise fail every
+// block.
+func checkETHPricePair(cfg *config.Config, registry *asset.Registry, cex pricingApp.CEXProvider) error {
+	pair, err := resolveETHPricePair(cfg.Arbitrage.ETHPriceSymbol, registry, cfg.Ethereum.ChainID)
+	if err != nil || pair == nil {
+		return err
+	}
+	if s, ok := cex.(pricingApp.Subscriber); ok && !s.Subscribes(*pair) {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("arbitrage.eth_price_symbol %s is not subscribed on any CEX venue (add it to the venue's symbols, or set it to \"\")", cfg.Arbitrage.ETHPriceSymbol)))
+	}
+	return nil
+}
+
+// resolveETHPricePair resolves arbitrage.eth_price_symbol, which must price
+// ETH in a USD-like quote to convert gas costs (nil when unset).
+func resolveETHPricePair(symbol string, registry *asset.Registry, chainID uint64) (*pricingDomain.Pair, error) {
+	if symbol == "" {
+		return nil, nil
+	}
+	pair, err := resolvePair(symbol, registry, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if !asset.SameUnderlying(pair.Base, asset.ETH) || !app.IsUSDLike(pair.Quote) {
+		return nil, apperror.New(apperror.CodeInvalidSymbol,
+			apperror.WithContext(fmt.Sprintf("arbitrage.eth_price_symbol %s must price ETH in USD or a USD stablecoin", symbol)))
+	}
+	return &pair, nil
+}
+
+// resolvePair converts a BASE-QUOTE string to a domain pair.
+func resolvePair(symbol string, registry *asset.Registry, chainID uint64) (pricingDomain.Pair, error) {
+	baseSymbol, quoteSymbol, ok := strings.Cut(symbol, "-")
+	if !ok {
+		return pricingDomain.Pair{}, apperror.New(apperror.CodeInvalidSymbol,
+			apperror.WithContext(fmt.Sprintf("invalid pair %q (expected BASE-QUOTE)", symbol)))
+	}
+
+	base, err := resolveAsset(strings.TrimSpace(baseSymbol), registry, chainID)
+	if err != nil {
+		return pricingDomain.Pair{}, apperror.New(apperror.CodeInvalidSymbol,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("pair %s: unknown base asset", symbol)))
+	}
+	quote, err := resolveAsset(strings.TrimSpace(quoteSymbol), registry, chainID)
+	if err != nil {
+		return pricingDomain.Pair{}, apperror.New(apperror.CodeInvalidSymbol,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("pair %s: unknown quote asset", symbol)))
+	}
+	return pricingDomain.NewPair(base, quote), nil
+}
+
+// resolveAsset looks up a symbol, preferring the selected chain's asset.
+func resolveAsset(symbol string, registry *asset.Registry, chainID uint64) (*asset.Asset, error) {
+	if a, ok := registry.GetBySymbolAndChain(symbol, chainID); ok {
+		return a, nil
+	}
+	if assets := registry.GetBySymbol(symbol); len(assets) > 0 {
+		return assets[0], nil
+	}
+	return nil, fmt.Errorf("asset %s is not registered (add it under ethereum.tokens)", symbol)
+}
+
+// ApplyConfig hot-reloads the detection settings (pairs, trade sizes and
+// profit thresholds) from cfg into the running detector.
+func ApplyConfig(sr di.ServiceRegistry, cfg *config.Config) {
+	log := sr.Get("logger").(logger.LoggerInterface)
+	registry := sr.Get("assetRegistry").(*asset.Registry)
+
+	calculator := arbitrageDI.GetProfitCalculator(sr)
+	calculator.SetThresholds(cfg.Arbitrage.MinProfitBpsDecimal(), cfg.Arbitrage.MinProfitUSDDecimal())
+
+	arbitrageDI.GetDetector(sr).UpdateConfig(newDetectorConfig(cfg, calculator, registry, log))
+}
+
+// newDetectorConfig builds the detector config from app config.
+func newDetectorConfig(cfg *config.Config, calculator *app.ProfitCalculator, registry *asset.Registry, log logger.LoggerInterface) app.DetectorConfig {
+	return app.DetectorConfig{
+		Pairs:           buildPairs(cfg.Arbitrage.PairSymbols(), registry, cfg.Ethereum.ChainID, log),
+		TradeSizes:      cfg.Arbitrage.TradeSizesDecimal(),
+		TradeSizesUSD:   cfg.Arbitrage.TradeSizesUSDDecimal(),
+		PairCalculators: buildPairCalculators(&cfg.Arbitrage, calculator, registry, cfg.Ethereum.ChainID, log),
+		ExecutionMode:   arbitrageDomain.ExecutionMode(cfg.Arbitrage.ExecutionMode),
+		MaxCapitalUSD:   cfg.Arbitrage.MaxCapitalUSDDecimal(),
+		SpreadBasis:     pricingDomain.SpreadBasis(cfg.Spread.Basis),
+		MaxSnapshotAge:  cfg.Arbitrage.MaxSnapshotAge,
+
+		DefaultSwapGasLimit: cfg.Arbitrage.DefaultSwapGasLimit,
+		ETHPricePair:        buildETHPricePair(cfg.Arbitrage.ETHPriceSymbol, registry, cfg.Ethereum.ChainID, log),
+		SimulateExecution:   cfg.Arbitrage.SimulateExecution,
+		ScanInterval:        cfg.Arbitrage.ScanInterval,
+		Dedupe:              cfg.Arbitrage.Dedupe,
+
+		Safety: app.SafetyConfig{
+			MaxGasGwei:       cfg.Arbitrage.Safety.MaxGasGwei,
+			MaxPriceMovePct:  cfg.Arbitrage.Safety.MaxPriceMovePctDecimal(),
+			HaltOnDisconnect: cfg.Arbitrage.Safety.HaltOnDisconnect,
+			ResumeAfter:      cfg.Arbitrage.Safety.ResumeAfterBlocks,
+		},
+	}
+}
+
+// buildPairCalculators derives a calculator for each pair that overrides the
+// global profit thresholds, keyed by the domain pair's String().
+func buildPairCalculators(cfg *config.ArbitrageConfig, calculator *app.ProfitCalculator, registry *asset.Registry, chainID uint64, log logger.LoggerInterface) map[string]*app.ProfitCalculator {
+	result := make(map[string]*app.ProfitCalculator)
+
+	for _, p := range cfg.Pairs {
+		if !p.HasOverrides() || cfg.Denied(p.Symbol) {
+			continue
+		}
+		pairs := buildPairs([]string{p.Symbol}, registry, chainID, log)
+		if len(pairs) == 0 {
+			continue
 		}
+		minProfitBps, minProfitUSD := cfg.PairThresholds(p)
+		result[pairs[0].String()] = calculator.WithThresholds(minProfitBps, minProfitUSD)
+	}
+
+	return result
+}
+
+// buildCycles converts configured triangular cycles to domain pairs, skipping invalid cycles.
+func buildCycles(cycles [][]string, registry *asset.Registry, chainID uint64, log logger.LoggerInterface) [][]pricingDomain.Pair {
+	result := make([][]pricingDomain.Pair, 0, len(cycles))
+	ctx := context.Background()
 
-		result = append(result, pricingDomain.NewPair(base, quote))
+	for _, cycle := range cycles {
+		pairs := buildPairs(cycle, registry, chainID, log)
+		if len(pairs) != len(cycle) {
+			log.Warn(ctx, "cycle has unknown pairs, skipping", "cycle", cycle)
+			continue
+		}
+		if err := app.ValidateCycle(pairs); err != nil {
+			log.Warn(ctx, "invalid triangular cycle, skipping", "cycle", cycle, "error", err)
+			continue
+		}
+		result = append(result, pairs)
 	}
 
 	return result
@@ -3,6 +3,8 @@ package app
 
 import (
 	"context"
+	"math/big"
+	"time"
 
 	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
 )
@@ -19,11 +21,52 @@ type BlockSubscriber interface {
 	State() domain.ConnectionState
 }
 
+// ReorgNotifier is implemented by subscribers that detect chain reorganizations.
+type ReorgNotifier interface {
+	// Reorgs returns a channel of detected reorganizations.
+	Reorgs() <-chan *domain.ReorgEvent
+}
+
+// TipSubscriber is implemented by subscribers that delay blocks for
+// confirmations but also expose the unconfirmed head.
+type TipSubscriber interface {
+	// Tip returns a channel of head blocks, emitted as soon as they arrive.
+	Tip() <-chan *domain.Block
+}
+
+// FailureNotifier is implemented by subscribers that can give up
+// reconnecting, leaving the block channel silent.
+type FailureNotifier interface {
+	// Failed returns a channel that receives the error the subscriber gave
+	// up with.
+	Failed() <-chan error
+}
+
+// LatencyReporter is implemented by subscribers that measure the RPC round
+// trip to their node.
+type LatencyReporter interface {
+	// Latency returns the moving average round trip, or 0 before the first
+	// measurement.
+	Latency() time.Duration
+}
+
+// GasPercentileRanker is implemented by gas oracles that keep recent prices
+// to rank the current one against.
+type GasPercentileRanker interface {
+	// PercentileRank returns where gwei falls among recent gas prices
+	// (0-100), or false without history.
+	PercentileRank(gwei float64) (float64, bool)
+}
+
 // GasOracle defines the interface for gas price information.
 type GasOracle interface {
 	// GetGasPrice retrieves the current gas price.
 	GetGasPrice(ctx context.Context) (*domain.GasPrice, error)
 
+	// GetGasPriceEIP1559 retrieves the EIP-1559 max fee per gas (baseFee*2 + tip)
+	// for a block's base fee, or the latest block's when baseFee is nil.
+	GetGasPriceEIP1559(ctx context.Context, baseFee *big.Int) (*domain.GasPrice, error)
+
 	// EstimateGas estimates the gas needed for a transaction.
 	EstimateGas(ctx context.Context, data []byte, to string) (uint64, error)
 }
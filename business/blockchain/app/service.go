@@ -3,8 +3,11 @@ package app
 
 import (
 	"context"
+	"math/big"
+	"time"
 
 	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 )
 
 // BlockchainService coordinates blockchain interactions.
@@ -26,11 +29,71 @@ func (s *BlockchainService) SubscribeBlocks(ctx context.Context) (<-chan *domain
 	return s.subscriber.Subscribe(ctx)
 }
 
+// SubscribeTip returns the unconfirmed head channel, or nil when the
+// subscriber does not expose one.
+func (s *BlockchainService) SubscribeTip() <-chan *domain.Block {
+	if tip, ok := s.subscriber.(TipSubscriber); ok {
+		return tip.Tip()
+	}
+	return nil
+}
+
+// SubscribeReorgs returns the subscriber's reorg channel, or nil when the
+// subscriber does not detect reorganizations (a nil channel never delivers).
+func (s *BlockchainService) SubscribeReorgs() <-chan *domain.ReorgEvent {
+	if notifier, ok := s.subscriber.(ReorgNotifier); ok {
+		return notifier.Reorgs()
+	}
+	return nil
+}
+
+// SubscribeFailures returns a channel that receives the error the subscriber
+// gives up with, or nil when it reconnects forever.
+func (s *BlockchainService) SubscribeFailures() <-chan error {
+	if notifier, ok := s.subscriber.(FailureNotifier); ok {
+		return notifier.Failed()
+	}
+	return nil
+}
+
 // GetGasPrice retrieves the current gas price.
 func (s *BlockchainService) GetGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 	return s.gasOracle.GetGasPrice(ctx)
 }
 
+// GetGasPriceEIP1559 retrieves the EIP-1559 max fee per gas for baseFee
+// (nil for the latest block's).
+func (s *BlockchainService) GetGasPriceEIP1559(ctx context.Context, baseFee *big.Int) (*domain.GasPrice, error) {
+	return s.gasOracle.GetGasPriceEIP1559(ctx, baseFee)
+}
+
+// GasPercentileRank returns where gwei falls among recent gas prices (0-100),
+// or false when the oracle keeps no history.
+func (s *BlockchainService) GasPercentileRank(gwei float64) (float64, bool) {
+	if ranker, ok := s.gasOracle.(GasPercentileRanker); ok {
+		return ranker.PercentileRank(gwei)
+	}
+	return 0, false
+}
+
+// Latency returns the subscriber's measured RPC round trip, or 0 when it
+// doesn't measure one.
+func (s *BlockchainService) Latency() time.Duration {
+	if lr, ok := s.subscriber.(LatencyReporter); ok {
+		return lr.Latency()
+	}
+	return 0
+}
+
+// ConnectionStats returns how stable the subscriber's connection has been
+// this session, or false when it doesn't track it.
+func (s *BlockchainService) ConnectionStats() (health.ConnectionStats, bool) {
+	if sr, ok := s.subscriber.(health.ConnectionStatsReporter); ok {
+		return sr.ConnectionStats(), true
+	}
+	return health.ConnectionStats{}, false
+}
+
 // ConnectionState returns the current connection state.
 func (s *BlockchainService) ConnectionState() domain.ConnectionState {
 	return s.subscriber.State()
@@ -17,6 +17,11 @@ type Block struct {
 	GasLimit   uint64
 	GasUsed    uint64
 	BaseFee    *big.Int
+	ReceivedAt time.Time // Local wall time the header arrived (for latency tracking)
+
+	// Confirmations is how many blocks were built on top of this one when it
+	// was emitted (0 for the chain head).
+	Confirmations uint64
 }
 
 // ConnectionState represents the state of a blockchain connection.
@@ -38,3 +43,24 @@ type ConnectionStatus struct {
 	Reconnects  int
 	UsingHTTP   bool // true if using HTTP fallback
 }
+
+// ReorgEvent describes a chain reorganization: blocks previously emitted
+// that are no longer on the canonical chain.
+type ReorgEvent struct {
+	FromBlock  uint64        // Lowest orphaned block number
+	ToBlock    uint64        // Highest orphaned block number (the old head)
+	OldHead    common.Hash   // Head hash before the reorg
+	NewHead    common.Hash   // Head hash that triggered detection
+	Orphaned   []common.Hash // Hashes of the orphaned blocks, lowest first
+	DetectedAt time.Time
+}
+
+// Depth returns the number of orphaned blocks.
+func (e *ReorgEvent) Depth() int {
+	return len(e.Orphaned)
+}
+
+// Affects reports whether number is within the orphaned range.
+func (e *ReorgEvent) Affects(number uint64) bool {
+	return number >= e.FromBlock && number <= e.ToBlock
+}
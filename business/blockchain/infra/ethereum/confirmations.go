@@ -0,0 +1,64 @@
+package ethereum
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+)
+
+// confirmationBuffer holds recent blocks until they are depth blocks below
+// the head. It is not safe for concurrent use.
+type confirmationBuffer struct {
+	depth   uint64
+	pending []*domain.Block // Ascending by number
+}
+
+// Push records a new head and returns the blocks that reached the
+// confirmation depth, oldest first, with Confirmations set.
+func (b *confirmationBuffer) Push(head *domain.Block) []*domain.Block {
+	if b.depth == 0 {
+		head.Confirmations = 0
+		return []*domain.Block{head}
+	}
+
+	// A head at or below pending blocks replaces them (same-height reorg)
+	kept := b.pending[:0]
+	for _, block := range b.pending {
+		if block.Number < head.Number {
+			kept = append(kept, block)
+		}
+	}
+	b.pending = append(kept, head)
+
+	if head.Number < b.depth {
+		return nil
+	}
+	confirmedAt := head.Number - b.depth
+
+	var released []*domain.Block
+	for len(b.pending) > 0 && b.pending[0].Number <= confirmedAt {
+		block := b.pending[0]
+		block.Confirmations = head.Number - block.Number
+		released = append(released, block)
+		b.pending = b.pending[1:]
+	}
+	return released
+}
+
+// Drop discards pending blocks orphaned by a reorg.
+func (b *confirmationBuffer) Drop(orphaned []common.Hash) {
+	kept := b.pending[:0]
+	for _, block := range b.pending {
+		orphan := false
+		for _, hash := range orphaned {
+			if block.Hash == hash {
+				orphan = true
+				break
+			}
+		}
+		if !orphan {
+			kept = append(kept, block)
+		}
+	}
+	b.pending = kept
+}
@@ -0,0 +1,101 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+)
+
+// TestConfirmationBuffer_Push tests releasing blocks once they are deep enough.
+func TestConfirmationBuffer_Push(t *testing.T) {
+	tests := []struct {
+		name      string
+		depth     uint64
+		heads     []*domain.Block
+		wantNums  []uint64 // Released block numbers across all pushes, in order
+		wantConfs []uint64
+	}{
+		{
+			name:      "depth_zero_passes_through",
+			depth:     0,
+			heads:     []*domain.Block{testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a')},
+			wantNums:  []uint64{10, 11},
+			wantConfs: []uint64{0, 0},
+		},
+		{
+			name:      "delays_by_depth",
+			depth:     2,
+			heads:     []*domain.Block{testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(12, 'a', 'a'), testBlock(13, 'a', 'a')},
+			wantNums:  []uint64{10, 11},
+			wantConfs: []uint64{2, 2},
+		},
+		{
+			name:      "gap_releases_everything_deep_enough",
+			depth:     2,
+			heads:     []*domain.Block{testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(15, 'a', 'a')},
+			wantNums:  []uint64{10, 11},
+			wantConfs: []uint64{5, 4},
+		},
+		{
+			name:      "same_height_head_replaces_pending",
+			depth:     1,
+			heads:     []*domain.Block{testBlock(10, 'a', 'a'), testBlock(10, 'b', 'a'), testBlock(11, 'b', 'b')},
+			wantNums:  []uint64{10},
+			wantConfs: []uint64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &confirmationBuffer{depth: tt.depth}
+
+			var released []*domain.Block
+			for _, head := range tt.heads {
+				released = append(released, buf.Push(head)...)
+			}
+
+			if len(released) != len(tt.wantNums) {
+				t.Fatalf("released %d blocks, want %d", len(released), len(tt.wantNums))
+			}
+			for i, block := range released {
+				if block.Number != tt.wantNums[i] {
+					t.Errorf("released[%d].Number = %d, want %d", i, block.Number, tt.wantNums[i])
+				}
+				if block.Confirmations != tt.wantConfs[i] {
+					t.Errorf("released[%d].Confirmations = %d, want %d", i, block.Confirmations, tt.wantConfs[i])
+				}
+			}
+		})
+	}
+}
+
+// TestConfirmationBuffer_SameHeightKeepsReplacement tests that a replaced
+// head is released from the new chain.
+func TestConfirmationBuffer_SameHeightKeepsReplacement(t *testing.T) {
+	buf := &confirmationBuffer{depth: 1}
+	buf.Push(testBlock(10, 'a', 'a'))
+	buf.Push(testBlock(10, 'b', 'a'))
+
+	released := buf.Push(testBlock(11, 'b', 'b'))
+	if len(released) != 1 || released[0].Hash != (common.Hash{'b', 10}) {
+		t.Fatalf("released = %+v, want block b-10", released)
+	}
+}
+
+// TestConfirmationBuffer_Drop tests that orphaned pending blocks are never released.
+func TestConfirmationBuffer_Drop(t *testing.T) {
+	buf := &confirmationBuffer{depth: 2}
+	buf.Push(testBlock(10, 'a', 'a'))
+	buf.Push(testBlock(11, 'a', 'a'))
+
+	buf.Drop([]common.Hash{{'a', 11}})
+
+	released := buf.Push(testBlock(12, 'a', 'a'))
+	released = append(released, buf.Push(testBlock(13, 'a', 'a'))...)
+
+	if len(released) != 1 || released[0].Number != 10 {
+		t.Fatalf("released = %+v, want only block 10", released)
+	}
+}
@@ -0,0 +1,57 @@
+package ethereum
+
+import "sync"
+
+// defaultGasHistorySize is how many fetched gas prices are ranked against:
+// about an hour of mainnet blocks.
+const defaultGasHistorySize = 300
+
+// gasHistory is a bounded window of recently fetched gas prices, used to
+// tell whether the current price is high or low. It is safe for concurrent
+// use.
+type gasHistory struct {
+	mu      sync.Mutex
+	samples []float64 // Ring buffer of gwei prices
+	next    int       // Slot the next sample overwrites once full
+	size    int
+}
+
+// newGasHistory creates a history keeping the last size prices.
+func newGasHistory(size int) *gasHistory {
+	return &gasHistory{samples: make([]float64, 0, size), size: size}
+}
+
+// add records a fetched price, evicting the oldest once the window is full.
+func (h *gasHistory) add(gwei float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) < h.size {
+		h.samples = append(h.samples, gwei)
+		return
+	}
+	h.samples[h.next] = gwei
+	h.next = (h.next + 1) % h.size
+}
+
+// percentileRank returns the percentage (0-100) of recorded prices below
+// gwei, counting equal prices as half below, or false with no history.
+func (h *gasHistory) percentileRank(gwei float64) (float64, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) == 0 {
+		return 0, false
+	}
+
+	var below, equal int
+	for _, sample := range h.samples {
+		switch {
+		case sample < gwei:
+			below++
+		case sample == gwei:
+			equal++
+		}
+	}
+	return (float64(below) + float64(equal)/2) / float64(len(h.samples)) * 100, true
+}
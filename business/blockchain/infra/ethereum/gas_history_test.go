@@ -0,0 +1,35 @@
+package ethereum
+
+import "testing"
+
+func TestGasHistory_PercentileRank(t *testing.T) {
+	h := newGasHistory(4)
+	if _, ok := h.percentileRank(10); ok {
+		t.Fatal("expected no rank without history")
+	}
+
+	for _, gwei := range []float64{10, 20, 30, 40} {
+		h.add(gwei)
+	}
+
+	tests := []struct {
+		gwei float64
+		want float64
+	}{
+		{gwei: 5, want: 0},
+		{gwei: 10, want: 12.5}, // Equal counts as half below
+		{gwei: 25, want: 50},
+		{gwei: 50, want: 100},
+	}
+	for _, tt := range tests {
+		if got, _ := h.percentileRank(tt.gwei); got != tt.want {
+			t.Errorf("percentileRank(%v) = %v, want %v", tt.gwei, got, tt.want)
+		}
+	}
+
+	// A full window evicts the oldest price (10)
+	h.add(50)
+	if got, _ := h.percentileRank(15); got != 0 {
+		t.Errorf("percentileRank(15) after eviction = %v, want 0", got)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
 	"github.com/fd1az/arbitrage-bot/internal/apperror"
@@ -25,10 +26,13 @@ import (
 
 // GasOracleConfig holds configuration for the gas oracle.
 type GasOracleConfig struct {
-	RPCURL       string        // Ethereum RPC endpoint
-	CacheTTL     time.Duration // How long to cache gas prices
-	MaxGasPrice  *big.Int      // Maximum acceptable gas price (safety)
-	DefaultGas   uint64        // Default gas limit for estimation
+	RPCURL           string        // Ethereum RPC endpoint
+	CacheTTL         time.Duration // How long to cache gas prices
+	MaxGasPrice      *big.Int      // Maximum acceptable gas price (safety)
+	DefaultGas       uint64        // Default gas limit for estimation
+	FeeHistoryBlocks uint64        // Blocks sampled via eth_feeHistory (0 = disabled)
+	RewardPercentile float64       // Priority fee percentile per block (e.g., 50)
+	HistorySize      int           // Fetched prices kept to rank the current one against (0 = no ranking)
 }
 
 // DefaultGasOracleConfig returns sensible defaults.
@@ -37,13 +41,21 @@ func DefaultGasOracleConfig(rpcURL string) GasOracleConfig {
 	maxGas.SetString("500000000000", 10) // 500 gwei max
 
 	return GasOracleConfig{
-		RPCURL:      rpcURL,
-		CacheTTL:    12 * time.Second, // ~1 block
-		MaxGasPrice: maxGas,
-		DefaultGas:  200000,
+		RPCURL:           rpcURL,
+		CacheTTL:         12 * time.Second, // ~1 block
+		MaxGasPrice:      maxGas,
+		DefaultGas:       200000,
+		FeeHistoryBlocks: 0,  // Disabled by default
+		RewardPercentile: 50, // Median tip
+		HistorySize:      defaultGasHistorySize,
 	}
 }
 
+// gasPriceCacheCapacity bounds the gas price cache; it holds one entry per
+// pricing method ("current", "fee_history"), plus one per recent base fee
+// for EIP-1559 prices.
+const gasPriceCacheCapacity = 16
+
 // gasOracleMetrics holds OTEL metric instruments.
 type gasOracleMetrics struct {
 	gasPriceFetches metric.Int64Counter
@@ -51,6 +63,7 @@ type gasOracleMetrics struct {
 	estimateGas     metric.Int64Counter
 	cacheHits       metric.Int64Counter
 	cacheMisses     metric.Int64Counter
+	coalesced       metric.Int64Counter
 }
 
 // GasOracle implements the GasOracle interface using go-ethereum.
@@ -68,6 +81,12 @@ type GasOracle struct {
 	// Circuit breaker
 	cb *circuitbreaker.CircuitBreaker[*big.Int]
 
+	// Concurrent cache misses share one RPC
+	flight singleflight.Group
+
+	// Recently fetched prices, to rank the current one (nil = disabled)
+	history *gasHistory
+
 	// Observability
 	tracer  trace.Tracer
 	metrics *gasOracleMetrics
@@ -78,10 +97,13 @@ func NewGasOracle(cfg GasOracleConfig, log logger.LoggerInterface) (*GasOracle,
 	g := &GasOracle{
 		config:        cfg,
 		logger:        log,
-		priceCache:    cache.New[string, *domain.GasPrice](5 * time.Minute),
+		priceCache:    cache.NewWithCapacity[string, *domain.GasPrice]("gas_price", gasPriceCacheCapacity, 5*time.Minute),
 		priceCacheTTL: cfg.CacheTTL,
 		tracer:        otel.Tracer(tracerName),
 	}
+	if cfg.HistorySize > 0 {
+		g.history = newGasHistory(cfg.HistorySize)
+	}
 
 	if err := g.initMetrics(); err != nil {
 		return nil, fmt.Errorf("init metrics: %w", err)
@@ -144,6 +166,11 @@ func (g *GasOracle) initMetrics() error {
 		return err
 	}
 
+	g.metrics.coalesced, err = newCoalescedCounter()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -179,7 +206,8 @@ func (g *GasOracle) Connect(ctx context.Context) error {
 	return nil
 }
 
-// GetGasPrice retrieves the current gas price with caching.
+// GetGasPrice retrieves the current gas price with caching. Concurrent
+// callers missing the cache share a single RPC.
 func (g *GasOracle) GetGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 	ctx, span := g.tracer.Start(ctx, "gas.get_price")
 	defer span.End()
@@ -192,6 +220,26 @@ func (g *GasOracle) GetGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 	}
 
 	g.metrics.cacheMisses.Add(ctx, 1)
+
+	price, coalesced, err := shareCall(ctx, &g.flight, "current", g.fetchGasPrice)
+	if coalesced {
+		g.metrics.coalesced.Add(ctx, 1, metric.WithAttributes(attribute.String("call", sharedCallGasPrice)))
+		span.AddEvent("coalesced")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fetch failed")
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Float64("gwei", price.Gwei()))
+	span.SetStatus(codes.Ok, "fetched")
+
+	return price, nil
+}
+
+// fetchGasPrice fetches the gas price over RPC and caches it.
+func (g *GasOracle) fetchGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 	g.metrics.gasPriceFetches.Add(ctx, 1)
 
 	g.clientMu.RLock()
@@ -199,10 +247,8 @@ func (g *GasOracle) GetGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 	g.clientMu.RUnlock()
 
 	if client == nil {
-		err := apperror.New(apperror.CodeEthereumConnectionFailed,
+		return nil, apperror.New(apperror.CodeEthereumConnectionFailed,
 			apperror.WithContext("gas oracle not connected"))
-		span.RecordError(err)
-		return nil, err
 	}
 
 	// Fetch through circuit breaker
@@ -210,8 +256,6 @@ func (g *GasOracle) GetGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 		return client.SuggestGasPrice(ctx)
 	})
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "fetch failed")
 		return nil, apperror.New(apperror.CodeEthereumRPCError,
 			apperror.WithCause(err),
 			apperror.WithContext("failed to get gas price"))
@@ -219,7 +263,7 @@ func (g *GasOracle) GetGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 
 	// Safety check
 	if g.config.MaxGasPrice != nil && wei.Cmp(g.config.MaxGasPrice) > 0 {
-		span.AddEvent("gas_price_exceeded_max",
+		trace.SpanFromContext(ctx).AddEvent("gas_price_exceeded_max",
 			trace.WithAttributes(attribute.String("wei", wei.String())))
 		g.logger.Warn(ctx, "gas price exceeds max", "wei", wei.String())
 		wei = g.config.MaxGasPrice
@@ -230,8 +274,172 @@ func (g *GasOracle) GetGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 	// Update cache
 	g.priceCache.Set(ctx, "current", price, g.priceCacheTTL)
 
-	// Record metric
-	g.metrics.gasPriceGwei.Record(ctx, price.Gwei())
+	g.recordPrice(ctx, price)
+
+	return price, nil
+}
+
+// GetGasPriceEIP1559 retrieves the EIP-1559 max fee per gas with caching.
+// The max fee is computed as baseFee*2 + tip, which keeps the transaction
+// includable across several consecutive full blocks. baseFee is that of the
+// block being priced; when nil, the latest header's base fee is fetched.
+func (g *GasOracle) GetGasPriceEIP1559(ctx context.Context, baseFee *big.Int) (*domain.GasPrice, error) {
+	ctx, span := g.tracer.Start(ctx, "gas.get_price_eip1559")
+	defer span.End()
+
+	// Smoothed estimate from fee history when configured
+	if g.config.FeeHistoryBlocks > 0 {
+		return g.GetGasPriceFromHistory(ctx)
+	}
+
+	g.clientMu.RLock()
+	client := g.client
+	g.clientMu.RUnlock()
+
+	if baseFee == nil {
+		if client == nil {
+			err := apperror.New(apperror.CodeEthereumConnectionFailed,
+				apperror.WithContext("gas oracle not connected"))
+			span.RecordError(err)
+			return nil, err
+		}
+
+		// Fetch base fee from the latest header through circuit breaker
+		var err error
+		baseFee, err = g.cb.Execute(func() (*big.Int, error) {
+			header, err := client.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			return header.BaseFee, nil
+		})
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "fetch failed")
+			return nil, apperror.New(apperror.CodeEthereumRPCError,
+				apperror.WithCause(err),
+				apperror.WithContext("failed to get latest header"))
+		}
+	}
+
+	if baseFee == nil {
+		err := apperror.New(apperror.CodeInvalidState,
+			apperror.WithContext("chain does not support EIP-1559 (no base fee)"))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Cached per base fee, so a price is never served for another block's fee
+	cacheKey := "eip1559:" + baseFee.String()
+	if price, found := g.priceCache.Get(ctx, cacheKey); found {
+		g.metrics.cacheHits.Add(ctx, 1)
+		span.AddEvent("cache_hit")
+		return price, nil
+	}
+
+	g.metrics.cacheMisses.Add(ctx, 1)
+	g.metrics.gasPriceFetches.Add(ctx, 1)
+
+	tipCap, err := g.GetGasTipCap(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	wei := maxFeeEIP1559(baseFee, tipCap)
+
+	// Safety check
+	if g.config.MaxGasPrice != nil && wei.Cmp(g.config.MaxGasPrice) > 0 {
+		span.AddEvent("gas_price_exceeded_max",
+			trace.WithAttributes(attribute.String("wei", wei.String())))
+		g.logger.Warn(ctx, "gas price exceeds max", "wei", wei.String())
+		wei = g.config.MaxGasPrice
+	}
+
+	price := domain.NewGasPrice(wei)
+
+	// Update cache
+	g.priceCache.Set(ctx, cacheKey, price, g.priceCacheTTL)
+
+	g.recordPrice(ctx, price)
+
+	span.SetAttributes(
+		attribute.String("base_fee_wei", baseFee.String()),
+		attribute.String("tip_cap_wei", tipCap.String()),
+		attribute.Float64("gwei", price.Gwei()),
+	)
+	span.SetStatus(codes.Ok, "fetched")
+
+	return price, nil
+}
+
+// GetGasPriceFromHistory retrieves a smoothed EIP-1559 max fee using eth_feeHistory.
+// The tip is the mean of the configured reward percentile over the last
+// FeeHistoryBlocks blocks; the max fee is nextBaseFee*2 + tip.
+// Falls back to GetGasPrice when fee history is unavailable (non-1559 chains).
+func (g *GasOracle) GetGasPriceFromHistory(ctx context.Context) (*domain.GasPrice, error) {
+	ctx, span := g.tracer.Start(ctx, "gas.get_price_from_history",
+		trace.WithAttributes(
+			attribute.Int64("blocks", int64(g.config.FeeHistoryBlocks)),
+			attribute.Float64("percentile", g.config.RewardPercentile),
+		),
+	)
+	defer span.End()
+
+	// Check cache first
+	if price, found := g.priceCache.Get(ctx, "fee_history"); found {
+		g.metrics.cacheHits.Add(ctx, 1)
+		span.AddEvent("cache_hit")
+		return price, nil
+	}
+
+	g.metrics.cacheMisses.Add(ctx, 1)
+	g.metrics.gasPriceFetches.Add(ctx, 1)
+
+	g.clientMu.RLock()
+	client := g.client
+	g.clientMu.RUnlock()
+
+	if client == nil {
+		err := apperror.New(apperror.CodeEthereumConnectionFailed,
+			apperror.WithContext("gas oracle not connected"))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	blocks := g.config.FeeHistoryBlocks
+	if blocks == 0 {
+		blocks = 1
+	}
+
+	history, err := client.FeeHistory(ctx, blocks, nil, []float64{g.config.RewardPercentile})
+	if err != nil {
+		span.AddEvent("fee_history_unavailable")
+		g.logger.Debug(ctx, "fee history unavailable, using legacy gas price", "error", err)
+		return g.GetGasPrice(ctx)
+	}
+
+	wei, ok := maxFeeFromHistory(history)
+	if !ok {
+		span.AddEvent("fee_history_empty")
+		g.logger.Debug(ctx, "fee history has no base fee data, using legacy gas price")
+		return g.GetGasPrice(ctx)
+	}
+
+	// Safety check
+	if g.config.MaxGasPrice != nil && wei.Cmp(g.config.MaxGasPrice) > 0 {
+		span.AddEvent("gas_price_exceeded_max",
+			trace.WithAttributes(attribute.String("wei", wei.String())))
+		g.logger.Warn(ctx, "gas price exceeds max", "wei", wei.String())
+		wei = g.config.MaxGasPrice
+	}
+
+	price := domain.NewGasPrice(wei)
+
+	// Update cache
+	g.priceCache.Set(ctx, "fee_history", price, g.priceCacheTTL)
+
+	g.recordPrice(ctx, price)
 
 	span.SetAttributes(attribute.Float64("gwei", price.Gwei()))
 	span.SetStatus(codes.Ok, "fetched")
@@ -239,6 +447,63 @@ func (g *GasOracle) GetGasPrice(ctx context.Context) (*domain.GasPrice, error) {
 	return price, nil
 }
 
+// recordPrice records a freshly fetched price in the gauge and the ranking
+// history. Cache hits aren't recorded, so each price counts once.
+func (g *GasOracle) recordPrice(ctx context.Context, price *domain.GasPrice) {
+	g.metrics.gasPriceGwei.Record(ctx, price.Gwei())
+	if g.history != nil {
+		g.history.add(price.Gwei())
+	}
+}
+
+// PercentileRank returns where gwei falls among recently fetched gas prices
+// as a percentile (0-100), or false before any price was fetched or with
+// ranking disabled.
+func (g *GasOracle) PercentileRank(gwei float64) (float64, bool) {
+	if g.history == nil {
+		return 0, false
+	}
+	return g.history.percentileRank(gwei)
+}
+
+// maxFeeFromHistory computes nextBaseFee*2 + mean(percentile tip) from a fee history.
+// Returns false when the history carries no base fee (pre-1559 chain).
+func maxFeeFromHistory(history *ethereum.FeeHistory) (*big.Int, bool) {
+	if history == nil || len(history.BaseFee) == 0 {
+		return nil, false
+	}
+
+	// The last base fee entry is the one for the next (pending) block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	if baseFee == nil || baseFee.Sign() == 0 {
+		return nil, false
+	}
+
+	tip := new(big.Int)
+	samples := int64(0)
+	for _, rewards := range history.Reward {
+		if len(rewards) == 0 || rewards[0] == nil {
+			continue
+		}
+		tip.Add(tip, rewards[0])
+		samples++
+	}
+	if samples > 0 {
+		tip.Div(tip, big.NewInt(samples))
+	}
+
+	wei := new(big.Int).Mul(baseFee, big.NewInt(2))
+	wei.Add(wei, tip)
+
+	return wei, true
+}
+
+// maxFeeEIP1559 computes maxFeePerGas = baseFee*2 + tip.
+func maxFeeEIP1559(baseFee, tipCap *big.Int) *big.Int {
+	wei := new(big.Int).Mul(baseFee, big.NewInt(2))
+	return wei.Add(wei, tipCap)
+}
+
 // GetGasTipCap retrieves the suggested gas tip cap (EIP-1559).
 func (g *GasOracle) GetGasTipCap(ctx context.Context) (*big.Int, error) {
 	ctx, span := g.tracer.Start(ctx, "gas.get_tip_cap")
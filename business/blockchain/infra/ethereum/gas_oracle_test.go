@@ -0,0 +1,106 @@
+package ethereum
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// TestMaxFeeFromHistory tests the fee history max fee computation.
+func TestMaxFeeFromHistory(t *testing.T) {
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9)) }
+
+	tests := []struct {
+		name    string
+		history *ethereum.FeeHistory
+		want    *big.Int
+		wantOK  bool
+	}{
+		{
+			name: "mean_tip_plus_double_next_base_fee",
+			history: &ethereum.FeeHistory{
+				Reward:  [][]*big.Int{{gwei(1)}, {gwei(2)}, {gwei(3)}},
+				BaseFee: []*big.Int{gwei(10), gwei(11), gwei(12), gwei(20)},
+			},
+			want:   gwei(42), // 20*2 + (1+2+3)/3
+			wantOK: true,
+		},
+		{
+			name: "no_rewards",
+			history: &ethereum.FeeHistory{
+				BaseFee: []*big.Int{gwei(15)},
+			},
+			want:   gwei(30),
+			wantOK: true,
+		},
+		{
+			name:    "pre_1559_chain",
+			history: &ethereum.FeeHistory{BaseFee: []*big.Int{big.NewInt(0)}},
+			wantOK:  false,
+		},
+		{
+			name:    "empty_history",
+			history: &ethereum.FeeHistory{},
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := maxFeeFromHistory(tt.history)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%v, got %v", tt.wantOK, ok)
+			}
+			if ok && got.Cmp(tt.want) != 0 {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestMaxFeeEIP1559 tests the baseFee*2 + tip max fee.
+func TestMaxFeeEIP1559(t *testing.T) {
+	baseFee := big.NewInt(20_000_000_000)
+	tip := big.NewInt(1_500_000_000)
+
+	if got := maxFeeEIP1559(baseFee, tip); got.Cmp(big.NewInt(41_500_000_000)) != 0 {
+		t.Errorf("expected 41500000000, got %s", got)
+	}
+	if baseFee.Cmp(big.NewInt(20_000_000_000)) != 0 {
+		t.Errorf("expected the base fee left untouched, got %s", baseFee)
+	}
+}
+
+// TestGasOracle_GetGasPriceEIP1559BaseFee tests that a supplied base fee is
+// priced without fetching a header, and that prices are cached per base fee.
+func TestGasOracle_GetGasPriceEIP1559BaseFee(t *testing.T) {
+	g, err := NewGasOracle(DefaultGasOracleConfig(""), logger.New(io.Discard, logger.LevelError, "test", nil))
+	if err != nil {
+		t.Fatalf("NewGasOracle failed: %v", err)
+	}
+	ctx := context.Background()
+
+	cached := domain.NewGasPrice(big.NewInt(41_000_000_000))
+	g.priceCache.Set(ctx, "eip1559:20000000000", cached, g.priceCacheTTL)
+
+	// Not connected: only the cache can answer
+	got, err := g.GetGasPriceEIP1559(ctx, big.NewInt(20_000_000_000))
+	if err != nil {
+		t.Fatalf("expected the cached price for the block's base fee, got %v", err)
+	}
+	if got != cached {
+		t.Errorf("expected the cached price, got %s", got.Wei())
+	}
+
+	_, err = g.GetGasPriceEIP1559(ctx, big.NewInt(30_000_000_000))
+	if apperror.GetCode(err) != apperror.CodeEthereumConnectionFailed {
+		t.Errorf("expected another block's base fee to miss the cache, got %v", err)
+	}
+}
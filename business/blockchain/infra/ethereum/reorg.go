@@ -0,0 +1,103 @@
+package ethereum
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+)
+
+// reorgWindow is how many recent headers are kept for reorg detection.
+const reorgWindow = 64
+
+// headerRef is the part of a header needed to check chain continuity.
+type headerRef struct {
+	number uint64
+	hash   common.Hash
+	parent common.Hash
+}
+
+// headerTracker keeps a ring of recent headers and detects when a new header
+// does not extend the chain seen so far. It is not safe for concurrent use.
+type headerTracker struct {
+	recent [reorgWindow]headerRef // Indexed by number % reorgWindow
+	tip    uint64                 // Highest tracked block number (0 = empty)
+
+	// fetchParent resolves a header by hash to walk the new chain back to the
+	// common ancestor; nil or failing lookups stop the walk early.
+	fetchParent func(hash common.Hash) (*domain.Block, error)
+}
+
+// lookup returns the tracked header at number, if still in the ring.
+func (t *headerTracker) lookup(number uint64) (headerRef, bool) {
+	ref := t.recent[number%reorgWindow]
+	return ref, ref.number == number && number != 0
+}
+
+// Track records block and returns the reorg it reveals, if any. duplicate is
+// true when block was already tracked with the same hash.
+func (t *headerTracker) Track(block *domain.Block) (event *domain.ReorgEvent, duplicate bool) {
+	if ref, ok := t.lookup(block.Number); ok && ref.hash == block.Hash {
+		return nil, true
+	}
+
+	var orphaned []headerRef
+
+	// Blocks at or above the new height were on the old chain
+	if t.tip != 0 && block.Number <= t.tip {
+		for n := t.tip; n >= block.Number && n > 0; n-- {
+			if ref, ok := t.lookup(n); ok {
+				orphaned = append(orphaned, ref)
+			}
+		}
+	}
+
+	// Walk the new chain back while it disagrees with the tracked ancestors
+	number, parent := block.Number-1, block.ParentHash
+	for number > 0 {
+		ref, ok := t.lookup(number)
+		if !ok || ref.hash == parent {
+			break
+		}
+		orphaned = append(orphaned, ref)
+
+		if t.fetchParent == nil {
+			break
+		}
+		header, err := t.fetchParent(parent)
+		if err != nil {
+			break
+		}
+		number, parent = number-1, header.ParentHash
+	}
+
+	oldHead, _ := t.lookup(t.tip)
+
+	// Forget orphaned headers and record the new one
+	for _, ref := range orphaned {
+		t.recent[ref.number%reorgWindow] = headerRef{}
+	}
+	t.recent[block.Number%reorgWindow] = headerRef{number: block.Number, hash: block.Hash, parent: block.ParentHash}
+	if len(orphaned) > 0 || block.Number > t.tip {
+		t.tip = block.Number
+	}
+
+	if len(orphaned) == 0 {
+		return nil, false
+	}
+
+	// Orphans were collected highest first
+	event = &domain.ReorgEvent{
+		FromBlock:  orphaned[len(orphaned)-1].number,
+		ToBlock:    orphaned[0].number,
+		OldHead:    oldHead.hash,
+		NewHead:    block.Hash,
+		Orphaned:   make([]common.Hash, 0, len(orphaned)),
+		DetectedAt: time.Now(),
+	}
+	for i := len(orphaned) - 1; i >= 0; i-- {
+		event.Orphaned = append(event.Orphaned, orphaned[i].hash)
+	}
+	return event, false
+}
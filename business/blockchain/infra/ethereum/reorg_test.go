@@ -0,0 +1,143 @@
+package ethereum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+)
+
+// testBlock builds a synthetic block; fork distinguishes competing chains.
+func testBlock(number uint64, fork, parentFork byte) *domain.Block {
+	return &domain.Block{
+		Number:     number,
+		Hash:       common.Hash{fork, byte(number)},
+		ParentHash: common.Hash{parentFork, byte(number - 1)},
+	}
+}
+
+// TestHeaderTracker_Track tests reorg detection on synthetic header sequences.
+func TestHeaderTracker_Track(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   []*domain.Block
+		parents   map[common.Hash]*domain.Block // Headers resolvable by hash
+		wantFrom  uint64
+		wantTo    uint64
+		wantDepth int // 0 = no reorg on the last header
+		wantDup   bool
+	}{
+		{
+			name:    "linear_chain",
+			headers: []*domain.Block{testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(12, 'a', 'a')},
+		},
+		{
+			name:    "duplicate_header",
+			headers: []*domain.Block{testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(11, 'a', 'a')},
+			wantDup: true,
+		},
+		{
+			name:      "tip_replaced_at_same_height",
+			headers:   []*domain.Block{testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(11, 'b', 'a')},
+			wantFrom:  11,
+			wantTo:    11,
+			wantDepth: 1,
+		},
+		{
+			name:      "parent_mismatch",
+			headers:   []*domain.Block{testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(12, 'b', 'b')},
+			wantFrom:  11,
+			wantTo:    11,
+			wantDepth: 1,
+		},
+		{
+			name: "deep_reorg_walks_to_common_ancestor",
+			headers: []*domain.Block{
+				testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(12, 'a', 'a'), testBlock(13, 'a', 'a'),
+				testBlock(14, 'b', 'b'),
+			},
+			parents: map[common.Hash]*domain.Block{
+				{'b', 13}: testBlock(13, 'b', 'b'),
+				{'b', 12}: testBlock(12, 'b', 'a'), // Forks off a-11
+			},
+			wantFrom:  12,
+			wantTo:    13,
+			wantDepth: 2,
+		},
+		{
+			name: "shorter_competing_chain",
+			headers: []*domain.Block{
+				testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(12, 'a', 'a'),
+				testBlock(11, 'b', 'a'),
+			},
+			wantFrom:  11,
+			wantTo:    12,
+			wantDepth: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &headerTracker{
+				fetchParent: func(hash common.Hash) (*domain.Block, error) {
+					if block, ok := tt.parents[hash]; ok {
+						return block, nil
+					}
+					return nil, errors.New("not found")
+				},
+			}
+
+			var event *domain.ReorgEvent
+			var dup bool
+			for i, header := range tt.headers {
+				event, dup = tracker.Track(header)
+				if i < len(tt.headers)-1 && event != nil {
+					t.Fatalf("unexpected reorg at header %d: %+v", i, event)
+				}
+			}
+
+			if dup != tt.wantDup {
+				t.Errorf("duplicate = %v, want %v", dup, tt.wantDup)
+			}
+			if tt.wantDepth == 0 {
+				if event != nil {
+					t.Fatalf("unexpected reorg: %+v", event)
+				}
+				return
+			}
+			if event == nil {
+				t.Fatal("expected a reorg event")
+			}
+			if event.FromBlock != tt.wantFrom || event.ToBlock != tt.wantTo {
+				t.Errorf("range = %d-%d, want %d-%d", event.FromBlock, event.ToBlock, tt.wantFrom, tt.wantTo)
+			}
+			if event.Depth() != tt.wantDepth {
+				t.Errorf("Depth() = %d, want %d", event.Depth(), tt.wantDepth)
+			}
+			last := tt.headers[len(tt.headers)-1]
+			if event.NewHead != last.Hash {
+				t.Errorf("NewHead = %x, want %x", event.NewHead, last.Hash)
+			}
+			if event.Orphaned[0] != (common.Hash{'a', byte(tt.wantFrom)}) {
+				t.Errorf("Orphaned[0] = %x, want a-%d", event.Orphaned[0], tt.wantFrom)
+			}
+		})
+	}
+}
+
+// TestHeaderTracker_ContinuesAfterReorg tests that the new chain is tracked after a reorg.
+func TestHeaderTracker_ContinuesAfterReorg(t *testing.T) {
+	tracker := &headerTracker{}
+	for _, header := range []*domain.Block{testBlock(10, 'a', 'a'), testBlock(11, 'a', 'a'), testBlock(11, 'b', 'a')} {
+		tracker.Track(header)
+	}
+
+	if event, _ := tracker.Track(testBlock(12, 'b', 'b')); event != nil {
+		t.Errorf("unexpected reorg extending the new chain: %+v", event)
+	}
+	if event, _ := tracker.Track(testBlock(13, 'a', 'a')); event == nil {
+		t.Error("expected a reorg for a header building on the orphaned chain")
+	}
+}
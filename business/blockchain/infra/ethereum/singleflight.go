@@ -0,0 +1,56 @@
+package ethereum
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+)
+
+// sharedCallTimeout bounds an RPC shared by concurrent callers. The call
+// runs detached from the first caller's context, so that caller giving up
+// does not fail the others waiting on the result.
+const sharedCallTimeout = 10 * time.Second
+
+// Calls recorded on eth_rpc_coalesced_total.
+const (
+	sharedCallGasPrice    = "gas_price"
+	sharedCallLatestBlock = "latest_block"
+)
+
+// newCoalescedCounter creates the counter of callers served by another
+// caller's in-flight RPC.
+func newCoalescedCounter() (metric.Int64Counter, error) {
+	return otel.Meter(meterName).Int64Counter(
+		"eth_rpc_coalesced_total",
+		metric.WithDescription("Calls served by an RPC already in flight for another caller (by call)"),
+		metric.WithUnit("{call}"),
+	)
+}
+
+// shareCall runs fn once for all concurrent callers with the same key and
+// hands each of them the result; coalesced reports that this caller waited
+// on another caller's RPC. Each caller still returns as soon as its own ctx
+// is done, so a stuck RPC never holds callers past their deadlines. fn must
+// not call shareCall with the same key: it would wait on itself.
+func shareCall[T any](ctx context.Context, group *singleflight.Group, key string, fn func(context.Context) (T, error)) (result T, coalesced bool, err error) {
+	led := false
+	ch := group.DoChan(key, func() (any, error) {
+		led = true
+		callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedCallTimeout)
+		defer cancel()
+		return fn(callCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return result, false, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return result, !led, res.Err
+		}
+		return res.Val.(T), !led, nil
+	}
+}
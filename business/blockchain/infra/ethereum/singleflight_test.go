@@ -0,0 +1,104 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+func TestShareCall_Coalesces(t *testing.T) {
+	var group singleflight.Group
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	fetch := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	const callers = 8
+	var wg sync.WaitGroup
+	var coalescedCalls atomic.Int32
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, coalesced, err := shareCall(context.Background(), &group, "key", fetch)
+			if err != nil || got != 42 {
+				t.Errorf("shareCall() = %d, %v, want 42", got, err)
+			}
+			if coalesced {
+				coalescedCalls.Add(1)
+			}
+		}()
+	}
+
+	// Let every caller join the in-flight call before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 RPC, got %d", got)
+	}
+	if got := coalescedCalls.Load(); got != callers-1 {
+		t.Errorf("expected %d coalesced callers, got %d", callers-1, got)
+	}
+}
+
+// TestShareCall_CallerCancels tests that a caller giving up returns at once
+// without failing the shared call for the others.
+func TestShareCall_CallerCancels(t *testing.T) {
+	var group singleflight.Group
+	release := make(chan struct{})
+
+	fetch := func(ctx context.Context) (int, error) {
+		select {
+		case <-release:
+			return 42, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, _, err := shareCall(ctx, &group, "key", fetch)
+		leaderErr <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	follower := make(chan int, 1)
+	go func() {
+		got, _, _ := shareCall(context.Background(), &group, "key", fetch)
+		follower <- got
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-leaderErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancelled caller did not return")
+	}
+
+	close(release)
+	select {
+	case got := <-follower:
+		if got != 42 {
+			t.Errorf("expected follower to get 42, got %d", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("follower did not get the shared result")
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/sony/gobreaker/v2"
@@ -18,13 +20,24 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 
+	"github.com/fd1az/arbitrage-bot/business/blockchain/app"
 	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
 	"github.com/fd1az/arbitrage-bot/internal/apperror"
 	"github.com/fd1az/arbitrage-bot/internal/circuitbreaker"
+	"github.com/fd1az/arbitrage-bot/internal/health"
+	"github.com/fd1az/arbitrage-bot/internal/latency"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
 )
 
+// Ensure Subscriber implements HealthReporter, ReorgNotifier and TipSubscriber.
+var (
+	_ health.HealthReporter = (*Subscriber)(nil)
+	_ app.ReorgNotifier     = (*Subscriber)(nil)
+	_ app.TipSubscriber     = (*Subscriber)(nil)
+)
+
 const (
 	tracerName = "github.com/fd1az/arbitrage-bot/business/blockchain/infra/ethereum"
 	meterName  = "github.com/fd1az/arbitrage-bot/business/blockchain/infra/ethereum"
@@ -35,8 +48,29 @@ type SubscriberConfig struct {
 	WSURL          string        // WebSocket endpoint (primary)
 	HTTPURL        string        // HTTP endpoint (fallback)
 	PollInterval   time.Duration // Polling interval for HTTP fallback
-	ReconnectDelay time.Duration // Delay before reconnecting WS
+	ReconnectDelay time.Duration // First WS reconnect delay, doubled per consecutive failure
 	BufferSize     int           // Block channel buffer size
+	ReorgBuffer    int           // Reorg event channel buffer size
+
+	// ConfirmationDepth delays blocks on the main channel until they are
+	// this many blocks below the head (0 = emit the head immediately).
+	// The unconfirmed head is always available on the tip channel.
+	ConfirmationDepth uint64
+
+	// ChainID is the chain the endpoints must serve; Subscribe fails on a
+	// mismatch so a profile never runs against the wrong network (0 = any).
+	ChainID uint64
+
+	// MaxReconnectDelay caps the exponential reconnect backoff, and
+	// BackoffResetAfter is how long a subscription must survive for the
+	// backoff to start over from ReconnectDelay.
+	MaxReconnectDelay time.Duration
+	BackoffResetAfter time.Duration
+
+	// MaxReconnects is how many consecutive WS reconnects are tried before
+	// the subscriber gives up for good and reports on Failed (0 = infinite).
+	// The count starts over once a subscription survives BackoffResetAfter.
+	MaxReconnects int
 }
 
 // DefaultSubscriberConfig returns sensible defaults.
@@ -47,6 +81,10 @@ func DefaultSubscriberConfig(wsURL, httpURL string) SubscriberConfig {
 		PollInterval:   12 * time.Second, // ~1 block time
 		ReconnectDelay: 5 * time.Second,
 		BufferSize:     16,
+		ReorgBuffer:    4,
+
+		MaxReconnectDelay: 2 * time.Minute,
+		BackoffResetAfter: time.Minute,
 	}
 }
 
@@ -57,6 +95,9 @@ type subscriberMetrics struct {
 	connectionState  metric.Int64Gauge
 	blockLatency     metric.Float64Histogram
 	httpFallbackUsed metric.Int64Counter
+	reorgs           metric.Int64Counter
+	reconnectBackoff metric.Float64Histogram
+	coalesced        metric.Int64Counter
 }
 
 // Subscriber implements BlockSubscriber using go-ethereum client.
@@ -71,14 +112,26 @@ type Subscriber struct {
 	clientMu   sync.RWMutex
 
 	// State
-	state      domain.ConnectionState
-	stateMu    sync.RWMutex
-	usingHTTP  atomic.Bool
-	lastBlock  atomic.Uint64
-	reconnects atomic.Int32
+	state       domain.ConnectionState
+	stateMu     sync.RWMutex
+	usingHTTP   atomic.Bool
+	lastBlock   atomic.Uint64
+	lastBlockAt atomic.Int64 // UnixNano when the last block arrived
+	reconnects  atomic.Int32
+	failures    atomic.Int32 // Consecutive short-lived WS subscriptions, for backoff
+	backoff     atomic.Int64 // Pending reconnect wait in nanoseconds (0 = none)
+	uptime      health.UptimeTracker
+
+	// Reorg detection and confirmation buffering
+	headers       headerTracker
+	confirmations confirmationBuffer
+	headersMu     sync.Mutex
 
 	// Channels
 	blocks     chan *domain.Block
+	tip        chan *domain.Block
+	reorgs     chan *domain.ReorgEvent
+	failed     chan error // Receives the terminal error once the subscriber gives up
 	done       chan struct{}
 	closeMu    sync.Mutex
 	closed     atomic.Bool
@@ -87,6 +140,12 @@ type Subscriber struct {
 	wsCB   *circuitbreaker.CircuitBreaker[*types.Header]
 	httpCB *circuitbreaker.CircuitBreaker[*types.Header]
 
+	// Concurrent LatestBlock calls share one RPC
+	flight singleflight.Group
+
+	// Moving average RPC round trip (head and chain ID requests)
+	latency *latency.EWMA
+
 	// Observability
 	tracer  trace.Tracer
 	metrics *subscriberMetrics
@@ -95,13 +154,19 @@ type Subscriber struct {
 // NewSubscriber creates a new Ethereum block subscriber.
 func NewSubscriber(cfg SubscriberConfig, log logger.LoggerInterface) (*Subscriber, error) {
 	s := &Subscriber{
-		config: cfg,
-		logger: log,
-		state:  domain.StateDisconnected,
-		blocks: make(chan *domain.Block, cfg.BufferSize),
-		done:   make(chan struct{}),
-		tracer: otel.Tracer(tracerName),
-	}
+		config:  cfg,
+		logger:  log,
+		state:   domain.StateDisconnected,
+		blocks:  make(chan *domain.Block, cfg.BufferSize),
+		tip:     make(chan *domain.Block, cfg.BufferSize),
+		reorgs:  make(chan *domain.ReorgEvent, cfg.ReorgBuffer),
+		failed:  make(chan error, 1),
+		done:    make(chan struct{}),
+		latency: latency.NewEWMA(latency.DefaultAlpha),
+		tracer:  otel.Tracer(tracerName),
+	}
+	s.headers.fetchParent = s.blockByHash
+	s.confirmations.depth = cfg.ConfirmationDepth
 
 	if err := s.initMetrics(); err != nil {
 		return nil, fmt.Errorf("init metrics: %w", err)
@@ -164,6 +229,29 @@ func (s *Subscriber) initMetrics() error {
 		return err
 	}
 
+	s.metrics.reorgs, err = meter.Int64Counter(
+		"eth_reorgs_total",
+		metric.WithDescription("Chain reorganizations detected"),
+		metric.WithUnit("{reorg}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	s.metrics.reconnectBackoff, err = meter.Float64Histogram(
+		"eth_reconnect_backoff_seconds",
+		metric.WithDescription("Delay before each WebSocket reconnect attempt"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	s.metrics.coalesced, err = newCoalescedCounter()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -218,6 +306,16 @@ func (s *Subscriber) Subscribe(ctx context.Context) (<-chan *domain.Block, error
 		}
 
 		s.usingHTTP.Store(true)
+	}
+
+	if err := s.verifyChain(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "wrong chain")
+		s.setState(domain.StateDisconnected)
+		return nil, err
+	}
+
+	if s.usingHTTP.Load() {
 		go s.runHTTPPoller(ctx)
 	} else {
 		go s.runWSSubscription(ctx)
@@ -229,6 +327,22 @@ func (s *Subscriber) Subscribe(ctx context.Context) (<-chan *domain.Block, error
 	return s.blocks, nil
 }
 
+// verifyChain checks that the connected node serves the configured chain.
+func (s *Subscriber) verifyChain(ctx context.Context) error {
+	if s.config.ChainID == 0 {
+		return nil
+	}
+	chainID, err := s.GetChainID(ctx)
+	if err != nil {
+		return err
+	}
+	if !chainID.IsUint64() || chainID.Uint64() != s.config.ChainID {
+		return apperror.New(apperror.CodeInvalidChainID,
+			apperror.WithContext(fmt.Sprintf("node serves chain %s, expected %d", chainID, s.config.ChainID)))
+	}
+	return nil
+}
+
 // connectWS establishes a WebSocket connection to the Ethereum node.
 func (s *Subscriber) connectWS(ctx context.Context) error {
 	ctx, span := s.tracer.Start(ctx, "eth.connect.ws",
@@ -313,10 +427,16 @@ func (s *Subscriber) runWSSubscription(ctx context.Context) {
 		}
 
 		s.logger.Info(ctx, "subscribed to new heads via ws")
+		subscribedAt := time.Now()
 
 		// Process headers until error
 		s.processWSHeaders(ctx, headers, sub)
 
+		// A subscription that held up ends the run of failures
+		if time.Since(subscribedAt) >= s.config.BackoffResetAfter {
+			s.failures.Store(0)
+		}
+
 		// If we get here, subscription ended - try to reconnect
 		sub.Unsubscribe()
 		s.handleWSDisconnect(ctx)
@@ -356,8 +476,37 @@ func (s *Subscriber) handleWSDisconnect(ctx context.Context) {
 	s.setState(domain.StateReconnecting)
 	s.reconnects.Add(1)
 
-	// Try to reconnect WS
-	time.Sleep(s.config.ReconnectDelay)
+	// Back off exponentially while subscriptions keep dying young
+	failures := s.failures.Add(1) - 1
+	if s.config.MaxReconnects > 0 && int(failures) >= s.config.MaxReconnects {
+		s.fail(ctx, apperror.New(apperror.CodeEthereumSubscribeFailed,
+			apperror.WithContext(fmt.Sprintf("gave up after %d consecutive reconnects", failures))))
+		return
+	}
+	delay := reconnectBackoff(s.config.ReconnectDelay, s.config.MaxReconnectDelay, failures)
+	s.metrics.reconnectBackoff.Record(ctx, delay.Seconds())
+
+	_, span := s.tracer.Start(ctx, "eth.reconnect.backoff",
+		trace.WithAttributes(
+			attribute.Int("consecutive_failures", int(failures)),
+			attribute.String("backoff", delay.String()),
+		),
+	)
+	span.AddEvent("backoff", trace.WithAttributes(attribute.Int64("backoff_ms", delay.Milliseconds())))
+	span.End()
+	s.logger.Info(ctx, "reconnecting ws", "backoff", delay, "consecutive_failures", failures)
+
+	s.backoff.Store(int64(delay))
+	select {
+	case <-s.done:
+		s.backoff.Store(0)
+		return
+	case <-ctx.Done():
+		s.backoff.Store(0)
+		return
+	case <-time.After(delay):
+		s.backoff.Store(0)
+	}
 
 	if s.closed.Load() {
 		return
@@ -369,8 +518,9 @@ func (s *Subscriber) handleWSDisconnect(ctx context.Context) {
 		// Switch to HTTP fallback
 		if s.httpClient == nil {
 			if err := s.connectHTTP(ctx); err != nil {
-				s.logger.Error(ctx, "http fallback connection failed", "error", err)
-				s.setState(domain.StateDisconnected)
+				s.fail(ctx, apperror.New(apperror.CodeEthereumConnectionFailed,
+					apperror.WithCause(err),
+					apperror.WithContext("ws reconnect and http fallback both failed")))
 				return
 			}
 		}
@@ -387,6 +537,41 @@ func (s *Subscriber) handleWSDisconnect(ctx context.Context) {
 	go s.runWSSubscription(ctx)
 }
 
+// fail stops the subscriber for good: it is left disconnected and err is
+// reported on Failed for the application to decide whether to exit.
+func (s *Subscriber) fail(ctx context.Context, err error) {
+	s.logger.Error(ctx, "ethereum subscriber giving up", "error", err)
+	s.setState(domain.StateDisconnected)
+	select {
+	case s.failed <- err:
+	default:
+	}
+}
+
+// Failed returns a channel that receives an error once the subscriber has
+// given up reconnecting. No more blocks arrive after that.
+func (s *Subscriber) Failed() <-chan error {
+	return s.failed
+}
+
+// reconnectBackoff returns the delay before a reconnect after failures
+// consecutive failures: base doubled per failure and capped at max (a max
+// below base keeps the delay fixed), plus up to 50% jitter so restarted
+// bots don't reconnect in step.
+func reconnectBackoff(base, max time.Duration, failures int32) time.Duration {
+	backoff := base
+	for i := int32(0); i < failures && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max && max > base {
+		backoff = max
+	}
+	if backoff/2 > 0 {
+		backoff += time.Duration(rand.Int63n(int64(backoff / 2)))
+	}
+	return backoff
+}
+
 // runHTTPPoller runs the HTTP polling loop as fallback.
 func (s *Subscriber) runHTTPPoller(ctx context.Context) {
 	ticker := time.NewTicker(s.config.PollInterval)
@@ -422,7 +607,7 @@ func (s *Subscriber) pollLatestBlock(ctx context.Context) {
 
 	// Execute through circuit breaker
 	header, err := s.httpCB.Execute(func() (*types.Header, error) {
-		return client.HeaderByNumber(ctx, nil) // nil = latest
+		return s.timeHeader(ctx, client) // Latest
 	})
 
 	if err != nil {
@@ -454,29 +639,116 @@ func (s *Subscriber) processHeader(ctx context.Context, header *types.Header, fr
 
 	block := s.headerToBlock(header)
 
+	if s.checkReorg(ctx, block) {
+		span.AddEvent("duplicate_block")
+		return
+	}
+
 	// Calculate latency
 	latency := time.Since(block.Timestamp)
 	s.metrics.blockLatency.Record(ctx, float64(latency.Milliseconds()))
 
 	// Update last block
 	s.lastBlock.Store(block.Number)
+	s.lastBlockAt.Store(block.ReceivedAt.UnixNano())
 
-	// Emit block (non-blocking)
+	// Emit the unconfirmed head (non-blocking; skipped when nobody reads it).
+	// Sent as a copy since confirmation buffering updates the original.
+	head := *block
 	select {
-	case s.blocks <- block:
-		s.metrics.blocksReceived.Add(ctx, 1)
-		s.logger.Debug(ctx, "block received",
-			"number", block.Number,
-			"hash", block.Hash.Hex()[:10],
-			"latency_ms", latency.Milliseconds())
+	case s.tip <- &head:
 	default:
-		span.AddEvent("block_dropped_buffer_full")
-		s.logger.Warn(ctx, "block dropped, buffer full", "number", block.Number)
+		span.AddEvent("tip_dropped_buffer_full")
+	}
+
+	s.headersMu.Lock()
+	confirmed := s.confirmations.Push(block)
+	s.headersMu.Unlock()
+
+	// Emit blocks that reached the confirmation depth (non-blocking)
+	for _, block := range confirmed {
+		select {
+		case s.blocks <- block:
+			s.metrics.blocksReceived.Add(ctx, 1)
+			s.logger.Debug(ctx, "block received",
+				"number", block.Number,
+				"hash", block.Hash.Hex()[:10],
+				"confirmations", block.Confirmations,
+				"latency_ms", latency.Milliseconds())
+		default:
+			span.AddEvent("block_dropped_buffer_full")
+			s.logger.Warn(ctx, "block dropped, buffer full", "number", block.Number)
+		}
 	}
 
 	span.SetStatus(codes.Ok, "processed")
 }
 
+// checkReorg tracks block for continuity with the recent chain and emits a
+// ReorgEvent when it replaces previously seen blocks. It reports whether block
+// was already seen (a re-delivered header).
+func (s *Subscriber) checkReorg(ctx context.Context, block *domain.Block) bool {
+	s.headersMu.Lock()
+	event, duplicate := s.headers.Track(block)
+	if event != nil {
+		s.confirmations.Drop(event.Orphaned)
+	}
+	s.headersMu.Unlock()
+
+	if event == nil {
+		return duplicate
+	}
+
+	s.metrics.reorgs.Add(ctx, 1, metric.WithAttributes(attribute.Int("depth", event.Depth())))
+	s.logger.Warn(ctx, "chain reorganization detected",
+		"from_block", event.FromBlock,
+		"to_block", event.ToBlock,
+		"depth", event.Depth(),
+		"old_head", event.OldHead.Hex(),
+		"new_head", event.NewHead.Hex())
+
+	// Emit reorg (non-blocking, like blocks)
+	select {
+	case s.reorgs <- event:
+	default:
+		s.logger.Warn(ctx, "reorg event dropped, buffer full", "from_block", event.FromBlock)
+	}
+	return false
+}
+
+// blockByHash fetches a header by hash (used to walk back to a reorg's common ancestor).
+func (s *Subscriber) blockByHash(hash common.Hash) (*domain.Block, error) {
+	s.clientMu.RLock()
+	client := s.wsClient
+	if client == nil || s.usingHTTP.Load() {
+		client = s.httpClient
+	}
+	s.clientMu.RUnlock()
+
+	if client == nil {
+		return nil, errors.New("no client available")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	header, err := client.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return s.headerToBlock(header), nil
+}
+
+// Tip returns the channel of unconfirmed head blocks, emitted as soon as they
+// arrive regardless of ConfirmationDepth. Blocks are dropped when it is full.
+func (s *Subscriber) Tip() <-chan *domain.Block {
+	return s.tip
+}
+
+// Reorgs returns the channel of detected chain reorganizations.
+func (s *Subscriber) Reorgs() <-chan *domain.ReorgEvent {
+	return s.reorgs
+}
+
 // headerToBlock converts an Ethereum header to domain Block.
 func (s *Subscriber) headerToBlock(header *types.Header) *domain.Block {
 	return &domain.Block{
@@ -487,14 +759,33 @@ func (s *Subscriber) headerToBlock(header *types.Header) *domain.Block {
 		GasLimit:   header.GasLimit,
 		GasUsed:    header.GasUsed,
 		BaseFee:    header.BaseFee,
+		ReceivedAt: time.Now(),
 	}
 }
 
-// LatestBlock retrieves the most recent block.
+// LatestBlock retrieves the most recent block. Concurrent callers share a
+// single RPC.
 func (s *Subscriber) LatestBlock(ctx context.Context) (*domain.Block, error) {
 	ctx, span := s.tracer.Start(ctx, "eth.latest_block")
 	defer span.End()
 
+	block, coalesced, err := shareCall(ctx, &s.flight, sharedCallLatestBlock, s.fetchLatestBlock)
+	if coalesced {
+		s.metrics.coalesced.Add(ctx, 1, metric.WithAttributes(attribute.String("call", sharedCallLatestBlock)))
+		span.AddEvent("coalesced")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fetch failed")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "fetched")
+	return block, nil
+}
+
+// fetchLatestBlock fetches the head header, trying WS first, then HTTP.
+func (s *Subscriber) fetchLatestBlock(ctx context.Context) (*domain.Block, error) {
 	// Try WS client first, then HTTP
 	s.clientMu.RLock()
 	wsClient := s.wsClient
@@ -506,35 +797,48 @@ func (s *Subscriber) LatestBlock(ctx context.Context) (*domain.Block, error) {
 
 	if wsClient != nil && !s.usingHTTP.Load() {
 		header, err = s.wsCB.Execute(func() (*types.Header, error) {
-			return wsClient.HeaderByNumber(ctx, nil)
+			return s.timeHeader(ctx, wsClient)
 		})
 	}
 
 	if header == nil && httpClient != nil {
 		header, err = s.httpCB.Execute(func() (*types.Header, error) {
-			return httpClient.HeaderByNumber(ctx, nil)
+			return s.timeHeader(ctx, httpClient)
 		})
 	}
 
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "fetch failed")
 		return nil, apperror.New(apperror.CodeBlockNotFound,
 			apperror.WithCause(err),
 			apperror.WithContext("failed to fetch latest block"))
 	}
 
 	if header == nil {
-		err := errors.New("no client available")
-		span.RecordError(err)
 		return nil, apperror.New(apperror.CodeEthereumConnectionFailed,
+			apperror.WithCause(errors.New("no client available")),
 			apperror.WithContext("no ethereum client connected"))
 	}
 
-	span.SetStatus(codes.Ok, "fetched")
 	return s.headerToBlock(header), nil
 }
 
+// timeHeader fetches the latest header, recording the round trip when it
+// succeeds.
+func (s *Subscriber) timeHeader(ctx context.Context, client *ethclient.Client) (*types.Header, error) {
+	start := time.Now()
+	header, err := client.HeaderByNumber(ctx, nil) // nil = latest
+	if err == nil {
+		s.latency.Since(start)
+	}
+	return header, err
+}
+
+// Latency returns the moving average RPC round trip, or 0 before the
+// first successful request.
+func (s *Subscriber) Latency() time.Duration {
+	return s.latency.Value()
+}
+
 // State returns the current connection state.
 func (s *Subscriber) State() domain.ConnectionState {
 	s.stateMu.RLock()
@@ -553,6 +857,30 @@ func (s *Subscriber) Status() domain.ConnectionStatus {
 	}
 }
 
+// ConnectionStats reports the session's uptime, reconnects and the backoff
+// before the pending reconnect. Time on the HTTP fallback counts as up.
+func (s *Subscriber) ConnectionStats() health.ConnectionStats {
+	return health.ConnectionStats{
+		ConnectedSince: s.uptime.ConnectedSince(),
+		UptimePct:      s.uptime.UptimePct(time.Now()),
+		Reconnects:     int(s.reconnects.Load()),
+		Backoff:        time.Duration(s.backoff.Load()),
+	}
+}
+
+// Health reports the connection state and when the last block arrived.
+func (s *Subscriber) Health() health.SourceHealth {
+	state := s.State()
+	h := health.SourceHealth{
+		State:     string(state),
+		Connected: state == domain.StateConnected,
+	}
+	if ts := s.lastBlockAt.Load(); ts > 0 {
+		h.LastMessage = time.Unix(0, ts)
+	}
+	return h
+}
+
 // Close gracefully closes the subscriber.
 func (s *Subscriber) Close() error {
 	s.closeMu.Lock()
@@ -579,6 +907,8 @@ func (s *Subscriber) Close() error {
 	s.clientMu.Unlock()
 
 	close(s.blocks)
+	close(s.tip)
+	close(s.reorgs)
 	s.setState(domain.StateDisconnected)
 
 	return nil
@@ -589,6 +919,7 @@ func (s *Subscriber) setState(state domain.ConnectionState) {
 	s.stateMu.Lock()
 	s.state = state
 	s.stateMu.Unlock()
+	s.uptime.SetConnected(state == domain.StateConnected, time.Now())
 
 	stateValue := int64(0)
 	switch state {
@@ -632,6 +963,7 @@ func (s *Subscriber) GetChainID(ctx context.Context) (*big.Int, error) {
 			apperror.WithContext("no ethereum client connected"))
 	}
 
+	start := time.Now()
 	chainID, err := client.ChainID(ctx)
 	if err != nil {
 		span.RecordError(err)
@@ -639,6 +971,7 @@ func (s *Subscriber) GetChainID(ctx context.Context) (*big.Int, error) {
 			apperror.WithCause(err),
 			apperror.WithContext("failed to get chain id"))
 	}
+	s.latency.Since(start)
 
 	span.SetStatus(codes.Ok, "fetched")
 	return chainID, nil
@@ -0,0 +1,63 @@
+package ethereum
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/blockchain/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+func TestReconnectBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     time.Duration
+		max      time.Duration
+		failures int32
+		want     time.Duration // Before jitter
+	}{
+		{name: "first_attempt", base: time.Second, max: 30 * time.Second, failures: 0, want: time.Second},
+		{name: "doubles", base: time.Second, max: 30 * time.Second, failures: 3, want: 8 * time.Second},
+		{name: "capped", base: time.Second, max: 30 * time.Second, failures: 10, want: 30 * time.Second},
+		{name: "many_failures", base: time.Second, max: 30 * time.Second, failures: 1 << 20, want: 30 * time.Second},
+		{name: "max_below_base_is_fixed", base: 5 * time.Second, max: 0, failures: 4, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reconnectBackoff(tt.base, tt.max, tt.failures)
+			if got < tt.want || got >= tt.want+tt.want/2 {
+				t.Errorf("reconnectBackoff() = %s, want [%s, %s)", got, tt.want, tt.want+tt.want/2)
+			}
+		})
+	}
+}
+
+// TestSubscriber_MaxReconnects tests that the subscriber gives up once
+// MaxReconnects consecutive reconnects have failed.
+func TestSubscriber_MaxReconnects(t *testing.T) {
+	cfg := DefaultSubscriberConfig("", "")
+	cfg.MaxReconnects = 3
+	s, err := NewSubscriber(cfg, logger.New(io.Discard, logger.LevelError, "test", nil))
+	if err != nil {
+		t.Fatalf("NewSubscriber() error = %v", err)
+	}
+	s.failures.Store(3)
+
+	s.handleWSDisconnect(context.Background())
+
+	select {
+	case err := <-s.Failed():
+		if apperror.GetCode(err) != apperror.CodeEthereumSubscribeFailed {
+			t.Errorf("Failed() error = %v, want %s", err, apperror.CodeEthereumSubscribeFailed)
+		}
+	default:
+		t.Fatal("expected the subscriber to report giving up")
+	}
+	if got := s.State(); got != domain.StateDisconnected {
+		t.Errorf("State() = %s, want %s", got, domain.StateDisconnected)
+	}
+}
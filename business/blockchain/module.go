@@ -9,6 +9,7 @@ import (
 	"github.com/fd1az/arbitrage-bot/business/blockchain/infra/ethereum"
 	"github.com/fd1az/arbitrage-bot/internal/config"
 	"github.com/fd1az/arbitrage-bot/internal/di"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
 	"github.com/fd1az/arbitrage-bot/internal/monolith"
 )
@@ -24,6 +25,13 @@ func (m *Module) RegisterServices(c di.Container) error {
 		log := sr.Get("logger").(logger.LoggerInterface)
 
 		subCfg := ethereum.DefaultSubscriberConfig(cfg.Ethereum.WebSocketURL, cfg.Ethereum.HTTPURL)
+		subCfg.ConfirmationDepth = cfg.Ethereum.ConfirmationDepth
+		subCfg.ChainID = cfg.Ethereum.ChainID
+		subCfg.PollInterval = cfg.Ethereum.BlockTime
+		subCfg.ReconnectDelay = cfg.Ethereum.InitialBackoff
+		subCfg.MaxReconnectDelay = cfg.Ethereum.MaxBackoff
+		subCfg.BackoffResetAfter = cfg.Ethereum.BackoffResetAfter
+		subCfg.MaxReconnects = cfg.Ethereum.MaxReconnects
 		sub, err := ethereum.NewSubscriber(subCfg, log)
 		if err != nil {
 			panic("failed to create subscriber: " + err.Error())
@@ -37,6 +45,9 @@ func (m *Module) RegisterServices(c di.Container) error {
 		log := sr.Get("logger").(logger.LoggerInterface)
 
 		oracleCfg := ethereum.DefaultGasOracleConfig(cfg.Ethereum.HTTPURL)
+		oracleCfg.FeeHistoryBlocks = cfg.Ethereum.FeeHistoryBlocks
+		oracleCfg.RewardPercentile = cfg.Ethereum.RewardPercentile
+		oracleCfg.CacheTTL = cfg.Ethereum.BlockTime
 		oracle, err := ethereum.NewGasOracle(oracleCfg, log)
 		if err != nil {
 			panic("failed to create gas oracle: " + err.Error())
@@ -77,6 +88,11 @@ func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) error {
 		}
 	}
 
+	// Block feed staleness is critical: without blocks nothing gets scanned
+	if reporter, ok := sub.(health.HealthReporter); ok && mono.Health() != nil {
+		mono.Health().RegisterReporter("ethereum", reporter, true)
+	}
+
 	log.Info(ctx, "blockchain module started")
 	return nil
 }
@@ -0,0 +1,282 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/shopspring/decimal"
+	"github.com/sony/gobreaker/v2"
+)
+
+// Ensure aggregating providers implement their ports.
+var (
+	_ CEXProvider    = (*AggregatingCEXProvider)(nil)
+	_ DEXProvider    = (*AggregatingDEXProvider)(nil)
+	_ BlockObserver  = (*AggregatingDEXProvider)(nil)
+	_ BookInspector  = (*AggregatingCEXProvider)(nil)
+	_ Subscriber     = (*AggregatingCEXProvider)(nil)
+	_ QuoteInspector = (*AggregatingDEXProvider)(nil)
+)
+
+// Venue is a named CEX provider participating in aggregation.
+type Venue struct {
+	Name     string // Venue identifier (e.g., "binance", "coinbase")
+	Provider CEXProvider
+}
+
+// AggregatingCEXProvider fans out requests to several CEX venues and returns
+// the best price across them. A venue that errors is skipped as long as at
+// least one other venue answers.
+type AggregatingCEXProvider struct {
+	venues []Venue
+}
+
+// NewAggregatingCEXProvider creates a provider that aggregates the given venues.
+func NewAggregatingCEXProvider(venues ...Venue) *AggregatingCEXProvider {
+	return &AggregatingCEXProvider{venues: venues}
+}
+
+// Venues returns the aggregated venues.
+func (a *AggregatingCEXProvider) Venues() []Venue {
+	return a.venues
+}
+
+// BookSnapshots returns the live books of every venue that keeps them.
+func (a *AggregatingCEXProvider) BookSnapshots(depth int) []BookSnapshot {
+	var books []BookSnapshot
+	for _, v := range a.venues {
+		if bi, ok := v.Provider.(BookInspector); ok {
+			books = append(books, bi.BookSnapshots(depth)...)
+		}
+	}
+	return books
+}
+
+// venueResult holds the outcome of a single venue call.
+type venueResult[T any] struct {
+	venue string
+	value T
+	err   error
+}
+
+// fanOut calls fn for every venue index concurrently and returns results in venue order.
+func fanOut[T any](names []string, fn func(i int) (T, error)) []venueResult[T] {
+	results := make([]venueResult[T], len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			value, err := fn(i)
+			results[i] = venueResult[T]{venue: name, value: value, err: err}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// venueNames returns the names of the CEX venues.
+func (a *AggregatingCEXProvider) venueNames() []string {
+	names := make([]string, len(a.venues))
+	for i, v := range a.venues {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// GetEffectivePrice returns the best effective price across venues:
+// the lowest price for buys and the highest price for sells.
+// The returned price's Source is set to the winning venue.
+func (a *AggregatingCEXProvider) GetEffectivePrice(ctx context.Context, pair domain.Pair, size decimal.Decimal, side domain.Side) (*domain.Price, error) {
+	results := fanOut(a.venueNames(), func(i int) (*domain.Price, error) {
+		return a.venues[i].Provider.GetEffectivePrice(ctx, pair, size, side)
+	})
+
+	var (
+		best *domain.Price
+		errs []error
+	)
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.venue, r.err))
+			continue
+		}
+
+		price := *r.value
+		price.Source = r.venue
+
+		if best == nil || isBetterPrice(price.Rate.Rate(), best.Rate.Rate(), side) {
+			best = &price
+		}
+	}
+
+	if best == nil {
+		return nil, apperror.New(apperror.CodeOrderbookFetchFailed,
+			apperror.WithCause(errors.Join(errs...)),
+			apperror.WithContext(fmt.Sprintf("no CEX venue returned a %s price for %s", side, pair)))
+	}
+
+	return best, nil
+}
+
+// Subscribes reports whether any venue prices pair. Venues that don't report
+// their subscriptions are assumed to.
+func (a *AggregatingCEXProvider) Subscribes(pair domain.Pair) bool {
+	for _, v := range a.venues {
+		if s, ok := v.Provider.(Subscriber); !ok || s.Subscribes(pair) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetOrderbook returns a consolidated orderbook merging levels from all venues.
+func (a *AggregatingCEXProvider) GetOrderbook(ctx context.Context, pair domain.Pair) (*domain.Orderbook, error) {
+	results := fanOut(a.venueNames(), func(i int) (*domain.Orderbook, error) {
+		return a.venues[i].Provider.GetOrderbook(ctx, pair)
+	})
+
+	merged := &domain.Orderbook{Pair: pair}
+	var errs []error
+	ok := false
+
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.venue, r.err))
+			continue
+		}
+		ok = true
+
+		merged.Bids = append(merged.Bids, r.value.Bids...)
+		merged.Asks = append(merged.Asks, r.value.Asks...)
+		if r.value.Timestamp.After(merged.Timestamp) {
+			merged.Timestamp = r.value.Timestamp
+		}
+	}
+
+	if !ok {
+		return nil, apperror.New(apperror.CodeOrderbookFetchFailed,
+			apperror.WithCause(errors.Join(errs...)),
+			apperror.WithContext(fmt.Sprintf("no CEX venue returned an orderbook for %s", pair)))
+	}
+
+	// Best levels first: highest bids, lowest asks
+	sort.SliceStable(merged.Bids, func(i, j int) bool {
+		return merged.Bids[i].Price.GreaterThan(merged.Bids[j].Price)
+	})
+	sort.SliceStable(merged.Asks, func(i, j int) bool {
+		return merged.Asks[i].Price.LessThan(merged.Asks[j].Price)
+	})
+
+	return merged, nil
+}
+
+// isBetterPrice reports whether candidate beats current for the given side.
+func isBetterPrice(candidate, current decimal.Decimal, side domain.Side) bool {
+	if side == domain.SideBuy {
+		return candidate.LessThan(current)
+	}
+	return candidate.GreaterThan(current)
+}
+
+// DEXVenue is a named DEX provider participating in aggregation.
+type DEXVenue struct {
+	Name     string // Protocol identifier (e.g., "uniswap_v3", "uniswap_v2")
+	Provider DEXProvider
+}
+
+// AggregatingDEXProvider fans out quotes to several DEX protocols and returns
+// the quote with the highest output. A protocol that errors (e.g., no pool)
+// is skipped as long as another one answers.
+type AggregatingDEXProvider struct {
+	venues []DEXVenue
+}
+
+// NewAggregatingDEXProvider creates a provider that aggregates the given DEX venues.
+func NewAggregatingDEXProvider(venues ...DEXVenue) *AggregatingDEXProvider {
+	return &AggregatingDEXProvider{venues: venues}
+}
+
+// GetQuote returns the best quote (highest AmountOut) across DEX venues.
+func (a *AggregatingDEXProvider) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*domain.Quote, error) {
+	names := make([]string, len(a.venues))
+	for i, v := range a.venues {
+		names[i] = v.Name
+	}
+
+	results := fanOut(names, func(i int) (*domain.Quote, error) {
+		return a.venues[i].Provider.GetQuote(ctx, tokenIn, tokenOut, amountIn)
+	})
+
+	var (
+		best *domain.Quote
+		errs []error
+	)
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.venue, r.err))
+			continue
+		}
+		if best == nil || r.value.AmountOut.Raw().Cmp(best.AmountOut.Raw()) > 0 {
+			best = r.value
+		}
+	}
+
+	if best == nil {
+		return nil, apperror.New(apperror.CodeUniswapQuoteFailed,
+			apperror.WithCause(errors.Join(errs...)),
+			apperror.WithContext("no DEX venue returned a quote"))
+	}
+
+	return best, nil
+}
+
+// CircuitState returns the healthiest circuit state across DEX venues:
+// the aggregate is only open when every venue's breaker is open.
+// Venues without a circuit breaker count as closed.
+func (a *AggregatingDEXProvider) CircuitState() gobreaker.State {
+	state := gobreaker.StateOpen
+	for _, v := range a.venues {
+		cs, ok := v.Provider.(CircuitStater)
+		if !ok {
+			return gobreaker.StateClosed
+		}
+		switch cs.CircuitState() {
+		case gobreaker.StateClosed:
+			return gobreaker.StateClosed
+		case gobreaker.StateHalfOpen:
+			state = gobreaker.StateHalfOpen
+		}
+	}
+	return state
+}
+
+// OnNewBlock forwards a new chain head to every venue that observes blocks.
+func (a *AggregatingDEXProvider) OnNewBlock(blockNumber uint64) {
+	for _, v := range a.venues {
+		if bo, ok := v.Provider.(BlockObserver); ok {
+			bo.OnNewBlock(blockNumber)
+		}
+	}
+}
+
+// LastQuotes returns the last quotes of every venue that remembers them.
+func (a *AggregatingDEXProvider) LastQuotes() []*domain.Quote {
+	var quotes []*domain.Quote
+	for _, v := range a.venues {
+		if qi, ok := v.Provider.(QuoteInspector); ok {
+			quotes = append(quotes, qi.LastQuotes()...)
+		}
+	}
+	return quotes
+}
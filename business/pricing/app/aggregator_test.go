@@ -0,0 +1,297 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/shopspring/decimal"
+	"github.com/sony/gobreaker/v2"
+)
+
+// fakeCEX is a CEXProvider returning fixed prices per side.
+type fakeCEX struct {
+	bid decimal.Decimal
+	ask decimal.Decimal
+	err error
+}
+
+func (f *fakeCEX) GetOrderbook(ctx context.Context, pair domain.Pair) (*domain.Orderbook, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.Orderbook{
+		Pair: pair,
+		Bids: []domain.OrderbookLevel{{Price: f.bid}},
+		Asks: []domain.OrderbookLevel{{Price: f.ask}},
+	}, nil
+}
+
+func (f *fakeCEX) GetEffectivePrice(ctx context.Context, pair domain.Pair, size decimal.Decimal, side domain.Side) (*domain.Price, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	rate := f.bid
+	if side == domain.SideBuy {
+		rate = f.ask
+	}
+	price := domain.NewPrice(asset.NewPriceNow(pair.Base, pair.Quote, rate), asset.Amount{}, side, "fake")
+	return &price, nil
+}
+
+var ethUSDC = domain.Pair{Base: asset.ETH, Quote: asset.USDC}
+
+// TestAggregatingCEXProvider_GetEffectivePrice tests best-price selection across venues.
+func TestAggregatingCEXProvider_GetEffectivePrice(t *testing.T) {
+	binance := &fakeCEX{bid: decimal.NewFromInt(3000), ask: decimal.NewFromInt(3002)}
+	coinbase := &fakeCEX{bid: decimal.NewFromInt(3001), ask: decimal.NewFromInt(3003)}
+	down := &fakeCEX{err: errors.New("connection refused")}
+
+	tests := []struct {
+		name      string
+		venues    []Venue
+		side      domain.Side
+		wantRate  string
+		wantVenue string
+		wantErr   bool
+	}{
+		{
+			name:      "best_ask_is_lowest",
+			venues:    []Venue{{"binance", binance}, {"coinbase", coinbase}},
+			side:      domain.SideBuy,
+			wantRate:  "3002",
+			wantVenue: "binance",
+		},
+		{
+			name:      "best_bid_is_highest",
+			venues:    []Venue{{"binance", binance}, {"coinbase", coinbase}},
+			side:      domain.SideSell,
+			wantRate:  "3001",
+			wantVenue: "coinbase",
+		},
+		{
+			name:      "failing_venue_is_skipped",
+			venues:    []Venue{{"binance", down}, {"coinbase", coinbase}},
+			side:      domain.SideBuy,
+			wantRate:  "3003",
+			wantVenue: "coinbase",
+		},
+		{
+			name:    "all_venues_fail",
+			venues:  []Venue{{"binance", down}, {"coinbase", down}},
+			side:    domain.SideBuy,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := NewAggregatingCEXProvider(tt.venues...)
+
+			price, err := agg.GetEffectivePrice(context.Background(), ethUSDC, decimal.NewFromInt(1), tt.side)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want := decimal.RequireFromString(tt.wantRate); !price.Rate.Rate().Equal(want) {
+				t.Errorf("expected rate %s, got %s", want, price.Rate.Rate())
+			}
+			if price.Source != tt.wantVenue {
+				t.Errorf("expected venue %s, got %s", tt.wantVenue, price.Source)
+			}
+		})
+	}
+}
+
+// TestAggregatingCEXProvider_GetOrderbook tests that levels are merged best-first.
+func TestAggregatingCEXProvider_GetOrderbook(t *testing.T) {
+	agg := NewAggregatingCEXProvider(
+		Venue{"binance", &fakeCEX{bid: decimal.NewFromInt(3000), ask: decimal.NewFromInt(3002)}},
+		Venue{"coinbase", &fakeCEX{bid: decimal.NewFromInt(3001), ask: decimal.NewFromInt(3003)}},
+		Venue{"kraken", &fakeCEX{err: errors.New("timeout")}},
+	)
+
+	ob, err := agg.GetOrderbook(context.Background(), ethUSDC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ob.Bids) != 2 || len(ob.Asks) != 2 {
+		t.Fatalf("expected 2 bids and 2 asks, got %d and %d", len(ob.Bids), len(ob.Asks))
+	}
+	if !ob.BestBid().Price.Equal(decimal.NewFromInt(3001)) {
+		t.Errorf("expected best bid 3001, got %s", ob.BestBid().Price)
+	}
+	if !ob.BestAsk().Price.Equal(decimal.NewFromInt(3002)) {
+		t.Errorf("expected best ask 3002, got %s", ob.BestAsk().Price)
+	}
+}
+
+// fakeDEX is a DEXProvider returning a fixed output amount.
+type fakeDEX struct {
+	protocol  string
+	amountOut int64
+	err       error
+}
+
+func (f *fakeDEX) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*domain.Quote, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	quote := domain.NewQuote(asset.ETH, asset.USDC,
+		asset.NewAmount(asset.ETH, amountIn), asset.NewAmount(asset.USDC, big.NewInt(f.amountOut)), 0, 3000)
+	quote.Protocol = f.protocol
+	return &quote, nil
+}
+
+// TestAggregatingDEXProvider_GetQuote tests best-quote selection across DEX protocols.
+// subscribedCEX is a fakeCEX that only subscribes the listed pairs.
+type subscribedCEX struct {
+	fakeCEX
+	pairs []domain.Pair
+}
+
+func (f *subscribedCEX) Subscribes(pair domain.Pair) bool {
+	return slices.Contains(f.pairs, pair)
+}
+
+func TestAggregatingCEXProvider_Subscribes(t *testing.T) {
+	wbtcUSDC := domain.NewPair(asset.WBTC, asset.USDC)
+	agg := NewAggregatingCEXProvider(
+		Venue{"binance", &subscribedCEX{pairs: []domain.Pair{wbtcUSDC}}},
+		Venue{"coinbase", &subscribedCEX{}},
+	)
+	if !agg.Subscribes(wbtcUSDC) || agg.Subscribes(ethUSDC) {
+		t.Error("expected only the pair a venue subscribed")
+	}
+
+	agg = NewAggregatingCEXProvider(Venue{"custom", &fakeCEX{}})
+	if !agg.Subscribes(ethUSDC) {
+		t.Error("expected a venue that doesn't report subscriptions assumed to cover the pair")
+	}
+}
+
+func TestAggregatingDEXProvider_GetQuote(t *testing.T) {
+	v3 := &fakeDEX{protocol: domain.ProtocolUniswapV3, amountOut: 3_000_000_000}
+	v2 := &fakeDEX{protocol: domain.ProtocolUniswapV2, amountOut: 3_001_000_000}
+	noPool := &fakeDEX{err: errors.New("no pool")}
+
+	tests := []struct {
+		name         string
+		venues       []DEXVenue
+		wantProtocol string
+		wantErr      bool
+	}{
+		{
+			name:         "highest_output_wins",
+			venues:       []DEXVenue{{"uniswap_v3", v3}, {"uniswap_v2", v2}},
+			wantProtocol: domain.ProtocolUniswapV2,
+		},
+		{
+			name:         "missing_pool_is_skipped",
+			venues:       []DEXVenue{{"uniswap_v3", v3}, {"uniswap_v2", noPool}},
+			wantProtocol: domain.ProtocolUniswapV3,
+		},
+		{
+			name:    "all_protocols_fail",
+			venues:  []DEXVenue{{"uniswap_v3", noPool}, {"uniswap_v2", noPool}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := NewAggregatingDEXProvider(tt.venues...)
+
+			quote, err := agg.GetQuote(context.Background(), asset.AddrWETHEthereum, asset.AddrUSDCEthereum, big.NewInt(1e18))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if quote.Protocol != tt.wantProtocol {
+				t.Errorf("expected protocol %s, got %s", tt.wantProtocol, quote.Protocol)
+			}
+		})
+	}
+}
+
+// breakerDEX is a fakeDEX that also reports a circuit breaker state.
+type breakerDEX struct {
+	fakeDEX
+	state gobreaker.State
+}
+
+func (b *breakerDEX) CircuitState() gobreaker.State {
+	return b.state
+}
+
+// TestAggregatingDEXProvider_CircuitState tests that the aggregate is only open when every venue is.
+func TestAggregatingDEXProvider_CircuitState(t *testing.T) {
+	open := &breakerDEX{state: gobreaker.StateOpen}
+	halfOpen := &breakerDEX{state: gobreaker.StateHalfOpen}
+	closed := &breakerDEX{state: gobreaker.StateClosed}
+
+	tests := []struct {
+		name      string
+		venues    []DEXVenue
+		want      gobreaker.State
+		available bool
+	}{
+		{name: "one_closed", venues: []DEXVenue{{"uniswap_v3", open}, {"uniswap_v2", closed}}, want: gobreaker.StateClosed, available: true},
+		{name: "half_open_beats_open", venues: []DEXVenue{{"uniswap_v3", open}, {"uniswap_v2", halfOpen}}, want: gobreaker.StateHalfOpen, available: true},
+		{name: "all_open", venues: []DEXVenue{{"uniswap_v3", open}, {"uniswap_v2", open}}, want: gobreaker.StateOpen},
+		{name: "no_breaker_counts_closed", venues: []DEXVenue{{"uniswap_v3", open}, {"other", &fakeDEX{}}}, want: gobreaker.StateClosed, available: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg := NewAggregatingDEXProvider(tt.venues...)
+
+			if got := agg.CircuitState(); got != tt.want {
+				t.Errorf("expected state %s, got %s", tt.want, got)
+			}
+			if got := NewPricingService(&fakeCEX{}, agg).DEXAvailable(); got != tt.available {
+				t.Errorf("expected DEXAvailable %v, got %v", tt.available, got)
+			}
+		})
+	}
+}
+
+// blockDEX is a fakeDEX that records the blocks it observes.
+type blockDEX struct {
+	fakeDEX
+	block uint64
+}
+
+func (b *blockDEX) OnNewBlock(blockNumber uint64) {
+	b.block = blockNumber
+}
+
+// TestAggregatingDEXProvider_OnNewBlock tests that new blocks reach every observing venue.
+func TestAggregatingDEXProvider_OnNewBlock(t *testing.T) {
+	v3, v2 := &blockDEX{}, &blockDEX{}
+	agg := NewAggregatingDEXProvider(DEXVenue{"uniswap_v3", v3}, DEXVenue{"other", &fakeDEX{}}, DEXVenue{"uniswap_v2", v2})
+
+	NewPricingService(&fakeCEX{}, agg).OnNewBlock(42)
+
+	if v3.block != 42 || v2.block != 42 {
+		t.Errorf("expected both venues at block 42, got %d and %d", v3.block, v2.block)
+	}
+}
@@ -4,10 +4,12 @@ package app
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
 	"github.com/shopspring/decimal"
+	"github.com/sony/gobreaker/v2"
 )
 
 // CEXProvider defines the interface for centralized exchange price providers.
@@ -25,3 +27,84 @@ type DEXProvider interface {
 	// GetQuote retrieves a price quote for swapping tokens on a DEX.
 	GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*domain.Quote, error)
 }
+
+// QuoteRequest is one swap to quote in a batch.
+type QuoteRequest struct {
+	TokenIn  common.Address
+	TokenOut common.Address
+	AmountIn *big.Int
+}
+
+// BatchQuote is the outcome of one QuoteRequest; exactly one field is set.
+type BatchQuote struct {
+	Quote *domain.Quote
+	Err   error
+}
+
+// BatchQuoter is implemented by DEX providers that can quote many swaps in a
+// single upstream round trip.
+type BatchQuoter interface {
+	// GetQuotesBatch returns one result per request, in request order. An
+	// individual failed quote is reported in its BatchQuote; the error is
+	// reserved for failures of the batch as a whole.
+	GetQuotesBatch(ctx context.Context, requests []QuoteRequest) ([]BatchQuote, error)
+}
+
+// FeeRateSource reports the account's actual trading fee rates on a venue,
+// available only when account credentials are configured.
+type FeeRateSource interface {
+	// FeeRates returns the maker and taker rates for a venue symbol (e.g., "ETHUSDC").
+	FeeRates(ctx context.Context, symbol string) (domain.FeeRates, error)
+}
+
+// BlockObserver is implemented by providers that keep per-block state, such
+// as quotes cached until the chain advances.
+type BlockObserver interface {
+	// OnNewBlock reports that blockNumber is the new chain head.
+	OnNewBlock(blockNumber uint64)
+}
+
+// CircuitStater is implemented by providers that guard their upstream calls
+// with a circuit breaker, so callers can tell a degraded venue from a quiet one.
+type CircuitStater interface {
+	// CircuitState returns the current circuit breaker state.
+	CircuitState() gobreaker.State
+}
+
+// LatencyReporter is implemented by providers that measure the round trip
+// to their upstream.
+type LatencyReporter interface {
+	// Latency returns the moving average round trip, or 0 before the first
+	// measurement.
+	Latency() time.Duration
+}
+
+// BookSnapshot is a copy of the top of a live orderbook held by a CEX provider.
+type BookSnapshot struct {
+	Venue      string
+	Symbol     string // Venue symbol (e.g., "ETHUSDC")
+	Bids       []domain.OrderbookLevel
+	Asks       []domain.OrderbookLevel
+	LastUpdate time.Time // Zero until the first update arrives
+}
+
+// BookInspector is implemented by CEX providers that maintain live books,
+// exposing them for troubleshooting.
+type BookInspector interface {
+	// BookSnapshots returns up to depth levels per side of every book, by symbol.
+	BookSnapshots(depth int) []BookSnapshot
+}
+
+// Subscriber is implemented by CEX providers that only price the books they
+// subscribed to, so callers can check a pair is covered up front.
+type Subscriber interface {
+	// Subscribes reports whether pair's book is subscribed.
+	Subscribes(pair domain.Pair) bool
+}
+
+// QuoteInspector is implemented by DEX providers that remember the last
+// quote returned for each token pair.
+type QuoteInspector interface {
+	// LastQuotes returns copies of the most recent quote per pair.
+	LastQuotes() []*domain.Quote
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// CEXFactory creates a CEX provider for a registered venue.
+type CEXFactory func() (CEXProvider, error)
+
+// DEXFactory creates a DEX provider for a registered protocol.
+type DEXFactory func() (DEXProvider, error)
+
+// ProviderRegistry maps provider names (e.g., "binance", "uniswap_v3") to the
+// factories that build them, so the set of venues is chosen by configuration.
+// Several names resolve to an aggregating provider over all of them.
+type ProviderRegistry struct {
+	mu  sync.RWMutex
+	cex map[string]CEXFactory
+	dex map[string]DEXFactory
+}
+
+// NewProviderRegistry creates an empty registry.
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{
+		cex: make(map[string]CEXFactory),
+		dex: make(map[string]DEXFactory),
+	}
+}
+
+// RegisterCEX registers a CEX provider factory, replacing any with the same name.
+func (r *ProviderRegistry) RegisterCEX(name string, factory CEXFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cex[name] = factory
+}
+
+// RegisterDEX registers a DEX provider factory, replacing any with the same name.
+func (r *ProviderRegistry) RegisterDEX(name string, factory DEXFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dex[name] = factory
+}
+
+// CEXNames returns the registered CEX provider names, sorted.
+func (r *ProviderRegistry) CEXNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedKeys(r.cex)
+}
+
+// DEXNames returns the registered DEX provider names, sorted.
+func (r *ProviderRegistry) DEXNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sortedKeys(r.dex)
+}
+
+// Validate checks that the selected providers are non-empty and registered.
+func (r *ProviderRegistry) Validate(cexNames, dexNames []string) error {
+	if err := validateNames("cex", cexNames, r.CEXNames()); err != nil {
+		return err
+	}
+	return validateNames("dex", dexNames, r.DEXNames())
+}
+
+// NewCEX builds the CEX provider for the given names: the venue itself for a
+// single name, or an aggregating provider across all of them.
+func (r *ProviderRegistry) NewCEX(names []string) (CEXProvider, error) {
+	if err := validateNames("cex", names, r.CEXNames()); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	venues := make([]Venue, 0, len(names))
+	for _, name := range names {
+		provider, err := r.cex[name]()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cex provider %s: %w", name, err)
+		}
+		venues = append(venues, Venue{Name: name, Provider: provider})
+	}
+	if len(venues) == 1 {
+		return venues[0].Provider, nil
+	}
+	return NewAggregatingCEXProvider(venues...), nil
+}
+
+// NewDEX builds the DEX provider for the given names: the protocol itself for
+// a single name, or an aggregating provider keeping the best quote.
+func (r *ProviderRegistry) NewDEX(names []string) (DEXProvider, error) {
+	if err := validateNames("dex", names, r.DEXNames()); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	venues := make([]DEXVenue, 0, len(names))
+	for _, name := range names {
+		provider, err := r.dex[name]()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dex provider %s: %w", name, err)
+		}
+		venues = append(venues, DEXVenue{Name: name, Provider: provider})
+	}
+	if len(venues) == 1 {
+		return venues[0].Provider, nil
+	}
+	return NewAggregatingDEXProvider(venues...), nil
+}
+
+// validateNames checks names against the registered ones for a provider kind.
+func validateNames(kind string, names, registered []string) error {
+	if len(names) == 0 {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("no %s providers selected", kind)))
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("duplicate %s provider: %s", kind, name)))
+		}
+		seen[name] = true
+
+		if !slices.Contains(registered, name) {
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("unknown %s provider: %s (registered: %s)",
+					kind, name, strings.Join(registered, ", "))))
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+func newTestRegistry() *ProviderRegistry {
+	r := NewProviderRegistry()
+	r.RegisterCEX("binance", func() (CEXProvider, error) { return &fakeCEX{}, nil })
+	r.RegisterCEX("coinbase", func() (CEXProvider, error) { return &fakeCEX{}, nil })
+	r.RegisterCEX("broken", func() (CEXProvider, error) { return nil, errors.New("no credentials") })
+	r.RegisterDEX("uniswap_v3", func() (DEXProvider, error) { return &fakeDEX{}, nil })
+	return r
+}
+
+// TestProviderRegistry_Validate tests that selected names must be registered.
+func TestProviderRegistry_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cex     []string
+		dex     []string
+		wantErr bool
+	}{
+		{name: "registered", cex: []string{"binance", "coinbase"}, dex: []string{"uniswap_v3"}},
+		{name: "unknown_cex", cex: []string{"kraken"}, dex: []string{"uniswap_v3"}, wantErr: true},
+		{name: "unknown_dex", cex: []string{"binance"}, dex: []string{"sushiswap"}, wantErr: true},
+		{name: "duplicate", cex: []string{"binance", "binance"}, dex: []string{"uniswap_v3"}, wantErr: true},
+		{name: "empty_dex", cex: []string{"binance"}, wantErr: true},
+	}
+
+	registry := newTestRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Validate(tt.cex, tt.dex)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && apperror.GetCode(err) != apperror.CodeConfigurationError {
+				t.Errorf("expected configuration error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestProviderRegistry_NewCEX tests single, aggregated and failing construction.
+func TestProviderRegistry_NewCEX(t *testing.T) {
+	registry := newTestRegistry()
+
+	single, err := registry.NewCEX([]string{"binance"})
+	if err != nil {
+		t.Fatalf("NewCEX(single) failed: %v", err)
+	}
+	if _, ok := single.(*fakeCEX); !ok {
+		t.Errorf("single venue should not be wrapped, got %T", single)
+	}
+
+	multi, err := registry.NewCEX([]string{"binance", "coinbase"})
+	if err != nil {
+		t.Fatalf("NewCEX(multi) failed: %v", err)
+	}
+	agg, ok := multi.(*AggregatingCEXProvider)
+	if !ok || len(agg.Venues()) != 2 || agg.Venues()[1].Name != "coinbase" {
+		t.Errorf("expected aggregation over binance and coinbase, got %T", multi)
+	}
+
+	if _, err := registry.NewCEX([]string{"binance", "broken"}); err == nil {
+		t.Error("expected factory error to propagate")
+	}
+}
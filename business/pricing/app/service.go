@@ -3,19 +3,26 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
 	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 	"github.com/shopspring/decimal"
+	"github.com/sony/gobreaker/v2"
 )
 
 // PricingService coordinates price fetching from CEX and DEX providers.
 type PricingService struct {
-	cex CEXProvider
-	dex DEXProvider
+	cex     CEXProvider
+	dex     DEXProvider
+	stables *StableEquivalents // nil = CEX prices in the pair's quote only
+
+	cexVenues []string // Venue names behind cex, for status reporting
 }
 
 // NewPricingService creates a new PricingService with the given providers.
@@ -26,47 +33,290 @@ func NewPricingService(cex CEXProvider, dex DEXProvider) *PricingService {
 	}
 }
 
-// GetPriceSnapshot retrieves current prices from both CEX and DEX for comparison.
+// SetStableEquivalents lets snapshots price the CEX side from a book quoted
+// in an equivalent stable when the pair's own quote isn't preferred or
+// available (nil = the pair's quote only).
+func (s *PricingService) SetStableEquivalents(stables *StableEquivalents) {
+	s.stables = stables
+}
+
+// SetCEXVenues names the venues behind the CEX provider (e.g. "binance"),
+// in the order they were configured.
+func (s *PricingService) SetCEXVenues(names []string) {
+	s.cexVenues = names
+}
+
+// CEXVenues returns the venue names set by SetCEXVenues.
+func (s *PricingService) CEXVenues() []string {
+	return s.cexVenues
+}
+
+// CEXName returns the name the CEX feed is reported under, e.g. "Coinbase".
+func (s *PricingService) CEXName() string {
+	return CEXDisplayName(s.cexVenues)
+}
+
+// StableEquivalents returns the stables treated as fungible, or nil.
+func (s *PricingService) StableEquivalents() *StableEquivalents {
+	return s.stables
+}
+
+// GetPriceSnapshot retrieves current prices from both CEX and DEX for
+// comparison. The DEX is quoted in both directions: selling the trade size
+// of base, and buying it back with the quote asset. A failed buy quote only
+// leaves DEXBuyQuote nil.
 func (s *PricingService) GetPriceSnapshot(ctx context.Context, pair domain.Pair, tradeSize decimal.Decimal) (*domain.PriceSnapshot, error) {
+	if err := checkTradeSize(pair, tradeSize); err != nil {
+		return nil, err
+	}
+	snapshot, err := s.cexSnapshot(ctx, pair, tradeSize)
+	if err != nil {
+		if !probesDEX(err) {
+			return nil, err
+		}
+		req, reqErr := dexQuoteRequest(pair, tradeSize)
+		if reqErr != nil {
+			return nil, reqErr
+		}
+		_, dexErr := s.dex.GetQuote(ctx, req.TokenIn, req.TokenOut, req.AmountIn)
+		return nil, noLiquidity(pair, err, dexErr)
+	}
+
+	req, err := dexQuoteRequest(pair, tradeSize)
+	if err != nil {
+		return nil, err
+	}
+	dexQuote, err := s.dex.GetQuote(ctx, req.TokenIn, req.TokenOut, req.AmountIn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DEX quote: %w", err)
+	}
+	snapshot.DEXQuote = dexQuote
+
+	buy, err := dexBuyQuoteRequest(pair, tradeSize, snapshot.CEXAsk.Rate.Rate())
+	if err != nil {
+		return nil, err
+	}
+	if buyQuote, err := s.dex.GetQuote(ctx, buy.TokenIn, buy.TokenOut, buy.AmountIn); err == nil {
+		snapshot.DEXBuyQuote = buyQuote
+	}
+
+	return snapshot, nil
+}
+
+// GetPriceSnapshotWithQuote prices the CEX side afresh against DEX quotes
+// already fetched (buy may be nil), so spreads can be re-evaluated between
+// blocks without another quoter call.
+func (s *PricingService) GetPriceSnapshotWithQuote(ctx context.Context, pair domain.Pair, tradeSize decimal.Decimal, sell, buy *domain.Quote) (*domain.PriceSnapshot, error) {
+	snapshot, err := s.cexSnapshot(ctx, pair, tradeSize)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.DEXQuote = sell
+	snapshot.DEXBuyQuote = buy
+	return snapshot, nil
+}
+
+// SnapshotRequest identifies a pair and trade size to price.
+type SnapshotRequest struct {
+	Pair      domain.Pair
+	TradeSize decimal.Decimal
+}
+
+// GetPriceSnapshots prices several pair/size combinations. When the DEX
+// provider is a BatchQuoter all DEX quotes, in both directions, are fetched
+// in one round trip. Results are in request order; a failed request has a
+// nil snapshot and a non-nil error at its index.
+func (s *PricingService) GetPriceSnapshots(ctx context.Context, requests []SnapshotRequest) ([]*domain.PriceSnapshot, []error) {
+	snapshots := make([]*domain.PriceSnapshot, len(requests))
+	errs := make([]error, len(requests))
+
+	batcher, ok := s.dex.(BatchQuoter)
+	if !ok {
+		for i, req := range requests {
+			snapshots[i], errs[i] = s.GetPriceSnapshot(ctx, req.Pair, req.TradeSize)
+		}
+		return snapshots, errs
+	}
+
+	// The CEX side comes first: the buy quote spends what the trade size
+	// costs at the CEX ask. Each priced request adds a sell and a buy quote;
+	// one the CEX couldn't price adds a sell quote to tell whether any venue
+	// has liquidity.
+	cexSnapshots := make([]*domain.PriceSnapshot, len(requests))
+	probed := make([]bool, len(requests))
+	quoteReqs := make([]QuoteRequest, 0, 2*len(requests))
+	for i, req := range requests {
+		if err := checkTradeSize(req.Pair, req.TradeSize); err != nil {
+			errs[i] = err
+			continue
+		}
+		sell, err := dexQuoteRequest(req.Pair, req.TradeSize)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		snapshot, err := s.cexSnapshot(ctx, req.Pair, req.TradeSize)
+		if err != nil {
+			errs[i] = err
+			if probesDEX(err) {
+				probed[i] = true
+				quoteReqs = append(quoteReqs, sell)
+			}
+			continue
+		}
+		buy, err := dexBuyQuoteRequest(req.Pair, req.TradeSize, snapshot.CEXAsk.Rate.Rate())
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		cexSnapshots[i] = snapshot
+		quoteReqs = append(quoteReqs, sell, buy)
+	}
+	if len(quoteReqs) == 0 {
+		return snapshots, errs
+	}
+
+	quotes, err := batcher.GetQuotesBatch(ctx, quoteReqs)
+	if err != nil {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = fmt.Errorf("failed to get DEX quote: %w", err)
+			}
+		}
+		return snapshots, errs
+	}
+
+	next := 0
+	for i, snapshot := range cexSnapshots {
+		if probed[i] {
+			errs[i] = noLiquidity(requests[i].Pair, errs[i], quotes[next].Err)
+			next++
+			continue
+		}
+		if snapshot == nil {
+			continue
+		}
+		sell, buy := quotes[next], quotes[next+1]
+		next += 2
+
+		if sell.Err != nil {
+			errs[i] = fmt.Errorf("failed to get DEX quote: %w", sell.Err)
+			continue
+		}
+		snapshot.DEXQuote = sell.Quote
+		snapshot.DEXBuyQuote = buy.Quote // nil if the buy quote failed
+		snapshots[i] = snapshot
+	}
+	return snapshots, errs
+}
+
+// probesDEX reports whether a CEX failure warrants checking the DEX for
+// liquidity. A book too thin for the size still has liquidity.
+func probesDEX(cexErr error) bool {
+	var fillErr *domain.PartialFillError
+	return !errors.As(cexErr, &fillErr)
+}
+
+// noLiquidity returns a *domain.NoLiquidityError when the DEX failed too,
+// otherwise the CEX error alone.
+func noLiquidity(pair domain.Pair, cexErr, dexErr error) error {
+	if dexErr == nil {
+		return cexErr
+	}
+	return &domain.NoLiquidityError{Pair: pair, CEXErr: cexErr, DEXErr: dexErr}
+}
+
+// cexSnapshot starts a snapshot with the CEX bid and ask for the trade size.
+// With stable equivalents the books quoted in each equivalent stable are
+// tried in turn, and the first that prices both sides is normalized into the
+// pair's quote; otherwise the error for the first is returned.
+func (s *PricingService) cexSnapshot(ctx context.Context, pair domain.Pair, tradeSize decimal.Decimal) (*domain.PriceSnapshot, error) {
+	var firstErr error
+	for _, quote := range s.stables.quotes(pair) {
+		snapshot, err := s.cexSnapshotIn(ctx, pair, quote, tradeSize)
+		if err == nil {
+			return snapshot, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// cexSnapshotIn prices the CEX side of pair from the book quoted in quote.
+func (s *PricingService) cexSnapshotIn(ctx context.Context, pair domain.Pair, quote *asset.Asset, tradeSize decimal.Decimal) (*domain.PriceSnapshot, error) {
 	snapshot := &domain.PriceSnapshot{
 		Pair:      pair,
 		Timestamp: time.Now(),
 	}
+	cexPair := domain.NewPair(pair.Base, quote)
 
 	// Get CEX prices (bid and ask for the trade size)
-	cexBid, err := s.cex.GetEffectivePrice(ctx, pair, tradeSize, domain.SideSell)
+	cexBid, err := s.cex.GetEffectivePrice(ctx, cexPair, tradeSize, domain.SideSell)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CEX bid: %w", err)
 	}
-	snapshot.CEXBid = cexBid
-
-	cexAsk, err := s.cex.GetEffectivePrice(ctx, pair, tradeSize, domain.SideBuy)
+	cexAsk, err := s.cex.GetEffectivePrice(ctx, cexPair, tradeSize, domain.SideBuy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CEX ask: %w", err)
 	}
+
+	if quote != pair.Quote {
+		cexBid = s.stables.normalize(cexBid, pair, quote)
+		cexAsk = s.stables.normalize(cexAsk, pair, quote)
+		snapshot.CEXQuote = quote
+	}
+	snapshot.CEXBid = cexBid
 	snapshot.CEXAsk = cexAsk
+	snapshot.CEXVenue = cexAsk.Source
 
-	// Get DEX quote
-	// Convert trade size to raw amount (considering base asset decimals)
-	amountIn := toRawAmount(pair.Base, tradeSize)
+	return snapshot, nil
+}
 
-	// For DEX, convert native ETH to WETH (Uniswap uses WETH)
-	tokenIn := pair.Base.Address()
-	tokenOut := pair.Quote.Address()
-	if pair.Base.IsNative() {
-		tokenIn = asset.AddrWETHEthereum // Use WETH for native ETH
+// checkTradeSize rejects a trade size finer than the base asset's decimals
+// (e.g. 0.000000001 WBTC, which has 8), rather than quote a truncated amount.
+func checkTradeSize(pair domain.Pair, tradeSize decimal.Decimal) error {
+	if _, err := asset.ParseDecimal(pair.Base, tradeSize); err != nil {
+		return fmt.Errorf("invalid trade size %s %s: %w", tradeSize, pair.Base.Symbol(), err)
 	}
-	if pair.Quote.IsNative() {
-		tokenOut = asset.AddrWETHEthereum
+	return nil
+}
+
+// dexQuoteRequest builds the DEX quote request selling tradeSize of the pair's base.
+func dexQuoteRequest(pair domain.Pair, tradeSize decimal.Decimal) (QuoteRequest, error) {
+	// Convert trade size to raw amount (considering base asset decimals)
+	amountIn, err := toRawAmount(pair.Base, tradeSize)
+	if err != nil {
+		return QuoteRequest{}, fmt.Errorf("invalid DEX sell amount %s %s: %w", tradeSize, pair.Base.Symbol(), err)
 	}
 
-	dexQuote, err := s.dex.GetQuote(ctx, tokenIn, tokenOut, amountIn)
+	// Pools trade the wrapped token in place of native ETH
+	tokenIn := asset.DEXAsset(pair.Base).Address()
+	tokenOut := asset.DEXAsset(pair.Quote).Address()
+
+	return QuoteRequest{TokenIn: tokenIn, TokenOut: tokenOut, AmountIn: amountIn}, nil
+}
+
+// dexBuyQuoteRequest builds the DEX quote request buying back about
+// tradeSize of the pair's base: it spends tradeSize at the CEX ask in the
+// quote asset, so the output lands near the trade size.
+func dexBuyQuoteRequest(pair domain.Pair, tradeSize, cexAsk decimal.Decimal) (QuoteRequest, error) {
+	spend := tradeSize.Mul(cexAsk)
+	amountIn, err := toRawAmount(pair.Quote, spend)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DEX quote: %w", err)
+		return QuoteRequest{}, fmt.Errorf("invalid DEX buy amount %s %s: %w", spend, pair.Quote.Symbol(), err)
 	}
-	snapshot.DEXQuote = dexQuote
 
-	return snapshot, nil
+	tokenIn := asset.DEXAsset(pair.Quote).Address()
+	tokenOut := asset.DEXAsset(pair.Base).Address()
+
+	return QuoteRequest{TokenIn: tokenIn, TokenOut: tokenOut, AmountIn: amountIn}, nil
+}
+
+// GetCEXPrice retrieves the effective CEX price for a trade size and side.
+func (s *PricingService) GetCEXPrice(ctx context.Context, pair domain.Pair, size decimal.Decimal, side domain.Side) (*domain.Price, error) {
+	return s.cex.GetEffectivePrice(ctx, pair, size, side)
 }
 
 // GetCEXOrderbook retrieves the current orderbook from CEX.
@@ -74,11 +324,82 @@ func (s *PricingService) GetCEXOrderbook(ctx context.Context, pair domain.Pair)
 	return s.cex.GetOrderbook(ctx, pair)
 }
 
-// toRawAmount converts a decimal amount to raw (wei-like) representation.
-func toRawAmount(a *asset.Asset, amount decimal.Decimal) *big.Int {
-	// Multiply by 10^decimals
-	multiplier := decimal.NewFromInt(10).Pow(decimal.NewFromInt(int64(a.Decimals())))
-	raw := amount.Mul(multiplier)
-	result := raw.BigInt()
-	return result
+// DEXAvailable reports whether the DEX provider is accepting requests.
+// It is false while the provider's circuit breaker is open; providers
+// without a circuit breaker are always considered available.
+func (s *PricingService) DEXAvailable() bool {
+	if cs, ok := s.dex.(CircuitStater); ok {
+		return cs.CircuitState() != gobreaker.StateOpen
+	}
+	return true
+}
+
+// CEXConnected reports whether the CEX provider's feed is connected.
+// Providers that don't report their health are considered connected.
+func (s *PricingService) CEXConnected() bool {
+	if hr, ok := s.cex.(health.HealthReporter); ok {
+		return hr.Health().Connected
+	}
+	return true
+}
+
+// CEXLatency returns the CEX provider's measured round trip, or 0 when it
+// doesn't measure one.
+func (s *PricingService) CEXLatency() time.Duration {
+	if lr, ok := s.cex.(LatencyReporter); ok {
+		return lr.Latency()
+	}
+	return 0
+}
+
+// CEXDisplayName names a set of CEX venues for display: the venue's display
+// name, the venues joined when aggregated ("Binance + Coinbase"), or "CEX"
+// when none are named.
+func CEXDisplayName(venues []string) string {
+	if len(venues) == 0 {
+		return "CEX"
+	}
+	names := make([]string, len(venues))
+	for i, venue := range venues {
+		names[i] = domain.VenueDisplayName(venue)
+	}
+	return strings.Join(names, " + ")
+}
+
+// CEXConnectionStats returns how stable each CEX venue's feed has been this
+// session, keyed by venue display name. Venues that don't track it are left
+// out.
+func (s *PricingService) CEXConnectionStats() map[string]health.ConnectionStats {
+	stats := make(map[string]health.ConnectionStats)
+	if agg, ok := s.cex.(*AggregatingCEXProvider); ok {
+		for _, venue := range agg.Venues() {
+			if sr, ok := venue.Provider.(health.ConnectionStatsReporter); ok {
+				stats[domain.VenueDisplayName(venue.Name)] = sr.ConnectionStats()
+			}
+		}
+		return stats
+	}
+	if sr, ok := s.cex.(health.ConnectionStatsReporter); ok {
+		stats[s.CEXName()] = sr.ConnectionStats()
+	}
+	return stats
+}
+
+// OnNewBlock forwards a new chain head to the DEX provider if it keeps
+// per-block state.
+func (s *PricingService) OnNewBlock(blockNumber uint64) {
+	if bo, ok := s.dex.(BlockObserver); ok {
+		bo.OnNewBlock(blockNumber)
+	}
+}
+
+// toRawAmount converts a decimal amount to the asset's smallest unit (wei
+// for ETH, satoshis for WBTC), truncating precision beyond its decimals. A
+// negative amount fails with asset.ErrNegativeAmount.
+func toRawAmount(a *asset.Asset, amount decimal.Decimal) (*big.Int, error) {
+	parsed, err := asset.ParseDecimal(a, amount.Truncate(int32(a.Decimals())))
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Raw(), nil
 }
@@ -0,0 +1,356 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/health"
+)
+
+// batchDEX is a fakeDEX that also quotes in batches, failing requests for failAmount.
+type batchDEX struct {
+	fakeDEX
+	failAmount *big.Int
+	batches    int
+	singles    int
+}
+
+func (b *batchDEX) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*domain.Quote, error) {
+	b.singles++
+	return b.fakeDEX.GetQuote(ctx, tokenIn, tokenOut, amountIn)
+}
+
+func (b *batchDEX) GetQuotesBatch(ctx context.Context, requests []QuoteRequest) ([]BatchQuote, error) {
+	b.batches++
+	if b.err != nil {
+		return nil, b.err
+	}
+	results := make([]BatchQuote, len(requests))
+	for i, req := range requests {
+		if b.failAmount != nil && req.AmountIn.Cmp(b.failAmount) == 0 {
+			results[i] = BatchQuote{Err: errors.New("no pool")}
+			continue
+		}
+		results[i].Quote, _ = b.fakeDEX.GetQuote(ctx, req.TokenIn, req.TokenOut, req.AmountIn)
+	}
+	return results, nil
+}
+
+// poolDEX quotes a WETH/USDC pool at a mid price of 3000 USDC with a 0.30%
+// fee, in whichever direction is asked.
+type poolDEX struct{}
+
+func (poolDEX) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*domain.Quote, error) {
+	mid, afterFee := decimal.NewFromInt(3000), decimal.RequireFromString("0.997")
+
+	sold, bought := asset.WETH, asset.USDC
+	if tokenIn != asset.WETH.Address() {
+		sold, bought = asset.USDC, asset.WETH
+	}
+	in := asset.NewAmount(sold, amountIn)
+	outDecimal := in.ToDecimal().Mul(afterFee).Mul(mid)
+	if sold == asset.USDC {
+		outDecimal = in.ToDecimal().Mul(afterFee).Div(mid)
+	}
+
+	out, err := asset.ParseDecimal(bought, outDecimal)
+	if err != nil {
+		return nil, err
+	}
+	quote := domain.NewQuote(sold, bought, in, out, 0, 3000)
+	return &quote, nil
+}
+
+// TestPricingService_GetPriceSnapshot_BothDirections tests that the DEX is
+// quoted selling and buying base, and that the buy side costs more.
+func TestPricingService_GetPriceSnapshot_BothDirections(t *testing.T) {
+	cex := &fakeCEX{bid: decimal.NewFromInt(3000), ask: decimal.NewFromInt(3000)}
+	svc := NewPricingService(cex, poolDEX{})
+
+	snapshot, err := svc.GetPriceSnapshot(context.Background(), ethUSDC, decimal.NewFromInt(2))
+	if err != nil {
+		t.Fatalf("GetPriceSnapshot failed: %v", err)
+	}
+	if snapshot.DEXBuyQuote == nil {
+		t.Fatal("expected a buy quote")
+	}
+
+	sell, buy := snapshot.DEXQuote, snapshot.DEXBuyQuote
+	if sell.TokenIn != asset.WETH || buy.TokenIn != asset.USDC {
+		t.Fatalf("expected WETH→USDC and USDC→WETH, got %s→%s and %s→%s",
+			sell.TokenIn.Symbol(), sell.TokenOut.Symbol(), buy.TokenIn.Symbol(), buy.TokenOut.Symbol())
+	}
+
+	// 2 ETH at the 3000 ask is 6000 USDC in, 1.994 WETH out
+	if got := buy.AmountIn.ToDecimal(); !got.Equal(decimal.NewFromInt(6000)) {
+		t.Errorf("buy amount in = %s, want 6000", got)
+	}
+	if got := buy.AmountOut.ToDecimal(); !got.Equal(decimal.RequireFromString("1.994")) {
+		t.Errorf("buy amount out = %s, want 1.994", got)
+	}
+
+	// Selling fetches 2991 per ETH; buying costs about 3009
+	sellPrice, buyPrice := sell.Price.Rate(), buy.InverseRate()
+	if !sellPrice.Equal(decimal.NewFromInt(2991)) {
+		t.Errorf("sell price = %s, want 2991", sellPrice)
+	}
+	if got := buyPrice.Round(2); !got.Equal(decimal.RequireFromString("3009.03")) {
+		t.Errorf("buy price = %s, want 3009.03", got)
+	}
+}
+
+// TestPricingService_GetPriceSnapshots tests batched DEX quoting and per-request failures.
+func TestPricingService_GetPriceSnapshots(t *testing.T) {
+	cex := &fakeCEX{bid: decimal.NewFromInt(3000), ask: decimal.NewFromInt(3002)}
+	requests := []SnapshotRequest{
+		{Pair: ethUSDC, TradeSize: decimal.NewFromInt(1)},
+		{Pair: ethUSDC, TradeSize: decimal.NewFromInt(10)},
+		{Pair: ethUSDC, TradeSize: decimal.NewFromInt(100)},
+	}
+	tenETH, _ := toRawAmount(ethUSDC.Base, decimal.NewFromInt(10))
+
+	tests := []struct {
+		name        string
+		dex         *batchDEX
+		wantErrs    []bool
+		wantBatches int
+	}{
+		{
+			name:        "single_round_trip",
+			dex:         &batchDEX{fakeDEX: fakeDEX{amountOut: 3_000_000_000}},
+			wantErrs:    []bool{false, false, false},
+			wantBatches: 1,
+		},
+		{
+			name:        "failed_sub_call_is_isolated",
+			dex:         &batchDEX{fakeDEX: fakeDEX{amountOut: 3_000_000_000}, failAmount: tenETH},
+			wantErrs:    []bool{false, true, false},
+			wantBatches: 1,
+		},
+		{
+			name:        "batch_failure_fails_all",
+			dex:         &batchDEX{fakeDEX: fakeDEX{err: errors.New("rpc down")}},
+			wantErrs:    []bool{true, true, true},
+			wantBatches: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPricingService(cex, tt.dex)
+			snapshots, errs := svc.GetPriceSnapshots(context.Background(), requests)
+
+			if tt.dex.batches != tt.wantBatches || tt.dex.singles != 0 {
+				t.Errorf("batches = %d, singles = %d; want %d batches and no single quotes",
+					tt.dex.batches, tt.dex.singles, tt.wantBatches)
+			}
+			for i, wantErr := range tt.wantErrs {
+				if (errs[i] != nil) != wantErr {
+					t.Errorf("request %d: error = %v, wantErr %v", i, errs[i], wantErr)
+				}
+				if !wantErr && (snapshots[i] == nil || snapshots[i].DEXQuote == nil || snapshots[i].DEXBuyQuote == nil || snapshots[i].CEXBid == nil) {
+					t.Errorf("request %d: incomplete snapshot %+v", i, snapshots[i])
+				}
+			}
+		})
+	}
+}
+
+// TestPricingService_GetPriceSnapshots_Fallback tests per-request quoting when
+// the DEX provider cannot batch.
+func TestPricingService_GetPriceSnapshots_Fallback(t *testing.T) {
+	cex := &fakeCEX{bid: decimal.NewFromInt(3000), ask: decimal.NewFromInt(3002)}
+	svc := NewPricingService(cex, &fakeDEX{amountOut: 3_000_000_000})
+
+	snapshots, errs := svc.GetPriceSnapshots(context.Background(), []SnapshotRequest{
+		{Pair: ethUSDC, TradeSize: decimal.NewFromInt(1)},
+		{Pair: ethUSDC, TradeSize: decimal.NewFromInt(10)},
+	})
+	for i := range snapshots {
+		if errs[i] != nil || snapshots[i] == nil || snapshots[i].DEXQuote == nil {
+			t.Errorf("request %d: snapshot %+v, error %v", i, snapshots[i], errs[i])
+		}
+	}
+}
+
+// TestPricingService_NoLiquidity tests that a pair neither the CEX nor the
+// DEX can price fails with a NoLiquidityError, in single and batched calls.
+func TestPricingService_NoLiquidity(t *testing.T) {
+	emptyBook := &fakeCEX{err: errors.New("no liquidity")}
+	oneETH := decimal.NewFromInt(1)
+
+	_, err := NewPricingService(emptyBook, &fakeDEX{err: errors.New("no pool")}).GetPriceSnapshot(context.Background(), ethUSDC, oneETH)
+	var noLiquidity *domain.NoLiquidityError
+	if !errors.As(err, &noLiquidity) {
+		t.Fatalf("expected a NoLiquidityError, got %v", err)
+	}
+
+	_, err = NewPricingService(emptyBook, &fakeDEX{amountOut: 3_000_000_000}).GetPriceSnapshot(context.Background(), ethUSDC, oneETH)
+	if err == nil || errors.As(err, &noLiquidity) {
+		t.Fatalf("expected the CEX error alone while the DEX quotes, got %v", err)
+	}
+
+	rawOneETH, _ := toRawAmount(ethUSDC.Base, oneETH)
+	dex := &batchDEX{fakeDEX: fakeDEX{amountOut: 3_000_000_000}, failAmount: rawOneETH}
+	_, errs := NewPricingService(emptyBook, dex).GetPriceSnapshots(context.Background(), []SnapshotRequest{
+		{Pair: ethUSDC, TradeSize: oneETH},
+		{Pair: ethUSDC, TradeSize: decimal.NewFromInt(10)},
+	})
+	if !errors.As(errs[0], &noLiquidity) {
+		t.Errorf("expected a NoLiquidityError where the DEX fails too, got %v", errs[0])
+	}
+	if errs[1] == nil || errors.As(errs[1], &noLiquidity) {
+		t.Errorf("expected the CEX error alone where the DEX quotes, got %v", errs[1])
+	}
+	if dex.batches != 1 {
+		t.Errorf("expected the DEX probed in one batch, got %d", dex.batches)
+	}
+}
+
+// TestDexQuoteRequests_BaseDecimals tests that quote amounts are scaled by
+// each asset's own decimals, not ETH's 18.
+func TestDexQuoteRequests_BaseDecimals(t *testing.T) {
+	tests := []struct {
+		name     string
+		pair     domain.Pair
+		size     string
+		cexAsk   string
+		wantSell string // Raw base sold
+		wantBuy  string // Raw quote spent buying back
+	}{
+		{
+			name: "eth_18_decimals", pair: ethUSDC, size: "0.5", cexAsk: "3000",
+			wantSell: "500000000000000000", wantBuy: "1500000000",
+		},
+		{
+			name: "wbtc_8_decimals", pair: domain.NewPair(asset.WBTC, asset.USDC), size: "0.5", cexAsk: "60000",
+			wantSell: "50000000", wantBuy: "30000000000",
+		},
+		{
+			name: "wbtc_quote_truncated", pair: domain.NewPair(asset.WBTC, asset.USDC), size: "0.00000001", cexAsk: "60000.1234567",
+			wantSell: "1", wantBuy: "600",
+		},
+		{
+			name: "wbtc_in_eth", pair: domain.NewPair(asset.WBTC, asset.ETH), size: "0.25", cexAsk: "20.5",
+			wantSell: "25000000", wantBuy: "5125000000000000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := decimal.RequireFromString(tt.size)
+			sell, err := dexQuoteRequest(tt.pair, size)
+			if err != nil {
+				t.Fatalf("dexQuoteRequest() error = %v", err)
+			}
+			buy, err := dexBuyQuoteRequest(tt.pair, size, decimal.RequireFromString(tt.cexAsk))
+			if err != nil {
+				t.Fatalf("dexBuyQuoteRequest() error = %v", err)
+			}
+
+			if got := sell.AmountIn.String(); got != tt.wantSell {
+				t.Errorf("sell amount = %s, want %s", got, tt.wantSell)
+			}
+			if got := buy.AmountIn.String(); got != tt.wantBuy {
+				t.Errorf("buy amount = %s, want %s", got, tt.wantBuy)
+			}
+		})
+	}
+}
+
+// TestDEXQuoteRequest_NegativeAmount tests that a negative amount fails
+// instead of quoting zero.
+func TestDEXQuoteRequest_NegativeAmount(t *testing.T) {
+	if _, err := dexQuoteRequest(ethUSDC, decimal.NewFromInt(-1)); !errors.Is(err, asset.ErrNegativeAmount) {
+		t.Errorf("expected ErrNegativeAmount for a negative size, got %v", err)
+	}
+	if _, err := dexBuyQuoteRequest(ethUSDC, decimal.NewFromInt(1), decimal.NewFromInt(-3000)); !errors.Is(err, asset.ErrNegativeAmount) {
+		t.Errorf("expected ErrNegativeAmount for a negative ask, got %v", err)
+	}
+	if raw, err := toRawAmount(asset.USDC, decimal.RequireFromString("-0.5")); err == nil {
+		t.Errorf("expected an error, got %s raw", raw)
+	}
+}
+
+// TestPricingService_TradeSizePrecision tests that a size finer than the
+// base asset's decimals is rejected instead of quoted truncated.
+func TestPricingService_TradeSizePrecision(t *testing.T) {
+	wbtcUSDC := domain.NewPair(asset.WBTC, asset.USDC)
+	tooFine := decimal.RequireFromString("0.000000001") // 9 decimals; WBTC has 8
+	dex := &batchDEX{fakeDEX: fakeDEX{amountOut: 60_000_000}}
+	svc := NewPricingService(&fakeCEX{bid: decimal.NewFromInt(60000), ask: decimal.NewFromInt(60010)}, dex)
+
+	if _, err := svc.GetPriceSnapshot(context.Background(), wbtcUSDC, tooFine); !errors.Is(err, asset.ErrTooManyDecimals) {
+		t.Errorf("expected ErrTooManyDecimals, got %v", err)
+	}
+
+	_, errs := svc.GetPriceSnapshots(context.Background(), []SnapshotRequest{
+		{Pair: wbtcUSDC, TradeSize: tooFine},
+		{Pair: wbtcUSDC, TradeSize: decimal.RequireFromString("0.00000001")},
+	})
+	if !errors.Is(errs[0], asset.ErrTooManyDecimals) {
+		t.Errorf("expected ErrTooManyDecimals for the 9-decimal size, got %v", errs[0])
+	}
+	if errs[1] != nil {
+		t.Errorf("expected one satoshi priced, got %v", errs[1])
+	}
+	if dex.singles != 0 {
+		t.Errorf("expected no DEX quote for the rejected size, got %d", dex.singles)
+	}
+}
+
+func TestCEXDisplayName(t *testing.T) {
+	tests := []struct {
+		venues []string
+		want   string
+	}{
+		{venues: nil, want: "CEX"},
+		{venues: []string{"coinbase"}, want: "Coinbase"},
+		{venues: []string{"binance", "kraken"}, want: "Binance + Kraken"},
+	}
+
+	for _, tt := range tests {
+		svc := NewPricingService(&fakeCEX{}, &fakeDEX{})
+		svc.SetCEXVenues(tt.venues)
+		if got := svc.CEXName(); got != tt.want {
+			t.Errorf("CEXName() for %v = %q, want %q", tt.venues, got, tt.want)
+		}
+	}
+}
+
+// statsCEX is a fakeCEX that tracks its connection's stability.
+type statsCEX struct {
+	fakeCEX
+	reconnects int
+}
+
+func (f *statsCEX) ConnectionStats() health.ConnectionStats {
+	return health.ConnectionStats{Reconnects: f.reconnects}
+}
+
+// TestPricingService_CEXConnectionStats tests that stats are keyed by the
+// venue they belong to.
+func TestPricingService_CEXConnectionStats(t *testing.T) {
+	single := NewPricingService(&statsCEX{reconnects: 1}, &fakeDEX{})
+	single.SetCEXVenues([]string{"coinbase"})
+	if stats := single.CEXConnectionStats(); len(stats) != 1 || stats["Coinbase"].Reconnects != 1 {
+		t.Errorf("expected Coinbase stats, got %v", stats)
+	}
+
+	agg := NewPricingService(NewAggregatingCEXProvider(
+		Venue{"binance", &statsCEX{reconnects: 2}},
+		Venue{"kraken", &statsCEX{reconnects: 3}},
+		Venue{"custom", &fakeCEX{}},
+	), &fakeDEX{})
+	stats := agg.CEXConnectionStats()
+	if len(stats) != 2 || stats["Binance"].Reconnects != 2 || stats["Kraken"].Reconnects != 3 {
+		t.Errorf("expected stats for each tracking venue, got %v", stats)
+	}
+}
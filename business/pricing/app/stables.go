@@ -0,0 +1,91 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/shopspring/decimal"
+)
+
+// StableEquivalents treats a set of stablecoins as fungible for spread
+// comparison: a CEX book quoted in one stable can price a pair quoted in
+// another (ETHUSDT against an ETH/USDC pool), converted through each
+// stable's USD peg.
+type StableEquivalents struct {
+	pegs     map[string]decimal.Decimal // Stable symbol -> USD peg
+	cexQuote string                     // Stable the CEX side prefers (empty = the pair's quote)
+	registry *asset.Registry
+}
+
+// NewStableEquivalents creates the equivalence set from pegs by symbol
+// (e.g. {"USDC": 1, "USDT": 1}). cexQuote, if set, is tried before the
+// pair's own quote on the CEX side; stables are resolved in registry on the
+// chain of the pair's quote.
+func NewStableEquivalents(pegs map[string]decimal.Decimal, cexQuote string, registry *asset.Registry) *StableEquivalents {
+	normalized := make(map[string]decimal.Decimal, len(pegs))
+	for symbol, peg := range pegs {
+		normalized[strings.ToUpper(symbol)] = peg
+	}
+	return &StableEquivalents{
+		pegs:     normalized,
+		cexQuote: strings.ToUpper(cexQuote),
+		registry: registry,
+	}
+}
+
+// Peg returns the USD peg of a stable, or false if it isn't in the set.
+func (s *StableEquivalents) Peg(symbol string) (decimal.Decimal, bool) {
+	if s == nil {
+		return decimal.Decimal{}, false
+	}
+	peg, ok := s.pegs[symbol]
+	return peg, ok
+}
+
+// quotes returns the assets a CEX book for pair may be quoted in, in the
+// order to try them: the preferred CEX quote, the pair's own quote, then
+// the other equivalents by symbol. Pairs not quoted in a listed stable
+// only have their own quote.
+func (s *StableEquivalents) quotes(pair domain.Pair) []*asset.Asset {
+	if _, ok := s.Peg(pair.Quote.Symbol()); !ok {
+		return []*asset.Asset{pair.Quote}
+	}
+
+	symbols := make([]string, 0, len(s.pegs))
+	for symbol := range s.pegs {
+		if symbol != pair.Quote.Symbol() && symbol != s.cexQuote {
+			symbols = append(symbols, symbol)
+		}
+	}
+	slices.Sort(symbols)
+	symbols = append([]string{pair.Quote.Symbol()}, symbols...)
+	if _, ok := s.pegs[s.cexQuote]; ok && s.cexQuote != pair.Quote.Symbol() {
+		symbols = append([]string{s.cexQuote}, symbols...)
+	}
+
+	quotes := make([]*asset.Asset, 0, len(symbols))
+	for _, symbol := range symbols {
+		if symbol == pair.Quote.Symbol() {
+			quotes = append(quotes, pair.Quote)
+			continue
+		}
+		if a, ok := s.registry.GetBySymbolAndChain(symbol, pair.Quote.ChainID()); ok {
+			quotes = append(quotes, a)
+		}
+	}
+	return quotes
+}
+
+// normalize converts a price quoted in the stable from into the quote of
+// pair: rate × peg(from) / peg(pair quote).
+func (s *StableEquivalents) normalize(price *domain.Price, pair domain.Pair, from *asset.Asset) *domain.Price {
+	fromPeg, _ := s.Peg(from.Symbol())
+	toPeg, _ := s.Peg(pair.Quote.Symbol())
+
+	rate := price.Rate.Rate().Mul(fromPeg).Div(toPeg)
+	normalized := *price
+	normalized.Rate = asset.NewPrice(pair.Base, pair.Quote, rate, price.Rate.Timestamp())
+	return &normalized
+}
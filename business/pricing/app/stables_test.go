@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// stableCEX has books only for the listed quote symbols, each at one rate
+// on both sides.
+type stableCEX struct {
+	rates map[string]decimal.Decimal // Quote symbol -> rate
+}
+
+func (f *stableCEX) GetOrderbook(ctx context.Context, pair domain.Pair) (*domain.Orderbook, error) {
+	return nil, fmt.Errorf("no book for %s", pair)
+}
+
+func (f *stableCEX) GetEffectivePrice(ctx context.Context, pair domain.Pair, size decimal.Decimal, side domain.Side) (*domain.Price, error) {
+	rate, ok := f.rates[pair.Quote.Symbol()]
+	if !ok {
+		return nil, fmt.Errorf("no book for %s", pair)
+	}
+	price := domain.NewPrice(asset.NewPriceNow(pair.Base, pair.Quote, rate), asset.Amount{}, side, "fake")
+	return &price, nil
+}
+
+func TestPricingService_StableEquivalents(t *testing.T) {
+	pegs := map[string]decimal.Decimal{
+		"USDC": decimal.NewFromInt(1),
+		"USDT": decimal.RequireFromString("0.999"),
+		"DAI":  decimal.NewFromInt(1), // Not in the registry; never tried
+	}
+
+	tests := []struct {
+		name      string
+		rates     map[string]decimal.Decimal
+		stables   *StableEquivalents
+		wantRate  string
+		wantQuote *asset.Asset // Expected CEXQuote (nil = the pair's own)
+		wantErr   bool
+	}{
+		{
+			name:     "no_equivalents",
+			rates:    map[string]decimal.Decimal{"USDC": decimal.NewFromInt(3000)},
+			wantRate: "3000",
+		},
+		{
+			name:     "own_quote_first",
+			rates:    map[string]decimal.Decimal{"USDC": decimal.NewFromInt(3000), "USDT": decimal.NewFromInt(3010)},
+			stables:  NewStableEquivalents(pegs, "", asset.DefaultRegistry()),
+			wantRate: "3000",
+		},
+		{
+			name:      "falls_back_to_equivalent",
+			rates:     map[string]decimal.Decimal{"USDT": decimal.NewFromInt(3000)},
+			stables:   NewStableEquivalents(pegs, "", asset.DefaultRegistry()),
+			wantRate:  "2997",
+			wantQuote: asset.USDT,
+		},
+		{
+			name:      "preferred_cex_quote",
+			rates:     map[string]decimal.Decimal{"USDC": decimal.NewFromInt(3000), "USDT": decimal.NewFromInt(3000)},
+			stables:   NewStableEquivalents(pegs, "usdt", asset.DefaultRegistry()),
+			wantRate:  "2997",
+			wantQuote: asset.USDT,
+		},
+		{
+			name:    "no_book",
+			rates:   map[string]decimal.Decimal{"EUR": decimal.NewFromInt(2800)},
+			stables: NewStableEquivalents(pegs, "", asset.DefaultRegistry()),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPricingService(&stableCEX{rates: tt.rates}, &fakeDEX{amountOut: 3_000_000_000})
+			svc.SetStableEquivalents(tt.stables)
+
+			snapshot, err := svc.GetPriceSnapshot(context.Background(), ethUSDC, decimal.NewFromInt(1))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error without any book")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPriceSnapshot failed: %v", err)
+			}
+
+			for _, price := range []*domain.Price{snapshot.CEXBid, snapshot.CEXAsk} {
+				if got := price.Rate.Rate(); !got.Equal(decimal.RequireFromString(tt.wantRate)) {
+					t.Errorf("expected %s rate %s, got %s", price.Side, tt.wantRate, got)
+				}
+				if price.Rate.Quote() != asset.USDC {
+					t.Errorf("expected %s price in USDC, got %s", price.Side, price.Rate.Quote().Symbol())
+				}
+			}
+			if snapshot.CEXQuote != tt.wantQuote {
+				t.Errorf("expected CEX quote %v, got %v", tt.wantQuote, snapshot.CEXQuote)
+			}
+		})
+	}
+}
@@ -3,12 +3,16 @@ package di
 
 import (
 	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/infra"
+	"github.com/fd1az/arbitrage-bot/business/pricing/infra/binance"
 	"github.com/fd1az/arbitrage-bot/internal/di"
 )
 
 // Public service tokens - exposed to other modules
 var (
 	PricingService = di.NewToken[*app.PricingService]("pricing.PricingService")
+	UserDataStream = di.NewToken[*binance.UserDataStream]("pricing.UserDataStream") // nil without Binance API credentials
+	DebugState     = di.NewToken[*infra.DebugState]("pricing.DebugState")
 )
 
 // Private dependency tokens - internal to pricing module
@@ -22,6 +26,14 @@ func GetPricingService(c di.ServiceRegistry) *app.PricingService {
 	return di.GetToken(c, PricingService)
 }
 
+func GetUserDataStream(c di.ServiceRegistry) *binance.UserDataStream {
+	return di.GetToken(c, UserDataStream)
+}
+
+func GetDebugState(c di.ServiceRegistry) *infra.DebugState {
+	return di.GetToken(c, DebugState)
+}
+
 func GetCEXProvider(c di.ServiceRegistry) app.CEXProvider {
 	return di.GetToken(c, CEXProvider)
 }
@@ -3,6 +3,7 @@ package domain
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/fd1az/arbitrage-bot/internal/asset"
@@ -41,13 +42,44 @@ func (p Pair) Invert() Pair {
 	return Pair{Base: p.Quote, Quote: p.Base}
 }
 
+// PartialFillError reports a book too thin to fill the requested size.
+type PartialFillError struct {
+	Venue     string
+	Pair      Pair
+	Side      Side
+	Requested decimal.Decimal // Base-asset size asked for
+	Filled    decimal.Decimal // Base-asset size the book could fill
+}
+
+func (e *PartialFillError) Error() string {
+	return fmt.Sprintf("%s book for %s fills %s of %s requested (%s)",
+		e.Venue, e.Pair, e.Filled.String(), e.Requested.String(), e.Side)
+}
+
+// NoLiquidityError reports a pair no venue could price: the CEX side failed
+// and the DEX returned no quote either (e.g. an empty book and no pool on
+// any fee tier).
+type NoLiquidityError struct {
+	Pair   Pair
+	CEXErr error
+	DEXErr error
+}
+
+func (e *NoLiquidityError) Error() string {
+	return fmt.Sprintf("no liquidity for %s on any venue (cex: %v; dex: %v)", e.Pair, e.CEXErr, e.DEXErr)
+}
+
+func (e *NoLiquidityError) Unwrap() []error {
+	return []error{e.CEXErr, e.DEXErr}
+}
+
 // Price represents a price point with metadata.
 // Uses asset.Price for the actual rate.
 type Price struct {
 	Rate      asset.Price
 	Size      asset.Amount // Trade size this price is valid for
 	Side      Side
-	Source    string // "binance", "uniswap", etc.
+	Source    string // Venue name (see Venue* constants)
 	Timestamp time.Time
 }
 
@@ -102,6 +134,25 @@ func (o *Orderbook) MidPrice() decimal.Decimal {
 	return bid.Price.Add(ask.Price).Div(decimal.NewFromInt(2))
 }
 
+// MicroPrice returns the top-of-book price weighted by the opposite side's
+// size: bid*askQty + ask*bidQty over bidQty + askQty. It leans toward the
+// side more likely to move (the thinner one). Falls back to MidPrice when
+// the top levels carry no size, and zero when a side is empty.
+func (o *Orderbook) MicroPrice() decimal.Decimal {
+	bid := o.BestBid()
+	ask := o.BestAsk()
+	if bid == nil || ask == nil {
+		return decimal.Zero
+	}
+	bidQty := bid.Amount.ToDecimal()
+	askQty := ask.Amount.ToDecimal()
+	total := bidQty.Add(askQty)
+	if total.IsZero() {
+		return o.MidPrice()
+	}
+	return bid.Price.Mul(askQty).Add(ask.Price.Mul(bidQty)).Div(total)
+}
+
 // Quote represents a DEX price quote.
 type Quote struct {
 	TokenIn     *asset.Asset
@@ -110,8 +161,38 @@ type Quote struct {
 	AmountOut   asset.Amount
 	Price       asset.Price // Effective price (AmountOut/AmountIn adjusted)
 	GasEstimate uint64
-	FeeTier     int // Fee tier in hundredths of a bip (e.g., 3000 = 0.30%)
-	Timestamp   time.Time
+	FeeTier     int    // Fee tier in hundredths of a bip (e.g., 3000 = 0.30%)
+	Protocol    string // DEX protocol that produced the quote (see Protocol* constants)
+	// TicksCrossed is the number of initialized ticks the swap crosses (V3 only);
+	// a high count means liquidity in this tier is thin around the current price.
+	TicksCrossed uint32
+	// Pool sqrt prices (Q64.96) before and after the swap (V3 only, nil when unknown)
+	SqrtPriceX96Before *big.Int
+	SqrtPriceX96After  *big.Int
+	Timestamp          time.Time
+}
+
+// PriceImpactBps returns the pool price move caused by the swap in basis points,
+// derived from the sqrt prices before and after. ok is false when either is unknown.
+func (q Quote) PriceImpactBps() (impact decimal.Decimal, ok bool) {
+	if q.SqrtPriceX96Before == nil || q.SqrtPriceX96After == nil || q.SqrtPriceX96Before.Sign() == 0 {
+		return decimal.Zero, false
+	}
+	// price = (sqrtPrice / 2^96)^2, so the Q96 scaling cancels in the ratio
+	ratio := decimal.NewFromBigInt(q.SqrtPriceX96After, 0).
+		DivRound(decimal.NewFromBigInt(q.SqrtPriceX96Before, 0), 18)
+	return ratio.Mul(ratio).Sub(decimal.NewFromInt(1)).Abs().Mul(decimal.NewFromInt(10000)), true
+}
+
+// InverseRate returns AmountIn per unit of AmountOut: the price paid for the
+// output token, e.g. USDC per WETH for a USDC→WETH quote. Zero when nothing
+// comes out.
+func (q Quote) InverseRate() decimal.Decimal {
+	out := q.AmountOut.ToDecimal()
+	if out.IsZero() {
+		return decimal.Zero
+	}
+	return q.AmountIn.ToDecimal().Div(out)
 }
 
 // FeeTierPercent returns the fee tier as a percentage string (e.g., "0.30%").
@@ -146,7 +227,10 @@ type PriceSnapshot struct {
 	Pair        Pair
 	CEXBid      *Price       // Best bid on CEX
 	CEXAsk      *Price       // Best ask on CEX
-	DEXQuote    *Quote       // DEX quote for the trade size
+	CEXVenue    string       // Venue that supplied CEXAsk (see Venue* constants)
+	CEXQuote    *asset.Asset // Stable the CEX book was quoted in when it stands in for the pair's quote (nil = the pair's own)
+	DEXQuote    *Quote       // DEX quote selling the trade size of base (base→quote)
+	DEXBuyQuote *Quote       // DEX quote buying about the trade size of base (quote→base); nil if unavailable
 	GasPrice    asset.Amount // Gas price in ETH
 	BlockNumber uint64
 	Timestamp   time.Time
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+func TestQuote_PriceImpactBps(t *testing.T) {
+	q96 := new(big.Int).Lsh(big.NewInt(1), 96)
+	scaled := func(num, den int64) *big.Int {
+		v := new(big.Int).Mul(q96, big.NewInt(num))
+		return v.Div(v, big.NewInt(den))
+	}
+
+	tests := []struct {
+		name   string
+		before *big.Int
+		after  *big.Int
+		want   string
+		wantOK bool
+	}{
+		// sqrt price up 1% -> price up 1.01^2 - 1 = 2.01%
+		{name: "price_up", before: q96, after: scaled(101, 100), want: "201", wantOK: true},
+		// sqrt price down 1% -> price down 1 - 0.99^2 = 1.99%
+		{name: "price_down", before: q96, after: scaled(99, 100), want: "199", wantOK: true},
+		{name: "no_move", before: q96, after: q96, want: "0", wantOK: true},
+		{name: "unknown_spot", before: nil, after: q96, want: "0", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := Quote{SqrtPriceX96Before: tt.before, SqrtPriceX96After: tt.after}
+			got, ok := q.PriceImpactBps()
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%v, got %v", tt.wantOK, ok)
+			}
+			if want := decimal.RequireFromString(tt.want); !got.Round(6).Equal(want) {
+				t.Errorf("expected impact %s bps, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestOrderbook_MicroPrice(t *testing.T) {
+	level := func(price, qty string) []OrderbookLevel {
+		amt, _ := asset.ParseDecimal(asset.ETH, decimal.RequireFromString(qty))
+		return []OrderbookLevel{{Price: decimal.RequireFromString(price), Amount: amt}}
+	}
+
+	tests := []struct {
+		name      string
+		bids      []OrderbookLevel
+		asks      []OrderbookLevel
+		wantMicro string
+		wantMid   string
+	}{
+		// Equal sizes: micro-price is the mid
+		{name: "balanced", bids: level("3000", "5"), asks: level("3002", "5"), wantMicro: "3001", wantMid: "3001"},
+		// Thin ask (1 vs 3 bid): 3000*1 + 3002*3 / 4 = 3001.5, leaning toward the ask
+		{name: "thin_ask", bids: level("3000", "3"), asks: level("3002", "1"), wantMicro: "3001.5", wantMid: "3001"},
+		// Thin bid (1 vs 3 ask): 3000*3 + 3002*1 / 4 = 3000.5, leaning toward the bid
+		{name: "thin_bid", bids: level("3000", "1"), asks: level("3002", "3"), wantMicro: "3000.5", wantMid: "3001"},
+		{name: "no_size_uses_mid", bids: level("3000", "0"), asks: level("3002", "0"), wantMicro: "3001", wantMid: "3001"},
+		{name: "empty_side", bids: level("3000", "1"), wantMicro: "0", wantMid: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ob := &Orderbook{Bids: tt.bids, Asks: tt.asks}
+			if got := ob.MicroPrice(); !got.Equal(decimal.RequireFromString(tt.wantMicro)) {
+				t.Errorf("MicroPrice() = %s, want %s", got, tt.wantMicro)
+			}
+			if got := ob.MidPrice(); !got.Equal(decimal.RequireFromString(tt.wantMid)) {
+				t.Errorf("MidPrice() = %s, want %s", got, tt.wantMid)
+			}
+		})
+	}
+}
@@ -21,6 +21,15 @@ const (
 	SpreadNone     SpreadDirection = "NONE"       // No profitable spread
 )
 
+// SpreadBasis selects the CEX price a spread is measured against.
+type SpreadBasis string
+
+const (
+	SpreadBasisAsk   SpreadBasis = "ask"   // Best executable ask (default)
+	SpreadBasisMid   SpreadBasis = "mid"   // Mid of best bid and ask
+	SpreadBasisMicro SpreadBasis = "micro" // Size-weighted top of book
+)
+
 // CalculateSpread computes the spread between CEX and DEX prices.
 func CalculateSpread(cexPrice, dexPrice decimal.Decimal) Spread {
 	absolute := dexPrice.Sub(cexPrice)
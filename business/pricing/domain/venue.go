@@ -0,0 +1,45 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+// Venue names as reported in Price.Source.
+const (
+	VenueBinance  = "binance"
+	VenueCoinbase = "coinbase"
+	VenueKraken   = "kraken"
+	VenueUniswap  = "uniswap"
+)
+
+// DEX protocols as reported in Quote.Protocol.
+const (
+	ProtocolUniswapV2 = "uniswap_v2"
+	ProtocolUniswapV3 = "uniswap_v3"
+)
+
+// venueDisplayNames maps venue identifiers to human-readable names.
+var venueDisplayNames = map[string]string{
+	VenueBinance:  "Binance",
+	VenueCoinbase: "Coinbase",
+	VenueKraken:   "Kraken",
+	VenueUniswap:  "Uniswap",
+
+	ProtocolUniswapV2: "Uniswap V2",
+	ProtocolUniswapV3: "Uniswap V3",
+}
+
+// VenueDisplayName returns the human-readable name for a venue or DEX protocol
+// (e.g., "binance" -> "Binance", "uniswap_v3" -> "Uniswap V3").
+// Unknown venues are returned unchanged.
+func VenueDisplayName(venue string) string {
+	if name, ok := venueDisplayNames[venue]; ok {
+		return name
+	}
+	return venue
+}
+
+// FeeRates are an account's trading fee rates on a venue, as fractions of
+// the traded value (0.001 = 0.1%).
+type FeeRates struct {
+	Maker decimal.Decimal
+	Taker decimal.Decimal
+}
@@ -0,0 +1,54 @@
+package binance
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+)
+
+// partialDepthLevels is the size of the @depth20 partial book.
+const partialDepthLevels = 20
+
+// maxBookLevels returns the levels kept per side of each book: the
+// configured cap, or else the depth the book is fed with (the REST seed for
+// diff-depth books, the partial book or SnapshotDepth otherwise).
+func maxBookLevels(cfg ProviderConfig) int {
+	switch {
+	case cfg.MaxBookLevels > 0:
+		return cfg.MaxBookLevels
+	case cfg.DiffDepth:
+		return diffDepthSnapshotLimit
+	default:
+		return max(cfg.SnapshotDepth, partialDepthLevels)
+	}
+}
+
+// newBookLevelsGauge creates the binance_orderbook_levels gauge.
+func newBookLevelsGauge() (metric.Int64Gauge, error) {
+	return otel.Meter(meterName).Int64Gauge(
+		"binance_orderbook_levels",
+		metric.WithDescription("Price levels held per side of each Binance book (by symbol and side)"),
+	)
+}
+
+// setLevels replaces the book's levels, truncated to maxLevels per side,
+// and marks it updated. Callers hold s.mu.
+func (s *orderbookState) setLevels(bids, asks []domain.OrderbookLevel, maxLevels int) {
+	s.bids = bids[:min(len(bids), maxLevels)]
+	s.asks = asks[:min(len(asks), maxLevels)]
+	s.lastUpdate = time.Now()
+}
+
+// recordBookSize exports the size of symbol's book. Callers hold state.mu.
+func (p *Provider) recordBookSize(symbol string, state *orderbookState) {
+	ctx := context.Background()
+	p.bookLevels.Record(ctx, int64(len(state.bids)),
+		metric.WithAttributes(attribute.String("symbol", symbol), attribute.String("side", "bid")))
+	p.bookLevels.Record(ctx, int64(len(state.asks)),
+		metric.WithAttributes(attribute.String("symbol", symbol), attribute.String("side", "ask")))
+}
@@ -0,0 +1,113 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// levels returns n [price, qty] levels stepping away from start.
+func levels(n int, start, step float64) [][]string {
+	out := make([][]string, n)
+	for i := range out {
+		out[i] = []string{fmt.Sprintf("%.2f", start+float64(i)*step), "1.0"}
+	}
+	return out
+}
+
+func TestMaxBookLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ProviderConfig
+		want int
+	}{
+		{name: "configured", cfg: ProviderConfig{MaxBookLevels: 50, DiffDepth: true}, want: 50},
+		{name: "diff depth", cfg: ProviderConfig{DiffDepth: true}, want: diffDepthSnapshotLimit},
+		{name: "partial book", cfg: ProviderConfig{SnapshotDepth: 5}, want: partialDepthLevels},
+		{name: "deep REST snapshots", cfg: ProviderConfig{SnapshotDepth: 100}, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maxBookLevels(tt.cfg); got != tt.want {
+				t.Errorf("maxBookLevels() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestProvider_BookLevelsCapped tests that books are truncated to
+// MaxBookLevels whichever stream feeds them.
+func TestProvider_BookLevelsCapped(t *testing.T) {
+	cfg := DefaultProviderConfig([]string{"ETHUSDC"})
+	cfg.EnableFallback = false
+	cfg.MaxBookLevels = 5
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	state := provider.orderbooks["ETHUSDC"]
+
+	provider.handleDepthUpdate(&PartialDepthEvent{
+		Symbol: "ETHUSDC",
+		Bids:   levels(20, 3400, -0.5),
+		Asks:   levels(20, 3401, 0.5),
+	})
+	if len(state.bids) != 5 || len(state.asks) != 5 {
+		t.Fatalf("expected partial book capped at 5 levels, got %d bids / %d asks", len(state.bids), len(state.asks))
+	}
+
+	provider.handleDepthSnapshot("ETHUSDC", &DepthResponse{
+		Bids: levels(100, 3400, -0.5),
+		Asks: levels(100, 3401, 0.5),
+	})
+	provider.handleDiffDepth(&DepthUpdateEvent{
+		Symbol: "ETHUSDC",
+		Bids:   levels(30, 3350, -0.5), // Below the kept range
+		Asks:   levels(30, 3399, -0.01),
+	})
+	if len(state.bids) != 5 || len(state.asks) != 5 {
+		t.Fatalf("expected diff book capped at 5 levels, got %d bids / %d asks", len(state.bids), len(state.asks))
+	}
+	if best := state.bids[0].Price.String(); best != "3400" {
+		t.Errorf("expected best bid 3400 kept, got %s", best)
+	}
+	if best := state.asks[0].Price.String(); best != "3398.71" {
+		t.Errorf("expected best ask 3398.71 from the diff, got %s", best)
+	}
+}
+
+// TestProvider_BookLevelsCappedOverHTTP tests the cap on REST fallback books.
+func TestProvider_BookLevelsCappedOverHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(DepthResponse{Bids: levels(20, 3400, -0.5), Asks: levels(20, 3401, 0.5)})
+	}))
+	defer server.Close()
+
+	cfg := DefaultProviderConfig([]string{"ETHUSDC"})
+	cfg.HTTPURL = server.URL
+	cfg.MaxBookLevels = 3
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	ob, err := provider.GetOrderbook(context.Background(), domain.Pair{Base: asset.ETH, Quote: asset.USDC})
+	if err != nil {
+		t.Fatalf("GetOrderbook failed: %v", err)
+	}
+	if len(ob.Bids) != 3 || len(ob.Asks) != 3 {
+		t.Errorf("expected 3 levels per side, got %d bids / %d asks", len(ob.Bids), len(ob.Asks))
+	}
+	if state := provider.orderbooks["ETHUSDC"]; len(state.bids) != 3 {
+		t.Errorf("expected the cached book capped at 3 levels, got %d", len(state.bids))
+	}
+}
@@ -16,12 +16,17 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/health"
+	"github.com/fd1az/arbitrage-bot/internal/latency"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
 	"github.com/fd1az/arbitrage-bot/internal/wsconn"
 )
 
 // Ensure interface compliance
-var _ logger.LoggerInterface = (*logger.Logger)(nil)
+var (
+	_ logger.LoggerInterface = (*logger.Logger)(nil)
+	_ health.HealthReporter  = (*Client)(nil)
+)
 
 const (
 	tracerName = "binance"
@@ -40,11 +45,14 @@ const (
 
 // ClientConfig holds configuration for the Binance client.
 type ClientConfig struct {
-	BaseURL      string        // WebSocket base URL
-	Symbols      []string      // Symbols to subscribe (e.g., "ETHUSDC")
-	DepthSpeedMs int           // Depth update speed (100 or 1000)
-	ReadTimeout  time.Duration // Read timeout
-	WriteTimeout time.Duration // Write timeout
+	BaseURL      string                 // WebSocket base URL
+	Symbols      []string               // Symbols to subscribe (e.g., "ETHUSDC")
+	DepthSpeedMs int                    // Depth update speed (100 or 1000)
+	DiffDepth    bool                   // Subscribe to the diff-depth stream instead of @depth20
+	ReadTimeout  time.Duration          // Read timeout
+	WriteTimeout time.Duration          // Write timeout
+	ProxyURL     string                 // http(s):// or socks5:// proxy (empty = direct)
+	Compression  wsconn.CompressionMode // permessage-deflate negotiation (empty = context takeover)
 }
 
 // DefaultClientConfig returns sensible defaults.
@@ -78,6 +86,7 @@ type Client struct {
 	// Message handlers
 	onAggTrade    func(*AggTradeEvent)
 	onDepthUpdate func(*PartialDepthEvent) // Uses PartialDepthEvent for @depth20 streams
+	onDiffDepth   func(*DepthUpdateEvent)  // Used when DiffDepth is enabled
 	onBookTicker  func(*BookTickerEvent)
 	handlersMu    sync.RWMutex
 
@@ -86,15 +95,19 @@ type Client struct {
 	subsMu        sync.RWMutex
 	nextID        atomic.Int64
 
-	// Keep-alive
+	// Keep-alive, doubling as a latency probe
 	stopKeepAlive chan struct{}
+	probeID       atomic.Int64 // Request ID of the outstanding keep-alive
+	probeSentAt   atomic.Int64 // UnixNano it was sent
+	latency       *latency.EWMA
 
 	// Observability
 	tracer  trace.Tracer
 	metrics *clientMetrics
 
 	// State
-	running atomic.Bool
+	running     atomic.Bool
+	lastMessage atomic.Int64 // UnixNano of the last message received
 }
 
 // NewClient creates a new Binance WebSocket client.
@@ -104,6 +117,7 @@ func NewClient(cfg ClientConfig, log logger.LoggerInterface) (*Client, error) {
 		logger:        log,
 		subscriptions: make(map[string]struct{}),
 		stopKeepAlive: make(chan struct{}),
+		latency:       latency.NewEWMA(latency.DefaultAlpha),
 		tracer:        otel.Tracer(tracerName),
 	}
 
@@ -177,6 +191,13 @@ func (c *Client) OnDepthUpdate(handler func(*PartialDepthEvent)) {
 	c.handlersMu.Unlock()
 }
 
+// OnDiffDepth registers a handler for diff depth events (@depth@<speed>ms streams).
+func (c *Client) OnDiffDepth(handler func(*DepthUpdateEvent)) {
+	c.handlersMu.Lock()
+	c.onDiffDepth = handler
+	c.handlersMu.Unlock()
+}
+
 // OnBookTicker registers a handler for book ticker events.
 func (c *Client) OnBookTicker(handler func(*BookTickerEvent)) {
 	c.handlersMu.Lock()
@@ -203,6 +224,10 @@ func (c *Client) Connect(ctx context.Context) error {
 	wsCfg := wsconn.DefaultConfig(wsURL, "binance")
 	wsCfg.ReadTimeout = c.config.ReadTimeout
 	wsCfg.WriteTimeout = c.config.WriteTimeout
+	wsCfg.ProxyURL = c.config.ProxyURL
+	if c.config.Compression != "" {
+		wsCfg.Compression = c.config.Compression
+	}
 
 	// Create connection
 	conn, err := wsconn.New(wsCfg)
@@ -230,7 +255,7 @@ func (c *Client) Connect(ctx context.Context) error {
 	c.subsMu.Lock()
 	for _, sym := range c.config.Symbols {
 		c.subscriptions[BookTickerStream(sym)] = struct{}{}
-		c.subscriptions[DepthStream(sym, c.config.DepthSpeedMs)] = struct{}{}
+		c.subscriptions[c.depthStream(sym)] = struct{}{}
 	}
 	c.subsMu.Unlock()
 
@@ -262,8 +287,7 @@ func (c *Client) buildStreamURL() (string, error) {
 		streams = append(streams, bookTickerStream)
 
 		// Depth stream for VWAP calculations on larger trade sizes
-		depthStream := DepthStream(sym, c.config.DepthSpeedMs)
-		streams = append(streams, depthStream)
+		streams = append(streams, c.depthStream(sym))
 	}
 
 	// Combined streams URL: /stream?streams=stream1/stream2/...
@@ -280,9 +304,18 @@ func (c *Client) buildStreamURL() (string, error) {
 	return finalURL, nil
 }
 
+// depthStream returns the configured depth stream name for a symbol.
+func (c *Client) depthStream(symbol string) string {
+	if c.config.DiffDepth {
+		return DiffDepthStream(symbol, c.config.DepthSpeedMs)
+	}
+	return DepthStream(symbol, c.config.DepthSpeedMs)
+}
+
 // handleMessage processes incoming WebSocket messages.
 func (c *Client) handleMessage(ctx context.Context, data []byte) {
 	c.metrics.messagesReceived.Add(ctx, 1)
+	c.lastMessage.Store(time.Now().UnixNano())
 
 	// Parse stream wrapper
 	var event StreamEvent
@@ -298,6 +331,15 @@ func (c *Client) handleMessage(ctx context.Context, data []byte) {
 		return
 	}
 
+	// Request responses (e.g. keep-alives) carry an ID instead of a stream
+	if event.Stream == "" {
+		var resp WSResponse
+		if json.Unmarshal(data, &resp) == nil {
+			c.observeProbe(resp.ID)
+		}
+		return
+	}
+
 	// Route by stream type
 	c.routeStreamEvent(ctx, &event)
 }
@@ -320,6 +362,21 @@ func (c *Client) routeStreamEvent(ctx context.Context, event *StreamEvent) {
 			handler(&ticker)
 		}
 
+	case c.config.DiffDepth && strings.Contains(stream, "@depth"):
+		var diff DepthUpdateEvent
+		if err := json.Unmarshal(event.Data, &diff); err != nil {
+			c.metrics.parseErrors.Add(ctx, 1)
+			c.logger.Warn(ctx, "failed to parse depth update", "error", err, "data", string(event.Data[:min(len(event.Data), 200)]))
+			return
+		}
+		c.metrics.depthUpdates.Add(ctx, 1)
+		c.handlersMu.RLock()
+		handler := c.onDiffDepth
+		c.handlersMu.RUnlock()
+		if handler != nil {
+			handler(&diff)
+		}
+
 	case strings.Contains(stream, "@depth"):
 		var depth PartialDepthEvent
 		if err := json.Unmarshal(event.Data, &depth); err != nil {
@@ -428,11 +485,13 @@ func (c *Client) Unsubscribe(ctx context.Context, streams ...string) error {
 	return nil
 }
 
-// keepAlive sends periodic pings to keep the connection alive.
+// keepAlive sends periodic pings to keep the connection alive. The first
+// goes out immediately so latency is known soon after connecting.
 func (c *Client) keepAlive(ctx context.Context) {
 	ticker := time.NewTicker(keepAliveInterval)
 	defer ticker.Stop()
 
+	c.sendKeepAlive(ctx)
 	for {
 		select {
 		case <-c.stopKeepAlive:
@@ -441,26 +500,60 @@ func (c *Client) keepAlive(ctx context.Context) {
 			if !c.running.Load() {
 				return
 			}
-
-			c.connMu.RLock()
-			conn := c.conn
-			c.connMu.RUnlock()
-
-			if conn != nil {
-				// Send a list_subscriptions request as keep-alive
-				req := WSRequest{
-					Method: "LIST_SUBSCRIPTIONS",
-					ID:     c.nextID.Add(1),
-				}
-				data, _ := json.Marshal(req)
-				if err := conn.Send(ctx, data); err != nil {
-					c.logger.Warn(ctx, "keep-alive failed", "error", err)
-				}
-			}
+			c.sendKeepAlive(ctx)
 		}
 	}
 }
 
+// sendKeepAlive sends a list_subscriptions request, timing its response.
+func (c *Client) sendKeepAlive(ctx context.Context) {
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	if conn == nil {
+		return
+	}
+
+	req := WSRequest{
+		Method: "LIST_SUBSCRIPTIONS",
+		ID:     c.nextID.Add(1),
+	}
+	data, _ := json.Marshal(req)
+	c.probeSentAt.Store(time.Now().UnixNano())
+	c.probeID.Store(req.ID)
+	if err := conn.Send(ctx, data); err != nil {
+		c.probeID.Store(0)
+		c.logger.Warn(ctx, "keep-alive failed", "error", err)
+	}
+}
+
+// observeProbe records the round trip if id answers the outstanding
+// keep-alive. Each probe is measured once.
+func (c *Client) observeProbe(id int64) {
+	if id == 0 || !c.probeID.CompareAndSwap(id, 0) {
+		return
+	}
+	c.latency.Since(time.Unix(0, c.probeSentAt.Load()))
+}
+
+// Latency returns the moving average keep-alive round trip. Before the
+// first keep-alive response it falls back to the last WebSocket ping round
+// trip, and is 0 when neither has been measured.
+func (c *Client) Latency() time.Duration {
+	if avg := c.latency.Value(); avg > 0 {
+		return avg
+	}
+
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+	if conn == nil {
+		return 0
+	}
+	return conn.LastPingRTT()
+}
+
 // Close closes the client connection.
 func (c *Client) Close() error {
 	c.running.Store(false)
@@ -482,6 +575,36 @@ func (c *Client) IsConnected() bool {
 	return c.conn != nil && c.conn.IsConnected()
 }
 
+// Health reports the WebSocket connection state and the last message time.
+func (c *Client) Health() health.SourceHealth {
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	h := health.SourceHealth{State: string(wsconn.StateDisconnected)}
+	if conn != nil {
+		h.State = string(conn.State())
+		h.Connected = conn.IsConnected()
+	}
+	if ts := c.lastMessage.Load(); ts > 0 {
+		h.LastMessage = time.Unix(0, ts)
+	}
+	return h
+}
+
+// ConnectionStats reports the WebSocket's session uptime, reconnects and
+// pending backoff, or zero stats before Connect.
+func (c *Client) ConnectionStats() health.ConnectionStats {
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	if conn == nil {
+		return health.ConnectionStats{}
+	}
+	return conn.ConnectionStats()
+}
+
 // extractSymbolFromStream extracts the symbol from a stream name.
 // Example: "ethusdc@depth20@100ms" -> "ETHUSDC"
 func extractSymbolFromStream(stream string) string {
@@ -0,0 +1,37 @@
+package binance
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestClient_KeepAliveLatency tests that the keep-alive response is timed
+// once and other request responses are ignored.
+func TestClient_KeepAliveLatency(t *testing.T) {
+	client, err := NewClient(DefaultClientConfig([]string{"ETHUSDC"}), &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	client.probeSentAt.Store(time.Now().Add(-50 * time.Millisecond).UnixNano())
+	client.probeID.Store(7)
+
+	client.handleMessage(ctx, []byte(`{"result":null,"id":3}`))
+	if client.Latency() != 0 {
+		t.Fatalf("expected another request's response ignored, got %s", client.Latency())
+	}
+
+	client.handleMessage(ctx, []byte(`{"result":["ethusdc@bookTicker"],"id":7}`))
+	first := client.Latency()
+	if first < 50*time.Millisecond {
+		t.Fatalf("expected the keep-alive round trip of at least 50ms, got %s", first)
+	}
+
+	client.probeSentAt.Store(time.Now().Add(-time.Second).UnixNano())
+	client.handleMessage(ctx, []byte(`{"result":["ethusdc@bookTicker"],"id":7}`))
+	if client.Latency() != first {
+		t.Errorf("expected a repeated response not measured again, got %s", client.Latency())
+	}
+}
@@ -0,0 +1,106 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// What Connect does when the local clock is off from Binance's by more than
+// ProviderConfig.MaxClockSkew (see ProviderConfig.ClockSkew).
+const (
+	ClockSkewIgnore = "ignore" // Don't measure the skew
+	ClockSkewWarn   = "warn"   // Log it and connect anyway
+	ClockSkewFail   = "fail"   // Refuse to connect
+)
+
+// defaultMaxClockSkew is how far the clocks may drift apart before the skew
+// check complains. Binance rejects signed requests stamped more than a
+// second ahead of its own clock.
+const defaultMaxClockSkew = time.Second
+
+// ServerClock is Binance's clock as seen locally: local time corrected by
+// the offset last measured against /api/v3/time. The zero value has no
+// offset. It is safe for concurrent use.
+type ServerClock struct {
+	offset atomic.Int64 // Nanoseconds Binance is ahead of the local clock
+}
+
+// Now returns the current time on Binance's clock.
+func (c *ServerClock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// Offset returns how far Binance's clock is ahead of the local one
+// (negative when it is behind).
+func (c *ServerClock) Offset() time.Duration {
+	return time.Duration(c.offset.Load())
+}
+
+// SetOffset replaces the offset applied by Now.
+func (c *ServerClock) SetOffset(offset time.Duration) {
+	c.offset.Store(int64(offset))
+}
+
+// measureClockOffset returns how far Binance's clock is ahead of the local
+// one, taking the server time to be stamped halfway through the round trip.
+func measureClockOffset(ctx context.Context, client *HTTPClient) (time.Duration, error) {
+	sent := time.Now()
+	serverTime, err := client.GetServerTime(ctx)
+	if err != nil {
+		return 0, err
+	}
+	received := time.Now()
+
+	midpoint := sent.Add(received.Sub(sent) / 2)
+	return serverTime.Sub(midpoint), nil
+}
+
+// checkClockSkew measures the clock offset before connecting and stores it
+// on the provider's ServerClock. A skew above MaxClockSkew is logged, or
+// fails the connect in fail mode. If the server time can't be fetched the
+// offset is left unchanged.
+func (p *Provider) checkClockSkew(ctx context.Context) error {
+	if p.timeClient == nil {
+		return nil
+	}
+
+	offset, err := measureClockOffset(ctx, p.timeClient)
+	if err != nil {
+		p.logger.Warn(ctx, "binance clock skew check unavailable", "error", err)
+		return nil
+	}
+	p.clock.SetOffset(offset)
+
+	skew := offset.Abs()
+	if skew <= p.maxClockSkew() {
+		p.logger.Info(ctx, "binance clock skew", "offset", offset)
+		return nil
+	}
+
+	if p.config.ClockSkew == ClockSkewFail {
+		return apperror.New(apperror.CodeBinanceClockSkew,
+			apperror.WithContext(fmt.Sprintf("local clock is %s off from Binance (max %s)", skew, p.maxClockSkew())))
+	}
+	p.logger.Warn(ctx, "local clock is off from binance, sync it (e.g. with NTP)",
+		"offset", offset,
+		"max_skew", p.maxClockSkew())
+	return nil
+}
+
+// maxClockSkew returns the configured skew threshold, or the default.
+func (p *Provider) maxClockSkew() time.Duration {
+	if p.config.MaxClockSkew > 0 {
+		return p.config.MaxClockSkew
+	}
+	return defaultMaxClockSkew
+}
+
+// Clock returns Binance's clock as measured on connect, for timestamping
+// requests Binance checks against its own time.
+func (p *Provider) Clock() *ServerClock {
+	return p.clock
+}
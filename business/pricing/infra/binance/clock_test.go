@@ -0,0 +1,131 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// newServerTimeServer serves /api/v3/time running ahead of the local clock
+// by offset.
+func newServerTimeServer(t *testing.T, offset time.Duration) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != serverTimeEndpoint {
+			t.Errorf("expected path %s, got %s", serverTimeEndpoint, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"serverTime":%d}`, time.Now().Add(offset).UnixMilli())
+	}))
+}
+
+func newClockCheckingProvider(t *testing.T, url, mode string) *Provider {
+	t.Helper()
+	cfg := DefaultProviderConfig([]string{"ETHUSDC"})
+	cfg.HTTPURL = url
+	cfg.EnableFallback = false
+	cfg.ClockSkew = mode
+	cfg.MaxClockSkew = time.Second
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	return provider
+}
+
+func TestMeasureClockOffset(t *testing.T) {
+	server := newServerTimeServer(t, -3*time.Second)
+	defer server.Close()
+
+	client, err := NewHTTPClient(HTTPClientConfig{BaseURL: server.URL}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	offset, err := measureClockOffset(context.Background(), client)
+	if err != nil {
+		t.Fatalf("measureClockOffset failed: %v", err)
+	}
+	if offset > -2900*time.Millisecond || offset < -3100*time.Millisecond {
+		t.Errorf("expected offset around -3s, got %s", offset)
+	}
+}
+
+func TestProvider_CheckClockSkew(t *testing.T) {
+	tests := []struct {
+		name     string
+		offset   time.Duration
+		mode     string
+		wantErr  bool
+		wantSkew bool // Offset stored on the clock
+	}{
+		{name: "within_threshold", offset: 200 * time.Millisecond, mode: ClockSkewFail, wantSkew: true},
+		{name: "warn", offset: 10 * time.Second, mode: ClockSkewWarn, wantSkew: true},
+		{name: "fail", offset: 10 * time.Second, mode: ClockSkewFail, wantErr: true, wantSkew: true},
+		{name: "ignore", offset: 10 * time.Second, mode: ClockSkewIgnore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newServerTimeServer(t, tt.offset)
+			defer server.Close()
+
+			provider := newClockCheckingProvider(t, server.URL, tt.mode)
+			err := provider.checkClockSkew(context.Background())
+			if tt.wantErr {
+				if code := apperror.GetCode(err); code != apperror.CodeBinanceClockSkew {
+					t.Fatalf("expected %s, got %v", apperror.CodeBinanceClockSkew, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			offset := provider.Clock().Offset()
+			if !tt.wantSkew {
+				if offset != 0 {
+					t.Errorf("expected no offset, got %s", offset)
+				}
+				return
+			}
+			if diff := (offset - tt.offset).Abs(); diff > 100*time.Millisecond {
+				t.Errorf("expected offset around %s, got %s", tt.offset, offset)
+			}
+		})
+	}
+}
+
+func TestProvider_CheckClockSkew_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// An unreachable time endpoint shouldn't keep the bot from starting
+	provider := newClockCheckingProvider(t, server.URL, ClockSkewFail)
+	if err := provider.checkClockSkew(context.Background()); err != nil {
+		t.Fatalf("expected check to be skipped, got %v", err)
+	}
+	if offset := provider.Clock().Offset(); offset != 0 {
+		t.Errorf("expected no offset, got %s", offset)
+	}
+}
+
+func TestUserDataStream_SignsWithServerClock(t *testing.T) {
+	clock := &ServerClock{}
+	clock.SetOffset(-time.Hour)
+
+	stream, err := NewUserDataStream(UserDataConfig{APIKey: "key", APISecret: "secret", Clock: clock}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	if drift := time.Since(stream.now()) - time.Hour; drift.Abs() > time.Second {
+		t.Errorf("expected timestamps an hour behind, off by %s", drift)
+	}
+}
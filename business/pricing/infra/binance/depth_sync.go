@@ -0,0 +1,234 @@
+package binance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+const (
+	// diffDepthSnapshotLimit is the REST snapshot size used to seed a diff-depth book.
+	diffDepthSnapshotLimit = 1000
+
+	// maxBufferedDiffs bounds the events held while a snapshot is in flight.
+	maxBufferedDiffs = 1000
+
+	// resyncBackoff is the minimum time between snapshot requests for a symbol.
+	resyncBackoff = time.Second
+)
+
+// DepthSnapshotFetcher fetches a REST orderbook snapshot (implemented by HTTPClient).
+type DepthSnapshotFetcher interface {
+	GetDepth(ctx context.Context, symbol string, limit int) (*DepthResponse, error)
+}
+
+// Ensure HTTPClient can seed diff-depth books.
+var _ DepthSnapshotFetcher = (*HTTPClient)(nil)
+
+// depthSyncState tracks one symbol's synchronization progress.
+type depthSyncState struct {
+	synced       bool
+	fetching     bool
+	lastAttempt  time.Time
+	lastUpdateID int64               // Final update ID applied to the book
+	buffer       []*DepthUpdateEvent // Diffs received while unsynced
+}
+
+// DepthSyncManager keeps local books consistent with Binance's diff-depth
+// stream (<symbol>@depth@100ms) using the documented algorithm:
+//
+//  1. Buffer diff events while fetching a REST snapshot.
+//  2. Refetch if the snapshot is older than the first buffered event (lastUpdateId < U).
+//  3. Drop buffered events already covered by the snapshot (u <= lastUpdateId).
+//  4. Apply the rest; each event must satisfy U <= lastUpdateId+1 <= u.
+//
+// Any gap in the U/u sequence (missed events, reconnects) triggers a resync.
+// Callbacks run with the manager's lock held, in stream order.
+type DepthSyncManager struct {
+	fetcher DepthSnapshotFetcher
+	limit   int
+	backoff time.Duration
+	logger  logger.LoggerInterface
+
+	onSnapshot func(symbol string, snapshot *DepthResponse)
+	onDiff     func(event *DepthUpdateEvent)
+
+	mu     sync.Mutex
+	states map[string]*depthSyncState
+
+	resyncs metric.Int64Counter
+}
+
+// NewDepthSyncManager creates a manager seeding books from fetcher with limit levels.
+func NewDepthSyncManager(fetcher DepthSnapshotFetcher, limit int, log logger.LoggerInterface) (*DepthSyncManager, error) {
+	if limit <= 0 {
+		limit = diffDepthSnapshotLimit
+	}
+
+	resyncs, err := otel.Meter(meterName).Int64Counter(
+		"binance_depth_resyncs_total",
+		metric.WithDescription("Diff-depth book resynchronizations (by symbol and reason)"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DepthSyncManager{
+		fetcher:    fetcher,
+		limit:      limit,
+		backoff:    resyncBackoff,
+		logger:     log,
+		onSnapshot: func(string, *DepthResponse) {},
+		onDiff:     func(*DepthUpdateEvent) {},
+		states:     make(map[string]*depthSyncState),
+		resyncs:    resyncs,
+	}, nil
+}
+
+// OnSnapshot registers the handler that replaces a symbol's book.
+func (m *DepthSyncManager) OnSnapshot(handler func(symbol string, snapshot *DepthResponse)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onSnapshot = handler
+}
+
+// OnDiff registers the handler that applies a validated diff to a symbol's book.
+func (m *DepthSyncManager) OnDiff(handler func(event *DepthUpdateEvent)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onDiff = handler
+}
+
+// Synced reports whether symbol's book is currently consistent with the stream.
+func (m *DepthSyncManager) Synced(symbol string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[symbol]
+	return ok && state.synced
+}
+
+// HandleEvent processes a diff-depth event from the WebSocket stream.
+func (m *DepthSyncManager) HandleEvent(ctx context.Context, event *DepthUpdateEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.states[event.Symbol]
+	if !ok {
+		state = &depthSyncState{}
+		m.states[event.Symbol] = state
+	}
+
+	if state.synced {
+		switch {
+		case event.FinalUpdateID <= state.lastUpdateID:
+			return // Already applied
+		case event.FirstUpdateID > state.lastUpdateID+1:
+			m.logger.Warn(ctx, "binance depth sequence gap, resyncing",
+				"symbol", event.Symbol,
+				"expected", state.lastUpdateID+1,
+				"got", event.FirstUpdateID)
+			m.resetLocked(ctx, event.Symbol, state, "gap")
+		default:
+			m.onDiff(event)
+			state.lastUpdateID = event.FinalUpdateID
+			return
+		}
+	}
+
+	if len(state.buffer) >= maxBufferedDiffs {
+		state.buffer = state.buffer[1:]
+	}
+	state.buffer = append(state.buffer, event)
+	m.fetchLocked(ctx, event.Symbol, state)
+}
+
+// resetLocked marks symbol unsynced and discards its buffer. Callers hold mu.
+func (m *DepthSyncManager) resetLocked(ctx context.Context, symbol string, state *depthSyncState, reason string) {
+	state.synced = false
+	state.buffer = nil
+	m.resyncs.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("symbol", symbol),
+		attribute.String("reason", reason),
+	))
+}
+
+// fetchLocked starts a snapshot request unless one is in flight or backing off.
+// Callers hold mu.
+func (m *DepthSyncManager) fetchLocked(ctx context.Context, symbol string, state *depthSyncState) {
+	if state.fetching || time.Since(state.lastAttempt) < m.backoff {
+		return
+	}
+	state.fetching = true
+	state.lastAttempt = time.Now()
+
+	go func() {
+		snapshot, err := m.fetcher.GetDepth(context.WithoutCancel(ctx), symbol, m.limit)
+		m.applySnapshot(ctx, symbol, snapshot, err)
+	}()
+}
+
+// applySnapshot seeds symbol's book from snapshot and replays buffered diffs.
+func (m *DepthSyncManager) applySnapshot(ctx context.Context, symbol string, snapshot *DepthResponse, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := m.states[symbol]
+	state.fetching = false
+
+	if err != nil {
+		// The next event retries once the backoff has passed
+		m.logger.Warn(ctx, "binance depth snapshot failed", "symbol", symbol, "error", err)
+		return
+	}
+
+	// Snapshot predates the buffered stream: fetch a newer one
+	if len(state.buffer) > 0 && snapshot.LastUpdateID < state.buffer[0].FirstUpdateID {
+		m.logger.Debug(ctx, "binance depth snapshot older than stream, refetching",
+			"symbol", symbol,
+			"snapshot", snapshot.LastUpdateID,
+			"first_event", state.buffer[0].FirstUpdateID)
+		m.fetchLocked(ctx, symbol, state)
+		return
+	}
+
+	// Drop events the snapshot already covers
+	pending := state.buffer[:0]
+	for _, event := range state.buffer {
+		if event.FinalUpdateID > snapshot.LastUpdateID {
+			pending = append(pending, event)
+		}
+	}
+
+	lastUpdateID := snapshot.LastUpdateID
+	for _, event := range pending {
+		if event.FirstUpdateID > lastUpdateID+1 {
+			m.logger.Warn(ctx, "binance depth buffer does not continue snapshot, resyncing",
+				"symbol", symbol,
+				"snapshot", snapshot.LastUpdateID,
+				"first_event", event.FirstUpdateID)
+			m.resetLocked(ctx, symbol, state, "snapshot_gap")
+			return
+		}
+		lastUpdateID = event.FinalUpdateID
+	}
+
+	m.onSnapshot(symbol, snapshot)
+	for _, event := range pending {
+		m.onDiff(event)
+	}
+
+	state.synced = true
+	state.lastUpdateID = lastUpdateID
+	state.buffer = nil
+
+	m.logger.Info(ctx, "binance depth book synchronized",
+		"symbol", symbol,
+		"snapshot", snapshot.LastUpdateID,
+		"replayed", len(pending))
+}
@@ -0,0 +1,169 @@
+package binance
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSnapshotFetcher serves queued snapshots, each released by the test.
+type fakeSnapshotFetcher struct {
+	mu        sync.Mutex
+	snapshots []*DepthResponse
+	calls     int
+	release   chan struct{}
+}
+
+func newFakeSnapshotFetcher(snapshots ...*DepthResponse) *fakeSnapshotFetcher {
+	return &fakeSnapshotFetcher{snapshots: snapshots, release: make(chan struct{})}
+}
+
+func (f *fakeSnapshotFetcher) GetDepth(ctx context.Context, symbol string, limit int) (*DepthResponse, error) {
+	<-f.release
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	snap := f.snapshots[min(f.calls, len(f.snapshots)-1)]
+	f.calls++
+	return snap, nil
+}
+
+func (f *fakeSnapshotFetcher) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// syncRecorder records the callbacks a DepthSyncManager emits.
+type syncRecorder struct {
+	mu        sync.Mutex
+	snapshots []int64
+	diffs     []int64 // FinalUpdateID of each applied diff
+}
+
+func (r *syncRecorder) attach(m *DepthSyncManager) {
+	m.OnSnapshot(func(symbol string, snapshot *DepthResponse) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.snapshots = append(r.snapshots, snapshot.LastUpdateID)
+	})
+	m.OnDiff(func(event *DepthUpdateEvent) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.diffs = append(r.diffs, event.FinalUpdateID)
+	})
+}
+
+func (r *syncRecorder) result() ([]int64, []int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64(nil), r.snapshots...), append([]int64(nil), r.diffs...)
+}
+
+func diffEvent(first, final int64) *DepthUpdateEvent {
+	return &DepthUpdateEvent{Symbol: "ETHUSDC", FirstUpdateID: first, FinalUpdateID: final}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func newTestSyncManager(t *testing.T, fetcher DepthSnapshotFetcher) (*DepthSyncManager, *syncRecorder) {
+	t.Helper()
+	m, err := NewDepthSyncManager(fetcher, 0, &mockLogger{})
+	if err != nil {
+		t.Fatalf("NewDepthSyncManager() error = %v", err)
+	}
+	m.backoff = 0
+	rec := &syncRecorder{}
+	rec.attach(m)
+	return m, rec
+}
+
+func TestDepthSyncManager_BuffersUntilSnapshot(t *testing.T) {
+	ctx := context.Background()
+	fetcher := newFakeSnapshotFetcher(&DepthResponse{LastUpdateID: 5})
+	m, rec := newTestSyncManager(t, fetcher)
+
+	m.HandleEvent(ctx, diffEvent(1, 5)) // Covered by the snapshot
+	m.HandleEvent(ctx, diffEvent(4, 8)) // Straddles lastUpdateId+1
+	if m.Synced("ETHUSDC") {
+		t.Fatal("Synced() = true before snapshot")
+	}
+
+	fetcher.release <- struct{}{}
+	waitFor(t, "sync", func() bool { return m.Synced("ETHUSDC") })
+
+	m.HandleEvent(ctx, diffEvent(9, 10))
+	m.HandleEvent(ctx, diffEvent(7, 10)) // Duplicate, ignored
+
+	snapshots, diffs := rec.result()
+	if !slices.Equal(snapshots, []int64{5}) {
+		t.Errorf("snapshots = %v, want [5]", snapshots)
+	}
+	if !slices.Equal(diffs, []int64{8, 10}) {
+		t.Errorf("diffs = %v, want [8 10]", diffs)
+	}
+}
+
+func TestDepthSyncManager_ResyncsOnGap(t *testing.T) {
+	ctx := context.Background()
+	fetcher := newFakeSnapshotFetcher(&DepthResponse{LastUpdateID: 10}, &DepthResponse{LastUpdateID: 20})
+	m, rec := newTestSyncManager(t, fetcher)
+
+	m.HandleEvent(ctx, diffEvent(10, 12))
+	fetcher.release <- struct{}{}
+	waitFor(t, "initial sync", func() bool { return m.Synced("ETHUSDC") })
+
+	// Events 13-14 were missed
+	m.HandleEvent(ctx, diffEvent(15, 16))
+	if m.Synced("ETHUSDC") {
+		t.Fatal("Synced() = true after sequence gap")
+	}
+	m.HandleEvent(ctx, diffEvent(17, 21))
+
+	fetcher.release <- struct{}{}
+	waitFor(t, "resync", func() bool { return m.Synced("ETHUSDC") })
+
+	snapshots, diffs := rec.result()
+	if !slices.Equal(snapshots, []int64{10, 20}) {
+		t.Errorf("snapshots = %v, want [10 20]", snapshots)
+	}
+	// 16 was buffered then dropped (covered by the second snapshot)
+	if !slices.Equal(diffs, []int64{12, 21}) {
+		t.Errorf("diffs = %v, want [12 21]", diffs)
+	}
+}
+
+func TestDepthSyncManager_RefetchesStaleSnapshot(t *testing.T) {
+	ctx := context.Background()
+	fetcher := newFakeSnapshotFetcher(&DepthResponse{LastUpdateID: 3}, &DepthResponse{LastUpdateID: 12})
+	m, rec := newTestSyncManager(t, fetcher)
+
+	m.HandleEvent(ctx, diffEvent(10, 11))
+	m.HandleEvent(ctx, diffEvent(12, 14))
+
+	// First snapshot predates the buffered stream
+	fetcher.release <- struct{}{}
+	fetcher.release <- struct{}{}
+	waitFor(t, "sync", func() bool { return m.Synced("ETHUSDC") })
+
+	if got := fetcher.Calls(); got != 2 {
+		t.Errorf("fetcher calls = %d, want 2", got)
+	}
+	snapshots, diffs := rec.result()
+	if !slices.Equal(snapshots, []int64{12}) {
+		t.Errorf("snapshots = %v, want [12]", snapshots)
+	}
+	if !slices.Equal(diffs, []int64{14}) {
+		t.Errorf("diffs = %v, want [14]", diffs)
+	}
+}
@@ -22,7 +22,9 @@ const (
 	BaseAPIURLUS = "https://api.binance.us"
 
 	// Endpoints
-	depthEndpoint = "/api/v3/depth"
+	depthEndpoint        = "/api/v3/depth"
+	exchangeInfoEndpoint = "/api/v3/exchangeInfo"
+	serverTimeEndpoint   = "/api/v3/time"
 
 	// Default HTTP client settings
 	httpTimeout = 10 * time.Second
@@ -30,8 +32,9 @@ const (
 
 // HTTPClientConfig holds configuration for the Binance HTTP client.
 type HTTPClientConfig struct {
-	BaseURL string        // API base URL (empty = default)
-	Timeout time.Duration // Request timeout
+	BaseURL         string        // API base URL (empty = default)
+	Timeout         time.Duration // Request timeout
+	WeightPerMinute int           // Request weight budget (zero = Binance's 6000/min)
 }
 
 // DefaultHTTPClientConfig returns sensible defaults.
@@ -46,6 +49,7 @@ func DefaultHTTPClientConfig() HTTPClientConfig {
 type HTTPClient struct {
 	client httpclient.Client
 	config HTTPClientConfig
+	limits *restLimiter
 	logger logger.LoggerInterface
 	tracer trace.Tracer
 }
@@ -77,9 +81,15 @@ func NewHTTPClient(cfg HTTPClientConfig, log logger.LoggerInterface) (*HTTPClien
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
+	limits, err := newRestLimiter(cfg.WeightPerMinute, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rate limiter: %w", err)
+	}
+
 	return &HTTPClient{
 		client: client,
 		config: cfg,
+		limits: limits,
 		logger: log,
 		tracer: tracer,
 	}, nil
@@ -109,6 +119,11 @@ func (c *HTTPClient) GetDepth(ctx context.Context, symbol string, limit int) (*D
 		limit = 20 // Default to 20 levels
 	}
 
+	if err := c.limits.wait(ctx, depthWeight(limit)); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	var result DepthResponse
 	resp, err := c.client.NewRequestWithOptions(
 		httpclient.WithLabels(
@@ -122,6 +137,13 @@ func (c *HTTPClient) GetDepth(ctx context.Context, symbol string, limit int) (*D
 		SetResult(&result).
 		Get(ctx, depthEndpoint)
 
+	if resp != nil {
+		if limitErr := c.limits.observe(ctx, resp.StatusCode, resp.Header); limitErr != nil {
+			span.RecordError(limitErr)
+			return nil, limitErr
+		}
+	}
+
 	if err != nil {
 		span.RecordError(err)
 		return nil, apperror.New(apperror.CodeBinanceConnectionFailed,
@@ -148,6 +170,120 @@ func (c *HTTPClient) GetDepth(ctx context.Context, symbol string, limit int) (*D
 	return &result, nil
 }
 
+// SymbolStatusTrading is the exchangeInfo status of a symbol open for trading.
+const SymbolStatusTrading = "TRADING"
+
+// errCodeInvalidSymbol is the API error for a symbol Binance doesn't list.
+const errCodeInvalidSymbol = -1121
+
+// SymbolInfo is the exchangeInfo entry of one symbol.
+type SymbolInfo struct {
+	Symbol     string `json:"symbol"`
+	Status     string `json:"status"` // TRADING, BREAK, HALT, ...
+	BaseAsset  string `json:"baseAsset"`
+	QuoteAsset string `json:"quoteAsset"`
+}
+
+// ExchangeInfoResponse is the REST API response for exchange information.
+type ExchangeInfoResponse struct {
+	Symbols []SymbolInfo `json:"symbols"`
+}
+
+// GetExchangeInfo fetches the exchangeInfo entries of symbols. Binance
+// rejects the whole request with code -1121 if any symbol is unknown; the
+// *BinanceAPIError is kept as the cause.
+func (c *HTTPClient) GetExchangeInfo(ctx context.Context, symbols []string) (*ExchangeInfoResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "binance.http.get_exchange_info",
+		trace.WithAttributes(attribute.StringSlice("symbols", symbols)),
+	)
+	defer span.End()
+
+	param, err := json.Marshal(symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.limits.wait(ctx, exchangeInfoWeight); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var result ExchangeInfoResponse
+	resp, err := c.client.NewRequestWithOptions(
+		httpclient.WithLabels(httpclient.NewLabel("endpoint", "exchangeInfo")),
+		httpclient.WithResponseErrorHandler(binanceErrorHandler),
+	).
+		SetQueryParam("symbols", string(param)).
+		SetResult(&result).
+		Get(ctx, exchangeInfoEndpoint)
+
+	if resp != nil {
+		if limitErr := c.limits.observe(ctx, resp.StatusCode, resp.Header); limitErr != nil {
+			span.RecordError(limitErr)
+			return nil, limitErr
+		}
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, apperror.New(apperror.CodeBinanceConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to fetch exchange info from REST API"))
+	}
+
+	if resp.IsError() {
+		return nil, apperror.New(apperror.CodeBinanceConnectionFailed,
+			apperror.WithContext(fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.String())))
+	}
+
+	return &result, nil
+}
+
+// ServerTimeResponse is the REST API response for the server time.
+type ServerTimeResponse struct {
+	ServerTime int64 `json:"serverTime"` // Unix milliseconds
+}
+
+// GetServerTime fetches Binance's current time.
+func (c *HTTPClient) GetServerTime(ctx context.Context) (time.Time, error) {
+	ctx, span := c.tracer.Start(ctx, "binance.http.get_server_time")
+	defer span.End()
+
+	if err := c.limits.wait(ctx, serverTimeWeight); err != nil {
+		span.RecordError(err)
+		return time.Time{}, err
+	}
+
+	var result ServerTimeResponse
+	resp, err := c.client.NewRequestWithOptions(
+		httpclient.WithLabels(httpclient.NewLabel("endpoint", "time")),
+		httpclient.WithResponseErrorHandler(binanceErrorHandler),
+	).
+		SetResult(&result).
+		Get(ctx, serverTimeEndpoint)
+
+	if resp != nil {
+		if limitErr := c.limits.observe(ctx, resp.StatusCode, resp.Header); limitErr != nil {
+			span.RecordError(limitErr)
+			return time.Time{}, limitErr
+		}
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		return time.Time{}, apperror.New(apperror.CodeBinanceConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to fetch server time from REST API"))
+	}
+
+	if resp.IsError() {
+		return time.Time{}, apperror.New(apperror.CodeBinanceConnectionFailed,
+			apperror.WithContext(fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.String())))
+	}
+
+	return time.UnixMilli(result.ServerTime), nil
+}
+
 // ToPartialDepthEvent converts a DepthResponse to a PartialDepthEvent.
 // This allows the HTTP response to be processed the same way as WebSocket data.
 func (d *DepthResponse) ToPartialDepthEvent(symbol string) *PartialDepthEvent {
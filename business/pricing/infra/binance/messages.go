@@ -151,6 +151,27 @@ func ParseOrderbookLevels(raw [][]string) ([]OrderbookLevel, error) {
 	return levels, nil
 }
 
+// ParseDiffLevels converts diff depth [price, qty] pairs to OrderbookLevels.
+// Unlike ParseOrderbookLevels it keeps zero quantities, which remove a level.
+func ParseDiffLevels(raw [][]string) ([]OrderbookLevel, error) {
+	levels := make([]OrderbookLevel, 0, len(raw))
+	for _, r := range raw {
+		if len(r) < 2 {
+			continue
+		}
+		price, err := decimal.NewFromString(r[0])
+		if err != nil {
+			return nil, err
+		}
+		qty, err := decimal.NewFromString(r[1])
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, OrderbookLevel{Price: price, Quantity: qty})
+	}
+	return levels, nil
+}
+
 // REST API responses (for initial orderbook snapshot)
 
 // OrderbookSnapshot is the REST API response for orderbook.
@@ -173,6 +194,12 @@ func DepthStream(symbol string, speedMs int) string {
 	return lowercase(symbol) + "@depth20@" + strconv.Itoa(speedMs) + "ms"
 }
 
+// DiffDepthStream returns the diff depth stream name for a symbol.
+// Example: "ethusdc@depth@100ms"
+func DiffDepthStream(symbol string, speedMs int) string {
+	return lowercase(symbol) + "@depth@" + strconv.Itoa(speedMs) + "ms"
+}
+
 // BookTickerStream returns the bookTicker stream name for a symbol.
 func BookTickerStream(symbol string) string {
 	return lowercase(symbol) + "@bookTicker"
@@ -3,34 +3,74 @@ package binance
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/fd1az/arbitrage-bot/business/pricing/app"
 	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
 	"github.com/fd1az/arbitrage-bot/internal/apperror"
 	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/fd1az/arbitrage-bot/internal/wsconn"
 	"github.com/shopspring/decimal"
 )
 
 // Ensure Provider implements CEXProvider.
 var _ app.CEXProvider = (*Provider)(nil)
 
+// Ensure Provider reports which books it subscribed to.
+var _ app.Subscriber = (*Provider)(nil)
+
+// Ensure Provider exposes its books for troubleshooting.
+var _ app.BookInspector = (*Provider)(nil)
+
 // ProviderConfig holds configuration for the Binance provider.
 type ProviderConfig struct {
 	WebSocketURL   string        // WebSocket base URL (empty = default)
 	HTTPURL        string        // REST API base URL (empty = default)
 	Symbols        []string      // Trading symbols (e.g., "ETHUSDC", "BTCUSDC")
 	DepthSpeedMs   int           // Depth update speed (100ms recommended)
-	SnapshotDepth  int           // Number of orderbook levels to maintain
+	SnapshotDepth  int           // Orderbook levels fetched over REST
 	StaleTimeout   time.Duration // How long before data is considered stale
 	EnableFallback bool          // Enable HTTP fallback when WS data is stale
+	DiffDepth      bool          // Maintain full books from the diff-depth stream (see DepthSyncManager)
+	ProxyURL       string        // Proxy for the WebSocket stream (empty = direct)
+	Compression    string        // permessage-deflate mode for the stream (empty = context takeover)
+
+	// SymbolOverrides maps pairs ("BASE-QUOTE") to Binance symbols that
+	// aren't simply BASE+QUOTE
+	SymbolOverrides map[string]string
+
+	// InvalidSymbols is what Connect does with symbols exchangeInfo doesn't
+	// list as trading: InvalidSymbolsDrop, InvalidSymbolsFail or
+	// InvalidSymbolsIgnore (empty = ignore, no check)
+	InvalidSymbols string
+
+	// ClockSkew is what Connect does when the local clock is more than
+	// MaxClockSkew off from /api/v3/time: ClockSkewWarn, ClockSkewFail or
+	// ClockSkewIgnore (empty = ignore, no check)
+	ClockSkew    string
+	MaxClockSkew time.Duration // Tolerated skew (0 = 1s)
+
+	// Clock receives the offset measured on connect (nil = a new clock,
+	// see Provider.Clock)
+	Clock *ServerClock
+
+	// RejectPartialFills fails GetEffectivePrice with a PartialFillError
+	// when the book can't fill the size, instead of averaging what it can
+	RejectPartialFills bool
+
+	// MaxBookLevels caps the levels kept per side of each book, whatever
+	// feeds it (0 = the feed's own depth; 1000 with DiffDepth)
+	MaxBookLevels int
 }
 
 // DefaultProviderConfig returns sensible defaults.
@@ -56,18 +96,28 @@ type orderbookState struct {
 type Provider struct {
 	config     ProviderConfig
 	logger     logger.LoggerInterface
-	client     *Client     // WebSocket client
-	httpClient *HTTPClient // HTTP client for fallback
-
-	// Orderbook state per symbol
+	client     *Client           // WebSocket client
+	httpClient *HTTPClient       // HTTP client for fallback
+	restClient *HTTPClient       // exchangeInfo lookups (nil = symbols not validated)
+	timeClient *HTTPClient       // Server time lookups (nil = clock skew not checked)
+	depthSync  *DepthSyncManager // Diff-depth synchronization (nil = @depth20 snapshots)
+	sequences  *sequenceTracker  // Diff-depth update ID gap detection (nil = @depth20 snapshots)
+
+	// Orderbook state per subscribed symbol
 	orderbooks map[string]*orderbookState
+	tradable   []string // Subscribed symbols
 	booksMu    sync.RWMutex
 
 	// Asset registry for conversions
 	registry *asset.Registry
+	symbols  *symbolMap
+
+	maxLevels int          // Levels kept per side (see MaxBookLevels)
+	clock     *ServerClock // Binance's clock, offset measured on connect
 
 	// Observability
-	tracer trace.Tracer
+	tracer     trace.Tracer
+	bookLevels metric.Int64Gauge
 }
 
 // NewProvider creates a new Binance CEX provider.
@@ -82,6 +132,9 @@ func NewProvider(cfg ProviderConfig, log logger.LoggerInterface) (*Provider, err
 		BaseURL:      wsURL,
 		Symbols:      cfg.Symbols,
 		DepthSpeedMs: cfg.DepthSpeedMs,
+		DiffDepth:    cfg.DiffDepth,
+		ProxyURL:     cfg.ProxyURL,
+		Compression:  wsconn.CompressionMode(cfg.Compression),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
@@ -104,14 +157,88 @@ func NewProvider(cfg ProviderConfig, log logger.LoggerInterface) (*Provider, err
 		}
 	}
 
+	// Diff-depth books are seeded from REST snapshots, so they need a client
+	// even when the fallback is disabled
+	var depthSync *DepthSyncManager
+	var sequences *sequenceTracker
+	if cfg.DiffDepth {
+		fetcher := httpClient
+		if fetcher == nil {
+			fetcher, err = NewHTTPClient(HTTPClientConfig{BaseURL: cfg.HTTPURL}, log)
+			if err != nil {
+				return nil, err
+			}
+		}
+		depthSync, err = NewDepthSyncManager(fetcher, diffDepthSnapshotLimit, log)
+		if err != nil {
+			return nil, err
+		}
+		sequences, err = newSequenceTracker(log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var restClient *HTTPClient
+	switch cfg.InvalidSymbols {
+	case InvalidSymbolsDrop, InvalidSymbolsFail:
+		restClient = httpClient
+		if restClient == nil {
+			restClient, err = NewHTTPClient(HTTPClientConfig{BaseURL: cfg.HTTPURL}, log)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var timeClient *HTTPClient
+	switch cfg.ClockSkew {
+	case ClockSkewWarn, ClockSkewFail:
+		timeClient = httpClient
+		if timeClient == nil {
+			timeClient = restClient
+		}
+		if timeClient == nil {
+			timeClient, err = NewHTTPClient(HTTPClientConfig{BaseURL: cfg.HTTPURL}, log)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	clock := cfg.Clock
+	if clock == nil {
+		clock = &ServerClock{}
+	}
+
+	registry := asset.DefaultRegistry()
+	symbols, err := newSymbolMap(cfg.SymbolOverrides, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	bookLevels, err := newBookLevelsGauge()
+	if err != nil {
+		return nil, err
+	}
+
 	p := &Provider{
 		config:     cfg,
 		logger:     log,
 		client:     client,
 		httpClient: httpClient,
+		restClient: restClient,
+		timeClient: timeClient,
+		depthSync:  depthSync,
+		sequences:  sequences,
 		orderbooks: make(map[string]*orderbookState),
-		registry:   asset.DefaultRegistry(),
+		tradable:   slices.Clone(cfg.Symbols),
+		registry:   registry,
+		symbols:    symbols,
+		maxLevels:  maxBookLevels(cfg),
+		clock:      clock,
 		tracer:     otel.Tracer(tracerName),
+		bookLevels: bookLevels,
 	}
 
 	// Initialize orderbook state for each symbol
@@ -125,12 +252,27 @@ func NewProvider(cfg ProviderConfig, log logger.LoggerInterface) (*Provider, err
 	// Register handlers
 	client.OnBookTicker(p.handleBookTicker)
 	client.OnDepthUpdate(p.handleDepthUpdate)
+	if depthSync != nil {
+		client.OnDiffDepth(func(event *DepthUpdateEvent) {
+			sequences.observe(context.Background(), event)
+			depthSync.HandleEvent(context.Background(), event)
+		})
+		depthSync.OnSnapshot(p.handleDepthSnapshot)
+		depthSync.OnDiff(p.handleDiffDepth)
+	}
 
 	return p, nil
 }
 
-// Connect establishes connection to Binance.
+// Connect checks the clock skew, validates the configured symbols and
+// establishes the connection to Binance.
 func (p *Provider) Connect(ctx context.Context) error {
+	if err := p.checkClockSkew(ctx); err != nil {
+		return err
+	}
+	if err := p.validateSymbols(ctx); err != nil {
+		return err
+	}
 	return p.client.Connect(ctx)
 }
 
@@ -139,6 +281,21 @@ func (p *Provider) Close() error {
 	return p.client.Close()
 }
 
+// Health reports the health of the underlying WebSocket client.
+func (p *Provider) Health() health.SourceHealth {
+	return p.client.Health()
+}
+
+// Latency returns the moving average WebSocket round trip.
+func (p *Provider) Latency() time.Duration {
+	return p.client.Latency()
+}
+
+// ConnectionStats reports the stream connection's stability this session.
+func (p *Provider) ConnectionStats() health.ConnectionStats {
+	return p.client.ConnectionStats()
+}
+
 // GetOrderbook retrieves the current orderbook for a trading pair.
 func (p *Provider) GetOrderbook(ctx context.Context, pair domain.Pair) (*domain.Orderbook, error) {
 	ctx, span := p.tracer.Start(ctx, "binance.get_orderbook",
@@ -146,7 +303,7 @@ func (p *Provider) GetOrderbook(ctx context.Context, pair domain.Pair) (*domain.
 	)
 	defer span.End()
 
-	symbol := pairToSymbol(pair)
+	symbol := p.symbols.symbol(pair)
 
 	p.booksMu.RLock()
 	state, ok := p.orderbooks[symbol]
@@ -212,6 +369,44 @@ func (p *Provider) GetOrderbook(ctx context.Context, pair domain.Pair) (*domain.
 	return ob, nil
 }
 
+// Subscribes reports whether pair's book is subscribed.
+func (p *Provider) Subscribes(pair domain.Pair) bool {
+	p.booksMu.RLock()
+	defer p.booksMu.RUnlock()
+	_, ok := p.orderbooks[p.symbols.symbol(pair)]
+	return ok
+}
+
+// BookSnapshots returns up to depth levels per side of every subscribed
+// book, sorted by symbol. Stale books are returned as they are; LastUpdate
+// tells how old they are.
+func (p *Provider) BookSnapshots(depth int) []app.BookSnapshot {
+	p.booksMu.RLock()
+	symbols := make([]string, 0, len(p.orderbooks))
+	states := make(map[string]*orderbookState, len(p.orderbooks))
+	for symbol, state := range p.orderbooks {
+		symbols = append(symbols, symbol)
+		states[symbol] = state
+	}
+	p.booksMu.RUnlock()
+	sort.Strings(symbols)
+
+	books := make([]app.BookSnapshot, 0, len(symbols))
+	for _, symbol := range symbols {
+		state := states[symbol]
+		state.mu.RLock()
+		books = append(books, app.BookSnapshot{
+			Venue:      domain.VenueBinance,
+			Symbol:     symbol,
+			Bids:       append([]domain.OrderbookLevel(nil), state.bids[:min(depth, len(state.bids))]...),
+			Asks:       append([]domain.OrderbookLevel(nil), state.asks[:min(depth, len(state.asks))]...),
+			LastUpdate: state.lastUpdate,
+		})
+		state.mu.RUnlock()
+	}
+	return books
+}
+
 // getOrderbookViaHTTP fetches the orderbook via REST API fallback.
 func (p *Provider) getOrderbookViaHTTP(ctx context.Context, pair domain.Pair, symbol string, span trace.Span) (*domain.Orderbook, error) {
 	depth, err := p.httpClient.GetDepth(ctx, symbol, p.config.SnapshotDepth)
@@ -248,22 +443,22 @@ func (p *Provider) getOrderbookViaHTTP(ctx context.Context, pair domain.Pair, sy
 		asks = append(asks, domain.OrderbookLevel{Price: level.Price, Amount: amt})
 	}
 
-	// Update the cached state with HTTP data
+	// Update the cached state with HTTP data (diff-depth books are owned by
+	// the sync manager)
 	p.booksMu.RLock()
 	state, ok := p.orderbooks[symbol]
 	p.booksMu.RUnlock()
-	if ok {
+	if ok && p.depthSync == nil {
 		state.mu.Lock()
-		state.bids = bids
-		state.asks = asks
-		state.lastUpdate = time.Now()
+		state.setLevels(bids, asks, p.maxLevels)
+		p.recordBookSize(symbol, state)
 		state.mu.Unlock()
 	}
 
 	ob := &domain.Orderbook{
 		Pair:      pair,
-		Bids:      bids,
-		Asks:      asks,
+		Bids:      bids[:min(len(bids), p.maxLevels)],
+		Asks:      asks[:min(len(asks), p.maxLevels)],
 		Timestamp: time.Now(),
 	}
 
@@ -333,20 +528,27 @@ func (p *Provider) GetEffectivePrice(ctx context.Context, pair domain.Pair, size
 	// Average price = total cost / total filled
 	avgPrice := totalCost.Div(totalFilled)
 
-	// Warn if not fully filled
+	// Reject or warn if the book can't fill the size
 	if remaining.IsPositive() {
+		if p.config.RejectPartialFills {
+			fillErr := &domain.PartialFillError{Venue: domain.VenueBinance, Pair: pair, Side: side, Requested: size, Filled: totalFilled}
+			span.RecordError(fillErr)
+			return nil, apperror.New(apperror.CodeInsufficientLiquidity,
+				apperror.WithCause(fillErr),
+				apperror.WithContext(fillErr.Error()))
+		}
 		p.logger.Warn(ctx, "partial fill in effective price calculation",
 			"requested", size.String(),
 			"filled", totalFilled.String(),
 			"remaining", remaining.String())
 	}
 
-	// Build Price with asset types
+	// Build Price with asset types, dated by the book it was derived from
 	baseAsset, quoteAsset := pairToAssets(pair, p.registry)
 	sizeAmount, _ := asset.ParseDecimal(baseAsset, totalFilled)
-	rate := asset.NewPriceNow(baseAsset, quoteAsset, avgPrice)
+	rate := asset.NewPrice(baseAsset, quoteAsset, avgPrice, ob.Timestamp)
 
-	price := domain.NewPrice(rate, sizeAmount, side, "binance")
+	price := domain.NewPrice(rate, sizeAmount, side, domain.VenueBinance)
 
 	span.SetAttributes(
 		attribute.String("effective_price", avgPrice.String()),
@@ -380,6 +582,12 @@ func (p *Provider) handleBookTicker(event *BookTickerEvent) {
 	askPrice, _ := event.ParseAskPrice()
 	askQty, _ := event.ParseAskQty()
 
+	// Diff-depth books are kept exact by the sync manager; patching the top
+	// level here would desynchronize them
+	if p.depthSync != nil {
+		return
+	}
+
 	// Get assets for amounts
 	baseAsset := p.guessBaseAsset(event.Symbol)
 
@@ -451,9 +659,66 @@ func (p *Provider) handleDepthUpdate(event *PartialDepthEvent) {
 	defer state.mu.Unlock()
 
 	// Replace entire orderbook (partial book sends complete snapshot)
-	state.bids = bids
-	state.asks = asks
-	state.lastUpdate = time.Now()
+	state.setLevels(bids, asks, p.maxLevels)
+	p.recordBookSize(event.Symbol, state)
+}
+
+// handleDepthSnapshot replaces a diff-depth book with a REST snapshot.
+func (p *Provider) handleDepthSnapshot(symbol string, snapshot *DepthResponse) {
+	p.booksMu.RLock()
+	state, ok := p.orderbooks[symbol]
+	p.booksMu.RUnlock()
+	if !ok {
+		return
+	}
+
+	bidLevels, err := ParseOrderbookLevels(snapshot.Bids)
+	if err != nil {
+		p.logger.Debug(context.Background(), "failed to parse snapshot bid levels", "error", err)
+	}
+	askLevels, err := ParseOrderbookLevels(snapshot.Asks)
+	if err != nil {
+		p.logger.Debug(context.Background(), "failed to parse snapshot ask levels", "error", err)
+	}
+
+	baseAsset := p.guessBaseAsset(symbol)
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.setLevels(
+		applyOrderbookUpdates(nil, bidLevels, baseAsset, true, p.maxLevels),
+		applyOrderbookUpdates(nil, askLevels, baseAsset, false, p.maxLevels),
+		p.maxLevels)
+	p.recordBookSize(symbol, state)
+}
+
+// handleDiffDepth applies a validated diff-depth event to its book.
+func (p *Provider) handleDiffDepth(event *DepthUpdateEvent) {
+	p.booksMu.RLock()
+	state, ok := p.orderbooks[event.Symbol]
+	p.booksMu.RUnlock()
+	if !ok {
+		return
+	}
+
+	bidLevels, err := ParseDiffLevels(event.Bids)
+	if err != nil {
+		p.logger.Debug(context.Background(), "failed to parse diff bid levels", "error", err)
+	}
+	askLevels, err := ParseDiffLevels(event.Asks)
+	if err != nil {
+		p.logger.Debug(context.Background(), "failed to parse diff ask levels", "error", err)
+	}
+
+	baseAsset := p.guessBaseAsset(event.Symbol)
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.setLevels(
+		applyOrderbookUpdates(state.bids, bidLevels, baseAsset, true, p.maxLevels),
+		applyOrderbookUpdates(state.asks, askLevels, baseAsset, false, p.maxLevels),
+		p.maxLevels)
+	p.recordBookSize(event.Symbol, state)
 }
 
 // applyOrderbookUpdates merges updates into the current orderbook.
@@ -500,8 +765,13 @@ func applyOrderbookUpdates(current []domain.OrderbookLevel, updates []OrderbookL
 	return result
 }
 
-// guessBaseAsset attempts to determine the base asset from symbol.
+// guessBaseAsset determines the base asset of symbol, from the configured
+// overrides or else by stripping a known quote suffix.
 func (p *Provider) guessBaseAsset(symbol string) *asset.Asset {
+	if a, ok := p.symbols.base(symbol); ok {
+		return a
+	}
+
 	// Common quote assets
 	quotes := []string{"USDC", "USDT", "BUSD", "USD"}
 	for _, q := range quotes {
@@ -3,12 +3,14 @@ package binance
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
 	"github.com/fd1az/arbitrage-bot/internal/asset"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
 	"github.com/shopspring/decimal"
@@ -200,6 +202,49 @@ func TestProvider_FallbackDisabled(t *testing.T) {
 	}
 }
 
+// TestProvider_RejectPartialFills tests that a size deeper than the book
+// fails with a PartialFillError only when partial fills are rejected.
+func TestProvider_RejectPartialFills(t *testing.T) {
+	pair := domain.Pair{Base: asset.ETH, Quote: asset.USDC}
+	for _, reject := range []bool{false, true} {
+		cfg := DefaultProviderConfig([]string{"ETHUSDC"})
+		cfg.EnableFallback = false
+		cfg.RejectPartialFills = reject
+
+		provider, err := NewProvider(cfg, &mockLogger{})
+		if err != nil {
+			t.Fatalf("failed to create provider: %v", err)
+		}
+		provider.handleDepthUpdate(&PartialDepthEvent{
+			Symbol: "ETHUSDC",
+			Bids:   [][]string{{"3400.00", "1.0"}},
+			Asks:   [][]string{{"3401.00", "0.5"}, {"3402.00", "0.5"}},
+		})
+
+		price, err := provider.GetEffectivePrice(context.Background(), pair, decimal.NewFromInt(2), domain.SideBuy)
+		if !reject {
+			if err != nil {
+				t.Fatalf("expected a partial-fill price, got %v", err)
+			}
+			if !price.Size.ToDecimal().Equal(decimal.NewFromInt(1)) {
+				t.Errorf("expected the filled size 1, got %s", price.Size.ToDecimal())
+			}
+			continue
+		}
+
+		var fillErr *domain.PartialFillError
+		if !errors.As(err, &fillErr) {
+			t.Fatalf("expected a PartialFillError, got %v", err)
+		}
+		if !fillErr.Requested.Equal(decimal.NewFromInt(2)) || !fillErr.Filled.Equal(decimal.NewFromInt(1)) {
+			t.Errorf("expected 1 of 2 filled, got %s of %s", fillErr.Filled, fillErr.Requested)
+		}
+		if apperror.GetCode(err) != apperror.CodeInsufficientLiquidity {
+			t.Errorf("expected %s, got %s", apperror.CodeInsufficientLiquidity, apperror.GetCode(err))
+		}
+	}
+}
+
 // TestHTTPClient_GetDepth tests the HTTP client depth endpoint.
 func TestHTTPClient_GetDepth(t *testing.T) {
 	mockResponse := DepthResponse{
@@ -324,3 +369,47 @@ func TestDepthResponse_ToPartialDepthEvent(t *testing.T) {
 		t.Errorf("expected 1 bid and 1 ask, got %d bids and %d asks", len(event.Bids), len(event.Asks))
 	}
 }
+
+// TestProvider_BookSnapshots tests that snapshots are capped at depth and
+// report books that have not received data yet.
+func TestProvider_BookSnapshots(t *testing.T) {
+	cfg := ProviderConfig{
+		Symbols:       []string{"ETHUSDC", "BTCUSDC"},
+		SnapshotDepth: 20,
+		StaleTimeout:  5 * time.Second,
+	}
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	provider.handleDepthUpdate(&PartialDepthEvent{
+		Symbol: "ETHUSDC",
+		Bids:   [][]string{{"3000.00", "1.0"}, {"2999.50", "2.0"}, {"2999.00", "3.0"}},
+		Asks:   [][]string{{"3000.50", "1.5"}},
+	})
+
+	books := provider.BookSnapshots(2)
+	if len(books) != 2 {
+		t.Fatalf("expected 2 books, got %d", len(books))
+	}
+	if books[0].Symbol != "BTCUSDC" || books[1].Symbol != "ETHUSDC" {
+		t.Fatalf("expected books sorted by symbol, got %s, %s", books[0].Symbol, books[1].Symbol)
+	}
+
+	if !books[0].LastUpdate.IsZero() || len(books[0].Bids) != 0 {
+		t.Errorf("expected empty BTCUSDC book, got %+v", books[0])
+	}
+
+	eth := books[1]
+	if eth.Venue != domain.VenueBinance {
+		t.Errorf("expected venue %s, got %s", domain.VenueBinance, eth.Venue)
+	}
+	if len(eth.Bids) != 2 || len(eth.Asks) != 1 {
+		t.Errorf("expected 2 bids and 1 ask, got %d and %d", len(eth.Bids), len(eth.Asks))
+	}
+	if eth.LastUpdate.IsZero() {
+		t.Error("expected LastUpdate to be set")
+	}
+}
@@ -0,0 +1,210 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/fd1az/arbitrage-bot/internal/ratelimit"
+)
+
+const (
+	// defaultWeightPerMinute is Binance's spot REQUEST_WEIGHT limit per IP.
+	defaultWeightPerMinute = 6000
+
+	// maxRequestWeight is the heaviest request sent (depth with limit 5000);
+	// the bucket always holds at least this much so it can be admitted.
+	maxRequestWeight = 250
+
+	// weightHighWater is the share of the budget, as reported by Binance,
+	// above which requests are refused until the minute rolls over.
+	weightHighWater = 0.9
+
+	// usedWeightHeader reports the IP's weight used in the current minute.
+	usedWeightHeader = "X-MBX-USED-WEIGHT-1M"
+
+	// exchangeInfoWeight is the request weight of GET /api/v3/exchangeInfo.
+	exchangeInfoWeight = 20
+
+	// serverTimeWeight is the request weight of GET /api/v3/time.
+	serverTimeWeight = 1
+
+	// Backoff after a 429/418 without a Retry-After header, doubling on
+	// each consecutive rejection.
+	minRateLimitBackoff = time.Second
+	maxRateLimitBackoff = 2 * time.Minute
+)
+
+// Reasons recorded on binance_rest_rate_limited_total.
+const (
+	rateLimitReasonTooMany   = "429"        // Weight limit exceeded
+	rateLimitReasonBanned    = "418"        // IP banned after ignoring 429s
+	rateLimitReasonBackoff   = "backoff"    // Request refused locally while backing off
+	rateLimitReasonHighWater = "high_water" // Request refused locally near the weight budget
+)
+
+// depthWeight returns the request weight of GET /api/v3/depth for limit.
+func depthWeight(limit int) int {
+	switch {
+	case limit <= 100:
+		return 5
+	case limit <= 500:
+		return 25
+	case limit <= 1000:
+		return 50
+	default:
+		return maxRequestWeight
+	}
+}
+
+// restLimiter keeps REST calls within Binance's weight budget. Requests
+// take their weight from a token bucket refilled at the per-minute limit;
+// the bucket can't see weight spent by other processes on the same IP, so
+// the used weight Binance reports is checked too. Once Binance rejects a
+// request, every request fails fast until the Retry-After window (or an
+// exponential backoff) has passed.
+type restLimiter struct {
+	bucket          *ratelimit.Limiter
+	weightPerMinute int
+	logger          logger.LoggerInterface
+
+	mu          sync.Mutex
+	backoffTill time.Time
+	rejections  int       // Consecutive 429/418 responses
+	usedWeight  int       // Last X-MBX-USED-WEIGHT-1M value
+	usedAt      time.Time // When usedWeight was reported
+
+	rateLimited metric.Int64Counter
+	used        metric.Int64Gauge
+}
+
+// newRestLimiter creates a limiter admitting weightPerMinute (zero = default).
+func newRestLimiter(weightPerMinute int, log logger.LoggerInterface) (*restLimiter, error) {
+	if weightPerMinute <= 0 {
+		weightPerMinute = defaultWeightPerMinute
+	}
+
+	rateLimited, err := otel.Meter(meterName).Int64Counter(
+		"binance_rest_rate_limited_total",
+		metric.WithDescription("Binance REST requests rejected or refused for rate limits (by reason)"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := otel.Meter(meterName).Int64Gauge(
+		"binance_used_weight",
+		metric.WithDescription("Binance REST request weight used in the current minute (X-MBX-USED-WEIGHT-1M)"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	burst := max(weightPerMinute/10, maxRequestWeight)
+	return &restLimiter{
+		bucket:          ratelimit.NewWithBurst(float64(weightPerMinute)/60.0, burst),
+		weightPerMinute: weightPerMinute,
+		logger:          log,
+		rateLimited:     rateLimited,
+		used:            used,
+	}, nil
+}
+
+// wait blocks until weight is available, failing with CodeBinanceRateLimited
+// if ctx ends first. While backing off from a rejection it fails at once
+// without sending anything: requests during the window only extend the ban.
+// When Binance reports the minute's budget nearly spent, it fails with
+// CodeRateLimitExceeded until the next minute.
+func (l *restLimiter) wait(ctx context.Context, weight int) error {
+	l.mu.Lock()
+	remaining := time.Until(l.backoffTill)
+	used := l.currentUsedWeight(time.Now())
+	l.mu.Unlock()
+	if remaining > 0 {
+		l.rateLimited.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", rateLimitReasonBackoff)))
+		return apperror.New(apperror.CodeBinanceRateLimited,
+			apperror.WithContext(fmt.Sprintf("backing off for %s", remaining.Round(time.Millisecond))))
+	}
+
+	if float64(used+weight) > weightHighWater*float64(l.weightPerMinute) {
+		l.rateLimited.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", rateLimitReasonHighWater)))
+		return apperror.New(apperror.CodeRateLimitExceeded,
+			apperror.WithContext(fmt.Sprintf("binance weight %d of %d used this minute", used, l.weightPerMinute)))
+	}
+
+	if err := l.bucket.WaitN(ctx, weight); err != nil {
+		return apperror.New(apperror.CodeBinanceRateLimited,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("no request weight available for %d", weight)))
+	}
+	return nil
+}
+
+// currentUsedWeight returns the reported used weight if it belongs to now's
+// minute; Binance resets the counter on each calendar minute. Callers hold mu.
+func (l *restLimiter) currentUsedWeight(now time.Time) int {
+	if !l.usedAt.Truncate(time.Minute).Equal(now.Truncate(time.Minute)) {
+		return 0
+	}
+	return l.usedWeight
+}
+
+// observe inspects a response: it records the used weight header, then the
+// status. A 429 or 418 starts a backoff of the Retry-After duration, or an
+// exponential one when the header is missing, and is returned as
+// CodeBinanceRateLimited. Other statuses end any streak.
+func (l *restLimiter) observe(ctx context.Context, status int, header http.Header) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if used, err := strconv.Atoi(header.Get(usedWeightHeader)); err == nil {
+		l.usedWeight = used
+		l.usedAt = time.Now()
+		l.used.Record(ctx, int64(used))
+	}
+
+	reason := ""
+	switch status {
+	case http.StatusTooManyRequests:
+		reason = rateLimitReasonTooMany
+	case http.StatusTeapot:
+		reason = rateLimitReasonBanned
+	default:
+		l.rejections = 0
+		return nil
+	}
+
+	backoff := retryAfter(header)
+	if backoff <= 0 {
+		backoff = min(minRateLimitBackoff<<min(l.rejections, 16), maxRateLimitBackoff)
+	}
+	l.rejections++
+	l.backoffTill = time.Now().Add(backoff)
+
+	l.rateLimited.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+	l.logger.Warn(ctx, "binance REST rate limited, backing off",
+		"status", status,
+		"backoff", backoff,
+		"consecutive", l.rejections)
+
+	return apperror.New(apperror.CodeBinanceRateLimited,
+		apperror.WithContext(fmt.Sprintf("HTTP %d, retry after %s", status, backoff)))
+}
+
+// retryAfter parses a Retry-After header given in seconds (Binance's form).
+func retryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
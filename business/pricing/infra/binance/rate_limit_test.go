@@ -0,0 +1,135 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+func TestDepthWeight(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 20, want: 5},
+		{limit: 100, want: 5},
+		{limit: 500, want: 25},
+		{limit: 1000, want: 50},
+		{limit: 5000, want: 250},
+	}
+
+	for _, tt := range tests {
+		if got := depthWeight(tt.limit); got != tt.want {
+			t.Errorf("depthWeight(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+// TestHTTPClient_RetryAfter tests that a 429 is surfaced as a rate limit and
+// that requests inside the Retry-After window never reach Binance.
+func TestHTTPClient_RetryAfter(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"code":-1003,"msg":"Too many requests."}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(HTTPClientConfig{BaseURL: server.URL}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("NewHTTPClient failed: %v", err)
+	}
+
+	for i := range 3 {
+		_, err := client.GetDepth(context.Background(), "ETHUSDC", 20)
+		if code := apperror.GetCode(err); code != apperror.CodeBinanceRateLimited {
+			t.Fatalf("call %d: expected %s, got %v", i, apperror.CodeBinanceRateLimited, err)
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("expected 1 request during the backoff window, got %d", n)
+	}
+}
+
+func TestRestLimiter_Backoff(t *testing.T) {
+	limiter, err := newRestLimiter(0, &mockLogger{})
+	if err != nil {
+		t.Fatalf("newRestLimiter failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		header  http.Header
+		wantErr bool
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "retry_after", status: http.StatusTooManyRequests, header: http.Header{"Retry-After": {"2"}}, wantErr: true},
+		{name: "banned_without_header", status: http.StatusTeapot, header: http.Header{}, wantErr: true},
+		{name: "other_error", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limiter.observe(context.Background(), tt.status, tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("observe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if limiter.rejections != 0 {
+		t.Errorf("expected a non-rate-limit status to end the streak, got %d", limiter.rejections)
+	}
+}
+
+// TestHTTPClient_UsedWeight tests that a used weight near the budget stops
+// further requests before Binance has to reject them.
+func TestHTTPClient_UsedWeight(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(usedWeightHeader, "5500")
+		w.Write([]byte(`{"lastUpdateId":1,"bids":[["3400.00","1.0"]],"asks":[["3401.00","1.0"]]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(HTTPClientConfig{BaseURL: server.URL}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("NewHTTPClient failed: %v", err)
+	}
+
+	if _, err := client.GetDepth(context.Background(), "ETHUSDC", 20); err != nil {
+		t.Fatalf("first GetDepth failed: %v", err)
+	}
+	_, err = client.GetDepth(context.Background(), "ETHUSDC", 20)
+	if code := apperror.GetCode(err); code != apperror.CodeRateLimitExceeded {
+		t.Fatalf("expected %s above the high-water mark, got %v", apperror.CodeRateLimitExceeded, err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("expected 1 request to reach Binance, got %d", n)
+	}
+}
+
+func TestRestLimiter_UsedWeightResetsEachMinute(t *testing.T) {
+	limiter, err := newRestLimiter(0, &mockLogger{})
+	if err != nil {
+		t.Fatalf("newRestLimiter failed: %v", err)
+	}
+	limiter.usedWeight = 5900
+	limiter.usedAt = time.Date(2026, 1, 1, 12, 0, 30, 0, time.UTC)
+
+	if got := limiter.currentUsedWeight(time.Date(2026, 1, 1, 12, 0, 59, 0, time.UTC)); got != 5900 {
+		t.Errorf("same minute: used weight = %d, want 5900", got)
+	}
+	if got := limiter.currentUsedWeight(time.Date(2026, 1, 1, 12, 1, 0, 0, time.UTC)); got != 0 {
+		t.Errorf("next minute: used weight = %d, want 0", got)
+	}
+}
@@ -0,0 +1,68 @@
+package binance
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// sequenceTracker watches the U/u update IDs of the diff-depth stream and
+// reports gaps per symbol. It only observes: resynchronization stays with
+// DepthSyncManager, so the gap count reflects what the stream delivered
+// rather than what the book recovered from.
+type sequenceTracker struct {
+	logger logger.LoggerInterface
+
+	mu     sync.Mutex
+	lastID map[string]int64 // Final update ID last seen per symbol
+
+	gaps metric.Int64Counter
+}
+
+// newSequenceTracker creates a tracker exporting binance_depth_sequence_gaps_total.
+func newSequenceTracker(log logger.LoggerInterface) (*sequenceTracker, error) {
+	gaps, err := otel.Meter(meterName).Int64Counter(
+		"binance_depth_sequence_gaps_total",
+		metric.WithDescription("Non-contiguous diff-depth update IDs (by symbol)"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sequenceTracker{
+		logger: log,
+		lastID: make(map[string]int64),
+		gaps:   gaps,
+	}, nil
+}
+
+// observe records event and reports whether it skipped update IDs since the
+// previous event for its symbol. The first event per symbol only sets the
+// baseline; replayed events (u at or below the last seen ID) are ignored.
+func (t *sequenceTracker) observe(ctx context.Context, event *DepthUpdateEvent) bool {
+	t.mu.Lock()
+	last, seen := t.lastID[event.Symbol]
+	if seen && event.FinalUpdateID <= last {
+		t.mu.Unlock()
+		return false
+	}
+	t.lastID[event.Symbol] = event.FinalUpdateID
+	t.mu.Unlock()
+
+	if !seen || event.FirstUpdateID <= last+1 {
+		return false
+	}
+
+	t.gaps.Add(ctx, 1, metric.WithAttributes(attribute.String("symbol", event.Symbol)))
+	t.logger.Warn(ctx, "binance depth update IDs not contiguous, book may be stale",
+		"symbol", event.Symbol,
+		"expected", last+1,
+		"got", event.FirstUpdateID,
+		"missed", event.FirstUpdateID-last-1)
+	return true
+}
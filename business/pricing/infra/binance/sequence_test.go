@@ -0,0 +1,35 @@
+package binance
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSequenceTracker_Observe(t *testing.T) {
+	tracker, err := newSequenceTracker(&mockLogger{})
+	if err != nil {
+		t.Fatalf("newSequenceTracker failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		event   *DepthUpdateEvent
+		wantGap bool
+	}{
+		{name: "baseline", event: diffEvent(10, 12)},
+		{name: "contiguous", event: diffEvent(13, 15)},
+		{name: "replayed", event: diffEvent(11, 15)},
+		{name: "gap", event: diffEvent(20, 22), wantGap: true},
+		{name: "contiguous_after_gap", event: diffEvent(23, 23)},
+		{name: "other_symbol_baseline", event: &DepthUpdateEvent{Symbol: "WBTCUSDC", FirstUpdateID: 500, FinalUpdateID: 501}},
+		{name: "other_symbol_gap", event: &DepthUpdateEvent{Symbol: "WBTCUSDC", FirstUpdateID: 503, FinalUpdateID: 504}, wantGap: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tracker.observe(context.Background(), tt.event); got != tt.wantGap {
+				t.Errorf("observe() = %v, want %v", got, tt.wantGap)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// symbolMap resolves pairs to Binance symbols and symbols back to their base
+// asset. Configured overrides take precedence; other pairs fall back to
+// concatenating base and quote, and other symbols to guessing the base by
+// stripping a known quote suffix.
+type symbolMap struct {
+	symbols map[string]string       // "BASE-QUOTE" -> symbol
+	bases   map[string]*asset.Asset // symbol -> base asset
+}
+
+// newSymbolMap validates overrides ("BASE-QUOTE" -> symbol) against
+// registry: both assets must be known, so a typo fails at startup instead of
+// quietly pricing the wrong book.
+func newSymbolMap(overrides map[string]string, registry *asset.Registry) (*symbolMap, error) {
+	m := &symbolMap{
+		symbols: make(map[string]string, len(overrides)),
+		bases:   make(map[string]*asset.Asset, len(overrides)),
+	}
+	for pair, symbol := range overrides {
+		baseSymbol, quoteSymbol, ok := strings.Cut(strings.ToUpper(pair), "-")
+		if !ok {
+			return nil, apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("invalid symbol override pair %q (expected BASE-QUOTE)", pair)))
+		}
+		base, ok := registry.GetBySymbolAndChain(baseSymbol, asset.ChainIDEthereum)
+		if !ok {
+			return nil, apperror.New(apperror.CodeInvalidSymbol,
+				apperror.WithContext(fmt.Sprintf("symbol override %s: unknown base asset %s", pair, baseSymbol)))
+		}
+		if _, ok := registry.GetBySymbolAndChain(quoteSymbol, asset.ChainIDEthereum); !ok {
+			return nil, apperror.New(apperror.CodeInvalidSymbol,
+				apperror.WithContext(fmt.Sprintf("symbol override %s: unknown quote asset %s", pair, quoteSymbol)))
+		}
+		m.symbols[baseSymbol+"-"+quoteSymbol] = symbol
+		m.bases[symbol] = base
+	}
+	return m, nil
+}
+
+// symbol returns the Binance symbol for pair.
+func (m *symbolMap) symbol(pair domain.Pair) string {
+	if symbol, ok := m.symbols[pair.String()]; ok {
+		return symbol
+	}
+	return pairToSymbol(pair)
+}
+
+// base returns the overridden base asset of symbol, if any.
+func (m *symbolMap) base(symbol string) (*asset.Asset, bool) {
+	a, ok := m.bases[symbol]
+	return a, ok
+}
@@ -0,0 +1,65 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+func TestSymbolMap(t *testing.T) {
+	m, err := newSymbolMap(map[string]string{"WBTC-USDC": "BTCUSDC"}, asset.DefaultRegistry())
+	if err != nil {
+		t.Fatalf("newSymbolMap failed: %v", err)
+	}
+
+	if got := m.symbol(domain.Pair{Base: asset.WBTC, Quote: asset.USDC}); got != "BTCUSDC" {
+		t.Errorf("expected overridden symbol BTCUSDC, got %s", got)
+	}
+	if got := m.symbol(domain.Pair{Base: asset.ETH, Quote: asset.USDC}); got != "ETHUSDC" {
+		t.Errorf("expected default symbol ETHUSDC, got %s", got)
+	}
+
+	base, ok := m.base("BTCUSDC")
+	if !ok || base != asset.WBTC {
+		t.Errorf("expected BTCUSDC base WBTC, got %v", base)
+	}
+	if _, ok := m.base("ETHUSDC"); ok {
+		t.Error("expected no override for ETHUSDC")
+	}
+}
+
+func TestSymbolMap_UnknownAsset(t *testing.T) {
+	_, err := newSymbolMap(map[string]string{"FOO-USDC": "FOOUSDC"}, asset.DefaultRegistry())
+	if apperror.GetCode(err) != apperror.CodeInvalidSymbol {
+		t.Errorf("expected %s, got %v", apperror.CodeInvalidSymbol, err)
+	}
+}
+
+// TestProvider_SymbolOverride tests that books are read from the overridden
+// symbol and sized in its base asset.
+func TestProvider_SymbolOverride(t *testing.T) {
+	cfg := DefaultProviderConfig([]string{"BTCUSDC"})
+	cfg.EnableFallback = false
+	cfg.SymbolOverrides = map[string]string{"WBTC-USDC": "BTCUSDC"}
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	provider.handleDepthUpdate(&PartialDepthEvent{
+		Symbol: "BTCUSDC",
+		Bids:   [][]string{{"60000.00", "0.5"}},
+		Asks:   [][]string{{"60001.00", "0.25"}},
+	})
+
+	ob, err := provider.GetOrderbook(t.Context(), domain.Pair{Base: asset.WBTC, Quote: asset.USDC})
+	if err != nil {
+		t.Fatalf("GetOrderbook failed: %v", err)
+	}
+	if ob.Bids[0].Amount.Asset() != asset.WBTC {
+		t.Errorf("expected amounts in WBTC, got %s", ob.Bids[0].Amount.Asset().Symbol())
+	}
+}
@@ -0,0 +1,129 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// What Connect does with configured symbols that aren't trading
+// (see ProviderConfig.InvalidSymbols).
+const (
+	InvalidSymbolsIgnore = "ignore" // Subscribe without checking exchangeInfo
+	InvalidSymbolsDrop   = "drop"   // Log and skip them
+	InvalidSymbolsFail   = "fail"   // Refuse to connect
+)
+
+// symbolStatusUnknown marks symbols exchangeInfo doesn't list.
+const symbolStatusUnknown = "UNKNOWN"
+
+// checkSymbols looks symbols up in exchangeInfo. It returns those that are
+// TRADING, in order, and the status of the others. An unknown symbol fails
+// the whole request, so each is then looked up on its own to find it.
+func checkSymbols(ctx context.Context, client *HTTPClient, symbols []string) ([]string, map[string]string, error) {
+	info, err := client.GetExchangeInfo(ctx, symbols)
+	if isInvalidSymbol(err) {
+		info = &ExchangeInfoResponse{}
+		for _, symbol := range symbols {
+			one, err := client.GetExchangeInfo(ctx, []string{symbol})
+			if isInvalidSymbol(err) {
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			info.Symbols = append(info.Symbols, one.Symbols...)
+		}
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	statuses := make(map[string]string, len(info.Symbols))
+	for _, s := range info.Symbols {
+		statuses[s.Symbol] = s.Status
+	}
+
+	tradable := make([]string, 0, len(symbols))
+	rejected := make(map[string]string)
+	for _, symbol := range symbols {
+		status, ok := statuses[symbol]
+		switch {
+		case !ok:
+			rejected[symbol] = symbolStatusUnknown
+		case status != SymbolStatusTrading:
+			rejected[symbol] = status
+		default:
+			tradable = append(tradable, symbol)
+		}
+	}
+	return tradable, rejected, nil
+}
+
+// isInvalidSymbol reports whether err is Binance rejecting an unknown symbol.
+func isInvalidSymbol(err error) bool {
+	var apiErr *BinanceAPIError
+	return errors.As(err, &apiErr) && apiErr.Code == errCodeInvalidSymbol
+}
+
+// validateSymbols checks the configured symbols before subscribing, so a
+// typo surfaces at startup instead of as a book that never fills. Symbols
+// that aren't trading are dropped, or fail the connect in fail mode or when
+// none are left. If exchangeInfo can't be reached every symbol is kept.
+func (p *Provider) validateSymbols(ctx context.Context) error {
+	if p.restClient == nil {
+		return nil
+	}
+
+	tradable, rejected, err := checkSymbols(ctx, p.restClient, p.config.Symbols)
+	if err != nil {
+		p.logger.Warn(ctx, "binance symbol validation unavailable, subscribing to all symbols", "error", err)
+		return nil
+	}
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	invalid := make([]string, 0, len(rejected))
+	for symbol, status := range rejected {
+		invalid = append(invalid, fmt.Sprintf("%s (%s)", symbol, status))
+	}
+	slices.Sort(invalid)
+
+	if p.config.InvalidSymbols == InvalidSymbolsFail || len(tradable) == 0 {
+		return apperror.New(apperror.CodeInvalidSymbol,
+			apperror.WithContext("binance symbols not trading: "+strings.Join(invalid, ", ")))
+	}
+
+	p.logger.Warn(ctx, "dropping binance symbols that are not trading",
+		"symbols", invalid,
+		"tradable", tradable)
+	p.setSymbols(tradable)
+	return nil
+}
+
+// setSymbols narrows the subscription to symbols. It runs before the
+// client connects, so the stream URL is built from the new list.
+func (p *Provider) setSymbols(symbols []string) {
+	p.booksMu.Lock()
+	defer p.booksMu.Unlock()
+
+	for symbol := range p.orderbooks {
+		if !slices.Contains(symbols, symbol) {
+			delete(p.orderbooks, symbol)
+		}
+	}
+	p.tradable = symbols
+	p.client.config.Symbols = symbols
+}
+
+// Symbols returns the symbols the provider subscribes to: the configured
+// ones, less any dropped by validation on connect.
+func (p *Provider) Symbols() []string {
+	p.booksMu.RLock()
+	defer p.booksMu.RUnlock()
+	return slices.Clone(p.tradable)
+}
@@ -0,0 +1,138 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// newExchangeInfoServer serves exchangeInfo for the listed symbol statuses,
+// rejecting requests naming any other symbol with -1121 as Binance does.
+func newExchangeInfoServer(t *testing.T, statuses map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != exchangeInfoEndpoint {
+			t.Errorf("expected path %s, got %s", exchangeInfoEndpoint, r.URL.Path)
+		}
+		var symbols []string
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("symbols")), &symbols); err != nil {
+			t.Errorf("invalid symbols param: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		var resp ExchangeInfoResponse
+		for _, symbol := range symbols {
+			status, ok := statuses[symbol]
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+				return
+			}
+			resp.Symbols = append(resp.Symbols, SymbolInfo{Symbol: symbol, Status: status})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func newValidatingProvider(t *testing.T, url, mode string, symbols ...string) *Provider {
+	t.Helper()
+	cfg := DefaultProviderConfig(symbols)
+	cfg.HTTPURL = url
+	cfg.EnableFallback = false
+	cfg.InvalidSymbols = mode
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	return provider
+}
+
+func TestCheckSymbols(t *testing.T) {
+	server := newExchangeInfoServer(t, map[string]string{"ETHUSDC": "TRADING", "BTCUSDC": "TRADING", "OLDUSDC": "BREAK"})
+	defer server.Close()
+
+	client, err := NewHTTPClient(HTTPClientConfig{BaseURL: server.URL}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	tradable, rejected, err := checkSymbols(context.Background(), client, []string{"ETHUSDC", "ETHUSCD", "OLDUSDC", "BTCUSDC"})
+	if err != nil {
+		t.Fatalf("checkSymbols failed: %v", err)
+	}
+	if !slices.Equal(tradable, []string{"ETHUSDC", "BTCUSDC"}) {
+		t.Errorf("expected ETHUSDC and BTCUSDC tradable, got %v", tradable)
+	}
+	if rejected["ETHUSCD"] != symbolStatusUnknown || rejected["OLDUSDC"] != "BREAK" || len(rejected) != 2 {
+		t.Errorf("expected ETHUSCD unknown and OLDUSDC on break, got %v", rejected)
+	}
+}
+
+func TestProvider_ValidateSymbols(t *testing.T) {
+	server := newExchangeInfoServer(t, map[string]string{"ETHUSDC": "TRADING"})
+	defer server.Close()
+
+	t.Run("drop", func(t *testing.T) {
+		provider := newValidatingProvider(t, server.URL, InvalidSymbolsDrop, "ETHUSDC", "ETHUSCD")
+		if err := provider.validateSymbols(context.Background()); err != nil {
+			t.Fatalf("validateSymbols failed: %v", err)
+		}
+		if got := provider.Symbols(); !slices.Equal(got, []string{"ETHUSDC"}) {
+			t.Errorf("expected only ETHUSDC subscribed, got %v", got)
+		}
+		if !slices.Equal(provider.client.config.Symbols, []string{"ETHUSDC"}) {
+			t.Errorf("expected the stream narrowed to ETHUSDC, got %v", provider.client.config.Symbols)
+		}
+		if _, ok := provider.orderbooks["ETHUSCD"]; ok {
+			t.Error("expected the dropped symbol's book removed")
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		provider := newValidatingProvider(t, server.URL, InvalidSymbolsFail, "ETHUSDC", "ETHUSCD")
+		err := provider.validateSymbols(context.Background())
+		if apperror.GetCode(err) != apperror.CodeInvalidSymbol {
+			t.Errorf("expected %s, got %v", apperror.CodeInvalidSymbol, err)
+		}
+	})
+
+	t.Run("none tradable", func(t *testing.T) {
+		provider := newValidatingProvider(t, server.URL, InvalidSymbolsDrop, "ETHUSCD")
+		if err := provider.validateSymbols(context.Background()); apperror.GetCode(err) != apperror.CodeInvalidSymbol {
+			t.Errorf("expected %s, got %v", apperror.CodeInvalidSymbol, err)
+		}
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		provider := newValidatingProvider(t, server.URL, InvalidSymbolsIgnore, "ETHUSDC", "ETHUSCD")
+		if err := provider.validateSymbols(context.Background()); err != nil {
+			t.Fatalf("validateSymbols failed: %v", err)
+		}
+		if got := provider.Symbols(); len(got) != 2 {
+			t.Errorf("expected both symbols kept, got %v", got)
+		}
+	})
+}
+
+// TestProvider_ValidateSymbolsUnavailable tests that an unreachable
+// exchangeInfo keeps every symbol rather than blocking startup.
+func TestProvider_ValidateSymbolsUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := newValidatingProvider(t, server.URL, InvalidSymbolsFail, "ETHUSDC", "ETHUSCD")
+	if err := provider.validateSymbols(context.Background()); err != nil {
+		t.Fatalf("expected validation skipped, got %v", err)
+	}
+	if got := provider.Symbols(); len(got) != 2 {
+		t.Errorf("expected both symbols kept, got %v", got)
+	}
+}
@@ -0,0 +1,450 @@
+package binance
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/httpclient"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/fd1az/arbitrage-bot/internal/wsconn"
+)
+
+// Ensure UserDataStream implements FeeRateSource.
+var _ app.FeeRateSource = (*UserDataStream)(nil)
+
+const (
+	// Authenticated REST endpoints
+	listenKeyEndpoint  = "/api/v3/userDataStream"
+	commissionEndpoint = "/api/v3/account/commission"
+
+	// Listen keys expire after 60 minutes without a keep-alive
+	listenKeyKeepAlive = 30 * time.Minute
+
+	// Max age of a signed request accepted by Binance (ms)
+	recvWindow = 5000
+
+	// EventTypeExecutionReport is the user-stream event for order updates and fills.
+	EventTypeExecutionReport = "executionReport"
+)
+
+// UserDataConfig holds configuration for the authenticated user-data stream.
+type UserDataConfig struct {
+	APIKey            string        // Required
+	APISecret         string        // Required; signs account requests
+	HTTPURL           string        // REST API base URL (empty = default)
+	WebSocketURL      string        // WebSocket base URL (empty = default)
+	ProxyURL          string        // Proxy for the stream (empty = direct)
+	KeepAliveInterval time.Duration // Listen key keep-alive (0 = 30m)
+	Clock             *ServerClock  // Timestamps signed requests (nil = local clock)
+}
+
+// Fill is a trade executed on the account, with the fee actually charged.
+type Fill struct {
+	Symbol          string
+	Side            string // "BUY" or "SELL"
+	Price           decimal.Decimal
+	Quantity        decimal.Decimal
+	Commission      decimal.Decimal
+	CommissionAsset string
+	Maker           bool
+	Time            time.Time
+}
+
+// FillHandler is called for every fill on the account.
+type FillHandler func(fill Fill)
+
+// ExecutionReportEvent is a user-stream order update. Binance reuses letters
+// with different case (e.g. "l"/"L"), so both are declared to keep
+// encoding/json's case-insensitive matching from mixing them up.
+type ExecutionReportEvent struct {
+	EventType       string `json:"e"` // "executionReport"
+	EventTime       int64  `json:"E"` // Event time (ms)
+	Symbol          string `json:"s"` // Symbol
+	Side            string `json:"S"` // "BUY" or "SELL"
+	ExecutionType   string `json:"x"` // "TRADE" for fills
+	OrderStatus     string `json:"X"` // Order status
+	LastQuantity    string `json:"l"` // Last executed quantity
+	LastPrice       string `json:"L"` // Last executed price
+	Commission      string `json:"n"` // Commission amount
+	CommissionAsset string `json:"N"` // Commission asset (null before a fill)
+	TradeID         int64  `json:"t"` // Trade ID
+	TradeTime       int64  `json:"T"` // Transaction time (ms)
+	Maker           bool   `json:"m"` // Is this trade the maker side?
+	Working         bool   `json:"M"` // Ignored
+}
+
+// commissionResponse is the REST response for account commission rates.
+type commissionResponse struct {
+	Symbol             string         `json:"symbol"`
+	StandardCommission commissionRate `json:"standardCommission"`
+	TaxCommission      commissionRate `json:"taxCommission"`
+}
+
+type commissionRate struct {
+	Maker string `json:"maker"`
+	Taker string `json:"taker"`
+}
+
+// listenKeyResponse is the REST response when creating a listen key.
+type listenKeyResponse struct {
+	ListenKey string `json:"listenKey"`
+}
+
+// UserDataStream reads the account's actual fees: commission rates via the
+// signed REST API and per-fill commissions via the user-data stream. It is
+// optional and never needed for read-only price scanning.
+type UserDataStream struct {
+	config UserDataConfig
+	client httpclient.Client
+	logger logger.LoggerInterface
+	tracer trace.Tracer
+
+	conn      *wsconn.Client
+	listenKey string
+	mu        sync.Mutex
+	stop      chan struct{}
+
+	handlersMu sync.RWMutex
+	onFill     FillHandler
+}
+
+// NewUserDataStream creates a user-data stream; credentials are required.
+func NewUserDataStream(cfg UserDataConfig, log logger.LoggerInterface) (*UserDataStream, error) {
+	if cfg.APIKey == "" || cfg.APISecret == "" {
+		return nil, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext("binance user data stream requires an API key and secret"))
+	}
+	if cfg.HTTPURL == "" {
+		cfg.HTTPURL = BaseAPIURL
+	}
+	if cfg.WebSocketURL == "" {
+		cfg.WebSocketURL = BaseWSURL
+	}
+	if cfg.KeepAliveInterval <= 0 {
+		cfg.KeepAliveInterval = listenKeyKeepAlive
+	}
+
+	tracer := otel.Tracer(tracerName)
+	client, err := httpclient.NewInstrumentedClient(
+		httpclient.WithProviderName("binance"),
+		httpclient.WithBaseURL(cfg.HTTPURL),
+		httpclient.WithRequestTimeout(httpTimeout),
+		httpclient.WithTraceOptions(tracer, httpclient.TraceRequest, httpclient.TraceResponse),
+		httpclient.WithHeaders(map[string]string{
+			"Accept":       "application/json",
+			"X-MBX-APIKEY": cfg.APIKey,
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	return &UserDataStream{
+		config: cfg,
+		client: client,
+		logger: log,
+		tracer: tracer,
+	}, nil
+}
+
+// OnFill sets the handler called for each account fill.
+func (s *UserDataStream) OnFill(handler FillHandler) {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+	s.onFill = handler
+}
+
+// Start obtains a listen key, subscribes to the user-data stream and keeps
+// the key alive until Stop or ctx is done.
+func (s *UserDataStream) Start(ctx context.Context) error {
+	ctx, span := s.tracer.Start(ctx, "binance.user_data.start")
+	defer span.End()
+
+	listenKey, err := s.createListenKey(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	wsCfg := wsconn.DefaultConfig(strings.TrimSuffix(s.config.WebSocketURL, "/")+"/ws/"+listenKey, "binance-user-data")
+	wsCfg.ProxyURL = s.config.ProxyURL
+	conn, err := wsconn.New(wsCfg)
+	if err != nil {
+		return apperror.New(apperror.CodeBinanceConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to create user data wsconn"))
+	}
+	conn.OnMessage(s.handleMessage)
+	if err := conn.ConnectWithRetry(ctx); err != nil {
+		return apperror.New(apperror.CodeBinanceConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to connect to user data stream"))
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.listenKey = listenKey
+	s.stop = make(chan struct{})
+	stop := s.stop
+	s.mu.Unlock()
+
+	go s.keepAlive(ctx, stop)
+
+	s.logger.Info(ctx, "binance user data stream connected")
+	return nil
+}
+
+// Stop closes the stream and deletes the listen key.
+func (s *UserDataStream) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	conn, listenKey, stop := s.conn, s.listenKey, s.stop
+	s.conn, s.listenKey, s.stop = nil, "", nil
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	close(stop)
+	if err := conn.Close(); err != nil {
+		s.logger.Warn(ctx, "failed to close user data stream", "error", err)
+	}
+	return s.listenKeyRequest(ctx, listenKey, "delete")
+}
+
+// FeeRates returns the account's maker and taker commission rates for a
+// symbol (standard plus tax commission; BNB discounts are not applied).
+func (s *UserDataStream) FeeRates(ctx context.Context, symbol string) (domain.FeeRates, error) {
+	ctx, span := s.tracer.Start(ctx, "binance.user_data.fee_rates",
+		trace.WithAttributes(attribute.String("symbol", symbol)),
+	)
+	defer span.End()
+
+	var result commissionResponse
+	query := s.signedQuery(url.Values{"symbol": {symbol}})
+	resp, err := s.client.NewRequestWithOptions(
+		httpclient.WithLabels(httpclient.NewLabel("endpoint", "account_commission")),
+		httpclient.WithResponseErrorHandler(binanceErrorHandler),
+	).
+		SetResult(&result).
+		Get(ctx, commissionEndpoint+"?"+query)
+	if err != nil {
+		span.RecordError(err)
+		return domain.FeeRates{}, apperror.New(apperror.CodeBinanceAPIError,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to fetch account commission"))
+	}
+	if resp.IsError() {
+		return domain.FeeRates{}, apperror.New(apperror.CodeBinanceAPIError,
+			apperror.WithContext(fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.String())))
+	}
+
+	rates, err := result.feeRates()
+	if err != nil {
+		return domain.FeeRates{}, apperror.New(apperror.CodeBinanceAPIError,
+			apperror.WithCause(err),
+			apperror.WithContext("invalid account commission response"))
+	}
+
+	span.SetAttributes(
+		attribute.String("maker", rates.Maker.String()),
+		attribute.String("taker", rates.Taker.String()),
+	)
+	return rates, nil
+}
+
+// feeRates sums the standard and tax commission rates.
+func (r *commissionResponse) feeRates() (domain.FeeRates, error) {
+	var rates domain.FeeRates
+	for _, rate := range []commissionRate{r.StandardCommission, r.TaxCommission} {
+		maker, err := parseRate(rate.Maker)
+		if err != nil {
+			return domain.FeeRates{}, err
+		}
+		taker, err := parseRate(rate.Taker)
+		if err != nil {
+			return domain.FeeRates{}, err
+		}
+		rates.Maker = rates.Maker.Add(maker)
+		rates.Taker = rates.Taker.Add(taker)
+	}
+	return rates, nil
+}
+
+// parseRate parses a commission rate; an absent rate is zero.
+func parseRate(raw string) (decimal.Decimal, error) {
+	if raw == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(raw)
+}
+
+// handleMessage dispatches fills from execution reports.
+func (s *UserDataStream) handleMessage(ctx context.Context, msg []byte) {
+	var event ExecutionReportEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		s.logger.Debug(ctx, "failed to parse user data event", "error", err)
+		return
+	}
+	if event.EventType != EventTypeExecutionReport || event.ExecutionType != "TRADE" {
+		return
+	}
+
+	fill, err := event.toFill()
+	if err != nil {
+		s.logger.Warn(ctx, "invalid execution report", "symbol", event.Symbol, "error", err)
+		return
+	}
+
+	s.logger.Info(ctx, "binance fill",
+		"symbol", fill.Symbol,
+		"side", fill.Side,
+		"price", fill.Price.String(),
+		"quantity", fill.Quantity.String(),
+		"commission", fill.Commission.String()+" "+fill.CommissionAsset,
+		"maker", fill.Maker,
+	)
+
+	s.handlersMu.RLock()
+	handler := s.onFill
+	s.handlersMu.RUnlock()
+	if handler != nil {
+		handler(fill)
+	}
+}
+
+// toFill converts a TRADE execution report into a Fill.
+func (e *ExecutionReportEvent) toFill() (Fill, error) {
+	price, err := decimal.NewFromString(e.LastPrice)
+	if err != nil {
+		return Fill{}, fmt.Errorf("invalid price: %w", err)
+	}
+	qty, err := decimal.NewFromString(e.LastQuantity)
+	if err != nil {
+		return Fill{}, fmt.Errorf("invalid quantity: %w", err)
+	}
+	commission, err := parseRate(e.Commission)
+	if err != nil {
+		return Fill{}, fmt.Errorf("invalid commission: %w", err)
+	}
+	return Fill{
+		Symbol:          e.Symbol,
+		Side:            e.Side,
+		Price:           price,
+		Quantity:        qty,
+		Commission:      commission,
+		CommissionAsset: e.CommissionAsset,
+		Maker:           e.Maker,
+		Time:            time.UnixMilli(e.TradeTime),
+	}, nil
+}
+
+// keepAlive extends the listen key until stopped.
+func (s *UserDataStream) keepAlive(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(s.config.KeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			listenKey := s.listenKey
+			s.mu.Unlock()
+			if err := s.listenKeyRequest(ctx, listenKey, "put"); err != nil {
+				s.logger.Warn(ctx, "failed to keep user data stream alive", "error", err)
+			}
+		}
+	}
+}
+
+// createListenKey obtains a new listen key for the user-data stream.
+func (s *UserDataStream) createListenKey(ctx context.Context) (string, error) {
+	var result listenKeyResponse
+	resp, err := s.client.NewRequestWithOptions(
+		httpclient.WithLabels(httpclient.NewLabel("endpoint", "user_data_stream")),
+		httpclient.WithResponseErrorHandler(binanceErrorHandler),
+	).
+		SetResult(&result).
+		Post(ctx, listenKeyEndpoint)
+	if err != nil {
+		return "", apperror.New(apperror.CodeBinanceAPIError,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to create listen key"))
+	}
+	if resp.IsError() || result.ListenKey == "" {
+		return "", apperror.New(apperror.CodeBinanceAPIError,
+			apperror.WithContext(fmt.Sprintf("failed to create listen key: HTTP %d: %s", resp.StatusCode, resp.String())))
+	}
+	return result.ListenKey, nil
+}
+
+// listenKeyRequest keeps alive ("put") or deletes ("delete") a listen key.
+func (s *UserDataStream) listenKeyRequest(ctx context.Context, listenKey, method string) error {
+	req := s.client.NewRequestWithOptions(
+		httpclient.WithLabels(httpclient.NewLabel("endpoint", "user_data_stream")),
+		httpclient.WithResponseErrorHandler(binanceErrorHandler),
+	).SetQueryParam("listenKey", listenKey)
+
+	var resp *httpclient.Response
+	var err error
+	if method == "delete" {
+		resp, err = req.Delete(ctx, listenKeyEndpoint)
+	} else {
+		resp, err = req.Put(ctx, listenKeyEndpoint)
+	}
+	if err != nil {
+		return apperror.New(apperror.CodeBinanceAPIError,
+			apperror.WithCause(err),
+			apperror.WithContext("listen key "+method+" failed"))
+	}
+	if resp.IsError() {
+		return apperror.New(apperror.CodeBinanceAPIError,
+			apperror.WithContext(fmt.Sprintf("listen key %s failed: HTTP %d", method, resp.StatusCode)))
+	}
+	return nil
+}
+
+// signedQuery adds the timestamp and recvWindow to params and appends the
+// HMAC-SHA256 signature as the last parameter, as Binance requires.
+func (s *UserDataStream) signedQuery(params url.Values) string {
+	params.Set("recvWindow", strconv.Itoa(recvWindow))
+	params.Set("timestamp", strconv.FormatInt(s.now().UnixMilli(), 10))
+	query := params.Encode()
+	return query + "&signature=" + signQuery(s.config.APISecret, query)
+}
+
+// now returns the time signed requests are stamped with: Binance's clock
+// when one is configured, so the local skew doesn't trip recvWindow.
+func (s *UserDataStream) now() time.Time {
+	if s.config.Clock != nil {
+		return s.config.Clock.Now()
+	}
+	return time.Now()
+}
+
+// signQuery returns the hex HMAC-SHA256 of query keyed by secret.
+func signQuery(secret, query string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(query))
+	return hex.EncodeToString(mac.Sum(nil))
+}
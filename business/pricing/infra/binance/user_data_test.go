@@ -0,0 +1,193 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// TestSignQuery tests the HMAC-SHA256 signature against Binance's documented example.
+func TestSignQuery(t *testing.T) {
+	secret := "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j"
+	query := "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559"
+	want := "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71"
+
+	if got := signQuery(secret, query); got != want {
+		t.Errorf("signQuery() = %s, want %s", got, want)
+	}
+}
+
+func TestNewUserDataStream_RequiresCredentials(t *testing.T) {
+	_, err := NewUserDataStream(UserDataConfig{APIKey: "key"}, &mockLogger{})
+	if code := apperror.GetCode(err); code != apperror.CodeConfigurationError {
+		t.Errorf("expected %s, got %v", apperror.CodeConfigurationError, err)
+	}
+}
+
+// userDataServer fakes the Binance REST API and user-data stream.
+type userDataServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	methods []string // listen key requests, in order
+}
+
+func newUserDataServer(t *testing.T, events ...string) *userDataServer {
+	t.Helper()
+	s := &userDataServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc(listenKeyEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-MBX-APIKEY") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":-2014,"msg":"API-key format invalid."}`))
+			return
+		}
+		s.mu.Lock()
+		s.methods = append(s.methods, r.Method)
+		s.mu.Unlock()
+		w.Write([]byte(`{"listenKey":"abc123"}`))
+	})
+	mux.HandleFunc(commissionEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("signature") == "" || q.Get("timestamp") == "" || q.Get("symbol") != "ETHUSDC" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1102,"msg":"Mandatory parameter was not sent."}`))
+			return
+		}
+		w.Write([]byte(`{"symbol":"ETHUSDC",
+			"standardCommission":{"maker":"0.00075000","taker":"0.00075000","buyer":"0","seller":"0"},
+			"taxCommission":{"maker":"0.00000000","taker":"0.00010000","buyer":"0","seller":"0"},
+			"discount":{"enabledForAccount":true,"enabledForSymbol":true,"discountAsset":"BNB","discount":"0.75"}}`))
+	})
+	mux.HandleFunc("/ws/abc123", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		for _, event := range events {
+			if err := conn.Write(r.Context(), websocket.MessageText, []byte(event)); err != nil {
+				return
+			}
+		}
+		// Read until the client closes so the close handshake completes
+		for {
+			if _, _, err := conn.Read(r.Context()); err != nil {
+				return
+			}
+		}
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *userDataServer) listenKeyMethods() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.methods...)
+}
+
+func newTestUserDataStream(t *testing.T, server *userDataServer) *UserDataStream {
+	t.Helper()
+	stream, err := NewUserDataStream(UserDataConfig{
+		APIKey:            "key",
+		APISecret:         "secret",
+		HTTPURL:           server.URL,
+		WebSocketURL:      "ws" + strings.TrimPrefix(server.URL, "http"),
+		KeepAliveInterval: 20 * time.Millisecond,
+	}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("NewUserDataStream failed: %v", err)
+	}
+	return stream
+}
+
+func TestUserDataStream_FeeRates(t *testing.T) {
+	server := newUserDataServer(t)
+	defer server.Close()
+	stream := newTestUserDataStream(t, server)
+
+	rates, err := stream.FeeRates(context.Background(), "ETHUSDC")
+	if err != nil {
+		t.Fatalf("FeeRates failed: %v", err)
+	}
+	if want := decimal.RequireFromString("0.00075"); !rates.Maker.Equal(want) {
+		t.Errorf("Maker = %s, want %s", rates.Maker, want)
+	}
+	// Tax commission is added to the standard rate
+	if want := decimal.RequireFromString("0.00085"); !rates.Taker.Equal(want) {
+		t.Errorf("Taker = %s, want %s", rates.Taker, want)
+	}
+}
+
+func TestUserDataStream_FeeRates_Error(t *testing.T) {
+	server := newUserDataServer(t)
+	defer server.Close()
+	stream := newTestUserDataStream(t, server)
+
+	if _, err := stream.FeeRates(context.Background(), "BTCUSDT"); apperror.GetCode(err) != apperror.CodeBinanceAPIError {
+		t.Errorf("expected %s, got %v", apperror.CodeBinanceAPIError, err)
+	}
+}
+
+func TestUserDataStream_Fills(t *testing.T) {
+	server := newUserDataServer(t,
+		`{"e":"outboundAccountPosition","E":1700000000000,"u":1700000000000,"B":[]}`,
+		`{"e":"executionReport","E":1700000000001,"s":"ETHUSDC","S":"BUY","x":"NEW","X":"NEW","l":"0.00000000","L":"0.00000000","n":"0","N":null,"t":-1,"T":1700000000001,"m":false,"M":false}`,
+		`{"e":"executionReport","E":1700000000002,"s":"ETHUSDC","S":"BUY","x":"TRADE","X":"FILLED","l":"0.50000000","L":"3000.10","n":"0.00037500","N":"ETH","t":42,"T":1700000000002,"m":true,"M":true}`,
+	)
+	defer server.Close()
+	stream := newTestUserDataStream(t, server)
+
+	fills := make(chan Fill, 4)
+	stream.OnFill(func(fill Fill) { fills <- fill })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := stream.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	select {
+	case fill := <-fills:
+		if fill.Symbol != "ETHUSDC" || fill.Side != "BUY" || !fill.Maker || fill.CommissionAsset != "ETH" {
+			t.Errorf("unexpected fill %+v", fill)
+		}
+		if !fill.Price.Equal(decimal.RequireFromString("3000.1")) || !fill.Quantity.Equal(decimal.RequireFromString("0.5")) {
+			t.Errorf("fill price/qty = %s/%s, want 3000.1/0.5", fill.Price, fill.Quantity)
+		}
+		if !fill.Commission.Equal(decimal.RequireFromString("0.000375")) {
+			t.Errorf("fill commission = %s, want 0.000375", fill.Commission)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for fill")
+	}
+
+	// Wait for at least one keep-alive before stopping
+	deadline := time.Now().Add(2 * time.Second)
+	for !slices.Contains(server.listenKeyMethods(), http.MethodPut) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := stream.Stop(ctx); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	methods := server.listenKeyMethods()
+	if methods[0] != http.MethodPost || !slices.Contains(methods, http.MethodPut) || methods[len(methods)-1] != http.MethodDelete {
+		t.Errorf("listen key requests = %v, want POST, PUT..., DELETE", methods)
+	}
+	select {
+	case fill := <-fills:
+		t.Errorf("unexpected extra fill %+v", fill)
+	default:
+	}
+}
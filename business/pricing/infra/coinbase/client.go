@@ -0,0 +1,276 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/fd1az/arbitrage-bot/internal/wsconn"
+)
+
+const (
+	tracerName = "coinbase"
+	meterName  = "coinbase"
+
+	// Coinbase Advanced Trade WebSocket endpoint (public market data)
+	BaseWSURL = "wss://advanced-trade-ws.coinbase.com"
+)
+
+// ClientConfig holds configuration for the Coinbase client.
+type ClientConfig struct {
+	BaseURL      string        // WebSocket URL
+	ProductIDs   []string      // Products to subscribe (e.g., "ETH-USD")
+	ReadTimeout  time.Duration // Read timeout
+	WriteTimeout time.Duration // Write timeout
+}
+
+// DefaultClientConfig returns sensible defaults.
+func DefaultClientConfig(productIDs []string) ClientConfig {
+	return ClientConfig{
+		BaseURL:      BaseWSURL,
+		ProductIDs:   productIDs,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+}
+
+// clientMetrics holds OTEL metric instruments.
+type clientMetrics struct {
+	messagesReceived metric.Int64Counter
+	snapshots        metric.Int64Counter
+	l2Updates        metric.Int64Counter
+	parseErrors      metric.Int64Counter
+}
+
+// Client is a Coinbase Advanced Trade WebSocket client.
+type Client struct {
+	config ClientConfig
+	logger logger.LoggerInterface
+
+	conn   *wsconn.Client
+	connMu sync.RWMutex
+
+	// Message handlers
+	onL2Event  func(*L2Event)
+	handlersMu sync.RWMutex
+
+	// Observability
+	tracer  trace.Tracer
+	metrics *clientMetrics
+
+	// State
+	running atomic.Bool
+}
+
+// NewClient creates a new Coinbase WebSocket client.
+func NewClient(cfg ClientConfig, log logger.LoggerInterface) (*Client, error) {
+	c := &Client{
+		config: cfg,
+		logger: log,
+		tracer: otel.Tracer(tracerName),
+	}
+
+	if err := c.initMetrics(); err != nil {
+		return nil, fmt.Errorf("init metrics: %w", err)
+	}
+
+	return c, nil
+}
+
+func (c *Client) initMetrics() error {
+	meter := otel.Meter(meterName)
+	var err error
+
+	c.metrics = &clientMetrics{}
+
+	c.metrics.messagesReceived, err = meter.Int64Counter(
+		"coinbase_messages_total",
+		metric.WithDescription("Total messages received"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.snapshots, err = meter.Int64Counter(
+		"coinbase_l2_snapshots_total",
+		metric.WithDescription("Total level2 snapshots received"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.l2Updates, err = meter.Int64Counter(
+		"coinbase_l2_updates_total",
+		metric.WithDescription("Total level2 updates received"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.parseErrors, err = meter.Int64Counter(
+		"coinbase_parse_errors_total",
+		metric.WithDescription("Message parse errors"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// OnL2Event registers a handler for level2 snapshot and update events.
+func (c *Client) OnL2Event(handler func(*L2Event)) {
+	c.handlersMu.Lock()
+	c.onL2Event = handler
+	c.handlersMu.Unlock()
+}
+
+// Connect establishes the WebSocket connection and subscribes to level2.
+// Subscriptions are re-sent after every reconnect, so the book is
+// re-snapshotted; a failed resubscription triggers another reconnect.
+func (c *Client) Connect(ctx context.Context) error {
+	ctx, span := c.tracer.Start(ctx, "coinbase.connect",
+		trace.WithAttributes(
+			attribute.StringSlice("products", c.config.ProductIDs),
+		),
+	)
+	defer span.End()
+
+	if len(c.config.ProductIDs) == 0 {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext("no product ids configured"))
+	}
+
+	baseURL := c.config.BaseURL
+	if baseURL == "" {
+		baseURL = BaseWSURL
+	}
+
+	wsCfg := wsconn.DefaultConfig(baseURL, "coinbase")
+	wsCfg.ReadTimeout = c.config.ReadTimeout
+	wsCfg.WriteTimeout = c.config.WriteTimeout
+
+	conn, err := wsconn.New(wsCfg)
+	if err != nil {
+		return apperror.New(apperror.CodeCoinbaseConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to create wsconn"))
+	}
+
+	conn.OnMessage(c.handleMessage)
+	conn.OnReconnect(func(ctx context.Context) error {
+		return c.subscribe(ctx, conn)
+	})
+
+	if err := conn.ConnectWithRetry(ctx); err != nil {
+		return apperror.New(apperror.CodeCoinbaseConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to connect to Coinbase"))
+	}
+
+	if err := c.subscribe(ctx, conn); err != nil {
+		conn.Close()
+		return apperror.New(apperror.CodeCoinbaseConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to subscribe to Coinbase channels"))
+	}
+
+	c.connMu.Lock()
+	c.conn = conn
+	c.connMu.Unlock()
+
+	c.running.Store(true)
+
+	c.logger.Info(ctx, "coinbase client connected",
+		"url", baseURL,
+		"products", c.config.ProductIDs)
+
+	return nil
+}
+
+// subscribe sends the level2 and heartbeats subscriptions.
+// Heartbeats keep the connection alive when a product is quiet.
+func (c *Client) subscribe(ctx context.Context, conn *wsconn.Client) error {
+	for _, channel := range []string{ChannelLevel2, ChannelHeartbeats} {
+		req := SubscribeRequest{
+			Type:       "subscribe",
+			ProductIDs: c.config.ProductIDs,
+			Channel:    channel,
+		}
+		if err := conn.SendJSON(ctx, req); err != nil {
+			c.logger.Warn(ctx, "coinbase subscribe failed", "channel", channel, "error", err)
+			return fmt.Errorf("subscribe %s: %w", channel, err)
+		}
+	}
+	c.logger.Debug(ctx, "coinbase subscriptions sent", "products", c.config.ProductIDs)
+	return nil
+}
+
+// handleMessage processes incoming WebSocket messages.
+func (c *Client) handleMessage(ctx context.Context, data []byte) {
+	c.metrics.messagesReceived.Add(ctx, 1)
+
+	var msg ChannelMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		c.metrics.parseErrors.Add(ctx, 1)
+		c.logger.Debug(ctx, "failed to parse message", "error", err, "data", string(data[:min(len(data), 500)]))
+		return
+	}
+
+	// Only level2 data is routed; heartbeats and subscription acks are ignored
+	if msg.Channel != channelL2Data {
+		return
+	}
+
+	var events []L2Event
+	if err := json.Unmarshal(msg.Events, &events); err != nil {
+		c.metrics.parseErrors.Add(ctx, 1)
+		c.logger.Warn(ctx, "failed to parse l2 events", "error", err)
+		return
+	}
+
+	c.handlersMu.RLock()
+	handler := c.onL2Event
+	c.handlersMu.RUnlock()
+
+	for i := range events {
+		if events[i].Type == EventTypeSnapshot {
+			c.metrics.snapshots.Add(ctx, 1)
+		} else {
+			c.metrics.l2Updates.Add(ctx, 1)
+		}
+		if handler != nil {
+			handler(&events[i])
+		}
+	}
+}
+
+// Close closes the client connection.
+func (c *Client) Close() error {
+	c.running.Store(false)
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// IsConnected returns whether the client is connected.
+func (c *Client) IsConnected() bool {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.conn != nil && c.conn.IsConnected()
+}
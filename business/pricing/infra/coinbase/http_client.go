@@ -0,0 +1,153 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/httpclient"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+const (
+	// Coinbase Exchange public REST API (no auth required for market data)
+	BaseAPIURL = "https://api.exchange.coinbase.com"
+
+	// Default HTTP client settings
+	httpTimeout = 10 * time.Second
+)
+
+// HTTPClientConfig holds configuration for the Coinbase HTTP client.
+type HTTPClientConfig struct {
+	BaseURL string        // API base URL (empty = default)
+	Timeout time.Duration // Request timeout
+}
+
+// DefaultHTTPClientConfig returns sensible defaults.
+func DefaultHTTPClientConfig() HTTPClientConfig {
+	return HTTPClientConfig{
+		BaseURL: BaseAPIURL,
+		Timeout: httpTimeout,
+	}
+}
+
+// HTTPClient provides Coinbase REST API access for fallback scenarios.
+type HTTPClient struct {
+	client httpclient.Client
+	config HTTPClientConfig
+	logger logger.LoggerInterface
+	tracer trace.Tracer
+}
+
+// NewHTTPClient creates a new Coinbase HTTP client.
+func NewHTTPClient(cfg HTTPClientConfig, log logger.LoggerInterface) (*HTTPClient, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = BaseAPIURL
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = httpTimeout
+	}
+
+	tracer := otel.Tracer(tracerName)
+
+	client, err := httpclient.NewInstrumentedClient(
+		httpclient.WithProviderName("coinbase"),
+		httpclient.WithBaseURL(baseURL),
+		httpclient.WithRequestTimeout(timeout),
+		httpclient.WithTraceOptions(tracer, httpclient.TraceRequest, httpclient.TraceResponse),
+		httpclient.WithHeaders(map[string]string{
+			"Accept": "application/json",
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	return &HTTPClient{
+		client: client,
+		config: cfg,
+		logger: log,
+		tracer: tracer,
+	}, nil
+}
+
+// GetBook fetches the aggregated level2 book for a product via REST API.
+// This is used as a fallback when WebSocket data is stale or unavailable.
+func (c *HTTPClient) GetBook(ctx context.Context, productID string) (*BookResponse, error) {
+	ctx, span := c.tracer.Start(ctx, "coinbase.http.get_book",
+		trace.WithAttributes(
+			attribute.String("product_id", productID),
+		),
+	)
+	defer span.End()
+
+	var result BookResponse
+	resp, err := c.client.NewRequestWithOptions(
+		httpclient.WithLabels(
+			httpclient.NewLabel("endpoint", "book"),
+			httpclient.NewLabel("product_id", productID),
+		),
+		httpclient.WithResponseErrorHandler(coinbaseErrorHandler),
+	).
+		SetQueryParam("level", "2").
+		SetResult(&result).
+		Get(ctx, "/products/"+url.PathEscape(productID)+"/book")
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, apperror.New(apperror.CodeCoinbaseConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to fetch book from REST API"))
+	}
+
+	if resp.IsError() {
+		return nil, apperror.New(apperror.CodeCoinbaseConnectionFailed,
+			apperror.WithContext(fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.String())))
+	}
+
+	span.SetAttributes(
+		attribute.Int("bids", len(result.Bids)),
+		attribute.Int("asks", len(result.Asks)),
+		attribute.Int64("sequence", result.Sequence),
+	)
+
+	c.logger.Debug(ctx, "fetched book via HTTP",
+		"product_id", productID,
+		"bids", len(result.Bids),
+		"asks", len(result.Asks))
+
+	return &result, nil
+}
+
+// CoinbaseAPIError represents an error response from Coinbase API.
+type CoinbaseAPIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message"`
+}
+
+func (e *CoinbaseAPIError) Error() string {
+	return fmt.Sprintf("coinbase API error %d: %s", e.StatusCode, e.Message)
+}
+
+// coinbaseErrorHandler parses Coinbase API error responses.
+func coinbaseErrorHandler(statusCode int, body []byte) error {
+	if statusCode >= 400 {
+		var apiErr CoinbaseAPIError
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+			apiErr.StatusCode = statusCode
+			return &apiErr
+		}
+		return fmt.Errorf("HTTP %d: %s", statusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package coinbase implements the CEXProvider interface for Coinbase Advanced Trade.
+package coinbase
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// WebSocket request messages
+
+// SubscribeRequest is a WebSocket channel subscription request.
+type SubscribeRequest struct {
+	Type       string   `json:"type"`        // "subscribe" or "unsubscribe"
+	ProductIDs []string `json:"product_ids"` // e.g. ["ETH-USD"]
+	Channel    string   `json:"channel"`     // e.g. "level2"
+}
+
+// Channel names
+const (
+	ChannelLevel2     = "level2"
+	ChannelHeartbeats = "heartbeats"
+
+	// channelL2Data is the channel name Coinbase uses in level2 responses.
+	channelL2Data = "l2_data"
+)
+
+// Event types within a channel message
+const (
+	EventTypeSnapshot = "snapshot"
+	EventTypeUpdate   = "update"
+)
+
+// Update sides
+const (
+	SideBid   = "bid"
+	SideOffer = "offer"
+)
+
+// ChannelMessage is the envelope for every message pushed by the WebSocket feed.
+type ChannelMessage struct {
+	Channel     string          `json:"channel"`
+	ClientID    string          `json:"client_id"`
+	Timestamp   string          `json:"timestamp"`
+	SequenceNum int64           `json:"sequence_num"`
+	Events      json.RawMessage `json:"events"`
+}
+
+// L2Event is a level2 snapshot or update for a single product.
+// Channel: l2_data
+type L2Event struct {
+	Type      string     `json:"type"`       // "snapshot" or "update"
+	ProductID string     `json:"product_id"` // e.g. "ETH-USD"
+	Updates   []L2Update `json:"updates"`
+}
+
+// L2Update is a single price level change.
+// A NewQuantity of zero removes the level from the book.
+type L2Update struct {
+	Side        string `json:"side"`         // "bid" or "offer"
+	EventTime   string `json:"event_time"`   // RFC3339
+	PriceLevel  string `json:"price_level"`  // Price
+	NewQuantity string `json:"new_quantity"` // Quantity at this level after the update
+}
+
+// ParsePrice parses the price level as decimal.
+func (u *L2Update) ParsePrice() (decimal.Decimal, error) {
+	return decimal.NewFromString(u.PriceLevel)
+}
+
+// ParseQuantity parses the new quantity as decimal.
+func (u *L2Update) ParseQuantity() (decimal.Decimal, error) {
+	return decimal.NewFromString(u.NewQuantity)
+}
+
+// IsBid returns true if the update applies to the bid side.
+func (u *L2Update) IsBid() bool {
+	return u.Side == SideBid
+}
+
+// REST API responses
+
+// BookResponse is the REST API response for /products/{id}/book?level=2.
+// Each level is [price, size, num_orders] where num_orders is a number.
+type BookResponse struct {
+	Sequence int64               `json:"sequence"`
+	Bids     [][]json.RawMessage `json:"bids"`
+	Asks     [][]json.RawMessage `json:"asks"`
+	Time     time.Time           `json:"time"`
+}
+
+// OrderbookLevel represents a price level in the orderbook.
+type OrderbookLevel struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+// ParseBookLevels parses raw REST book levels into orderbook levels.
+func ParseBookLevels(raw [][]json.RawMessage) ([]OrderbookLevel, error) {
+	levels := make([]OrderbookLevel, 0, len(raw))
+	for _, r := range raw {
+		if len(r) < 2 {
+			continue
+		}
+		var priceStr, qtyStr string
+		if err := json.Unmarshal(r[0], &priceStr); err != nil {
+			return nil, fmt.Errorf("invalid price: %w", err)
+		}
+		if err := json.Unmarshal(r[1], &qtyStr); err != nil {
+			return nil, fmt.Errorf("invalid size: %w", err)
+		}
+		price, err := decimal.NewFromString(priceStr)
+		if err != nil {
+			return nil, err
+		}
+		qty, err := decimal.NewFromString(qtyStr)
+		if err != nil {
+			return nil, err
+		}
+		// Skip empty levels
+		if qty.IsZero() {
+			continue
+		}
+		levels = append(levels, OrderbookLevel{Price: price, Quantity: qty})
+	}
+	return levels, nil
+}
+
+// Product ID helpers
+
+// ProductID builds a Coinbase product ID from base and quote symbols.
+// Example: ("ETH", "USD") -> "ETH-USD"
+func ProductID(base, quote string) string {
+	return strings.ToUpper(base) + "-" + strings.ToUpper(quote)
+}
+
+// SplitProductID splits a Coinbase product ID into base and quote symbols.
+// Example: "ETH-USD" -> ("ETH", "USD", true)
+func SplitProductID(productID string) (string, string, bool) {
+	parts := strings.Split(productID, "-")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
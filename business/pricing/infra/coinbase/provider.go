@@ -0,0 +1,491 @@
+package coinbase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/shopspring/decimal"
+)
+
+// Ensure Provider implements CEXProvider.
+var _ app.CEXProvider = (*Provider)(nil)
+
+// Ensure Provider reports which books it subscribed to.
+var _ app.Subscriber = (*Provider)(nil)
+
+// quoteAliases maps on-chain quote symbols to the Coinbase product quote.
+// Coinbase unified its USDC books into USD, so ETH-USDC trades on ETH-USD.
+var quoteAliases = map[string]string{
+	"USDC": "USD",
+}
+
+// ProviderConfig holds configuration for the Coinbase provider.
+type ProviderConfig struct {
+	WebSocketURL   string        // WebSocket URL (empty = default)
+	HTTPURL        string        // REST API base URL (empty = default)
+	ProductIDs     []string      // Products (e.g., "ETH-USD", "BTC-USD")
+	SnapshotDepth  int           // Number of orderbook levels to expose
+	StaleTimeout   time.Duration // How long before data is considered stale
+	EnableFallback bool          // Enable HTTP fallback when WS data is stale
+
+	// RejectPartialFills fails GetEffectivePrice with a PartialFillError
+	// when the book can't fill the size, instead of averaging what it can
+	RejectPartialFills bool
+}
+
+// DefaultProviderConfig returns sensible defaults.
+func DefaultProviderConfig(productIDs []string) ProviderConfig {
+	return ProviderConfig{
+		ProductIDs:     productIDs,
+		SnapshotDepth:  20,
+		StaleTimeout:   5 * time.Second,
+		EnableFallback: true,
+	}
+}
+
+// orderbookState holds the full level2 book for a product.
+// Coinbase sends a snapshot followed by incremental updates, so the whole
+// book is kept keyed by price and sorted on read.
+type orderbookState struct {
+	bids       map[string]OrderbookLevel
+	asks       map[string]OrderbookLevel
+	lastUpdate time.Time
+	mu         sync.RWMutex
+}
+
+func newOrderbookState() *orderbookState {
+	return &orderbookState{
+		bids: make(map[string]OrderbookLevel),
+		asks: make(map[string]OrderbookLevel),
+	}
+}
+
+// Provider implements CEXProvider for Coinbase.
+type Provider struct {
+	config     ProviderConfig
+	logger     logger.LoggerInterface
+	client     *Client     // WebSocket client
+	httpClient *HTTPClient // HTTP client for fallback
+
+	// Orderbook state per product
+	orderbooks map[string]*orderbookState
+	booksMu    sync.RWMutex
+
+	// Asset registry for conversions
+	registry *asset.Registry
+
+	// Observability
+	tracer trace.Tracer
+}
+
+// NewProvider creates a new Coinbase CEX provider.
+func NewProvider(cfg ProviderConfig, log logger.LoggerInterface) (*Provider, error) {
+	wsURL := cfg.WebSocketURL
+	if wsURL == "" {
+		wsURL = BaseWSURL
+	}
+
+	clientCfg := ClientConfig{
+		BaseURL:      wsURL,
+		ProductIDs:   cfg.ProductIDs,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	client, err := NewClient(clientCfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create HTTP client for fallback (optional)
+	var httpClient *HTTPClient
+	if cfg.EnableFallback {
+		httpCfg := HTTPClientConfig{
+			BaseURL: cfg.HTTPURL, // Empty = default
+		}
+		httpClient, err = NewHTTPClient(httpCfg, log)
+		if err != nil {
+			log.Warn(context.Background(), "failed to create HTTP fallback client", "error", err)
+			// Continue without HTTP fallback
+		}
+	}
+
+	p := &Provider{
+		config:     cfg,
+		logger:     log,
+		client:     client,
+		httpClient: httpClient,
+		orderbooks: make(map[string]*orderbookState),
+		registry:   asset.DefaultRegistry(),
+		tracer:     otel.Tracer(tracerName),
+	}
+
+	for _, id := range cfg.ProductIDs {
+		p.orderbooks[id] = newOrderbookState()
+	}
+
+	client.OnL2Event(p.handleL2Event)
+
+	return p, nil
+}
+
+// Connect establishes connection to Coinbase.
+func (p *Provider) Connect(ctx context.Context) error {
+	return p.client.Connect(ctx)
+}
+
+// Close closes the provider.
+func (p *Provider) Close() error {
+	return p.client.Close()
+}
+
+// Subscribes reports whether pair's book is subscribed.
+func (p *Provider) Subscribes(pair domain.Pair) bool {
+	p.booksMu.RLock()
+	defer p.booksMu.RUnlock()
+	_, ok := p.orderbooks[pairToProductID(pair)]
+	return ok
+}
+
+// GetOrderbook retrieves the current orderbook for a trading pair.
+func (p *Provider) GetOrderbook(ctx context.Context, pair domain.Pair) (*domain.Orderbook, error) {
+	ctx, span := p.tracer.Start(ctx, "coinbase.get_orderbook",
+		trace.WithAttributes(attribute.String("pair", pair.String())),
+	)
+	defer span.End()
+
+	productID := pairToProductID(pair)
+
+	p.booksMu.RLock()
+	state, ok := p.orderbooks[productID]
+	p.booksMu.RUnlock()
+
+	if !ok {
+		return nil, apperror.New(apperror.CodeNotFound,
+			apperror.WithContext(fmt.Sprintf("product %s not subscribed", productID)))
+	}
+
+	state.mu.RLock()
+	isStale := time.Since(state.lastUpdate) > p.config.StaleTimeout
+	bidsLen := len(state.bids)
+	asksLen := len(state.asks)
+	state.mu.RUnlock()
+
+	// Check staleness - try HTTP fallback if available
+	if isStale {
+		span.SetAttributes(attribute.Bool("stale", true))
+
+		if p.httpClient != nil {
+			p.logger.Debug(ctx, "orderbook stale, using HTTP fallback", "product_id", productID)
+			return p.getOrderbookViaHTTP(ctx, pair, productID, span)
+		}
+
+		return nil, apperror.New(apperror.CodeCacheExpired,
+			apperror.WithContext(fmt.Sprintf("orderbook stale for %s", productID)))
+	}
+
+	// Check if we have any data
+	if bidsLen == 0 || asksLen == 0 {
+		if p.httpClient != nil {
+			p.logger.Debug(ctx, "no WS data yet, using HTTP fallback", "product_id", productID)
+			return p.getOrderbookViaHTTP(ctx, pair, productID, span)
+		}
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithContext(fmt.Sprintf("no orderbook data for %s", productID)))
+	}
+
+	baseAsset := p.baseAsset(pair)
+
+	state.mu.RLock()
+	ob := &domain.Orderbook{
+		Pair:      pair,
+		Bids:      sortedLevels(state.bids, baseAsset, true, p.config.SnapshotDepth),
+		Asks:      sortedLevels(state.asks, baseAsset, false, p.config.SnapshotDepth),
+		Timestamp: state.lastUpdate,
+	}
+	state.mu.RUnlock()
+
+	span.SetAttributes(
+		attribute.Int("bids", len(ob.Bids)),
+		attribute.Int("asks", len(ob.Asks)),
+		attribute.String("source", "websocket"),
+	)
+
+	p.logger.Debug(ctx, "orderbook retrieved", "product_id", productID, "bids", len(ob.Bids), "asks", len(ob.Asks))
+
+	return ob, nil
+}
+
+// getOrderbookViaHTTP fetches the orderbook via REST API fallback.
+func (p *Provider) getOrderbookViaHTTP(ctx context.Context, pair domain.Pair, productID string, span trace.Span) (*domain.Orderbook, error) {
+	book, err := p.httpClient.GetBook(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	bidLevels, err := ParseBookLevels(book.Bids)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to parse bid levels"))
+	}
+	askLevels, err := ParseBookLevels(book.Asks)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to parse ask levels"))
+	}
+
+	bids := make(map[string]OrderbookLevel, len(bidLevels))
+	for _, level := range bidLevels {
+		bids[level.Price.String()] = level
+	}
+	asks := make(map[string]OrderbookLevel, len(askLevels))
+	for _, level := range askLevels {
+		asks[level.Price.String()] = level
+	}
+
+	// Update the cached state with HTTP data
+	p.booksMu.RLock()
+	state, ok := p.orderbooks[productID]
+	p.booksMu.RUnlock()
+	if ok {
+		state.mu.Lock()
+		state.bids = bids
+		state.asks = asks
+		state.lastUpdate = time.Now()
+		state.mu.Unlock()
+	}
+
+	baseAsset := p.baseAsset(pair)
+	ob := &domain.Orderbook{
+		Pair:      pair,
+		Bids:      sortedLevels(bids, baseAsset, true, p.config.SnapshotDepth),
+		Asks:      sortedLevels(asks, baseAsset, false, p.config.SnapshotDepth),
+		Timestamp: time.Now(),
+	}
+
+	span.SetAttributes(
+		attribute.Int("bids", len(ob.Bids)),
+		attribute.Int("asks", len(ob.Asks)),
+		attribute.String("source", "http_fallback"),
+	)
+
+	p.logger.Info(ctx, "orderbook retrieved via HTTP fallback", "product_id", productID, "bids", len(ob.Bids), "asks", len(ob.Asks))
+
+	return ob, nil
+}
+
+// GetEffectivePrice calculates the effective price for a given trade size.
+func (p *Provider) GetEffectivePrice(ctx context.Context, pair domain.Pair, size decimal.Decimal, side domain.Side) (*domain.Price, error) {
+	ctx, span := p.tracer.Start(ctx, "coinbase.get_effective_price",
+		trace.WithAttributes(
+			attribute.String("pair", pair.String()),
+			attribute.String("size", size.String()),
+			attribute.String("side", string(side)),
+		),
+	)
+	defer span.End()
+
+	ob, err := p.GetOrderbook(ctx, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	var levels []domain.OrderbookLevel
+	if side == domain.SideBuy {
+		levels = ob.Asks // Buy from asks
+	} else {
+		levels = ob.Bids // Sell into bids
+	}
+
+	if len(levels) == 0 {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithContext("no liquidity"))
+	}
+
+	// VWAP calculation
+	remaining := size
+	totalCost := decimal.Zero
+	totalFilled := decimal.Zero
+
+	for _, level := range levels {
+		if remaining.IsZero() {
+			break
+		}
+
+		fillQty := decimal.Min(remaining, level.Amount.ToDecimal())
+		fillCost := fillQty.Mul(level.Price)
+
+		totalCost = totalCost.Add(fillCost)
+		totalFilled = totalFilled.Add(fillQty)
+		remaining = remaining.Sub(fillQty)
+	}
+
+	if totalFilled.IsZero() {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithContext("could not fill any quantity"))
+	}
+
+	avgPrice := totalCost.Div(totalFilled)
+
+	if remaining.IsPositive() {
+		if p.config.RejectPartialFills {
+			fillErr := &domain.PartialFillError{Venue: domain.VenueCoinbase, Pair: pair, Side: side, Requested: size, Filled: totalFilled}
+			span.RecordError(fillErr)
+			return nil, apperror.New(apperror.CodeInsufficientLiquidity,
+				apperror.WithCause(fillErr),
+				apperror.WithContext(fillErr.Error()))
+		}
+		p.logger.Warn(ctx, "partial fill in effective price calculation",
+			"requested", size.String(),
+			"filled", totalFilled.String(),
+			"remaining", remaining.String())
+	}
+
+	sizeAmount, _ := asset.ParseDecimal(pair.Base, totalFilled)
+	rate := asset.NewPrice(pair.Base, pair.Quote, avgPrice, ob.Timestamp)
+
+	price := domain.NewPrice(rate, sizeAmount, side, domain.VenueCoinbase)
+
+	span.SetAttributes(
+		attribute.String("effective_price", avgPrice.String()),
+		attribute.String("filled", totalFilled.String()),
+	)
+
+	return &price, nil
+}
+
+// handleL2Event applies a level2 snapshot or update to the product's book.
+func (p *Provider) handleL2Event(event *L2Event) {
+	ctx := context.Background()
+
+	p.booksMu.RLock()
+	state, ok := p.orderbooks[event.ProductID]
+	p.booksMu.RUnlock()
+
+	if !ok {
+		p.logger.Debug(ctx, "l2 event for unknown product", "product_id", event.ProductID)
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	// A snapshot replaces the entire book
+	if event.Type == EventTypeSnapshot {
+		state.bids = make(map[string]OrderbookLevel, len(event.Updates))
+		state.asks = make(map[string]OrderbookLevel, len(event.Updates))
+	}
+
+	for i := range event.Updates {
+		upd := &event.Updates[i]
+		price, err := upd.ParsePrice()
+		if err != nil {
+			p.logger.Debug(ctx, "failed to parse price level", "error", err)
+			continue
+		}
+		qty, err := upd.ParseQuantity()
+		if err != nil {
+			p.logger.Debug(ctx, "failed to parse quantity", "error", err)
+			continue
+		}
+
+		book := state.asks
+		if upd.IsBid() {
+			book = state.bids
+		}
+
+		key := price.String()
+		if qty.IsZero() {
+			delete(book, key) // Level removed
+		} else {
+			book[key] = OrderbookLevel{Price: price, Quantity: qty}
+		}
+	}
+
+	state.lastUpdate = time.Now()
+}
+
+// baseAsset resolves the base asset of a pair for amount conversions.
+func (p *Provider) baseAsset(pair domain.Pair) *asset.Asset {
+	if pair.Base != nil {
+		return pair.Base
+	}
+	return asset.ETH
+}
+
+// sortedLevels converts a price-keyed book side into sorted domain levels.
+// Bids are sorted descending, asks ascending, truncated to maxDepth (0 = no limit).
+func sortedLevels(book map[string]OrderbookLevel, baseAsset *asset.Asset, isBid bool, maxDepth int) []domain.OrderbookLevel {
+	result := make([]domain.OrderbookLevel, 0, len(book))
+	for _, level := range book {
+		amt, _ := asset.ParseDecimal(baseAsset, level.Quantity)
+		result = append(result, domain.OrderbookLevel{Price: level.Price, Amount: amt})
+	}
+
+	if isBid {
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Price.GreaterThan(result[j].Price)
+		})
+	} else {
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Price.LessThan(result[j].Price)
+		})
+	}
+
+	if maxDepth > 0 && len(result) > maxDepth {
+		result = result[:maxDepth]
+	}
+
+	return result
+}
+
+// pairToProductID converts a domain.Pair to Coinbase product ID format.
+// Example: ETH/USDC -> "ETH-USD" (see quoteAliases)
+func pairToProductID(pair domain.Pair) string {
+	quote := pair.Quote.Symbol()
+	if alias, ok := quoteAliases[quote]; ok {
+		quote = alias
+	}
+	return ProductID(pair.Base.Symbol(), quote)
+}
+
+// ProductIDToPair converts a Coinbase product ID to a domain.Pair using the registry.
+// The USD quote resolves to the fiat USD asset.
+func ProductIDToPair(productID string, registry *asset.Registry) (domain.Pair, error) {
+	baseSym, quoteSym, ok := SplitProductID(productID)
+	if !ok {
+		return domain.Pair{}, apperror.New(apperror.CodeInvalidInput,
+			apperror.WithContext(fmt.Sprintf("invalid product id %q", productID)))
+	}
+
+	base, ok := registry.GetBySymbolAndChain(baseSym, asset.ChainIDEthereum)
+	if !ok {
+		return domain.Pair{}, apperror.New(apperror.CodeNotFound,
+			apperror.WithContext(fmt.Sprintf("unknown base asset %s", baseSym)))
+	}
+
+	quote, ok := registry.GetBySymbolAndChain(quoteSym, asset.ChainIDEthereum)
+	if !ok {
+		quote, ok = registry.GetBySymbolAndChain(quoteSym, asset.ChainIDFiat)
+	}
+	if !ok {
+		return domain.Pair{}, apperror.New(apperror.CodeNotFound,
+			apperror.WithContext(fmt.Sprintf("unknown quote asset %s", quoteSym)))
+	}
+
+	return domain.NewPair(base, quote), nil
+}
@@ -0,0 +1,185 @@
+package coinbase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/shopspring/decimal"
+)
+
+// mockLogger implements logger.LoggerInterface for testing.
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Info(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (m *mockLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+var _ logger.LoggerInterface = (*mockLogger)(nil)
+
+var ethUSDC = domain.Pair{Base: asset.ETH, Quote: asset.USDC}
+
+// TestProvider_FallbackToHTTP tests that the provider uses the REST book
+// when no WebSocket data has been received.
+func TestProvider_FallbackToHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/products/ETH-USD/book" {
+			t.Errorf("expected path /products/ETH-USD/book, got %s", r.URL.Path)
+		}
+		if level := r.URL.Query().Get("level"); level != "2" {
+			t.Errorf("expected level 2, got %s", level)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"sequence": 42,
+			"bids": [["3400.00", "20.0", 3], ["3400.50", "10.5", 1]],
+			"asks": [["3401.50", "12.0", 2], ["3401.00", "8.0", 4]],
+			"time": "2025-01-01T00:00:00Z"
+		}`))
+	}))
+	defer server.Close()
+
+	cfg := ProviderConfig{
+		HTTPURL:        server.URL,
+		ProductIDs:     []string{"ETH-USD"},
+		SnapshotDepth:  20,
+		StaleTimeout:   100 * time.Millisecond,
+		EnableFallback: true,
+	}
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	ob, err := provider.GetOrderbook(context.Background(), ethUSDC)
+	if err != nil {
+		t.Fatalf("expected HTTP fallback to succeed, got error: %v", err)
+	}
+
+	if len(ob.Bids) != 2 || len(ob.Asks) != 2 {
+		t.Fatalf("expected 2 bids and 2 asks, got %d bids and %d asks", len(ob.Bids), len(ob.Asks))
+	}
+
+	// Levels must be sorted best-first regardless of REST ordering
+	if !ob.Bids[0].Price.Equal(decimal.RequireFromString("3400.50")) {
+		t.Errorf("expected best bid 3400.50, got %s", ob.Bids[0].Price)
+	}
+	if !ob.Asks[0].Price.Equal(decimal.RequireFromString("3401.00")) {
+		t.Errorf("expected best ask 3401.00, got %s", ob.Asks[0].Price)
+	}
+}
+
+// TestProvider_FallbackDisabled tests that an error is returned without data or fallback.
+func TestProvider_FallbackDisabled(t *testing.T) {
+	cfg := ProviderConfig{
+		ProductIDs:     []string{"ETH-USD"},
+		SnapshotDepth:  20,
+		StaleTimeout:   100 * time.Millisecond,
+		EnableFallback: false,
+	}
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	if _, err := provider.GetOrderbook(context.Background(), ethUSDC); err == nil {
+		t.Error("expected error when no WS data and fallback disabled, got nil")
+	}
+}
+
+// TestProvider_HandleL2Event tests snapshot and incremental update handling.
+func TestProvider_HandleL2Event(t *testing.T) {
+	cfg := DefaultProviderConfig([]string{"ETH-USD"})
+	cfg.EnableFallback = false
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	provider.handleL2Event(&L2Event{
+		Type:      EventTypeSnapshot,
+		ProductID: "ETH-USD",
+		Updates: []L2Update{
+			{Side: SideBid, PriceLevel: "3000.00", NewQuantity: "1.0"},
+			{Side: SideBid, PriceLevel: "2999.00", NewQuantity: "2.0"},
+			{Side: SideOffer, PriceLevel: "3001.00", NewQuantity: "1.5"},
+			{Side: SideOffer, PriceLevel: "3002.00", NewQuantity: "3.0"},
+		},
+	})
+
+	provider.handleL2Event(&L2Event{
+		Type:      EventTypeUpdate,
+		ProductID: "ETH-USD",
+		Updates: []L2Update{
+			{Side: SideBid, PriceLevel: "3000.00", NewQuantity: "0"},     // remove best bid
+			{Side: SideOffer, PriceLevel: "3000.50", NewQuantity: "0.5"}, // new best ask
+		},
+	})
+
+	tests := []struct {
+		name    string
+		side    domain.Side
+		size    string
+		wantAvg string
+	}{
+		{name: "buy_top_level", side: domain.SideBuy, size: "0.5", wantAvg: "3000.5"},
+		{name: "buy_walks_book", side: domain.SideBuy, size: "1", wantAvg: "3000.75"},
+		{name: "sell_after_removal", side: domain.SideSell, size: "1", wantAvg: "2999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := provider.GetEffectivePrice(context.Background(), ethUSDC, decimal.RequireFromString(tt.size), tt.side)
+			if err != nil {
+				t.Fatalf("GetEffectivePrice failed: %v", err)
+			}
+			if want := decimal.RequireFromString(tt.wantAvg); !price.Rate.Rate().Equal(want) {
+				t.Errorf("expected effective price %s, got %s", want, price.Rate.Rate())
+			}
+			if price.Source != "coinbase" {
+				t.Errorf("expected source coinbase, got %s", price.Source)
+			}
+		})
+	}
+}
+
+// TestProductIDMapping tests conversions between product IDs and pairs.
+func TestProductIDMapping(t *testing.T) {
+	if got := pairToProductID(ethUSDC); got != "ETH-USD" {
+		t.Errorf("expected ETH-USD for ETH/USDC, got %s", got)
+	}
+	if got := pairToProductID(domain.Pair{Base: asset.WBTC, Quote: asset.USDT}); got != "WBTC-USDT" {
+		t.Errorf("expected WBTC-USDT, got %s", got)
+	}
+
+	registry := asset.DefaultRegistry()
+
+	pair, err := ProductIDToPair("ETH-USD", registry)
+	if err != nil {
+		t.Fatalf("ProductIDToPair failed: %v", err)
+	}
+	if pair.Base.Symbol() != "ETH" || pair.Quote.Symbol() != "USD" {
+		t.Errorf("expected ETH/USD, got %s", pair)
+	}
+
+	if _, err := ProductIDToPair("ETHUSD", registry); err == nil {
+		t.Error("expected error for malformed product id, got nil")
+	}
+	if _, err := ProductIDToPair("FOO-USD", registry); err == nil {
+		t.Error("expected error for unknown base asset, got nil")
+	}
+}
@@ -0,0 +1,95 @@
+// Package infra adapts pricing provider state for operational endpoints.
+package infra
+
+import (
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/debugserver"
+)
+
+// DebugBookDepth is how many levels per side the debug endpoint shows.
+const DebugBookDepth = 5
+
+// Ensure DebugState can back the debug endpoint.
+var _ debugserver.StateSource = (*DebugState)(nil)
+
+// DebugState reads live books and last quotes from the configured
+// providers. Providers that keep no such state contribute nothing.
+type DebugState struct {
+	cex app.CEXProvider
+	dex app.DEXProvider
+}
+
+// NewDebugState creates a debug view over the given providers.
+func NewDebugState(cex app.CEXProvider, dex app.DEXProvider) *DebugState {
+	return &DebugState{cex: cex, dex: dex}
+}
+
+// Orderbooks implements debugserver.StateSource.
+func (s *DebugState) Orderbooks() []debugserver.Orderbook {
+	bi, ok := s.cex.(app.BookInspector)
+	if !ok {
+		return []debugserver.Orderbook{}
+	}
+
+	snapshots := bi.BookSnapshots(DebugBookDepth)
+	books := make([]debugserver.Orderbook, 0, len(snapshots))
+	for _, snap := range snapshots {
+		book := debugserver.Orderbook{
+			Venue:  snap.Venue,
+			Symbol: snap.Symbol,
+			Bids:   toDebugLevels(snap.Bids),
+			Asks:   toDebugLevels(snap.Asks),
+		}
+		if !snap.LastUpdate.IsZero() {
+			lastUpdate := snap.LastUpdate.UTC()
+			book.LastUpdate = &lastUpdate
+		}
+		books = append(books, book)
+	}
+	return books
+}
+
+// Quotes implements debugserver.StateSource.
+func (s *DebugState) Quotes() []debugserver.Quote {
+	qi, ok := s.dex.(app.QuoteInspector)
+	if !ok {
+		return []debugserver.Quote{}
+	}
+
+	last := qi.LastQuotes()
+	quotes := make([]debugserver.Quote, 0, len(last))
+	for _, q := range last {
+		quotes = append(quotes, toDebugQuote(q))
+	}
+	return quotes
+}
+
+func toDebugLevels(levels []domain.OrderbookLevel) []debugserver.Level {
+	out := make([]debugserver.Level, 0, len(levels))
+	for _, l := range levels {
+		out = append(out, debugserver.Level{
+			Price:  l.Price.String(),
+			Amount: l.Amount.ToDecimal().String(),
+		})
+	}
+	return out
+}
+
+func toDebugQuote(q *domain.Quote) debugserver.Quote {
+	quote := debugserver.Quote{
+		Protocol:  q.Protocol,
+		AmountIn:  q.AmountIn.ToDecimal().String(),
+		AmountOut: q.AmountOut.ToDecimal().String(),
+		Price:     q.Price.Rate().String(),
+		FeeTier:   q.FeeTier,
+		Timestamp: q.Timestamp.UTC(),
+	}
+	if q.TokenIn != nil {
+		quote.TokenIn = q.TokenIn.Symbol()
+	}
+	if q.TokenOut != nil {
+		quote.TokenOut = q.TokenOut.Symbol()
+	}
+	return quote
+}
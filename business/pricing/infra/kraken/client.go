@@ -0,0 +1,313 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/fd1az/arbitrage-bot/internal/wsconn"
+)
+
+const (
+	tracerName = "kraken"
+	meterName  = "kraken"
+
+	// Kraken public WebSocket endpoint (market data)
+	BaseWSURL = "wss://ws.kraken.com"
+)
+
+// ClientConfig holds configuration for the Kraken client.
+type ClientConfig struct {
+	BaseURL      string        // WebSocket URL
+	Pairs        []string      // Pairs to subscribe (e.g., "XBT/USD")
+	Depth        int           // Book depth per side
+	ReadTimeout  time.Duration // Read timeout
+	WriteTimeout time.Duration // Write timeout
+}
+
+// DefaultClientConfig returns sensible defaults.
+func DefaultClientConfig(pairs []string) ClientConfig {
+	return ClientConfig{
+		BaseURL:      BaseWSURL,
+		Pairs:        pairs,
+		Depth:        10,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+}
+
+// clientMetrics holds OTEL metric instruments.
+type clientMetrics struct {
+	messagesReceived metric.Int64Counter
+	snapshots        metric.Int64Counter
+	bookUpdates      metric.Int64Counter
+	resubscribes     metric.Int64Counter
+	parseErrors      metric.Int64Counter
+}
+
+// Client is a Kraken WebSocket client.
+type Client struct {
+	config ClientConfig
+	logger logger.LoggerInterface
+
+	conn   *wsconn.Client
+	connMu sync.RWMutex
+
+	// Message handlers
+	onBook     func(*BookMessage)
+	handlersMu sync.RWMutex
+
+	// Observability
+	tracer  trace.Tracer
+	metrics *clientMetrics
+
+	// State
+	running atomic.Bool
+}
+
+// NewClient creates a new Kraken WebSocket client.
+func NewClient(cfg ClientConfig, log logger.LoggerInterface) (*Client, error) {
+	c := &Client{
+		config: cfg,
+		logger: log,
+		tracer: otel.Tracer(tracerName),
+	}
+
+	if err := c.initMetrics(); err != nil {
+		return nil, fmt.Errorf("init metrics: %w", err)
+	}
+
+	return c, nil
+}
+
+func (c *Client) initMetrics() error {
+	meter := otel.Meter(meterName)
+	var err error
+
+	c.metrics = &clientMetrics{}
+
+	c.metrics.messagesReceived, err = meter.Int64Counter(
+		"kraken_messages_total",
+		metric.WithDescription("Total messages received"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.snapshots, err = meter.Int64Counter(
+		"kraken_book_snapshots_total",
+		metric.WithDescription("Total book snapshots received"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.bookUpdates, err = meter.Int64Counter(
+		"kraken_book_updates_total",
+		metric.WithDescription("Total book updates received"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.resubscribes, err = meter.Int64Counter(
+		"kraken_book_resubscribes_total",
+		metric.WithDescription("Book resubscriptions after checksum mismatches"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.parseErrors, err = meter.Int64Counter(
+		"kraken_parse_errors_total",
+		metric.WithDescription("Message parse errors"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// OnBook registers a handler for book snapshots and updates.
+func (c *Client) OnBook(handler func(*BookMessage)) {
+	c.handlersMu.Lock()
+	c.onBook = handler
+	c.handlersMu.Unlock()
+}
+
+// Connect establishes the WebSocket connection and subscribes to the book.
+// Subscriptions are re-sent after every reconnect, so each book is
+// re-snapshotted; a failed resubscription triggers another reconnect.
+func (c *Client) Connect(ctx context.Context) error {
+	ctx, span := c.tracer.Start(ctx, "kraken.connect",
+		trace.WithAttributes(
+			attribute.StringSlice("pairs", c.config.Pairs),
+		),
+	)
+	defer span.End()
+
+	if len(c.config.Pairs) == 0 {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext("no pairs configured"))
+	}
+
+	baseURL := c.config.BaseURL
+	if baseURL == "" {
+		baseURL = BaseWSURL
+	}
+
+	wsCfg := wsconn.DefaultConfig(baseURL, "kraken")
+	wsCfg.ReadTimeout = c.config.ReadTimeout
+	wsCfg.WriteTimeout = c.config.WriteTimeout
+
+	conn, err := wsconn.New(wsCfg)
+	if err != nil {
+		return apperror.New(apperror.CodeKrakenConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to create wsconn"))
+	}
+
+	conn.OnMessage(c.handleMessage)
+	conn.OnReconnect(func(ctx context.Context) error {
+		return c.send(ctx, conn, EventSubscribe, c.config.Pairs)
+	})
+
+	if err := conn.ConnectWithRetry(ctx); err != nil {
+		return apperror.New(apperror.CodeKrakenConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to connect to Kraken"))
+	}
+
+	if err := c.send(ctx, conn, EventSubscribe, c.config.Pairs); err != nil {
+		conn.Close()
+		return apperror.New(apperror.CodeKrakenConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to subscribe to Kraken book"))
+	}
+
+	c.connMu.Lock()
+	c.conn = conn
+	c.connMu.Unlock()
+
+	c.running.Store(true)
+
+	c.logger.Info(ctx, "kraken client connected",
+		"url", baseURL,
+		"pairs", c.config.Pairs)
+
+	return nil
+}
+
+// Resubscribe drops and re-requests the book of a pair, which makes Kraken
+// send a fresh snapshot. Used when the local book fails its checksum.
+func (c *Client) Resubscribe(ctx context.Context, pair string) error {
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	if conn == nil {
+		return apperror.New(apperror.CodeKrakenConnectionFailed,
+			apperror.WithContext("not connected"))
+	}
+
+	c.metrics.resubscribes.Add(ctx, 1, metric.WithAttributes(attribute.String("pair", pair)))
+
+	if err := c.send(ctx, conn, EventUnsubscribe, []string{pair}); err != nil {
+		return err
+	}
+	return c.send(ctx, conn, EventSubscribe, []string{pair})
+}
+
+// send sends a book subscribe or unsubscribe request for pairs.
+func (c *Client) send(ctx context.Context, conn *wsconn.Client, event string, pairs []string) error {
+	req := SubscribeRequest{
+		Event:        event,
+		Pair:         pairs,
+		Subscription: Subscription{Name: ChannelBook, Depth: c.config.Depth},
+	}
+	if err := conn.SendJSON(ctx, req); err != nil {
+		c.logger.Warn(ctx, "kraken "+event+" failed", "pairs", pairs, "error", err)
+		return fmt.Errorf("%s book: %w", event, err)
+	}
+	c.logger.Debug(ctx, "kraken "+event+" sent", "pairs", pairs)
+	return nil
+}
+
+// handleMessage processes incoming WebSocket messages.
+func (c *Client) handleMessage(ctx context.Context, data []byte) {
+	c.metrics.messagesReceived.Add(ctx, 1)
+
+	if len(data) == 0 {
+		return
+	}
+
+	// Events (heartbeats, status) are objects; channel data are arrays
+	if data[0] == '{' {
+		c.handleEvent(ctx, data)
+		return
+	}
+
+	msg, err := ParseBookMessage(data)
+	if err != nil {
+		c.metrics.parseErrors.Add(ctx, 1)
+		c.logger.Debug(ctx, "failed to parse message", "error", err, "data", string(data[:min(len(data), 500)]))
+		return
+	}
+
+	if msg.Snapshot {
+		c.metrics.snapshots.Add(ctx, 1)
+	} else {
+		c.metrics.bookUpdates.Add(ctx, 1)
+	}
+
+	c.handlersMu.RLock()
+	handler := c.onBook
+	c.handlersMu.RUnlock()
+
+	if handler != nil {
+		handler(msg)
+	}
+}
+
+// handleEvent logs subscription errors; heartbeats and status are ignored.
+func (c *Client) handleEvent(ctx context.Context, data []byte) {
+	var event EventMessage
+	if err := json.Unmarshal(data, &event); err != nil {
+		c.metrics.parseErrors.Add(ctx, 1)
+		return
+	}
+	if event.Event == EventSubscriptionStatus && event.Status == "error" {
+		c.logger.Warn(ctx, "kraken subscription rejected", "pair", event.Pair, "error", event.ErrorMessage)
+	}
+}
+
+// Close closes the client connection.
+func (c *Client) Close() error {
+	c.running.Store(false)
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// IsConnected returns whether the client is connected.
+func (c *Client) IsConnected() bool {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.conn != nil && c.conn.IsConnected()
+}
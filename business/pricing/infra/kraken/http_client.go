@@ -0,0 +1,167 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/httpclient"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+const (
+	// Kraken public REST API (no auth required for market data)
+	BaseAPIURL = "https://api.kraken.com"
+
+	// Default HTTP client settings
+	httpTimeout = 10 * time.Second
+)
+
+// HTTPClientConfig holds configuration for the Kraken HTTP client.
+type HTTPClientConfig struct {
+	BaseURL string        // API base URL (empty = default)
+	Timeout time.Duration // Request timeout
+}
+
+// DefaultHTTPClientConfig returns sensible defaults.
+func DefaultHTTPClientConfig() HTTPClientConfig {
+	return HTTPClientConfig{
+		BaseURL: BaseAPIURL,
+		Timeout: httpTimeout,
+	}
+}
+
+// HTTPClient provides Kraken REST API access for fallback scenarios.
+type HTTPClient struct {
+	client httpclient.Client
+	config HTTPClientConfig
+	logger logger.LoggerInterface
+	tracer trace.Tracer
+}
+
+// NewHTTPClient creates a new Kraken HTTP client.
+func NewHTTPClient(cfg HTTPClientConfig, log logger.LoggerInterface) (*HTTPClient, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = BaseAPIURL
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = httpTimeout
+	}
+
+	tracer := otel.Tracer(tracerName)
+
+	client, err := httpclient.NewInstrumentedClient(
+		httpclient.WithProviderName("kraken"),
+		httpclient.WithBaseURL(baseURL),
+		httpclient.WithRequestTimeout(timeout),
+		httpclient.WithTraceOptions(tracer, httpclient.TraceRequest, httpclient.TraceResponse),
+		httpclient.WithHeaders(map[string]string{
+			"Accept": "application/json",
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	return &HTTPClient{
+		client: client,
+		config: cfg,
+		logger: log,
+		tracer: tracer,
+	}, nil
+}
+
+// GetDepth fetches the book for a pair (e.g. "XBT/USD") via REST API.
+// This is used as a fallback when WebSocket data is stale or unavailable.
+func (c *HTTPClient) GetDepth(ctx context.Context, pair string, count int) (*DepthResult, error) {
+	ctx, span := c.tracer.Start(ctx, "kraken.http.get_depth",
+		trace.WithAttributes(
+			attribute.String("pair", pair),
+			attribute.Int("count", count),
+		),
+	)
+	defer span.End()
+
+	var result DepthResponse
+	resp, err := c.client.NewRequestWithOptions(
+		httpclient.WithLabels(
+			httpclient.NewLabel("endpoint", "depth"),
+			httpclient.NewLabel("pair", pair),
+		),
+		httpclient.WithResponseErrorHandler(krakenErrorHandler),
+	).
+		SetQueryParam("pair", restPairName(pair)).
+		SetQueryParam("count", strconv.Itoa(count)).
+		SetResult(&result).
+		Get(ctx, "/0/public/Depth")
+
+	if err != nil {
+		span.RecordError(err)
+		return nil, apperror.New(apperror.CodeKrakenConnectionFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to fetch depth from REST API"))
+	}
+
+	if resp.IsError() {
+		return nil, apperror.New(apperror.CodeKrakenConnectionFailed,
+			apperror.WithContext(fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.String())))
+	}
+
+	// Kraken reports request errors with HTTP 200 and a non-empty error list
+	if len(result.Error) > 0 {
+		return nil, apperror.New(apperror.CodeKrakenAPIError,
+			apperror.WithContext(strings.Join(result.Error, "; ")))
+	}
+
+	// The result is keyed by Kraken's internal pair name; one pair was requested
+	for _, book := range result.Result {
+		span.SetAttributes(
+			attribute.Int("bids", len(book.Bids)),
+			attribute.Int("asks", len(book.Asks)),
+		)
+
+		c.logger.Debug(ctx, "fetched depth via HTTP",
+			"pair", pair,
+			"bids", len(book.Bids),
+			"asks", len(book.Asks))
+
+		return &book, nil
+	}
+
+	return nil, apperror.New(apperror.CodeKrakenAPIError,
+		apperror.WithContext(fmt.Sprintf("no depth returned for %s", pair)))
+}
+
+// KrakenAPIError represents an error response from Kraken API.
+type KrakenAPIError struct {
+	StatusCode int      `json:"-"`
+	Errors     []string `json:"error"`
+}
+
+func (e *KrakenAPIError) Error() string {
+	return fmt.Sprintf("kraken API error %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+// krakenErrorHandler parses Kraken API error responses.
+func krakenErrorHandler(statusCode int, body []byte) error {
+	if statusCode >= 400 {
+		var apiErr KrakenAPIError
+		if err := json.Unmarshal(body, &apiErr); err == nil && len(apiErr.Errors) > 0 {
+			apiErr.StatusCode = statusCode
+			return &apiErr
+		}
+		return fmt.Errorf("HTTP %d: %s", statusCode, string(body))
+	}
+	return nil
+}
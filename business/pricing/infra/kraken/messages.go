@@ -0,0 +1,217 @@
+// Package kraken implements the CEXProvider interface for Kraken spot.
+package kraken
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// WebSocket request messages
+
+// SubscribeRequest is a WebSocket subscribe or unsubscribe request.
+type SubscribeRequest struct {
+	Event        string       `json:"event"` // "subscribe" or "unsubscribe"
+	Pair         []string     `json:"pair"`  // e.g. ["XBT/USD"]
+	Subscription Subscription `json:"subscription"`
+}
+
+// Subscription selects the channel and its options.
+type Subscription struct {
+	Name  string `json:"name"`            // e.g. "book"
+	Depth int    `json:"depth,omitempty"` // Book depth: 10, 25, 100, 500 or 1000
+}
+
+// Channel and event names
+const (
+	ChannelBook = "book"
+
+	EventSubscribe          = "subscribe"
+	EventUnsubscribe        = "unsubscribe"
+	EventHeartbeat          = "heartbeat"
+	EventSubscriptionStatus = "subscriptionStatus"
+)
+
+// checksumDepth is the number of levels per side covered by the book checksum.
+const checksumDepth = 10
+
+// EventMessage is a non-data message (heartbeat, system or subscription status).
+// Data messages are JSON arrays; events are objects.
+type EventMessage struct {
+	Event        string `json:"event"`
+	Status       string `json:"status"`
+	Pair         string `json:"pair"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// BookLevel is a raw price level as sent by Kraken. The strings are kept
+// as received because the checksum is computed over their exact digits.
+type BookLevel struct {
+	Price  string
+	Volume string
+}
+
+// ParsePrice parses the price as decimal.
+func (l BookLevel) ParsePrice() (decimal.Decimal, error) {
+	return decimal.NewFromString(l.Price)
+}
+
+// ParseVolume parses the volume as decimal.
+func (l BookLevel) ParseVolume() (decimal.Decimal, error) {
+	return decimal.NewFromString(l.Volume)
+}
+
+// BookMessage is a book snapshot or incremental update for one pair.
+type BookMessage struct {
+	Pair        string // e.g. "XBT/USD"
+	Snapshot    bool   // true for the initial snapshot ("as"/"bs")
+	Asks        []BookLevel
+	Bids        []BookLevel
+	Checksum    uint32 // CRC32 of the top 10 levels after the update
+	HasChecksum bool
+}
+
+// bookPayload is one object of a book message. Snapshots use "as"/"bs";
+// updates use "a"/"b" and carry the checksum "c". An update touching both
+// sides may arrive as two objects in the same message.
+type bookPayload struct {
+	AsksSnapshot [][]string `json:"as"`
+	BidsSnapshot [][]string `json:"bs"`
+	Asks         [][]string `json:"a"`
+	Bids         [][]string `json:"b"`
+	Checksum     string     `json:"c"`
+}
+
+// ParseBookMessage parses a book data message:
+// [channelID, {payload}, ({payload},) "book-<depth>", "<pair>"].
+func ParseBookMessage(data []byte) (*BookMessage, error) {
+	var parts []json.RawMessage
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return nil, err
+	}
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("book message has %d elements, want at least 4", len(parts))
+	}
+
+	var channel, pair string
+	if err := json.Unmarshal(parts[len(parts)-2], &channel); err != nil {
+		return nil, fmt.Errorf("invalid channel name: %w", err)
+	}
+	if !strings.HasPrefix(channel, ChannelBook+"-") {
+		return nil, fmt.Errorf("unexpected channel %q", channel)
+	}
+	if err := json.Unmarshal(parts[len(parts)-1], &pair); err != nil {
+		return nil, fmt.Errorf("invalid pair: %w", err)
+	}
+
+	msg := &BookMessage{Pair: pair}
+	for _, raw := range parts[1 : len(parts)-2] {
+		var payload bookPayload
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return nil, fmt.Errorf("invalid book payload: %w", err)
+		}
+		if payload.AsksSnapshot != nil || payload.BidsSnapshot != nil {
+			msg.Snapshot = true
+		}
+		msg.Asks = appendLevels(msg.Asks, payload.AsksSnapshot, payload.Asks)
+		msg.Bids = appendLevels(msg.Bids, payload.BidsSnapshot, payload.Bids)
+		if payload.Checksum != "" {
+			checksum, err := strconv.ParseUint(payload.Checksum, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid checksum %q: %w", payload.Checksum, err)
+			}
+			msg.Checksum = uint32(checksum)
+			msg.HasChecksum = true
+		}
+	}
+	return msg, nil
+}
+
+// appendLevels appends [price, volume, timestamp, ("r")] entries as levels.
+func appendLevels(levels []BookLevel, groups ...[][]string) []BookLevel {
+	for _, group := range groups {
+		for _, entry := range group {
+			if len(entry) < 2 {
+				continue
+			}
+			levels = append(levels, BookLevel{Price: entry[0], Volume: entry[1]})
+		}
+	}
+	return levels
+}
+
+// REST API responses
+
+// DepthResponse is the REST API response for /0/public/Depth. The result is
+// keyed by Kraken's internal pair name (e.g. "XETHZUSD"), not the request name.
+type DepthResponse struct {
+	Error  []string               `json:"error"`
+	Result map[string]DepthResult `json:"result"`
+}
+
+// DepthResult is the book of one pair. Each level is [price, volume, timestamp]
+// where timestamp is a number.
+type DepthResult struct {
+	Asks [][]json.RawMessage `json:"asks"`
+	Bids [][]json.RawMessage `json:"bids"`
+}
+
+// ParseDepthLevels parses raw REST depth levels.
+func ParseDepthLevels(raw [][]json.RawMessage) ([]BookLevel, error) {
+	levels := make([]BookLevel, 0, len(raw))
+	for _, r := range raw {
+		if len(r) < 2 {
+			continue
+		}
+		var level BookLevel
+		if err := json.Unmarshal(r[0], &level.Price); err != nil {
+			return nil, fmt.Errorf("invalid price: %w", err)
+		}
+		if err := json.Unmarshal(r[1], &level.Volume); err != nil {
+			return nil, fmt.Errorf("invalid volume: %w", err)
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// Pair name helpers
+
+// symbolAliases maps asset symbols to Kraken's names. Kraken lists bitcoin
+// as XBT; the wrapped token prices against the same book.
+var symbolAliases = map[string]string{
+	"BTC":  "XBT",
+	"WBTC": "XBT",
+}
+
+// PairName builds a Kraken WebSocket pair name from base and quote symbols.
+// Example: ("WBTC", "USD") -> "XBT/USD"
+func PairName(base, quote string) string {
+	return krakenSymbol(base) + "/" + krakenSymbol(quote)
+}
+
+// SplitPairName splits a Kraken pair name into base and quote symbols.
+// Example: "XBT/USD" -> ("XBT", "USD", true)
+func SplitPairName(name string) (string, string, bool) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// restPairName converts a WebSocket pair name to the REST form ("XBT/USD" -> "XBTUSD").
+func restPairName(name string) string {
+	return strings.ReplaceAll(name, "/", "")
+}
+
+func krakenSymbol(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+	if alias, ok := symbolAliases[symbol]; ok {
+		return alias
+	}
+	return symbol
+}
@@ -0,0 +1,149 @@
+package kraken
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// errChecksumMismatch means the local book no longer matches Kraken's.
+var errChecksumMismatch = errors.New("book checksum mismatch")
+
+// bookEntry is a price level with its raw strings kept for the checksum.
+type bookEntry struct {
+	level  BookLevel
+	price  decimal.Decimal
+	volume decimal.Decimal
+}
+
+// orderbookState holds the book of one pair, kept in sync with Kraken's
+// incremental updates and verified against the checksum of each update.
+// Updates are dropped while unsynced, i.e. until a snapshot arrives.
+type orderbookState struct {
+	bids       map[string]bookEntry // Keyed by normalized price
+	asks       map[string]bookEntry
+	depth      int // Subscribed depth; levels beyond it are dropped
+	synced     bool
+	lastUpdate time.Time
+	mu         sync.RWMutex
+}
+
+func newOrderbookState(depth int) *orderbookState {
+	return &orderbookState{
+		bids:  make(map[string]bookEntry),
+		asks:  make(map[string]bookEntry),
+		depth: depth,
+	}
+}
+
+// apply applies a snapshot or update; the caller holds mu. On a checksum
+// mismatch or malformed level the book is cleared and marked unsynced.
+func (s *orderbookState) apply(msg *BookMessage) error {
+	if msg.Snapshot {
+		s.bids = make(map[string]bookEntry, s.depth)
+		s.asks = make(map[string]bookEntry, s.depth)
+		s.synced = true
+	} else if !s.synced {
+		return nil
+	}
+
+	if err := applyLevels(s.asks, msg.Asks); err != nil {
+		s.desync()
+		return err
+	}
+	if err := applyLevels(s.bids, msg.Bids); err != nil {
+		s.desync()
+		return err
+	}
+	truncate(s.asks, false, s.depth)
+	truncate(s.bids, true, s.depth)
+
+	if msg.HasChecksum {
+		if got := s.checksum(); got != msg.Checksum {
+			s.desync()
+			return fmt.Errorf("%w: got %d, want %d", errChecksumMismatch, got, msg.Checksum)
+		}
+	}
+
+	s.lastUpdate = time.Now()
+	return nil
+}
+
+// desync clears the book until the next snapshot.
+func (s *orderbookState) desync() {
+	s.bids = make(map[string]bookEntry)
+	s.asks = make(map[string]bookEntry)
+	s.synced = false
+}
+
+// checksum computes Kraken's CRC32 over the top 10 asks (ascending) then
+// the top 10 bids (descending), each level as price digits then volume
+// digits with the decimal point and leading zeros removed.
+func (s *orderbookState) checksum() uint32 {
+	var b strings.Builder
+	for _, side := range [][]bookEntry{sortedEntries(s.asks, false), sortedEntries(s.bids, true)} {
+		for _, entry := range side[:min(len(side), checksumDepth)] {
+			b.WriteString(checksumDigits(entry.level.Price))
+			b.WriteString(checksumDigits(entry.level.Volume))
+		}
+	}
+	return crc32.ChecksumIEEE([]byte(b.String()))
+}
+
+// checksumDigits formats a price or volume for the checksum ("0.05005" -> "5005").
+func checksumDigits(value string) string {
+	return strings.TrimLeft(strings.ReplaceAll(value, ".", ""), "0")
+}
+
+// applyLevels inserts, replaces or (volume zero) removes levels on one side.
+func applyLevels(side map[string]bookEntry, levels []BookLevel) error {
+	for _, level := range levels {
+		price, err := level.ParsePrice()
+		if err != nil {
+			return fmt.Errorf("invalid price %q: %w", level.Price, err)
+		}
+		volume, err := level.ParseVolume()
+		if err != nil {
+			return fmt.Errorf("invalid volume %q: %w", level.Volume, err)
+		}
+
+		key := price.String()
+		if volume.IsZero() {
+			delete(side, key)
+			continue
+		}
+		side[key] = bookEntry{level: level, price: price, volume: volume}
+	}
+	return nil
+}
+
+// truncate drops the levels beyond depth, as Kraken stops updating them.
+func truncate(side map[string]bookEntry, isBid bool, depth int) {
+	if depth <= 0 || len(side) <= depth {
+		return
+	}
+	for _, entry := range sortedEntries(side, isBid)[depth:] {
+		delete(side, entry.price.String())
+	}
+}
+
+// sortedEntries returns one side best-first: bids descending, asks ascending.
+func sortedEntries(side map[string]bookEntry, isBid bool) []bookEntry {
+	entries := make([]bookEntry, 0, len(side))
+	for _, entry := range side {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b bookEntry) int {
+		if isBid {
+			return b.price.Cmp(a.price)
+		}
+		return a.price.Cmp(b.price)
+	})
+	return entries
+}
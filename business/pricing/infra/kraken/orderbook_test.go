@@ -0,0 +1,164 @@
+package kraken
+
+import (
+	"errors"
+	"testing"
+)
+
+// checksumSnapshot is a 10-level book whose checksum input is
+// "5005500" "5010500" ... "5000500" "4995500" ... (asks then bids).
+func checksumSnapshot() *BookMessage {
+	msg := &BookMessage{Pair: "XBT/USD", Snapshot: true}
+	for _, price := range []string{"0.05005", "0.05010", "0.05015", "0.05020", "0.05025", "0.05030", "0.05035", "0.05040", "0.05045", "0.05050"} {
+		msg.Asks = append(msg.Asks, BookLevel{Price: price, Volume: "0.00000500"})
+	}
+	for _, price := range []string{"0.05000", "0.04995", "0.04990", "0.04985", "0.04980", "0.04975", "0.04970", "0.04965", "0.04960", "0.04955"} {
+		msg.Bids = append(msg.Bids, BookLevel{Price: price, Volume: "0.00000500"})
+	}
+	return msg
+}
+
+func TestOrderbookState_Checksum(t *testing.T) {
+	state := newOrderbookState(10)
+	if err := state.apply(checksumSnapshot()); err != nil {
+		t.Fatalf("apply snapshot: %v", err)
+	}
+
+	// CRC32 of the concatenated digits, computed independently
+	if got, want := state.checksum(), uint32(2726735196); got != want {
+		t.Errorf("checksum() = %d, want %d", got, want)
+	}
+}
+
+func TestOrderbookState_Apply(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     *BookMessage
+		wantErr    error
+		wantSynced bool
+		wantAsks   int
+		wantBest   string // Best ask after the update
+	}{
+		{
+			name:       "matching_checksum",
+			update:     &BookMessage{Pair: "XBT/USD", Checksum: 2726735196, HasChecksum: true},
+			wantSynced: true,
+			wantAsks:   10,
+			wantBest:   "0.05005",
+		},
+		{
+			name: "insert_truncates_to_depth",
+			update: &BookMessage{Pair: "XBT/USD", Asks: []BookLevel{
+				{Price: "0.05001", Volume: "1.00000000"},
+			}},
+			wantSynced: true,
+			wantAsks:   10,
+			wantBest:   "0.05001",
+		},
+		{
+			name: "zero_volume_removes_level",
+			update: &BookMessage{Pair: "XBT/USD", Asks: []BookLevel{
+				{Price: "0.05005", Volume: "0.00000000"},
+			}},
+			wantSynced: true,
+			wantAsks:   9,
+			wantBest:   "0.05010",
+		},
+		{
+			name:       "checksum_mismatch_desyncs",
+			update:     &BookMessage{Pair: "XBT/USD", Checksum: 1, HasChecksum: true},
+			wantErr:    errChecksumMismatch,
+			wantSynced: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newOrderbookState(10)
+			if err := state.apply(checksumSnapshot()); err != nil {
+				t.Fatalf("apply snapshot: %v", err)
+			}
+
+			err := state.apply(tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("apply() error = %v, want %v", err, tt.wantErr)
+			}
+			if state.synced != tt.wantSynced {
+				t.Errorf("synced = %v, want %v", state.synced, tt.wantSynced)
+			}
+			if len(state.asks) != tt.wantAsks {
+				t.Errorf("asks = %d, want %d", len(state.asks), tt.wantAsks)
+			}
+			if tt.wantBest != "" {
+				if best := sortedEntries(state.asks, false)[0].level.Price; best != tt.wantBest {
+					t.Errorf("best ask = %s, want %s", best, tt.wantBest)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderbookState_IgnoresUpdatesUntilSnapshot(t *testing.T) {
+	state := newOrderbookState(10)
+	update := &BookMessage{Pair: "XBT/USD", Asks: []BookLevel{{Price: "0.05005", Volume: "1.0"}}, Checksum: 1, HasChecksum: true}
+
+	if err := state.apply(update); err != nil {
+		t.Fatalf("update before snapshot should be dropped, got %v", err)
+	}
+	if state.synced || len(state.asks) != 0 {
+		t.Errorf("expected empty unsynced book, got synced=%v asks=%d", state.synced, len(state.asks))
+	}
+}
+
+func TestParseBookMessage(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantSnapshot bool
+		wantAsks     int
+		wantBids     int
+		wantChecksum uint32
+		wantErr      bool
+	}{
+		{
+			name:         "snapshot",
+			data:         `[0,{"as":[["5541.30000","2.50700000","1534614248.123678"]],"bs":[["5541.20000","1.52900000","1534614248.765567"]]},"book-10","XBT/USD"]`,
+			wantSnapshot: true,
+			wantAsks:     1,
+			wantBids:     1,
+		},
+		{
+			name:         "update_both_sides",
+			data:         `[1234,{"a":[["5541.30000","2.50700000","1534614248.456738","r"]]},{"b":[["5541.20000","0.00000000","1534614248.456738"]],"c":"974942666"},"book-10","XBT/USD"]`,
+			wantAsks:     1,
+			wantBids:     1,
+			wantChecksum: 974942666,
+		},
+		{
+			name:    "other_channel",
+			data:    `[42,[["5541.2","0.1","1534614248.1","s","l",""]],"trade","XBT/USD"]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := ParseBookMessage([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBookMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if msg.Pair != "XBT/USD" || msg.Snapshot != tt.wantSnapshot {
+				t.Errorf("pair = %s, snapshot = %v", msg.Pair, msg.Snapshot)
+			}
+			if len(msg.Asks) != tt.wantAsks || len(msg.Bids) != tt.wantBids {
+				t.Errorf("asks = %d, bids = %d; want %d, %d", len(msg.Asks), len(msg.Bids), tt.wantAsks, tt.wantBids)
+			}
+			if msg.Checksum != tt.wantChecksum {
+				t.Errorf("checksum = %d, want %d", msg.Checksum, tt.wantChecksum)
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+package kraken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+	"github.com/shopspring/decimal"
+)
+
+// Ensure Provider implements CEXProvider.
+var _ app.CEXProvider = (*Provider)(nil)
+
+// Ensure Provider reports which books it subscribed to.
+var _ app.Subscriber = (*Provider)(nil)
+
+// registryAliases maps Kraken symbols back to registry symbols.
+var registryAliases = map[string]string{
+	"XBT": "WBTC",
+}
+
+// ProviderConfig holds configuration for the Kraken provider.
+type ProviderConfig struct {
+	WebSocketURL   string        // WebSocket URL (empty = default)
+	HTTPURL        string        // REST API base URL (empty = default)
+	Pairs          []string      // Pairs (e.g., "ETH/USDC", "XBT/USD")
+	Depth          int           // Subscribed book depth (10, 25, 100, 500 or 1000)
+	SnapshotDepth  int           // Number of orderbook levels to expose
+	StaleTimeout   time.Duration // How long before data is considered stale
+	EnableFallback bool          // Enable HTTP fallback when WS data is stale
+
+	// RejectPartialFills fails GetEffectivePrice with a PartialFillError
+	// when the book can't fill the size, instead of averaging what it can
+	RejectPartialFills bool
+}
+
+// DefaultProviderConfig returns sensible defaults.
+func DefaultProviderConfig(pairs []string) ProviderConfig {
+	return ProviderConfig{
+		Pairs:          pairs,
+		Depth:          10,
+		SnapshotDepth:  10,
+		StaleTimeout:   5 * time.Second,
+		EnableFallback: true,
+	}
+}
+
+// Provider implements CEXProvider for Kraken.
+type Provider struct {
+	config     ProviderConfig
+	logger     logger.LoggerInterface
+	client     *Client     // WebSocket client
+	httpClient *HTTPClient // HTTP client for fallback
+
+	// Orderbook state per pair
+	orderbooks map[string]*orderbookState
+	booksMu    sync.RWMutex
+
+	// Asset registry for conversions
+	registry *asset.Registry
+
+	// Observability
+	tracer trace.Tracer
+}
+
+// NewProvider creates a new Kraken CEX provider.
+func NewProvider(cfg ProviderConfig, log logger.LoggerInterface) (*Provider, error) {
+	if cfg.Depth == 0 {
+		cfg.Depth = 10
+	}
+
+	wsURL := cfg.WebSocketURL
+	if wsURL == "" {
+		wsURL = BaseWSURL
+	}
+
+	clientCfg := ClientConfig{
+		BaseURL:      wsURL,
+		Pairs:        cfg.Pairs,
+		Depth:        cfg.Depth,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	client, err := NewClient(clientCfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create HTTP client for fallback (optional)
+	var httpClient *HTTPClient
+	if cfg.EnableFallback {
+		httpCfg := HTTPClientConfig{
+			BaseURL: cfg.HTTPURL, // Empty = default
+		}
+		httpClient, err = NewHTTPClient(httpCfg, log)
+		if err != nil {
+			log.Warn(context.Background(), "failed to create HTTP fallback client", "error", err)
+			// Continue without HTTP fallback
+		}
+	}
+
+	p := &Provider{
+		config:     cfg,
+		logger:     log,
+		client:     client,
+		httpClient: httpClient,
+		orderbooks: make(map[string]*orderbookState),
+		registry:   asset.DefaultRegistry(),
+		tracer:     otel.Tracer(tracerName),
+	}
+
+	for _, pair := range cfg.Pairs {
+		p.orderbooks[pair] = newOrderbookState(cfg.Depth)
+	}
+
+	client.OnBook(p.handleBook)
+
+	return p, nil
+}
+
+// Connect establishes connection to Kraken.
+func (p *Provider) Connect(ctx context.Context) error {
+	return p.client.Connect(ctx)
+}
+
+// Close closes the provider.
+func (p *Provider) Close() error {
+	return p.client.Close()
+}
+
+// Subscribes reports whether pair's book is subscribed.
+func (p *Provider) Subscribes(pair domain.Pair) bool {
+	p.booksMu.RLock()
+	defer p.booksMu.RUnlock()
+	_, ok := p.orderbooks[pairToName(pair)]
+	return ok
+}
+
+// GetOrderbook retrieves the current orderbook for a trading pair.
+func (p *Provider) GetOrderbook(ctx context.Context, pair domain.Pair) (*domain.Orderbook, error) {
+	ctx, span := p.tracer.Start(ctx, "kraken.get_orderbook",
+		trace.WithAttributes(attribute.String("pair", pair.String())),
+	)
+	defer span.End()
+
+	name := pairToName(pair)
+
+	p.booksMu.RLock()
+	state, ok := p.orderbooks[name]
+	p.booksMu.RUnlock()
+
+	if !ok {
+		return nil, apperror.New(apperror.CodeNotFound,
+			apperror.WithContext(fmt.Sprintf("pair %s not subscribed", name)))
+	}
+
+	state.mu.RLock()
+	isStale := time.Since(state.lastUpdate) > p.config.StaleTimeout
+	synced := state.synced
+	bidsLen := len(state.bids)
+	asksLen := len(state.asks)
+	state.mu.RUnlock()
+
+	// Check staleness - try HTTP fallback if available
+	if isStale {
+		span.SetAttributes(attribute.Bool("stale", true))
+
+		if p.httpClient != nil {
+			p.logger.Debug(ctx, "orderbook stale, using HTTP fallback", "pair", name)
+			return p.getOrderbookViaHTTP(ctx, pair, name, span)
+		}
+
+		return nil, apperror.New(apperror.CodeCacheExpired,
+			apperror.WithContext(fmt.Sprintf("orderbook stale for %s", name)))
+	}
+
+	// Check if we have a verified book (none yet, or resyncing after a checksum mismatch)
+	if !synced || bidsLen == 0 || asksLen == 0 {
+		if p.httpClient != nil {
+			p.logger.Debug(ctx, "no synced WS book, using HTTP fallback", "pair", name)
+			return p.getOrderbookViaHTTP(ctx, pair, name, span)
+		}
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithContext(fmt.Sprintf("no orderbook data for %s", name)))
+	}
+
+	baseAsset := p.baseAsset(pair)
+
+	state.mu.RLock()
+	ob := &domain.Orderbook{
+		Pair:      pair,
+		Bids:      domainLevels(sortedEntries(state.bids, true), baseAsset, p.config.SnapshotDepth),
+		Asks:      domainLevels(sortedEntries(state.asks, false), baseAsset, p.config.SnapshotDepth),
+		Timestamp: state.lastUpdate,
+	}
+	state.mu.RUnlock()
+
+	span.SetAttributes(
+		attribute.Int("bids", len(ob.Bids)),
+		attribute.Int("asks", len(ob.Asks)),
+		attribute.String("source", "websocket"),
+	)
+
+	p.logger.Debug(ctx, "orderbook retrieved", "pair", name, "bids", len(ob.Bids), "asks", len(ob.Asks))
+
+	return ob, nil
+}
+
+// getOrderbookViaHTTP fetches the orderbook via REST API fallback. Unlike
+// the other venues the REST book is not cached: the WebSocket book must
+// only ever hold checksum-verified state.
+func (p *Provider) getOrderbookViaHTTP(ctx context.Context, pair domain.Pair, name string, span trace.Span) (*domain.Orderbook, error) {
+	count := p.config.SnapshotDepth
+	if count <= 0 {
+		count = p.config.Depth
+	}
+
+	depth, err := p.httpClient.GetDepth(ctx, name, count)
+	if err != nil {
+		return nil, err
+	}
+
+	bidLevels, err := ParseDepthLevels(depth.Bids)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to parse bid levels"))
+	}
+	askLevels, err := ParseDepthLevels(depth.Asks)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithCause(err),
+			apperror.WithContext("failed to parse ask levels"))
+	}
+
+	bids := make(map[string]bookEntry, len(bidLevels))
+	if err := applyLevels(bids, bidLevels); err != nil {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook, apperror.WithCause(err))
+	}
+	asks := make(map[string]bookEntry, len(askLevels))
+	if err := applyLevels(asks, askLevels); err != nil {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook, apperror.WithCause(err))
+	}
+
+	baseAsset := p.baseAsset(pair)
+	ob := &domain.Orderbook{
+		Pair:      pair,
+		Bids:      domainLevels(sortedEntries(bids, true), baseAsset, p.config.SnapshotDepth),
+		Asks:      domainLevels(sortedEntries(asks, false), baseAsset, p.config.SnapshotDepth),
+		Timestamp: time.Now(),
+	}
+
+	span.SetAttributes(
+		attribute.Int("bids", len(ob.Bids)),
+		attribute.Int("asks", len(ob.Asks)),
+		attribute.String("source", "http_fallback"),
+	)
+
+	p.logger.Info(ctx, "orderbook retrieved via HTTP fallback", "pair", name, "bids", len(ob.Bids), "asks", len(ob.Asks))
+
+	return ob, nil
+}
+
+// GetEffectivePrice calculates the effective price for a given trade size.
+func (p *Provider) GetEffectivePrice(ctx context.Context, pair domain.Pair, size decimal.Decimal, side domain.Side) (*domain.Price, error) {
+	ctx, span := p.tracer.Start(ctx, "kraken.get_effective_price",
+		trace.WithAttributes(
+			attribute.String("pair", pair.String()),
+			attribute.String("size", size.String()),
+			attribute.String("side", string(side)),
+		),
+	)
+	defer span.End()
+
+	ob, err := p.GetOrderbook(ctx, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	var levels []domain.OrderbookLevel
+	if side == domain.SideBuy {
+		levels = ob.Asks // Buy from asks
+	} else {
+		levels = ob.Bids // Sell into bids
+	}
+
+	if len(levels) == 0 {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithContext("no liquidity"))
+	}
+
+	// VWAP calculation
+	remaining := size
+	totalCost := decimal.Zero
+	totalFilled := decimal.Zero
+
+	for _, level := range levels {
+		if remaining.IsZero() {
+			break
+		}
+
+		fillQty := decimal.Min(remaining, level.Amount.ToDecimal())
+		fillCost := fillQty.Mul(level.Price)
+
+		totalCost = totalCost.Add(fillCost)
+		totalFilled = totalFilled.Add(fillQty)
+		remaining = remaining.Sub(fillQty)
+	}
+
+	if totalFilled.IsZero() {
+		return nil, apperror.New(apperror.CodeInvalidOrderbook,
+			apperror.WithContext("could not fill any quantity"))
+	}
+
+	avgPrice := totalCost.Div(totalFilled)
+
+	if remaining.IsPositive() {
+		if p.config.RejectPartialFills {
+			fillErr := &domain.PartialFillError{Venue: domain.VenueKraken, Pair: pair, Side: side, Requested: size, Filled: totalFilled}
+			span.RecordError(fillErr)
+			return nil, apperror.New(apperror.CodeInsufficientLiquidity,
+				apperror.WithCause(fillErr),
+				apperror.WithContext(fillErr.Error()))
+		}
+		p.logger.Warn(ctx, "partial fill in effective price calculation",
+			"requested", size.String(),
+			"filled", totalFilled.String(),
+			"remaining", remaining.String())
+	}
+
+	sizeAmount, _ := asset.ParseDecimal(pair.Base, totalFilled)
+	rate := asset.NewPrice(pair.Base, pair.Quote, avgPrice, ob.Timestamp)
+
+	price := domain.NewPrice(rate, sizeAmount, side, domain.VenueKraken)
+
+	span.SetAttributes(
+		attribute.String("effective_price", avgPrice.String()),
+		attribute.String("filled", totalFilled.String()),
+	)
+
+	return &price, nil
+}
+
+// handleBook applies a book message to the pair's state and resubscribes
+// when the book fails its checksum.
+func (p *Provider) handleBook(msg *BookMessage) {
+	ctx := context.Background()
+
+	p.booksMu.RLock()
+	state, ok := p.orderbooks[msg.Pair]
+	p.booksMu.RUnlock()
+
+	if !ok {
+		p.logger.Debug(ctx, "book message for unknown pair", "pair", msg.Pair)
+		return
+	}
+
+	state.mu.Lock()
+	err := state.apply(msg)
+	state.mu.Unlock()
+
+	if err == nil {
+		return
+	}
+
+	if errors.Is(err, errChecksumMismatch) {
+		p.logger.Warn(ctx, "kraken book out of sync, resubscribing", "pair", msg.Pair, "error", err)
+	} else {
+		p.logger.Warn(ctx, "invalid kraken book update, resubscribing", "pair", msg.Pair, "error", err)
+	}
+
+	// Don't block the read loop on the round trip
+	go func() {
+		if err := p.client.Resubscribe(ctx, msg.Pair); err != nil {
+			p.logger.Warn(ctx, "kraken resubscribe failed", "pair", msg.Pair, "error", err)
+		}
+	}()
+}
+
+// baseAsset resolves the base asset of a pair for amount conversions.
+func (p *Provider) baseAsset(pair domain.Pair) *asset.Asset {
+	if pair.Base != nil {
+		return pair.Base
+	}
+	return asset.ETH
+}
+
+// domainLevels converts sorted book entries to domain levels,
+// truncated to maxDepth (0 = no limit).
+func domainLevels(entries []bookEntry, baseAsset *asset.Asset, maxDepth int) []domain.OrderbookLevel {
+	if maxDepth > 0 && len(entries) > maxDepth {
+		entries = entries[:maxDepth]
+	}
+	result := make([]domain.OrderbookLevel, 0, len(entries))
+	for _, entry := range entries {
+		amt, _ := asset.ParseDecimal(baseAsset, entry.volume)
+		result = append(result, domain.OrderbookLevel{Price: entry.price, Amount: amt})
+	}
+	return result
+}
+
+// pairToName converts a domain.Pair to a Kraken pair name.
+// Example: WBTC/USDC -> "XBT/USDC" (see symbolAliases)
+func pairToName(pair domain.Pair) string {
+	return PairName(pair.Base.Symbol(), pair.Quote.Symbol())
+}
+
+// PairNameToPair converts a Kraken pair name to a domain.Pair using the registry.
+// XBT resolves to WBTC and a fiat quote (e.g. USD) to the fiat asset.
+func PairNameToPair(name string, registry *asset.Registry) (domain.Pair, error) {
+	baseSym, quoteSym, ok := SplitPairName(name)
+	if !ok {
+		return domain.Pair{}, apperror.New(apperror.CodeInvalidInput,
+			apperror.WithContext(fmt.Sprintf("invalid pair name %q", name)))
+	}
+	if alias, ok := registryAliases[baseSym]; ok {
+		baseSym = alias
+	}
+
+	base, ok := registry.GetBySymbolAndChain(baseSym, asset.ChainIDEthereum)
+	if !ok {
+		return domain.Pair{}, apperror.New(apperror.CodeNotFound,
+			apperror.WithContext(fmt.Sprintf("unknown base asset %s", baseSym)))
+	}
+
+	quote, ok := registry.GetBySymbolAndChain(quoteSym, asset.ChainIDEthereum)
+	if !ok {
+		quote, ok = registry.GetBySymbolAndChain(quoteSym, asset.ChainIDFiat)
+	}
+	if !ok {
+		return domain.Pair{}, apperror.New(apperror.CodeNotFound,
+			apperror.WithContext(fmt.Sprintf("unknown quote asset %s", quoteSym)))
+	}
+
+	return domain.NewPair(base, quote), nil
+}
@@ -0,0 +1,250 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// mockLogger implements logger.LoggerInterface for testing.
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Info(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (m *mockLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+var _ logger.LoggerInterface = (*mockLogger)(nil)
+
+var wbtcUSDC = domain.Pair{Base: asset.WBTC, Quote: asset.USDC}
+
+// TestProvider_FallbackToHTTP tests that the provider uses the REST depth
+// when no WebSocket book has been received.
+func TestProvider_FallbackToHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/0/public/Depth" {
+			t.Errorf("expected path /0/public/Depth, got %s", r.URL.Path)
+		}
+		if pair := r.URL.Query().Get("pair"); pair != "XBTUSDC" {
+			t.Errorf("expected pair XBTUSDC, got %s", pair)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"error":[],"result":{"XBTUSDC":{
+			"asks":[["60001.00000","0.500",1700000000],["60000.50000","0.250",1700000000]],
+			"bids":[["59999.00000","1.000",1700000000],["59999.50000","0.750",1700000000]]
+		}}}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultProviderConfig([]string{"XBT/USDC"})
+	cfg.HTTPURL = server.URL
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	ob, err := provider.GetOrderbook(context.Background(), wbtcUSDC)
+	if err != nil {
+		t.Fatalf("expected HTTP fallback to succeed, got error: %v", err)
+	}
+
+	// Levels must be sorted best-first regardless of REST ordering
+	if !ob.Bids[0].Price.Equal(decimal.RequireFromString("59999.5")) {
+		t.Errorf("expected best bid 59999.5, got %s", ob.Bids[0].Price)
+	}
+	if !ob.Asks[0].Price.Equal(decimal.RequireFromString("60000.5")) {
+		t.Errorf("expected best ask 60000.5, got %s", ob.Asks[0].Price)
+	}
+
+	price, err := provider.GetEffectivePrice(context.Background(), wbtcUSDC, decimal.RequireFromString("0.5"), domain.SideBuy)
+	if err != nil {
+		t.Fatalf("GetEffectivePrice failed: %v", err)
+	}
+	if price.Source != domain.VenueKraken {
+		t.Errorf("expected source %s, got %s", domain.VenueKraken, price.Source)
+	}
+}
+
+func TestProvider_FallbackAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":["EQuery:Unknown asset pair"]}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultProviderConfig([]string{"XBT/USDC"})
+	cfg.HTTPURL = server.URL
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	if _, err := provider.GetOrderbook(context.Background(), wbtcUSDC); apperror.GetCode(err) != apperror.CodeKrakenAPIError {
+		t.Errorf("expected %s, got %v", apperror.CodeKrakenAPIError, err)
+	}
+}
+
+// krakenWSServer serves book snapshots and updates and records subscription requests.
+type krakenWSServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []SubscribeRequest
+}
+
+// newKrakenWSServer answers every subscribe with a snapshot followed by
+// the next of updates (if any).
+func newKrakenWSServer(t *testing.T, snapshot string, updates ...string) *krakenWSServer {
+	t.Helper()
+	s := &krakenWSServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.StatusNormalClosure, "")
+
+		ctx := r.Context()
+		for {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			var req SubscribeRequest
+			if err := json.Unmarshal(data, &req); err != nil {
+				continue
+			}
+			s.mu.Lock()
+			s.requests = append(s.requests, req)
+			subscribes := 0
+			for _, r := range s.requests {
+				if r.Event == EventSubscribe {
+					subscribes++
+				}
+			}
+			s.mu.Unlock()
+
+			if req.Event != EventSubscribe {
+				continue
+			}
+			conn.Write(ctx, websocket.MessageText, []byte(`{"event":"subscriptionStatus","status":"subscribed","pair":"XBT/USDC"}`))
+			conn.Write(ctx, websocket.MessageText, []byte(snapshot))
+			if subscribes <= len(updates) {
+				conn.Write(ctx, websocket.MessageText, []byte(updates[subscribes-1]))
+			}
+		}
+	}))
+	return s
+}
+
+func (s *krakenWSServer) events() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := make([]string, 0, len(s.requests))
+	for _, r := range s.requests {
+		events = append(events, r.Event)
+	}
+	return events
+}
+
+// TestProvider_ResubscribesOnChecksumMismatch tests that a bad checksum
+// clears the book and requests a fresh snapshot.
+func TestProvider_ResubscribesOnChecksumMismatch(t *testing.T) {
+	snapshot := `[0,{"as":[["60000.50000","0.25000000","1700000000.1"],["60001.00000","0.50000000","1700000000.1"]],"bs":[["59999.50000","0.75000000","1700000000.1"],["59999.00000","1.00000000","1700000000.1"]]},"book-10","XBT/USDC"]`
+	badUpdate := `[0,{"a":[["60000.50000","0.30000000","1700000000.2"]],"c":"1"},"book-10","XBT/USDC"]`
+
+	server := newKrakenWSServer(t, snapshot, badUpdate)
+	defer server.Close()
+
+	cfg := DefaultProviderConfig([]string{"XBT/USDC"})
+	cfg.WebSocketURL = "ws" + strings.TrimPrefix(server.URL, "http")
+	cfg.EnableFallback = false
+
+	provider, err := NewProvider(cfg, &mockLogger{})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	defer provider.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := provider.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// subscribe, unsubscribe, subscribe; the second snapshot resyncs the book
+	want := fmt.Sprint([]string{EventSubscribe, EventUnsubscribe, EventSubscribe})
+	for fmt.Sprint(server.events()) != want {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected requests %s, got %v", want, server.events())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	var ob *domain.Orderbook
+	for ob == nil {
+		ob, _ = provider.GetOrderbook(ctx, wbtcUSDC)
+		select {
+		case <-ctx.Done():
+			t.Fatal("book did not resync after resubscribe")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if !ob.Asks[0].Amount.ToDecimal().Equal(decimal.RequireFromString("0.25")) {
+		t.Errorf("expected resynced best ask size 0.25, got %s", ob.Asks[0].Amount.ToDecimal())
+	}
+}
+
+func TestPairNameToPair(t *testing.T) {
+	registry := asset.DefaultRegistry()
+
+	tests := []struct {
+		name      string
+		wantBase  string
+		wantQuote string
+		wantErr   bool
+	}{
+		{name: "XBT/USDC", wantBase: "WBTC", wantQuote: "USDC"},
+		{name: "ETH/USD", wantBase: "ETH", wantQuote: "USD"},
+		{name: "XBTUSD", wantErr: true},
+		{name: "DOGE/USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := PairNameToPair(tt.name, registry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PairNameToPair() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if pair.Base.Symbol() != tt.wantBase || pair.Quote.Symbol() != tt.wantQuote {
+				t.Errorf("PairNameToPair() = %s, want %s/%s", pair, tt.wantBase, tt.wantQuote)
+			}
+			if got := pairToName(pair); got != tt.name {
+				t.Errorf("pairToName() = %s, want %s", got, tt.name)
+			}
+		})
+	}
+}
@@ -59,4 +59,86 @@ type QuoteResult struct {
 	SqrtPriceX96After       *big.Int
 	InitializedTicksCrossed uint32
 	GasEstimate             *big.Int
+
+	// Spot price the quote's price limit was derived from (nil without a limit)
+	SqrtPriceX96Before *big.Int
+}
+
+// FactoryABI is the ABI for the Uniswap V3 factory (getPool only).
+const FactoryABI = `[
+	{
+		"inputs": [
+			{"internalType": "address", "name": "tokenA", "type": "address"},
+			{"internalType": "address", "name": "tokenB", "type": "address"},
+			{"internalType": "uint24", "name": "fee", "type": "uint24"}
+		],
+		"name": "getPool",
+		"outputs": [{"internalType": "address", "name": "pool", "type": "address"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// PoolABI is the ABI for a Uniswap V3 pool (slot0 only).
+const PoolABI = `[
+	{
+		"inputs": [],
+		"name": "slot0",
+		"outputs": [
+			{"internalType": "uint160", "name": "sqrtPriceX96", "type": "uint160"},
+			{"internalType": "int24", "name": "tick", "type": "int24"},
+			{"internalType": "uint16", "name": "observationIndex", "type": "uint16"},
+			{"internalType": "uint16", "name": "observationCardinality", "type": "uint16"},
+			{"internalType": "uint16", "name": "observationCardinalityNext", "type": "uint16"},
+			{"internalType": "uint8", "name": "feeProtocol", "type": "uint8"},
+			{"internalType": "bool", "name": "unlocked", "type": "bool"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// Multicall3ABI is the ABI for the Multicall3 contract (aggregate3 only).
+const Multicall3ABI = `[
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "address", "name": "target", "type": "address"},
+					{"internalType": "bool", "name": "allowFailure", "type": "bool"},
+					{"internalType": "bytes", "name": "callData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall3.Call3[]",
+				"name": "calls",
+				"type": "tuple[]"
+			}
+		],
+		"name": "aggregate3",
+		"outputs": [
+			{
+				"components": [
+					{"internalType": "bool", "name": "success", "type": "bool"},
+					{"internalType": "bytes", "name": "returnData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall3.Result[]",
+				"name": "returnData",
+				"type": "tuple[]"
+			}
+		],
+		"stateMutability": "payable",
+		"type": "function"
+	}
+]`
+
+// Multicall3Call is one sub-call of aggregate3.
+type Multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// Multicall3Result is the outcome of one aggregate3 sub-call.
+type Multicall3Result struct {
+	Success    bool
+	ReturnData []byte
 }
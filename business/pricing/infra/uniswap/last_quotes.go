@@ -0,0 +1,65 @@
+package uniswap
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+)
+
+// Ensure Provider exposes its last quotes for troubleshooting.
+var _ app.QuoteInspector = (*Provider)(nil)
+
+// pairKey identifies a swap direction.
+type pairKey struct {
+	tokenIn  common.Address
+	tokenOut common.Address
+}
+
+// lastQuotes holds the most recent quote fetched per swap direction. Unlike
+// the quote cache it survives new blocks, so it always shows what the
+// provider last saw.
+type lastQuotes struct {
+	mu     sync.RWMutex
+	quotes map[pairKey]*domain.Quote
+}
+
+func newLastQuotes() *lastQuotes {
+	return &lastQuotes{quotes: make(map[pairKey]*domain.Quote)}
+}
+
+// record stores a copy of quote as the latest for its direction.
+func (l *lastQuotes) record(tokenIn, tokenOut common.Address, quote *domain.Quote) {
+	q := *quote
+	l.mu.Lock()
+	l.quotes[pairKey{tokenIn: tokenIn, tokenOut: tokenOut}] = &q
+	l.mu.Unlock()
+}
+
+// LastQuotes returns copies of the most recent quote per swap direction,
+// ordered by token addresses.
+func (p *Provider) LastQuotes() []*domain.Quote {
+	p.lastQuotes.mu.RLock()
+	keys := make([]pairKey, 0, len(p.lastQuotes.quotes))
+	for key := range p.lastQuotes.quotes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if c := bytes.Compare(keys[i].tokenIn[:], keys[j].tokenIn[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(keys[i].tokenOut[:], keys[j].tokenOut[:]) < 0
+	})
+
+	quotes := make([]*domain.Quote, 0, len(keys))
+	for _, key := range keys {
+		q := *p.lastQuotes.quotes[key]
+		quotes = append(quotes, &q)
+	}
+	p.lastQuotes.mu.RUnlock()
+	return quotes
+}
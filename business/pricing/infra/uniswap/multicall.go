@@ -0,0 +1,259 @@
+package uniswap
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// batchCall maps an aggregate3 sub-call back to what it asked for: a quote
+// for request req at feeTier (bounded by limit, if set), or the slot0 of pool.
+type batchCall struct {
+	req     int
+	feeTier int
+	pool    common.Address
+	slot0   bool
+	limit   *big.Int
+}
+
+// GetQuotesBatch quotes every request across all fee tiers in a single
+// Multicall3 aggregate3 eth_call. Sub-calls may fail individually (e.g. no
+// pool at a tier); a request fails only when none of its tiers quoted.
+// Without a Multicall3 address it falls back to one GetQuote per request.
+// Requests already quoted at the current block are served from the per-block
+// cache and left out of the multicall.
+func (p *Provider) GetQuotesBatch(ctx context.Context, requests []app.QuoteRequest) ([]app.BatchQuote, error) {
+	ctx, span := p.tracer.Start(ctx, "uniswap.get_quotes_batch",
+		trace.WithAttributes(attribute.Int("requests", len(requests))),
+	)
+	defer span.End()
+
+	results := make([]app.BatchQuote, len(requests))
+	if p.multicall == (common.Address{}) {
+		for i, req := range requests {
+			quote, err := p.GetQuote(ctx, req.TokenIn, req.TokenOut, req.AmountIn)
+			results[i] = app.BatchQuote{Quote: quote, Err: err}
+		}
+		return results, nil
+	}
+
+	keys := make([]quoteCacheKey, len(requests))
+	cacheable := make([]bool, len(requests))
+	pending := make([]int, 0, len(requests))
+	for i, req := range requests {
+		keys[i], cacheable[i] = p.quoteKey(req.TokenIn, req.TokenOut, req.AmountIn)
+		if cacheable[i] {
+			if quote, ok := p.cachedQuote(ctx, keys[i]); ok {
+				results[i] = app.BatchQuote{Quote: quote}
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+	span.SetAttributes(attribute.Int("cache_hits", len(requests)-len(pending)))
+	if len(pending) == 0 {
+		span.SetStatus(codes.Ok, "batch cached")
+		return results, nil
+	}
+
+	misses := make([]app.QuoteRequest, len(pending))
+	for i, idx := range pending {
+		misses[i] = requests[idx]
+	}
+
+	start := time.Now()
+	p.metrics.quotesTotal.Add(ctx, int64(len(misses)))
+	budget := newRetryBudget(p.quoteRetries, p.quoteRetryBackoff)
+
+	// Price limits need every pool's spot price before quoting
+	var spots map[common.Address]*big.Int
+	if p.maxPriceImpactBps > 0 {
+		var err error
+		if spots, err = p.batchSpotPrices(ctx, misses, budget); err != nil {
+			span.SetStatus(codes.Error, "spot prices failed")
+			return nil, err
+		}
+	}
+
+	calls, index, err := p.buildBatchCalls(misses, spots)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	callData, err := encodeAggregate3(p.multicallABI, calls)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	raw, err := p.callWithRetry(ctx, p.multicall, callData, budget)
+	if err != nil {
+		span.SetStatus(codes.Error, "multicall failed")
+		return nil, apperror.New(apperror.CodeContractCallFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("multicall aggregate3 call failed"))
+	}
+
+	subResults, err := decodeAggregate3(p.multicallABI, raw)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if len(subResults) != len(calls) {
+		span.SetStatus(codes.Error, "unexpected result count")
+		return nil, fmt.Errorf("unexpected multicall result count: %d, want %d", len(subResults), len(calls))
+	}
+
+	quotes := make([][]*domain.Quote, len(misses))
+	if spots == nil {
+		spots = make(map[common.Address]*big.Int)
+	}
+	for i, sub := range subResults {
+		c := index[i]
+		if !sub.Success {
+			continue
+		}
+		if c.slot0 {
+			if outputs, err := p.poolABI.Unpack("slot0", sub.ReturnData); err == nil && len(outputs) > 0 {
+				spots[c.pool] = outputs[0].(*big.Int)
+			}
+			continue
+		}
+
+		res, err := p.unpackQuote(sub.ReturnData)
+		if err != nil || checkPriceLimit(res, c.limit, c.feeTier) != nil {
+			continue
+		}
+		req := misses[c.req]
+		assetIn := p.resolveAsset(req.TokenIn)
+		quotes[c.req] = append(quotes[c.req], newQuote(assetIn, p.resolveAsset(req.TokenOut), asset.NewAmount(assetIn, req.AmountIn), c.feeTier, res))
+	}
+
+	failed := 0
+	for i, req := range misses {
+		best := selectBestQuote(quotes[i])
+		if best == nil {
+			failed++
+			p.metrics.quoteErrors.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", "no_quote")))
+			results[pending[i]] = app.BatchQuote{Err: apperror.New(apperror.CodeUniswapQuoteFailed,
+				apperror.WithContext("no pool found for token pair"))}
+			continue
+		}
+
+		// Pools first seen in this batch have no slot0 yet; look them up
+		// individually, which caches the pool for the next batch
+		if pool, ok := p.cachedPool(req.TokenIn, req.TokenOut, best.FeeTier); ok && spots[pool] != nil {
+			best.SqrtPriceX96Before = spots[pool]
+		} else if spot, err := p.getSpotSqrtPrice(ctx, req.TokenIn, req.TokenOut, best.FeeTier); err == nil {
+			best.SqrtPriceX96Before = spot
+		} else {
+			span.AddEvent("spot_price_failed", trace.WithAttributes(attribute.String("error", err.Error())))
+		}
+
+		results[pending[i]] = app.BatchQuote{Quote: best}
+		if cacheable[pending[i]] {
+			p.storeQuote(ctx, keys[pending[i]], best)
+		}
+		p.lastQuotes.record(req.TokenIn, req.TokenOut, best)
+	}
+
+	p.metrics.quoteLatency.Record(ctx, float64(time.Since(start).Milliseconds()))
+
+	span.SetAttributes(
+		attribute.Int("sub_calls", len(calls)),
+		attribute.Int("failed_requests", failed),
+	)
+	span.SetStatus(codes.Ok, "batch quoted")
+
+	p.logger.Debug(ctx, "uniswap batch quote",
+		"requests", len(requests),
+		"cache_hits", len(requests)-len(pending),
+		"sub_calls", len(calls),
+		"failed", failed,
+	)
+
+	return results, nil
+}
+
+// buildBatchCalls creates one quoter sub-call per request and fee tier, plus
+// one slot0 sub-call per already resolved pool the requests touch. Given
+// the pools' spot prices (price limits enabled), each quote is bounded by
+// its price limit, tiers without a spot price are skipped and no slot0
+// sub-calls are added.
+func (p *Provider) buildBatchCalls(requests []app.QuoteRequest, spots map[common.Address]*big.Int) ([]Multicall3Call, []batchCall, error) {
+	tiers := p.distinctFeeTiers()
+	calls := make([]Multicall3Call, 0, len(requests)*len(tiers))
+	index := make([]batchCall, 0, cap(calls))
+	seenPools := make(map[common.Address]bool)
+
+	for i, req := range requests {
+		for _, feeTier := range tiers {
+			pool, ok := p.cachedPool(req.TokenIn, req.TokenOut, feeTier)
+
+			var limit *big.Int
+			if spots != nil {
+				spot := spots[pool]
+				if !ok || spot == nil {
+					continue
+				}
+				limit = sqrtPriceLimit(spot, isZeroForOne(req.TokenIn, req.TokenOut), p.maxPriceImpactBps)
+			}
+
+			callData, err := p.packQuote(req.TokenIn, req.TokenOut, req.AmountIn, feeTier, limit)
+			if err != nil {
+				return nil, nil, err
+			}
+			calls = append(calls, Multicall3Call{Target: p.quoter, AllowFailure: true, CallData: callData})
+			index = append(index, batchCall{req: i, feeTier: feeTier, limit: limit})
+
+			if !ok || spots != nil || seenPools[pool] {
+				continue
+			}
+			seenPools[pool] = true
+
+			slot0Data, err := p.poolABI.Pack("slot0")
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to encode call: %w", err)
+			}
+			calls = append(calls, Multicall3Call{Target: pool, AllowFailure: true, CallData: slot0Data})
+			index = append(index, batchCall{req: i, feeTier: feeTier, pool: pool, slot0: true})
+		}
+	}
+
+	return calls, index, nil
+}
+
+// encodeAggregate3 encodes a Multicall3.aggregate3 call.
+func encodeAggregate3(parsed abi.ABI, calls []Multicall3Call) ([]byte, error) {
+	data, err := parsed.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode multicall: %w", err)
+	}
+	return data, nil
+}
+
+// decodeAggregate3 decodes the per-call results of Multicall3.aggregate3.
+func decodeAggregate3(parsed abi.ABI, data []byte) ([]Multicall3Result, error) {
+	outputs, err := parsed.Unpack("aggregate3", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode multicall result: %w", err)
+	}
+	if len(outputs) < 1 {
+		return nil, fmt.Errorf("unexpected output length: %d", len(outputs))
+	}
+	return *abi.ConvertType(outputs[0], new([]Multicall3Result)).(*[]Multicall3Result), nil
+}
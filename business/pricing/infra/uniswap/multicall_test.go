@@ -0,0 +1,62 @@
+package uniswap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestAggregate3RoundTrip tests encoding sub-calls and decoding mixed
+// successful and failed sub-call results.
+func TestAggregate3RoundTrip(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(Multicall3ABI))
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+
+	calls := []Multicall3Call{
+		{Target: common.HexToAddress("0x61fFE014bA17989E743c5F6cB21bF9697530B21e"), AllowFailure: true, CallData: []byte{0xc6, 0xa5, 0x02, 0x6a}},
+		{Target: common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"), AllowFailure: true, CallData: []byte{0x38, 0x50, 0xc7, 0xbd}},
+	}
+	data, err := encodeAggregate3(parsed, calls)
+	if err != nil {
+		t.Fatalf("encodeAggregate3() failed: %v", err)
+	}
+	if !bytes.Equal(data[:4], parsed.Methods["aggregate3"].ID) {
+		t.Errorf("selector = %x, want %x", data[:4], parsed.Methods["aggregate3"].ID)
+	}
+
+	args, err := parsed.Methods["aggregate3"].Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("failed to unpack inputs: %v", err)
+	}
+	decodedCalls := *abi.ConvertType(args[0], new([]Multicall3Call)).(*[]Multicall3Call)
+	if len(decodedCalls) != 2 || decodedCalls[1].Target != calls[1].Target || !bytes.Equal(decodedCalls[0].CallData, calls[0].CallData) {
+		t.Errorf("encoded calls = %+v, want %+v", decodedCalls, calls)
+	}
+
+	want := []Multicall3Result{
+		{Success: true, ReturnData: []byte{0x01, 0x02}},
+		{Success: false, ReturnData: []byte{}},
+	}
+	raw, err := parsed.Methods["aggregate3"].Outputs.Pack(want)
+	if err != nil {
+		t.Fatalf("failed to pack outputs: %v", err)
+	}
+
+	got, err := decodeAggregate3(parsed, raw)
+	if err != nil {
+		t.Fatalf("decodeAggregate3() failed: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Success != want[i].Success || !bytes.Equal(got[i].ReturnData, want[i].ReturnData) {
+			t.Errorf("result[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
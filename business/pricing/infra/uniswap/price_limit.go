@@ -0,0 +1,136 @@
+package uniswap
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// bpsDenominator is 100% in basis points.
+const bpsDenominator = 10_000
+
+// Bounds of sqrtPriceX96 enforced by pools (TickMath.MIN_SQRT_RATIO and
+// MAX_SQRT_RATIO); a price limit must lie strictly between them.
+var (
+	minSqrtRatio    = big.NewInt(4295128739)
+	maxSqrtRatio, _ = new(big.Int).SetString("1461446703485210103287273052203988822378723970342", 10)
+)
+
+// sqrtPriceLimit returns the sqrtPriceX96 at which the marginal rate a
+// trader receives has worsened by maxImpactBps from spot. Selling token0
+// (zeroForOne) lowers the pool price, so the limit is spot×(1−impact);
+// selling token1 raises it to spot÷(1−impact).
+func sqrtPriceLimit(spot *big.Int, zeroForOne bool, maxImpactBps int) *big.Int {
+	remaining := big.NewInt(int64(bpsDenominator - maxImpactBps))
+	full := big.NewInt(bpsDenominator)
+
+	// Scale the price (sqrtPrice²) and take the root again
+	price := new(big.Int).Mul(spot, spot)
+	if zeroForOne {
+		price.Mul(price, remaining).Quo(price, full)
+	} else {
+		price.Mul(price, full).Quo(price, remaining)
+	}
+	limit := price.Sqrt(price)
+
+	if limit.Cmp(minSqrtRatio) <= 0 {
+		return new(big.Int).Add(minSqrtRatio, big.NewInt(1))
+	}
+	if limit.Cmp(maxSqrtRatio) >= 0 {
+		return new(big.Int).Sub(maxSqrtRatio, big.NewInt(1))
+	}
+	return limit
+}
+
+// isZeroForOne reports whether tokenIn is the pool's token0 (the lower address).
+func isZeroForOne(tokenIn, tokenOut common.Address) bool {
+	return tokenIn.Cmp(tokenOut) < 0
+}
+
+// priceLimit returns the spot sqrtPriceX96 of the pool for a fee tier and
+// the sqrtPriceLimitX96 to quote tokenIn→tokenOut with. Both are nil when
+// max_price_impact_bps is zero (no limit).
+func (p *Provider) priceLimit(ctx context.Context, tokenIn, tokenOut common.Address, feeTier int) (spot, limit *big.Int, err error) {
+	if p.maxPriceImpactBps <= 0 {
+		return nil, nil, nil
+	}
+
+	spot, err = p.getSpotSqrtPrice(ctx, tokenIn, tokenOut, feeTier)
+	if err != nil {
+		return nil, nil, err
+	}
+	return spot, sqrtPriceLimit(spot, isZeroForOne(tokenIn, tokenOut), p.maxPriceImpactBps), nil
+}
+
+// checkPriceLimit fails a quote whose swap stopped at limit: the pool ran
+// out of liquidity within max_price_impact_bps, so the output only covers
+// part of the input and would overstate what the trade could fill.
+func checkPriceLimit(res *QuoteResult, limit *big.Int, feeTier int) error {
+	if limit == nil || res.SqrtPriceX96After == nil || res.SqrtPriceX96After.Cmp(limit) != 0 {
+		return nil
+	}
+	return apperror.New(apperror.CodeUniswapPriceImpactExceeded,
+		apperror.WithContext(fmt.Sprintf("quote reached the price limit at fee tier %d", feeTier)))
+}
+
+// batchSpotPrices returns the slot0 price of every pool the requests can
+// quote through, read in one aggregate3 call. Pools not yet resolved are
+// looked up individually first; tiers without a pool are left out.
+func (p *Provider) batchSpotPrices(ctx context.Context, requests []app.QuoteRequest, budget *retryBudget) (map[common.Address]*big.Int, error) {
+	var pools []common.Address
+	seen := make(map[common.Address]bool)
+	for _, req := range requests {
+		for _, feeTier := range p.distinctFeeTiers() {
+			pool, err := p.getPool(ctx, req.TokenIn, req.TokenOut, feeTier)
+			if err != nil || seen[pool] {
+				continue
+			}
+			seen[pool] = true
+			pools = append(pools, pool)
+		}
+	}
+
+	spots := make(map[common.Address]*big.Int, len(pools))
+	if len(pools) == 0 {
+		return spots, nil
+	}
+
+	slot0Data, err := p.poolABI.Pack("slot0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode call: %w", err)
+	}
+	calls := make([]Multicall3Call, len(pools))
+	for i, pool := range pools {
+		calls[i] = Multicall3Call{Target: pool, AllowFailure: true, CallData: slot0Data}
+	}
+
+	callData, err := encodeAggregate3(p.multicallABI, calls)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := p.callWithRetry(ctx, p.multicall, callData, budget)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeContractCallFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("multicall slot0 call failed"))
+	}
+	subResults, err := decodeAggregate3(p.multicallABI, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, sub := range subResults {
+		if i >= len(pools) || !sub.Success {
+			continue
+		}
+		if outputs, err := p.poolABI.Unpack("slot0", sub.ReturnData); err == nil && len(outputs) > 0 {
+			spots[pools[i]] = outputs[0].(*big.Int)
+		}
+	}
+	return spots, nil
+}
@@ -0,0 +1,118 @@
+package uniswap
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+func TestSqrtPriceLimit(t *testing.T) {
+	// sqrtPriceX96 of the ETH/USDC 0.05% pool around $3000
+	spot, _ := new(big.Int).SetString("4339505179874779489431521", 10)
+
+	tests := []struct {
+		name       string
+		zeroForOne bool
+		bps        int
+		wantRatio  float64 // (limit/spot)²
+	}{
+		{name: "zero_for_one", zeroForOne: true, bps: 50, wantRatio: 0.995},
+		{name: "one_for_zero", zeroForOne: false, bps: 50, wantRatio: 1 / 0.995},
+		{name: "wide", zeroForOne: true, bps: 5000, wantRatio: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := sqrtPriceLimit(spot, tt.zeroForOne, tt.bps)
+
+			ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(limit), new(big.Float).SetInt(spot)).Float64()
+			if got := ratio * ratio; got < tt.wantRatio-1e-9 || got > tt.wantRatio+1e-9 {
+				t.Errorf("price ratio = %.12f, want %.12f", got, tt.wantRatio)
+			}
+		})
+	}
+}
+
+func TestSqrtPriceLimit_ClampsToPoolBounds(t *testing.T) {
+	low := new(big.Int).Add(minSqrtRatio, big.NewInt(10))
+	if got := sqrtPriceLimit(low, true, 9999); got.Cmp(minSqrtRatio) <= 0 {
+		t.Errorf("limit %s is not above MIN_SQRT_RATIO", got)
+	}
+
+	high := new(big.Int).Sub(maxSqrtRatio, big.NewInt(10))
+	if got := sqrtPriceLimit(high, false, 9999); got.Cmp(maxSqrtRatio) >= 0 {
+		t.Errorf("limit %s is not below MAX_SQRT_RATIO", got)
+	}
+}
+
+func TestCheckPriceLimit(t *testing.T) {
+	limit := big.NewInt(1000)
+
+	if err := checkPriceLimit(&QuoteResult{SqrtPriceX96After: big.NewInt(1001)}, limit, 500); err != nil {
+		t.Errorf("expected a swap ending before the limit to pass, got %v", err)
+	}
+	if err := checkPriceLimit(&QuoteResult{SqrtPriceX96After: big.NewInt(1000)}, nil, 500); err != nil {
+		t.Errorf("expected no check without a limit, got %v", err)
+	}
+
+	err := checkPriceLimit(&QuoteResult{SqrtPriceX96After: big.NewInt(1000)}, limit, 500)
+	if apperror.GetCode(err) != apperror.CodeUniswapPriceImpactExceeded {
+		t.Errorf("expected %s, got %v", apperror.CodeUniswapPriceImpactExceeded, err)
+	}
+}
+
+// TestBuildBatchCalls_PriceLimits tests that with spot prices each quote
+// carries its price limit, tiers without a pool are skipped and no slot0
+// sub-calls are added.
+func TestBuildBatchCalls_PriceLimits(t *testing.T) {
+	quoterABI, err := abi.JSON(strings.NewReader(QuoterV2ABI))
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+	poolABI, err := abi.JSON(strings.NewReader(PoolABI))
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	pool := common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+
+	p := &Provider{
+		quoterABI:         quoterABI,
+		poolABI:           poolABI,
+		feeTiers:          []int{FeeTier005, FeeTier030},
+		pools:             map[string]common.Address{poolKey(weth, usdc, FeeTier005): pool},
+		maxPriceImpactBps: 100,
+	}
+	spot, _ := new(big.Int).SetString("4339505179874779489431521", 10)
+	requests := []app.QuoteRequest{{TokenIn: usdc, TokenOut: weth, AmountIn: big.NewInt(1_000_000)}}
+
+	calls, index, err := p.buildBatchCalls(requests, map[common.Address]*big.Int{pool: spot})
+	if err != nil {
+		t.Fatalf("buildBatchCalls() failed: %v", err)
+	}
+	if len(calls) != 1 || len(index) != 1 {
+		t.Fatalf("expected 1 sub-call (0.05%% tier only), got %d", len(calls))
+	}
+
+	want := sqrtPriceLimit(spot, isZeroForOne(usdc, weth), 100)
+	if index[0].slot0 || index[0].feeTier != FeeTier005 || index[0].limit.Cmp(want) != 0 {
+		t.Errorf("sub-call = %+v, want 0.05%% quote limited at %s", index[0], want)
+	}
+
+	args, err := quoterABI.Methods["quoteExactInputSingle"].Inputs.Unpack(calls[0].CallData[4:])
+	if err != nil {
+		t.Fatalf("failed to unpack call: %v", err)
+	}
+	params := *abi.ConvertType(args[0], new(QuoteExactInputSingleParams)).(*QuoteExactInputSingleParams)
+	if params.SqrtPriceLimitX96.Cmp(want) != 0 {
+		t.Errorf("encoded sqrtPriceLimitX96 = %s, want %s", params.SqrtPriceLimitX96, want)
+	}
+}
@@ -5,13 +5,18 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/sony/gobreaker/v2"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -22,6 +27,7 @@ import (
 	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
 	"github.com/fd1az/arbitrage-bot/internal/apperror"
 	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/cache"
 	"github.com/fd1az/arbitrage-bot/internal/circuitbreaker"
 	"github.com/fd1az/arbitrage-bot/internal/config"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
@@ -32,14 +38,24 @@ const (
 	meterName  = "uniswap"
 )
 
-// Ensure Provider implements DEXProvider.
-var _ app.DEXProvider = (*Provider)(nil)
+// maxConcurrentTierQuotes bounds the quoter calls in flight for one quote.
+const maxConcurrentTierQuotes = 4
+
+// Ensure Provider implements DEXProvider, BatchQuoter, CircuitStater and BlockObserver.
+var (
+	_ app.DEXProvider   = (*Provider)(nil)
+	_ app.BatchQuoter   = (*Provider)(nil)
+	_ app.CircuitStater = (*Provider)(nil)
+	_ app.BlockObserver = (*Provider)(nil)
+)
 
 // providerMetrics holds OTEL metric instruments.
 type providerMetrics struct {
 	quotesTotal   metric.Int64Counter
 	quoteLatency  metric.Float64Histogram
 	quoteErrors   metric.Int64Counter
+	quoteCacheHits   metric.Int64Counter
+	quoteCacheMisses metric.Int64Counter
 }
 
 // Provider implements DEXProvider for Uniswap V3.
@@ -49,29 +65,83 @@ type Provider struct {
 	quoterABI abi.ABI
 	feeTiers []int
 
+	// Spot price lookup (factory.getPool + pool.slot0) for price impact
+	factory    common.Address
+	factoryABI abi.ABI
+	poolABI    abi.ABI
+	pools      map[string]common.Address // tokenA+tokenB+fee -> pool; pools never move
+	poolsMu    sync.RWMutex
+
+	// Multicall3 for batched quotes; zero address disables batching
+	multicall    common.Address
+	multicallABI abi.ABI
+
+	chainID  uint64 // Chain the quoted tokens live on
 	registry *asset.Registry
 	logger   logger.LoggerInterface
 	cb       *circuitbreaker.CircuitBreaker[[]byte]
 
+	// Quoter retry budget per GetQuote call
+	quoteRetries      int
+	quoteRetryBackoff time.Duration
+
+	// Quotes moving the pool price further than this fail (0 = no limit)
+	maxPriceImpactBps int
+
+	// Quotes fetched at the current block; cleared on every new block
+	quoteCache *cache.Cache[quoteCacheKey, *domain.Quote]
+	block      atomic.Uint64
+
+	// Most recent quote per swap direction, for troubleshooting
+	lastQuotes *lastQuotes
+
 	tracer  trace.Tracer
 	metrics *providerMetrics
 }
 
-// NewProvider creates a new Uniswap V3 provider.
-func NewProvider(client *ethclient.Client, cfg config.UniswapConfig, log logger.LoggerInterface) (*Provider, error) {
+// NewProvider creates a new Uniswap V3 provider for the contracts in cfg on
+// chainID; quoted tokens are resolved against registry.
+func NewProvider(client *ethclient.Client, cfg config.UniswapConfig, chainID uint64, registry *asset.Registry, log logger.LoggerInterface) (*Provider, error) {
 	// Parse QuoterV2 ABI
 	parsedABI, err := abi.JSON(strings.NewReader(QuoterV2ABI))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse quoter ABI: %w", err)
 	}
 
+	factoryABI, err := abi.JSON(strings.NewReader(FactoryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse factory ABI: %w", err)
+	}
+
+	poolABI, err := abi.JSON(strings.NewReader(PoolABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pool ABI: %w", err)
+	}
+
+	multicallABI, err := abi.JSON(strings.NewReader(Multicall3ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse multicall ABI: %w", err)
+	}
+
 	p := &Provider{
 		client:    client,
 		quoter:    cfg.QuoterAddressHex(),
 		quoterABI: parsedABI,
 		feeTiers:  []int{cfg.DefaultFeeTier, FeeTier005, FeeTier030, FeeTier100},
-		registry:  asset.DefaultRegistry(),
+		factory:    cfg.FactoryAddressHex(),
+		factoryABI: factoryABI,
+		poolABI:    poolABI,
+		pools:      make(map[string]common.Address),
+		multicall:    cfg.MulticallAddressHex(),
+		multicallABI: multicallABI,
+		chainID:   chainID,
+		registry:  registry,
 		logger:    log,
+		quoteRetries:      cfg.QuoteRetries,
+		quoteRetryBackoff: cfg.QuoteRetryBackoff,
+		maxPriceImpactBps: cfg.MaxPriceImpactBps,
+		quoteCache:        cache.NewWithCapacity[quoteCacheKey, *domain.Quote]("uniswap_quotes", quoteCacheCapacity, quoteCacheTTL),
+		lastQuotes:        newLastQuotes(),
 		tracer:    otel.Tracer(tracerName),
 	}
 
@@ -117,10 +187,28 @@ func (p *Provider) initMetrics() error {
 		return err
 	}
 
+	p.metrics.quoteCacheHits, err = meter.Int64Counter(
+		"uniswap_quote_cache_hits_total",
+		metric.WithDescription("Quotes served from the per-block cache"),
+	)
+	if err != nil {
+		return err
+	}
+
+	p.metrics.quoteCacheMisses, err = meter.Int64Counter(
+		"uniswap_quote_cache_misses_total",
+		metric.WithDescription("Quotes not found in the per-block cache"),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // GetQuote retrieves a price quote for swapping tokens on Uniswap V3.
+// It quotes every configured fee tier and returns the one with the highest output.
+// Repeated requests within a block are served from the per-block cache.
 func (p *Provider) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*domain.Quote, error) {
 	ctx, span := p.tracer.Start(ctx, "uniswap.get_quote",
 		trace.WithAttributes(
@@ -131,97 +219,241 @@ func (p *Provider) GetQuote(ctx context.Context, tokenIn, tokenOut common.Addres
 	)
 	defer span.End()
 
+	key, cacheable := p.quoteKey(tokenIn, tokenOut, amountIn)
+	if cacheable {
+		if quote, ok := p.cachedQuote(ctx, key); ok {
+			span.SetAttributes(attribute.Bool("cache_hit", true))
+			span.SetStatus(codes.Ok, "quote cached")
+			return quote, nil
+		}
+	}
+
+	quotes, err := p.quoteAllTiers(ctx, span, tokenIn, tokenOut, amountIn)
+	if err != nil {
+		return nil, err
+	}
+
+	best := selectBestQuote(quotes)
+
+	// Spot price is only needed for price impact; a failure leaves it unknown
+	if best.SqrtPriceX96Before != nil {
+		// Already read to derive the price limit
+	} else if spot, err := p.getSpotSqrtPrice(ctx, tokenIn, tokenOut, best.FeeTier); err == nil {
+		best.SqrtPriceX96Before = spot
+	} else {
+		span.AddEvent("spot_price_failed", trace.WithAttributes(attribute.String("error", err.Error())))
+	}
+
+	span.SetAttributes(
+		attribute.String("amount_out", best.AmountOut.Raw().String()),
+		attribute.Int("fee_tier", best.FeeTier),
+		attribute.Int64("gas_estimate", int64(best.GasEstimate)),
+	)
+	span.SetStatus(codes.Ok, "quote received")
+
+	p.logger.Debug(ctx, "uniswap quote",
+		"token_in", tokenIn.Hex(),
+		"token_out", tokenOut.Hex(),
+		"amount_in", amountIn.String(),
+		"amount_out", best.AmountOut.Raw().String(),
+		"fee_tier", best.FeeTier,
+	)
+
+	if cacheable {
+		p.storeQuote(ctx, key, best)
+	}
+	p.lastQuotes.record(tokenIn, tokenOut, best)
+
+	return best, nil
+}
+
+// GetQuoteAllTiers returns one quote per fee tier that has a pool, ordered by fee tier.
+// Comparing outputs and ticks crossed across tiers shows where liquidity is concentrated.
+func (p *Provider) GetQuoteAllTiers(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) ([]*domain.Quote, error) {
+	ctx, span := p.tracer.Start(ctx, "uniswap.get_quote_all_tiers",
+		trace.WithAttributes(
+			attribute.String("token_in", tokenIn.Hex()),
+			attribute.String("token_out", tokenOut.Hex()),
+			attribute.String("amount_in", amountIn.String()),
+		),
+	)
+	defer span.End()
+
+	quotes, err := p.quoteAllTiers(ctx, span, tokenIn, tokenOut, amountIn)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("tiers_quoted", len(quotes)))
+	span.SetStatus(codes.Ok, "quotes received")
+
+	return quotes, nil
+}
+
+// quoteAllTiers queries each distinct fee tier and converts successful results to domain quotes.
+func (p *Provider) quoteAllTiers(ctx context.Context, span trace.Span, tokenIn, tokenOut common.Address, amountIn *big.Int) ([]*domain.Quote, error) {
 	start := time.Now()
 	p.metrics.quotesTotal.Add(ctx, 1)
 
-	// Try each fee tier to find the best quote
-	var bestQuote *QuoteResult
-	var bestFeeTier int
+	assetIn := p.resolveAsset(tokenIn)
+	assetOut := p.resolveAsset(tokenOut)
+	amtIn := asset.NewAmount(assetIn, amountIn)
 
-	for _, feeTier := range p.feeTiers {
-		quote, err := p.getQuoteForFeeTier(ctx, tokenIn, tokenOut, amountIn, feeTier)
-		if err != nil {
+	tiers := p.distinctFeeTiers()
+	budget := newRetryBudget(p.quoteRetries, p.quoteRetryBackoff)
+	results := quoteTiersConcurrently(tiers, maxConcurrentTierQuotes, func(feeTier int) (*QuoteResult, error) {
+		return p.getQuoteForFeeTier(ctx, tokenIn, tokenOut, amountIn, feeTier, budget)
+	})
+
+	// Span events are recorded here, after all calls finished, in tier order
+	var quotes []*domain.Quote
+	for _, r := range results {
+		if r.err != nil {
 			span.AddEvent("fee_tier_failed",
 				trace.WithAttributes(
-					attribute.Int("fee_tier", feeTier),
-					attribute.String("error", err.Error()),
+					attribute.Int("fee_tier", r.feeTier),
+					attribute.String("error", r.err.Error()),
 				),
 			)
 			continue
 		}
 
-		// Keep the best (highest output) quote
-		if bestQuote == nil || quote.AmountOut.Cmp(bestQuote.AmountOut) > 0 {
-			bestQuote = quote
-			bestFeeTier = feeTier
-		}
+		quotes = append(quotes, newQuote(assetIn, assetOut, amtIn, r.feeTier, r.result))
 	}
 
 	latency := float64(time.Since(start).Milliseconds())
 	p.metrics.quoteLatency.Record(ctx, latency)
 
-	if bestQuote == nil {
-		p.metrics.quoteErrors.Add(ctx, 1)
+	if len(quotes) == 0 {
+		p.metrics.quoteErrors.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", "no_quote")))
 		span.SetStatus(codes.Error, "no valid quote")
 		return nil, apperror.New(apperror.CodeUniswapQuoteFailed,
 			apperror.WithContext("no pool found for token pair"))
 	}
 
-	// Build domain.Quote
-	assetIn := p.resolveAsset(tokenIn)
-	assetOut := p.resolveAsset(tokenOut)
+	sort.Slice(quotes, func(i, j int) bool {
+		return quotes[i].FeeTier < quotes[j].FeeTier
+	})
 
-	amtIn := asset.NewAmount(assetIn, amountIn)
-	amtOut := asset.NewAmount(assetOut, bestQuote.AmountOut)
+	return quotes, nil
+}
 
-	result := domain.NewQuote(assetIn, assetOut, amtIn, amtOut, bestQuote.GasEstimate.Uint64(), bestFeeTier)
+// distinctFeeTiers returns the configured fee tiers without duplicates;
+// the default tier usually duplicates one of the standard tiers.
+func (p *Provider) distinctFeeTiers() []int {
+	tiers := make([]int, 0, len(p.feeTiers))
+	for _, feeTier := range p.feeTiers {
+		if !slices.Contains(tiers, feeTier) {
+			tiers = append(tiers, feeTier)
+		}
+	}
+	return tiers
+}
 
-	span.SetAttributes(
-		attribute.String("amount_out", bestQuote.AmountOut.String()),
-		attribute.Int("fee_tier", bestFeeTier),
-		attribute.Int64("gas_estimate", bestQuote.GasEstimate.Int64()),
-	)
-	span.SetStatus(codes.Ok, "quote received")
+// newQuote converts a quoter result for a fee tier into a domain quote.
+func newQuote(assetIn, assetOut *asset.Asset, amtIn asset.Amount, feeTier int, res *QuoteResult) *domain.Quote {
+	quote := domain.NewQuote(assetIn, assetOut, amtIn, asset.NewAmount(assetOut, res.AmountOut), res.GasEstimate.Uint64(), feeTier)
+	quote.Protocol = domain.ProtocolUniswapV3
+	quote.TicksCrossed = res.InitializedTicksCrossed
+	quote.SqrtPriceX96After = res.SqrtPriceX96After
+	quote.SqrtPriceX96Before = res.SqrtPriceX96Before
+	return &quote
+}
 
-	p.logger.Debug(ctx, "uniswap quote",
-		"token_in", tokenIn.Hex(),
-		"token_out", tokenOut.Hex(),
-		"amount_in", amountIn.String(),
-		"amount_out", bestQuote.AmountOut.String(),
-		"fee_tier", bestFeeTier,
-	)
+// tierResult is the outcome of quoting one fee tier.
+type tierResult struct {
+	feeTier int
+	result  *QuoteResult
+	err     error
+}
 
-	return &result, nil
+// quoteTiersConcurrently calls quote for every tier with at most limit calls
+// in flight, returning results in tier order.
+func quoteTiersConcurrently(tiers []int, limit int, quote func(feeTier int) (*QuoteResult, error)) []tierResult {
+	results := make([]tierResult, len(tiers))
+	sem := make(chan struct{}, max(limit, 1))
+
+	var wg sync.WaitGroup
+	for i, feeTier := range tiers {
+		wg.Add(1)
+		go func(i, feeTier int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			res, err := quote(feeTier)
+			results[i] = tierResult{feeTier: feeTier, result: res, err: err}
+		}(i, feeTier)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// selectBestQuote returns the quote with the highest output amount.
+func selectBestQuote(quotes []*domain.Quote) *domain.Quote {
+	var best *domain.Quote
+	for _, q := range quotes {
+		if best == nil || q.AmountOut.Raw().Cmp(best.AmountOut.Raw()) > 0 {
+			best = q
+		}
+	}
+	return best
 }
 
-// getQuoteForFeeTier calls QuoterV2.quoteExactInputSingle for a specific fee tier.
-func (p *Provider) getQuoteForFeeTier(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int, feeTier int) (*QuoteResult, error) {
-	// Encode call data for quoteExactInputSingle
+// getQuoteForFeeTier calls QuoterV2.quoteExactInputSingle for a specific fee tier,
+// retrying transient failures while budget lasts. With max_price_impact_bps
+// set, the swap is bounded by a price limit and fails if it reaches it.
+func (p *Provider) getQuoteForFeeTier(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int, feeTier int, budget *retryBudget) (*QuoteResult, error) {
+	spot, limit, err := p.priceLimit(ctx, tokenIn, tokenOut, feeTier)
+	if err != nil {
+		return nil, err
+	}
+
+	callData, err := p.packQuote(tokenIn, tokenOut, amountIn, feeTier, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := p.callWithRetry(ctx, p.quoter, callData, budget)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeContractCallFailed,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("quoter call failed for fee tier %d", feeTier)))
+	}
+
+	res, err := p.unpackQuote(result)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPriceLimit(res, limit, feeTier); err != nil {
+		return nil, err
+	}
+	res.SqrtPriceX96Before = spot
+	return res, nil
+}
+
+// packQuote encodes the quoteExactInputSingle call for a fee tier, bounded
+// by sqrtPriceLimit (nil = no limit).
+func (p *Provider) packQuote(tokenIn, tokenOut common.Address, amountIn *big.Int, feeTier int, sqrtPriceLimit *big.Int) ([]byte, error) {
+	if sqrtPriceLimit == nil {
+		sqrtPriceLimit = big.NewInt(0) // No price limit
+	}
 	callData, err := p.quoterABI.Pack("quoteExactInputSingle", QuoteExactInputSingleParams{
 		TokenIn:           tokenIn,
 		TokenOut:          tokenOut,
 		AmountIn:          amountIn,
 		Fee:               big.NewInt(int64(feeTier)),
-		SqrtPriceLimitX96: big.NewInt(0), // No price limit
+		SqrtPriceLimitX96: sqrtPriceLimit,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode call: %w", err)
 	}
+	return callData, nil
+}
 
-	// Execute call through circuit breaker
-	result, err := p.cb.Execute(func() ([]byte, error) {
-		return p.client.CallContract(ctx, ethereum.CallMsg{
-			To:   &p.quoter,
-			Data: callData,
-		}, nil)
-	})
-	if err != nil {
-		return nil, apperror.New(apperror.CodeContractCallFailed,
-			apperror.WithCause(err),
-			apperror.WithContext(fmt.Sprintf("quoter call failed for fee tier %d", feeTier)))
-	}
-
-	// Decode result
+// unpackQuote decodes the quoteExactInputSingle return data.
+func (p *Provider) unpackQuote(result []byte) (*QuoteResult, error) {
 	outputs, err := p.quoterABI.Unpack("quoteExactInputSingle", result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode result: %w", err)
@@ -239,14 +471,137 @@ func (p *Provider) getQuoteForFeeTier(ctx context.Context, tokenIn, tokenOut com
 	}, nil
 }
 
+// callWithRetry executes an eth_call through the circuit breaker, retrying
+// transient RPC errors while budget lasts.
+func (p *Provider) callWithRetry(ctx context.Context, to common.Address, data []byte, budget *retryBudget) ([]byte, error) {
+	for {
+		result, err := p.call(ctx, to, data)
+		if err == nil || !isRetryable(err) {
+			return result, err
+		}
+		if !budget.wait(ctx) {
+			if ctx.Err() == nil {
+				p.metrics.quoteErrors.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", "retry_budget_exhausted")))
+			}
+			return nil, err
+		}
+		p.logger.Debug(ctx, "retrying contract call", "to", to.Hex(), "error", err)
+	}
+}
+
+// getSpotSqrtPrice returns the current sqrtPriceX96 of the pool for a token pair and fee tier.
+func (p *Provider) getSpotSqrtPrice(ctx context.Context, tokenA, tokenB common.Address, feeTier int) (*big.Int, error) {
+	pool, err := p.getPool(ctx, tokenA, tokenB, feeTier)
+	if err != nil {
+		return nil, err
+	}
+
+	callData, err := p.poolABI.Pack("slot0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode call: %w", err)
+	}
+
+	result, err := p.call(ctx, pool, callData)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeContractCallFailed,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("slot0 call failed for %s", pool.Hex())))
+	}
+
+	outputs, err := p.poolABI.Unpack("slot0", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode result: %w", err)
+	}
+	if len(outputs) < 1 {
+		return nil, fmt.Errorf("unexpected output length: %d", len(outputs))
+	}
+
+	return outputs[0].(*big.Int), nil
+}
+
+// getPool resolves the pool address via factory.getPool (cached).
+func (p *Provider) getPool(ctx context.Context, tokenA, tokenB common.Address, feeTier int) (common.Address, error) {
+	if pool, ok := p.cachedPool(tokenA, tokenB, feeTier); ok {
+		return pool, nil
+	}
+
+	// Factory lookups are order-independent; normalize the token order
+	if tokenB.Cmp(tokenA) < 0 {
+		tokenA, tokenB = tokenB, tokenA
+	}
+
+	callData, err := p.factoryABI.Pack("getPool", tokenA, tokenB, big.NewInt(int64(feeTier)))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to encode call: %w", err)
+	}
+
+	result, err := p.call(ctx, p.factory, callData)
+	if err != nil {
+		return common.Address{}, apperror.New(apperror.CodeContractCallFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("factory getPool call failed"))
+	}
+
+	outputs, err := p.factoryABI.Unpack("getPool", result)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode result: %w", err)
+	}
+	if len(outputs) < 1 {
+		return common.Address{}, fmt.Errorf("unexpected output length: %d", len(outputs))
+	}
+
+	pool := outputs[0].(common.Address)
+	if pool == (common.Address{}) {
+		return common.Address{}, apperror.New(apperror.CodeUniswapPoolNotFound,
+			apperror.WithContext(fmt.Sprintf("no pool for fee tier %d", feeTier)))
+	}
+
+	p.poolsMu.Lock()
+	p.pools[poolKey(tokenA, tokenB, feeTier)] = pool
+	p.poolsMu.Unlock()
+
+	return pool, nil
+}
+
+// cachedPool returns the pool for a token pair and fee tier if it was already resolved.
+func (p *Provider) cachedPool(tokenA, tokenB common.Address, feeTier int) (common.Address, bool) {
+	p.poolsMu.RLock()
+	defer p.poolsMu.RUnlock()
+	pool, ok := p.pools[poolKey(tokenA, tokenB, feeTier)]
+	return pool, ok
+}
+
+// poolKey is the pool cache key; factory lookups are order-independent.
+func poolKey(tokenA, tokenB common.Address, feeTier int) string {
+	if tokenB.Cmp(tokenA) < 0 {
+		tokenA, tokenB = tokenB, tokenA
+	}
+	return fmt.Sprintf("%s%s%d", tokenA.Hex(), tokenB.Hex(), feeTier)
+}
+
+// CircuitState returns the state of the circuit breaker guarding quoter and pool calls.
+func (p *Provider) CircuitState() gobreaker.State {
+	return p.cb.State()
+}
+
+// call executes an eth_call through the circuit breaker.
+func (p *Provider) call(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+	return p.cb.Execute(func() ([]byte, error) {
+		return p.client.CallContract(ctx, ethereum.CallMsg{
+			To:   &to,
+			Data: data,
+		}, nil)
+	})
+}
+
 // resolveAsset attempts to find the asset in the registry.
 func (p *Provider) resolveAsset(addr common.Address) *asset.Asset {
-	if a, ok := p.registry.GetToken(asset.ChainIDEthereum, addr); ok {
+	if a, ok := p.registry.GetToken(p.chainID, addr); ok {
 		return a
 	}
 	// Return a generic ERC20 if not found
 	return asset.NewAsset(
-		asset.NewTokenAssetID(asset.ChainIDEthereum, addr),
+		asset.NewTokenAssetID(p.chainID, addr),
 		addr.Hex()[:8],
 		18, // Assume 18 decimals
 	)
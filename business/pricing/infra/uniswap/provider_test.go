@@ -0,0 +1,100 @@
+package uniswap
+
+import (
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+// TestSelectBestQuote tests that the tier with the highest output wins.
+func TestSelectBestQuote(t *testing.T) {
+	newQuote := func(feeTier int, out int64) *domain.Quote {
+		q := domain.NewQuote(asset.ETH, asset.USDC,
+			asset.NewAmount(asset.ETH, big.NewInt(1e18)), asset.NewAmount(asset.USDC, big.NewInt(out)), 0, feeTier)
+		return &q
+	}
+
+	tests := []struct {
+		name        string
+		quotes      []*domain.Quote
+		wantFeeTier int
+	}{
+		{
+			name:        "single_tier",
+			quotes:      []*domain.Quote{newQuote(FeeTier030, 3_000_000_000)},
+			wantFeeTier: FeeTier030,
+		},
+		{
+			name: "deepest_tier_wins",
+			quotes: []*domain.Quote{
+				newQuote(FeeTier005, 3_001_000_000),
+				newQuote(FeeTier030, 2_999_000_000),
+				newQuote(FeeTier100, 2_980_000_000),
+			},
+			wantFeeTier: FeeTier005,
+		},
+		{
+			name: "first_wins_on_tie",
+			quotes: []*domain.Quote{
+				newQuote(FeeTier030, 3_000_000_000),
+				newQuote(FeeTier100, 3_000_000_000),
+			},
+			wantFeeTier: FeeTier030,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			best := selectBestQuote(tt.quotes)
+			if best == nil || best.FeeTier != tt.wantFeeTier {
+				t.Errorf("expected fee tier %d, got %+v", tt.wantFeeTier, best)
+			}
+		})
+	}
+}
+
+// TestQuoteTiersConcurrently tests the in-flight bound and tier-ordered results.
+func TestQuoteTiersConcurrently(t *testing.T) {
+	tiers := []int{FeeTier030, FeeTier005, FeeTier100, 100, 2500}
+	var inFlight, peak atomic.Int32
+
+	results := quoteTiersConcurrently(tiers, 2, func(feeTier int) (*QuoteResult, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if feeTier == FeeTier100 {
+			return nil, errors.New("no pool")
+		}
+		return &QuoteResult{AmountOut: big.NewInt(int64(feeTier))}, nil
+	})
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("peak in-flight calls = %d, want <= 2", got)
+	}
+	if len(results) != len(tiers) {
+		t.Fatalf("got %d results, want %d", len(results), len(tiers))
+	}
+	for i, r := range results {
+		if r.feeTier != tiers[i] {
+			t.Errorf("results[%d].feeTier = %d, want %d", i, r.feeTier, tiers[i])
+		}
+		if wantErr := tiers[i] == FeeTier100; (r.err != nil) != wantErr {
+			t.Errorf("results[%d] error = %v, wantErr %v", i, r.err, wantErr)
+		}
+		if r.err == nil && r.result.AmountOut.Int64() != int64(tiers[i]) {
+			t.Errorf("results[%d] belongs to another tier: %s", i, r.result.AmountOut)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package uniswap
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+)
+
+// quoteCacheTTL bounds how long a quote may be served if no new block is
+// reported; new blocks clear the cache well before this.
+const quoteCacheTTL = time.Minute
+
+// quoteCacheCapacity bounds the quotes held for one block: pairs × trade
+// sizes × directions stays far below this for any realistic config.
+const quoteCacheCapacity = 4096
+
+// quoteCacheKey identifies a quote at one block. Quoter results only change
+// when chain state does, so repeated requests within a block are identical.
+type quoteCacheKey struct {
+	tokenIn  common.Address
+	tokenOut common.Address
+	amountIn string
+	block    uint64
+}
+
+// OnNewBlock invalidates quotes cached for earlier blocks.
+func (p *Provider) OnNewBlock(blockNumber uint64) {
+	for {
+		current := p.block.Load()
+		if blockNumber <= current {
+			return
+		}
+		if p.block.CompareAndSwap(current, blockNumber) {
+			p.quoteCache.Clear(context.Background())
+			return
+		}
+	}
+}
+
+// quoteKey builds the cache key for a request at the current block. It
+// reports false before the first block is seen, when nothing is cached.
+// The key is taken before quoting so a block arriving mid-request cannot
+// file an old quote under the new block.
+func (p *Provider) quoteKey(tokenIn, tokenOut common.Address, amountIn *big.Int) (quoteCacheKey, bool) {
+	block := p.block.Load()
+	if block == 0 {
+		return quoteCacheKey{}, false
+	}
+	return quoteCacheKey{tokenIn: tokenIn, tokenOut: tokenOut, amountIn: amountIn.String(), block: block}, true
+}
+
+// cachedQuote returns the quote already fetched for key.
+func (p *Provider) cachedQuote(ctx context.Context, key quoteCacheKey) (*domain.Quote, bool) {
+	quote, ok := p.quoteCache.Get(ctx, key)
+	if !ok {
+		p.metrics.quoteCacheMisses.Add(ctx, 1)
+		return nil, false
+	}
+	p.metrics.quoteCacheHits.Add(ctx, 1)
+
+	// Callers may annotate the quote; hand out a copy
+	q := *quote
+	return &q, true
+}
+
+// storeQuote caches a quote under key.
+func (p *Provider) storeQuote(ctx context.Context, key quoteCacheKey, quote *domain.Quote) {
+	q := *quote
+	p.quoteCache.Set(ctx, key, &q, quoteCacheTTL)
+}
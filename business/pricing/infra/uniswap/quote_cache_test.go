@@ -0,0 +1,68 @@
+package uniswap
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/cache"
+)
+
+// TestProvider_QuoteCache tests that quotes are cached per block and dropped
+// when a new block arrives.
+func TestProvider_QuoteCache(t *testing.T) {
+	p := &Provider{quoteCache: cache.New[quoteCacheKey, *domain.Quote](quoteCacheTTL)}
+	defer p.quoteCache.Close()
+	if err := p.initMetrics(); err != nil {
+		t.Fatalf("initMetrics failed: %v", err)
+	}
+
+	ctx := context.Background()
+	tokenIn, tokenOut := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	amountIn := big.NewInt(1e18)
+	quote := domain.NewQuote(asset.ETH, asset.USDC,
+		asset.NewAmount(asset.ETH, amountIn), asset.NewAmount(asset.USDC, big.NewInt(3_000_000_000)), 0, FeeTier005)
+
+	// Nothing is cached before the first block
+	if _, ok := p.quoteKey(tokenIn, tokenOut, amountIn); ok {
+		t.Fatal("expected no cache key before the first block")
+	}
+
+	p.OnNewBlock(100)
+	key, ok := p.quoteKey(tokenIn, tokenOut, amountIn)
+	if !ok {
+		t.Fatal("expected a cache key after a block")
+	}
+	if _, hit := p.cachedQuote(ctx, key); hit {
+		t.Fatal("expected miss before the quote is stored")
+	}
+	p.storeQuote(ctx, key, &quote)
+
+	cached, hit := p.cachedQuote(ctx, key)
+	if !hit || cached.FeeTier != FeeTier005 {
+		t.Fatalf("expected cached quote, got %+v (hit=%v)", cached, hit)
+	}
+
+	other, _ := p.quoteKey(tokenIn, tokenOut, big.NewInt(2e18))
+	if _, hit := p.cachedQuote(ctx, other); hit {
+		t.Error("expected miss for a different amount")
+	}
+
+	// A stale block number must not clear the cache
+	p.OnNewBlock(99)
+	if _, hit := p.cachedQuote(ctx, key); !hit {
+		t.Error("expected older block to leave the cache intact")
+	}
+
+	p.OnNewBlock(101)
+	if _, hit := p.cachedQuote(ctx, key); hit {
+		t.Error("expected new block to invalidate the cached quote")
+	}
+	if stats := p.quoteCache.Stats(); stats.ItemCount != 0 {
+		t.Errorf("expected empty cache after new block, got %d items", stats.ItemCount)
+	}
+}
@@ -0,0 +1,71 @@
+package uniswap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sony/gobreaker/v2"
+)
+
+// retryBudget bounds the quoter retries of a single GetQuote call. It is
+// shared by the concurrently quoted fee tiers so one flaky tier can't
+// stretch the total latency.
+type retryBudget struct {
+	mu        sync.Mutex
+	remaining int
+	backoff   time.Duration
+}
+
+// newRetryBudget creates a budget of retries starting at backoff.
+func newRetryBudget(retries int, backoff time.Duration) *retryBudget {
+	return &retryBudget{remaining: retries, backoff: backoff}
+}
+
+// wait consumes one retry and sleeps for the current backoff, doubling it.
+// It returns false without waiting when the budget is spent, or early when
+// ctx is canceled.
+func (b *retryBudget) wait(ctx context.Context) bool {
+	b.mu.Lock()
+	if b.remaining <= 0 {
+		b.mu.Unlock()
+		return false
+	}
+	b.remaining--
+	delay := b.backoff
+	b.backoff *= 2
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// isRetryable reports whether a quoter call error may succeed on retry.
+// Reverts (e.g. no pool at the tier), an open breaker and canceled contexts
+// are final; anything else is treated as a transient RPC failure.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return false
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		return false
+	}
+	return !strings.Contains(err.Error(), "execution reverted")
+}
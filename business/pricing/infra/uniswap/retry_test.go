@@ -0,0 +1,62 @@
+package uniswap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker/v2"
+)
+
+// revertError mimics the rpc.DataError returned for reverted eth_calls.
+type revertError struct{}
+
+func (revertError) Error() string          { return "execution reverted" }
+func (revertError) ErrorData() interface{} { return "0x" }
+
+// TestRetryBudget_Wait tests that the budget is shared and backs off exponentially.
+func TestRetryBudget_Wait(t *testing.T) {
+	budget := newRetryBudget(2, time.Millisecond)
+
+	if !budget.wait(context.Background()) || !budget.wait(context.Background()) {
+		t.Fatal("expected two retries within budget")
+	}
+	if budget.wait(context.Background()) {
+		t.Error("expected budget to be exhausted after two retries")
+	}
+	if budget.backoff != 4*time.Millisecond {
+		t.Errorf("backoff = %s, want 4ms after two doublings", budget.backoff)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if newRetryBudget(1, time.Hour).wait(ctx) {
+		t.Error("expected canceled context to stop the retry")
+	}
+}
+
+// TestIsRetryable tests which quoter errors are worth retrying.
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "transport_error", err: errors.New("read tcp: connection reset by peer"), want: true},
+		{name: "rate_limited", err: errors.New("429 Too Many Requests"), want: true},
+		{name: "revert_data_error", err: revertError{}, want: false},
+		{name: "revert_message", err: errors.New("execution reverted: SPL"), want: false},
+		{name: "breaker_open", err: gobreaker.ErrOpenState, want: false},
+		{name: "deadline", err: fmt.Errorf("call: %w", context.DeadlineExceeded), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
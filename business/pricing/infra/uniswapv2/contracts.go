@@ -0,0 +1,85 @@
+package uniswapv2
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Uniswap V2 charges a flat 0.30% fee on every swap.
+const (
+	FeeTier        = 3000      // 0.30% in hundredths of a bip (same unit as V3 fee tiers)
+	feeDenominator = 1_000_000 // Fee tiers are parts per million
+)
+
+// DefaultFactoryAddress is the Uniswap V2 factory on Ethereum mainnet.
+const DefaultFactoryAddress = "0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"
+
+// FactoryABI is the ABI for the Uniswap V2 factory.
+// Only includes getPair which we use to resolve pair contracts.
+const FactoryABI = `[
+	{
+		"constant": true,
+		"inputs": [
+			{"internalType": "address", "name": "tokenA", "type": "address"},
+			{"internalType": "address", "name": "tokenB", "type": "address"}
+		],
+		"name": "getPair",
+		"outputs": [{"internalType": "address", "name": "pair", "type": "address"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// PairABI is the ABI for a Uniswap V2 pair.
+// Only includes getReserves which we use for quotes.
+const PairABI = `[
+	{
+		"constant": true,
+		"inputs": [],
+		"name": "getReserves",
+		"outputs": [
+			{"internalType": "uint112", "name": "_reserve0", "type": "uint112"},
+			{"internalType": "uint112", "name": "_reserve1", "type": "uint112"},
+			{"internalType": "uint32", "name": "_blockTimestampLast", "type": "uint32"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// Reserves represents the output of getReserves.
+type Reserves struct {
+	Reserve0           *big.Int
+	Reserve1           *big.Int
+	BlockTimestampLast uint32
+}
+
+// SortTokens returns the pair's token0 and token1 (sorted by address).
+func SortTokens(tokenA, tokenB common.Address) (common.Address, common.Address) {
+	if tokenA.Cmp(tokenB) < 0 {
+		return tokenA, tokenB
+	}
+	return tokenB, tokenA
+}
+
+// GetAmountOut computes the constant-product output for an input amount,
+// including the 0.30% fee. Mirrors UniswapV2Library.getAmountOut.
+func GetAmountOut(amountIn, reserveIn, reserveOut *big.Int) *big.Int {
+	return GetAmountOutWithFee(amountIn, reserveIn, reserveOut, FeeTier)
+}
+
+// GetAmountOutWithFee computes the constant-product output for a pool
+// charging fee (in hundredths of a bip, 2500 = 0.25%), as V2 forks do.
+func GetAmountOutWithFee(amountIn, reserveIn, reserveOut *big.Int, fee int) *big.Int {
+	if amountIn.Sign() <= 0 || reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return new(big.Int)
+	}
+
+	amountInWithFee := new(big.Int).Mul(amountIn, big.NewInt(int64(feeDenominator-fee)))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Mul(reserveIn, big.NewInt(feeDenominator))
+	denominator.Add(denominator, amountInWithFee)
+
+	return numerator.Div(numerator, denominator)
+}
@@ -0,0 +1,75 @@
+package uniswapv2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TestGetAmountOut tests the constant-product formula with the 0.30% fee.
+func TestGetAmountOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		amountIn   int64
+		reserveIn  int64
+		reserveOut int64
+		want       int64
+	}{
+		// 1000*997*1_000_000 / (1_000_000*1000 + 1000*997) = 996
+		{name: "small_trade", amountIn: 1000, reserveIn: 1_000_000, reserveOut: 1_000_000, want: 996},
+		// Large trade moves the price: 100000*997*1e6 / (1e6*1000 + 100000*997) = 90661
+		{name: "price_impact", amountIn: 100_000, reserveIn: 1_000_000, reserveOut: 1_000_000, want: 90661},
+		{name: "zero_input", amountIn: 0, reserveIn: 1_000_000, reserveOut: 1_000_000, want: 0},
+		{name: "empty_pool", amountIn: 1000, reserveIn: 0, reserveOut: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetAmountOut(big.NewInt(tt.amountIn), big.NewInt(tt.reserveIn), big.NewInt(tt.reserveOut))
+			if got.Cmp(big.NewInt(tt.want)) != 0 {
+				t.Errorf("GetAmountOut() = %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetAmountOutWithFee tests the formula with fork fees.
+func TestGetAmountOutWithFee(t *testing.T) {
+	tests := []struct {
+		name string
+		fee  int
+		want int64
+	}{
+		// Matches GetAmountOut at the V2 fee
+		{name: "uniswap_030", fee: FeeTier, want: 996},
+		// 1000*997500*1e6 / (1e6*1e6 + 1000*997500) = 996.5 -> 996
+		{name: "pancake_025", fee: 2500, want: 996},
+		// 1000*1e6*1e6 / (1e6*1e6 + 1000*1e6) = 999.0
+		{name: "zero_fee", fee: 0, want: 999},
+		// 1000*990000*1e6 / (1e6*1e6 + 1000*990000) = 989.02
+		{name: "one_percent", fee: 10_000, want: 989},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetAmountOutWithFee(big.NewInt(1000), big.NewInt(1_000_000), big.NewInt(1_000_000), tt.fee)
+			if got.Cmp(big.NewInt(tt.want)) != 0 {
+				t.Errorf("GetAmountOutWithFee() = %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSortTokens tests that token0 is the lower address regardless of input order.
+func TestSortTokens(t *testing.T) {
+	low := common.HexToAddress("0x1000000000000000000000000000000000000000")
+	high := common.HexToAddress("0x2000000000000000000000000000000000000000")
+
+	for _, in := range [][2]common.Address{{low, high}, {high, low}} {
+		token0, token1 := SortTokens(in[0], in[1])
+		if token0 != low || token1 != high {
+			t.Errorf("SortTokens(%s, %s) = (%s, %s)", in[0].Hex(), in[1].Hex(), token0.Hex(), token1.Hex())
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package uniswapv2 implements the DEXProvider interface for Uniswap V2.
+package uniswapv2
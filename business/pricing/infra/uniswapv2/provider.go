@@ -0,0 +1,357 @@
+package uniswapv2
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/sony/gobreaker/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/domain"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/circuitbreaker"
+	"github.com/fd1az/arbitrage-bot/internal/config"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+const (
+	tracerName = "uniswapv2"
+	meterName  = "uniswapv2"
+
+	// swapGasEstimate is the typical gas used by a single-hop V2 swap.
+	swapGasEstimate = 110_000
+)
+
+// Ensure Provider implements DEXProvider and CircuitStater.
+var (
+	_ app.DEXProvider   = (*Provider)(nil)
+	_ app.CircuitStater = (*Provider)(nil)
+)
+
+// providerMetrics holds OTEL metric instruments.
+type providerMetrics struct {
+	quotesTotal  metric.Int64Counter
+	quoteLatency metric.Float64Histogram
+	quoteErrors  metric.Int64Counter
+}
+
+// Provider implements DEXProvider for Uniswap V2 and forks sharing its
+// factory/pair ABI.
+type Provider struct {
+	client     *ethclient.Client
+	factory    common.Address
+	factoryABI abi.ABI
+	pairABI    abi.ABI
+	fee        int    // Swap fee in hundredths of a bip
+	protocol   string // Reported as Quote.Protocol
+
+	// Pair address cache (token0+token1 -> pair); pairs never move
+	pairs   map[string]common.Address
+	pairsMu sync.RWMutex
+
+	chainID  uint64 // Chain the quoted tokens live on
+	registry *asset.Registry
+	logger   logger.LoggerInterface
+	cb       *circuitbreaker.CircuitBreaker[[]byte]
+
+	tracer  trace.Tracer
+	metrics *providerMetrics
+}
+
+// Options selects the constant-product DEX a Provider prices.
+type Options struct {
+	Protocol    string         // Quote.Protocol and metric label, e.g. "uniswap_v2"
+	Factory     common.Address // Factory resolving pairs via getPair
+	Fee         int            // Swap fee in hundredths of a bip (3000 = 0.30%)
+	BreakerName string         // Circuit breaker name for contract calls
+}
+
+// NewProvider creates a new Uniswap V2 provider for the factory in cfg on
+// chainID; quoted tokens are resolved against registry.
+func NewProvider(client *ethclient.Client, cfg config.UniswapConfig, chainID uint64, registry *asset.Registry, log logger.LoggerInterface) (*Provider, error) {
+	// The default factory only exists on mainnet
+	factory := cfg.V2FactoryAddressHex()
+	if cfg.V2FactoryAddress == "" {
+		if chainID != asset.ChainIDEthereum {
+			return nil, apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("uniswap.v2_factory_address is required on chain %d", chainID)))
+		}
+		factory = common.HexToAddress(DefaultFactoryAddress)
+	}
+
+	return NewProviderWithOptions(client, Options{
+		Protocol:    domain.ProtocolUniswapV2,
+		Factory:     factory,
+		Fee:         FeeTier,
+		BreakerName: "uniswap-v2",
+	}, chainID, registry, log)
+}
+
+// NewProviderWithOptions creates a Provider for any DEX sharing the Uniswap
+// V2 factory/pair ABI, such as SushiSwap or PancakeSwap.
+func NewProviderWithOptions(client *ethclient.Client, opts Options, chainID uint64, registry *asset.Registry, log logger.LoggerInterface) (*Provider, error) {
+	factoryABI, err := abi.JSON(strings.NewReader(FactoryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse factory ABI: %w", err)
+	}
+
+	pairABI, err := abi.JSON(strings.NewReader(PairABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pair ABI: %w", err)
+	}
+
+	p := &Provider{
+		client:     client,
+		factory:    opts.Factory,
+		factoryABI: factoryABI,
+		pairABI:    pairABI,
+		fee:        opts.Fee,
+		protocol:   opts.Protocol,
+		pairs:      make(map[string]common.Address),
+		chainID:    chainID,
+		registry:   registry,
+		logger:     log,
+		tracer:     otel.Tracer(tracerName),
+	}
+
+	// Initialize circuit breaker
+	cbCfg := circuitbreaker.DefaultConfig(opts.BreakerName)
+	p.cb = circuitbreaker.New[[]byte](cbCfg)
+
+	if err := p.initMetrics(); err != nil {
+		return nil, fmt.Errorf("failed to init metrics: %w", err)
+	}
+
+	return p, nil
+}
+
+func (p *Provider) initMetrics() error {
+	meter := otel.Meter(meterName)
+	var err error
+
+	p.metrics = &providerMetrics{}
+
+	p.metrics.quotesTotal, err = meter.Int64Counter(
+		"uniswapv2_quotes_total",
+		metric.WithDescription("Total quote requests"),
+	)
+	if err != nil {
+		return err
+	}
+
+	p.metrics.quoteLatency, err = meter.Float64Histogram(
+		"uniswapv2_quote_latency_ms",
+		metric.WithDescription("Quote request latency in milliseconds"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return err
+	}
+
+	p.metrics.quoteErrors, err = meter.Int64Counter(
+		"uniswapv2_quote_errors_total",
+		metric.WithDescription("Total quote errors"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetQuote retrieves a price quote for swapping tokens on Uniswap V2.
+// The output is computed locally from the pair reserves using the
+// constant-product formula with the provider's fee.
+func (p *Provider) GetQuote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*domain.Quote, error) {
+	ctx, span := p.tracer.Start(ctx, "uniswapv2.get_quote",
+		trace.WithAttributes(
+			attribute.String("protocol", p.protocol),
+			attribute.String("token_in", tokenIn.Hex()),
+			attribute.String("token_out", tokenOut.Hex()),
+			attribute.String("amount_in", amountIn.String()),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	attrs := metric.WithAttributes(attribute.String("protocol", p.protocol))
+	p.metrics.quotesTotal.Add(ctx, 1, attrs)
+
+	amountOut, err := p.quote(ctx, tokenIn, tokenOut, amountIn)
+
+	latency := float64(time.Since(start).Milliseconds())
+	p.metrics.quoteLatency.Record(ctx, latency, attrs)
+
+	if err != nil {
+		p.metrics.quoteErrors.Add(ctx, 1, attrs)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "no valid quote")
+		return nil, err
+	}
+
+	// Build domain.Quote
+	assetIn := p.resolveAsset(tokenIn)
+	assetOut := p.resolveAsset(tokenOut)
+
+	amtIn := asset.NewAmount(assetIn, amountIn)
+	amtOut := asset.NewAmount(assetOut, amountOut)
+
+	result := domain.NewQuote(assetIn, assetOut, amtIn, amtOut, swapGasEstimate, p.fee)
+	result.Protocol = p.protocol
+
+	span.SetAttributes(attribute.String("amount_out", amountOut.String()))
+	span.SetStatus(codes.Ok, "quote received")
+
+	p.logger.Debug(ctx, "uniswap v2 quote",
+		"protocol", p.protocol,
+		"token_in", tokenIn.Hex(),
+		"token_out", tokenOut.Hex(),
+		"amount_in", amountIn.String(),
+		"amount_out", amountOut.String(),
+	)
+
+	return &result, nil
+}
+
+// quote resolves the pair, reads its reserves and computes the output amount.
+func (p *Provider) quote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error) {
+	pair, err := p.getPair(ctx, tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+
+	reserves, err := p.getReserves(ctx, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orient reserves to the swap direction
+	reserveIn, reserveOut := reserves.Reserve0, reserves.Reserve1
+	if token0, _ := SortTokens(tokenIn, tokenOut); token0 != tokenIn {
+		reserveIn, reserveOut = reserveOut, reserveIn
+	}
+
+	amountOut := GetAmountOutWithFee(amountIn, reserveIn, reserveOut, p.fee)
+	if amountOut.Sign() == 0 {
+		return nil, apperror.New(apperror.CodeInsufficientLiquidity,
+			apperror.WithContext(fmt.Sprintf("no liquidity in pair %s", pair.Hex())))
+	}
+
+	return amountOut, nil
+}
+
+// getPair resolves the pair contract for two tokens via factory.getPair (cached).
+func (p *Provider) getPair(ctx context.Context, tokenA, tokenB common.Address) (common.Address, error) {
+	token0, token1 := SortTokens(tokenA, tokenB)
+	key := token0.Hex() + token1.Hex()
+
+	p.pairsMu.RLock()
+	pair, ok := p.pairs[key]
+	p.pairsMu.RUnlock()
+	if ok {
+		return pair, nil
+	}
+
+	callData, err := p.factoryABI.Pack("getPair", token0, token1)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to encode call: %w", err)
+	}
+
+	result, err := p.call(ctx, p.factory, callData)
+	if err != nil {
+		return common.Address{}, apperror.New(apperror.CodeContractCallFailed,
+			apperror.WithCause(err),
+			apperror.WithContext("factory getPair call failed"))
+	}
+
+	outputs, err := p.factoryABI.Unpack("getPair", result)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode result: %w", err)
+	}
+	if len(outputs) < 1 {
+		return common.Address{}, fmt.Errorf("unexpected output length: %d", len(outputs))
+	}
+
+	pair = outputs[0].(common.Address)
+	if pair == (common.Address{}) {
+		return common.Address{}, apperror.New(apperror.CodeUniswapPoolNotFound,
+			apperror.WithContext(fmt.Sprintf("no V2 pair for %s/%s", token0.Hex(), token1.Hex())))
+	}
+
+	p.pairsMu.Lock()
+	p.pairs[key] = pair
+	p.pairsMu.Unlock()
+
+	return pair, nil
+}
+
+// getReserves calls getReserves on a V2 pair contract.
+func (p *Provider) getReserves(ctx context.Context, pair common.Address) (*Reserves, error) {
+	callData, err := p.pairABI.Pack("getReserves")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode call: %w", err)
+	}
+
+	result, err := p.call(ctx, pair, callData)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeContractCallFailed,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("getReserves call failed for %s", pair.Hex())))
+	}
+
+	outputs, err := p.pairABI.Unpack("getReserves", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode result: %w", err)
+	}
+	if len(outputs) < 3 {
+		return nil, fmt.Errorf("unexpected output length: %d", len(outputs))
+	}
+
+	return &Reserves{
+		Reserve0:           outputs[0].(*big.Int),
+		Reserve1:           outputs[1].(*big.Int),
+		BlockTimestampLast: outputs[2].(uint32),
+	}, nil
+}
+
+// CircuitState returns the state of the circuit breaker guarding pair contract calls.
+func (p *Provider) CircuitState() gobreaker.State {
+	return p.cb.State()
+}
+
+// call executes an eth_call through the circuit breaker.
+func (p *Provider) call(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+	return p.cb.Execute(func() ([]byte, error) {
+		return p.client.CallContract(ctx, ethereum.CallMsg{
+			To:   &to,
+			Data: data,
+		}, nil)
+	})
+}
+
+// resolveAsset attempts to find the asset in the registry.
+func (p *Provider) resolveAsset(addr common.Address) *asset.Asset {
+	if a, ok := p.registry.GetToken(p.chainID, addr); ok {
+		return a
+	}
+	// Return a generic ERC20 if not found
+	return asset.NewAsset(
+		asset.NewTokenAssetID(p.chainID, addr),
+		addr.Hex()[:8],
+		18, // Assume 18 decimals
+	)
+}
@@ -0,0 +1,4 @@
+// Package uniswapv2fork implements the DEXProvider interface for Uniswap V2
+// forks (SushiSwap, PancakeSwap, ...) that share the V2 factory/pair ABI but
+// deploy their own factory and may charge a different swap fee.
+package uniswapv2fork
@@ -0,0 +1,53 @@
+package uniswapv2fork
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/fd1az/arbitrage-bot/business/pricing/app"
+	"github.com/fd1az/arbitrage-bot/business/pricing/infra/uniswapv2"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/config"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// Ensure Provider implements DEXProvider and CircuitStater.
+var (
+	_ app.DEXProvider   = (*Provider)(nil)
+	_ app.CircuitStater = (*Provider)(nil)
+)
+
+// Provider prices one V2 fork from its pair reserves, reusing the Uniswap V2
+// constant-product quote with the fork's fee.
+type Provider struct {
+	*uniswapv2.Provider
+}
+
+// NewProvider creates a provider for the fork in cfg on chainID. Quotes carry
+// the fork's name as their protocol, so the aggregating DEX provider and the
+// UI can tell forks apart.
+func NewProvider(client *ethclient.Client, cfg config.DEXForkConfig, chainID uint64, registry *asset.Registry, log logger.LoggerInterface) (*Provider, error) {
+	if cfg.Name == "" {
+		return nil, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext("dex fork name is required"))
+	}
+	if !common.IsHexAddress(cfg.FactoryAddress) {
+		return nil, apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("invalid factory address for dex fork %s: %q", cfg.Name, cfg.FactoryAddress)))
+	}
+
+	v2, err := uniswapv2.NewProviderWithOptions(client, uniswapv2.Options{
+		Protocol:    cfg.Name,
+		Factory:     common.HexToAddress(cfg.FactoryAddress),
+		Fee:         cfg.FeeTier(),
+		BreakerName: cfg.Name,
+	}, chainID, registry, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{Provider: v2}, nil
+}
@@ -0,0 +1,63 @@
+package uniswapv2fork
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/config"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// mockLogger implements logger.LoggerInterface for testing.
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Info(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any)               {}
+func (m *mockLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (m *mockLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+var _ logger.LoggerInterface = (*mockLogger)(nil)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.DEXForkConfig
+		wantCode apperror.Code
+	}{
+		{
+			name: "sushiswap",
+			cfg:  config.DEXForkConfig{Name: "sushiswap", FactoryAddress: "0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac", FeeBps: 30},
+		},
+		{
+			name:     "missing_name",
+			cfg:      config.DEXForkConfig{FactoryAddress: "0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac", FeeBps: 30},
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name:     "invalid_factory",
+			cfg:      config.DEXForkConfig{Name: "sushiswap", FactoryAddress: "sushi", FeeBps: 30},
+			wantCode: apperror.CodeConfigurationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProvider(nil, tt.cfg, asset.ChainIDEthereum, asset.DefaultRegistry(), &mockLogger{})
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("NewProvider() unexpected error: %v", err)
+				}
+				return
+			}
+			if code := apperror.GetCode(err); code != tt.wantCode {
+				t.Errorf("NewProvider() code = %s, want %s (err %v)", code, tt.wantCode, err)
+			}
+		})
+	}
+}
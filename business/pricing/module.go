@@ -9,10 +9,17 @@ import (
 
 	"github.com/fd1az/arbitrage-bot/business/pricing/app"
 	pricingDI "github.com/fd1az/arbitrage-bot/business/pricing/di"
+	"github.com/fd1az/arbitrage-bot/business/pricing/infra"
 	"github.com/fd1az/arbitrage-bot/business/pricing/infra/binance"
+	"github.com/fd1az/arbitrage-bot/business/pricing/infra/coinbase"
+	"github.com/fd1az/arbitrage-bot/business/pricing/infra/kraken"
 	"github.com/fd1az/arbitrage-bot/business/pricing/infra/uniswap"
+	"github.com/fd1az/arbitrage-bot/business/pricing/infra/uniswapv2"
+	"github.com/fd1az/arbitrage-bot/business/pricing/infra/uniswapv2fork"
+	"github.com/fd1az/arbitrage-bot/internal/asset"
 	"github.com/fd1az/arbitrage-bot/internal/config"
 	"github.com/fd1az/arbitrage-bot/internal/di"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
 	"github.com/fd1az/arbitrage-bot/internal/monolith"
 )
@@ -22,35 +29,32 @@ type Module struct{}
 
 // RegisterServices registers all pricing services with the DI container.
 func (m *Module) RegisterServices(c di.Container) error {
-	// Register CEXProvider (Binance) - private dependency
-	di.RegisterToken(c, pricingDI.CEXProvider, func(sr di.ServiceRegistry) app.CEXProvider {
-		cfg := sr.Get("config").(*config.Config)
-		log := sr.Get("logger").(logger.LoggerInterface)
-
-		providerCfg := binance.ProviderConfig{
-			WebSocketURL:  cfg.Binance.WebSocketURL,
-			Symbols:       cfg.Binance.Symbols,
-			DepthSpeedMs:  cfg.Binance.DepthSpeedMs,
-			SnapshotDepth: 20,
-			StaleTimeout:  cfg.Binance.StaleTimeout,
-		}
+	cfg := c.Get("config").(*config.Config)
+
+	// The Binance provider measures the clock offset on connect; the user
+	// data stream stamps its signed requests with it
+	binanceClock := &binance.ServerClock{}
 
-		provider, err := binance.NewProvider(providerCfg, log)
+	// Providers are selected by name; fail fast on names nothing registered
+	registry := newProviderRegistry(c, binanceClock)
+	if err := registry.Validate(cfg.CEXProviderNames(), cfg.DEXProviderNames()); err != nil {
+		return err
+	}
+
+	// Register CEXProvider (one or more venues, aggregated for best bid/ask) - private dependency
+	di.RegisterToken(c, pricingDI.CEXProvider, func(sr di.ServiceRegistry) app.CEXProvider {
+		provider, err := registry.NewCEX(cfg.CEXProviderNames())
 		if err != nil {
-			panic("failed to create binance provider: " + err.Error())
+			panic("failed to create cex provider: " + err.Error())
 		}
 		return provider
 	})
 
-	// Register DEXProvider (Uniswap) - private dependency
+	// Register DEXProvider (one or more protocols, best quote wins) - private dependency
 	di.RegisterToken(c, pricingDI.DEXProvider, func(sr di.ServiceRegistry) app.DEXProvider {
-		cfg := sr.Get("config").(*config.Config)
-		log := sr.Get("logger").(logger.LoggerInterface)
-		ethClient := sr.Get("ethClient").(*ethclient.Client)
-
-		provider, err := uniswap.NewProvider(ethClient, cfg.Uniswap, log)
+		provider, err := registry.NewDEX(cfg.DEXProviderNames())
 		if err != nil {
-			panic("failed to create uniswap provider: " + err.Error())
+			panic("failed to create dex provider: " + err.Error())
 		}
 		return provider
 	})
@@ -59,39 +63,144 @@ func (m *Module) RegisterServices(c di.Container) error {
 	di.RegisterToken(c, pricingDI.PricingService, func(sr di.ServiceRegistry) *app.PricingService {
 		cex := pricingDI.GetCEXProvider(sr)
 		dex := pricingDI.GetDEXProvider(sr)
-		return app.NewPricingService(cex, dex)
+		service := app.NewPricingService(cex, dex)
+		service.SetCEXVenues(cfg.CEXProviderNames())
+		if len(cfg.Pricing.StableEquivalents) > 0 {
+			assets := sr.Get("assetRegistry").(*asset.Registry)
+			service.SetStableEquivalents(app.NewStableEquivalents(cfg.Pricing.StablePegs(), cfg.Pricing.CEXQuote, assets))
+		}
+		return service
+	})
+
+	// Register UserDataStream (public - nil unless Binance API credentials are set)
+	di.RegisterToken(c, pricingDI.UserDataStream, func(sr di.ServiceRegistry) *binance.UserDataStream {
+		if !cfg.Binance.UserDataEnabled() {
+			return nil
+		}
+		stream, err := binance.NewUserDataStream(binance.UserDataConfig{
+			APIKey:    cfg.Binance.APIKey,
+			APISecret: cfg.Binance.APISecret,
+			ProxyURL:  cfg.Binance.ProxyURL,
+			Clock:     binanceClock,
+		}, sr.Get("logger").(logger.LoggerInterface))
+		if err != nil {
+			panic("failed to create binance user data stream: " + err.Error())
+		}
+		return stream
+	})
+
+	// Register DebugState (public - backs the /debug endpoint)
+	di.RegisterToken(c, pricingDI.DebugState, func(sr di.ServiceRegistry) *infra.DebugState {
+		return infra.NewDebugState(pricingDI.GetCEXProvider(sr), pricingDI.GetDEXProvider(sr))
 	})
 
 	return nil
 }
 
+// newProviderRegistry registers the built-in price providers. A new venue is
+// added here and then selected via pricing.cex_providers/pricing.dex_providers.
+func newProviderRegistry(sr di.ServiceRegistry, binanceClock *binance.ServerClock) *app.ProviderRegistry {
+	registry := app.NewProviderRegistry()
+
+	registry.RegisterCEX(config.CEXProviderBinance, func() (app.CEXProvider, error) {
+		cfg := sr.Get("config").(*config.Config)
+		return binance.NewProvider(binance.ProviderConfig{
+			WebSocketURL:    cfg.Binance.WebSocketURL,
+			Symbols:         cfg.Binance.Symbols,
+			DepthSpeedMs:    cfg.Binance.DepthSpeedMs,
+			SnapshotDepth:   20,
+			StaleTimeout:    cfg.Binance.StaleTimeout,
+			DiffDepth:       cfg.Binance.DiffDepth,
+			ProxyURL:        cfg.Binance.ProxyURL,
+			Compression:     cfg.Binance.Compression,
+			SymbolOverrides: cfg.Binance.SymbolOverrides,
+			InvalidSymbols:  cfg.Binance.InvalidSymbols,
+			ClockSkew:       cfg.Binance.ClockSkew,
+			MaxClockSkew:    cfg.Binance.MaxClockSkew,
+			Clock:           binanceClock,
+			MaxBookLevels:   cfg.Binance.MaxBookLevels,
+
+			RejectPartialFills: cfg.CEX.RejectPartialFills,
+		}, sr.Get("logger").(logger.LoggerInterface))
+	})
+
+	registry.RegisterCEX(config.CEXProviderCoinbase, func() (app.CEXProvider, error) {
+		cfg := sr.Get("config").(*config.Config)
+		return coinbase.NewProvider(coinbase.ProviderConfig{
+			WebSocketURL:   cfg.Coinbase.WebSocketURL,
+			HTTPURL:        cfg.Coinbase.HTTPURL,
+			ProductIDs:     cfg.Coinbase.ProductIDs,
+			SnapshotDepth:  20,
+			StaleTimeout:   cfg.Coinbase.StaleTimeout,
+			EnableFallback: true,
+
+			RejectPartialFills: cfg.CEX.RejectPartialFills,
+		}, sr.Get("logger").(logger.LoggerInterface))
+	})
+
+	registry.RegisterCEX(config.CEXProviderKraken, func() (app.CEXProvider, error) {
+		cfg := sr.Get("config").(*config.Config)
+		return kraken.NewProvider(kraken.ProviderConfig{
+			WebSocketURL:   cfg.Kraken.WebSocketURL,
+			HTTPURL:        cfg.Kraken.HTTPURL,
+			Pairs:          cfg.Kraken.Pairs,
+			Depth:          cfg.Kraken.Depth,
+			SnapshotDepth:  cfg.Kraken.Depth,
+			StaleTimeout:   cfg.Kraken.StaleTimeout,
+			EnableFallback: true,
+
+			RejectPartialFills: cfg.CEX.RejectPartialFills,
+		}, sr.Get("logger").(logger.LoggerInterface))
+	})
+
+	registry.RegisterDEX(config.DEXProviderUniswapV3, func() (app.DEXProvider, error) {
+		cfg := sr.Get("config").(*config.Config)
+		return uniswap.NewProvider(sr.Get("ethClient").(*ethclient.Client), cfg.Uniswap, cfg.Ethereum.ChainID,
+			sr.Get("assetRegistry").(*asset.Registry), sr.Get("logger").(logger.LoggerInterface))
+	})
+
+	registry.RegisterDEX(config.DEXProviderUniswapV2, func() (app.DEXProvider, error) {
+		cfg := sr.Get("config").(*config.Config)
+		return uniswapv2.NewProvider(sr.Get("ethClient").(*ethclient.Client), cfg.Uniswap, cfg.Ethereum.ChainID,
+			sr.Get("assetRegistry").(*asset.Registry), sr.Get("logger").(logger.LoggerInterface))
+	})
+
+	// Uniswap V2 forks are registered under their configured names
+	for _, fork := range sr.Get("config").(*config.Config).DEX.Forks {
+		registry.RegisterDEX(fork.Name, func() (app.DEXProvider, error) {
+			cfg := sr.Get("config").(*config.Config)
+			return uniswapv2fork.NewProvider(sr.Get("ethClient").(*ethclient.Client), fork, cfg.Ethereum.ChainID,
+				sr.Get("assetRegistry").(*asset.Registry), sr.Get("logger").(logger.LoggerInterface))
+		})
+	}
+
+	return registry
+}
+
 // Startup initializes the pricing module.
 func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) error {
 	log := mono.Logger()
 
-	// Connect Binance provider (don't fail if connection fails - will retry)
+	// Connect CEX providers (don't fail if connection fails - will retry)
 	cex := pricingDI.GetCEXProvider(mono.Services())
-	if connector, ok := cex.(interface{ Connect(context.Context) error }); ok {
-		// Try to connect with a short timeout - don't block startup
-		connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-
-		if err := connector.Connect(connectCtx); err != nil {
-			log.Warn(ctx, "binance connection failed, will retry in background", "error", err)
-			// Start background connection retry
+	venues := []app.Venue{{Name: mono.Config().CEXProviderNames()[0], Provider: cex}}
+	if agg, ok := cex.(*app.AggregatingCEXProvider); ok {
+		venues = agg.Venues()
+	}
+	for _, venue := range venues {
+		connectCEX(ctx, log, venue)
+		registerHealth(mono.Health(), venue)
+	}
+
+	// The user-data stream only reports fills; never block startup on it
+	if stream := pricingDI.GetUserDataStream(mono.Services()); stream != nil {
+		if err := stream.Start(ctx); err != nil {
+			log.Warn(ctx, "binance user data stream unavailable", "error", err)
+		} else {
 			go func() {
-				for {
-					select {
-					case <-ctx.Done():
-						return
-					case <-time.After(5 * time.Second):
-						if err := connector.Connect(ctx); err != nil {
-							log.Warn(ctx, "binance retry failed", "error", err)
-						} else {
-							log.Info(ctx, "binance connected successfully")
-							return
-						}
-					}
+				<-ctx.Done()
+				if err := stream.Stop(context.Background()); err != nil {
+					log.Warn(context.Background(), "failed to close binance user data stream", "error", err)
 				}
 			}()
 		}
@@ -100,3 +209,45 @@ func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) error {
 	log.Info(ctx, "pricing module started")
 	return nil
 }
+
+// registerHealth registers a CEX venue's feed with the health server.
+// A stale feed is critical since every spread depends on it.
+func registerHealth(server *health.Server, venue app.Venue) {
+	reporter, ok := venue.Provider.(health.HealthReporter)
+	if !ok || server == nil {
+		return
+	}
+	server.RegisterReporter(venue.Name, reporter, true)
+}
+
+// connectCEX connects a CEX venue, retrying in the background on failure.
+func connectCEX(ctx context.Context, log logger.LoggerInterface, venue app.Venue) {
+	connector, ok := venue.Provider.(interface{ Connect(context.Context) error })
+	if !ok {
+		return
+	}
+
+	// Try to connect with a short timeout - don't block startup
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := connector.Connect(connectCtx); err != nil {
+		log.Warn(ctx, "cex connection failed, will retry in background", "provider", venue.Name, "error", err)
+		// Start background connection retry
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(5 * time.Second):
+					if err := connector.Connect(ctx); err != nil {
+						log.Warn(ctx, "cex retry failed", "provider", venue.Name, "error", err)
+					} else {
+						log.Info(ctx, "cex connected successfully", "provider", venue.Name)
+						return
+					}
+				}
+			}
+		}()
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/business/arbitrage/infra/storage"
+	"github.com/fd1az/arbitrage-bot/internal/apiserver"
+	"github.com/fd1az/arbitrage-bot/internal/config"
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// runExport implements the one-shot "export" subcommand: it writes the
+// opportunities detected in the last --since window as CSV. Records come
+// from the SQLite store when storage is enabled, otherwise from the running
+// bot's in-memory buffer via the REST API.
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	configPath := fs.String("config", "", "Path to configuration file")
+	since := fs.Duration("since", time.Hour, "Export opportunities detected within this window")
+	out := fs.String("out", "-", "Output CSV file (- for stdout)")
+	apiURL := fs.String("api", "", "REST API base URL used when storage is disabled (default http://localhost:<api.port>)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *since <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	to := time.Now()
+	from := to.Add(-*since)
+
+	var records []storage.Record
+	if cfg.Storage.Enabled {
+		records, err = queryStore(cfg, from, to)
+	} else {
+		baseURL := *apiURL
+		if baseURL == "" {
+			baseURL = fmt.Sprintf("http://localhost:%d", cfg.API.Port)
+		}
+		records, err = fetchRecent(ctx, baseURL, from)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *out == "-" {
+		if err := storage.WriteCSV(os.Stdout, records); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+		return nil
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *out, err)
+	}
+	if err := storage.WriteCSV(f, records); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	// Close reports write-back failures (e.g. a full disk) the writes didn't
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", *out, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "exported %d opportunities to %s\n", len(records), *out)
+	return nil
+}
+
+// queryStore reads opportunities in [from, to] from the SQLite store.
+func queryStore(cfg *config.Config, from, to time.Time) ([]storage.Record, error) {
+	log := logger.New(io.Discard, logger.LevelError, cfg.App.Name, nil)
+	store, err := storage.NewSQLiteReporter(storage.DefaultConfig(cfg.Storage.Path), nil, log)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Stop()
+
+	return store.Query(from, to)
+}
+
+// fetchRecent reads the running bot's recent opportunities from the REST API,
+// keeping those detected at or after from, oldest first.
+func fetchRecent(ctx context.Context, baseURL string, from time.Time) ([]storage.Record, error) {
+	url := fmt.Sprintf("%s/api/opportunities?limit=%d", baseURL, apiserver.MaxOpportunityLimit)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage is disabled and the API is unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("api returned %s", resp.Status)
+	}
+
+	var body struct {
+		Opportunities []apiserver.Opportunity `json:"opportunities"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode opportunities: %w", err)
+	}
+
+	records := make([]storage.Record, 0, len(body.Opportunities))
+	for _, opp := range body.Opportunities {
+		if opp.Timestamp.Before(from) {
+			continue
+		}
+		records = append(records, storage.Record{
+			BlockNumber:    opp.BlockNumber,
+			Pair:           opp.Pair,
+			Direction:      opp.Direction,
+			SpreadBps:      parseDecimal(opp.SpreadBps),
+			GrossProfitUSD: parseDecimal(opp.GrossProfitUSD),
+			GasCostUSD:     parseDecimal(opp.GasCostUSD),
+			FeesUSD:        parseDecimal(opp.FeesUSD),
+			NetProfitUSD:   parseDecimal(opp.NetProfitUSD),
+			Profitable:     opp.Profitable,
+			Timestamp:      opp.Timestamp,
+		})
+	}
+	// The API returns newest first; the export is chronological
+	slices.Reverse(records)
+	return records, nil
+}
+
+// parseDecimal parses an API amount, treating missing values as zero.
+func parseDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
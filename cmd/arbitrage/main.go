@@ -8,8 +8,8 @@ import (
 	"io"
 	"os"
 	"os/signal"
-	"strconv"
 	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joho/godotenv"
@@ -17,10 +17,19 @@ import (
 	"github.com/fd1az/arbitrage-bot/business/arbitrage"
 	arbitrageApp "github.com/fd1az/arbitrage-bot/business/arbitrage/app"
 	arbitrageDI "github.com/fd1az/arbitrage-bot/business/arbitrage/di"
+	arbitrageDomain "github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
 	"github.com/fd1az/arbitrage-bot/business/blockchain"
+	blockchainApp "github.com/fd1az/arbitrage-bot/business/blockchain/app"
+	blockchainDI "github.com/fd1az/arbitrage-bot/business/blockchain/di"
 	"github.com/fd1az/arbitrage-bot/business/pricing"
+	pricingApp "github.com/fd1az/arbitrage-bot/business/pricing/app"
+	pricingDI "github.com/fd1az/arbitrage-bot/business/pricing/di"
+	"github.com/fd1az/arbitrage-bot/internal/apiserver"
 	"github.com/fd1az/arbitrage-bot/internal/apm"
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
 	"github.com/fd1az/arbitrage-bot/internal/config"
+	"github.com/fd1az/arbitrage-bot/internal/debugserver"
+	"github.com/fd1az/arbitrage-bot/internal/grpcserver"
 	"github.com/fd1az/arbitrage-bot/internal/health"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
 	"github.com/fd1az/arbitrage-bot/internal/metrics"
@@ -28,6 +37,10 @@ import (
 	"github.com/fd1az/arbitrage-bot/pkg/ui"
 )
 
+// shutdownTimeout bounds the drain of in-flight analysis and the flush of
+// reporters, spans and metrics on exit.
+const shutdownTimeout = 10 * time.Second
+
 var (
 	version   = "dev"
 	commit    = "none"
@@ -38,10 +51,22 @@ func main() {
 	// Load .env file if present (ignore error if not found)
 	_ = godotenv.Load()
 
+	// One-shot subcommands
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(context.Background(), os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Parse flags
 	configPath := flag.String("config", "", "Path to configuration file")
+	chain := flag.String("chain", "", "Chain profile from the chains config section (e.g. arbitrum)")
 	cliMode := flag.Bool("cli", false, "Run in CLI mode with logs (no TUI)")
 	showVersion := flag.Bool("version", false, "Show version information")
+	backtestDir := flag.String("backtest", "", "Replay recorded data from this directory instead of connecting live")
+	recordDir := flag.String("record", "", "Record blocks, orderbooks and quotes to this directory for backtesting")
 	flag.Parse()
 
 	if *showVersion {
@@ -49,8 +74,8 @@ func main() {
 		os.Exit(0)
 	}
 
-	// TUI is the default, CLI is for debugging
-	tuiMode := !*cliMode
+	// TUI is the default, CLI is for debugging (backtests always print to the console)
+	tuiMode := !*cliMode && *backtestDir == ""
 
 	// Setup context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
@@ -68,32 +93,31 @@ func main() {
 	}()
 
 	// Run application
-	if err := run(ctx, *configPath, tuiMode); err != nil {
+	runApp := func() error { return run(ctx, *configPath, *chain, *recordDir, tuiMode) }
+	if *backtestDir != "" {
+		runApp = func() error { return runBacktest(ctx, *configPath, *chain, *backtestDir) }
+	}
+	if err := runApp(); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run(ctx context.Context, configPath string, tuiMode bool) error {
+func run(ctx context.Context, configPath, chain, recordDir string, tuiMode bool) error {
 	// Load configuration
-	cfg, err := config.Load(configPath)
+	cfg, err := config.LoadChain(configPath, chain)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Set TUI mode in config so modules know
 	cfg.Arbitrage.TUIMode = tuiMode
+	if recordDir != "" {
+		cfg.Recording.Dir = recordDir
+	}
 
 	// Setup logger (only log to stderr in CLI mode)
-	logLevel := logger.LevelInfo
-	switch cfg.App.LogLevel {
-	case "debug":
-		logLevel = logger.LevelDebug
-	case "warn":
-		logLevel = logger.LevelWarn
-	case "error":
-		logLevel = logger.LevelError
-	}
+	logLevel := parseLogLevel(cfg.App.LogLevel)
 
 	var log *logger.Logger
 	if tuiMode {
@@ -104,11 +128,14 @@ func run(ctx context.Context, configPath string, tuiMode bool) error {
 		log.Info(ctx, "starting CEX-DEX Arbitrage Bot",
 			"version", version,
 			"environment", cfg.App.Environment,
+			"chain_id", cfg.Ethereum.ChainID,
 		)
 	}
 
 	// Initialize observability if enabled
 	var traceProvider apm.TraceProvider
+	var meterProvider metrics.MetricProvider
+	var metricsServer *metrics.PrometheusServer
 	if cfg.Telemetry.Enabled {
 		// Set service name env var for OTEL
 		if cfg.Telemetry.ServiceName != "" {
@@ -118,13 +145,20 @@ func run(ctx context.Context, configPath string, tuiMode bool) error {
 			os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", cfg.Telemetry.OTLPEndpoint)
 		}
 
-		// Initialize tracing with Zipkin (local dev friendly)
-		traceProvider = apm.NewTraceProvider(log, apm.WithProvider(apm.ZipkinProvider, log))
-		log.Info(ctx, "tracing initialized", "provider", "zipkin", "endpoint", cfg.Telemetry.OTLPEndpoint)
+		// Initialize tracing (Zipkin by default, local dev friendly)
+		traceProvider = apm.NewTraceProvider(log,
+			apm.WithProvider(traceProviders[cfg.Telemetry.TraceExporter], log),
+			apm.WithSampleRatio(cfg.Telemetry.SampleRatio),
+		)
+		log.Info(ctx, "tracing initialized",
+			"provider", cfg.Telemetry.TraceExporter,
+			"endpoint", cfg.Telemetry.OTLPEndpoint,
+			"sample_ratio", cfg.Telemetry.SampleRatio)
 
 		// Initialize metrics with Prometheus
-		metrics.NewMetricProvider(
+		meterProvider = metrics.NewMetricProvider(
 			metrics.WithServiceName(cfg.Telemetry.ServiceName),
+			metrics.WithMetricPrefix(cfg.Telemetry.MetricPrefix),
 			metrics.WithProviderConfig(metrics.ProviderCfg{
 				Provider: metrics.PrometheusProvider,
 			}),
@@ -135,26 +169,65 @@ func run(ctx context.Context, configPath string, tuiMode bool) error {
 		if port == 0 {
 			port = 9090
 		}
-		go metrics.ServePrometheusMetrics(metrics.WithPort(strconv.Itoa(port)))
+		// A taken port would silently leave Prometheus with nothing to scrape
+		metricsServer = metrics.NewPrometheusServer(port)
+		if err := metricsServer.Start(); err != nil {
+			return fmt.Errorf("failed to start metrics server: %w", err)
+		}
 		log.Info(ctx, "prometheus metrics server started", "port", port)
+
+		// Goroutines, heap and GC pauses alongside the bot's own metrics
+		if err := metrics.RegisterRuntimeMetrics(); err != nil {
+			log.Warn(ctx, "failed to register runtime metrics", "error", err)
+		}
 	}
+	// Flush spans and metrics last, after the modules have shut down
 	defer func() {
 		if traceProvider != nil {
 			traceProvider.Stop()
 		}
+		flushCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if metricsServer != nil {
+			// Let in-flight scrapes finish before the provider shuts down
+			if err := metricsServer.Stop(flushCtx); err != nil {
+				log.Warn(flushCtx, "failed to stop metrics server", "error", err)
+			}
+		}
+		if meterProvider != nil {
+			meterProvider.Shutdown(flushCtx)
+		}
 	}()
 
+	// Expose profiling endpoints only when asked for
+	if cfg.App.PprofPort > 0 {
+		pprofServer := metrics.NewPprofServer(cfg.App.PprofPort)
+		if err := pprofServer.Start(); err != nil {
+			log.Warn(ctx, "failed to start pprof server", "error", err)
+		} else {
+			log.Info(ctx, "pprof server started", "port", cfg.App.PprofPort)
+		}
+		defer pprofServer.Stop(ctx)
+	}
+
 	// Start health check server on port 8081
+	// Probes depend on it, so a taken port is fatal rather than silent
 	healthServer := health.NewServer(8081, version)
 	if err := healthServer.Start(); err != nil {
-		log.Warn(ctx, "failed to start health server", "error", err)
-	} else {
-		log.Info(ctx, "health server started", "port", 8081)
+		return fmt.Errorf("failed to start health server: %w", err)
 	}
-	defer healthServer.Stop(ctx)
+	log.Info(ctx, "health server started", "port", 8081)
+	// ctx is already cancelled on shutdown; drain in-flight probes on a fresh one
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := healthServer.Stop(stopCtx); err != nil {
+			log.Warn(stopCtx, "failed to stop health server", "error", err)
+		}
+	}()
 
 	// Create monolith (application container)
-	mono, err := monolith.New(cfg, log)
+	mono, err := monolith.New(cfg, log, monolith.WithHealthServer(healthServer))
 	if err != nil {
 		return fmt.Errorf("failed to create monolith: %w", err)
 	}
@@ -172,6 +245,51 @@ func run(ctx context.Context, configPath string, tuiMode bool) error {
 		return fmt.Errorf("failed to register modules: %w", err)
 	}
 
+	// Serve the REST API from the reporter's state
+	if cfg.API.Enabled {
+		apiServer := apiserver.NewServer(cfg.API.Port, arbitrageDI.GetMarketState(mono.Services()))
+		if err := apiServer.Start(); err != nil {
+			log.Warn(ctx, "failed to start api server", "error", err)
+		} else {
+			log.Info(ctx, "api server started", "port", cfg.API.Port)
+		}
+		defer apiServer.Stop(ctx)
+	}
+
+	// Dump live provider state for troubleshooting
+	if cfg.App.DebugPort > 0 {
+		debugServer := debugserver.NewServer(cfg.App.DebugPort, pricingDI.GetDebugState(mono.Services()))
+		if err := debugServer.Start(); err != nil {
+			log.Warn(ctx, "failed to start debug server", "error", err)
+		} else {
+			log.Info(ctx, "debug server started", "port", cfg.App.DebugPort)
+		}
+		defer debugServer.Stop(ctx)
+	}
+
+	// Stream opportunities to gRPC clients
+	if cfg.GRPC.Enabled {
+		grpcServer := grpcserver.NewServer(cfg.GRPC.Port, arbitrageDI.GetOpportunityStream(mono.Services()))
+		if err := grpcServer.Start(); err != nil {
+			log.Warn(ctx, "failed to start grpc server", "error", err)
+		} else {
+			log.Info(ctx, "grpc server started", "port", cfg.GRPC.Port)
+		}
+		defer grpcServer.Stop(ctx)
+	}
+
+	// Hot-reload detection settings on SIGHUP (kill -HUP <pid>)
+	watcher := config.NewWatcher(configPath, cfg, log)
+	watcher.OnReload(func(newCfg *config.Config) {
+		arbitrage.ApplyConfig(mono.Services(), newCfg)
+	})
+	watcher.Start(ctx)
+
+	// A safety halt with arbitrage.safety.exit_on_halt, or the node lost for
+	// good, cancels ctx with the error as cause
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
 	if tuiMode {
 		// TUI mode: Start modules in background so TUI shows immediately
 		startFunc := func() error {
@@ -179,13 +297,24 @@ func run(ctx context.Context, configPath string, tuiMode bool) error {
 				return fmt.Errorf("failed to start modules: %w", err)
 			}
 			detector := arbitrageDI.GetDetector(mono.Services())
+			if cfg.Arbitrage.Safety.ExitOnHalt {
+				exitOnSafetyHalt(detector, cancel)
+			}
+			exitOnNodeLoss(ctx, blockchainDI.GetBlockchainService(mono.Services()), cancel)
 			return detector.Start(ctx)
 		}
 		stopFunc := func() {
-			detector := arbitrageDI.GetDetector(mono.Services())
-			detector.Stop()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			arbitrageDI.GetDetector(mono.Services()).Shutdown(shutdownCtx)
 		}
-		return runTUI(ctx, startFunc, stopFunc)
+		ui.OnPauseToggled = func(paused bool) {
+			arbitrageDI.GetDetector(mono.Services()).SetPaused(paused)
+		}
+		return haltCause(ctx, runTUI(ctx, startFunc, stopFunc,
+			ui.WithOpportunityTTL(cfg.UI.OpportunityTTL, cfg.UI.RemoveExpired),
+			ui.WithDisplayPrecision(int32(cfg.UI.DisplayPrecision)),
+			ui.WithCEXName(pricingApp.CEXDisplayName(cfg.CEXProviderNames()))))
 	}
 
 	// CLI mode: Start modules synchronously
@@ -195,7 +324,94 @@ func run(ctx context.Context, configPath string, tuiMode bool) error {
 
 	// Get detector
 	detector := arbitrageDI.GetDetector(mono.Services())
-	return runCLI(ctx, detector, log)
+	if cfg.Arbitrage.Safety.ExitOnHalt {
+		exitOnSafetyHalt(detector, cancel)
+	}
+	exitOnNodeLoss(ctx, blockchainDI.GetBlockchainService(mono.Services()), cancel)
+	return haltCause(ctx, runCLI(ctx, detector, log))
+}
+
+// exitOnSafetyHalt shuts the bot down when a safety condition halts detection.
+func exitOnSafetyHalt(detector *arbitrageApp.Detector, cancel context.CancelCauseFunc) {
+	detector.OnSafetyHalt(func(event *arbitrageDomain.SafetyEvent) {
+		cancel(apperror.New(apperror.CodeSafetyHalt, apperror.WithContext(event.String())))
+		if ui.Program != nil {
+			ui.Program.Quit()
+		}
+	})
+}
+
+// exitOnNodeLoss shuts the bot down once the block subscriber gives up
+// reconnecting (ethereum.max_reconnects), so an orchestrator can restart it
+// instead of the bot running on without blocks.
+func exitOnNodeLoss(ctx context.Context, blockchainService *blockchainApp.BlockchainService, cancel context.CancelCauseFunc) {
+	failures := blockchainService.SubscribeFailures()
+	if failures == nil {
+		return
+	}
+	go func() {
+		select {
+		case err := <-failures:
+			cancel(err)
+			if ui.Program != nil {
+				ui.Program.Quit()
+			}
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// haltCause returns the safety halt or lost node that shut the bot down in
+// place of a clean exit, so the process exits non-zero.
+func haltCause(ctx context.Context, err error) error {
+	if err != nil {
+		return err
+	}
+	switch cause := context.Cause(ctx); apperror.GetCode(cause) {
+	case apperror.CodeSafetyHalt, apperror.CodeEthereumSubscribeFailed, apperror.CodeEthereumConnectionFailed:
+		return cause
+	}
+	return nil
+}
+
+// runBacktest replays recorded blocks, depth snapshots and quotes from dir
+// through the detector and prints a summary of the opportunities found.
+func runBacktest(ctx context.Context, configPath, chain, dir string) error {
+	cfg, err := config.LoadChain(configPath, chain)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	log := logger.New(os.Stderr, parseLogLevel(cfg.App.LogLevel), cfg.App.Name, nil)
+	log.Info(ctx, "starting backtest", "dir", dir)
+
+	summary, err := arbitrage.RunBacktest(ctx, cfg, dir, log)
+	if err != nil {
+		return fmt.Errorf("backtest failed: %w", err)
+	}
+
+	fmt.Println()
+	return summary.Write(os.Stdout)
+}
+
+// traceProviders maps telemetry.trace_exporter to the APM trace provider.
+var traceProviders = map[string]apm.Provider{
+	config.TraceExporterZipkin:  apm.ZipkinProvider,
+	config.TraceExporterJaeger:  apm.JaegerProvider,
+	config.TraceExporterConsole: apm.ConsoleProvider,
+}
+
+// parseLogLevel maps the configured log level name to a logger level (default info).
+func parseLogLevel(level string) logger.Level {
+	switch level {
+	case "debug":
+		return logger.LevelDebug
+	case "warn":
+		return logger.LevelWarn
+	case "error":
+		return logger.LevelError
+	}
+	return logger.LevelInfo
 }
 
 func runCLI(ctx context.Context, detector *arbitrageApp.Detector, log *logger.Logger) error {
@@ -211,15 +427,17 @@ func runCLI(ctx context.Context, detector *arbitrageApp.Detector, log *logger.Lo
 
 	log.Info(ctx, "shutting down")
 
-	// Stop detector gracefully
-	if err := detector.Stop(); err != nil {
+	// Drain the detector: finish the current analysis and flush reporters
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := detector.Shutdown(shutdownCtx); err != nil {
 		log.Error(ctx, "error stopping detector", "error", err)
 	}
 
 	return nil
 }
 
-func runTUI(ctx context.Context, startFunc func() error, stopFunc func()) error {
+func runTUI(ctx context.Context, startFunc func() error, stopFunc func(), opts ...ui.Option) error {
 	// Channel to receive StartModulesMsg signal
 	startSignal := make(chan struct{}, 1)
 	ui.OnStartModules = func() {
@@ -230,7 +448,7 @@ func runTUI(ctx context.Context, startFunc func() error, stopFunc func()) error
 	}
 
 	// Create and start the TUI program IMMEDIATELY (shows welcome screen)
-	p := tea.NewProgram(ui.New(), tea.WithAltScreen())
+	p := tea.NewProgram(ui.New(opts...), tea.WithAltScreen())
 	ui.Program = p
 
 	// Run bot logic in background (non-blocking)
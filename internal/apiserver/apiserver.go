@@ -0,0 +1,156 @@
+// Package apiserver provides a read-only HTTP API over the bot's live state.
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Opportunity limits for GET /api/opportunities.
+const (
+	DefaultOpportunityLimit = 20
+	MaxOpportunityLimit     = 100
+)
+
+// Price is the latest price snapshot for a pair.
+type Price struct {
+	Pair         string    `json:"pair"`
+	CEXVenue     string    `json:"cex_venue,omitempty"`
+	CEXBid       string    `json:"cex_bid,omitempty"`
+	CEXAsk       string    `json:"cex_ask,omitempty"`
+	DEXPrice     string    `json:"dex_price,omitempty"` // Effective price of the DEX quote
+	DEXFeeTier   int       `json:"dex_fee_tier,omitempty"`
+	DEXProtocol  string    `json:"dex_protocol,omitempty"`
+	GasPriceGwei float64   `json:"gas_price_gwei,omitempty"`
+	BlockNumber  uint64    `json:"block"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// Opportunity is a detected arbitrage opportunity.
+type Opportunity struct {
+	ID             string    `json:"id"`
+	Pair           string    `json:"pair"`
+	Direction      string    `json:"direction"`
+	TradeSize      string    `json:"trade_size"`
+	TradeSizeUSD   string    `json:"trade_size_usd,omitempty"` // Configured USD notional, when sized in USD
+	CEXVenue       string    `json:"cex_venue,omitempty"`
+	CEXPrice       string    `json:"cex_price"`
+	DEXPrice       string    `json:"dex_price"`
+	SpreadBps      string    `json:"spread_bps"`
+	GrossProfitUSD string    `json:"gross_profit_usd"`
+	GasCostUSD     string    `json:"gas_cost_usd"`
+	FeesUSD        string    `json:"fees_usd"`
+	NetProfitUSD   string    `json:"net_profit_usd"`
+	Profitable     bool      `json:"profitable"`
+	BlockNumber    uint64    `json:"block"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// Connection is the last reported state of a data source.
+type Connection struct {
+	Name      string    `json:"name"`
+	Connected bool      `json:"connected"`
+	LatencyMs float64   `json:"latency_ms"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Status summarizes connection states and chain progress.
+type Status struct {
+	BlockNumber  uint64       `json:"block"`
+	GasPriceGwei float64      `json:"gas_price_gwei"`
+	Connections  []Connection `json:"connections"`
+	Timestamp    time.Time    `json:"timestamp"`
+}
+
+// StateSource supplies the state served by the API. Implementations must be
+// safe for concurrent use.
+type StateSource interface {
+	Prices() []Price
+	Opportunities(limit int) []Opportunity // Newest first
+	Status() Status
+}
+
+// Server serves the REST API.
+type Server struct {
+	port   int
+	source StateSource
+	server *http.Server
+}
+
+// NewServer creates a new API server reading from source.
+func NewServer(port int, source StateSource) *Server {
+	return &Server{
+		port:   port,
+		source: source,
+	}
+}
+
+// Handler returns the API routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/prices", s.handlePrices)
+	mux.HandleFunc("GET /api/opportunities", s.handleOpportunities)
+	mux.HandleFunc("GET /api/status", s.handleStatus)
+	return mux
+}
+
+// Start starts the API server in the background.
+func (s *Server) Start() error {
+	s.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			// Log error but don't crash - the API is optional
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully stops the API server.
+func (s *Server) Stop(ctx context.Context) error {
+	if s.server != nil {
+		return s.server.Shutdown(ctx)
+	}
+	return nil
+}
+
+// handlePrices returns the latest snapshot per pair.
+func (s *Server) handlePrices(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"prices": s.source.Prices()})
+}
+
+// handleOpportunities returns the most recent opportunities (?limit=N).
+func (s *Server) handleOpportunities(w http.ResponseWriter, r *http.Request) {
+	limit := DefaultOpportunityLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = min(n, MaxOpportunityLimit)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"opportunities": s.source.Opportunities(limit)})
+}
+
+// handleStatus returns connection states.
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.source.Status())
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	// Headers must be set before WriteHeader
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
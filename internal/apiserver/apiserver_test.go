@@ -0,0 +1,97 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeSource serves fixed state and records the requested limit.
+type fakeSource struct {
+	opportunities []Opportunity
+	lastLimit     int
+}
+
+func (f *fakeSource) Prices() []Price {
+	return []Price{{Pair: "ETH-USDC", CEXBid: "3000", CEXAsk: "3001", BlockNumber: 100}}
+}
+
+func (f *fakeSource) Opportunities(limit int) []Opportunity {
+	f.lastLimit = limit
+	return f.opportunities[:min(limit, len(f.opportunities))]
+}
+
+func (f *fakeSource) Status() Status {
+	return Status{BlockNumber: 100, Connections: []Connection{{Name: "Binance", Connected: true}}}
+}
+
+func TestServer_Opportunities_Limit(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantLimit int
+	}{
+		{name: "default", query: "", wantCode: http.StatusOK, wantLimit: DefaultOpportunityLimit},
+		{name: "explicit", query: "?limit=5", wantCode: http.StatusOK, wantLimit: 5},
+		{name: "capped", query: "?limit=1000", wantCode: http.StatusOK, wantLimit: MaxOpportunityLimit},
+		{name: "zero", query: "?limit=0", wantCode: http.StatusBadRequest},
+		{name: "not_a_number", query: "?limit=abc", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &fakeSource{opportunities: []Opportunity{{ID: "a"}, {ID: "b"}}}
+			rec := httptest.NewRecorder()
+			NewServer(0, source).Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/opportunities"+tt.query, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, rec.Code)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if source.lastLimit != tt.wantLimit {
+				t.Errorf("expected limit %d, got %d", tt.wantLimit, source.lastLimit)
+			}
+			var body struct {
+				Opportunities []Opportunity `json:"opportunities"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(body.Opportunities) != min(tt.wantLimit, 2) {
+				t.Errorf("expected %d opportunities, got %d", min(tt.wantLimit, 2), len(body.Opportunities))
+			}
+		})
+	}
+}
+
+func TestServer_Routes(t *testing.T) {
+	handler := NewServer(0, &fakeSource{}).Handler()
+
+	tests := []struct {
+		method   string
+		path     string
+		wantCode int
+	}{
+		{method: http.MethodGet, path: "/api/prices", wantCode: http.StatusOK},
+		{method: http.MethodGet, path: "/api/status", wantCode: http.StatusOK},
+		{method: http.MethodPost, path: "/api/prices", wantCode: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: "/api/unknown", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, rec.Code)
+			}
+			if tt.wantCode == http.StatusOK && rec.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("expected application/json, got %q", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -42,10 +42,32 @@ type TracerOptions struct {
 	exporter           sdktrace.SpanExporter
 	tracerProviderName string
 	useEmpty           bool
+	sampler            sdktrace.Sampler
 }
 
 type TracerOption func(*TracerOptions)
 
+// WithSampleRatio records the given fraction of new traces (1 or more =
+// every trace, 0 or less = none). Spans in a trace started elsewhere follow
+// the parent's sampling decision.
+func WithSampleRatio(ratio float64) TracerOption {
+	return func(option *TracerOptions) {
+		option.sampler = sampler(ratio)
+	}
+}
+
+// sampler returns the root sampler for ratio, wrapped to honor parent spans.
+func sampler(ratio float64) sdktrace.Sampler {
+	switch {
+	case ratio >= 1:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+	case ratio <= 0:
+		return sdktrace.ParentBased(sdktrace.NeverSample())
+	default:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+	}
+}
+
 func WithProvider(provider Provider, log logger.LoggerInterface) TracerOption {
 	if provider == NewRelicProvider {
 		return useNewRelic(log)
@@ -63,6 +85,10 @@ func WithProvider(provider Provider, log logger.LoggerInterface) TracerOption {
 		return useHoneycomb(log)
 	}
 
+	if provider == JaegerProvider {
+		return useJaeger(log)
+	}
+
 	log.Warn(context.Background(), "TracerProvider not found, using EmptyProvider")
 
 	return useEmpty()
@@ -101,6 +127,44 @@ func useZipkin(log logger.LoggerInterface) TracerOption {
 	}
 }
 
+// jaegerDefaultEndpoint is the OTLP/HTTP receiver of a local Jaeger collector.
+const jaegerDefaultEndpoint = "http://localhost:4318"
+
+// useJaeger exports to a Jaeger collector over OTLP, which Jaeger ingests
+// natively. OTEL_EXPORTER_OTLP_ENDPOINT overrides the local collector, and
+// OTEL_EXPORTER_OTLP_PROTOCOL=grpc switches from HTTP to gRPC (port 4317).
+func useJaeger(log logger.LoggerInterface) TracerOption {
+	return func(option *TracerOptions) {
+		url := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if url == "" {
+			url = jaegerDefaultEndpoint
+		}
+		// A local collector is plain HTTP unless told otherwise
+		if !strings.Contains(url, "://") {
+			url = "http://" + url
+		}
+
+		var exp sdktrace.SpanExporter
+		var err error
+
+		if os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL") == "grpc" {
+			log.Info(context.Background(), "Initializing Jaeger with gRPC exporter", "endpoint", url)
+			exp, err = otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpointURL(url))
+		} else {
+			log.Info(context.Background(), "Initializing Jaeger with HTTP/Protobuf exporter", "endpoint", url)
+			exp, err = otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(url))
+		}
+
+		if err != nil {
+			log.Error(context.Background(), "Error initializing Jaeger exporter", "error", err)
+			panic(err)
+		}
+
+		option.exporter = exp
+		option.tracerProviderName = string(JaegerProvider)
+	}
+}
+
 func useNewRelic(log logger.LoggerInterface) TracerOption {
 	return func(option *TracerOptions) {
 		headers := os.Getenv("OTEL_EXPORTER_OTLP_HEADERS_KEY")
@@ -184,7 +248,7 @@ func NewTraceProvider(log logger.LoggerInterface, options ...TracerOption) Trace
 		options = []TracerOption{useHoneycomb(log)}
 	}
 
-	opts := &TracerOptions{}
+	opts := &TracerOptions{sampler: sdktrace.AlwaysSample()}
 
 	for _, opt := range options {
 		opt(opts)
@@ -205,7 +269,7 @@ func NewTraceProvider(log logger.LoggerInterface, options ...TracerOption) Trace
 		))
 
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(opts.sampler),
 		sdktrace.WithBatcher(exp),
 		sdktrace.WithResource(rsrc),
 	)
@@ -15,6 +15,9 @@ const (
 
 	// Configuration
 	CodeConfigurationError Code = "CONFIGURATION_ERROR"
+	CodeInvalidEndpointURL Code = "INVALID_ENDPOINT_URL"
+	CodeInvalidChainID     Code = "INVALID_CHAIN_ID"
+	CodeInvalidSymbol      Code = "INVALID_SYMBOL"
 
 	// External service errors
 	CodeExternalServiceError Code = "EXTERNAL_SERVICE_ERROR"
@@ -46,20 +49,31 @@ const (
 	CodeBinanceConnectionFailed Code = "BINANCE_CONNECTION_FAILED"
 	CodeBinanceAPIError         Code = "BINANCE_API_ERROR"
 	CodeBinanceRateLimited      Code = "BINANCE_RATE_LIMITED"
+	CodeBinanceClockSkew        Code = "BINANCE_CLOCK_SKEW"
 	CodeOrderbookFetchFailed    Code = "ORDERBOOK_FETCH_FAILED"
 	CodeInvalidOrderbook        Code = "INVALID_ORDERBOOK"
 
+	// CEX (Coinbase) errors
+	CodeCoinbaseConnectionFailed Code = "COINBASE_CONNECTION_FAILED"
+	CodeCoinbaseAPIError         Code = "COINBASE_API_ERROR"
+
+	// CEX (Kraken) errors
+	CodeKrakenConnectionFailed Code = "KRAKEN_CONNECTION_FAILED"
+	CodeKrakenAPIError         Code = "KRAKEN_API_ERROR"
+
 	// DEX (Uniswap) errors
-	CodeUniswapQuoteFailed  Code = "UNISWAP_QUOTE_FAILED"
-	CodeUniswapPoolNotFound Code = "UNISWAP_POOL_NOT_FOUND"
-	CodeInvalidQuote        Code = "INVALID_QUOTE"
-	CodeContractCallFailed  Code = "CONTRACT_CALL_FAILED"
+	CodeUniswapQuoteFailed         Code = "UNISWAP_QUOTE_FAILED"
+	CodeUniswapPoolNotFound        Code = "UNISWAP_POOL_NOT_FOUND"
+	CodeUniswapPriceImpactExceeded Code = "UNISWAP_PRICE_IMPACT_EXCEEDED"
+	CodeInvalidQuote               Code = "INVALID_QUOTE"
+	CodeContractCallFailed         Code = "CONTRACT_CALL_FAILED"
 
 	// Arbitrage detection errors
 	CodePriceCalculationFailed Code = "PRICE_CALCULATION_FAILED"
 	CodeSpreadCalculationError Code = "SPREAD_CALCULATION_ERROR"
 	CodeInsufficientLiquidity  Code = "INSUFFICIENT_LIQUIDITY"
 	CodeInvalidTradeSize       Code = "INVALID_TRADE_SIZE"
+	CodeSafetyHalt             Code = "SAFETY_HALT"
 
 	// Cache errors
 	CodeCacheMiss    Code = "CACHE_MISS"
@@ -68,4 +82,12 @@ const (
 	// Circuit breaker errors
 	CodeCircuitOpen     Code = "CIRCUIT_OPEN"
 	CodeCircuitHalfOpen Code = "CIRCUIT_HALF_OPEN"
+
+	// Storage errors
+	CodeStorageOpenFailed  Code = "STORAGE_OPEN_FAILED"
+	CodeStorageWriteFailed Code = "STORAGE_WRITE_FAILED"
+	CodeStorageQueryFailed Code = "STORAGE_QUERY_FAILED"
+
+	// Alerting errors
+	CodeAlertDeliveryFailed Code = "ALERT_DELIVERY_FAILED"
 )
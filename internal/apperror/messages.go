@@ -12,6 +12,9 @@ var messages = map[Code]string{
 
 	// Configuration
 	CodeConfigurationError: "Configuration error",
+	CodeInvalidEndpointURL: "Invalid endpoint URL",
+	CodeInvalidChainID:     "Invalid chain ID",
+	CodeInvalidSymbol:      "Invalid trading symbol",
 
 	// External service errors
 	CodeExternalServiceError: "External service error",
@@ -40,20 +43,31 @@ var messages = map[Code]string{
 	CodeBinanceConnectionFailed: "Failed to connect to Binance API",
 	CodeBinanceAPIError:         "Binance API error",
 	CodeBinanceRateLimited:      "Binance rate limit exceeded",
+	CodeBinanceClockSkew:        "Local clock is out of sync with Binance",
 	CodeOrderbookFetchFailed:    "Failed to fetch orderbook",
 	CodeInvalidOrderbook:        "Invalid orderbook data",
 
+	// CEX (Coinbase) errors
+	CodeCoinbaseConnectionFailed: "Failed to connect to Coinbase API",
+	CodeCoinbaseAPIError:         "Coinbase API error",
+
+	// CEX (Kraken) errors
+	CodeKrakenConnectionFailed: "Failed to connect to Kraken API",
+	CodeKrakenAPIError:         "Kraken API error",
+
 	// DEX (Uniswap) errors
-	CodeUniswapQuoteFailed:  "Failed to get Uniswap quote",
-	CodeUniswapPoolNotFound: "Uniswap pool not found",
-	CodeInvalidQuote:        "Invalid quote data",
-	CodeContractCallFailed:  "Smart contract call failed",
+	CodeUniswapQuoteFailed:         "Failed to get Uniswap quote",
+	CodeUniswapPoolNotFound:        "Uniswap pool not found",
+	CodeUniswapPriceImpactExceeded: "Uniswap quote exceeds the maximum price impact",
+	CodeInvalidQuote:               "Invalid quote data",
+	CodeContractCallFailed:         "Smart contract call failed",
 
 	// Arbitrage detection errors
 	CodePriceCalculationFailed: "Price calculation failed",
 	CodeSpreadCalculationError: "Spread calculation error",
 	CodeInsufficientLiquidity:  "Insufficient liquidity for trade size",
 	CodeInvalidTradeSize:       "Invalid trade size",
+	CodeSafetyHalt:             "Detection halted by a safety check",
 
 	// Cache errors
 	CodeCacheMiss:    "Cache miss",
@@ -62,4 +76,12 @@ var messages = map[Code]string{
 	// Circuit breaker errors
 	CodeCircuitOpen:     "Circuit breaker is open",
 	CodeCircuitHalfOpen: "Circuit breaker is half-open",
+
+	// Storage errors
+	CodeStorageOpenFailed:  "Failed to open storage",
+	CodeStorageWriteFailed: "Failed to write to storage",
+	CodeStorageQueryFailed: "Failed to query storage",
+
+	// Alerting errors
+	CodeAlertDeliveryFailed: "Failed to deliver alert",
 }
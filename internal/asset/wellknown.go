@@ -27,6 +27,20 @@ var (
 	AddrWBTCEthereum = common.HexToAddress("0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599")
 )
 
+// Well-known token addresses on Arbitrum One
+var (
+	AddrUSDCArbitrum = common.HexToAddress("0xaf88d065e77c8cC2239327C5EDb3A432268e5831") // Native USDC
+	AddrUSDTArbitrum = common.HexToAddress("0xFd086bC7CD5C481DCC9C85ebE478A1C0b69FCbb9")
+	AddrWETHArbitrum = common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1")
+	AddrWBTCArbitrum = common.HexToAddress("0x2f2a2543B76A4166549F7aaB2e75Bef0aefC5B0f")
+)
+
+// Well-known token addresses on Base
+var (
+	AddrUSDCBase = common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913") // Native USDC
+	AddrWETHBase = common.HexToAddress("0x4200000000000000000000000000000000000006")
+)
+
 // Well-known AssetIDs
 var (
 	// Ethereum Mainnet
@@ -36,6 +50,18 @@ var (
 	IDEthereumWETH = NewTokenAssetID(ChainIDEthereum, AddrWETHEthereum)
 	IDEthereumWBTC = NewTokenAssetID(ChainIDEthereum, AddrWBTCEthereum)
 
+	// Arbitrum One
+	IDArbitrumETH  = NewNativeAssetID(ChainIDArbitrum)
+	IDArbitrumUSDC = NewTokenAssetID(ChainIDArbitrum, AddrUSDCArbitrum)
+	IDArbitrumUSDT = NewTokenAssetID(ChainIDArbitrum, AddrUSDTArbitrum)
+	IDArbitrumWETH = NewTokenAssetID(ChainIDArbitrum, AddrWETHArbitrum)
+	IDArbitrumWBTC = NewTokenAssetID(ChainIDArbitrum, AddrWBTCArbitrum)
+
+	// Base
+	IDBaseETH  = NewNativeAssetID(ChainIDBase)
+	IDBaseUSDC = NewTokenAssetID(ChainIDBase, AddrUSDCBase)
+	IDBaseWETH = NewTokenAssetID(ChainIDBase, AddrWETHBase)
+
 	// Fiat
 	IDUSD = NewFiatAssetID("USD")
 	IDEUR = NewFiatAssetID("EUR")
@@ -51,12 +77,66 @@ var (
 	WETH = NewAssetWithName(IDEthereumWETH, "WETH", "Wrapped Ether", 18)
 	WBTC = NewAssetWithName(IDEthereumWBTC, "WBTC", "Wrapped Bitcoin", 8)
 
+	// Arbitrum One (same symbols as mainnet; resolve them by chain)
+	ETHArbitrum  = NewAssetWithName(IDArbitrumETH, "ETH", "Ethereum", 18)
+	USDCArbitrum = NewAssetWithName(IDArbitrumUSDC, "USDC", "USD Coin", 6)
+	USDTArbitrum = NewAssetWithName(IDArbitrumUSDT, "USDT", "Tether USD", 6)
+	WETHArbitrum = NewAssetWithName(IDArbitrumWETH, "WETH", "Wrapped Ether", 18)
+	WBTCArbitrum = NewAssetWithName(IDArbitrumWBTC, "WBTC", "Wrapped Bitcoin", 8)
+
+	// Base
+	ETHBase  = NewAssetWithName(IDBaseETH, "ETH", "Ethereum", 18)
+	USDCBase = NewAssetWithName(IDBaseUSDC, "USDC", "USD Coin", 6)
+	WETHBase = NewAssetWithName(IDBaseWETH, "WETH", "Wrapped Ether", 18)
+
 	// Fiat
 	USD = NewAssetWithName(IDUSD, "USD", "US Dollar", 2)
 	EUR = NewAssetWithName(IDEUR, "EUR", "Euro", 2)
 	ARS = NewAssetWithName(IDARS, "ARS", "Argentine Peso", 2)
 )
 
+// wrappedNatives maps a chain ID to the ERC20 wrapper of its native coin.
+var wrappedNatives = map[uint64]*Asset{
+	ChainIDEthereum: WETH,
+	ChainIDArbitrum: WETHArbitrum,
+	ChainIDBase:     WETHBase,
+}
+
+// WrappedNative returns the ERC20 wrapper of a chain's native coin (WETH for ETH).
+func WrappedNative(chainID uint64) (*Asset, bool) {
+	a, ok := wrappedNatives[chainID]
+	return a, ok
+}
+
+// IsWrappedNative reports whether a is the ERC20 wrapper of its chain's native coin.
+func IsWrappedNative(a *Asset) bool {
+	if a == nil || !a.IsToken() {
+		return false
+	}
+	wrapped, ok := wrappedNatives[a.ChainID()]
+	return ok && wrapped.Equals(a)
+}
+
+// DEXAsset returns the asset DEX pools trade in place of a: the wrapped token
+// for a native coin, a itself otherwise. Pools never hold native ETH.
+func DEXAsset(a *Asset) *Asset {
+	if a != nil && a.IsNative() {
+		if wrapped, ok := wrappedNatives[a.ChainID()]; ok {
+			return wrapped
+		}
+	}
+	return a
+}
+
+// SameUnderlying reports whether a and b are the same asset, or a native coin
+// and its wrapper, which convert 1:1 through a wrap or unwrap.
+func SameUnderlying(a, b *Asset) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	return DEXAsset(a).Equals(DEXAsset(b))
+}
+
 // DefaultRegistry returns a registry pre-populated with well-known assets.
 func DefaultRegistry() *Registry {
 	r := NewRegistry()
@@ -68,6 +148,18 @@ func DefaultRegistry() *Registry {
 	r.Register(WETH)
 	r.Register(WBTC)
 
+	// Arbitrum One
+	r.Register(ETHArbitrum)
+	r.Register(USDCArbitrum)
+	r.Register(USDTArbitrum)
+	r.Register(WETHArbitrum)
+	r.Register(WBTCArbitrum)
+
+	// Base
+	r.Register(ETHBase)
+	r.Register(USDCBase)
+	r.Register(WETHBase)
+
 	// Fiat
 	r.Register(USD)
 	r.Register(EUR)
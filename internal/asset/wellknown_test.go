@@ -0,0 +1,42 @@
+package asset_test
+
+import (
+	"testing"
+
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+)
+
+func TestWrappedNative(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           *asset.Asset
+		wantWrapped bool
+		wantDEX     *asset.Asset
+	}{
+		{name: "ETH", a: asset.ETH, wantWrapped: false, wantDEX: asset.WETH},
+		{name: "WETH", a: asset.WETH, wantWrapped: true, wantDEX: asset.WETH},
+		{name: "USDC", a: asset.USDC, wantWrapped: false, wantDEX: asset.USDC},
+		{name: "USD", a: asset.USD, wantWrapped: false, wantDEX: asset.USD},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := asset.IsWrappedNative(tt.a); got != tt.wantWrapped {
+				t.Errorf("IsWrappedNative() = %v, want %v", got, tt.wantWrapped)
+			}
+			if got := asset.DEXAsset(tt.a); !got.Equals(tt.wantDEX) {
+				t.Errorf("DEXAsset() = %s, want %s", got, tt.wantDEX)
+			}
+		})
+	}
+
+	if !asset.SameUnderlying(asset.ETH, asset.WETH) {
+		t.Error("expected ETH and WETH to share an underlying")
+	}
+	if asset.SameUnderlying(asset.ETH, asset.WBTC) {
+		t.Error("expected ETH and WBTC to differ")
+	}
+	if wrapped, ok := asset.WrappedNative(asset.ChainIDEthereum); !ok || !wrapped.Equals(asset.WETH) {
+		t.Errorf("WrappedNative(mainnet) = %v, %v; want WETH", wrapped, ok)
+	}
+}
@@ -2,6 +2,7 @@
 package cache
 
 import (
+	"container/list"
 	"context"
 	"sync"
 	"time"
@@ -14,11 +15,19 @@ type Cache[K comparable, V any] struct {
 	stopCh   chan struct{}
 	stats    Stats
 	statsMu  sync.RWMutex
+
+	// LRU bookkeeping for capped caches; lru is nil when unbounded.
+	// The front of lru is the most recently used key.
+	capacity int
+	lru      *list.List
+
+	metrics *cacheMetrics // nil for unnamed caches
 }
 
 type item[V any] struct {
 	value     V
 	expiresAt time.Time
+	elem      *list.Element // Position in lru (capped caches only)
 }
 
 // Stats holds cache statistics.
@@ -41,25 +50,54 @@ func New[K comparable, V any](cleanupInterval time.Duration) *Cache[K, V] {
 	return c
 }
 
+// NewWithCapacity creates a Cache holding at most capacity entries; adding
+// beyond that evicts the least recently used entry. Hits, misses and
+// evictions are exported as OTEL counters labeled with name. A capacity of
+// zero or less leaves the cache unbounded.
+func NewWithCapacity[K comparable, V any](name string, capacity int, cleanupInterval time.Duration) *Cache[K, V] {
+	c := &Cache[K, V]{
+		items:    make(map[K]*item[V]),
+		stopCh:   make(chan struct{}),
+		capacity: capacity,
+		metrics:  newCacheMetrics(name),
+	}
+	if capacity > 0 {
+		c.lru = list.New()
+	}
+
+	go c.cleanup(cleanupInterval)
+
+	return c
+}
+
 // Get retrieves a value from the cache.
 func (c *Cache[K, V]) Get(ctx context.Context, key K) (V, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	// A hit reorders the LRU list, so capped caches need the write lock
+	if c.lru != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	} else {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
 
 	it, ok := c.items[key]
 	if !ok {
-		c.recordMiss()
+		c.recordMiss(ctx)
 		var zero V
 		return zero, false
 	}
 
 	if time.Now().After(it.expiresAt) {
-		c.recordMiss()
+		c.recordMiss(ctx)
 		var zero V
 		return zero, false
 	}
 
-	c.recordHit()
+	if c.lru != nil {
+		c.lru.MoveToFront(it.elem)
+	}
+	c.recordHit(ctx)
 	return it.value, true
 }
 
@@ -68,12 +106,30 @@ func (c *Cache[K, V]) Set(ctx context.Context, key K, value V, ttl time.Duration
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.items[key] = &item[V]{
+	it := &item[V]{
 		value:     value,
 		expiresAt: time.Now().Add(ttl),
 	}
+	evicted := int64(0)
+	if c.lru != nil {
+		if old, ok := c.items[key]; ok {
+			c.lru.Remove(old.elem)
+		}
+		it.elem = c.lru.PushFront(key)
+		for c.lru.Len() > c.capacity {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.items, oldest.Value.(K))
+			evicted++
+		}
+	}
+	c.items[key] = it
+	if evicted > 0 {
+		c.metrics.recordEvictions(ctx, evicted, evictReasonCapacity)
+	}
 
 	c.statsMu.Lock()
+	c.stats.Evictions += evicted
 	c.stats.ItemCount = int64(len(c.items))
 	c.statsMu.Unlock()
 }
@@ -82,7 +138,34 @@ func (c *Cache[K, V]) Set(ctx context.Context, key K, value V, ttl time.Duration
 func (c *Cache[K, V]) Delete(ctx context.Context, key K) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if it, ok := c.items[key]; ok && c.lru != nil {
+		c.lru.Remove(it.elem)
+	}
 	delete(c.items, key)
+
+	c.statsMu.Lock()
+	c.stats.ItemCount = int64(len(c.items))
+	c.statsMu.Unlock()
+}
+
+// Clear removes all entries from the cache.
+func (c *Cache[K, V]) Clear(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	evicted := int64(len(c.items))
+	c.items = make(map[K]*item[V])
+	if c.lru != nil {
+		c.lru.Init()
+	}
+	if evicted > 0 {
+		c.metrics.recordEvictions(ctx, evicted, evictReasonCleared)
+	}
+
+	c.statsMu.Lock()
+	c.stats.Evictions += evicted
+	c.stats.ItemCount = 0
+	c.statsMu.Unlock()
 }
 
 // Stats returns current cache statistics.
@@ -119,10 +202,16 @@ func (c *Cache[K, V]) evictExpired() {
 	evicted := int64(0)
 	for key, it := range c.items {
 		if now.After(it.expiresAt) {
+			if c.lru != nil {
+				c.lru.Remove(it.elem)
+			}
 			delete(c.items, key)
 			evicted++
 		}
 	}
+	if evicted > 0 {
+		c.metrics.recordEvictions(context.Background(), evicted, evictReasonExpired)
+	}
 
 	c.statsMu.Lock()
 	c.stats.Evictions += evicted
@@ -130,14 +219,16 @@ func (c *Cache[K, V]) evictExpired() {
 	c.statsMu.Unlock()
 }
 
-func (c *Cache[K, V]) recordHit() {
+func (c *Cache[K, V]) recordHit(ctx context.Context) {
 	c.statsMu.Lock()
 	c.stats.Hits++
 	c.statsMu.Unlock()
+	c.metrics.recordHit(ctx)
 }
 
-func (c *Cache[K, V]) recordMiss() {
+func (c *Cache[K, V]) recordMiss(ctx context.Context) {
 	c.statsMu.Lock()
 	c.stats.Misses++
 	c.statsMu.Unlock()
+	c.metrics.recordMiss(ctx)
 }
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCache_CapacityEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewWithCapacity[string, int]("test", 2, time.Minute)
+	defer c.Close()
+
+	c.Set(ctx, "a", 1, time.Minute)
+	c.Set(ctx, "b", 2, time.Minute)
+
+	// Touch "a" so "b" becomes the least recently used
+	if _, ok := c.Get(ctx, "a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	c.Set(ctx, "c", 3, time.Minute)
+
+	if _, ok := c.Get(ctx, "b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(ctx, key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+
+	stats := c.Stats()
+	if stats.ItemCount != 2 || stats.Evictions != 1 {
+		t.Errorf("expected 2 items and 1 eviction, got %+v", stats)
+	}
+	if stats.Hits != 3 || stats.Misses != 1 {
+		t.Errorf("expected 3 hits and 1 miss, got %+v", stats)
+	}
+}
+
+func TestCache_OverwriteKeepsCapacity(t *testing.T) {
+	ctx := context.Background()
+	c := NewWithCapacity[string, int]("test", 2, time.Minute)
+	defer c.Close()
+
+	c.Set(ctx, "a", 1, time.Minute)
+	c.Set(ctx, "a", 2, time.Minute)
+	c.Set(ctx, "b", 3, time.Minute)
+
+	if v, ok := c.Get(ctx, "a"); !ok || v != 2 {
+		t.Errorf("expected a = 2, got %d (ok=%v)", v, ok)
+	}
+	if stats := c.Stats(); stats.ItemCount != 2 || stats.Evictions != 0 {
+		t.Errorf("expected 2 items and no evictions, got %+v", stats)
+	}
+}
+
+func TestCache_DeleteAndClear(t *testing.T) {
+	ctx := context.Background()
+	c := NewWithCapacity[string, int]("test", 2, time.Minute)
+	defer c.Close()
+
+	c.Set(ctx, "a", 1, time.Minute)
+	c.Set(ctx, "b", 2, time.Minute)
+	c.Delete(ctx, "a")
+
+	// The deleted key must not count against capacity
+	c.Set(ctx, "c", 3, time.Minute)
+	if _, ok := c.Get(ctx, "b"); !ok {
+		t.Error("expected b to survive after a was deleted")
+	}
+
+	c.Clear(ctx)
+	if stats := c.Stats(); stats.ItemCount != 0 {
+		t.Errorf("expected empty cache after Clear, got %d items", stats.ItemCount)
+	}
+	c.Set(ctx, "d", 4, time.Minute)
+	if _, ok := c.Get(ctx, "d"); !ok {
+		t.Error("expected d to be cached after Clear")
+	}
+}
+
+func TestCache_ExpiredEntriesEvicted(t *testing.T) {
+	ctx := context.Background()
+	c := NewWithCapacity[string, int]("test", 10, time.Hour)
+	defer c.Close()
+
+	c.Set(ctx, "a", 1, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	if _, ok := c.Get(ctx, "a"); ok {
+		t.Error("expected expired entry to miss")
+	}
+	c.evictExpired()
+	if stats := c.Stats(); stats.ItemCount != 0 || stats.Evictions != 1 {
+		t.Errorf("expected expired entry evicted, got %+v", stats)
+	}
+	if c.lru.Len() != 0 {
+		t.Errorf("expected empty LRU list, got %d", c.lru.Len())
+	}
+}
+
+// TestCache_ConcurrentAccess exercises the capped cache from many goroutines;
+// run with -race to check the locking.
+func TestCache_ConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	const capacity = 32
+	c := NewWithCapacity[string, int]("test", capacity, time.Minute)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				key := fmt.Sprintf("k%d", (g*500+i)%100)
+				c.Set(ctx, key, i, time.Minute)
+				c.Get(ctx, key)
+				if i%50 == 0 {
+					c.Delete(ctx, key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stats := c.Stats(); stats.ItemCount > capacity {
+		t.Errorf("expected at most %d items, got %d", capacity, stats.ItemCount)
+	}
+	if c.lru.Len() != len(c.items) {
+		t.Errorf("LRU list (%d) out of sync with items (%d)", c.lru.Len(), len(c.items))
+	}
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "cache"
+
+// Eviction reasons reported on cache_evictions_total.
+const (
+	evictReasonCapacity = "capacity"
+	evictReasonExpired  = "expired"
+	evictReasonCleared  = "cleared"
+)
+
+// cacheMetrics holds the OTEL counters of one named cache. A nil
+// *cacheMetrics records nothing, so unnamed caches skip every call.
+type cacheMetrics struct {
+	name      attribute.KeyValue
+	hits      metric.Int64Counter
+	misses    metric.Int64Counter
+	evictions metric.Int64Counter
+}
+
+// newCacheMetrics creates the counters for a cache. It returns nil if they
+// could not be created, leaving the cache unobserved.
+func newCacheMetrics(name string) *cacheMetrics {
+	meter := otel.Meter(meterName)
+	m := &cacheMetrics{name: attribute.String("cache", name)}
+	var err error
+
+	m.hits, err = meter.Int64Counter(
+		"cache_hits_total",
+		metric.WithDescription("Cache lookups that found a live entry"),
+	)
+	if err != nil {
+		otel.Handle(err)
+		return nil
+	}
+
+	m.misses, err = meter.Int64Counter(
+		"cache_misses_total",
+		metric.WithDescription("Cache lookups that found no entry or an expired one"),
+	)
+	if err != nil {
+		otel.Handle(err)
+		return nil
+	}
+
+	m.evictions, err = meter.Int64Counter(
+		"cache_evictions_total",
+		metric.WithDescription("Entries removed for exceeding capacity, expiring or being cleared"),
+	)
+	if err != nil {
+		otel.Handle(err)
+		return nil
+	}
+
+	return m
+}
+
+func (m *cacheMetrics) recordHit(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.hits.Add(ctx, 1, metric.WithAttributes(m.name))
+}
+
+func (m *cacheMetrics) recordMiss(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.misses.Add(ctx, 1, metric.WithAttributes(m.name))
+}
+
+func (m *cacheMetrics) recordEvictions(ctx context.Context, n int64, reason string) {
+	if m == nil {
+		return
+	}
+	m.evictions.Add(ctx, n, metric.WithAttributes(m.name, attribute.String("reason", reason)))
+}
@@ -36,7 +36,8 @@ type CircuitBreaker[T any] struct {
 	cb *gobreaker.CircuitBreaker[T]
 }
 
-// New creates a new CircuitBreaker with the given configuration.
+// New creates a new CircuitBreaker with the given configuration. Its state,
+// trips and consecutive failures are exported as OTEL metrics labeled by name.
 func New[T any](cfg Config) *CircuitBreaker[T] {
 	settings := gobreaker.Settings{
 		Name:          cfg.Name,
@@ -47,9 +48,18 @@ func New[T any](cfg Config) *CircuitBreaker[T] {
 		OnStateChange: cfg.OnStateChange,
 	}
 
-	return &CircuitBreaker[T]{
+	m := sharedMetrics()
+	if m != nil {
+		settings.OnStateChange = m.countTrips(cfg.OnStateChange)
+	}
+
+	c := &CircuitBreaker[T]{
 		cb: gobreaker.NewCircuitBreaker[T](settings),
 	}
+	if m != nil {
+		m.observe(cfg.Name, c.State, c.Counts)
+	}
+	return c
 }
 
 // Execute runs the given function through the circuit breaker.
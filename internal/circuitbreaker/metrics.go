@@ -0,0 +1,97 @@
+package circuitbreaker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sony/gobreaker/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "circuitbreaker"
+
+// breakerMetrics holds the OTEL instruments shared by every breaker; each
+// breaker is distinguished by its "name" attribute.
+type breakerMetrics struct {
+	meter               metric.Meter
+	state               metric.Int64ObservableGauge
+	trips               metric.Int64Counter
+	consecutiveFailures metric.Int64ObservableGauge
+}
+
+var (
+	metricsOnce sync.Once
+	metrics     *breakerMetrics
+)
+
+// sharedMetrics creates the instruments on first use. It returns nil if
+// they could not be created, in which case breakers run unobserved.
+func sharedMetrics() *breakerMetrics {
+	metricsOnce.Do(func() {
+		m := &breakerMetrics{meter: otel.Meter(meterName)}
+		var err error
+
+		m.state, err = m.meter.Int64ObservableGauge(
+			"circuit_breaker_state",
+			metric.WithDescription("Circuit breaker state: 0 closed, 1 half-open, 2 open"),
+		)
+		if err != nil {
+			otel.Handle(err)
+			return
+		}
+
+		m.trips, err = m.meter.Int64Counter(
+			"circuit_breaker_trips_total",
+			metric.WithDescription("Times the circuit breaker opened"),
+		)
+		if err != nil {
+			otel.Handle(err)
+			return
+		}
+
+		m.consecutiveFailures, err = m.meter.Int64ObservableGauge(
+			"circuit_breaker_consecutive_failures",
+			metric.WithDescription("Consecutive failed requests in the current breaker generation"),
+		)
+		if err != nil {
+			otel.Handle(err)
+			return
+		}
+
+		metrics = m
+	})
+	return metrics
+}
+
+// stateValue maps a breaker state to its gauge value. gobreaker orders the
+// states closed, half-open, open, so the gauge grows with severity.
+func stateValue(state gobreaker.State) int64 {
+	return int64(state)
+}
+
+// observe reports the breaker's state and failure streak on every collection.
+func (m *breakerMetrics) observe(name string, state func() gobreaker.State, counts func() gobreaker.Counts) {
+	attrs := metric.WithAttributes(attribute.String("name", name))
+	_, err := m.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		o.ObserveInt64(m.state, stateValue(state()), attrs)
+		o.ObserveInt64(m.consecutiveFailures, int64(counts().ConsecutiveFailures), attrs)
+		return nil
+	}, m.state, m.consecutiveFailures)
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// countTrips wraps onStateChange to count transitions to open.
+func (m *breakerMetrics) countTrips(onStateChange func(name string, from, to gobreaker.State)) func(name string, from, to gobreaker.State) {
+	return func(name string, from, to gobreaker.State) {
+		if to == gobreaker.StateOpen {
+			m.trips.Add(context.Background(), 1, metric.WithAttributes(attribute.String("name", name)))
+		}
+		if onStateChange != nil {
+			onStateChange(name, from, to)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sony/gobreaker/v2"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// TestCountTrips tests that only transitions to open are counted and the
+// configured callback still runs.
+func TestCountTrips(t *testing.T) {
+	m := &breakerMetrics{meter: noop.NewMeterProvider().Meter(meterName)}
+	var err error
+	if m.trips, err = m.meter.Int64Counter("circuit_breaker_trips_total"); err != nil {
+		t.Fatalf("Int64Counter failed: %v", err)
+	}
+
+	var transitions []gobreaker.State
+	onStateChange := m.countTrips(func(name string, from, to gobreaker.State) {
+		transitions = append(transitions, to)
+	})
+
+	onStateChange("test", gobreaker.StateClosed, gobreaker.StateOpen)
+	onStateChange("test", gobreaker.StateOpen, gobreaker.StateHalfOpen)
+
+	if len(transitions) != 2 || transitions[0] != gobreaker.StateOpen || transitions[1] != gobreaker.StateHalfOpen {
+		t.Errorf("expected callback for both transitions, got %v", transitions)
+	}
+}
+
+// TestNew_TripsOpen tests that a breaker built by New still trips and
+// reports its state with metrics wired in.
+func TestNew_TripsOpen(t *testing.T) {
+	var opened bool
+	cfg := DefaultConfig("test")
+	cfg.OnStateChange = func(name string, from, to gobreaker.State) {
+		opened = opened || to == gobreaker.StateOpen
+	}
+	cb := New[int](cfg)
+
+	for range 5 {
+		cb.Execute(func() (int, error) { return 0, errors.New("boom") })
+	}
+
+	if cb.State() != gobreaker.StateOpen || !opened {
+		t.Errorf("expected breaker to open, got %s (callback saw open: %v)", cb.State(), opened)
+	}
+	if got := stateValue(cb.State()); got != 2 {
+		t.Errorf("expected open state gauge 2, got %d", got)
+	}
+}
@@ -2,22 +2,63 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/viper"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
 )
 
+// binanceSymbolPattern matches Binance spot symbols such as ETHUSDC.
+var binanceSymbolPattern = regexp.MustCompile(`^[A-Z0-9]+$`)
+
+// krakenPairPattern matches Kraken WebSocket pair names such as XBT/USD.
+var krakenPairPattern = regexp.MustCompile(`^[A-Z0-9]+/[A-Z0-9]+$`)
+
+// metricPrefixPattern matches prefixes that keep metric names valid in
+// Prometheus, such as eth_mainnet.
+var metricPrefixPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// maxDisplayPrecision caps ui.display_precision; finer digits are noise for
+// USD amounts.
+const maxDisplayPrecision = 8
+
 // Config holds all application configuration.
 type Config struct {
 	App       AppConfig       `mapstructure:"app"`
 	Ethereum  EthereumConfig  `mapstructure:"ethereum"`
+	CEX       CEXConfig       `mapstructure:"cex"`
+	DEX       DEXConfig       `mapstructure:"dex"`
+	Pricing   PricingConfig   `mapstructure:"pricing"`
 	Binance   BinanceConfig   `mapstructure:"binance"`
+	Coinbase  CoinbaseConfig  `mapstructure:"coinbase"`
+	Kraken    KrakenConfig    `mapstructure:"kraken"`
 	Uniswap   UniswapConfig   `mapstructure:"uniswap"`
 	Arbitrage ArbitrageConfig `mapstructure:"arbitrage"`
+	Spread    SpreadConfig    `mapstructure:"spread"`
+	Storage   StorageConfig   `mapstructure:"storage"`
+	Recording RecordingConfig `mapstructure:"recording"`
+	Alerts    AlertsConfig    `mapstructure:"alerts"`
+	API       APIConfig       `mapstructure:"api"`
+	GRPC      GRPCConfig      `mapstructure:"grpc"`
+	UI        UIConfig        `mapstructure:"ui"`
 	Telemetry TelemetryConfig `mapstructure:"telemetry"`
+
+	// Chain selects a profile from Chains (by --chain or ARB_CHAIN); the
+	// profile's settings override the ethereum and uniswap sections
+	Chain  string                  `mapstructure:"chain"`
+	Chains map[string]ChainProfile `mapstructure:"chains"`
 }
 
 // AppConfig holds general application settings.
@@ -25,6 +66,8 @@ type AppConfig struct {
 	Name        string `mapstructure:"name"`
 	Environment string `mapstructure:"environment"`
 	LogLevel    string `mapstructure:"log_level"`
+	PprofPort   int    `mapstructure:"pprof_port"` // Serve net/http/pprof on this port (0 = disabled)
+	DebugPort   int    `mapstructure:"debug_port"` // Serve the /debug state dump on this port (0 = disabled)
 }
 
 // EthereumConfig holds Ethereum node configuration.
@@ -32,9 +75,89 @@ type EthereumConfig struct {
 	WebSocketURL   string        `mapstructure:"websocket_url"`
 	HTTPURL        string        `mapstructure:"http_url"`
 	ChainID        uint64        `mapstructure:"chain_id"`
-	MaxReconnects  int           `mapstructure:"max_reconnects"`
+	MaxReconnects  int           `mapstructure:"max_reconnects"` // Consecutive WS reconnects before exiting (0 = infinite)
 	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
 	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+
+	// BackoffResetAfter is how long a WS subscription must survive for the
+	// reconnect backoff to start over from InitialBackoff
+	BackoffResetAfter time.Duration `mapstructure:"backoff_reset_after"`
+
+	// Blocks are analyzed once this many blocks deep (0 = at the chain tip)
+	ConfirmationDepth uint64 `mapstructure:"confirmation_depth"`
+
+	// Gas oracle: smoothed fee-history estimate (0 blocks = disabled)
+	FeeHistoryBlocks uint64  `mapstructure:"fee_history_blocks"`
+	RewardPercentile float64 `mapstructure:"reward_percentile"`
+
+	// BlockTime sets the HTTP polling interval and the gas price cache TTL
+	BlockTime time.Duration `mapstructure:"block_time"`
+
+	// Tokens registers ERC20s on chain_id beyond the built-in assets, keyed
+	// by symbol (case-insensitive)
+	Tokens map[string]TokenConfig `mapstructure:"tokens"`
+}
+
+// TokenConfig describes an ERC20 token on the configured chain.
+type TokenConfig struct {
+	Address  string `mapstructure:"address"`
+	Decimals uint8  `mapstructure:"decimals"`
+}
+
+// ChainProfile holds the settings that differ between chains. Set fields
+// override the ethereum and uniswap sections when the profile is selected;
+// tokens are added to ethereum.tokens.
+type ChainProfile struct {
+	ChainID      uint64        `mapstructure:"chain_id"`
+	WebSocketURL string        `mapstructure:"websocket_url"`
+	HTTPURL      string        `mapstructure:"http_url"`
+	BlockTime    time.Duration `mapstructure:"block_time"`
+
+	QuoterAddress    string `mapstructure:"quoter_address"`
+	RouterAddress    string `mapstructure:"router_address"`
+	FactoryAddress   string `mapstructure:"factory_address"`
+	V2FactoryAddress string `mapstructure:"v2_factory_address"`
+	MulticallAddress string `mapstructure:"multicall_address"`
+
+	Tokens map[string]TokenConfig `mapstructure:"tokens"`
+}
+
+// applyChain overlays the profile named by c.Chain onto the ethereum and
+// uniswap sections. Profile names are case-insensitive.
+func (c *Config) applyChain() error {
+	name := strings.ToLower(c.Chain)
+	profile, ok := c.Chains[name]
+	if !ok {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("unknown chain profile %q", c.Chain)))
+	}
+	c.Chain = name
+
+	override := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	override(&c.Ethereum.WebSocketURL, profile.WebSocketURL)
+	override(&c.Ethereum.HTTPURL, profile.HTTPURL)
+	override(&c.Uniswap.QuoterAddress, profile.QuoterAddress)
+	override(&c.Uniswap.RouterAddress, profile.RouterAddress)
+	override(&c.Uniswap.FactoryAddress, profile.FactoryAddress)
+	override(&c.Uniswap.V2FactoryAddress, profile.V2FactoryAddress)
+	override(&c.Uniswap.MulticallAddress, profile.MulticallAddress)
+	if profile.ChainID != 0 {
+		c.Ethereum.ChainID = profile.ChainID
+	}
+	if profile.BlockTime > 0 {
+		c.Ethereum.BlockTime = profile.BlockTime
+	}
+	if len(profile.Tokens) > 0 && c.Ethereum.Tokens == nil {
+		c.Ethereum.Tokens = make(map[string]TokenConfig, len(profile.Tokens))
+	}
+	for symbol, token := range profile.Tokens {
+		c.Ethereum.Tokens[symbol] = token
+	}
+	return nil
 }
 
 // BinanceConfig holds Binance API configuration.
@@ -43,14 +166,217 @@ type BinanceConfig struct {
 	Symbols      []string      `mapstructure:"symbols"`
 	DepthSpeedMs int           `mapstructure:"depth_speed_ms"`
 	StaleTimeout time.Duration `mapstructure:"stale_timeout"`
+	DiffDepth    bool          `mapstructure:"diff_depth"`  // Full books from <symbol>@depth@100ms synced against REST snapshots
+	ProxyURL     string        `mapstructure:"proxy_url"`   // http(s):// or socks5:// proxy for the stream connection (empty = direct)
+	Compression  string        `mapstructure:"compression"` // permessage-deflate: "context_takeover", "no_context_takeover" or "disabled"
+
+	// SymbolOverrides maps pairs ("BASE-QUOTE") to the exact Binance symbol
+	// where it isn't BASE+QUOTE, e.g. "WBTC-USDC": "BTCUSDC". Each target
+	// must be one of Symbols.
+	SymbolOverrides map[string]string `mapstructure:"symbol_overrides"`
+
+	// InvalidSymbols is what happens on connect to symbols exchangeInfo
+	// doesn't list as TRADING: "drop" (log and skip them), "fail" (refuse
+	// to start) or "ignore" (subscribe without checking)
+	InvalidSymbols string `mapstructure:"invalid_symbols"`
+
+	// ClockSkew is what happens on connect when the local clock is more
+	// than MaxClockSkew off from Binance's /api/v3/time: "warn" (log it),
+	// "fail" (refuse to start) or "ignore" (don't check). The measured
+	// offset is applied to signed request timestamps either way.
+	ClockSkew    string        `mapstructure:"clock_skew"`
+	MaxClockSkew time.Duration `mapstructure:"max_clock_skew"`
+
+	// MaxBookLevels caps the price levels kept per side of each book,
+	// bounding memory for diff-depth books (0 = the stream's own depth)
+	MaxBookLevels int `mapstructure:"max_book_levels"`
+
+	// API credentials for the optional user-data stream (real fees and fills).
+	// Read only from ARB_BINANCE_API_KEY / ARB_BINANCE_API_SECRET, never from the config file.
+	APIKey    string `mapstructure:"-"`
+	APISecret string `mapstructure:"-"`
+}
+
+// UserDataEnabled reports whether API credentials for the user-data stream are set.
+func (c *BinanceConfig) UserDataEnabled() bool {
+	return c.APIKey != "" && c.APISecret != ""
+}
+
+// Supported CEX providers.
+const (
+	CEXProviderBinance  = "binance"
+	CEXProviderCoinbase = "coinbase"
+	CEXProviderKraken   = "kraken"
+)
+
+// CEXConfig selects the centralized exchanges used for price discovery.
+type CEXConfig struct {
+	Provider  string   `mapstructure:"provider"`  // "binance", "coinbase" or "kraken"
+	Providers []string `mapstructure:"providers"` // Multiple venues aggregated for best bid/ask (overrides Provider)
+
+	// RejectPartialFills makes a trade size the book can't fully fill an
+	// error instead of a price averaged over the part it can
+	RejectPartialFills bool `mapstructure:"reject_partial_fills"`
+}
+
+// Venues returns the configured CEX venues.
+// Providers takes precedence; otherwise the single Provider is used.
+func (c CEXConfig) Venues() []string {
+	if len(c.Providers) > 0 {
+		return c.Providers
+	}
+	return []string{c.Provider}
+}
+
+// Supported DEX providers.
+const (
+	DEXProviderUniswapV3 = "uniswap_v3"
+	DEXProviderUniswapV2 = "uniswap_v2"
+)
+
+// DEXConfig selects the decentralized exchanges used for quotes.
+// With several providers the best quote (highest output) wins.
+type DEXConfig struct {
+	Providers []string        `mapstructure:"providers"` // "uniswap_v3", "uniswap_v2" or a fork name
+	Forks     []DEXForkConfig `mapstructure:"forks"`     // Uniswap V2 forks, enabled by listing their name in providers
+}
+
+// DEXForkConfig describes a Uniswap V2 fork (SushiSwap, PancakeSwap, ...):
+// the same factory/pair ABI deployed at its own factory with its own fee.
+type DEXForkConfig struct {
+	Name           string `mapstructure:"name"`            // Provider name, e.g. "sushiswap"
+	FactoryAddress string `mapstructure:"factory_address"` // Fork's UniswapV2Factory equivalent
+	FeeBps         int    `mapstructure:"fee_bps"`         // Swap fee, e.g. 30 (0.30%) or 25 (0.25%)
+}
+
+// FeeTier returns the fork's fee in hundredths of a bip, the unit quotes
+// carry (30 bps = 3000).
+func (f DEXForkConfig) FeeTier() int {
+	return f.FeeBps * 100
+}
+
+// validateForks checks each fork has a unique name distinct from the
+// built-in providers, a factory address and a fee below 100%.
+func (c *DEXConfig) validateForks() error {
+	seen := make(map[string]bool, len(c.Forks))
+	for _, fork := range c.Forks {
+		switch {
+		case fork.Name == "":
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext("dex.forks: name is required"))
+		case fork.Name == DEXProviderUniswapV3 || fork.Name == DEXProviderUniswapV2:
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("dex.forks: %s is a built-in provider", fork.Name)))
+		case seen[fork.Name]:
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("dex.forks: duplicate fork %s", fork.Name)))
+		case !common.IsHexAddress(fork.FactoryAddress):
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("dex.forks: invalid factory_address for %s: %q", fork.Name, fork.FactoryAddress)))
+		case fork.FeeBps < 0 || fork.FeeBps >= 10_000:
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("dex.forks: fee_bps for %s must be in [0, 10000), got %d", fork.Name, fork.FeeBps)))
+		}
+		seen[fork.Name] = true
+	}
+	return nil
+}
+
+// PricingConfig selects price providers by their registered names. When set,
+// each list overrides the cex/dex sections, and unlike those it accepts any
+// provider registered with the pricing module's provider registry.
+type PricingConfig struct {
+	CEXProviders []string `mapstructure:"cex_providers"` // e.g. ["binance", "coinbase"]
+	DEXProviders []string `mapstructure:"dex_providers"` // e.g. ["uniswap_v3", "uniswap_v2"]
+
+	// StableEquivalents lists stablecoins treated as fungible for spread
+	// comparison, with their USD peg (e.g. {USDC: 1, USDT: 1, DAI: 1}). A
+	// pair quoted in one can be priced on the CEX from a book quoted in
+	// another, converted through the pegs and flagged with a basis risk.
+	StableEquivalents map[string]float64 `mapstructure:"stable_equivalents"`
+
+	// CEXQuote is the stable the CEX side is priced in for pairs quoted in
+	// another equivalent (empty = the pair's quote, falling back to the
+	// other equivalents when its book isn't available)
+	CEXQuote string `mapstructure:"cex_quote"`
+}
+
+// StablePegs returns pricing.stable_equivalents as decimals.
+func (c *PricingConfig) StablePegs() map[string]decimal.Decimal {
+	pegs := make(map[string]decimal.Decimal, len(c.StableEquivalents))
+	for symbol, peg := range c.StableEquivalents {
+		pegs[symbol] = decimal.NewFromFloat(peg)
+	}
+	return pegs
+}
+
+// validate checks the stable pegs and that the CEX quote is one of them.
+func (c *PricingConfig) validate() error {
+	for symbol, peg := range c.StableEquivalents {
+		if peg <= 0 {
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("pricing.stable_equivalents.%s must be positive, got %v", symbol, peg)))
+		}
+	}
+	if _, ok := c.StableEquivalents[c.CEXQuote]; c.CEXQuote != "" && !ok {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("pricing.cex_quote %s is not in pricing.stable_equivalents", c.CEXQuote)))
+	}
+	return nil
+}
+
+// CEXProviderNames returns the CEX providers to build: pricing.cex_providers
+// if set, otherwise the cex section's venues.
+func (c *Config) CEXProviderNames() []string {
+	if len(c.Pricing.CEXProviders) > 0 {
+		return c.Pricing.CEXProviders
+	}
+	return c.CEX.Venues()
+}
+
+// DEXProviderNames returns the DEX providers to build: pricing.dex_providers
+// if set, otherwise dex.providers.
+func (c *Config) DEXProviderNames() []string {
+	if len(c.Pricing.DEXProviders) > 0 {
+		return c.Pricing.DEXProviders
+	}
+	return c.DEX.Providers
+}
+
+// CoinbaseConfig holds Coinbase Advanced Trade API configuration.
+type CoinbaseConfig struct {
+	WebSocketURL string        `mapstructure:"websocket_url"` // wss://advanced-trade-ws.coinbase.com
+	HTTPURL      string        `mapstructure:"http_url"`      // https://api.exchange.coinbase.com
+	ProductIDs   []string      `mapstructure:"product_ids"`   // e.g. ETH-USD
+	StaleTimeout time.Duration `mapstructure:"stale_timeout"`
+}
+
+// KrakenConfig holds Kraken spot API configuration.
+type KrakenConfig struct {
+	WebSocketURL string        `mapstructure:"websocket_url"` // wss://ws.kraken.com
+	HTTPURL      string        `mapstructure:"http_url"`      // https://api.kraken.com
+	Pairs        []string      `mapstructure:"pairs"`         // e.g. ETH/USDC, XBT/USD
+	Depth        int           `mapstructure:"depth"`         // Book depth: 10, 25, 100, 500 or 1000
+	StaleTimeout time.Duration `mapstructure:"stale_timeout"`
 }
 
-// UniswapConfig holds Uniswap V3 contract addresses.
+// UniswapConfig holds Uniswap V3 (and V2 factory) contract addresses.
 type UniswapConfig struct {
-	QuoterAddress  string `mapstructure:"quoter_address"`
-	RouterAddress  string `mapstructure:"router_address"`
-	FactoryAddress string `mapstructure:"factory_address"`
-	DefaultFeeTier int    `mapstructure:"default_fee_tier"`
+	QuoterAddress    string `mapstructure:"quoter_address"`
+	RouterAddress    string `mapstructure:"router_address"`
+	FactoryAddress   string `mapstructure:"factory_address"`
+	DefaultFeeTier   int    `mapstructure:"default_fee_tier"`
+	V2FactoryAddress string `mapstructure:"v2_factory_address"`
+	MulticallAddress string `mapstructure:"multicall_address"` // Multicall3 for batched quotes ("" = one eth_call per quote)
+
+	// Quoter retries are a budget shared by all fee tiers of one quote, so a
+	// flaky RPC response doesn't drop a tier while total latency stays bounded
+	QuoteRetries      int           `mapstructure:"quote_retries"`       // 0 disables retries
+	QuoteRetryBackoff time.Duration `mapstructure:"quote_retry_backoff"` // Doubled after each retry
+
+	// Quotes are bounded by a sqrtPriceLimitX96 this far from the pool's spot
+	// price; a swap that would move the price further fails (0 = no limit)
+	MaxPriceImpactBps int `mapstructure:"max_price_impact_bps"`
 }
 
 // QuoterAddressHex returns the quoter address as common.Address.
@@ -68,13 +394,204 @@ func (c *UniswapConfig) FactoryAddressHex() common.Address {
 	return common.HexToAddress(c.FactoryAddress)
 }
 
+// V2FactoryAddressHex returns the Uniswap V2 factory address as common.Address.
+func (c *UniswapConfig) V2FactoryAddressHex() common.Address {
+	return common.HexToAddress(c.V2FactoryAddress)
+}
+
+// MulticallAddressHex returns the Multicall3 address as common.Address
+// (the zero address when batching is disabled).
+func (c *UniswapConfig) MulticallAddressHex() common.Address {
+	return common.HexToAddress(c.MulticallAddress)
+}
+
 // ArbitrageConfig holds arbitrage detection configuration.
 type ArbitrageConfig struct {
-	Pairs        []string  `mapstructure:"pairs"`
-	TradeSizes   []float64 `mapstructure:"trade_sizes"`
-	MinProfitBps float64   `mapstructure:"min_profit_bps"`
-	MinProfitUSD float64   `mapstructure:"min_profit_usd"`
-	TUIMode      bool      `mapstructure:"-"` // Set at runtime, not from config file
+	Pairs        []PairConfig `mapstructure:"pairs"`
+	TradeSizes   []float64    `mapstructure:"trade_sizes"`
+	MinProfitBps float64      `mapstructure:"min_profit_bps"`
+	MinProfitUSD float64      `mapstructure:"min_profit_usd"`
+	TUIMode      bool         `mapstructure:"-"` // Set at runtime, not from config file
+
+	// TradeSizesUSD sizes trades by USD notional instead of base units,
+	// converted per pair at the CEX price on every block. Mutually exclusive
+	// with an explicit trade_sizes; setting it drops the trade_sizes default.
+	TradeSizesUSD []float64 `mapstructure:"trade_sizes_usd"`
+
+	// DenyPairs removes pairs from Pairs, e.g. to drop one pair from a
+	// list shared through ARB_PAIRS or a chain profile
+	DenyPairs []string `mapstructure:"deny_pairs"`
+
+	// TriangularCycles lists pair cycles scanned for triangular arbitrage.
+	// Each cycle starts and ends on the first pair's base asset,
+	// e.g. ["ETH-USDC", "WBTC-USDC", "WBTC-ETH"].
+	TriangularCycles [][]string `mapstructure:"triangular_cycles"`
+
+	// Slippage model: DEX price impact charged against gross profit
+	SlippageImpactFactor float64 `mapstructure:"slippage_impact_factor"`  // 0 disables the haircut
+	SlippageMaxTradeSize float64 `mapstructure:"slippage_max_trade_size"` // "High Slippage" risk above this size (0 = off)
+
+	// ExecutionMode is the assumed CEX fill: "taker" (VWAP through the book,
+	// taker fee) or "maker" (limit order at the best bid/ask, maker fee)
+	ExecutionMode string `mapstructure:"execution_mode"`
+
+	// MaxCapitalUSD caps the capital a single trade may commit; opportunities
+	// are sized to fit and flagged when they exceed it (0 = unlimited)
+	MaxCapitalUSD float64 `mapstructure:"max_capital_usd"`
+
+	// MaxSnapshotAge skips opportunities whose CEX or DEX price is older than
+	// this, even if the provider still serves it (0 = off)
+	MaxSnapshotAge time.Duration `mapstructure:"max_snapshot_age"`
+
+	// DefaultSwapGasLimit is the gas charged per DEX swap when the quoter
+	// returns no estimate for the route
+	DefaultSwapGasLimit uint64 `mapstructure:"default_swap_gas_limit"`
+
+	// ETHPriceSymbol is the BASE-QUOTE pair whose CEX price converts gas
+	// costs to USD, refreshed every block whether or not it is scanned
+	// (empty = take it from a scanned ETH pair)
+	ETHPriceSymbol string `mapstructure:"eth_price_symbol"`
+
+	// Fixed round-trip overheads added to every trade's costs (0 = none)
+	ApprovalGas         uint64  `mapstructure:"approval_gas"`           // Token approval transaction gas
+	CEXWithdrawalFeeUSD float64 `mapstructure:"cex_withdrawal_fee_usd"` // Network fee withdrawing from the CEX
+	CEXDepositFeeUSD    float64 `mapstructure:"cex_deposit_fee_usd"`    // Fee depositing back onto the CEX
+
+	// SimulateExecution walks the CEX book and applies DEX price impact to
+	// each profitable opportunity to estimate the realized profit
+	SimulateExecution bool `mapstructure:"simulate_execution"`
+
+	// ScanInterval re-evaluates pairs between blocks with fresh CEX prices
+	// against the last block's DEX quotes; blocks still refresh quotes (0 = off)
+	ScanInterval time.Duration `mapstructure:"scan_interval"`
+
+	// Dedupe reports one opportunity per block, pair and direction (the best
+	// size by net profit, with the profitable size range) instead of one per size
+	Dedupe bool `mapstructure:"dedupe"`
+
+	// SummaryInterval logs a profit summary (profitable opportunities and
+	// blocks, theoretical profit, best opportunity) this often (0 = off)
+	SummaryInterval time.Duration `mapstructure:"summary_interval"`
+
+	// Safety halts detection on conditions that suggest broken data
+	Safety SafetyConfig `mapstructure:"safety"`
+}
+
+// SafetyConfig is the kill switch that halts detection (no opportunities
+// are reported) while a condition holds. Each check is off at its zero value.
+type SafetyConfig struct {
+	MaxGasGwei       float64 `mapstructure:"max_gas_gwei"`       // Halt while gas is above this
+	MaxPriceMovePct  float64 `mapstructure:"max_price_move_pct"` // Halt when ETH moves more than this % between blocks
+	HaltOnDisconnect bool    `mapstructure:"halt_on_disconnect"` // Halt while neither the CEX feed nor the DEX is available
+
+	// ResumeAfterBlocks is how many consecutive clear blocks detection
+	// waits for before resuming (0 = the first clear block)
+	ResumeAfterBlocks int `mapstructure:"resume_after_blocks"`
+
+	// ExitOnHalt shuts the bot down with an error instead of waiting to resume
+	ExitOnHalt bool `mapstructure:"exit_on_halt"`
+}
+
+// MaxPriceMovePctDecimal returns the per-block price move limit as decimal.Decimal.
+func (c *SafetyConfig) MaxPriceMovePctDecimal() decimal.Decimal {
+	return decimal.NewFromFloat(c.MaxPriceMovePct)
+}
+
+// Supported CEX execution modes.
+const (
+	ExecutionModeTaker = "taker"
+	ExecutionModeMaker = "maker"
+)
+
+// SpreadConfig selects how CEX/DEX spreads are measured.
+type SpreadConfig struct {
+	// Basis is the CEX price the DEX price is compared with: "ask" (best
+	// executable ask), "mid" (mid of best bid/ask) or "micro" (top of book
+	// weighted by size). mid and micro are less directionally biased for
+	// monitoring, but profit then ignores the CEX half-spread.
+	Basis string `mapstructure:"basis"`
+}
+
+// Supported spread bases.
+const (
+	SpreadBasisAsk   = "ask"
+	SpreadBasisMid   = "mid"
+	SpreadBasisMicro = "micro"
+)
+
+// PairConfig is a monitored pair with optional profit threshold overrides.
+// In YAML an entry is either a plain "BASE-QUOTE" string or a map:
+//
+//	pairs:
+//	  - ETH-USDC
+//	  - symbol: WBTC-USDC
+//	    min_profit_bps: 15
+//	    min_profit_usd: 25
+type PairConfig struct {
+	Symbol       string   `mapstructure:"symbol"`         // BASE-QUOTE
+	MinProfitBps *float64 `mapstructure:"min_profit_bps"` // nil = arbitrage.min_profit_bps
+	MinProfitUSD *float64 `mapstructure:"min_profit_usd"` // nil = arbitrage.min_profit_usd
+}
+
+// HasOverrides reports whether the pair overrides any global threshold.
+func (p PairConfig) HasOverrides() bool {
+	return p.MinProfitBps != nil || p.MinProfitUSD != nil
+}
+
+// stringToPairConfigHook decodes a plain "BASE-QUOTE" entry (or an element of
+// the comma-separated ARB_PAIRS env var) into a PairConfig.
+func stringToPairConfigHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String {
+		return data, nil
+	}
+
+	switch to {
+	case reflect.TypeOf(PairConfig{}):
+		return PairConfig{Symbol: strings.TrimSpace(data.(string))}, nil
+	case reflect.TypeOf([]PairConfig{}):
+		var pairs []PairConfig
+		for _, symbol := range strings.Split(data.(string), ",") {
+			if symbol = strings.TrimSpace(symbol); symbol != "" {
+				pairs = append(pairs, PairConfig{Symbol: symbol})
+			}
+		}
+		return pairs, nil
+	}
+	return data, nil
+}
+
+// PairSymbols returns the configured pair symbols, less any denied.
+func (c *ArbitrageConfig) PairSymbols() []string {
+	symbols := make([]string, 0, len(c.Pairs))
+	for _, p := range c.Pairs {
+		if !c.Denied(p.Symbol) {
+			symbols = append(symbols, p.Symbol)
+		}
+	}
+	return symbols
+}
+
+// Denied reports whether symbol is on the deny list (case-insensitive).
+func (c *ArbitrageConfig) Denied(symbol string) bool {
+	for _, denied := range c.DenyPairs {
+		if strings.EqualFold(strings.TrimSpace(denied), symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// PairThresholds returns the pair's min profit bps and USD, falling back to the global defaults.
+func (c *ArbitrageConfig) PairThresholds(p PairConfig) (minProfitBps, minProfitUSD decimal.Decimal) {
+	minProfitBps = c.MinProfitBpsDecimal()
+	if p.MinProfitBps != nil {
+		minProfitBps = decimal.NewFromFloat(*p.MinProfitBps)
+	}
+	minProfitUSD = c.MinProfitUSDDecimal()
+	if p.MinProfitUSD != nil {
+		minProfitUSD = decimal.NewFromFloat(*p.MinProfitUSD)
+	}
+	return minProfitBps, minProfitUSD
 }
 
 // TradeSizesDecimal returns trade sizes as decimal.Decimal slice.
@@ -86,6 +603,15 @@ func (c *ArbitrageConfig) TradeSizesDecimal() []decimal.Decimal {
 	return result
 }
 
+// TradeSizesUSDDecimal returns USD trade sizes as decimal.Decimal slice.
+func (c *ArbitrageConfig) TradeSizesUSDDecimal() []decimal.Decimal {
+	result := make([]decimal.Decimal, len(c.TradeSizesUSD))
+	for i, s := range c.TradeSizesUSD {
+		result[i] = decimal.NewFromFloat(s)
+	}
+	return result
+}
+
 // MinProfitBpsDecimal returns min profit bps as decimal.Decimal.
 func (c *ArbitrageConfig) MinProfitBpsDecimal() decimal.Decimal {
 	return decimal.NewFromFloat(c.MinProfitBps)
@@ -96,6 +622,99 @@ func (c *ArbitrageConfig) MinProfitUSDDecimal() decimal.Decimal {
 	return decimal.NewFromFloat(c.MinProfitUSD)
 }
 
+// SlippageImpactFactorDecimal returns the slippage impact factor as decimal.Decimal.
+func (c *ArbitrageConfig) SlippageImpactFactorDecimal() decimal.Decimal {
+	return decimal.NewFromFloat(c.SlippageImpactFactor)
+}
+
+// SlippageMaxTradeSizeDecimal returns the slippage trade size cap as decimal.Decimal.
+func (c *ArbitrageConfig) SlippageMaxTradeSizeDecimal() decimal.Decimal {
+	return decimal.NewFromFloat(c.SlippageMaxTradeSize)
+}
+
+// MaxCapitalUSDDecimal returns the capital limit as decimal.Decimal.
+func (c *ArbitrageConfig) MaxCapitalUSDDecimal() decimal.Decimal {
+	return decimal.NewFromFloat(c.MaxCapitalUSD)
+}
+
+// CEXWithdrawalFeeUSDDecimal returns the CEX withdrawal fee as decimal.Decimal.
+func (c *ArbitrageConfig) CEXWithdrawalFeeUSDDecimal() decimal.Decimal {
+	return decimal.NewFromFloat(c.CEXWithdrawalFeeUSD)
+}
+
+// CEXDepositFeeUSDDecimal returns the CEX deposit fee as decimal.Decimal.
+func (c *ArbitrageConfig) CEXDepositFeeUSDDecimal() decimal.Decimal {
+	return decimal.NewFromFloat(c.CEXDepositFeeUSD)
+}
+
+// StorageConfig holds opportunity persistence configuration.
+type StorageConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Path          string        `mapstructure:"path"`           // SQLite database file
+	BatchSize     int           `mapstructure:"batch_size"`     // Records buffered before an insert
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // Max time a record stays buffered
+}
+
+// RecordingConfig holds live market data recording settings (backtest input).
+type RecordingConfig struct {
+	Dir            string        `mapstructure:"dir"`              // Empty disables recording (--record sets it)
+	MaxFileSizeMB  int           `mapstructure:"max_file_size_mb"` // Rotate a file once it reaches this size (0 = no limit)
+	RotateInterval time.Duration `mapstructure:"rotate_interval"`  // Rotate files this often (0 = never)
+}
+
+// AlertsConfig holds external alerting configuration.
+type AlertsConfig struct {
+	WebhookURL   string        `mapstructure:"webhook_url"`    // Empty disables webhook alerts
+	MinProfitUSD float64       `mapstructure:"min_profit_usd"` // Alert only above this net profit
+	Debounce     time.Duration `mapstructure:"debounce"`       // Per-pair quiet period between alerts
+
+	// Telegram Bot API notifications (both token and chat id enable it)
+	TelegramBotToken          string `mapstructure:"telegram_bot_token"`
+	TelegramChatID            string `mapstructure:"telegram_chat_id"`
+	TelegramMessagesPerMinute int    `mapstructure:"telegram_messages_per_minute"`
+
+	// SendSummaries also sends each arbitrage.summary_interval summary to Telegram
+	SendSummaries bool `mapstructure:"send_summaries"`
+}
+
+// TelegramEnabled returns true if Telegram notifications are configured.
+func (c *AlertsConfig) TelegramEnabled() bool {
+	return c.TelegramBotToken != "" && c.TelegramChatID != ""
+}
+
+// MinProfitUSDDecimal returns the alert threshold as decimal.Decimal.
+func (c *AlertsConfig) MinProfitUSDDecimal() decimal.Decimal {
+	return decimal.NewFromFloat(c.MinProfitUSD)
+}
+
+// APIConfig holds the REST API server configuration.
+type APIConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	Port    int  `mapstructure:"port"`
+}
+
+// GRPCConfig holds the gRPC opportunity stream configuration.
+type GRPCConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	Port       int  `mapstructure:"port"`
+	BufferSize int  `mapstructure:"buffer_size"` // Opportunities queued per client before it is dropped
+}
+
+// UIConfig holds TUI display settings.
+type UIConfig struct {
+	// OpportunityTTL is how old a listed opportunity gets before it is
+	// dimmed as stale (0 = never)
+	OpportunityTTL time.Duration `mapstructure:"opportunity_ttl"`
+
+	// RemoveExpired drops opportunities past OpportunityTTL instead of
+	// dimming them
+	RemoveExpired bool `mapstructure:"remove_expired"`
+
+	// DisplayPrecision is how many decimals USD profit and cost amounts are
+	// shown with. Calculations always run at full precision.
+	DisplayPrecision int `mapstructure:"display_precision"`
+}
+
 // TelemetryConfig holds observability configuration.
 type TelemetryConfig struct {
 	Enabled        bool   `mapstructure:"enabled"`
@@ -103,10 +722,38 @@ type TelemetryConfig struct {
 	OTLPEndpoint   string `mapstructure:"otlp_endpoint"`
 	OTLPHeaders    string `mapstructure:"otlp_headers"`
 	PrometheusPort int    `mapstructure:"prometheus_port"`
+
+	// TraceExporter is where spans are sent: "zipkin", "jaeger" (OTLP to
+	// a Jaeger collector, http://localhost:4318 unless otlp_endpoint is
+	// set) or "console" (stdout)
+	TraceExporter string `mapstructure:"trace_exporter"`
+
+	// SampleRatio is the fraction of traces recorded, from 0 to 1. A span per
+	// quote per pair per block is a lot of volume; production setups
+	// typically sample a few percent (1 = every trace)
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+
+	// MetricPrefix is prepended to every metric name (e.g. "eth_mainnet"
+	// exports eth_mainnet_binance_messages_total), so several bot instances
+	// can share one Prometheus. Empty keeps the plain names
+	MetricPrefix string `mapstructure:"metric_prefix"`
 }
 
+// Supported trace exporters.
+const (
+	TraceExporterZipkin  = "zipkin"
+	TraceExporterJaeger  = "jaeger"
+	TraceExporterConsole = "console"
+)
+
 // Load loads configuration from file and environment variables.
 func Load(configPath string) (*Config, error) {
+	return LoadChain(configPath, "")
+}
+
+// LoadChain loads configuration like Load, selecting the named chain profile
+// (empty = the chain key, if set).
+func LoadChain(configPath, chain string) (*Config, error) {
 	v := viper.New()
 
 	// Config file
@@ -138,10 +785,50 @@ func Load(configPath string) (*Config, error) {
 	}
 
 	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		stringToPairConfigHook,
+	))); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// USD sizes replace the built-in base sizes unless both are written out,
+	// which Validate rejects
+	if len(cfg.Arbitrage.TradeSizesUSD) > 0 && !v.InConfig("arbitrage.trade_sizes") {
+		cfg.Arbitrage.TradeSizes = nil
+	}
+
+	// Secrets come from the environment only
+	cfg.Binance.APIKey = os.Getenv("ARB_BINANCE_API_KEY")
+	cfg.Binance.APISecret = os.Getenv("ARB_BINANCE_API_SECRET")
+
+	// Viper lowercases map keys; pairs and symbols are upper-case everywhere else
+	if len(cfg.Pricing.StableEquivalents) > 0 {
+		pegs := make(map[string]float64, len(cfg.Pricing.StableEquivalents))
+		for symbol, peg := range cfg.Pricing.StableEquivalents {
+			pegs[strings.ToUpper(symbol)] = peg
+		}
+		cfg.Pricing.StableEquivalents = pegs
+	}
+	cfg.Pricing.CEXQuote = strings.ToUpper(cfg.Pricing.CEXQuote)
+	if len(cfg.Binance.SymbolOverrides) > 0 {
+		overrides := make(map[string]string, len(cfg.Binance.SymbolOverrides))
+		for pair, symbol := range cfg.Binance.SymbolOverrides {
+			overrides[strings.ToUpper(pair)] = symbol
+		}
+		cfg.Binance.SymbolOverrides = overrides
+	}
+
+	if chain != "" {
+		cfg.Chain = chain
+	}
+	if cfg.Chain != "" {
+		if err := cfg.applyChain(); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
@@ -154,30 +841,105 @@ func bindEnvVars(v *viper.Viper) {
 	v.BindEnv("app.name", "ARB_APP_NAME", "SERVICE_NAME")
 	v.BindEnv("app.environment", "ARB_ENVIRONMENT", "ENVIRONMENT")
 	v.BindEnv("app.log_level", "ARB_LOG_LEVEL", "LOG_LEVEL")
+	v.BindEnv("app.pprof_port", "ARB_PPROF_PORT")
+	v.BindEnv("app.debug_port", "ARB_DEBUG_PORT")
 
 	// Ethereum
 	v.BindEnv("ethereum.websocket_url", "ARB_ETH_WS_URL", "ETH_WS_URL")
 	v.BindEnv("ethereum.http_url", "ARB_ETH_HTTP_URL", "ETH_HTTP_URL")
 	v.BindEnv("ethereum.chain_id", "ARB_ETH_CHAIN_ID", "ETH_CHAIN_ID")
+	v.BindEnv("chain", "ARB_CHAIN")
 
 	// Binance
 	v.BindEnv("binance.websocket_url", "ARB_BINANCE_WS_URL", "BINANCE_WS_URL")
 	v.BindEnv("binance.symbols", "ARB_BINANCE_SYMBOLS", "BINANCE_SYMBOLS")
+	v.BindEnv("binance.diff_depth", "ARB_BINANCE_DIFF_DEPTH")
+	v.BindEnv("binance.proxy_url", "ARB_BINANCE_PROXY_URL")
+	v.BindEnv("binance.compression", "ARB_BINANCE_COMPRESSION")
+	v.BindEnv("binance.invalid_symbols", "ARB_BINANCE_INVALID_SYMBOLS")
+	v.BindEnv("binance.clock_skew", "ARB_BINANCE_CLOCK_SKEW")
+	v.BindEnv("binance.max_clock_skew", "ARB_BINANCE_MAX_CLOCK_SKEW")
+	v.BindEnv("binance.max_book_levels", "ARB_BINANCE_MAX_BOOK_LEVELS")
+
+	// CEX selection
+	v.BindEnv("cex.provider", "ARB_CEX_PROVIDER", "CEX_PROVIDER")
+	v.BindEnv("cex.providers", "ARB_CEX_PROVIDERS", "CEX_PROVIDERS")
+	v.BindEnv("cex.reject_partial_fills", "ARB_CEX_REJECT_PARTIAL_FILLS")
+	v.BindEnv("dex.providers", "ARB_DEX_PROVIDERS", "DEX_PROVIDERS")
+	v.BindEnv("pricing.cex_providers", "ARB_PRICING_CEX_PROVIDERS")
+	v.BindEnv("pricing.dex_providers", "ARB_PRICING_DEX_PROVIDERS")
+	v.BindEnv("pricing.cex_quote", "ARB_PRICING_CEX_QUOTE")
+
+	// Coinbase
+	v.BindEnv("coinbase.websocket_url", "ARB_COINBASE_WS_URL", "COINBASE_WS_URL")
+	v.BindEnv("coinbase.product_ids", "ARB_COINBASE_PRODUCT_IDS", "COINBASE_PRODUCT_IDS")
+
+	// Kraken
+	v.BindEnv("kraken.websocket_url", "ARB_KRAKEN_WS_URL")
+	v.BindEnv("kraken.pairs", "ARB_KRAKEN_PAIRS")
 
 	// Uniswap
 	v.BindEnv("uniswap.quoter_address", "ARB_UNISWAP_QUOTER", "UNISWAP_QUOTER")
 	v.BindEnv("uniswap.router_address", "ARB_UNISWAP_ROUTER", "UNISWAP_ROUTER")
 	v.BindEnv("uniswap.factory_address", "ARB_UNISWAP_FACTORY", "UNISWAP_FACTORY")
+	v.BindEnv("uniswap.multicall_address", "ARB_UNISWAP_MULTICALL")
+	v.BindEnv("uniswap.max_price_impact_bps", "ARB_UNISWAP_MAX_PRICE_IMPACT_BPS")
 
 	// Arbitrage
 	v.BindEnv("arbitrage.pairs", "ARB_PAIRS")
+	v.BindEnv("arbitrage.trade_sizes_usd", "ARB_TRADE_SIZES_USD")
+	v.BindEnv("arbitrage.deny_pairs", "ARB_DENY_PAIRS")
 	v.BindEnv("arbitrage.min_profit_bps", "ARB_MIN_PROFIT_BPS")
 	v.BindEnv("arbitrage.min_profit_usd", "ARB_MIN_PROFIT_USD")
+	v.BindEnv("arbitrage.max_capital_usd", "ARB_MAX_CAPITAL_USD")
+	v.BindEnv("arbitrage.max_snapshot_age", "ARB_MAX_SNAPSHOT_AGE")
+	v.BindEnv("arbitrage.default_swap_gas_limit", "ARB_DEFAULT_SWAP_GAS_LIMIT")
+	v.BindEnv("arbitrage.eth_price_symbol", "ARB_ETH_PRICE_SYMBOL")
+	v.BindEnv("arbitrage.simulate_execution", "ARB_SIMULATE_EXECUTION")
+	v.BindEnv("arbitrage.scan_interval", "ARB_SCAN_INTERVAL")
+	v.BindEnv("arbitrage.dedupe", "ARB_DEDUPE")
+	v.BindEnv("arbitrage.summary_interval", "ARB_SUMMARY_INTERVAL")
+	v.BindEnv("arbitrage.safety.max_gas_gwei", "ARB_SAFETY_MAX_GAS_GWEI")
+	v.BindEnv("arbitrage.safety.max_price_move_pct", "ARB_SAFETY_MAX_PRICE_MOVE_PCT")
+	v.BindEnv("arbitrage.safety.halt_on_disconnect", "ARB_SAFETY_HALT_ON_DISCONNECT")
+	v.BindEnv("arbitrage.safety.resume_after_blocks", "ARB_SAFETY_RESUME_AFTER_BLOCKS")
+	v.BindEnv("arbitrage.safety.exit_on_halt", "ARB_SAFETY_EXIT_ON_HALT")
+	v.BindEnv("spread.basis", "ARB_SPREAD_BASIS")
+
+	// Storage
+	v.BindEnv("storage.enabled", "ARB_STORAGE_ENABLED")
+	v.BindEnv("storage.path", "ARB_STORAGE_PATH")
+
+	// Recording
+	v.BindEnv("recording.dir", "ARB_RECORDING_DIR")
+
+	// Alerts
+	v.BindEnv("alerts.webhook_url", "ARB_ALERTS_WEBHOOK_URL", "ALERTS_WEBHOOK_URL")
+	v.BindEnv("alerts.min_profit_usd", "ARB_ALERTS_MIN_PROFIT_USD")
+	v.BindEnv("alerts.telegram_bot_token", "ARB_TELEGRAM_BOT_TOKEN", "TELEGRAM_BOT_TOKEN")
+	v.BindEnv("alerts.telegram_chat_id", "ARB_TELEGRAM_CHAT_ID", "TELEGRAM_CHAT_ID")
+	v.BindEnv("alerts.send_summaries", "ARB_ALERTS_SEND_SUMMARIES")
+
+	// API
+	v.BindEnv("api.enabled", "ARB_API_ENABLED")
+	v.BindEnv("api.port", "ARB_API_PORT")
+
+	// gRPC
+	v.BindEnv("grpc.enabled", "ARB_GRPC_ENABLED")
+	v.BindEnv("grpc.port", "ARB_GRPC_PORT")
+
+	// UI
+	v.BindEnv("ui.opportunity_ttl", "ARB_UI_OPPORTUNITY_TTL")
+	v.BindEnv("ui.remove_expired", "ARB_UI_REMOVE_EXPIRED")
+	v.BindEnv("ui.display_precision", "ARB_UI_DISPLAY_PRECISION")
 
 	// Telemetry
 	v.BindEnv("telemetry.enabled", "ARB_OTEL_ENABLED", "OTEL_ENABLED")
 	v.BindEnv("telemetry.service_name", "ARB_OTEL_SERVICE_NAME", "OTEL_SERVICE_NAME")
 	v.BindEnv("telemetry.otlp_endpoint", "ARB_OTEL_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT")
+	v.BindEnv("telemetry.trace_exporter", "ARB_OTEL_TRACE_EXPORTER")
+	v.BindEnv("telemetry.sample_ratio", "ARB_OTEL_SAMPLE_RATIO")
+	v.BindEnv("telemetry.metric_prefix", "ARB_OTEL_METRIC_PREFIX")
 }
 
 func setDefaults(v *viper.Viper) {
@@ -185,35 +947,125 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("app.name", "arbitrage-bot")
 	v.SetDefault("app.environment", "development")
 	v.SetDefault("app.log_level", "info")
+	v.SetDefault("app.pprof_port", 0) // disabled
+	v.SetDefault("app.debug_port", 0) // disabled
 
 	// Ethereum defaults
 	v.SetDefault("ethereum.chain_id", 1)
 	v.SetDefault("ethereum.max_reconnects", 0) // infinite
 	v.SetDefault("ethereum.initial_backoff", "1s")
 	v.SetDefault("ethereum.max_backoff", "30s")
+	v.SetDefault("ethereum.backoff_reset_after", "1m")
+	v.SetDefault("ethereum.confirmation_depth", 0)
+	v.SetDefault("ethereum.fee_history_blocks", 0)
+	v.SetDefault("ethereum.reward_percentile", 50)
+	v.SetDefault("ethereum.block_time", "12s")
 
 	// Binance defaults
 	v.SetDefault("binance.websocket_url", "wss://stream.binance.com:9443")
 	v.SetDefault("binance.symbols", []string{"ETHUSDC"})
 	v.SetDefault("binance.depth_speed_ms", 100)
 	v.SetDefault("binance.stale_timeout", "5s")
+	v.SetDefault("binance.diff_depth", false)
+	v.SetDefault("binance.compression", "context_takeover")
+	v.SetDefault("binance.invalid_symbols", "drop")
+	v.SetDefault("binance.clock_skew", "warn")
+	v.SetDefault("binance.max_clock_skew", "1s")
+	v.SetDefault("binance.max_book_levels", 0)
+
+	// CEX defaults
+	v.SetDefault("cex.provider", CEXProviderBinance)
+	v.SetDefault("cex.reject_partial_fills", false)
+
+	// Coinbase defaults
+	v.SetDefault("coinbase.websocket_url", "wss://advanced-trade-ws.coinbase.com")
+	v.SetDefault("coinbase.http_url", "https://api.exchange.coinbase.com")
+	v.SetDefault("coinbase.product_ids", []string{"ETH-USD"})
+	v.SetDefault("coinbase.stale_timeout", "5s")
+
+	// Kraken defaults
+	v.SetDefault("kraken.websocket_url", "wss://ws.kraken.com")
+	v.SetDefault("kraken.http_url", "https://api.kraken.com")
+	v.SetDefault("kraken.pairs", []string{"ETH/USDC"})
+	v.SetDefault("kraken.depth", 10)
+	v.SetDefault("kraken.stale_timeout", "5s")
 
 	// Uniswap V3 Mainnet defaults
 	v.SetDefault("uniswap.quoter_address", "0x61fFE014bA17989E743c5F6cB21bF9697530B21e")
 	v.SetDefault("uniswap.router_address", "0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45")
 	v.SetDefault("uniswap.factory_address", "0x1F98431c8aD98523631AE4a59f267346ea31F984")
 	v.SetDefault("uniswap.default_fee_tier", 3000) // 0.3%
+	v.SetDefault("uniswap.v2_factory_address", "0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+	v.SetDefault("uniswap.multicall_address", "0xcA11bde05977b3631167028862bE2a173976CA11")
+	v.SetDefault("uniswap.quote_retries", 2)
+	v.SetDefault("uniswap.quote_retry_backoff", "50ms")
+	v.SetDefault("uniswap.max_price_impact_bps", 0) // no limit
+
+	// DEX defaults
+	v.SetDefault("dex.providers", []string{DEXProviderUniswapV3})
 
 	// Arbitrage defaults
 	v.SetDefault("arbitrage.pairs", []string{"ETH-USDC"})
 	v.SetDefault("arbitrage.trade_sizes", []float64{0.1, 0.5, 1.0})
 	v.SetDefault("arbitrage.min_profit_bps", 10)
 	v.SetDefault("arbitrage.min_profit_usd", 5)
+	v.SetDefault("arbitrage.slippage_impact_factor", 1.0)
+	v.SetDefault("arbitrage.slippage_max_trade_size", 50)
+	v.SetDefault("arbitrage.execution_mode", ExecutionModeTaker)
+	v.SetDefault("arbitrage.max_capital_usd", 0)
+	v.SetDefault("arbitrage.max_snapshot_age", "2s")
+	v.SetDefault("arbitrage.default_swap_gas_limit", 200_000)
+	v.SetDefault("arbitrage.eth_price_symbol", "ETH-USDC")
+	v.SetDefault("arbitrage.simulate_execution", false)
+	v.SetDefault("arbitrage.scan_interval", "0s")
+	v.SetDefault("arbitrage.dedupe", false)
+	v.SetDefault("arbitrage.summary_interval", "1m")
+	v.SetDefault("arbitrage.safety.max_gas_gwei", 0)
+	v.SetDefault("arbitrage.safety.max_price_move_pct", 0)
+	v.SetDefault("arbitrage.safety.halt_on_disconnect", false)
+	v.SetDefault("arbitrage.safety.resume_after_blocks", 0)
+	v.SetDefault("arbitrage.safety.exit_on_halt", false)
+	v.SetDefault("arbitrage.approval_gas", 0)
+	v.SetDefault("arbitrage.cex_withdrawal_fee_usd", 0)
+	v.SetDefault("arbitrage.cex_deposit_fee_usd", 0)
+	v.SetDefault("spread.basis", SpreadBasisAsk)
+
+	// Storage defaults
+	v.SetDefault("storage.enabled", false)
+	v.SetDefault("storage.path", "opportunities.db")
+	v.SetDefault("storage.batch_size", 50)
+	v.SetDefault("storage.flush_interval", "5s")
+
+	// Recording defaults
+	v.SetDefault("recording.max_file_size_mb", 100)
+	v.SetDefault("recording.rotate_interval", "1h")
+
+	// Alerts defaults
+	v.SetDefault("alerts.min_profit_usd", 50)
+	v.SetDefault("alerts.debounce", "1m")
+	v.SetDefault("alerts.telegram_messages_per_minute", 20)
+	v.SetDefault("alerts.send_summaries", false)
+
+	// API defaults
+	v.SetDefault("api.enabled", false)
+	v.SetDefault("api.port", 8082)
+
+	// gRPC defaults
+	v.SetDefault("grpc.enabled", false)
+	v.SetDefault("grpc.port", 50051)
+	v.SetDefault("grpc.buffer_size", 64)
+
+	// UI defaults
+	v.SetDefault("ui.opportunity_ttl", time.Minute)
+	v.SetDefault("ui.remove_expired", false)
+	v.SetDefault("ui.display_precision", 2)
 
 	// Telemetry defaults
 	v.SetDefault("telemetry.enabled", false)
 	v.SetDefault("telemetry.service_name", "arbitrage-bot")
 	v.SetDefault("telemetry.prometheus_port", 9090)
+	v.SetDefault("telemetry.trace_exporter", TraceExporterZipkin)
+	v.SetDefault("telemetry.sample_ratio", 1.0)
 }
 
 // Validate validates the configuration.
@@ -224,14 +1076,365 @@ func (c *Config) Validate() error {
 	if c.Ethereum.HTTPURL == "" {
 		return fmt.Errorf("ethereum.http_url is required")
 	}
+	if err := validateURL("ethereum.websocket_url", c.Ethereum.WebSocketURL, "ws", "wss"); err != nil {
+		return err
+	}
+	if err := validateURL("ethereum.http_url", c.Ethereum.HTTPURL, "http", "https"); err != nil {
+		return err
+	}
+	if c.Ethereum.ChainID == 0 {
+		return apperror.New(apperror.CodeInvalidChainID,
+			apperror.WithContext("ethereum.chain_id must be greater than zero"))
+	}
+	if c.Ethereum.RewardPercentile < 0 || c.Ethereum.RewardPercentile > 100 {
+		return fmt.Errorf("ethereum.reward_percentile must be between 0 and 100")
+	}
+	if c.Ethereum.BlockTime <= 0 {
+		return fmt.Errorf("ethereum.block_time must be positive")
+	}
+	if c.Ethereum.InitialBackoff <= 0 || c.Ethereum.MaxBackoff < c.Ethereum.InitialBackoff {
+		return fmt.Errorf("ethereum.initial_backoff must be positive and not above ethereum.max_backoff")
+	}
+	if c.Ethereum.BackoffResetAfter < 0 {
+		return fmt.Errorf("ethereum.backoff_reset_after cannot be negative")
+	}
+	if c.Ethereum.MaxReconnects < 0 {
+		return fmt.Errorf("ethereum.max_reconnects cannot be negative")
+	}
+	for symbol, token := range c.Ethereum.Tokens {
+		if !common.IsHexAddress(token.Address) {
+			return fmt.Errorf("invalid ethereum.tokens.%s.address: %s", symbol, token.Address)
+		}
+	}
 	if !common.IsHexAddress(c.Uniswap.QuoterAddress) {
 		return fmt.Errorf("invalid uniswap.quoter_address: %s", c.Uniswap.QuoterAddress)
 	}
 	if !common.IsHexAddress(c.Uniswap.RouterAddress) {
 		return fmt.Errorf("invalid uniswap.router_address: %s", c.Uniswap.RouterAddress)
 	}
-	if len(c.Binance.Symbols) == 0 {
-		return fmt.Errorf("binance.symbols cannot be empty")
+	if c.Uniswap.MulticallAddress != "" && !common.IsHexAddress(c.Uniswap.MulticallAddress) {
+		return fmt.Errorf("invalid uniswap.multicall_address: %s", c.Uniswap.MulticallAddress)
+	}
+	if c.Uniswap.QuoteRetries < 0 || c.Uniswap.QuoteRetryBackoff < 0 {
+		return fmt.Errorf("uniswap quote retry settings cannot be negative")
+	}
+	if c.Uniswap.MaxPriceImpactBps < 0 || c.Uniswap.MaxPriceImpactBps >= 10_000 {
+		return fmt.Errorf("uniswap.max_price_impact_bps must be between 0 and 9999, got %d", c.Uniswap.MaxPriceImpactBps)
+	}
+	// Provider names are checked against the pricing provider registry at
+	// startup; only settings of the built-in providers are validated here
+	seen := make(map[string]bool)
+	for _, venue := range c.CEXProviderNames() {
+		if seen[venue] {
+			return fmt.Errorf("duplicate cex provider: %s", venue)
+		}
+		seen[venue] = true
+
+		switch venue {
+		case CEXProviderBinance:
+			if len(c.Binance.Symbols) == 0 {
+				return fmt.Errorf("binance.symbols cannot be empty")
+			}
+			if err := c.Binance.validate(); err != nil {
+				return err
+			}
+		case CEXProviderCoinbase:
+			if len(c.Coinbase.ProductIDs) == 0 {
+				return fmt.Errorf("coinbase.product_ids cannot be empty")
+			}
+		case CEXProviderKraken:
+			if err := c.Kraken.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(c.DEXProviderNames()) == 0 {
+		return fmt.Errorf("dex providers cannot be empty")
+	}
+	if err := c.Pricing.validate(); err != nil {
+		return err
+	}
+	if err := c.DEX.validateForks(); err != nil {
+		return err
+	}
+	for _, provider := range c.DEXProviderNames() {
+		if provider == DEXProviderUniswapV2 && !common.IsHexAddress(c.Uniswap.V2FactoryAddress) {
+			return fmt.Errorf("invalid uniswap.v2_factory_address: %s", c.Uniswap.V2FactoryAddress)
+		}
+	}
+	if err := c.Arbitrage.validatePairs(); err != nil {
+		return err
+	}
+	if err := c.Arbitrage.validateTradeSizes(); err != nil {
+		return err
+	}
+	if c.Arbitrage.SlippageImpactFactor < 0 || c.Arbitrage.SlippageMaxTradeSize < 0 {
+		return fmt.Errorf("arbitrage slippage settings cannot be negative")
+	}
+	switch c.Arbitrage.ExecutionMode {
+	case ExecutionModeTaker, ExecutionModeMaker:
+	default:
+		return fmt.Errorf("invalid arbitrage.execution_mode: %s (expected %s or %s)",
+			c.Arbitrage.ExecutionMode, ExecutionModeTaker, ExecutionModeMaker)
+	}
+	if c.Arbitrage.MaxCapitalUSD < 0 {
+		return fmt.Errorf("arbitrage.max_capital_usd cannot be negative")
+	}
+	if c.Arbitrage.MaxSnapshotAge < 0 {
+		return fmt.Errorf("arbitrage.max_snapshot_age cannot be negative")
+	}
+	if c.Arbitrage.ScanInterval < 0 {
+		return fmt.Errorf("arbitrage.scan_interval cannot be negative")
+	}
+	if c.Arbitrage.SummaryInterval < 0 {
+		return fmt.Errorf("arbitrage.summary_interval cannot be negative")
+	}
+	if s := c.Arbitrage.Safety; s.MaxGasGwei < 0 || s.MaxPriceMovePct < 0 || s.ResumeAfterBlocks < 0 {
+		return fmt.Errorf("arbitrage.safety thresholds cannot be negative")
+	}
+	if c.Arbitrage.DefaultSwapGasLimit == 0 {
+		return fmt.Errorf("arbitrage.default_swap_gas_limit must be positive")
+	}
+	if c.Arbitrage.CEXWithdrawalFeeUSD < 0 || c.Arbitrage.CEXDepositFeeUSD < 0 {
+		return fmt.Errorf("arbitrage CEX transfer fees cannot be negative")
+	}
+	switch c.Spread.Basis {
+	case SpreadBasisAsk, SpreadBasisMid, SpreadBasisMicro:
+	default:
+		return fmt.Errorf("invalid spread.basis: %s (expected %s, %s or %s)",
+			c.Spread.Basis, SpreadBasisAsk, SpreadBasisMid, SpreadBasisMicro)
+	}
+	if c.Storage.Enabled && c.Storage.Path == "" {
+		return fmt.Errorf("storage.path is required when storage is enabled")
+	}
+	if c.Recording.MaxFileSizeMB < 0 || c.Recording.RotateInterval < 0 {
+		return fmt.Errorf("recording rotation settings cannot be negative")
+	}
+	if c.Alerts.WebhookURL != "" {
+		if u, err := url.Parse(c.Alerts.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid alerts.webhook_url: %s", c.Alerts.WebhookURL)
+		}
+	}
+	if (c.Alerts.TelegramBotToken == "") != (c.Alerts.TelegramChatID == "") {
+		return fmt.Errorf("alerts.telegram_bot_token and alerts.telegram_chat_id must be set together")
+	}
+	if c.App.PprofPort < 0 || c.App.PprofPort > 65535 {
+		return fmt.Errorf("app.pprof_port must be between 0 and 65535, got %d", c.App.PprofPort)
+	}
+	if c.App.DebugPort < 0 || c.App.DebugPort > 65535 {
+		return fmt.Errorf("app.debug_port must be between 0 and 65535, got %d", c.App.DebugPort)
+	}
+	if c.API.Enabled && (c.API.Port <= 0 || c.API.Port > 65535) {
+		return fmt.Errorf("api.port must be between 1 and 65535, got %d", c.API.Port)
+	}
+	if c.GRPC.Enabled && (c.GRPC.Port <= 0 || c.GRPC.Port > 65535) {
+		return fmt.Errorf("grpc.port must be between 1 and 65535, got %d", c.GRPC.Port)
+	}
+	if c.UI.OpportunityTTL < 0 {
+		return fmt.Errorf("ui.opportunity_ttl must not be negative, got %s", c.UI.OpportunityTTL)
+	}
+	if c.UI.DisplayPrecision < 0 || c.UI.DisplayPrecision > maxDisplayPrecision {
+		return fmt.Errorf("ui.display_precision must be between 0 and %d, got %d", maxDisplayPrecision, c.UI.DisplayPrecision)
+	}
+	if c.Telemetry.Enabled {
+		switch c.Telemetry.TraceExporter {
+		case TraceExporterZipkin, TraceExporterJaeger, TraceExporterConsole:
+		default:
+			return fmt.Errorf("invalid telemetry.trace_exporter: %s (expected %s, %s or %s)",
+				c.Telemetry.TraceExporter, TraceExporterZipkin, TraceExporterJaeger, TraceExporterConsole)
+		}
+		if c.Telemetry.SampleRatio < 0 || c.Telemetry.SampleRatio > 1 {
+			return fmt.Errorf("telemetry.sample_ratio must be between 0 and 1, got %v", c.Telemetry.SampleRatio)
+		}
+		if c.Telemetry.MetricPrefix != "" && !metricPrefixPattern.MatchString(c.Telemetry.MetricPrefix) {
+			return fmt.Errorf("invalid telemetry.metric_prefix: %q (letters, digits and underscores, not starting with a digit)", c.Telemetry.MetricPrefix)
+		}
+	}
+	return nil
+}
+
+// validate checks the Binance stream and proxy URLs and symbol format.
+func (c *BinanceConfig) validate() error {
+	if err := validateURL("binance.websocket_url", c.WebSocketURL, "ws", "wss"); err != nil {
+		return err
+	}
+	if c.ProxyURL != "" {
+		if err := validateURL("binance.proxy_url", c.ProxyURL, "http", "https", "socks5", "socks5h"); err != nil {
+			return err
+		}
+	}
+	switch c.Compression {
+	case "context_takeover", "no_context_takeover", "disabled":
+	default:
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("invalid binance.compression %q (expected context_takeover, no_context_takeover or disabled)", c.Compression)))
+	}
+	switch c.InvalidSymbols {
+	case "drop", "fail", "ignore":
+	default:
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("invalid binance.invalid_symbols %q (expected drop, fail or ignore)", c.InvalidSymbols)))
+	}
+	switch c.ClockSkew {
+	case "warn", "fail", "ignore":
+	default:
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("invalid binance.clock_skew %q (expected warn, fail or ignore)", c.ClockSkew)))
+	}
+	if c.MaxClockSkew <= 0 {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("binance.max_clock_skew must be positive, got %s", c.MaxClockSkew)))
+	}
+	if c.MaxBookLevels < 0 {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext(fmt.Sprintf("binance.max_book_levels cannot be negative, got %d", c.MaxBookLevels)))
+	}
+	if (c.APIKey == "") != (c.APISecret == "") {
+		return apperror.New(apperror.CodeConfigurationError,
+			apperror.WithContext("ARB_BINANCE_API_KEY and ARB_BINANCE_API_SECRET must be set together"))
+	}
+	for _, symbol := range c.Symbols {
+		if !binanceSymbolPattern.MatchString(symbol) {
+			return apperror.New(apperror.CodeInvalidSymbol,
+				apperror.WithContext(fmt.Sprintf("invalid binance symbol %q (expected uppercase alphanumeric, e.g. ETHUSDC)", symbol)))
+		}
+	}
+	return c.validateSymbolOverrides()
+}
+
+// validateSymbolOverrides checks that every override maps a BASE-QUOTE pair
+// to a subscribed symbol, and no symbol is claimed by two pairs.
+func (c *BinanceConfig) validateSymbolOverrides() error {
+	pairsBySymbol := make(map[string]string, len(c.SymbolOverrides))
+	for pair, symbol := range c.SymbolOverrides {
+		base, quote, ok := strings.Cut(pair, "-")
+		if !ok || strings.TrimSpace(base) == "" || strings.TrimSpace(quote) == "" {
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("invalid binance.symbol_overrides pair %q (expected BASE-QUOTE)", pair)))
+		}
+		if !slices.Contains(c.Symbols, symbol) {
+			return apperror.New(apperror.CodeInvalidSymbol,
+				apperror.WithContext(fmt.Sprintf("binance.symbol_overrides maps %s to %q, which is not in binance.symbols", pair, symbol)))
+		}
+		if other, ok := pairsBySymbol[symbol]; ok {
+			return apperror.New(apperror.CodeConfigurationError,
+				apperror.WithContext(fmt.Sprintf("binance.symbol_overrides maps both %s and %s to %s", other, pair, symbol)))
+		}
+		pairsBySymbol[symbol] = pair
+	}
+	return nil
+}
+
+// krakenDepths are the book depths Kraken accepts on subscribe.
+var krakenDepths = []int{10, 25, 100, 500, 1000}
+
+// validate checks the Kraken URLs, pairs and book depth.
+func (c *KrakenConfig) validate() error {
+	if len(c.Pairs) == 0 {
+		return fmt.Errorf("kraken.pairs cannot be empty")
+	}
+	if err := validateURL("kraken.websocket_url", c.WebSocketURL, "ws", "wss"); err != nil {
+		return err
+	}
+	if err := validateURL("kraken.http_url", c.HTTPURL, "http", "https"); err != nil {
+		return err
+	}
+	for _, pair := range c.Pairs {
+		if !krakenPairPattern.MatchString(pair) {
+			return apperror.New(apperror.CodeInvalidSymbol,
+				apperror.WithContext(fmt.Sprintf("invalid kraken pair %q (expected BASE/QUOTE, e.g. XBT/USD)", pair)))
+		}
+	}
+	if !slices.Contains(krakenDepths, c.Depth) {
+		return fmt.Errorf("invalid kraken.depth: %d (expected one of %v)", c.Depth, krakenDepths)
+	}
+	return nil
+}
+
+// validateURL checks that raw parses as an absolute URL with one of the allowed schemes and a host.
+// Errors name the URL by scheme and host only: RPC URLs carry API keys in the path or query.
+func validateURL(field, raw string, schemes ...string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		// *url.Error repeats the raw URL; keep only the underlying reason
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return apperror.New(apperror.CodeInvalidEndpointURL,
+			apperror.WithCause(err),
+			apperror.WithContext(fmt.Sprintf("%s is not a valid URL", field)))
+	}
+	if !slices.Contains(schemes, u.Scheme) || u.Host == "" {
+		return apperror.New(apperror.CodeInvalidEndpointURL,
+			apperror.WithContext(fmt.Sprintf("%s must be a %s URL: %s", field, strings.Join(schemes, "/"), redactURL(u))))
+	}
+	return nil
+}
+
+// redactURL returns u's scheme and host, dropping credentials, path and query.
+func redactURL(u *url.URL) string {
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+}
+
+// validatePairs checks pair symbols and per-pair threshold overrides.
+func (c *ArbitrageConfig) validateTradeSizes() error {
+	if len(c.TradeSizes) > 0 && len(c.TradeSizesUSD) > 0 {
+		return fmt.Errorf("arbitrage.trade_sizes and arbitrage.trade_sizes_usd are mutually exclusive")
+	}
+	if len(c.TradeSizes) == 0 && len(c.TradeSizesUSD) == 0 {
+		return fmt.Errorf("arbitrage.trade_sizes or arbitrage.trade_sizes_usd is required")
+	}
+	for _, size := range c.TradeSizes {
+		if size <= 0 {
+			return fmt.Errorf("arbitrage.trade_sizes must be positive, got %v", size)
+		}
+	}
+	for _, size := range c.TradeSizesUSD {
+		if size <= 0 {
+			return fmt.Errorf("arbitrage.trade_sizes_usd must be positive, got %v", size)
+		}
+	}
+	// Cycles start from an amount of their first asset, not a USD notional
+	if len(c.TriangularCycles) > 0 && len(c.TradeSizes) == 0 {
+		return fmt.Errorf("arbitrage.triangular_cycles require arbitrage.trade_sizes")
+	}
+	return nil
+}
+
+func (c *ArbitrageConfig) validatePairs() error {
+	seen := make(map[string]bool, len(c.Pairs))
+	for _, p := range c.Pairs {
+		base, quote, ok := strings.Cut(p.Symbol, "-")
+		if !ok || strings.TrimSpace(base) == "" || strings.TrimSpace(quote) == "" {
+			return fmt.Errorf("invalid arbitrage pair %q (expected BASE-QUOTE)", p.Symbol)
+		}
+		if seen[p.Symbol] {
+			return fmt.Errorf("duplicate arbitrage pair: %s", p.Symbol)
+		}
+		seen[p.Symbol] = true
+
+		if p.MinProfitBps != nil && *p.MinProfitBps < 0 {
+			return fmt.Errorf("arbitrage pair %s: min_profit_bps cannot be negative", p.Symbol)
+		}
+		if p.MinProfitUSD != nil && *p.MinProfitUSD < 0 {
+			return fmt.Errorf("arbitrage pair %s: min_profit_usd cannot be negative", p.Symbol)
+		}
+	}
+	for _, denied := range c.DenyPairs {
+		base, quote, ok := strings.Cut(denied, "-")
+		if !ok || strings.TrimSpace(base) == "" || strings.TrimSpace(quote) == "" {
+			return fmt.Errorf("invalid arbitrage.deny_pairs entry %q (expected BASE-QUOTE)", denied)
+		}
+	}
+	if len(c.Pairs) > 0 && len(c.PairSymbols()) == 0 {
+		return fmt.Errorf("arbitrage.deny_pairs excludes every configured pair")
+	}
+	if symbol := c.ETHPriceSymbol; symbol != "" {
+		base, quote, ok := strings.Cut(symbol, "-")
+		if !ok || strings.TrimSpace(base) == "" || strings.TrimSpace(quote) == "" {
+			return fmt.Errorf("invalid arbitrage.eth_price_symbol %q (expected BASE-QUOTE)", symbol)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,486 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/fd1az/arbitrage-bot/internal/apperror"
+)
+
+// writeConfig writes a minimal valid config with the given arbitrage section.
+func writeConfig(t *testing.T, arbitrage string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `ethereum:
+  websocket_url: wss://example.invalid
+  http_url: https://example.invalid
+arbitrage:
+  min_profit_bps: 10
+  min_profit_usd: 5
+` + arbitrage
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoad_PairThresholds(t *testing.T) {
+	path := writeConfig(t, `  pairs:
+    - ETH-USDC
+    - symbol: WBTC-USDC
+      min_profit_bps: 25
+    - symbol: USDT-USDC
+      min_profit_usd: 1
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tests := []struct {
+		symbol  string
+		wantBps int64
+		wantUSD int64
+	}{
+		{symbol: "ETH-USDC", wantBps: 10, wantUSD: 5},
+		{symbol: "WBTC-USDC", wantBps: 25, wantUSD: 5},
+		{symbol: "USDT-USDC", wantBps: 10, wantUSD: 1},
+	}
+
+	if len(cfg.Arbitrage.Pairs) != len(tests) {
+		t.Fatalf("expected %d pairs, got %d", len(tests), len(cfg.Arbitrage.Pairs))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			pair := cfg.Arbitrage.Pairs[i]
+			if pair.Symbol != tt.symbol {
+				t.Fatalf("expected symbol %s, got %s", tt.symbol, pair.Symbol)
+			}
+			bps, usd := cfg.Arbitrage.PairThresholds(pair)
+			if !bps.Equal(decimal.NewFromInt(tt.wantBps)) {
+				t.Errorf("expected min_profit_bps %d, got %s", tt.wantBps, bps)
+			}
+			if !usd.Equal(decimal.NewFromInt(tt.wantUSD)) {
+				t.Errorf("expected min_profit_usd %d, got %s", tt.wantUSD, usd)
+			}
+		})
+	}
+}
+
+func TestLoad_PairsFromEnv(t *testing.T) {
+	t.Setenv("ARB_PAIRS", "ETH-USDC,WBTC-USDC")
+
+	cfg, err := Load(writeConfig(t, ""))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	symbols := cfg.Arbitrage.PairSymbols()
+	if len(symbols) != 2 || symbols[0] != "ETH-USDC" || symbols[1] != "WBTC-USDC" {
+		t.Errorf("expected [ETH-USDC WBTC-USDC], got %v", symbols)
+	}
+}
+
+func TestLoad_DenyPairs(t *testing.T) {
+	t.Setenv("ARB_PAIRS", "ETH-USDC,WBTC-USDC,USDT-USDC")
+	t.Setenv("ARB_DENY_PAIRS", "WBTC-USDC")
+
+	cfg, err := Load(writeConfig(t, ""))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	symbols := cfg.Arbitrage.PairSymbols()
+	if len(symbols) != 2 || symbols[0] != "ETH-USDC" || symbols[1] != "USDT-USDC" {
+		t.Errorf("expected [ETH-USDC USDT-USDC], got %v", symbols)
+	}
+}
+
+// TestLoad_SymbolOverrides tests that override pairs come back upper-case
+// although viper lowercases map keys.
+func TestLoad_SymbolOverrides(t *testing.T) {
+	cfg, err := Load(writeConfig(t, `binance:
+  symbols: [ETHUSDC, BTCUSDC]
+  symbol_overrides:
+    WBTC-USDC: BTCUSDC
+`))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if got := cfg.Binance.SymbolOverrides["WBTC-USDC"]; got != "BTCUSDC" {
+		t.Errorf("expected WBTC-USDC -> BTCUSDC, got %v", cfg.Binance.SymbolOverrides)
+	}
+}
+
+// TestLoad_StableEquivalents tests that stable symbols come back upper-case
+// although viper lowercases map keys.
+func TestLoad_StableEquivalents(t *testing.T) {
+	cfg, err := Load(writeConfig(t, `pricing:
+  stable_equivalents:
+    USDC: 1
+    USDT: 0.999
+  cex_quote: usdt
+`))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if got := cfg.Pricing.StableEquivalents["USDT"]; got != 0.999 {
+		t.Errorf("expected USDT pegged at 0.999, got %v", cfg.Pricing.StableEquivalents)
+	}
+	if cfg.Pricing.CEXQuote != "USDT" {
+		t.Errorf("expected cex_quote USDT, got %q", cfg.Pricing.CEXQuote)
+	}
+}
+
+func TestLoad_TradeSizesUSD(t *testing.T) {
+	cfg, err := Load(writeConfig(t, `  trade_sizes_usd: [1000, 5000]
+`))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.Arbitrage.TradeSizes) != 0 {
+		t.Errorf("expected USD sizes to drop the trade_sizes default, got %v", cfg.Arbitrage.TradeSizes)
+	}
+	if len(cfg.Arbitrage.TradeSizesUSD) != 2 || cfg.Arbitrage.TradeSizesUSD[1] != 5000 {
+		t.Errorf("expected [1000 5000], got %v", cfg.Arbitrage.TradeSizesUSD)
+	}
+
+	// Both written out is ambiguous
+	if _, err := Load(writeConfig(t, `  trade_sizes: [1]
+  trade_sizes_usd: [1000]
+`)); err == nil {
+		t.Error("expected trade_sizes with trade_sizes_usd to be rejected")
+	}
+}
+
+func TestValidate_Pairs(t *testing.T) {
+	negative := -1.0
+
+	tests := []struct {
+		name     string
+		pairs    []PairConfig
+		deny     []string
+		ethPrice string
+		wantErr  bool
+	}{
+		{name: "valid", pairs: []PairConfig{{Symbol: "ETH-USDC"}}},
+		{name: "missing_quote", pairs: []PairConfig{{Symbol: "ETH"}}, wantErr: true},
+		{name: "duplicate", pairs: []PairConfig{{Symbol: "ETH-USDC"}, {Symbol: "ETH-USDC"}}, wantErr: true},
+		{name: "negative_bps", pairs: []PairConfig{{Symbol: "ETH-USDC", MinProfitBps: &negative}}, wantErr: true},
+		{name: "negative_usd", pairs: []PairConfig{{Symbol: "ETH-USDC", MinProfitUSD: &negative}}, wantErr: true},
+		{name: "deny_one", pairs: []PairConfig{{Symbol: "ETH-USDC"}, {Symbol: "WBTC-USDC"}}, deny: []string{"wbtc-usdc"}},
+		{name: "deny_malformed", pairs: []PairConfig{{Symbol: "ETH-USDC"}}, deny: []string{"WBTC"}, wantErr: true},
+		{name: "deny_all", pairs: []PairConfig{{Symbol: "ETH-USDC"}}, deny: []string{"ETH-USDC"}, wantErr: true},
+		{name: "eth_price_unscanned", pairs: []PairConfig{{Symbol: "WBTC-USDT"}}, ethPrice: "ETH-USDT"},
+		{name: "eth_price_malformed", pairs: []PairConfig{{Symbol: "ETH-USDC"}}, ethPrice: "ETHUSDC", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ArbitrageConfig{Pairs: tt.pairs, DenyPairs: tt.deny, ETHPriceSymbol: tt.ethPrice}
+			err := cfg.validatePairs()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePairs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Endpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(c *Config)
+		wantCode apperror.Code
+	}{
+		{name: "valid", mutate: func(c *Config) {}},
+		{
+			name:     "ws_url_http_scheme",
+			mutate:   func(c *Config) { c.Ethereum.WebSocketURL = "https://mainnet.example" },
+			wantCode: apperror.CodeInvalidEndpointURL,
+		},
+		{
+			name:     "ws_url_missing_host",
+			mutate:   func(c *Config) { c.Ethereum.WebSocketURL = "wss://" },
+			wantCode: apperror.CodeInvalidEndpointURL,
+		},
+		{
+			name:     "http_url_ws_scheme",
+			mutate:   func(c *Config) { c.Ethereum.HTTPURL = "wss://mainnet.example" },
+			wantCode: apperror.CodeInvalidEndpointURL,
+		},
+		{
+			name:     "zero_chain_id",
+			mutate:   func(c *Config) { c.Ethereum.ChainID = 0 },
+			wantCode: apperror.CodeInvalidChainID,
+		},
+		{
+			name:     "binance_url_scheme",
+			mutate:   func(c *Config) { c.Binance.WebSocketURL = "stream.binance.com:9443" },
+			wantCode: apperror.CodeInvalidEndpointURL,
+		},
+		{
+			name:   "binance_socks5_proxy",
+			mutate: func(c *Config) { c.Binance.ProxyURL = "socks5://127.0.0.1:1080" },
+		},
+		{
+			name:     "binance_proxy_scheme",
+			mutate:   func(c *Config) { c.Binance.ProxyURL = "ftp://127.0.0.1:21" },
+			wantCode: apperror.CodeInvalidEndpointURL,
+		},
+		{
+			name: "binance_api_credentials",
+			mutate: func(c *Config) {
+				c.Binance.APIKey = "key"
+				c.Binance.APISecret = "secret"
+			},
+		},
+		{
+			name:     "binance_api_key_without_secret",
+			mutate:   func(c *Config) { c.Binance.APIKey = "key" },
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name:   "kraken_pairs",
+			mutate: func(c *Config) { c.CEX.Providers = []string{"binance", "kraken"} },
+		},
+		{
+			name: "kraken_pair_without_slash",
+			mutate: func(c *Config) {
+				c.CEX.Providers = []string{"kraken"}
+				c.Kraken.Pairs = []string{"XBTUSD"}
+			},
+			wantCode: apperror.CodeInvalidSymbol,
+		},
+		{
+			name: "kraken_http_url_scheme",
+			mutate: func(c *Config) {
+				c.CEX.Providers = []string{"kraken"}
+				c.Kraken.HTTPURL = "wss://api.kraken.com"
+			},
+			wantCode: apperror.CodeInvalidEndpointURL,
+		},
+		{
+			name: "dex_fork",
+			mutate: func(c *Config) {
+				c.DEX.Forks = []DEXForkConfig{{Name: "sushiswap", FactoryAddress: "0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac", FeeBps: 30}}
+			},
+		},
+		{
+			name: "dex_fork_shadows_builtin",
+			mutate: func(c *Config) {
+				c.DEX.Forks = []DEXForkConfig{{Name: DEXProviderUniswapV2, FactoryAddress: "0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac", FeeBps: 30}}
+			},
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name: "dex_fork_fee_out_of_range",
+			mutate: func(c *Config) {
+				c.DEX.Forks = []DEXForkConfig{{Name: "sushiswap", FactoryAddress: "0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac", FeeBps: 10_000}}
+			},
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name:   "binance_compression_disabled",
+			mutate: func(c *Config) { c.Binance.Compression = "disabled" },
+		},
+		{
+			name:     "binance_compression_unknown",
+			mutate:   func(c *Config) { c.Binance.Compression = "gzip" },
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name:   "binance_invalid_symbols_fail",
+			mutate: func(c *Config) { c.Binance.InvalidSymbols = "fail" },
+		},
+		{
+			name:     "binance_invalid_symbols_unknown",
+			mutate:   func(c *Config) { c.Binance.InvalidSymbols = "skip" },
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name:   "binance_clock_skew_fail",
+			mutate: func(c *Config) { c.Binance.ClockSkew = "fail" },
+		},
+		{
+			name:     "binance_clock_skew_unknown",
+			mutate:   func(c *Config) { c.Binance.ClockSkew = "adjust" },
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name:     "binance_zero_max_clock_skew",
+			mutate:   func(c *Config) { c.Binance.MaxClockSkew = 0 },
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name: "stable_equivalents",
+			mutate: func(c *Config) {
+				c.Pricing.StableEquivalents = map[string]float64{"USDC": 1, "USDT": 0.999}
+				c.Pricing.CEXQuote = "USDT"
+			},
+		},
+		{
+			name:     "stable_equivalents_zero_peg",
+			mutate:   func(c *Config) { c.Pricing.StableEquivalents = map[string]float64{"USDC": 1, "USDT": 0} },
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name: "cex_quote_not_equivalent",
+			mutate: func(c *Config) {
+				c.Pricing.StableEquivalents = map[string]float64{"USDC": 1, "USDT": 1}
+				c.Pricing.CEXQuote = "DAI"
+			},
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name:     "binance_negative_max_book_levels",
+			mutate:   func(c *Config) { c.Binance.MaxBookLevels = -1 },
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name:     "binance_lowercase_symbol",
+			mutate:   func(c *Config) { c.Binance.Symbols = []string{"ethusdc"} },
+			wantCode: apperror.CodeInvalidSymbol,
+		},
+		{
+			name:     "binance_dashed_symbol",
+			mutate:   func(c *Config) { c.Binance.Symbols = []string{"ETH-USDC"} },
+			wantCode: apperror.CodeInvalidSymbol,
+		},
+		{
+			name: "binance_symbol_override",
+			mutate: func(c *Config) {
+				c.Binance.Symbols = []string{"ETHUSDC", "BTCUSDC"}
+				c.Binance.SymbolOverrides = map[string]string{"WBTC-USDC": "BTCUSDC"}
+			},
+		},
+		{
+			name:     "binance_symbol_override_unsubscribed",
+			mutate:   func(c *Config) { c.Binance.SymbolOverrides = map[string]string{"WBTC-USDC": "BTCUSDC"} },
+			wantCode: apperror.CodeInvalidSymbol,
+		},
+		{
+			name:     "binance_symbol_override_malformed_pair",
+			mutate:   func(c *Config) { c.Binance.SymbolOverrides = map[string]string{"ETHUSDC": "ETHUSDC"} },
+			wantCode: apperror.CodeConfigurationError,
+		},
+		{
+			name: "binance_symbol_override_shared_symbol",
+			mutate: func(c *Config) {
+				c.Binance.SymbolOverrides = map[string]string{"ETH-USDC": "ETHUSDC", "WETH-USDC": "ETHUSDC"}
+			},
+			wantCode: apperror.CodeConfigurationError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, ""))
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			tt.mutate(cfg)
+
+			err = cfg.Validate()
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var appErr *apperror.AppError
+			if !errors.As(err, &appErr) {
+				t.Fatalf("Validate() error = %v, want apperror %s", err, tt.wantCode)
+			}
+			if appErr.Code != tt.wantCode {
+				t.Errorf("Validate() code = %s, want %s", appErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+// TestValidate_URLErrorsRedactKeys tests that URL errors don't leak the API
+// key RPC providers put in the path or query.
+func TestValidate_URLErrorsRedactKeys(t *testing.T) {
+	const key = "s3cr3tk3y"
+	for _, raw := range []string{
+		"https://mainnet.infura.io/v3/" + key,
+		"http://user:" + key + "@node.example/?apikey=" + key,
+		"wss://node.example:bad/" + key,
+	} {
+		cfg, err := Load(writeConfig(t, ""))
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		cfg.Ethereum.WebSocketURL = raw
+
+		err = cfg.Validate()
+		if err == nil {
+			t.Fatalf("expected %q rejected as a websocket URL", raw)
+		}
+		if strings.Contains(err.Error(), key) {
+			t.Errorf("expected the key redacted, got %q", err.Error())
+		}
+	}
+}
+
+func TestLoadChain(t *testing.T) {
+	path := writeConfig(t, `chains:
+  arbitrum:
+    chain_id: 42161
+    http_url: https://arbitrum.invalid
+    block_time: 250ms
+    v2_factory_address: "0xf1D7CC64Fb4452F05c498126312eBE29f30Fbcf9"
+    tokens:
+      ARB:
+        address: "0x912CE59144191C1204E64559FE8253a0e49E6548"
+        decimals: 18
+`)
+
+	cfg, err := LoadChain(path, "Arbitrum")
+	if err != nil {
+		t.Fatalf("LoadChain failed: %v", err)
+	}
+	if cfg.Chain != "arbitrum" || cfg.Ethereum.ChainID != 42161 {
+		t.Errorf("expected arbitrum (42161), got %s (%d)", cfg.Chain, cfg.Ethereum.ChainID)
+	}
+	if cfg.Ethereum.HTTPURL != "https://arbitrum.invalid" {
+		t.Errorf("expected profile http_url, got %s", cfg.Ethereum.HTTPURL)
+	}
+	if cfg.Ethereum.WebSocketURL != "wss://example.invalid" {
+		t.Errorf("unset profile fields should keep the ethereum section, got %s", cfg.Ethereum.WebSocketURL)
+	}
+	if cfg.Ethereum.BlockTime != 250*time.Millisecond {
+		t.Errorf("expected block_time 250ms, got %s", cfg.Ethereum.BlockTime)
+	}
+	if cfg.Uniswap.V2FactoryAddress != "0xf1D7CC64Fb4452F05c498126312eBE29f30Fbcf9" {
+		t.Errorf("expected profile v2 factory, got %s", cfg.Uniswap.V2FactoryAddress)
+	}
+	if token, ok := cfg.Ethereum.Tokens["arb"]; !ok || token.Decimals != 18 {
+		t.Errorf("expected profile token arb, got %+v", cfg.Ethereum.Tokens)
+	}
+
+	// Without a selection the sections apply as written
+	cfg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Chain != "" || cfg.Ethereum.ChainID != 1 {
+		t.Errorf("expected mainnet without a chain, got %s (%d)", cfg.Chain, cfg.Ethereum.ChainID)
+	}
+
+	var appErr *apperror.AppError
+	if _, err := LoadChain(path, "solana"); !errors.As(err, &appErr) || appErr.Code != apperror.CodeConfigurationError {
+		t.Errorf("expected %s for an unknown chain, got %v", apperror.CodeConfigurationError, err)
+	}
+}
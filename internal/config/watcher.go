@@ -0,0 +1,122 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// ReloadFunc receives the newly loaded configuration.
+type ReloadFunc func(cfg *Config)
+
+// Watcher re-reads the configuration on SIGHUP and notifies subscribers.
+// Only hot-reloadable settings (pairs, trade sizes, thresholds) are applied
+// by subscribers; changes to connection settings are logged as requiring
+// a restart.
+type Watcher struct {
+	path   string
+	logger logger.LoggerInterface
+
+	current  *Config
+	handlers []ReloadFunc
+	mu       sync.Mutex
+}
+
+// NewWatcher creates a watcher for the config at path (as passed to Load).
+func NewWatcher(path string, current *Config, log logger.LoggerInterface) *Watcher {
+	return &Watcher{
+		path:    path,
+		logger:  log,
+		current: current,
+	}
+}
+
+// OnReload registers a handler called after each successful reload.
+func (w *Watcher) OnReload(fn ReloadFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, fn)
+}
+
+// Start reloads the configuration on every SIGHUP until ctx is cancelled.
+func (w *Watcher) Start(ctx context.Context) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				if err := w.Reload(ctx); err != nil {
+					w.logger.Error(ctx, "config reload failed, keeping current config", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Reload re-reads and validates the configuration, then notifies handlers.
+// An invalid file leaves the current configuration in place.
+func (w *Watcher) Reload(ctx context.Context) error {
+	// Keep the chain selected at startup (it may come from --chain)
+	cfg, err := LoadChain(w.path, w.current.Chain)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Runtime-only settings are not part of the file
+	cfg.Arbitrage.TUIMode = w.current.Arbitrage.TUIMode
+
+	for _, field := range RestartRequired(w.current, cfg) {
+		w.logger.Warn(ctx, "config change requires restart to take effect", "field", field)
+	}
+
+	w.current = cfg
+	for _, fn := range w.handlers {
+		fn(cfg)
+	}
+
+	w.logger.Info(ctx, "configuration reloaded",
+		"pairs", len(cfg.Arbitrage.Pairs),
+		"trade_sizes", len(cfg.Arbitrage.TradeSizes),
+		"trade_sizes_usd", len(cfg.Arbitrage.TradeSizesUSD),
+	)
+	return nil
+}
+
+// RestartRequired returns the keys of connection settings that differ between
+// old and updated; these only take effect after a restart.
+func RestartRequired(old, updated *Config) []string {
+	var fields []string
+	check := func(key string, changed bool) {
+		if changed {
+			fields = append(fields, key)
+		}
+	}
+
+	check("ethereum.websocket_url", old.Ethereum.WebSocketURL != updated.Ethereum.WebSocketURL)
+	check("ethereum.http_url", old.Ethereum.HTTPURL != updated.Ethereum.HTTPURL)
+	check("ethereum.chain_id", old.Ethereum.ChainID != updated.Ethereum.ChainID)
+	check("binance.websocket_url", old.Binance.WebSocketURL != updated.Binance.WebSocketURL)
+	check("binance.symbols", !slices.Equal(old.Binance.Symbols, updated.Binance.Symbols))
+	check("coinbase.websocket_url", old.Coinbase.WebSocketURL != updated.Coinbase.WebSocketURL)
+	check("coinbase.product_ids", !slices.Equal(old.Coinbase.ProductIDs, updated.Coinbase.ProductIDs))
+	check("kraken.websocket_url", old.Kraken.WebSocketURL != updated.Kraken.WebSocketURL)
+	check("kraken.pairs", !slices.Equal(old.Kraken.Pairs, updated.Kraken.Pairs))
+	check("cex.providers", !slices.Equal(old.CEXProviderNames(), updated.CEXProviderNames()))
+	check("dex.providers", !slices.Equal(old.DEXProviderNames(), updated.DEXProviderNames()))
+	check("uniswap.quoter_address", old.Uniswap.QuoterAddress != updated.Uniswap.QuoterAddress)
+
+	return fields
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fd1az/arbitrage-bot/internal/logger"
+)
+
+// mockLogger implements logger.LoggerInterface and records warnings.
+type mockLogger struct {
+	mu    sync.Mutex
+	warns []string
+}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, args ...any) {}
+func (m *mockLogger) Info(ctx context.Context, msg string, args ...any)  {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.warns = append(m.warns, msg)
+}
+func (m *mockLogger) Error(ctx context.Context, msg string, args ...any)              {}
+func (m *mockLogger) Debugc(ctx context.Context, caller int, msg string, args ...any) {}
+func (m *mockLogger) Infoc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Warnc(ctx context.Context, caller int, msg string, args ...any)  {}
+func (m *mockLogger) Errorc(ctx context.Context, caller int, msg string, args ...any) {}
+
+var _ logger.LoggerInterface = (*mockLogger)(nil)
+
+func TestWatcher_Reload(t *testing.T) {
+	path := writeConfig(t, "  pairs: [ETH-USDC]\n")
+	current, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	current.Arbitrage.TUIMode = true
+
+	log := &mockLogger{}
+	watcher := NewWatcher(path, current, log)
+
+	var reloaded *Config
+	watcher.OnReload(func(cfg *Config) { reloaded = cfg })
+
+	// Change a hot-reloadable field and a connection URL
+	content, _ := os.ReadFile(path)
+	content = []byte(string(content) + "  trade_sizes: [2, 4]\n")
+	content = []byte(strings.Replace(string(content), "wss://example.invalid", "wss://other.invalid", 1))
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed to rewrite config: %v", err)
+	}
+
+	if err := watcher.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if reloaded == nil {
+		t.Fatal("expected reload handler to be called")
+	}
+	if len(reloaded.Arbitrage.TradeSizes) != 2 {
+		t.Errorf("expected 2 trade sizes, got %v", reloaded.Arbitrage.TradeSizes)
+	}
+	if !reloaded.Arbitrage.TUIMode {
+		t.Error("expected runtime TUIMode to be preserved")
+	}
+	if len(log.warns) != 1 {
+		t.Errorf("expected one restart warning, got %v", log.warns)
+	}
+
+	// An invalid file keeps the current config and skips handlers
+	reloaded = nil
+	if err := os.WriteFile(path, []byte("ethereum: {}\n"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite config: %v", err)
+	}
+	if err := watcher.Reload(context.Background()); err == nil {
+		t.Error("expected error for invalid config")
+	}
+	if reloaded != nil {
+		t.Error("expected handlers not to run for invalid config")
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	old := &Config{}
+	old.Ethereum.WebSocketURL = "wss://a"
+	old.DEX.Providers = []string{DEXProviderUniswapV3}
+
+	updated := &Config{}
+	updated.Ethereum.WebSocketURL = "wss://b"
+	updated.DEX.Providers = []string{DEXProviderUniswapV3, DEXProviderUniswapV2}
+	updated.Arbitrage.MinProfitBps = 25 // Hot-reloadable, not reported
+
+	fields := RestartRequired(old, updated)
+	if len(fields) != 2 || fields[0] != "ethereum.websocket_url" || fields[1] != "dex.providers" {
+		t.Errorf("expected [ethereum.websocket_url dex.providers], got %v", fields)
+	}
+}
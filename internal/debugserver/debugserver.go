@@ -0,0 +1,125 @@
+// Package debugserver provides an HTTP endpoint dumping the bot's live
+// pricing state (orderbooks and DEX quotes) for troubleshooting.
+package debugserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Level is one orderbook price level.
+type Level struct {
+	Price  string `json:"price"`
+	Amount string `json:"amount"`
+}
+
+// Orderbook is the top of a venue's live book for one symbol.
+type Orderbook struct {
+	Venue      string     `json:"venue"`
+	Symbol     string     `json:"symbol"`
+	Bids       []Level    `json:"bids"`
+	Asks       []Level    `json:"asks"`
+	LastUpdate *time.Time `json:"last_update,omitempty"` // nil if no update arrived yet
+	AgeSeconds *float64   `json:"age_seconds,omitempty"` // Filled in at request time
+}
+
+// Quote is the most recent DEX quote for a token pair.
+type Quote struct {
+	Protocol   string    `json:"protocol"`
+	TokenIn    string    `json:"token_in"`
+	TokenOut   string    `json:"token_out"`
+	AmountIn   string    `json:"amount_in"`
+	AmountOut  string    `json:"amount_out"`
+	Price      string    `json:"price"`
+	FeeTier    int       `json:"fee_tier,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	AgeSeconds float64   `json:"age_seconds"` // Filled in at request time
+}
+
+// Snapshot is the body of GET /debug.
+type Snapshot struct {
+	Orderbooks []Orderbook `json:"orderbooks"`
+	Quotes     []Quote     `json:"quotes"`
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+// StateSource supplies the state dumped by the endpoint. Implementations must be
+// safe for concurrent use.
+type StateSource interface {
+	Orderbooks() []Orderbook
+	Quotes() []Quote
+}
+
+// Server serves the debug endpoint.
+type Server struct {
+	port   int
+	source StateSource
+	server *http.Server
+}
+
+// NewServer creates a new debug server reading from source.
+func NewServer(port int, source StateSource) *Server {
+	return &Server{
+		port:   port,
+		source: source,
+	}
+}
+
+// Handler returns the debug routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug", s.handleDebug)
+	return mux
+}
+
+// Start starts the debug server in the background.
+func (s *Server) Start() error {
+	s.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			// Log error but don't crash - the debug endpoint is optional
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully stops the debug server.
+func (s *Server) Stop(ctx context.Context) error {
+	if s.server != nil {
+		return s.server.Shutdown(ctx)
+	}
+	return nil
+}
+
+// handleDebug returns the current orderbooks and last quotes with their ages.
+func (s *Server) handleDebug(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+
+	snapshot := Snapshot{
+		Orderbooks: s.source.Orderbooks(),
+		Quotes:     s.source.Quotes(),
+		Timestamp:  now,
+	}
+	for i, ob := range snapshot.Orderbooks {
+		if ob.LastUpdate != nil {
+			age := now.Sub(*ob.LastUpdate).Seconds()
+			snapshot.Orderbooks[i].AgeSeconds = &age
+		}
+	}
+	for i, q := range snapshot.Quotes {
+		snapshot.Quotes[i].AgeSeconds = now.Sub(q.Timestamp).Seconds()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(snapshot)
+}
@@ -0,0 +1,54 @@
+package debugserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeSource serves fixed state.
+type fakeSource struct {
+	updated time.Time
+}
+
+func (f *fakeSource) Orderbooks() []Orderbook {
+	return []Orderbook{
+		{Venue: "binance", Symbol: "ETHUSDC", Bids: []Level{{Price: "3000", Amount: "1"}}, Asks: []Level{{Price: "3001", Amount: "2"}}, LastUpdate: &f.updated},
+		{Venue: "binance", Symbol: "BTCUSDC"},
+	}
+}
+
+func (f *fakeSource) Quotes() []Quote {
+	return []Quote{{Protocol: "uniswap_v3", TokenIn: "USDC", TokenOut: "WETH", Timestamp: f.updated}}
+}
+
+func TestServer_Debug(t *testing.T) {
+	source := &fakeSource{updated: time.Now().Add(-3 * time.Second)}
+	rec := httptest.NewRecorder()
+	NewServer(0, source).Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var body Snapshot
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if len(body.Orderbooks) != 2 || len(body.Quotes) != 1 {
+		t.Fatalf("expected 2 orderbooks and 1 quote, got %+v", body)
+	}
+
+	eth := body.Orderbooks[0]
+	if eth.AgeSeconds == nil || *eth.AgeSeconds < 3 || *eth.AgeSeconds > 10 {
+		t.Errorf("expected ETHUSDC age around 3s, got %v", eth.AgeSeconds)
+	}
+	if body.Orderbooks[1].AgeSeconds != nil {
+		t.Errorf("expected no age for a book without updates, got %v", *body.Orderbooks[1].AgeSeconds)
+	}
+	if age := body.Quotes[0].AgeSeconds; age < 3 || age > 10 {
+		t.Errorf("expected quote age around 3s, got %v", age)
+	}
+}
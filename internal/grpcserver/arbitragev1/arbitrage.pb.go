@@ -0,0 +1,406 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: arbitrage/v1/arbitrage.proto
+
+package arbitragev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StreamOpportunitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream these routes (e.g. "ETH-USDC"); empty streams every route.
+	Pairs []string `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	// Skip opportunities that do not clear the profit thresholds.
+	ProfitableOnly bool `protobuf:"varint,2,opt,name=profitable_only,json=profitableOnly,proto3" json:"profitable_only,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StreamOpportunitiesRequest) Reset() {
+	*x = StreamOpportunitiesRequest{}
+	mi := &file_arbitrage_v1_arbitrage_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamOpportunitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamOpportunitiesRequest) ProtoMessage() {}
+
+func (x *StreamOpportunitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_arbitrage_v1_arbitrage_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamOpportunitiesRequest.ProtoReflect.Descriptor instead.
+func (*StreamOpportunitiesRequest) Descriptor() ([]byte, []int) {
+	return file_arbitrage_v1_arbitrage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamOpportunitiesRequest) GetPairs() []string {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *StreamOpportunitiesRequest) GetProfitableOnly() bool {
+	if x != nil {
+		return x.ProfitableOnly
+	}
+	return false
+}
+
+// Opportunity is a detected CEX-DEX or triangular arbitrage opportunity.
+// Decimal values are strings to preserve precision.
+type Opportunity struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Pair           string                 `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"` // Route, e.g. "ETH-USDC" or "ETH-USDC-WBTC" for cycles
+	Direction      string                 `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	TradeSize      string                 `protobuf:"bytes,4,opt,name=trade_size,json=tradeSize,proto3" json:"trade_size,omitempty"` // In base asset units
+	CexVenue       string                 `protobuf:"bytes,5,opt,name=cex_venue,json=cexVenue,proto3" json:"cex_venue,omitempty"`
+	CexPrice       string                 `protobuf:"bytes,6,opt,name=cex_price,json=cexPrice,proto3" json:"cex_price,omitempty"`
+	DexPrice       string                 `protobuf:"bytes,7,opt,name=dex_price,json=dexPrice,proto3" json:"dex_price,omitempty"`
+	SpreadBps      string                 `protobuf:"bytes,8,opt,name=spread_bps,json=spreadBps,proto3" json:"spread_bps,omitempty"`
+	GrossProfitUsd string                 `protobuf:"bytes,9,opt,name=gross_profit_usd,json=grossProfitUsd,proto3" json:"gross_profit_usd,omitempty"`
+	NetProfitUsd   string                 `protobuf:"bytes,10,opt,name=net_profit_usd,json=netProfitUsd,proto3" json:"net_profit_usd,omitempty"` // Signed
+	Profitable     bool                   `protobuf:"varint,11,opt,name=profitable,proto3" json:"profitable,omitempty"`
+	BlockNumber    uint64                 `protobuf:"varint,12,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Legs           []*Leg                 `protobuf:"bytes,14,rep,name=legs,proto3" json:"legs,omitempty"` // Triangular cycles only
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Opportunity) Reset() {
+	*x = Opportunity{}
+	mi := &file_arbitrage_v1_arbitrage_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Opportunity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Opportunity) ProtoMessage() {}
+
+func (x *Opportunity) ProtoReflect() protoreflect.Message {
+	mi := &file_arbitrage_v1_arbitrage_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Opportunity.ProtoReflect.Descriptor instead.
+func (*Opportunity) Descriptor() ([]byte, []int) {
+	return file_arbitrage_v1_arbitrage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Opportunity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Opportunity) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Opportunity) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Opportunity) GetTradeSize() string {
+	if x != nil {
+		return x.TradeSize
+	}
+	return ""
+}
+
+func (x *Opportunity) GetCexVenue() string {
+	if x != nil {
+		return x.CexVenue
+	}
+	return ""
+}
+
+func (x *Opportunity) GetCexPrice() string {
+	if x != nil {
+		return x.CexPrice
+	}
+	return ""
+}
+
+func (x *Opportunity) GetDexPrice() string {
+	if x != nil {
+		return x.DexPrice
+	}
+	return ""
+}
+
+func (x *Opportunity) GetSpreadBps() string {
+	if x != nil {
+		return x.SpreadBps
+	}
+	return ""
+}
+
+func (x *Opportunity) GetGrossProfitUsd() string {
+	if x != nil {
+		return x.GrossProfitUsd
+	}
+	return ""
+}
+
+func (x *Opportunity) GetNetProfitUsd() string {
+	if x != nil {
+		return x.NetProfitUsd
+	}
+	return ""
+}
+
+func (x *Opportunity) GetProfitable() bool {
+	if x != nil {
+		return x.Profitable
+	}
+	return false
+}
+
+func (x *Opportunity) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Opportunity) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Opportunity) GetLegs() []*Leg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+// Leg is one swap of a triangular cycle.
+type Leg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pair          string                 `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Side          string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`
+	Venue         string                 `protobuf:"bytes,3,opt,name=venue,proto3" json:"venue,omitempty"`
+	Price         string                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	AmountIn      string                 `protobuf:"bytes,5,opt,name=amount_in,json=amountIn,proto3" json:"amount_in,omitempty"`
+	AmountOut     string                 `protobuf:"bytes,6,opt,name=amount_out,json=amountOut,proto3" json:"amount_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Leg) Reset() {
+	*x = Leg{}
+	mi := &file_arbitrage_v1_arbitrage_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Leg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Leg) ProtoMessage() {}
+
+func (x *Leg) ProtoReflect() protoreflect.Message {
+	mi := &file_arbitrage_v1_arbitrage_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Leg.ProtoReflect.Descriptor instead.
+func (*Leg) Descriptor() ([]byte, []int) {
+	return file_arbitrage_v1_arbitrage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Leg) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Leg) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *Leg) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *Leg) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Leg) GetAmountIn() string {
+	if x != nil {
+		return x.AmountIn
+	}
+	return ""
+}
+
+func (x *Leg) GetAmountOut() string {
+	if x != nil {
+		return x.AmountOut
+	}
+	return ""
+}
+
+var File_arbitrage_v1_arbitrage_proto protoreflect.FileDescriptor
+
+const file_arbitrage_v1_arbitrage_proto_rawDesc = "" +
+	"\n" +
+	"\x1carbitrage/v1/arbitrage.proto\x12\farbitrage.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"[\n" +
+	"\x1aStreamOpportunitiesRequest\x12\x14\n" +
+	"\x05pairs\x18\x01 \x03(\tR\x05pairs\x12'\n" +
+	"\x0fprofitable_only\x18\x02 \x01(\bR\x0eprofitableOnly\"\xd8\x03\n" +
+	"\vOpportunity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04pair\x18\x02 \x01(\tR\x04pair\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\x12\x1d\n" +
+	"\n" +
+	"trade_size\x18\x04 \x01(\tR\ttradeSize\x12\x1b\n" +
+	"\tcex_venue\x18\x05 \x01(\tR\bcexVenue\x12\x1b\n" +
+	"\tcex_price\x18\x06 \x01(\tR\bcexPrice\x12\x1b\n" +
+	"\tdex_price\x18\a \x01(\tR\bdexPrice\x12\x1d\n" +
+	"\n" +
+	"spread_bps\x18\b \x01(\tR\tspreadBps\x12(\n" +
+	"\x10gross_profit_usd\x18\t \x01(\tR\x0egrossProfitUsd\x12$\n" +
+	"\x0enet_profit_usd\x18\n" +
+	" \x01(\tR\fnetProfitUsd\x12\x1e\n" +
+	"\n" +
+	"profitable\x18\v \x01(\bR\n" +
+	"profitable\x12!\n" +
+	"\fblock_number\x18\f \x01(\x04R\vblockNumber\x128\n" +
+	"\ttimestamp\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12%\n" +
+	"\x04legs\x18\x0e \x03(\v2\x11.arbitrage.v1.LegR\x04legs\"\x95\x01\n" +
+	"\x03Leg\x12\x12\n" +
+	"\x04pair\x18\x01 \x01(\tR\x04pair\x12\x12\n" +
+	"\x04side\x18\x02 \x01(\tR\x04side\x12\x14\n" +
+	"\x05venue\x18\x03 \x01(\tR\x05venue\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12\x1b\n" +
+	"\tamount_in\x18\x05 \x01(\tR\bamountIn\x12\x1d\n" +
+	"\n" +
+	"amount_out\x18\x06 \x01(\tR\tamountOut2p\n" +
+	"\x10ArbitrageService\x12\\\n" +
+	"\x13StreamOpportunities\x12(.arbitrage.v1.StreamOpportunitiesRequest\x1a\x19.arbitrage.v1.Opportunity0\x01BLZJgithub.com/fd1az/arbitrage-bot/internal/grpcserver/arbitragev1;arbitragev1b\x06proto3"
+
+var (
+	file_arbitrage_v1_arbitrage_proto_rawDescOnce sync.Once
+	file_arbitrage_v1_arbitrage_proto_rawDescData []byte
+)
+
+func file_arbitrage_v1_arbitrage_proto_rawDescGZIP() []byte {
+	file_arbitrage_v1_arbitrage_proto_rawDescOnce.Do(func() {
+		file_arbitrage_v1_arbitrage_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_arbitrage_v1_arbitrage_proto_rawDesc), len(file_arbitrage_v1_arbitrage_proto_rawDesc)))
+	})
+	return file_arbitrage_v1_arbitrage_proto_rawDescData
+}
+
+var file_arbitrage_v1_arbitrage_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_arbitrage_v1_arbitrage_proto_goTypes = []any{
+	(*StreamOpportunitiesRequest)(nil), // 0: arbitrage.v1.StreamOpportunitiesRequest
+	(*Opportunity)(nil),                // 1: arbitrage.v1.Opportunity
+	(*Leg)(nil),                        // 2: arbitrage.v1.Leg
+	(*timestamppb.Timestamp)(nil),      // 3: google.protobuf.Timestamp
+}
+var file_arbitrage_v1_arbitrage_proto_depIdxs = []int32{
+	3, // 0: arbitrage.v1.Opportunity.timestamp:type_name -> google.protobuf.Timestamp
+	2, // 1: arbitrage.v1.Opportunity.legs:type_name -> arbitrage.v1.Leg
+	0, // 2: arbitrage.v1.ArbitrageService.StreamOpportunities:input_type -> arbitrage.v1.StreamOpportunitiesRequest
+	1, // 3: arbitrage.v1.ArbitrageService.StreamOpportunities:output_type -> arbitrage.v1.Opportunity
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_arbitrage_v1_arbitrage_proto_init() }
+func file_arbitrage_v1_arbitrage_proto_init() {
+	if File_arbitrage_v1_arbitrage_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_arbitrage_v1_arbitrage_proto_rawDesc), len(file_arbitrage_v1_arbitrage_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_arbitrage_v1_arbitrage_proto_goTypes,
+		DependencyIndexes: file_arbitrage_v1_arbitrage_proto_depIdxs,
+		MessageInfos:      file_arbitrage_v1_arbitrage_proto_msgTypes,
+	}.Build()
+	File_arbitrage_v1_arbitrage_proto = out.File
+	file_arbitrage_v1_arbitrage_proto_goTypes = nil
+	file_arbitrage_v1_arbitrage_proto_depIdxs = nil
+}
@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: arbitrage/v1/arbitrage.proto
+
+package arbitragev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ArbitrageService_StreamOpportunities_FullMethodName = "/arbitrage.v1.ArbitrageService/StreamOpportunities"
+)
+
+// ArbitrageServiceClient is the client API for ArbitrageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ArbitrageService streams the bot's detection results.
+type ArbitrageServiceClient interface {
+	// StreamOpportunities pushes each opportunity as it is reported. Clients
+	// that fall behind are disconnected with RESOURCE_EXHAUSTED rather than
+	// slowing detection down.
+	StreamOpportunities(ctx context.Context, in *StreamOpportunitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Opportunity], error)
+}
+
+type arbitrageServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArbitrageServiceClient(cc grpc.ClientConnInterface) ArbitrageServiceClient {
+	return &arbitrageServiceClient{cc}
+}
+
+func (c *arbitrageServiceClient) StreamOpportunities(ctx context.Context, in *StreamOpportunitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Opportunity], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArbitrageService_ServiceDesc.Streams[0], ArbitrageService_StreamOpportunities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamOpportunitiesRequest, Opportunity]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArbitrageService_StreamOpportunitiesClient = grpc.ServerStreamingClient[Opportunity]
+
+// ArbitrageServiceServer is the server API for ArbitrageService service.
+// All implementations must embed UnimplementedArbitrageServiceServer
+// for forward compatibility.
+//
+// ArbitrageService streams the bot's detection results.
+type ArbitrageServiceServer interface {
+	// StreamOpportunities pushes each opportunity as it is reported. Clients
+	// that fall behind are disconnected with RESOURCE_EXHAUSTED rather than
+	// slowing detection down.
+	StreamOpportunities(*StreamOpportunitiesRequest, grpc.ServerStreamingServer[Opportunity]) error
+	mustEmbedUnimplementedArbitrageServiceServer()
+}
+
+// UnimplementedArbitrageServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedArbitrageServiceServer struct{}
+
+func (UnimplementedArbitrageServiceServer) StreamOpportunities(*StreamOpportunitiesRequest, grpc.ServerStreamingServer[Opportunity]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamOpportunities not implemented")
+}
+func (UnimplementedArbitrageServiceServer) mustEmbedUnimplementedArbitrageServiceServer() {}
+func (UnimplementedArbitrageServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeArbitrageServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArbitrageServiceServer will
+// result in compilation errors.
+type UnsafeArbitrageServiceServer interface {
+	mustEmbedUnimplementedArbitrageServiceServer()
+}
+
+func RegisterArbitrageServiceServer(s grpc.ServiceRegistrar, srv ArbitrageServiceServer) {
+	// If the following call pancis, it indicates UnimplementedArbitrageServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ArbitrageService_ServiceDesc, srv)
+}
+
+func _ArbitrageService_StreamOpportunities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamOpportunitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArbitrageServiceServer).StreamOpportunities(m, &grpc.GenericServerStream[StreamOpportunitiesRequest, Opportunity]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArbitrageService_StreamOpportunitiesServer = grpc.ServerStreamingServer[Opportunity]
+
+// ArbitrageService_ServiceDesc is the grpc.ServiceDesc for ArbitrageService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ArbitrageService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "arbitrage.v1.ArbitrageService",
+	HandlerType: (*ArbitrageServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOpportunities",
+			Handler:       _ArbitrageService_StreamOpportunities_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "arbitrage/v1/arbitrage.proto",
+}
@@ -0,0 +1,115 @@
+// Package grpcserver serves the arbitrage gRPC API (see proto/arbitrage/v1).
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fd1az/arbitrage-bot/internal/grpcserver/arbitragev1"
+)
+
+// Broadcaster fans opportunities out to subscribers. The returned channel is
+// closed when the subscriber is dropped for falling behind or the
+// broadcaster stops; cancel releases the subscription and must always be
+// called. Stopped is closed before the subscriber channels are on stop, to
+// tell shutdown apart from a drop.
+type Broadcaster interface {
+	Subscribe() (opps <-chan *arbitragev1.Opportunity, cancel func())
+	Stopped() <-chan struct{}
+}
+
+// Server serves ArbitrageService over gRPC.
+type Server struct {
+	arbitragev1.UnimplementedArbitrageServiceServer
+
+	port        int
+	broadcaster Broadcaster
+	server      *grpc.Server
+}
+
+// NewServer creates a gRPC server streaming opportunities from broadcaster.
+func NewServer(port int, broadcaster Broadcaster) *Server {
+	s := &Server{
+		port:        port,
+		broadcaster: broadcaster,
+		server:      grpc.NewServer(),
+	}
+	arbitragev1.RegisterArbitrageServiceServer(s.server, s)
+	return s
+}
+
+// Start listens on the configured port and serves in the background.
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", s.port, err)
+	}
+
+	go func() {
+		if err := s.server.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			// Log error but don't crash - the gRPC API is optional
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully stops the server, closing open streams once ctx is done.
+func (s *Server) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.server.Stop()
+	}
+	return nil
+}
+
+// StreamOpportunities pushes each reported opportunity matching the request
+// until the client disconnects, is dropped for falling behind, or the
+// broadcaster stops at shutdown.
+func (s *Server) StreamOpportunities(req *arbitragev1.StreamOpportunitiesRequest, stream grpc.ServerStreamingServer[arbitragev1.Opportunity]) error {
+	opps, cancel := s.broadcaster.Subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case opp, ok := <-opps:
+			if !ok {
+				select {
+				case <-s.broadcaster.Stopped():
+					return status.Error(codes.Unavailable, "server is shutting down")
+				default:
+					return status.Error(codes.ResourceExhausted, "stream dropped: client is not keeping up")
+				}
+			}
+			if !matches(req, opp) {
+				continue
+			}
+			if err := stream.Send(opp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// matches applies the request's route and profitability filters.
+func matches(req *arbitragev1.StreamOpportunitiesRequest, opp *arbitragev1.Opportunity) bool {
+	if req.GetProfitableOnly() && !opp.GetProfitable() {
+		return false
+	}
+	return len(req.GetPairs()) == 0 || slices.Contains(req.GetPairs(), opp.GetPair())
+}
@@ -0,0 +1,106 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/fd1az/arbitrage-bot/internal/grpcserver/arbitragev1"
+)
+
+// fakeBroadcaster hands out a single pre-filled channel.
+type fakeBroadcaster struct {
+	ch      chan *arbitragev1.Opportunity
+	stopped chan struct{}
+}
+
+func newFakeBroadcaster(size int) *fakeBroadcaster {
+	return &fakeBroadcaster{ch: make(chan *arbitragev1.Opportunity, size), stopped: make(chan struct{})}
+}
+
+func (f *fakeBroadcaster) Subscribe() (<-chan *arbitragev1.Opportunity, func()) {
+	return f.ch, func() {}
+}
+
+func (f *fakeBroadcaster) Stopped() <-chan struct{} {
+	return f.stopped
+}
+
+// dial serves s over an in-memory listener and returns a connected client.
+func dial(t *testing.T, s *Server) arbitragev1.ArbitrageServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	go s.server.Serve(lis)
+	t.Cleanup(s.server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return arbitragev1.NewArbitrageServiceClient(conn)
+}
+
+func TestServer_StreamOpportunities(t *testing.T) {
+	broadcaster := newFakeBroadcaster(3)
+	broadcaster.ch <- &arbitragev1.Opportunity{Pair: "ETH-USDC", BlockNumber: 100, Profitable: false}
+	broadcaster.ch <- &arbitragev1.Opportunity{Pair: "WBTC-USDC", BlockNumber: 100, Profitable: true}
+	broadcaster.ch <- &arbitragev1.Opportunity{Pair: "ETH-USDC", BlockNumber: 101, Profitable: true}
+	close(broadcaster.ch) // Subscriber dropped after these
+
+	client := dial(t, NewServer(0, broadcaster))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamOpportunities(ctx, &arbitragev1.StreamOpportunitiesRequest{
+		Pairs:          []string{"ETH-USDC"},
+		ProfitableOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("StreamOpportunities failed: %v", err)
+	}
+
+	opp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	if opp.GetPair() != "ETH-USDC" || opp.GetBlockNumber() != 101 {
+		t.Errorf("expected only the profitable ETH-USDC opportunity, got %+v", opp)
+	}
+
+	if _, err := stream.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted once dropped, got %v", err)
+	}
+}
+
+func TestServer_StreamOpportunitiesShutdown(t *testing.T) {
+	broadcaster := newFakeBroadcaster(1)
+	broadcaster.ch <- &arbitragev1.Opportunity{Pair: "ETH-USDC", BlockNumber: 100}
+	close(broadcaster.stopped)
+	close(broadcaster.ch) // Closed by the broadcaster stopping, not a drop
+
+	client := dial(t, NewServer(0, broadcaster))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamOpportunities(ctx, &arbitragev1.StreamOpportunitiesRequest{})
+	if err != nil {
+		t.Fatalf("StreamOpportunities failed: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable on shutdown, got %v", err)
+	}
+}
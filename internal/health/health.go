@@ -4,16 +4,25 @@ package health
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
+// DefaultStaleThreshold is how long a critical source may go without
+// a message before /health reports it as unhealthy.
+const DefaultStaleThreshold = 60 * time.Second
+
 // Status represents the health check response.
 type Status struct {
 	Status    string            `json:"status"`
 	Checks    map[string]Check  `json:"checks"`
+	Sources   map[string]Source `json:"sources,omitempty"`
 	Version   string            `json:"version,omitempty"`
 	Timestamp string            `json:"timestamp"`
 }
@@ -27,21 +36,73 @@ type Check struct {
 // CheckFunc is a function that performs a health check.
 type CheckFunc func(ctx context.Context) (bool, string)
 
+// SourceHealth is a point-in-time view of a data source's connection.
+type SourceHealth struct {
+	State       string    // Connection state as reported by the source (e.g. "connected")
+	Connected   bool      // Whether the source is currently connected
+	LastMessage time.Time // When the last message/block arrived (zero if none yet)
+}
+
+// HealthReporter is implemented by data sources (WebSocket feeds, block
+// subscribers) that report their connection health.
+type HealthReporter interface {
+	Health() SourceHealth
+}
+
+// Source represents a data source in the health response.
+type Source struct {
+	State                 string  `json:"state"`
+	Connected             bool    `json:"connected"`
+	Critical              bool    `json:"critical"`
+	Healthy               bool    `json:"healthy"`
+	LastMessageAt         string  `json:"last_message_at,omitempty"`
+	LastMessageAgeSeconds float64 `json:"last_message_age_seconds"`
+}
+
+// registeredSource is a HealthReporter with its registration metadata.
+type registeredSource struct {
+	reporter     HealthReporter
+	critical     bool
+	registeredAt time.Time
+}
+
 // Server provides health check HTTP endpoints.
 type Server struct {
-	port    int
-	version string
-	checks  map[string]CheckFunc
-	mu      sync.RWMutex
-	server  *http.Server
+	port           int
+	version        string
+	checks         map[string]CheckFunc
+	sources        map[string]registeredSource
+	staleThreshold time.Duration
+	ready          atomic.Bool
+	mu             sync.RWMutex
+	server         *http.Server
+	listener       net.Listener // Bound by Start
 }
 
 // NewServer creates a new health check server.
 func NewServer(port int, version string) *Server {
 	return &Server{
-		port:    port,
-		version: version,
-		checks:  make(map[string]CheckFunc),
+		port:           port,
+		version:        version,
+		checks:         make(map[string]CheckFunc),
+		sources:        make(map[string]registeredSource),
+		staleThreshold: DefaultStaleThreshold,
+	}
+}
+
+// SetReady sets the readiness gate checked by /ready before any checks run.
+// Servers start not ready, so /ready returns 503 until the application
+// reports it is functioning.
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// SetStaleThreshold sets how long a critical source may go without a message.
+func (s *Server) SetStaleThreshold(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d > 0 {
+		s.staleThreshold = d
 	}
 }
 
@@ -52,21 +113,82 @@ func (s *Server) RegisterCheck(name string, check CheckFunc) {
 	s.checks[name] = check
 }
 
-// Start starts the health check server.
+// RegisterReporter registers a data source. A critical source makes /health
+// return 503 once no message has arrived within the stale threshold, which
+// covers both a dropped connection and a connected but silent feed.
+// Sources that have not reported yet are measured from registration.
+func (s *Server) RegisterReporter(name string, reporter HealthReporter, critical bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sources[name] = registeredSource{
+		reporter:     reporter,
+		critical:     critical,
+		registeredAt: time.Now(),
+	}
+}
+
+// sourceStatus evaluates a registered source against the stale threshold.
+func sourceStatus(src registeredSource, threshold time.Duration, now time.Time) Source {
+	h := src.reporter.Health()
+
+	since := h.LastMessage
+	if since.IsZero() {
+		since = src.registeredAt
+	}
+	age := now.Sub(since)
+
+	status := Source{
+		State:                 h.State,
+		Connected:             h.Connected,
+		Critical:              src.critical,
+		Healthy:               !src.critical || age <= threshold,
+		LastMessageAgeSeconds: age.Seconds(),
+	}
+	if !h.LastMessage.IsZero() {
+		status.LastMessageAt = h.LastMessage.UTC().Format(time.RFC3339)
+	}
+	return status
+}
+
+// PortInUseError is returned by Start when another process already listens
+// on the server's port.
+type PortInUseError struct {
+	Port int
+	Err  error
+}
+
+func (e *PortInUseError) Error() string {
+	return fmt.Sprintf("health server port %d is already in use", e.Port)
+}
+
+func (e *PortInUseError) Unwrap() error {
+	return e.Err
+}
+
+// Start binds the server's port and serves in the background. A port that
+// is already taken fails with a *PortInUseError instead of going unnoticed.
 func (s *Server) Start() error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", s.handleHealth)
 	mux.HandleFunc("/ready", s.handleReady)
 	mux.HandleFunc("/live", s.handleLive)
 
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return &PortInUseError{Port: s.port, Err: err}
+		}
+		return fmt.Errorf("failed to listen on port %d: %w", s.port, err)
+	}
+	s.listener = lis
+
 	s.server = &http.Server{
-		Addr:              fmt.Sprintf(":%d", s.port),
 		Handler:           mux,
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
 	go func() {
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := s.server.Serve(lis); err != nil && err != http.ErrServerClosed {
 			// Log error but don't crash - health endpoint is optional
 		}
 	}()
@@ -74,7 +196,17 @@ func (s *Server) Start() error {
 	return nil
 }
 
-// Stop gracefully stops the health check server.
+// Addr returns the address the server listens on, or nil before Start.
+// With port 0 it carries the port the system picked.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Stop stops accepting connections and waits for in-flight requests to
+// finish, or for ctx to be done, whichever comes first.
 func (s *Server) Stop(ctx context.Context) error {
 	if s.server != nil {
 		return s.server.Shutdown(ctx)
@@ -92,6 +224,11 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	for k, v := range s.checks {
 		checks[k] = v
 	}
+	sources := make(map[string]registeredSource, len(s.sources))
+	for k, v := range s.sources {
+		sources[k] = v
+	}
+	threshold := s.staleThreshold
 	s.mu.RUnlock()
 
 	status := Status{
@@ -113,6 +250,21 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if len(sources) > 0 {
+		status.Sources = make(map[string]Source, len(sources))
+	}
+	now := time.Now()
+	for name, src := range sources {
+		srcStatus := sourceStatus(src, threshold, now)
+		status.Sources[name] = srcStatus
+		if !srcStatus.Healthy {
+			allHealthy = false
+		}
+	}
+
+	// Headers must be set before WriteHeader
+	w.Header().Set("Content-Type", "application/json")
+
 	if !allHealthy {
 		status.Status = "degraded"
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -120,12 +272,17 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(status)
 }
 
 // handleReady returns whether the service is ready to receive traffic.
 func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !s.ready.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("not ready"))
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
@@ -0,0 +1,202 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeReporter returns a fixed SourceHealth.
+type fakeReporter struct {
+	health SourceHealth
+}
+
+func (f *fakeReporter) Health() SourceHealth {
+	return f.health
+}
+
+func TestServer_HandleHealth_Sources(t *testing.T) {
+	fresh := SourceHealth{State: "connected", Connected: true, LastMessage: time.Now()}
+	stale := SourceHealth{State: "reconnecting", LastMessage: time.Now().Add(-2 * time.Minute)}
+
+	tests := []struct {
+		name        string
+		source      SourceHealth
+		critical    bool
+		wantCode    int
+		wantHealthy bool
+	}{
+		{name: "fresh_critical", source: fresh, critical: true, wantCode: http.StatusOK, wantHealthy: true},
+		{name: "stale_critical", source: stale, critical: true, wantCode: http.StatusServiceUnavailable},
+		{name: "stale_non_critical", source: stale, critical: false, wantCode: http.StatusOK, wantHealthy: true},
+		{name: "no_message_within_grace", source: SourceHealth{State: "connecting"}, critical: true, wantCode: http.StatusOK, wantHealthy: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(0, "test")
+			s.RegisterReporter("feed", &fakeReporter{health: tt.source}, tt.critical)
+
+			rec := httptest.NewRecorder()
+			s.handleHealth(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected application/json, got %q", ct)
+			}
+
+			var status Status
+			if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			src, ok := status.Sources["feed"]
+			if !ok {
+				t.Fatal("expected feed in sources")
+			}
+			if src.Healthy != tt.wantHealthy {
+				t.Errorf("expected healthy %v, got %v", tt.wantHealthy, src.Healthy)
+			}
+			if src.State != tt.source.State {
+				t.Errorf("expected state %q, got %q", tt.source.State, src.State)
+			}
+		})
+	}
+}
+
+func TestServer_SetStaleThreshold(t *testing.T) {
+	s := NewServer(0, "test")
+	s.SetStaleThreshold(time.Second)
+	s.RegisterReporter("feed", &fakeReporter{health: SourceHealth{
+		State:       "connected",
+		Connected:   true,
+		LastMessage: time.Now().Add(-5 * time.Second),
+	}}, true)
+
+	rec := httptest.NewRecorder()
+	s.handleHealth(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected connected but silent feed to be unhealthy, got %d", rec.Code)
+	}
+}
+
+func TestServer_HandleReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		ready    bool
+		check    CheckFunc
+		wantCode int
+	}{
+		{name: "not_ready_until_set", ready: false, wantCode: http.StatusServiceUnavailable},
+		{name: "ready", ready: true, wantCode: http.StatusOK},
+		{
+			name:     "ready_but_check_failing",
+			ready:    true,
+			check:    func(ctx context.Context) (bool, string) { return false, "down" },
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(0, "test")
+			s.SetReady(tt.ready)
+			if tt.check != nil {
+				s.RegisterCheck("dep", tt.check)
+			}
+
+			rec := httptest.NewRecorder()
+			s.handleReady(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, rec.Code)
+			}
+		})
+	}
+}
+
+// TestServer_StartScrapeStop tests that a started server answers probes and
+// stops cleanly, draining a scrape still in flight.
+func TestServer_StartScrapeStop(t *testing.T) {
+	s := NewServer(0, "test")
+	inCheck := make(chan struct{})
+	release := make(chan struct{})
+	s.RegisterCheck("slow", func(ctx context.Context) (bool, string) {
+		close(inCheck)
+		<-release
+		return true, ""
+	})
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	base := fmt.Sprintf("http://%s", s.Addr())
+
+	resp, err := http.Get(base + "/live")
+	if err != nil {
+		t.Fatalf("GET /live failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected /live 200, got %d", resp.StatusCode)
+	}
+
+	scraped := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(base + "/health")
+		if err != nil {
+			scraped <- 0
+			return
+		}
+		resp.Body.Close()
+		scraped <- resp.StatusCode
+	}()
+	<-inCheck
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Stop(context.Background()) }()
+
+	select {
+	case err := <-stopped:
+		t.Fatalf("Stop returned before the in-flight scrape finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if code := <-scraped; code != http.StatusOK {
+		t.Errorf("expected the in-flight scrape to complete with 200, got %d", code)
+	}
+	if err := <-stopped; err != nil {
+		t.Errorf("Stop failed: %v", err)
+	}
+	if _, err := http.Get(base + "/live"); err == nil {
+		t.Error("expected the stopped server to refuse connections")
+	}
+}
+
+// TestServer_Start_PortInUse tests that a taken port is reported.
+func TestServer_Start_PortInUse(t *testing.T) {
+	first := NewServer(0, "test")
+	if err := first.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer first.Stop(context.Background())
+
+	port := first.Addr().(*net.TCPAddr).Port
+	err := NewServer(port, "test").Start()
+
+	var inUse *PortInUseError
+	if !errors.As(err, &inUse) {
+		t.Fatalf("expected a *PortInUseError, got %v", err)
+	}
+	if inUse.Port != port {
+		t.Errorf("expected port %d, got %d", port, inUse.Port)
+	}
+}
@@ -0,0 +1,77 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// ConnectionStats describes how stable a data source's connection has been
+// this session.
+type ConnectionStats struct {
+	ConnectedSince time.Time     // When the current connection came up (zero while down)
+	UptimePct      float64       // Share of the session spent connected (0-100)
+	Reconnects     int           // Reconnects this session
+	Backoff        time.Duration // Wait before the pending reconnect attempt (0 if none)
+}
+
+// ConnectionStatsReporter is implemented by data sources that track their
+// connection's stability.
+type ConnectionStatsReporter interface {
+	ConnectionStats() ConnectionStats
+}
+
+// UptimeTracker accumulates how long a connection has been up. The session
+// it measures starts with the first SetConnected call. The zero value is
+// ready to use and safe for concurrent use.
+type UptimeTracker struct {
+	mu      sync.Mutex
+	start   time.Time     // Zero until the first SetConnected
+	upSince time.Time     // Zero while down
+	upTotal time.Duration // Connected time of earlier, closed periods
+}
+
+// SetConnected records the connection coming up or going down at now.
+// Repeating the current state is a no-op.
+func (t *UptimeTracker) SetConnected(connected bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.start.IsZero() {
+		t.start = now
+	}
+	switch {
+	case connected && t.upSince.IsZero():
+		t.upSince = now
+	case !connected && !t.upSince.IsZero():
+		t.upTotal += now.Sub(t.upSince)
+		t.upSince = time.Time{}
+	}
+}
+
+// ConnectedSince returns when the current connection came up, or the zero
+// time while down.
+func (t *UptimeTracker) ConnectedSince() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.upSince
+}
+
+// UptimePct returns the share of the session up to now spent connected
+// (0-100), or 0 before the session starts.
+func (t *UptimeTracker) UptimePct(now time.Time) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elapsed := now.Sub(t.start)
+	if t.start.IsZero() || elapsed <= 0 {
+		if !t.upSince.IsZero() {
+			return 100
+		}
+		return 0
+	}
+	up := t.upTotal
+	if !t.upSince.IsZero() {
+		up += now.Sub(t.upSince)
+	}
+	return min(float64(up)/float64(elapsed)*100, 100)
+}
@@ -0,0 +1,35 @@
+package health
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUptimeTracker(t *testing.T) {
+	var tracker UptimeTracker
+	start := time.Unix(1_700_000_000, 0)
+
+	if got := tracker.UptimePct(start); got != 0 {
+		t.Errorf("UptimePct before the session = %v, want 0", got)
+	}
+
+	// Connecting for 10s, up for 60s, down for 20s, up for the last 10s
+	tracker.SetConnected(false, start)
+	tracker.SetConnected(true, start.Add(10*time.Second))
+	tracker.SetConnected(true, start.Add(30*time.Second)) // repeated state is a no-op
+	tracker.SetConnected(false, start.Add(70*time.Second))
+
+	if since := tracker.ConnectedSince(); !since.IsZero() {
+		t.Errorf("ConnectedSince while down = %v, want zero", since)
+	}
+
+	reconnected := start.Add(90 * time.Second)
+	tracker.SetConnected(true, reconnected)
+	if since := tracker.ConnectedSince(); !since.Equal(reconnected) {
+		t.Errorf("ConnectedSince = %v, want %v", since, reconnected)
+	}
+
+	if got := tracker.UptimePct(start.Add(100 * time.Second)); got != 70 {
+		t.Errorf("UptimePct = %v, want 70", got)
+	}
+}
@@ -0,0 +1,61 @@
+// Package latency tracks connection latency as an exponentially weighted
+// moving average of round-trip samples.
+package latency
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultAlpha weights each new sample at 20%, smoothing out single slow
+// round trips while following a sustained change within a few samples.
+const DefaultAlpha = 0.2
+
+// EWMA is an exponentially weighted moving average of durations. The zero
+// value is not usable; create one with NewEWMA. It is safe for concurrent use.
+type EWMA struct {
+	alpha float64
+
+	mu      sync.Mutex
+	value   float64 // Nanoseconds
+	samples int
+}
+
+// NewEWMA creates an average weighting each new sample by alpha, which must
+// be in (0, 1]; other values use DefaultAlpha.
+func NewEWMA(alpha float64) *EWMA {
+	if alpha <= 0 || alpha > 1 {
+		alpha = DefaultAlpha
+	}
+	return &EWMA{alpha: alpha}
+}
+
+// Observe adds a sample. The first sample seeds the average; negative
+// samples (clock steps) are ignored.
+func (e *EWMA) Observe(d time.Duration) {
+	if d < 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.samples == 0 {
+		e.value = float64(d)
+	} else {
+		e.value += e.alpha * (float64(d) - e.value)
+	}
+	e.samples++
+}
+
+// Since observes the time elapsed since start.
+func (e *EWMA) Since(start time.Time) {
+	e.Observe(time.Since(start))
+}
+
+// Value returns the current average, or 0 before the first sample.
+func (e *EWMA) Value() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return time.Duration(e.value)
+}
@@ -0,0 +1,36 @@
+package latency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEWMA(t *testing.T) {
+	e := NewEWMA(0.5)
+	if e.Value() != 0 {
+		t.Fatalf("expected 0 before any sample, got %s", e.Value())
+	}
+
+	e.Observe(100 * time.Millisecond)
+	if e.Value() != 100*time.Millisecond {
+		t.Errorf("expected the first sample to seed the average, got %s", e.Value())
+	}
+
+	e.Observe(200 * time.Millisecond)
+	if e.Value() != 150*time.Millisecond {
+		t.Errorf("expected 150ms, got %s", e.Value())
+	}
+
+	e.Observe(-time.Second)
+	if e.Value() != 150*time.Millisecond {
+		t.Errorf("expected a negative sample ignored, got %s", e.Value())
+	}
+}
+
+func TestNewEWMA_InvalidAlpha(t *testing.T) {
+	for _, alpha := range []float64{0, -1, 1.5} {
+		if e := NewEWMA(alpha); e.alpha != DefaultAlpha {
+			t.Errorf("NewEWMA(%v) alpha = %v, want %v", alpha, e.alpha, DefaultAlpha)
+		}
+	}
+}
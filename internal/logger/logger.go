@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TraceIDFunc represents a function that can return the trace id from
@@ -109,11 +111,25 @@ func (log *Logger) write(ctx context.Context, level Level, caller int, msg strin
 	if log.traceIDFunc != nil {
 		args = append(args, "trace_id", log.traceIDFunc(ctx))
 	}
+	args = appendSpanContext(ctx, args, log.traceIDFunc == nil)
 	r.Add(args...)
 
 	log.handler.Handle(ctx, r)
 }
 
+// appendSpanContext adds the IDs of the span active in ctx so log lines can
+// be correlated with traces. Nothing is added when ctx carries no span.
+func appendSpanContext(ctx context.Context, args []any, withTraceID bool) []any {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return args
+	}
+	if withTraceID {
+		args = append(args, "trace_id", sc.TraceID().String())
+	}
+	return append(args, "span_id", sc.SpanID().String())
+}
+
 // =============================================================================
 
 func new(w io.Writer, minLevel Level, serviceName string, traceIDFunc TraceIDFunc, events Events) *Logger {
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestLogger_SpanContext tests that the active span's IDs are added to log
+// entries and omitted when there is no span.
+func TestLogger_SpanContext(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantTraceID string
+		wantSpanID  string
+	}{
+		{name: "active_span", ctx: spanCtx, wantTraceID: traceID.String(), wantSpanID: spanID.String()},
+		{name: "no_span", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			New(&buf, LevelInfo, "test", nil).Info(tt.ctx, "hello")
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("invalid log entry %q: %v", buf.String(), err)
+			}
+
+			for key, want := range map[string]string{"trace_id": tt.wantTraceID, "span_id": tt.wantSpanID} {
+				got, ok := entry[key]
+				if want == "" {
+					if ok {
+						t.Errorf("expected no %s, got %v", key, got)
+					}
+					continue
+				}
+				if got != want {
+					t.Errorf("expected %s %s, got %v", key, want, got)
+				}
+			}
+		})
+	}
+}
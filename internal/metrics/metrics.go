@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	_ "net/http/pprof" // Expone /debug/pprof/* para profiling (heap, allocs, goroutines, etc.)
 	"os"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
@@ -97,6 +97,10 @@ func NewMetricProvider(options ...OptionFn) MetricProvider {
 		))
 	}
 
+	if cfg.MetricPrefix != "" {
+		metricsOps = append(metricsOps, metric2.WithView(prefixView(cfg.MetricPrefix)))
+	}
+
 	meterProvider := metric2.NewMeterProvider(metricsOps...)
 
 	otel.SetMeterProvider(meterProvider)
@@ -104,6 +108,23 @@ func NewMetricProvider(options ...OptionFn) MetricProvider {
 	return meterProvider
 }
 
+// prefixView renames every instrument to prefix_name, so the instruments
+// registered across packages keep their names in code but don't collide with
+// another bot instance's in a shared backend.
+func prefixView(prefix string) metric2.View {
+	if !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+
+	return func(inst metric2.Instrument) (metric2.Stream, bool) {
+		return metric2.Stream{
+			Name:        prefix + inst.Name,
+			Description: inst.Description,
+			Unit:        inst.Unit,
+		}, true
+	}
+}
+
 func ServePrometheusMetrics(opt ...PromOptionFn) {
 	var cfg PromServerConfig
 	var port = "2223"
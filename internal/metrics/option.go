@@ -43,8 +43,9 @@ func NewOtelCollectorConfig(url string, headers map[string]string, insecure bool
 }
 
 type Config struct {
-	ServiceName string
-	Provider    []ProviderCfg
+	ServiceName  string
+	MetricPrefix string
+	Provider     []ProviderCfg
 }
 
 type ProviderCfg struct {
@@ -84,3 +85,14 @@ func WithServiceName(serviceName string) OptionFn {
 		return config
 	}
 }
+
+// WithMetricPrefix prepends prefix to the name of every metric the provider
+// exports, e.g. "eth_mainnet" turns binance_messages_total into
+// eth_mainnet_binance_messages_total.
+func WithMetricPrefix(prefix string) OptionFn {
+	return func(config Config) Config {
+		config.MetricPrefix = prefix
+
+		return config
+	}
+}
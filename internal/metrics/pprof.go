@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// PprofServer serves the net/http/pprof handlers on a dedicated port, kept
+// off the metrics and API ports since profiles expose process internals.
+type PprofServer struct {
+	port   int
+	server *http.Server
+}
+
+// NewPprofServer creates a profiling server listening on port.
+func NewPprofServer(port int) *PprofServer {
+	return &PprofServer{port: port}
+}
+
+// Start serves /debug/pprof/* in the background.
+func (s *PprofServer) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	s.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			// Profiling is optional; a busy port must not stop the bot
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully stops the profiling server.
+func (s *PprofServer) Stop(ctx context.Context) error {
+	if s.server != nil {
+		return s.server.Shutdown(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PortInUseError is returned by Start when another process already listens
+// on the server's port.
+type PortInUseError struct {
+	Port int
+	Err  error
+}
+
+func (e *PortInUseError) Error() string {
+	return fmt.Sprintf("metrics server port %d is already in use", e.Port)
+}
+
+func (e *PortInUseError) Unwrap() error {
+	return e.Err
+}
+
+// PrometheusServer serves /metrics for Prometheus scrapes on a dedicated port.
+type PrometheusServer struct {
+	port     int
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewPrometheusServer creates a metrics server listening on port.
+func NewPrometheusServer(port int) *PrometheusServer {
+	return &PrometheusServer{port: port}
+}
+
+// Start binds the server's port and serves /metrics in the background. A
+// port that is already taken fails with a *PortInUseError instead of going
+// unnoticed.
+func (s *PrometheusServer) Start() error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return &PortInUseError{Port: s.port, Err: err}
+		}
+		return fmt.Errorf("failed to listen on port %d: %w", s.port, err)
+	}
+	s.listener = lis
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := s.server.Serve(lis); err != nil && err != http.ErrServerClosed {
+			// Scrapes just fail; detection carries on without metrics
+		}
+	}()
+
+	return nil
+}
+
+// Addr returns the address the server listens on, or nil before Start.
+func (s *PrometheusServer) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Stop stops accepting scrapes and waits for in-flight ones to finish, or
+// for ctx to be done, whichever comes first.
+func (s *PrometheusServer) Stop(ctx context.Context) error {
+	if s.server != nil {
+		return s.server.Shutdown(ctx)
+	}
+	return nil
+}
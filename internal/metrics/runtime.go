@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"runtime"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const runtimeMeterName = "github.com/fd1az/arbitrage-bot/internal/metrics"
+
+// RegisterRuntimeMetrics exports Go runtime statistics (goroutines, heap,
+// GC pauses) through the global meter provider. Memory stats are read once
+// per collection, so the stop-the-world cost follows the scrape interval.
+func RegisterRuntimeMetrics() error {
+	meter := otel.Meter(runtimeMeterName)
+
+	goroutines, err := meter.Int64ObservableGauge(
+		"runtime_goroutines",
+		metric.WithDescription("Number of live goroutines"),
+	)
+	if err != nil {
+		return err
+	}
+
+	heapAlloc, err := meter.Int64ObservableGauge(
+		"runtime_heap_alloc_bytes",
+		metric.WithDescription("Bytes of allocated heap objects"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+
+	heapObjects, err := meter.Int64ObservableGauge(
+		"runtime_heap_objects",
+		metric.WithDescription("Number of allocated heap objects"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gcCount, err := meter.Int64ObservableCounter(
+		"runtime_gc_count_total",
+		metric.WithDescription("Completed GC cycles"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gcPauseTotal, err := meter.Float64ObservableCounter(
+		"runtime_gc_pause_seconds_total",
+		metric.WithDescription("Cumulative stop-the-world GC pause time"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gcPauseLast, err := meter.Float64ObservableGauge(
+		"runtime_gc_pause_last_seconds",
+		metric.WithDescription("Duration of the most recent GC pause"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+
+		o.ObserveInt64(goroutines, int64(runtime.NumGoroutine()))
+		o.ObserveInt64(heapAlloc, int64(ms.HeapAlloc))
+		o.ObserveInt64(heapObjects, int64(ms.HeapObjects))
+		o.ObserveInt64(gcCount, int64(ms.NumGC))
+		o.ObserveFloat64(gcPauseTotal, float64(ms.PauseTotalNs)/1e9)
+		if ms.NumGC > 0 {
+			// PauseNs is a circular buffer; the latest pause is at (NumGC+255)%256
+			o.ObserveFloat64(gcPauseLast, float64(ms.PauseNs[(ms.NumGC+255)%256])/1e9)
+		}
+		return nil
+	}, goroutines, heapAlloc, heapObjects, gcCount, gcPauseTotal, gcPauseLast)
+	return err
+}
@@ -0,0 +1,33 @@
+package monolith
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/fd1az/arbitrage-bot/internal/asset"
+	"github.com/fd1az/arbitrage-bot/internal/config"
+)
+
+// NewAssetRegistry returns the default registry extended with the tokens
+// configured for the selected chain (ethereum.tokens and the chain profile).
+func NewAssetRegistry(cfg *config.Config) (*asset.Registry, error) {
+	registry := asset.DefaultRegistry()
+	chainID := cfg.Ethereum.ChainID
+
+	for name, token := range cfg.Ethereum.Tokens {
+		// Config keys are lowercased; token symbols are conventionally upper case
+		symbol := strings.ToUpper(name)
+		addr := common.HexToAddress(token.Address)
+		if registry.Has(asset.NewTokenAssetID(chainID, addr)) {
+			continue
+		}
+		if existing, ok := registry.GetBySymbolAndChain(symbol, chainID); ok {
+			return nil, fmt.Errorf("token %s on chain %d is already registered at %s", symbol, chainID, existing.Address().Hex())
+		}
+		registry.Register(asset.MustNewToken(chainID, addr, symbol, symbol, token.Decimals))
+	}
+
+	return registry, nil
+}
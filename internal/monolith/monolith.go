@@ -9,6 +9,7 @@ import (
 	"github.com/fd1az/arbitrage-bot/internal/asset"
 	"github.com/fd1az/arbitrage-bot/internal/config"
 	"github.com/fd1az/arbitrage-bot/internal/di"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 	"github.com/fd1az/arbitrage-bot/internal/logger"
 )
 
@@ -19,6 +20,7 @@ type Monolith interface {
 	EthClient() *ethclient.Client
 	AssetRegistry() *asset.Registry
 	Services() di.ServiceRegistry
+	Health() *health.Server // nil when no health server is running
 }
 
 // Module represents a bounded context module that can register services and start up.
@@ -34,18 +36,33 @@ type app struct {
 	ethClient     *ethclient.Client
 	assetRegistry *asset.Registry
 	container     di.Container
+	health        *health.Server
+}
+
+// Option configures the Monolith.
+type Option func(*app)
+
+// WithHealthServer lets modules register data sources with the health server.
+func WithHealthServer(s *health.Server) Option {
+	return func(a *app) {
+		a.health = s
+	}
 }
 
 // New creates a new Monolith instance.
-func New(cfg *config.Config, log logger.LoggerInterface) (*app, error) {
+func New(cfg *config.Config, log logger.LoggerInterface, opts ...Option) (*app, error) {
 	// Create Ethereum client
 	ethClient, err := ethclient.Dial(cfg.Ethereum.HTTPURL)
 	if err != nil {
 		return nil, err
 	}
 
-	// Use default asset registry (pre-populated with common assets)
-	assetRegistry := asset.DefaultRegistry()
+	// Default asset registry plus the selected chain's configured tokens
+	assetRegistry, err := NewAssetRegistry(cfg)
+	if err != nil {
+		ethClient.Close()
+		return nil, err
+	}
 
 	container := di.NewContainer()
 
@@ -55,13 +72,18 @@ func New(cfg *config.Config, log logger.LoggerInterface) (*app, error) {
 	container.Register("ethClient", ethClient)
 	container.Register("assetRegistry", assetRegistry)
 
-	return &app{
+	a := &app{
 		config:        cfg,
 		logger:        log,
 		ethClient:     ethClient,
 		assetRegistry: assetRegistry,
 		container:     container,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a, nil
 }
 
 func (a *app) Config() *config.Config {
@@ -84,6 +106,10 @@ func (a *app) Services() di.ServiceRegistry {
 	return a.container
 }
 
+func (a *app) Health() *health.Server {
+	return a.health
+}
+
 // Container returns the DI container for module registration.
 func (a *app) Container() di.Container {
 	return a.container
@@ -0,0 +1,111 @@
+package wsconn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/coder/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// CompressionMode selects permessage-deflate (RFC 7692) negotiation.
+type CompressionMode string
+
+const (
+	// CompressionContextTakeover keeps the deflate window across messages
+	// (default): best ratio, but every message pays the deflate cost.
+	CompressionContextTakeover CompressionMode = "context_takeover"
+	// CompressionNoContextTakeover compresses each message on its own:
+	// less memory, lower ratio.
+	CompressionNoContextTakeover CompressionMode = "no_context_takeover"
+	// CompressionDisabled skips negotiation, trading bandwidth for latency
+	// on small messages.
+	CompressionDisabled CompressionMode = "disabled"
+)
+
+// dialMode maps the mode to the websocket library's; empty is the default.
+func (m CompressionMode) dialMode() (websocket.CompressionMode, error) {
+	switch m {
+	case "", CompressionContextTakeover:
+		return websocket.CompressionContextTakeover, nil
+	case CompressionNoContextTakeover:
+		return websocket.CompressionNoContextTakeover, nil
+	case CompressionDisabled:
+		return websocket.CompressionDisabled, nil
+	}
+	return 0, fmt.Errorf("unsupported compression mode %q (want %s, %s or %s)",
+		m, CompressionContextTakeover, CompressionNoContextTakeover, CompressionDisabled)
+}
+
+// CompressionRatio returns wire bytes read per decoded payload byte since
+// the client was created. Wire bytes include framing and TLS overhead, so
+// an uncompressed stream sits slightly above 1; zero until a message arrives.
+func (c *Client) CompressionRatio() float64 {
+	payload := c.payloadBytes.Load()
+	if payload == 0 {
+		return 0
+	}
+	return float64(c.wireBytes.Load()) / float64(payload)
+}
+
+// countingConn counts bytes read off the underlying connection, before TLS
+// and permessage-deflate are undone.
+type countingConn struct {
+	net.Conn
+	read *atomic.Int64
+}
+
+func (c countingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.read.Add(int64(n))
+	return n, err
+}
+
+// wireCountingClient returns a handshake client whose connections count
+// the bytes read into read. base supplies the transport (e.g. a proxy);
+// nil dials directly.
+func wireCountingClient(base *http.Client, read *atomic.Int64) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if base != nil {
+		if t, ok := base.Transport.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+	}
+
+	// The upgrade needs HTTP/1.1; a custom dialer only gets HTTP/2 when forced
+	transport.ForceAttemptHTTP2 = false
+
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return countingConn{Conn: conn, read: read}, nil
+	}
+
+	// No client timeout: the dial is bounded by its context and websocket
+	// rejects clients with one
+	return &http.Client{Transport: transport}
+}
+
+// recordCompression adds a received payload to the compression stats and
+// exports the wire bytes read since the last message.
+func (c *Client) recordCompression(ctx context.Context, payload int, attrs []attribute.KeyValue) {
+	c.payloadBytes.Add(int64(payload))
+
+	wire := c.wireBytes.Load()
+	if delta := wire - c.wireRecorded.Swap(wire); delta > 0 {
+		c.metrics.wireBytesReceived.Add(ctx, delta, metric.WithAttributes(attrs...))
+	}
+
+	attrs = append(attrs, attribute.String("ws.compression", string(c.config.Compression)))
+	c.metrics.compressionRatio.Record(ctx, c.CompressionRatio(), metric.WithAttributes(attrs...))
+}
@@ -0,0 +1,51 @@
+package wsconn
+
+import (
+	"sync"
+	"time"
+)
+
+// DropInfo describes a message dropped because the buffer was full.
+type DropInfo struct {
+	Size      int       // Message size in bytes
+	Timestamp time.Time // When the message was dropped
+}
+
+// dropRing is a fixed-capacity ring buffer of the most recent drops.
+type dropRing struct {
+	mu      sync.Mutex
+	entries []DropInfo
+	next    int
+	full    bool
+}
+
+// newDropRing creates a ring holding up to size entries.
+func newDropRing(size int) *dropRing {
+	return &dropRing{entries: make([]DropInfo, size)}
+}
+
+// add records a drop, overwriting the oldest entry when full.
+func (r *dropRing) add(info DropInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[r.next] = info
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot returns the recorded drops, oldest first.
+func (r *dropRing) snapshot() []DropInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]DropInfo(nil), r.entries[:r.next]...)
+	}
+
+	out := make([]DropInfo, 0, len(r.entries))
+	out = append(out, r.entries[r.next:]...)
+	return append(out, r.entries[:r.next]...)
+}
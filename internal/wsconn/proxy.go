@@ -0,0 +1,40 @@
+package wsconn
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// proxyHTTPClient returns the HTTP client used for the WebSocket handshake
+// when a proxy is configured, or nil to dial directly.
+//
+// http:// and https:// proxies tunnel wss:// URLs with CONNECT; socks5://
+// (and socks5h://, resolving names on the proxy) work for ws:// and wss://.
+// An unreachable proxy fails the dial like an unreachable server, so
+// ConnectWithRetry backs off and retries it the same way.
+func proxyHTTPClient(proxyURL string) (*http.Client, error) {
+	if proxyURL == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (want http, https or socks5)", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL: missing host")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(u)
+
+	// No client timeout: the dial is bounded by its context and websocket
+	// rejects clients with one
+	return &http.Client{Transport: transport}, nil
+}
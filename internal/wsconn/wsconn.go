@@ -8,11 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/coder/websocket"
+	"github.com/fd1az/arbitrage-bot/internal/health"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -36,6 +38,18 @@ const (
 	StateClosed       State = "closed"
 )
 
+// BackpressurePolicy controls what happens when the message buffer is full.
+type BackpressurePolicy string
+
+const (
+	// DropNewest discards the incoming message (default).
+	DropNewest BackpressurePolicy = "drop_newest"
+	// DropOldest discards the oldest buffered message to make room.
+	DropOldest BackpressurePolicy = "drop_oldest"
+	// Block waits up to BlockTimeout for buffer space before dropping the incoming message.
+	Block BackpressurePolicy = "block"
+)
+
 // Config holds WebSocket client configuration.
 type Config struct {
 	URL            string
@@ -48,6 +62,16 @@ type Config struct {
 	WriteTimeout   time.Duration
 	BufferSize     int
 	MaxMessageSize int64 // Max message size in bytes (0 = no limit)
+
+	BackpressurePolicy BackpressurePolicy // Behavior when the buffer is full (default DropNewest)
+	BlockTimeout       time.Duration      // Max wait for buffer space under the Block policy
+
+	TrackDrops      bool // Record recent drops for DroppedMessageStats
+	DropHistorySize int  // Drops kept when TrackDrops is set
+
+	ProxyURL string // http://, https:// or socks5:// proxy for the dial (empty = direct)
+
+	Compression CompressionMode // permessage-deflate negotiation (empty = CompressionContextTakeover)
 }
 
 // DefaultConfig returns sensible defaults.
@@ -63,6 +87,14 @@ func DefaultConfig(url string, name string) Config {
 		WriteTimeout:   10 * time.Second,
 		BufferSize:     1024,             // Increased from 256 to reduce message drops
 		MaxMessageSize: 10 * 1024 * 1024, // 10MB
+
+		BackpressurePolicy: DropNewest,
+		BlockTimeout:       100 * time.Millisecond,
+
+		TrackDrops:      false,
+		DropHistorySize: 100,
+
+		Compression: CompressionContextTakeover,
 	}
 }
 
@@ -72,18 +104,25 @@ type MessageHandler func(ctx context.Context, msg []byte)
 // StateChangeHandler is called when connection state changes.
 type StateChangeHandler func(state State, err error)
 
+// ReconnectHandler is called after a successful reconnect, e.g. to re-send
+// subscriptions. Returning an error drops the connection and retries.
+type ReconnectHandler func(ctx context.Context) error
+
 // metrics holds OTEL metric instruments.
 type metrics struct {
-	connectionState  metric.Int64Gauge
-	messagesReceived metric.Int64Counter
-	messagesSent     metric.Int64Counter
-	reconnectsTotal  metric.Int64Counter
-	droppedMessages  metric.Int64Counter
-	messageLatency   metric.Float64Histogram
-	bytesReceived    metric.Int64Counter
-	bytesSent        metric.Int64Counter
-	pingsTotal       metric.Int64Counter
-	pingsFailed      metric.Int64Counter
+	connectionState   metric.Int64Gauge
+	messagesReceived  metric.Int64Counter
+	messagesSent      metric.Int64Counter
+	reconnectsTotal   metric.Int64Counter
+	droppedMessages   metric.Int64Counter
+	messageLatency    metric.Float64Histogram
+	bytesReceived     metric.Int64Counter
+	bytesSent         metric.Int64Counter
+	pingsTotal        metric.Int64Counter
+	pingsFailed       metric.Int64Counter
+	pingRTT           metric.Float64Histogram
+	wireBytesReceived metric.Int64Counter
+	compressionRatio  metric.Float64Gauge
 }
 
 // Client is a production-grade WebSocket client with OTEL instrumentation.
@@ -103,15 +142,38 @@ type Client struct {
 	reconnects   int
 	reconnectsMu sync.Mutex
 
+	// Session connection stability, for ConnectionStats
+	uptime          health.UptimeTracker
+	totalReconnects atomic.Int64
+	backoff         atomic.Int64 // Pending reconnect wait in nanoseconds (0 = none)
+
+	lastPingRTT atomic.Int64 // Nanoseconds from the last ping to its pong (0 = none yet)
+
+	// A single supervisor goroutine, started by the first successful
+	// Connect, owns reconnection; disconnects wakes it.
+	disconnects chan error
+	supervising atomic.Bool
+
 	tracer  trace.Tracer
 	metrics *metrics
 
 	handlersMu    sync.RWMutex
 	onMessage     MessageHandler
 	onStateChange StateChangeHandler
+	onReconnect   ReconnectHandler
 
 	connectedAt time.Time
 	stopPing    chan struct{}
+
+	drops *dropRing // nil unless TrackDrops is set
+
+	httpClient  *http.Client // Handshake client, counting wire bytes (proxied when ProxyURL is set)
+	compression websocket.CompressionMode
+
+	// Bytes read off the wire vs decoded payload bytes, for the compression ratio
+	wireBytes    atomic.Int64
+	wireRecorded atomic.Int64 // wireBytes already added to the wire counter
+	payloadBytes atomic.Int64
 }
 
 // New creates a new WebSocket client with OTEL instrumentation.
@@ -123,6 +185,27 @@ func New(config Config) (*Client, error) {
 		done:     make(chan struct{}),
 		stopPing: make(chan struct{}),
 		tracer:   otel.Tracer(tracerName),
+
+		disconnects: make(chan error, 1),
+	}
+
+	httpClient, err := proxyHTTPClient(config.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	c.httpClient = wireCountingClient(httpClient, &c.wireBytes)
+
+	c.compression, err = config.Compression.dialMode()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.TrackDrops {
+		size := config.DropHistorySize
+		if size <= 0 {
+			size = DefaultConfig(config.URL, config.Name).DropHistorySize
+		}
+		c.drops = newDropRing(size)
 	}
 
 	if err := c.initMetrics(); err != nil {
@@ -230,6 +313,33 @@ func (c *Client) initMetrics() error {
 		return err
 	}
 
+	c.metrics.pingRTT, err = meter.Float64Histogram(
+		"ws_ping_rtt_ms",
+		metric.WithDescription("WebSocket ping to pong round trip in milliseconds"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.wireBytesReceived, err = meter.Int64Counter(
+		"ws_wire_bytes_received_total",
+		metric.WithDescription("Total bytes read off the connection before decompression, including framing and TLS overhead"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.metrics.compressionRatio, err = meter.Float64Gauge(
+		"ws_compression_ratio",
+		metric.WithDescription("Wire bytes received per decoded payload byte (below 1 means compression pays off)"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -247,12 +357,35 @@ func (c *Client) OnStateChange(handler StateChangeHandler) {
 	c.onStateChange = handler
 }
 
-// Connect establishes the WebSocket connection.
+// OnReconnect sets the handler called after each successful reconnect.
+// It is not called for the initial connection.
+func (c *Client) OnReconnect(handler ReconnectHandler) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.onReconnect = handler
+}
+
+// Connect establishes the WebSocket connection and starts the reconnect
+// supervisor if it is not already running.
 func (c *Client) Connect(ctx context.Context) error {
+	if err := c.dial(ctx); err != nil {
+		return err
+	}
+
+	if c.supervising.CompareAndSwap(false, true) {
+		go c.supervise()
+	}
+	return nil
+}
+
+// dial opens a connection and starts its read and ping loops.
+func (c *Client) dial(ctx context.Context) error {
 	ctx, span := c.tracer.Start(ctx, "ws.connect",
 		trace.WithAttributes(
 			attribute.String("ws.url", c.config.URL),
 			attribute.String("ws.name", c.config.Name),
+			attribute.Bool("ws.proxied", c.config.ProxyURL != ""),
+			attribute.String("ws.compression", string(c.config.Compression)),
 		),
 		trace.WithSpanKind(trace.SpanKindClient),
 	)
@@ -261,7 +394,8 @@ func (c *Client) Connect(ctx context.Context) error {
 	c.setState(StateConnecting)
 
 	conn, _, err := websocket.Dial(ctx, c.config.URL, &websocket.DialOptions{
-		CompressionMode: websocket.CompressionContextTakeover,
+		CompressionMode: c.compression,
+		HTTPClient:      c.httpClient,
 	})
 	if err != nil {
 		span.RecordError(err)
@@ -285,16 +419,17 @@ func (c *Client) Connect(ctx context.Context) error {
 	span.AddEvent("connection established")
 
 	// Start read loop with background context (not tied to connection context)
-	go c.readLoop(context.Background())
+	go c.readLoop(context.Background(), conn)
 
 	// Start ping loop for heartbeat
-	go c.startPingLoop(context.Background())
+	go c.startPingLoop(context.Background(), conn)
 
 	return nil
 }
 
-// startPingLoop sends periodic pings to detect half-open connections.
-func (c *Client) startPingLoop(ctx context.Context) {
+// startPingLoop sends periodic pings on conn to detect half-open
+// connections. It exits once conn is no longer the client's connection.
+func (c *Client) startPingLoop(ctx context.Context, conn *websocket.Conn) {
 	if c.config.PingInterval <= 0 {
 		return
 	}
@@ -312,23 +447,27 @@ func (c *Client) startPingLoop(ctx context.Context) {
 			return
 		case <-ticker.C:
 			c.connMu.RLock()
-			conn := c.conn
+			current := c.conn
 			c.connMu.RUnlock()
 
-			if conn == nil {
+			if current != conn {
 				return
 			}
 
 			pingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-			err := conn.Ping(pingCtx)
+			start := time.Now()
+			err := conn.Ping(pingCtx) // Returns once the pong arrives
+			rtt := time.Since(start)
 			cancel()
 
 			if err != nil {
 				c.metrics.pingsFailed.Add(ctx, 1, attrs)
-				c.handleDisconnect(ctx, fmt.Errorf("ping failed: %w", err))
+				c.handleDisconnect(ctx, conn, fmt.Errorf("ping failed: %w", err))
 				return
 			}
 			c.metrics.pingsTotal.Add(ctx, 1, attrs)
+			c.metrics.pingRTT.Record(ctx, float64(rtt.Microseconds())/1000, attrs)
+			c.lastPingRTT.Store(int64(rtt))
 		}
 	}
 }
@@ -400,8 +539,9 @@ func (c *Client) ConnectWithRetry(ctx context.Context) error {
 	}
 }
 
-// readLoop continuously reads messages from the WebSocket.
-func (c *Client) readLoop(ctx context.Context) {
+// readLoop continuously reads messages from conn until it fails or is
+// replaced.
+func (c *Client) readLoop(ctx context.Context, conn *websocket.Conn) {
 	attrs := []attribute.KeyValue{
 		attribute.String("ws.name", c.config.Name),
 	}
@@ -416,10 +556,10 @@ func (c *Client) readLoop(ctx context.Context) {
 		}
 
 		c.connMu.RLock()
-		conn := c.conn
+		current := c.conn
 		c.connMu.RUnlock()
 
-		if conn == nil {
+		if current != conn {
 			return
 		}
 
@@ -446,7 +586,7 @@ func (c *Client) readLoop(ctx context.Context) {
 
 			// Handle reconnection
 			if websocket.CloseStatus(err) != -1 || errors.Is(err, context.DeadlineExceeded) {
-				c.handleDisconnect(ctx, err)
+				c.handleDisconnect(ctx, conn, err)
 				return
 			}
 
@@ -457,7 +597,7 @@ func (c *Client) readLoop(ctx context.Context) {
 			span.SetStatus(codes.Error, "read failed")
 			span.End()
 
-			c.handleDisconnect(ctx, err)
+			c.handleDisconnect(ctx, conn, err)
 			return
 		}
 
@@ -473,16 +613,18 @@ func (c *Client) readLoop(ctx context.Context) {
 
 			c.metrics.messagesReceived.Add(ctx, 1, metric.WithAttributes(attrs...))
 			c.metrics.bytesReceived.Add(ctx, int64(len(data)), metric.WithAttributes(attrs...))
+			c.recordCompression(ctx, len(data), attrs)
 			c.metrics.messageLatency.Record(ctx, latency, metric.WithAttributes(attrs...))
 
-			// Send to channel (non-blocking to prevent read loop stall)
-			select {
-			case c.messages <- data:
-			default:
-				// Buffer full - drop message but track it
-				c.metrics.droppedMessages.Add(ctx, 1, metric.WithAttributes(attrs...))
+			// Send to channel according to the backpressure policy
+			if dropped := c.enqueue(data); dropped > 0 {
+				// Buffer full - track dropped messages
+				c.metrics.droppedMessages.Add(ctx, int64(dropped), metric.WithAttributes(attrs...))
 				span.AddEvent("message dropped - buffer full",
-					trace.WithAttributes(attribute.Int("buffer_size", c.config.BufferSize)))
+					trace.WithAttributes(
+						attribute.Int("buffer_size", c.config.BufferSize),
+						attribute.String("backpressure_policy", string(c.config.BackpressurePolicy)),
+					))
 			}
 
 			// Call handler if set (with mutex protection)
@@ -499,13 +641,85 @@ func (c *Client) readLoop(ctx context.Context) {
 	}
 }
 
-// handleDisconnect handles connection loss and initiates reconnection.
-func (c *Client) handleDisconnect(ctx context.Context, err error) {
+// enqueue delivers a message to the buffer, applying the backpressure policy
+// when it is full. It returns the number of messages dropped (0 or 1).
+func (c *Client) enqueue(data []byte) int {
+	select {
+	case c.messages <- data:
+		return 0
+	default:
+	}
+
+	switch c.config.BackpressurePolicy {
+	case DropOldest:
+		dropped := 0
+		select {
+		case oldest := <-c.messages:
+			c.recordDrop(oldest)
+			dropped = 1
+		default:
+			// Consumer drained the buffer in the meantime
+		}
+		// Only the read loop sends, so there is now room for the new message
+		c.messages <- data
+		return dropped
+
+	case Block:
+		if c.config.BlockTimeout <= 0 {
+			c.recordDrop(data)
+			return 1
+		}
+		timer := time.NewTimer(c.config.BlockTimeout)
+		defer timer.Stop()
+
+		select {
+		case c.messages <- data:
+			return 0
+		case <-timer.C:
+		case <-c.done:
+		}
+		c.recordDrop(data)
+		return 1
+
+	default:
+		c.recordDrop(data)
+		return 1
+	}
+}
+
+// recordDrop adds a dropped message to the drop history when tracking is enabled.
+func (c *Client) recordDrop(data []byte) {
+	if c.drops == nil {
+		return
+	}
+	c.drops.add(DropInfo{Size: len(data), Timestamp: time.Now()})
+}
+
+// DroppedMessageStats returns the most recent dropped messages, oldest first.
+// It returns nil unless Config.TrackDrops is set.
+func (c *Client) DroppedMessageStats() []DropInfo {
+	if c.drops == nil {
+		return nil
+	}
+	return c.drops.snapshot()
+}
+
+// errStopReconnecting ends the supervisor's backoff loop: the client was
+// closed or MaxReconnects was exceeded.
+var errStopReconnecting = errors.New("reconnection stopped")
+
+// handleDisconnect handles the loss of conn and wakes the supervisor. Losses
+// reported for a connection that was already dropped or replaced (the read
+// and ping loops may both notice) are ignored.
+func (c *Client) handleDisconnect(ctx context.Context, conn *websocket.Conn, err error) {
 	if c.closed.Load() {
 		return
 	}
+	if !c.detach(conn) {
+		return
+	}
 
-	ctx, span := c.tracer.Start(ctx, "ws.disconnect",
+	_, span := c.tracer.Start(ctx, "ws.disconnect",
 		trace.WithAttributes(
 			attribute.String("ws.name", c.config.Name),
 		),
@@ -518,23 +732,62 @@ func (c *Client) handleDisconnect(ctx context.Context, err error) {
 
 	c.setState(StateReconnecting)
 
+	// A pending signal already covers this loss
+	select {
+	case c.disconnects <- err:
+	default:
+	}
+}
+
+// detach closes conn if it is still the client's connection, reporting
+// whether it was.
+func (c *Client) detach(conn *websocket.Conn) bool {
 	c.connMu.Lock()
-	if c.conn != nil {
-		c.conn.Close(websocket.StatusGoingAway, "reconnecting")
-		c.conn = nil
+	if conn == nil || c.conn != conn {
+		c.connMu.Unlock()
+		return false
 	}
+	c.conn = nil
 	c.connMu.Unlock()
 
-	// Attempt reconnection
-	go c.reconnect(ctx)
+	conn.Close(websocket.StatusGoingAway, "reconnecting")
+	return true
+}
+
+// supervise owns reconnection for the client's lifetime: it waits for a
+// connection loss and runs the backoff loop until reconnected, so at most
+// one reconnect is ever in flight. It exits on Close or when MaxReconnects
+// is exceeded.
+func (c *Client) supervise() {
+	defer c.supervising.Store(false)
+
+	ctx := context.Background()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.disconnects:
+		}
+
+		for {
+			err := c.reconnect(ctx)
+			if err == nil {
+				break
+			}
+			if errors.Is(err, errStopReconnecting) {
+				return
+			}
+		}
+	}
 }
 
-// reconnect attempts to reconnect with exponential backoff.
-func (c *Client) reconnect(ctx context.Context) {
+// reconnect makes one reconnect attempt after an exponential backoff.
+func (c *Client) reconnect(ctx context.Context) error {
 	c.reconnectsMu.Lock()
 	c.reconnects++
 	attempt := c.reconnects
 	c.reconnectsMu.Unlock()
+	c.totalReconnects.Add(1)
 
 	ctx, span := c.tracer.Start(ctx, "ws.reconnect",
 		trace.WithAttributes(
@@ -560,6 +813,7 @@ func (c *Client) reconnect(ctx context.Context) {
 	// Add jitter
 	jitter := time.Duration(rand.Int63n(int64(backoff) / 2))
 	sleepDuration := backoff + jitter
+	c.backoff.Store(int64(sleepDuration))
 
 	span.AddEvent("waiting before reconnect",
 		trace.WithAttributes(
@@ -569,15 +823,18 @@ func (c *Client) reconnect(ctx context.Context) {
 
 	select {
 	case <-ctx.Done():
+		c.backoff.Store(0)
 		span.RecordError(ctx.Err())
-		return
+		return errStopReconnecting
 	case <-c.done:
-		return
+		c.backoff.Store(0)
+		return errStopReconnecting
 	case <-time.After(sleepDuration):
+		c.backoff.Store(0)
 	}
 
 	if c.closed.Load() {
-		return
+		return errStopReconnecting
 	}
 
 	if c.config.MaxReconnects > 0 && attempt > c.config.MaxReconnects {
@@ -589,16 +846,39 @@ func (c *Client) reconnect(ctx context.Context) {
 		if stateHandler != nil {
 			stateHandler(StateDisconnected, errors.New("max reconnects exceeded"))
 		}
-		return
+		return errStopReconnecting
 	}
 
-	err := c.Connect(ctx)
-	if err != nil {
+	// Losses signalled so far are for connections this attempt replaces
+	select {
+	case <-c.disconnects:
+	default:
+	}
+
+	if err := c.dial(ctx); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "reconnect failed")
-		// Try again
-		go c.reconnect(ctx)
-		return
+		return err
+	}
+
+	// Let consumers restore subscriptions (handler called without the mutex held)
+	c.handlersMu.RLock()
+	reconnectHandler := c.onReconnect
+	c.handlersMu.RUnlock()
+	if reconnectHandler != nil {
+		if err := reconnectHandler(ctx); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "reconnect handler failed")
+
+			// Drop the connection and retry in this loop
+			c.connMu.RLock()
+			conn := c.conn
+			c.connMu.RUnlock()
+			if c.detach(conn) {
+				c.setState(StateReconnecting)
+			}
+			return fmt.Errorf("reconnect handler: %w", err)
+		}
 	}
 
 	// Reset reconnect counter on successful connection
@@ -607,6 +887,7 @@ func (c *Client) reconnect(ctx context.Context) {
 	c.reconnectsMu.Unlock()
 
 	span.SetStatus(codes.Ok, "reconnected")
+	return nil
 }
 
 // Send sends a message through the WebSocket.
@@ -739,6 +1020,7 @@ func (c *Client) setState(state State) {
 	if oldState == state {
 		return
 	}
+	c.uptime.SetConnected(state == StateConnected, time.Now())
 
 	// Record state as metric
 	stateValue := int64(0)
@@ -768,6 +1050,23 @@ func (c *Client) setState(state State) {
 	}
 }
 
+// LastPingRTT returns the round trip of the last answered ping, or 0 before
+// the first pong (or when PingInterval is 0).
+func (c *Client) LastPingRTT() time.Duration {
+	return time.Duration(c.lastPingRTT.Load())
+}
+
+// ConnectionStats reports the session's uptime, reconnect attempts and the
+// backoff before the pending reconnect.
+func (c *Client) ConnectionStats() health.ConnectionStats {
+	return health.ConnectionStats{
+		ConnectedSince: c.uptime.ConnectedSince(),
+		UptimePct:      c.uptime.UptimePct(time.Now()),
+		Reconnects:     int(c.totalReconnects.Load()),
+		Backoff:        time.Duration(c.backoff.Load()),
+	}
+}
+
 // ReconnectCount returns the current reconnect attempt count.
 func (c *Client) ReconnectCount() int {
 	c.reconnectsMu.Lock()
@@ -3,8 +3,10 @@ package wsconn
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -423,3 +425,424 @@ func TestClient_MaxMessageSize(t *testing.T) {
 		t.Error("expected client to disconnect after receiving oversized message")
 	}
 }
+
+func TestClient_BackpressurePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      BackpressurePolicy
+		drain       bool // Consumer frees a slot while the third message waits
+		wantDropped int
+		wantBuffer  []string
+	}{
+		{name: "drop_newest", policy: DropNewest, wantDropped: 1, wantBuffer: []string{"1", "2"}},
+		{name: "drop_oldest", policy: DropOldest, wantDropped: 1, wantBuffer: []string{"2", "3"}},
+		{name: "block_times_out", policy: Block, wantDropped: 1, wantBuffer: []string{"1", "2"}},
+		{name: "block_waits_for_space", policy: Block, drain: true, wantDropped: 0, wantBuffer: []string{"2", "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig("ws://unused", "test")
+			cfg.BufferSize = 2
+			cfg.BackpressurePolicy = tt.policy
+			cfg.BlockTimeout = 50 * time.Millisecond
+
+			client, err := New(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			// Fill the buffer
+			for _, msg := range []string{"1", "2"} {
+				if dropped := client.enqueue([]byte(msg)); dropped != 0 {
+					t.Fatalf("expected no drops while filling buffer, got %d", dropped)
+				}
+			}
+
+			drained := make(chan []byte, 1)
+			if tt.drain {
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					drained <- <-client.Messages()
+				}()
+			}
+
+			dropped := client.enqueue([]byte("3"))
+			if dropped != tt.wantDropped {
+				t.Errorf("expected %d dropped, got %d", tt.wantDropped, dropped)
+			}
+			if tt.drain {
+				if msg := <-drained; string(msg) != "1" {
+					t.Errorf("expected consumer to receive 1, got %q", msg)
+				}
+			}
+
+			var got []string
+			for len(client.Messages()) > 0 {
+				got = append(got, string(<-client.Messages()))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantBuffer, ",") {
+				t.Errorf("expected buffer %v, got %v", tt.wantBuffer, got)
+			}
+		})
+	}
+}
+
+func TestClient_DroppedMessageStats(t *testing.T) {
+	t.Run("disabled_by_default", func(t *testing.T) {
+		cfg := DefaultConfig("ws://unused", "test")
+		cfg.BufferSize = 1
+
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		client.enqueue([]byte("a"))
+		client.enqueue([]byte("bb"))
+
+		if stats := client.DroppedMessageStats(); stats != nil {
+			t.Errorf("expected nil stats when tracking is off, got %v", stats)
+		}
+	})
+
+	t.Run("keeps_last_n_drops", func(t *testing.T) {
+		cfg := DefaultConfig("ws://unused", "test")
+		cfg.BufferSize = 1
+		cfg.TrackDrops = true
+		cfg.DropHistorySize = 3
+
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		client.enqueue([]byte("x")) // Fills the buffer
+		for _, msg := range []string{"1", "22", "333", "4444", "55555"} {
+			client.enqueue([]byte(msg))
+		}
+
+		stats := client.DroppedMessageStats()
+		if len(stats) != 3 {
+			t.Fatalf("expected 3 drops, got %d", len(stats))
+		}
+		for i, want := range []int{3, 4, 5} {
+			if stats[i].Size != want {
+				t.Errorf("drop %d: expected size %d, got %d", i, want, stats[i].Size)
+			}
+			if stats[i].Timestamp.IsZero() {
+				t.Errorf("drop %d: expected timestamp", i)
+			}
+		}
+	})
+
+	t.Run("drop_oldest_records_evicted_message", func(t *testing.T) {
+		cfg := DefaultConfig("ws://unused", "test")
+		cfg.BufferSize = 1
+		cfg.BackpressurePolicy = DropOldest
+		cfg.TrackDrops = true
+
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		client.enqueue([]byte("old message"))
+		client.enqueue([]byte("new"))
+
+		stats := client.DroppedMessageStats()
+		if len(stats) != 1 || stats[0].Size != len("old message") {
+			t.Errorf("expected evicted message of size %d, got %v", len("old message"), stats)
+		}
+	})
+}
+
+func TestClient_OnReconnect(t *testing.T) {
+	var connections atomic.Int32
+	server := mockWSServer(t, func(conn *websocket.Conn) {
+		// Drop the first connection to force a reconnect
+		if connections.Add(1) == 1 {
+			return
+		}
+		// Keep reading so client-initiated closes complete
+		for {
+			if _, _, err := conn.Read(context.Background()); err != nil {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	cfg := DefaultConfig(wsURL, "test")
+	cfg.PingInterval = 0
+	cfg.InitialBackoff = 10 * time.Millisecond
+	cfg.MaxBackoff = 20 * time.Millisecond
+
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	var calls atomic.Int32
+	resubscribed := make(chan struct{})
+	var once sync.Once
+
+	client.OnReconnect(func(ctx context.Context) error {
+		// Fail the first resubscription; it must trigger another reconnect
+		if calls.Add(1) == 1 {
+			return errors.New("subscribe failed")
+		}
+		once.Do(func() { close(resubscribed) })
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	select {
+	case <-resubscribed:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("reconnect handler did not succeed, called %d times", calls.Load())
+	}
+
+	if got := connections.Load(); got < 3 {
+		t.Errorf("expected at least 3 connections (initial, failed resubscribe, retry), got %d", got)
+	}
+}
+
+func TestClient_ConnectionStats(t *testing.T) {
+	var connections atomic.Int32
+	server := mockWSServer(t, func(conn *websocket.Conn) {
+		// Drop the first connection to force a reconnect
+		if connections.Add(1) == 1 {
+			return
+		}
+		for {
+			if _, _, err := conn.Read(context.Background()); err != nil {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	cfg := DefaultConfig(wsURL, "test")
+	cfg.PingInterval = 0
+	cfg.InitialBackoff = 10 * time.Millisecond
+	cfg.MaxBackoff = 20 * time.Millisecond
+
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	reconnected := make(chan struct{})
+	var once sync.Once
+	client.OnReconnect(func(ctx context.Context) error {
+		once.Do(func() { close(reconnected) })
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	select {
+	case <-reconnected:
+	case <-time.After(3 * time.Second):
+		t.Fatal("client did not reconnect")
+	}
+
+	stats := client.ConnectionStats()
+	if stats.Reconnects < 1 {
+		t.Errorf("Reconnects = %d, want at least 1", stats.Reconnects)
+	}
+	if stats.ConnectedSince.IsZero() {
+		t.Error("ConnectedSince is zero after reconnecting")
+	}
+	if stats.Backoff != 0 {
+		t.Errorf("Backoff = %s after reconnecting, want 0", stats.Backoff)
+	}
+	if stats.UptimePct <= 0 || stats.UptimePct > 100 {
+		t.Errorf("UptimePct = %v, want within (0, 100]", stats.UptimePct)
+	}
+}
+
+func TestClient_LastPingRTT(t *testing.T) {
+	// The server must be reading for pongs to be written
+	server := mockWSServer(t, echoHandler)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	cfg := DefaultConfig(wsURL, "test")
+	cfg.PingInterval = 20 * time.Millisecond
+
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if rtt := client.LastPingRTT(); rtt != 0 {
+		t.Errorf("LastPingRTT before any ping = %s, want 0", rtt)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for client.LastPingRTT() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no ping round trip measured")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if rtt := client.LastPingRTT(); rtt >= 10*time.Second {
+		t.Errorf("LastPingRTT = %s, want under the ping timeout", rtt)
+	}
+}
+
+// TestClient_ReconnectGoroutinesBounded tests that repeated connection
+// losses, each noticed by both the read and the ping loop, are retried by a
+// single supervisor instead of a growing set of reconnect goroutines.
+func TestClient_ReconnectGoroutinesBounded(t *testing.T) {
+	var connections atomic.Int32
+	server := mockWSServer(t, func(conn *websocket.Conn) {
+		// Never answer pings, then drop the connection
+		connections.Add(1)
+		time.Sleep(5 * time.Millisecond)
+	})
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	cfg := DefaultConfig(wsURL, "test")
+	cfg.PingInterval = time.Millisecond
+	cfg.InitialBackoff = time.Millisecond
+	cfg.MaxBackoff = 2 * time.Millisecond
+
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	baseline := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	peak := 0
+	for connections.Load() < 30 {
+		peak = max(peak, runtime.NumGoroutine())
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected 30 connections, got %d", connections.Load())
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	// One connection's loops, the supervisor and HTTP internals at most
+	if peak > baseline+20 {
+		t.Errorf("goroutines grew from %d to %d over %d reconnects", baseline, peak, connections.Load())
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+// TestClient_CompressionRatio tests that negotiated compression shows up
+// as fewer wire bytes than payload bytes, and that disabling it does not.
+func TestClient_CompressionRatio(t *testing.T) {
+	payload := []byte(`{"bids":[` + strings.Repeat(`["3000.00","1.000"],`, 200) + `["3000.00","1.000"]]}`)
+	const messages = 20
+
+	tests := []struct {
+		name           string
+		compression    CompressionMode
+		wantCompressed bool
+	}{
+		{name: "default", compression: "", wantCompressed: true},
+		{name: "no_context_takeover", compression: CompressionNoContextTakeover, wantCompressed: true},
+		{name: "disabled", compression: CompressionDisabled, wantCompressed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{CompressionMode: websocket.CompressionContextTakeover})
+				if err != nil {
+					return
+				}
+				defer conn.Close(websocket.StatusNormalClosure, "")
+				for range messages {
+					if err := conn.Write(r.Context(), websocket.MessageText, payload); err != nil {
+						return
+					}
+				}
+				time.Sleep(200 * time.Millisecond)
+			}))
+			defer server.Close()
+
+			cfg := DefaultConfig("ws"+strings.TrimPrefix(server.URL, "http"), "test")
+			cfg.PingInterval = 0
+			cfg.Compression = tt.compression
+
+			client, err := New(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := client.Connect(ctx); err != nil {
+				t.Fatalf("Connect failed: %v", err)
+			}
+			for range messages {
+				select {
+				case <-client.Messages():
+				case <-ctx.Done():
+					t.Fatal("timed out waiting for messages")
+				}
+			}
+
+			ratio := client.CompressionRatio()
+			if compressed := ratio < 0.5; compressed != tt.wantCompressed {
+				t.Errorf("CompressionRatio() = %.3f, want compressed=%v", ratio, tt.wantCompressed)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidCompression(t *testing.T) {
+	cfg := DefaultConfig("ws://localhost", "test")
+	cfg.Compression = "brotli"
+	if _, err := New(cfg); err == nil {
+		t.Error("expected an error for an unsupported compression mode")
+	}
+}
@@ -0,0 +1,22 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultDisplayPrecision is how many decimals USD profit and cost amounts
+// are shown with unless configured otherwise.
+const DefaultDisplayPrecision int32 = 2
+
+// formatUSD renders a USD amount rounded to places decimals. Amounts arrive
+// at full precision; this is the only place they are rounded.
+func formatUSD(amount decimal.Decimal, places int32) string {
+	return "$" + amount.StringFixed(places)
+}
+
+// formatUSDFloat is formatUSD for amounts already converted to float64.
+func formatUSDFloat(amount float64, places int32) string {
+	return fmt.Sprintf("$%.*f", int(places), amount)
+}
@@ -3,6 +3,9 @@ package components
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/shopspring/decimal"
@@ -16,26 +19,120 @@ type ExecutionStepRow struct {
 
 // RiskFactorRow represents a risk factor for display.
 type RiskFactorRow struct {
-	Name     string
-	Severity string
+	Name        string
+	Severity    string
+	Description string
+}
+
+// LegRow represents a single leg of a multi-leg opportunity.
+type LegRow struct {
+	Side  string // "BUY" or "SELL"
+	Pair  string
+	Price decimal.Decimal
+	Venue string
 }
 
 // OpportunityRow represents an opportunity in the list.
 type OpportunityRow struct {
-	Timestamp       string
-	BlockNumber     uint64
-	Pair            string
-	TradeSize       string
-	Direction       string
-	SpreadBps       decimal.Decimal
-	Profit          decimal.Decimal
-	PoolFeeTier     string
-	RequiredCapital decimal.Decimal
-	CEXPrice        decimal.Decimal
-	ExecutionSteps  []ExecutionStepRow
-	RiskFactors     []RiskFactorRow
-	Status          string
-	Profitable      bool
+	Timestamp        string
+	DetectedAt       time.Time // Ages the row against the TTL (zero = never expires)
+	BlockNumber      uint64
+	Pair             string
+	TradeSize        string
+	Direction        string
+	Venue            string
+	SpreadBps        decimal.Decimal
+	Profit           decimal.Decimal
+	PoolFeeTier      string
+	RequiredCapital  decimal.Decimal
+	AvailableCapital decimal.Decimal // Zero = unlimited
+	OverCapital      bool            // Required capital exceeds the limit
+	OptimalSize      string
+	CEXPrice         decimal.Decimal
+	DEXPrice         decimal.Decimal
+	GrossProfit      decimal.Decimal
+	GasCostUSD       decimal.Decimal
+	FeesUSD          decimal.Decimal
+	TotalCostsUSD    decimal.Decimal
+	ExecutionSteps   []ExecutionStepRow
+	RiskFactors      []RiskFactorRow
+	Legs             []LegRow // Set for triangular opportunities
+	Status           string
+	Profitable       bool
+}
+
+// Breakdown returns the row's full details as plain text, for sharing, with
+// profit and cost amounts shown to places decimals.
+func (r OpportunityRow) Breakdown(places int32) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Opportunity: %s (%s)\n", r.Pair, r.Status)
+	fmt.Fprintf(&b, "Block:       #%d at %s\n", r.BlockNumber, r.Timestamp)
+	fmt.Fprintf(&b, "Direction:   %s via %s\n", r.Direction, r.Venue)
+	fmt.Fprintf(&b, "Size:        %s\n", r.TradeSize)
+	if len(r.Legs) > 0 {
+		fmt.Fprintf(&b, "Return:      %s bps\n", r.SpreadBps.StringFixed(2))
+		b.WriteString("\nLegs\n")
+		for i, leg := range r.Legs {
+			fmt.Fprintf(&b, "  %d. %s %s @ %s (%s)\n", i+1, leg.Side, leg.Pair, leg.Price.Round(6).String(), leg.Venue)
+		}
+	} else {
+		fmt.Fprintf(&b, "CEX price:   $%s\n", r.CEXPrice.StringFixed(2))
+		fmt.Fprintf(&b, "DEX price:   $%s (pool fee %s)\n", r.DEXPrice.StringFixed(2), r.PoolFeeTier)
+		fmt.Fprintf(&b, "Spread:      %s bps\n", r.SpreadBps.StringFixed(2))
+	}
+
+	b.WriteString("\nCosts\n")
+	fmt.Fprintf(&b, "  Gross profit:  %s\n", formatUSD(r.GrossProfit, places))
+	fmt.Fprintf(&b, "  Gas:           %s\n", formatUSD(r.GasCostUSD, places))
+	fmt.Fprintf(&b, "  Exchange fees: %s\n", formatUSD(r.FeesUSD, places))
+	fmt.Fprintf(&b, "  Total costs:   %s\n", formatUSD(r.TotalCostsUSD, places))
+	fmt.Fprintf(&b, "  Net profit:    %s\n", formatUSD(r.Profit, places))
+	fmt.Fprintf(&b, "  Capital:       $%s", r.RequiredCapital.StringFixed(2))
+	if r.AvailableCapital.IsPositive() {
+		fmt.Fprintf(&b, " of $%s", r.AvailableCapital.StringFixed(2))
+		if r.OverCapital {
+			b.WriteString(" (exceeds limit)")
+		}
+	}
+	b.WriteString("\n")
+	if r.OptimalSize != "" {
+		fmt.Fprintf(&b, "  Optimal size:  %s\n", r.OptimalSize)
+	}
+
+	if len(r.ExecutionSteps) > 0 {
+		b.WriteString("\nExecution steps\n")
+		for _, step := range r.ExecutionSteps {
+			fmt.Fprintf(&b, "  %d. %s\n", step.Number, step.Description)
+		}
+	}
+	if len(r.RiskFactors) > 0 {
+		b.WriteString("\nRisk factors\n")
+		for _, risk := range r.RiskFactors {
+			fmt.Fprintf(&b, "  - %s (%s): %s\n", risk.Name, risk.Severity, risk.Description)
+		}
+	}
+	return b.String()
+}
+
+// SortMode is the order opportunities are listed in.
+type SortMode int
+
+const (
+	SortRecent    SortMode = iota // Newest first (insertion order)
+	SortNetProfit                 // Highest net profit first
+	SortSpread                    // Widest spread first
+)
+
+// String returns the sort mode label shown in the UI.
+func (s SortMode) String() string {
+	switch s {
+	case SortNetProfit:
+		return "net profit"
+	case SortSpread:
+		return "spread"
+	default:
+		return "recent"
+	}
 }
 
 // OpportunitiesComponent renders the opportunities list.
@@ -43,8 +140,18 @@ type OpportunitiesComponent struct {
 	rows       []OpportunityRow
 	maxRows    int
 	offset     int // For scrolling
+	selected   int  // Index of the selected row in visibleRows
+	expanded   bool // Show the selected row's steps and risks in full
 	visibleMax int // How many to show at once
 	maxHeight  int // Max lines to render
+
+	profitableOnly bool     // Render only profitable rows
+	sortMode       SortMode // Render order; rows stay in insertion order
+
+	ttl           time.Duration // Rows older than this are dimmed (0 = never)
+	removeExpired bool          // Drop expired rows instead of dimming them
+
+	precision int32 // Decimals net profit is shown with
 }
 
 // NewOpportunitiesComponent creates a new opportunities component.
@@ -55,7 +162,41 @@ func NewOpportunitiesComponent(maxRows int) *OpportunitiesComponent {
 		offset:     0,
 		visibleMax: 3, // Show max 3 opportunities at once
 		maxHeight:  25, // Max lines to render
+		precision:  DefaultDisplayPrecision,
+	}
+}
+
+// SetDisplayPrecision sets how many decimals net profit is shown with.
+func (o *OpportunitiesComponent) SetDisplayPrecision(places int32) {
+	o.precision = places
+}
+
+// DisplayPrecision returns how many decimals net profit is shown with.
+func (o *OpportunitiesComponent) DisplayPrecision() int32 {
+	return o.precision
+}
+
+// SetTTL sets the age past which rows are dimmed as stale, or removed when
+// removeExpired is set (0 = rows never expire).
+func (o *OpportunitiesComponent) SetTTL(ttl time.Duration, removeExpired bool) {
+	o.ttl = ttl
+	o.removeExpired = removeExpired
+}
+
+// expired reports whether row is older than the TTL at now.
+func (o *OpportunitiesComponent) expired(row OpportunityRow, now time.Time) bool {
+	return o.ttl > 0 && !row.DetectedAt.IsZero() && now.Sub(row.DetectedAt) > o.ttl
+}
+
+// RemoveExpired drops rows older than the TTL when removal is enabled.
+func (o *OpportunitiesComponent) RemoveExpired(now time.Time) {
+	if !o.removeExpired || o.ttl <= 0 {
+		return
 	}
+	o.rows = slices.DeleteFunc(o.rows, func(row OpportunityRow) bool {
+		return o.expired(row, now)
+	})
+	o.clampSelection()
 }
 
 // Add adds a new opportunity to the list.
@@ -64,31 +205,125 @@ func (o *OpportunitiesComponent) Add(row OpportunityRow) {
 	if len(o.rows) > o.maxRows {
 		o.rows = o.rows[:o.maxRows]
 	}
-	// Reset scroll to top on new opportunity
+	// Follow the newest row unless one further down is selected; in recent
+	// order that row moves down one place with the new one above it
+	if o.selected == 0 {
+		o.offset = 0
+		o.expanded = false
+	} else if o.sortMode == SortRecent && (!o.profitableOnly || row.Profitable) {
+		o.selected++
+		o.offset++
+	}
+	o.clampSelection()
+}
+
+// ToggleProfitableFilter switches between showing all rows and only
+// profitable ones. Rows are kept either way; the filter applies on render.
+func (o *OpportunitiesComponent) ToggleProfitableFilter() {
+	o.profitableOnly = !o.profitableOnly
+	o.offset = 0
+	o.selected = 0
+	o.expanded = false
+}
+
+// ProfitableOnly reports whether only profitable rows are shown.
+func (o *OpportunitiesComponent) ProfitableOnly() bool {
+	return o.profitableOnly
+}
+
+// CycleSort advances to the next sort mode: recent, net profit, spread.
+func (o *OpportunitiesComponent) CycleSort() {
+	o.sortMode = (o.sortMode + 1) % (SortSpread + 1)
 	o.offset = 0
+	o.selected = 0
+	o.expanded = false
+}
+
+// SortMode returns the active sort mode.
+func (o *OpportunitiesComponent) SortMode() SortMode {
+	return o.sortMode
+}
+
+// visibleRows returns the rows that pass the current filter, in the active
+// sort order. The stored rows are never reordered.
+func (o *OpportunitiesComponent) visibleRows() []OpportunityRow {
+	if !o.profitableOnly && o.sortMode == SortRecent {
+		return o.rows
+	}
+
+	rows := make([]OpportunityRow, 0, len(o.rows))
+	for _, row := range o.rows {
+		if !o.profitableOnly || row.Profitable {
+			rows = append(rows, row)
+		}
+	}
+
+	// Stable so ties stay newest first
+	switch o.sortMode {
+	case SortNetProfit:
+		slices.SortStableFunc(rows, func(a, b OpportunityRow) int {
+			return b.Profit.Cmp(a.Profit)
+		})
+	case SortSpread:
+		slices.SortStableFunc(rows, func(a, b OpportunityRow) int {
+			return b.SpreadBps.Cmp(a.SpreadBps)
+		})
+	}
+	return rows
 }
 
 // Clear clears all opportunities.
 func (o *OpportunitiesComponent) Clear() {
 	o.rows = make([]OpportunityRow, 0)
 	o.offset = 0
+	o.selected = 0
+	o.expanded = false
 }
 
-// ScrollUp scrolls the list up.
-func (o *OpportunitiesComponent) ScrollUp() {
-	if o.offset > 0 {
-		o.offset--
+// SelectUp moves the selection up, scrolling to keep it in view. The
+// previously selected row collapses.
+func (o *OpportunitiesComponent) SelectUp() {
+	if o.selected > 0 {
+		o.selected--
+		o.expanded = false
 	}
+	o.clampSelection()
 }
 
-// ScrollDown scrolls the list down.
-func (o *OpportunitiesComponent) ScrollDown() {
-	maxOffset := len(o.rows) - o.visibleMax
-	if maxOffset < 0 {
-		maxOffset = 0
+// SelectDown moves the selection down, scrolling to keep it in view. The
+// previously selected row collapses.
+func (o *OpportunitiesComponent) SelectDown() {
+	if o.selected < len(o.visibleRows())-1 {
+		o.selected++
+		o.expanded = false
 	}
-	if o.offset < maxOffset {
-		o.offset++
+	o.clampSelection()
+}
+
+// ToggleExpanded expands the selected row to its full execution steps and
+// risk factors, or collapses it back to the summary.
+func (o *OpportunitiesComponent) ToggleExpanded() {
+	o.expanded = !o.expanded
+}
+
+// Selected returns the selected row, or false when the list is empty.
+func (o *OpportunitiesComponent) Selected() (OpportunityRow, bool) {
+	rows := o.visibleRows()
+	if o.selected >= len(rows) {
+		return OpportunityRow{}, false
+	}
+	return rows[o.selected], true
+}
+
+// clampSelection keeps the selection on a visible row and scrolls so it
+// stays within the rendered window.
+func (o *OpportunitiesComponent) clampSelection() {
+	o.selected = max(min(o.selected, len(o.visibleRows())-1), 0)
+	if o.selected < o.offset {
+		o.offset = o.selected
+	}
+	if o.selected >= o.offset+o.visibleMax {
+		o.offset = o.selected - o.visibleMax + 1
 	}
 }
 
@@ -104,18 +339,34 @@ func (o *OpportunitiesComponent) View() string {
 	profitStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Bold(true)
 	scrollHint := lipgloss.NewStyle().Foreground(lipgloss.Color("#60A5FA"))
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	staleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#4B5563")).Faint(true)
+	plainStyle := lipgloss.NewStyle()
+
+	rows := o.visibleRows()
+	now := time.Now()
 
 	var result string
 	result = headerStyle.Render("OPPORTUNITIES")
 
 	// Show count and scroll position
-	if len(o.rows) > 0 {
-		countStr := fmt.Sprintf(" (%d total, ↑↓ scroll)", len(o.rows))
+	if o.profitableOnly {
+		result += mutedStyle.Render(fmt.Sprintf(" (%d of %d profitable, ↑↓ select, enter details)", len(rows), len(o.rows)))
+	} else if len(o.rows) > 0 {
+		countStr := fmt.Sprintf(" (%d total, ↑↓ select, enter details)", len(o.rows))
 		result += mutedStyle.Render(countStr)
 	}
+	if o.sortMode != SortRecent {
+		result += scrollHint.Render(" ↓ " + o.sortMode.String())
+	}
 	result += "\n\n"
 
-	if len(o.rows) == 0 {
+	if len(rows) == 0 {
+		if o.profitableOnly && len(o.rows) > 0 {
+			result += mutedStyle.Render("  No profitable opportunities yet.\n")
+			result += mutedStyle.Render("  Press f to show all.\n")
+			return result
+		}
 		result += mutedStyle.Render("  No opportunities detected yet.\n")
 		result += mutedStyle.Render("  Monitoring spreads...\n")
 		return result
@@ -128,12 +379,12 @@ func (o *OpportunitiesComponent) View() string {
 
 	// Render visible rows (compact format: 4 lines per opportunity)
 	end := o.offset + o.visibleMax
-	if end > len(o.rows) {
-		end = len(o.rows)
+	if end > len(rows) {
+		end = len(rows)
 	}
 
 	for i := o.offset; i < end; i++ {
-		row := o.rows[i]
+		row := rows[i]
 		icon := "●"
 		style := profitStyle
 		if !row.Profitable {
@@ -141,25 +392,77 @@ func (o *OpportunitiesComponent) View() string {
 			style = mutedStyle
 		}
 
-		// Line 1: icon [time] Pair | Direction | Size
-		result += fmt.Sprintf("  %s [%s] %s | %s | %s\n",
+		// Rows past the TTL are rendered unstyled, then grayed out as a whole
+		stale := o.expired(row, now)
+		rowDim, rowWarn := dimStyle, warnStyle
+		if stale {
+			style, rowDim, rowWarn = plainStyle, plainStyle, plainStyle
+		}
+		var entry string
+
+		// Line 1: cursor icon [time] Pair | Direction | Venue | Size
+		cursor := "  "
+		if i == o.selected {
+			cursor = "▸ "
+		}
+		entry += fmt.Sprintf("%s%s [%s] %s | %s | %s | %s\n",
+			cursor,
 			style.Render(icon),
 			row.Timestamp,
 			row.Pair,
 			row.Direction,
+			row.Venue,
 			row.TradeSize,
 		)
 
-		// Line 2: Spread | Net | Pool | Capital
-		result += fmt.Sprintf("    Spread: %.1f bps | Net: %s | Pool: %s\n",
-			row.SpreadBps.InexactFloat64(),
-			style.Render(fmt.Sprintf("$%.0f", row.Profit.InexactFloat64())),
-			row.PoolFeeTier,
-		)
+		// Line 2: Spread | Net | Pool (legs for multi-leg opportunities)
+		if len(row.Legs) > 0 {
+			entry += fmt.Sprintf("    Return: %.1f bps | Net: %s\n",
+				row.SpreadBps.InexactFloat64(),
+				style.Render(formatUSD(row.Profit, o.precision)),
+			)
+			for j, leg := range row.Legs {
+				entry += rowDim.Render(fmt.Sprintf("    %d. %s %s @ %s (%s)\n",
+					j+1, leg.Side, leg.Pair, leg.Price.Round(6).String(), leg.Venue))
+			}
+		} else {
+			entry += fmt.Sprintf("    Spread: %.1f bps | Net: %s | Pool: %s\n",
+				row.SpreadBps.InexactFloat64(),
+				style.Render(formatUSD(row.Profit, o.precision)),
+				row.PoolFeeTier,
+			)
+		}
+
+		// Capital: required vs available, flagged when over the limit
+		if row.AvailableCapital.IsPositive() {
+			capital := fmt.Sprintf("    Capital: $%.0f / $%.0f", row.RequiredCapital.InexactFloat64(), row.AvailableCapital.InexactFloat64())
+			if row.OptimalSize != "" {
+				capital += " | Optimal: " + row.OptimalSize
+			}
+			if row.OverCapital {
+				entry += rowWarn.Render(capital+" ⚠ over limit") + "\n"
+			} else {
+				entry += rowDim.Render(capital) + "\n"
+			}
+		}
 
-		// Line 3: Risks (compact)
-		if len(row.RiskFactors) > 0 {
-			result += dimStyle.Render("    Risks: ")
+		// Expanded: every execution step and risk factor in full
+		if i == o.selected && o.expanded {
+			if len(row.ExecutionSteps) > 0 {
+				entry += rowDim.Render("    Steps:") + "\n"
+			}
+			for _, step := range row.ExecutionSteps {
+				entry += fmt.Sprintf("      %d. %s\n", step.Number, step.Description)
+			}
+			if len(row.RiskFactors) > 0 {
+				entry += rowDim.Render("    Risks:") + "\n"
+			}
+			for _, risk := range row.RiskFactors {
+				entry += fmt.Sprintf("      - %s (%s): %s\n", risk.Name, risk.Severity, risk.Description)
+			}
+		} else if len(row.RiskFactors) > 0 {
+			// Line 3: Risks (compact)
+			entry += rowDim.Render("    Risks: ")
 			for j, risk := range row.RiskFactors {
 				sev := risk.Severity
 				if sev == "medium" {
@@ -170,13 +473,18 @@ func (o *OpportunitiesComponent) View() string {
 					name = name[:8]
 				}
 				if j > 0 {
-					result += " "
+					entry += " "
 				}
-				result += dimStyle.Render(fmt.Sprintf("%s(%s)", name, sev))
+				entry += rowDim.Render(fmt.Sprintf("%s(%s)", name, sev))
 			}
-			result += "\n"
+			entry += "\n"
 		}
 
+		if stale {
+			entry = staleLines(entry, staleStyle)
+		}
+		result += entry
+
 		// Separator between opportunities
 		if i < end-1 {
 			result += dimStyle.Render("    ─────────────────────────────────\n")
@@ -184,9 +492,21 @@ func (o *OpportunitiesComponent) View() string {
 	}
 
 	// Scroll indicator (bottom)
-	if end < len(o.rows) {
-		result += scrollHint.Render(fmt.Sprintf("\n  ▼ %d more below\n", len(o.rows)-end))
+	if end < len(rows) {
+		result += scrollHint.Render(fmt.Sprintf("\n  ▼ %d more below\n", len(rows)-end))
 	}
 
 	return result
 }
+
+// staleLines grays out each line of s, keeping the line breaks outside the
+// styling so rows stay aligned.
+func staleLines(s string, style lipgloss.Style) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = style.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -15,6 +15,10 @@ type PriceRow struct {
 	CEXPrice  decimal.Decimal
 	DEXPrice  decimal.Decimal
 	SpreadBps decimal.Decimal
+
+	// NetSpreadBps is the spread left after fees and gas (nil until the
+	// detector has costed this size)
+	NetSpreadBps *decimal.Decimal
 }
 
 // CostBreakdown holds domain-calculated cost data for display.
@@ -27,6 +31,12 @@ type CostBreakdown struct {
 	TotalCosts    float64
 	NetProfit     float64
 	IsProfitable  bool
+	NetSpreadBps  float64
+
+	// Fixed round-trip overheads, shown only when configured
+	ApprovalGasUSD   float64
+	WithdrawalFeeUSD float64
+	DepositFeeUSD    float64
 }
 
 // PricesComponent renders the price comparison table.
@@ -35,16 +45,30 @@ type PricesComponent struct {
 	pair          string
 	gasGwei       float64
 	costBreakdown *CostBreakdown // Pre-calculated by domain
+	precision     int32          // Decimals profit and costs are shown with
+	cexName       string         // CEX column label
 }
 
 // NewPricesComponent creates a new prices component.
 func NewPricesComponent() *PricesComponent {
 	return &PricesComponent{
-		rows: make([]PriceRow, 0),
-		pair: "ETH-USDC",
+		rows:      make([]PriceRow, 0),
+		pair:      "ETH-USDC",
+		precision: DefaultDisplayPrecision,
+		cexName:   "Binance",
 	}
 }
 
+// SetDisplayPrecision sets how many decimals profit and costs are shown with.
+func (p *PricesComponent) SetDisplayPrecision(places int32) {
+	p.precision = places
+}
+
+// SetCEXName labels the CEX price column with the venue name.
+func (p *PricesComponent) SetCEXName(name string) {
+	p.cexName = name
+}
+
 // Update updates the price data.
 func (p *PricesComponent) Update(rows []PriceRow) {
 	p.rows = rows
@@ -83,9 +107,9 @@ func (p *PricesComponent) View() string {
 	result += "\n\n"
 
 	// Simple aligned table without box drawing
-	result += fmt.Sprintf("  %-10s  %14s  %14s  %12s\n",
-		"Size", "Binance (CEX)", "Uniswap (DEX)", "Spread")
-	result += dimStyle.Render("  " + strings.Repeat("─", 56)) + "\n"
+	result += fmt.Sprintf("  %-10s  %14s  %14s  %12s  %12s\n",
+		"Size", p.cexName+" (CEX)", "Uniswap (DEX)", "Spread", "Net")
+	result += dimStyle.Render("  " + strings.Repeat("─", 70)) + "\n"
 
 	for _, row := range p.rows {
 		spreadStyle := positiveStyle
@@ -97,18 +121,29 @@ func (p *PricesComponent) View() string {
 		spreadVal := row.SpreadBps.InexactFloat64()
 		spreadStr := fmt.Sprintf("%+.1f bps", spreadVal)
 
-		result += fmt.Sprintf("  %-10s  %14s  %14s  %s\n",
+		// Net spread after costs: green only when actionable
+		netStr := dimStyle.Render(fmt.Sprintf("%12s", "-"))
+		if row.NetSpreadBps != nil {
+			netStyle := dimStyle
+			if row.NetSpreadBps.IsPositive() {
+				netStyle = positiveStyle
+			}
+			netStr = netStyle.Render(fmt.Sprintf("%12s", fmt.Sprintf("%+.1f bps", row.NetSpreadBps.InexactFloat64())))
+		}
+
+		result += fmt.Sprintf("  %-10s  %14s  %14s  %s  %s\n",
 			row.TradeSize.StringFixed(0)+" ETH",
 			"$"+row.CEXPrice.StringFixed(2),
 			"$"+row.DEXPrice.StringFixed(2),
 			spreadStyle.Render(fmt.Sprintf("%12s", spreadStr)),
+			netStr,
 		)
 	}
 
 	// Cost breakdown section - DISPLAY ONLY, no calculations
 	// All values come pre-calculated from the domain
 	result += "\n"
-	result += dimStyle.Render("  " + strings.Repeat("─", 56)) + "\n"
+	result += dimStyle.Render("  " + strings.Repeat("─", 70)) + "\n"
 
 	if p.costBreakdown != nil {
 		cb := p.costBreakdown
@@ -122,14 +157,29 @@ func (p *PricesComponent) View() string {
 
 		result += fmt.Sprintf("  Best trade: %s\n", dimStyle.Render(cb.TradeSize))
 		result += fmt.Sprintf("  Trade value: %s\n", dimStyle.Render(fmt.Sprintf("$%.0f", cb.TradeValueUSD)))
-		result += fmt.Sprintf("  Gross profit: %s\n", warnStyle.Render(fmt.Sprintf("$%.2f", cb.GrossProfit)))
-		result += fmt.Sprintf("  Gas cost: %s\n", negativeStyle.Render(fmt.Sprintf("-$%.2f", cb.GasCostUSD)))
-		result += fmt.Sprintf("  Fees (0.4%%): %s\n", negativeStyle.Render(fmt.Sprintf("-$%.2f", cb.ExchangeFees)))
+		result += fmt.Sprintf("  Gross profit: %s\n", warnStyle.Render(formatUSDFloat(cb.GrossProfit, p.precision)))
+		result += fmt.Sprintf("  Gas cost: %s\n", negativeStyle.Render("-"+formatUSDFloat(cb.GasCostUSD, p.precision)))
+		result += fmt.Sprintf("  Fees (0.4%%): %s\n", negativeStyle.Render("-"+formatUSDFloat(cb.ExchangeFees, p.precision)))
+		if cb.ApprovalGasUSD > 0 {
+			result += fmt.Sprintf("  Approval gas: %s\n", negativeStyle.Render("-"+formatUSDFloat(cb.ApprovalGasUSD, p.precision)))
+		}
+		if cb.WithdrawalFeeUSD > 0 {
+			result += fmt.Sprintf("  CEX withdrawal: %s\n", negativeStyle.Render("-"+formatUSDFloat(cb.WithdrawalFeeUSD, p.precision)))
+		}
+		if cb.DepositFeeUSD > 0 {
+			result += fmt.Sprintf("  CEX deposit: %s\n", negativeStyle.Render("-"+formatUSDFloat(cb.DepositFeeUSD, p.precision)))
+		}
+
+		netSpreadStyle := negativeStyle
+		if cb.NetSpreadBps > 0 {
+			netSpreadStyle = positiveStyle
+		}
+		result += fmt.Sprintf("  Net spread: %s\n", netSpreadStyle.Render(fmt.Sprintf("%+.1f bps", cb.NetSpreadBps)))
 
 		if cb.IsProfitable {
-			result += fmt.Sprintf("  Net profit: %s\n", positiveStyle.Render(fmt.Sprintf("+$%.2f", cb.NetProfit)))
+			result += fmt.Sprintf("  Net profit: %s\n", positiveStyle.Render("+"+formatUSDFloat(cb.NetProfit, p.precision)))
 		} else {
-			result += fmt.Sprintf("  Net profit: %s\n", negativeStyle.Render(fmt.Sprintf("-$%.2f", cb.TotalCosts-cb.GrossProfit)))
+			result += fmt.Sprintf("  Net profit: %s\n", negativeStyle.Render("-"+formatUSDFloat(cb.TotalCosts-cb.GrossProfit, p.precision)))
 			result += "\n"
 			result += dimStyle.Render("  Need ~50+ bps spread for profit") + "\n"
 		}
@@ -0,0 +1,123 @@
+// Package components provides reusable TUI components.
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkBars are the eighth-height block characters, lowest to highest.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// spreadSeries is a bounded ring buffer of spreads in bps.
+type spreadSeries struct {
+	values []float64
+	next   int
+	full   bool
+}
+
+// push records a value, overwriting the oldest once full.
+func (s *spreadSeries) push(v float64) {
+	s.values[s.next] = v
+	s.next = (s.next + 1) % len(s.values)
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+// ordered returns the recorded values, oldest first.
+func (s *spreadSeries) ordered() []float64 {
+	if !s.full {
+		return s.values[:s.next]
+	}
+	return append(append([]float64{}, s.values[s.next:]...), s.values[:s.next]...)
+}
+
+// SparklineComponent renders the recent spread history of the selected pair.
+type SparklineComponent struct {
+	series   map[string]*spreadSeries // Pair -> recent spreads
+	capacity int
+	pair     string
+	width    int
+}
+
+// NewSparklineComponent creates a sparkline keeping the last capacity spreads per pair.
+func NewSparklineComponent(capacity int) *SparklineComponent {
+	return &SparklineComponent{
+		series:   make(map[string]*spreadSeries),
+		capacity: capacity,
+		width:    40,
+	}
+}
+
+// Push records a spread in bps for a pair.
+func (s *SparklineComponent) Push(pair string, spreadBps float64) {
+	series, ok := s.series[pair]
+	if !ok {
+		series = &spreadSeries{values: make([]float64, s.capacity)}
+		s.series[pair] = series
+	}
+	series.push(spreadBps)
+}
+
+// SetPair selects the pair to render.
+func (s *SparklineComponent) SetPair(pair string) {
+	s.pair = pair
+}
+
+// SetWidth sets the available width in columns.
+func (s *SparklineComponent) SetWidth(width int) {
+	s.width = width
+}
+
+// View renders the sparkline component.
+func (s *SparklineComponent) View() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	positiveStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	negativeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+
+	result := headerStyle.Render(fmt.Sprintf("SPREAD HISTORY (%s)", s.pair)) + "\n\n"
+
+	var values []float64
+	if series, ok := s.series[s.pair]; ok {
+		values = series.ordered()
+	}
+	if len(values) < 2 {
+		return result + mutedStyle.Render("  Collecting spreads...") + "\n"
+	}
+
+	// Only the most recent values fit in the available width
+	if cols := s.width - 2; cols > 0 && len(values) > cols {
+		values = values[len(values)-cols:]
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	var line strings.Builder
+	for _, v := range values {
+		idx := len(sparkBars) / 2
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(sparkBars)-1))
+		}
+		line.WriteRune(sparkBars[idx])
+	}
+
+	last := values[len(values)-1]
+	lastStyle := positiveStyle
+	if last < 0 {
+		lastStyle = negativeStyle
+	}
+
+	result += "  " + line.String() + "\n"
+	result += mutedStyle.Render(fmt.Sprintf("  min %+.1f  max %+.1f bps  now ", lo, hi)) +
+		lastStyle.Render(fmt.Sprintf("%+.1f bps", last)) + "\n"
+
+	return result
+}
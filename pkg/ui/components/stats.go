@@ -3,28 +3,37 @@ package components
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/shopspring/decimal"
 )
 
-// Stats holds statistics for display.
+// Stats holds session statistics for display.
 type Stats struct {
-	BlocksProcessed   int64
-	Opportunities     int64
-	Profitable        int64
-	AvgLatencyMs      float64
-	CacheHitRate      float64
-	Errors            int64
+	BlocksProcessed int64
+	Opportunities   int64
+	Profitable      int64
+	BestNetProfit   decimal.Decimal // Highest net profit seen this session
+	AvgSpreadBps    decimal.Decimal // Mean spread across all opportunities
+	Uptime          time.Duration
+	Errors          int64
 }
 
 // StatsComponent renders statistics.
 type StatsComponent struct {
-	stats Stats
+	stats     Stats
+	precision int32 // Decimals the best net profit is shown with
 }
 
 // NewStatsComponent creates a new stats component.
 func NewStatsComponent() *StatsComponent {
-	return &StatsComponent{}
+	return &StatsComponent{precision: DefaultDisplayPrecision}
+}
+
+// SetDisplayPrecision sets how many decimals the best net profit is shown with.
+func (s *StatsComponent) SetDisplayPrecision(places int32) {
+	s.precision = places
 }
 
 // Update updates the statistics.
@@ -37,6 +46,7 @@ func (s *StatsComponent) View() string {
 	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
 	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
 	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Bold(true)
+	profitStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Bold(true)
 
 	profitableRate := float64(0)
 	if s.stats.Opportunities > 0 {
@@ -48,16 +58,26 @@ func (s *StatsComponent) View() string {
 		errorsDisplay = errorStyle.Render(fmt.Sprintf("%d", s.stats.Errors))
 	}
 
-	return style.Render("STATS") + "\n" +
-		fmt.Sprintf("Blocks processed: %s  │  Opportunities: %s  │  Profitable: %s (%.1f%%)\n",
+	bestDisplay := valueStyle.Render("-")
+	if s.stats.Opportunities > 0 {
+		bestStyle := valueStyle
+		if s.stats.BestNetProfit.IsPositive() {
+			bestStyle = profitStyle
+		}
+		bestDisplay = bestStyle.Render(formatUSD(s.stats.BestNetProfit, s.precision))
+	}
+
+	return style.Render("SESSION") + "\n" +
+		fmt.Sprintf("Blocks: %s  │  Opportunities: %s  │  Profitable: %s (%.1f%%)\n",
 			valueStyle.Render(fmt.Sprintf("%d", s.stats.BlocksProcessed)),
 			valueStyle.Render(fmt.Sprintf("%d", s.stats.Opportunities)),
 			valueStyle.Render(fmt.Sprintf("%d", s.stats.Profitable)),
 			profitableRate,
 		) +
-		fmt.Sprintf("Avg latency: %s       │  Cache hit rate: %s    │  Errors: %s",
-			valueStyle.Render(fmt.Sprintf("%.0fms", s.stats.AvgLatencyMs)),
-			valueStyle.Render(fmt.Sprintf("%.1f%%", s.stats.CacheHitRate)),
+		fmt.Sprintf("Best net: %s  │  Avg spread: %s  │  Uptime: %s  │  Errors: %s",
+			bestDisplay,
+			valueStyle.Render(fmt.Sprintf("%.1f bps", s.stats.AvgSpreadBps.InexactFloat64())),
+			valueStyle.Render(s.stats.Uptime.Round(time.Second).String()),
 			errorsDisplay,
 		)
 }
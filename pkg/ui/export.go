@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+
+	"github.com/fd1az/arbitrage-bot/pkg/ui/components"
+)
+
+// exportBreakdown copies an opportunity's breakdown to the clipboard and
+// saves it to a file in the working directory, returning the file's path.
+// The copy uses the OSC 52 escape sequence, which most terminals honor (also
+// over SSH) but none acknowledge, so the file is always written as well.
+func exportBreakdown(row components.OpportunityRow, places int32) (string, error) {
+	text := row.Breakdown(places)
+
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	}
+	// Stderr shares the terminal without interleaving with the TUI's renderer
+	_, _ = seq.WriteTo(os.Stderr)
+
+	path := fmt.Sprintf("opportunity-%d-%s.txt", row.BlockNumber, row.DetectedAt.Format("150405"))
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		return "", fmt.Errorf("save opportunity breakdown: %w", err)
+	}
+	return path, nil
+}
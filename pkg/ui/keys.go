@@ -8,6 +8,10 @@ type KeyMap struct {
 	Quit   key.Binding
 	Pause  key.Binding
 	Clear  key.Binding
+	Filter key.Binding
+	Sort   key.Binding
+	Copy   key.Binding
+	Expand key.Binding
 	Logs   key.Binding
 	Metrics key.Binding
 	Help   key.Binding
@@ -28,6 +32,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "clear"),
 		),
+		Filter: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "profitable only"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "cycle sort"),
+		),
+		Expand: key.NewBinding(
+			key.WithKeys("enter", " "),
+			key.WithHelp("enter", "expand details"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy breakdown"),
+		),
 		Logs: key.NewBinding(
 			key.WithKeys("l"),
 			key.WithHelp("l", "logs"),
@@ -51,7 +71,7 @@ func (k KeyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings for the expanded help view.
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Quit, k.Pause, k.Clear},
+		{k.Quit, k.Pause, k.Clear, k.Filter, k.Sort, k.Expand, k.Copy},
 		{k.Logs, k.Metrics, k.Help},
 	}
 }
@@ -4,6 +4,8 @@ package ui
 import (
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
 	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
 )
@@ -27,6 +29,16 @@ type ConnectionStatusMsg struct {
 	Latency   time.Duration
 }
 
+// ConnectionStatsMsg is sent periodically with how stable a source's
+// connection has been this session.
+type ConnectionStatsMsg struct {
+	Name           string
+	ConnectedSince time.Time     // Zero while disconnected
+	UptimePct      float64       // Share of the session spent connected (0-100)
+	Reconnects     int           // Reconnects this session
+	Backoff        time.Duration // Wait before the pending reconnect (0 if none)
+}
+
 // BlockMsg is sent when a new block is received.
 type BlockMsg struct {
 	Number    uint64
@@ -36,6 +48,16 @@ type BlockMsg struct {
 // GasPriceMsg is sent when gas price is updated.
 type GasPriceMsg struct {
 	GweiPrice float64
+
+	// PercentileRank is where the price falls among recent ones (0-100),
+	// set when Ranked
+	PercentileRank float64
+	Ranked         bool
+}
+
+// SummaryMsg is sent at the end of each profit summary interval.
+type SummaryMsg struct {
+	Summary *domain.ProfitSummary
 }
 
 // ErrorMsg is sent when an error occurs.
@@ -86,4 +108,19 @@ type CostBreakdownMsg struct {
 	TotalCosts    float64
 	NetProfit     float64
 	IsProfitable  bool
+	NetSpreadBps  float64 // Spread left after costs
+
+	// Fixed round-trip overheads (zero when not configured)
+	ApprovalGasUSD   float64
+	WithdrawalFeeUSD float64
+	DepositFeeUSD    float64
+
+	// Net spread of every analyzed size, shown beside each price row
+	SizeSpreads []SizeSpread
+}
+
+// SizeSpread is the net spread of one analyzed trade size.
+type SizeSpread struct {
+	TradeSize    decimal.Decimal
+	NetSpreadBps decimal.Decimal
 }
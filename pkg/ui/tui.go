@@ -3,12 +3,14 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/fd1az/arbitrage-bot/business/arbitrage/domain"
+	pricingDomain "github.com/fd1az/arbitrage-bot/business/pricing/domain"
 	"github.com/fd1az/arbitrage-bot/pkg/ui/components"
 	"github.com/shopspring/decimal"
 )
@@ -38,6 +40,9 @@ const (
 // WelcomeDuration is how long the welcome screen shows before auto-advancing.
 const WelcomeDuration = 2 * time.Second
 
+// sparklineCapacity is how many recent spreads the sparkline keeps per pair.
+const sparklineCapacity = 120
+
 // ErrorEntry represents an error with timestamp.
 type ErrorEntry struct {
 	Message   string
@@ -49,6 +54,8 @@ type Model struct {
 	// Components
 	prices        *components.PricesComponent
 	opportunities *components.OpportunitiesComponent
+	sparkline     *components.SparklineComponent
+	stats         *components.StatsComponent
 
 	// Phase state
 	phase        Phase
@@ -62,7 +69,10 @@ type Model struct {
 	height          int
 	currentBlock    uint64
 	gasPrice        float64
+	gasRank         float64 // Percentile of gasPrice among recent prices
+	gasRanked       bool    // gasRank is known
 	connectionState map[string]*ConnectionInfo
+	connectionStats map[string]ConnectionStatsMsg // Session uptime and reconnects by source
 	lastUpdate      time.Time
 	errorMsg        string
 	errors          []ErrorEntry // Persistent error panel (last 3)
@@ -76,29 +86,76 @@ type Model struct {
 	// Activity tracking
 	scanCount      uint64
 	pricesBySize   map[string]components.PriceRow // Trade size -> latest price
+	sparkSizes     map[string]decimal.Decimal     // Pair -> smallest trade size, charted in the sparkline
 	activityFeed   []string                       // Recent activity messages
 	lastScanTime   time.Time
 	blocksScanned  uint64
 
+	// Session statistics, kept here so they outlive the scrolled-away rows
+	sessionStart    time.Time
+	oppCount        int64
+	profitableCount int64
+	bestNetProfit   decimal.Decimal
+	spreadSumBps    decimal.Decimal
+	errorCount      int64
+	lastSummary     *domain.ProfitSummary // Latest profit summary interval (nil until the first)
+
 	// Cost breakdown (pre-calculated by domain, UI just displays)
 	costBreakdown *CostBreakdownMsg
 }
 
+// Option configures the TUI model.
+type Option func(*Model)
+
+// WithOpportunityTTL dims opportunities older than ttl, or removes them when
+// remove is set (0 = opportunities never expire).
+func WithOpportunityTTL(ttl time.Duration, remove bool) Option {
+	return func(m *Model) {
+		m.opportunities.SetTTL(ttl, remove)
+	}
+}
+
+// WithCEXName labels the CEX feed's connection status and prices column
+// with name (e.g. "Coinbase") instead of "Binance".
+func WithCEXName(name string) Option {
+	return func(m *Model) {
+		delete(m.connectionState, "Binance")
+		m.connectionState[name] = &ConnectionInfo{Connected: false}
+		delete(m.startupSteps, "binance")
+		m.startupSteps[strings.ToLower(name)] = &StartupStep{Name: "Connecting to " + name, Status: "pending"}
+		m.prices.SetCEXName(name)
+	}
+}
+
+// WithDisplayPrecision shows USD profit and cost amounts with places
+// decimals. Amounts are calculated at full precision and only rounded here.
+func WithDisplayPrecision(places int32) Option {
+	return func(m *Model) {
+		m.opportunities.SetDisplayPrecision(places)
+		m.prices.SetDisplayPrecision(places)
+		m.stats.SetDisplayPrecision(places)
+	}
+}
+
 // New creates a new TUI model.
-func New() Model {
+func New(opts ...Option) Model {
 	now := time.Now()
-	return Model{
+	m := Model{
 		prices:        components.NewPricesComponent(),
 		opportunities: components.NewOpportunitiesComponent(50), // Store more for scrolling
+		sparkline:     components.NewSparklineComponent(sparklineCapacity),
+		stats:         components.NewStatsComponent(),
 		phase:         PhaseWelcome,
 		welcomeStart:  now,
 		connectionState: map[string]*ConnectionInfo{
 			"Ethereum": {Connected: false},
 			"Binance":  {Connected: false},
 		},
+		connectionStats: make(map[string]ConnectionStatsMsg),
 		logs:         make([]string, 0, 10),
 		errors:       make([]ErrorEntry, 0, 3),
 		pricesBySize: make(map[string]components.PriceRow),
+		sparkSizes:   make(map[string]decimal.Decimal),
 		activityFeed: make([]string, 0, 8),
 		startupSteps: map[string]*StartupStep{
 			"config":   {Name: "Loading configuration", Status: "pending"},
@@ -106,8 +163,13 @@ func New() Model {
 			"binance":  {Name: "Connecting to Binance", Status: "pending"},
 			"uniswap":  {Name: "Initializing Uniswap", Status: "pending"},
 		},
-		startupTime: now,
+		startupTime:  now,
+		sessionStart: now,
+	}
+	for _, opt := range opts {
+		opt(&m)
 	}
+	return m
 }
 
 // Init initializes the TUI model.
@@ -147,14 +209,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "c":
 			m.opportunities.Clear()
 			return m, nil
+		case "f":
+			m.opportunities.ToggleProfitableFilter()
+			return m, nil
+		case "s":
+			m.opportunities.CycleSort()
+			return m, nil
 		case "p":
 			m.paused = !m.paused
+			if OnPauseToggled != nil {
+				OnPauseToggled(m.paused)
+			}
 			return m, nil
 		case "up", "k":
-			m.opportunities.ScrollUp()
+			m.opportunities.SelectUp()
 			return m, nil
 		case "down", "j":
-			m.opportunities.ScrollDown()
+			m.opportunities.SelectDown()
+			return m, nil
+		case "enter", " ":
+			m.opportunities.ToggleExpanded()
+			return m, nil
+		case "y":
+			row, ok := m.opportunities.Selected()
+			if !ok {
+				return m, nil
+			}
+			path, err := exportBreakdown(row, m.opportunities.DisplayPrecision())
+			if err != nil {
+				return m, func() tea.Msg { return ErrorMsg{Error: err} }
+			}
+			m.activityFeed = addActivity(m.activityFeed, fmt.Sprintf("Copied %s breakdown (saved to %s)", row.Pair, path))
 			return m, nil
 		case "e":
 			// Clear errors
@@ -167,6 +252,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.ready = true
+		// Match the prices panel box (minus border and padding)
+		if m.width > 100 {
+			m.sparkline.SetWidth(m.width/2 - 6)
+		} else {
+			m.sparkline.SetWidth(m.width - 8)
+		}
 
 	case TickMsg:
 		// Check if welcome timeout has elapsed
@@ -178,6 +269,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				go OnStartModules()
 			}
 		}
+		m.opportunities.RemoveExpired(time.Now())
 		return m, tickCmd()
 
 	case OpportunityMsg:
@@ -197,8 +289,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			riskFactors := make([]components.RiskFactorRow, 0, len(opp.RiskFactors))
 			for _, risk := range opp.RiskFactors {
 				riskFactors = append(riskFactors, components.RiskFactorRow{
-					Name:     risk.Name,
-					Severity: risk.Severity,
+					Name:        risk.Name,
+					Severity:    risk.Severity,
+					Description: risk.Description,
 				})
 			}
 
@@ -208,24 +301,75 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				poolFeeTier = opp.DEXQuote.FeeTierPercent()
 			}
 
+			// Build leg rows (triangular opportunities)
+			legs := make([]components.LegRow, 0, len(opp.Legs))
+			for _, leg := range opp.Legs {
+				legs = append(legs, components.LegRow{
+					Side:  strings.ToUpper(string(leg.Side)),
+					Pair:  leg.Pair.String(),
+					Price: leg.Price,
+					Venue: pricingDomain.VenueDisplayName(leg.Venue),
+				})
+			}
+
+			optimalSize := ""
+			if opp.OptimalSize.IsPositive() {
+				optimalSize = opp.OptimalSize.String() + " " + opp.Pair.Base.Symbol()
+			}
+
+			tradeSize := opp.TradeSize.String() + " " + opp.Pair.Base.Symbol()
+			if r := opp.SizeRange; r != nil {
+				tradeSize = fmt.Sprintf("%s (%s-%s)", tradeSize, r.Min, r.Max)
+			}
+
+			venue := pricingDomain.VenueDisplayName(opp.CEXVenue)
+			if opp.IsTriangular() {
+				venue = "Multi"
+			}
+
+			gasCostUSD := decimal.Zero
+			if opp.GasCost != nil {
+				gasCostUSD = opp.GasCost.ExactUSD
+			}
+
 			row := components.OpportunityRow{
-				Timestamp:       opp.Timestamp.Format("15:04:05"),
-				BlockNumber:     opp.BlockNumber,
-				Pair:            opp.Pair.String(),
-				TradeSize:       opp.TradeSize.String() + " ETH",
-				Direction:       opp.Direction.ShortString(),
-				SpreadBps:       opp.Spread.BasisPoints,
-				Profit:          opp.Profit.NetProfitRaw, // Use raw value to preserve sign
-				PoolFeeTier:     poolFeeTier,
-				RequiredCapital: opp.RequiredCapital,
-				CEXPrice:        opp.CEXPrice,
-				ExecutionSteps:  execSteps,
-				RiskFactors:     riskFactors,
-				Profitable:      opp.IsProfitable(),
-				Status:          getOpportunityStatus(opp),
+				Timestamp:        opp.Timestamp.Format("15:04:05"),
+				DetectedAt:       opp.Timestamp,
+				BlockNumber:      opp.BlockNumber,
+				Pair:             opp.Route(),
+				TradeSize:        tradeSize,
+				Direction:        opp.Direction.ShortString(),
+				Venue:            venue,
+				SpreadBps:        opp.Spread.BasisPoints,
+				Profit:           opp.Profit.NetProfitRaw, // Use raw value to preserve sign
+				PoolFeeTier:      poolFeeTier,
+				RequiredCapital:  opp.RequiredCapital,
+				AvailableCapital: opp.AvailableCapital,
+				OverCapital:      opp.ExceedsCapital(),
+				OptimalSize:      optimalSize,
+				CEXPrice:         opp.CEXPrice,
+				DEXPrice:         opp.DEXPrice,
+				GrossProfit:      opp.Profit.Exact.GrossProfit.Abs(),
+				GasCostUSD:       gasCostUSD,
+				FeesUSD:          opp.Profit.Exact.ExchangeFees,
+				TotalCostsUSD:    opp.Profit.Exact.TotalCosts,
+				ExecutionSteps:   execSteps,
+				RiskFactors:      riskFactors,
+				Legs:             legs,
+				Profitable:       opp.IsProfitable(),
+				Status:           getOpportunityStatus(opp),
 			}
 			m.opportunities.Add(row)
 			m.lastUpdate = time.Now()
+
+			if m.oppCount == 0 || row.Profit.GreaterThan(m.bestNetProfit) {
+				m.bestNetProfit = row.Profit
+			}
+			m.oppCount++
+			if row.Profitable {
+				m.profitableCount++
+			}
+			m.spreadSumBps = m.spreadSumBps.Add(row.SpreadBps)
 		}
 
 	case PriceUpdateMsg:
@@ -256,23 +400,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				tradeSize = s.CEXAsk.Size.ToDecimal()
 			}
 
-			// Accumulate prices by trade size
+			// Accumulate prices by trade size, keeping the last net spread
+			// until this snapshot's cost breakdown replaces it
 			sizeKey := tradeSize.StringFixed(0)
 			m.pricesBySize[sizeKey] = components.PriceRow{
-				TradeSize: tradeSize,
-				CEXPrice:  cexPrice,
-				DEXPrice:  dexPrice,
-				SpreadBps: spreadBps,
+				TradeSize:    tradeSize,
+				CEXPrice:     cexPrice,
+				DEXPrice:     dexPrice,
+				SpreadBps:    spreadBps,
+				NetSpreadBps: m.pricesBySize[sizeKey].NetSpreadBps,
 			}
+			m.updatePriceRows()
 
-			// Update prices component with all accumulated sizes
-			rows := make([]components.PriceRow, 0, len(m.pricesBySize))
-			for _, key := range []string{"1", "10", "100"} {
-				if row, ok := m.pricesBySize[key]; ok {
-					rows = append(rows, row)
-				}
+			// Chart the smallest size so the history isn't a mix of sizes
+			pair := s.Pair.String()
+			if smallest, ok := m.sparkSizes[pair]; !ok || tradeSize.LessThanOrEqual(smallest) {
+				m.sparkSizes[pair] = tradeSize
+				m.sparkline.Push(pair, spreadBps.InexactFloat64())
 			}
-			m.prices.Update(rows)
+			m.sparkline.SetPair(pair)
 
 			// Increment scan count
 			m.scanCount++
@@ -314,6 +460,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.startupSteps["uniswap"].Status = "done"
 		}
 
+	case ConnectionStatsMsg:
+		m.connectionStats[msg.Name] = msg
+
 	case BlockMsg:
 		m.currentBlock = msg.Number
 		m.blocksScanned++
@@ -324,10 +473,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case GasPriceMsg:
 		m.gasPrice = msg.GweiPrice
+		if msg.Ranked {
+			m.gasRank = msg.PercentileRank
+			m.gasRanked = true
+		}
 		m.lastUpdate = time.Now()
 
+	case SummaryMsg:
+		m.lastSummary = msg.Summary
+
 	case ErrorMsg:
 		m.errorMsg = msg.Error.Error()
+		m.errorCount++
 		m.logs = addLog(m.logs, "error", msg.Error.Error())
 		// Add to persistent errors (keep last 3)
 		m.errors = append(m.errors, ErrorEntry{
@@ -360,6 +517,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case CostBreakdownMsg:
 		// Store the domain-calculated cost breakdown for display
 		m.costBreakdown = &msg
+		for _, s := range msg.SizeSpreads {
+			key := s.TradeSize.StringFixed(0)
+			if row, ok := m.pricesBySize[key]; ok {
+				net := s.NetSpreadBps
+				row.NetSpreadBps = &net
+				m.pricesBySize[key] = row
+			}
+		}
+		m.updatePriceRows()
 		// Pass to prices component for rendering (convert message to component type)
 		m.prices.SetCostBreakdown(components.CostBreakdown{
 			TradeSize:     msg.TradeSize,
@@ -370,6 +536,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			TotalCosts:    msg.TotalCosts,
 			NetProfit:     msg.NetProfit,
 			IsProfitable:  msg.IsProfitable,
+			NetSpreadBps:  msg.NetSpreadBps,
+
+			ApprovalGasUSD:   msg.ApprovalGasUSD,
+			WithdrawalFeeUSD: msg.WithdrawalFeeUSD,
+			DepositFeeUSD:    msg.DepositFeeUSD,
 		})
 	}
 
@@ -405,6 +576,17 @@ func addActivity(feed []string, message string) []string {
 	return feed
 }
 
+// updatePriceRows pushes the accumulated price rows to the prices component.
+func (m Model) updatePriceRows() {
+	rows := make([]components.PriceRow, 0, len(m.pricesBySize))
+	for _, key := range []string{"1", "10", "100"} {
+		if row, ok := m.pricesBySize[key]; ok {
+			rows = append(rows, row)
+		}
+	}
+	m.prices.Update(rows)
+}
+
 // View renders the TUI.
 func (m Model) View() string {
 	if m.quitting {
@@ -436,10 +618,16 @@ func (m Model) View() string {
 
 	// Status bar
 	b.WriteString(m.renderStatusBar())
+	b.WriteString("\n")
+	m.stats.Update(m.sessionStats())
+	b.WriteString(m.stats.View())
 	b.WriteString("\n\n")
 
 	// Main content: prices on left, activity + opportunities on right
-	leftCol := m.prices.View()
+	leftCol := m.prices.View() + "\n" + m.sparkline.View()
+	if len(m.connectionStats) > 0 {
+		leftCol += "\n\n" + m.renderConnectionPanel()
+	}
 
 	// Right column: activity feed + opportunities
 	var rightContent strings.Builder
@@ -480,7 +668,12 @@ func (m Model) View() string {
 	}
 
 	// Help
-	helpText := "q: quit • c: clear • p: pause • ↑↓: scroll"
+	filter := "all"
+	if m.opportunities.ProfitableOnly() {
+		filter = "profitable"
+	}
+	helpText := fmt.Sprintf("q: quit • c: clear • p: pause • f: filter (%s) • s: sort (%s) • ↑↓: select • enter: details • y: copy",
+		filter, m.opportunities.SortMode())
 	if m.paused {
 		pauseStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F59E0B"))
 		b.WriteString(pauseStyle.Render("⏸ PAUSED"))
@@ -491,6 +684,23 @@ func (m Model) View() string {
 	return b.String()
 }
 
+// sessionStats summarizes the session for the stats panel.
+func (m Model) sessionStats() components.Stats {
+	avgSpread := decimal.Zero
+	if m.oppCount > 0 {
+		avgSpread = m.spreadSumBps.Div(decimal.NewFromInt(m.oppCount))
+	}
+	return components.Stats{
+		BlocksProcessed: int64(m.blocksScanned),
+		Opportunities:   m.oppCount,
+		Profitable:      m.profitableCount,
+		BestNetProfit:   m.bestNetProfit,
+		AvgSpreadBps:    avgSpread,
+		Uptime:          time.Since(m.sessionStart),
+		Errors:          m.errorCount,
+	}
+}
+
 // renderActivityFeed renders the recent activity feed.
 func (m Model) renderActivityFeed() string {
 	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
@@ -518,6 +728,49 @@ func (m Model) renderActivityFeed() string {
 	return sb.String()
 }
 
+// renderConnectionPanel renders each source's uptime this session, its
+// reconnect count and the current connection age or reconnect backoff.
+func (m Model) renderConnectionPanel() string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorPrimary)
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+
+	names := make([]string, 0, len(m.connectionStats))
+	for name := range m.connectionStats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString(headerStyle.Render("CONNECTIONS"))
+	sb.WriteString("\n")
+	for _, name := range names {
+		stats := m.connectionStats[name]
+
+		// Green at three nines of the session, amber above 95%, red below
+		uptimeStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+		switch {
+		case stats.UptimePct < 95:
+			uptimeStyle = uptimeStyle.Foreground(ColorDanger)
+		case stats.UptimePct < 99.9:
+			uptimeStyle = uptimeStyle.Foreground(ColorWarning)
+		}
+
+		state := "down"
+		switch {
+		case !stats.ConnectedSince.IsZero():
+			state = "up " + formatWindow(time.Since(stats.ConnectedSince))
+		case stats.Backoff > 0:
+			state = "retry in " + stats.Backoff.Round(100*time.Millisecond).String()
+		}
+
+		sb.WriteString(fmt.Sprintf("  %-9s %s  %s\n",
+			name,
+			uptimeStyle.Render(fmt.Sprintf("%5.1f%%", stats.UptimePct)),
+			mutedStyle.Render(fmt.Sprintf("%d reconnects • %s", stats.Reconnects, state))))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
 // renderWelcomeScreen renders the animated welcome screen.
 func (m Model) renderWelcomeScreen() string {
 	// Styles
@@ -674,6 +927,17 @@ func (m Model) renderStatusBar() string {
 	// Gas price
 	if m.gasPrice > 0 {
 		gasStr := fmt.Sprintf("Gas: %.1f gwei", m.gasPrice)
+		if m.gasRanked {
+			// Green in the cheapest third of recent prices, red in the dearest
+			gasStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+			switch {
+			case m.gasRank >= 66:
+				gasStyle = gasStyle.Foreground(ColorDanger)
+			case m.gasRank >= 33:
+				gasStyle = gasStyle.Foreground(ColorWarning)
+			}
+			gasStr = gasStyle.Render(fmt.Sprintf("%s (p%.0f)", gasStr, m.gasRank))
+		}
 		parts = append(parts, gasStr)
 	}
 
@@ -683,6 +947,12 @@ func (m Model) renderStatusBar() string {
 		parts = append(parts, scanStyle.Render(fmt.Sprintf("Scans: %d", m.scanCount)))
 	}
 
+	// Last profit summary interval
+	if s := m.lastSummary; s != nil {
+		parts = append(parts, fmt.Sprintf("Last %s: %d profitable, $%s max",
+			formatWindow(s.Window()), s.Opportunities, s.BestProfitUSD().StringFixed(2)))
+	}
+
 	// Connection status
 	for name, info := range m.connectionState {
 		var statusStyle lipgloss.Style
@@ -717,6 +987,19 @@ func (m Model) renderStatusBar() string {
 	return strings.Join(parts, "  │  ")
 }
 
+// formatWindow renders an interval to the second, dropping zero
+// trailing units ("1m" rather than "1m0s").
+func formatWindow(d time.Duration) string {
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
 // Program holds the Bubble Tea program instance for external access.
 var Program *tea.Program
 
@@ -724,9 +1007,13 @@ var Program *tea.Program
 // This is set by main.go to signal when to begin loading modules.
 var OnStartModules func()
 
+// OnPauseToggled is called with the new state when the pause key is pressed.
+// This is set by main.go so pausing also stops detection work.
+var OnPauseToggled func(paused bool)
+
 // Run starts the Bubble Tea program.
-func Run() error {
-	Program = tea.NewProgram(New(), tea.WithAltScreen())
+func Run(opts ...Option) error {
+	Program = tea.NewProgram(New(opts...), tea.WithAltScreen())
 	_, err := Program.Run()
 	return err
 }